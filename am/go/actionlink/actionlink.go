@@ -0,0 +1,191 @@
+// Package actionlink creates and verifies signed links which allow alert
+// owners to act on their alerts directly from reminder emails. Following a
+// link shows a page which asks the owner to confirm the action, so that the
+// action is not performed by merely fetching the link, eg. by a link scanner.
+package actionlink
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Supported values for Action.Type.
+const (
+	// Silence silences the alert for SilenceDuration.
+	Silence = "silence"
+	// Unassign unassigns the alert from the user.
+	Unassign = "unassign"
+
+	// SilenceDuration is the duration of silences created from action links.
+	SilenceDuration = "24h"
+
+	// Path is the path of the endpoint which handles action links.
+	Path = "/_/email_action"
+
+	// tokenParam is the URL query parameter which contains the token.
+	tokenParam = "token"
+
+	// defaultExpiry is how long action links remain valid.
+	defaultExpiry = 7 * 24 * time.Hour
+)
+
+// Action is an action on an incident which is encoded in a signed token.
+type Action struct {
+	Type string `json:"type"`
+	// IncidentKey is the web-safe serialized Datastore key of the incident.
+	IncidentKey string `json:"key"`
+	// IncidentID is the ID of the incident's alert.
+	IncidentID string `json:"id"`
+	// User is the email address of the user the link was sent to.
+	User string `json:"user"`
+	// Expires is the time in seconds since the epoch after which the action
+	// is no longer valid.
+	Expires int64 `json:"expires"`
+}
+
+// Description returns a human-readable description of the action.
+func (a *Action) Description() string {
+	if a.Type == Unassign {
+		return fmt.Sprintf("Unassign alert %s from %s", a.IncidentID, a.User)
+	}
+	return fmt.Sprintf("Silence alert %s for %s", a.IncidentID, SilenceDuration)
+}
+
+// CheckUser returns an error if the given logged in user is not the user the
+// action link was sent to, since the action is performed on their behalf.
+func (a *Action) CheckUser(user string) error {
+	if !strings.EqualFold(user, a.User) {
+		return fmt.Errorf("link was sent to %s, but logged in as %s", a.User, user)
+	}
+	return nil
+}
+
+// Signer creates and verifies signed action links.
+type Signer struct {
+	secret  []byte
+	baseURL string
+}
+
+// NewSigner returns a Signer which signs tokens with the given secret and
+// creates links to the given base URL, eg. "https://am.skia.org".
+func NewSigner(secret []byte, baseURL string) (*Signer, error) {
+	if len(secret) == 0 {
+		return nil, fmt.Errorf("Action link secret must not be empty.")
+	}
+	return &Signer{
+		secret:  secret,
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+	}, nil
+}
+
+func (s *Signer) mac(payload []byte) []byte {
+	h := hmac.New(sha256.New, s.secret)
+	_, _ = h.Write(payload)
+	return h.Sum(nil)
+}
+
+// Token returns a signed token for the given action.
+func (s *Signer) Token(a Action) (string, error) {
+	payload, err := json.Marshal(a)
+	if err != nil {
+		return "", fmt.Errorf("Failed to encode action: %s", err)
+	}
+	enc := base64.RawURLEncoding
+	return enc.EncodeToString(payload) + "." + enc.EncodeToString(s.mac(payload)), nil
+}
+
+// Verify returns the action encoded in the given token, or an error if the
+// token was not signed by this Signer or has expired.
+func (s *Signer) Verify(token string, now time.Time) (*Action, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("Malformed token.")
+	}
+	enc := base64.RawURLEncoding
+	payload, err := enc.DecodeString(parts[0])
+	if err != nil {
+		return nil, fmt.Errorf("Malformed token payload: %s", err)
+	}
+	sig, err := enc.DecodeString(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("Malformed token signature: %s", err)
+	}
+	if !hmac.Equal(sig, s.mac(payload)) {
+		return nil, fmt.Errorf("Invalid token signature.")
+	}
+	var a Action
+	if err := json.Unmarshal(payload, &a); err != nil {
+		return nil, fmt.Errorf("Failed to decode action: %s", err)
+	}
+	if now.Unix() > a.Expires {
+		return nil, fmt.Errorf("This link has expired.")
+	}
+	if a.Type != Silence && a.Type != Unassign {
+		return nil, fmt.Errorf("Unknown action %q.", a.Type)
+	}
+	return &a, nil
+}
+
+// URL returns a signed link which performs the given type of action on the
+// incident on behalf of the given user. The link expires after a week.
+func (s *Signer) URL(actionType, incidentKey, incidentID, user string, now time.Time) (string, error) {
+	token, err := s.Token(Action{
+		Type:        actionType,
+		IncidentKey: incidentKey,
+		IncidentID:  incidentID,
+		User:        user,
+		Expires:     now.Add(defaultExpiry).Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+	return s.baseURL + Path + "?" + url.Values{tokenParam: []string{token}}.Encode(), nil
+}
+
+// TokenFromURL returns the token from the given action link URL query.
+func TokenFromURL(u *url.URL) string {
+	return u.Query().Get(tokenParam)
+}
+
+// TokenFromRequest returns the token from the given request, which is either
+// a request for an action link or the submission of its confirmation page.
+func TokenFromRequest(r *http.Request) string {
+	return r.FormValue(tokenParam)
+}
+
+// confirmTemplate is the page which asks the user to confirm an action. The
+// action is only performed once the form is POSTed.
+var confirmTemplate = template.Must(template.New("confirm").Parse(`<!DOCTYPE html>
+<html>
+<head>
+  <title>Alert Manager</title>
+</head>
+<body>
+  <p>{{.Description}}?</p>
+  <form method="POST" action="{{.Path}}">
+    <input type="hidden" name="{{.TokenParam}}" value="{{.Token}}">
+    <button type="submit">Confirm</button>
+  </form>
+</body>
+</html>
+`))
+
+// WriteConfirmPage writes the page which asks the user to confirm the given
+// action, which is encoded in the given token.
+func WriteConfirmPage(w io.Writer, token string, a *Action) error {
+	return confirmTemplate.Execute(w, map[string]string{
+		"Description": a.Description(),
+		"Path":        Path,
+		"TokenParam":  tokenParam,
+		"Token":       token,
+	})
+}
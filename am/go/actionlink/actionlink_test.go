@@ -0,0 +1,108 @@
+package actionlink
+
+import (
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestURL_RoundTrip(t *testing.T) {
+
+	now := time.Date(2011, 11, 30, 16, 0, 0, 0, time.UTC)
+	s, err := NewSigner([]byte("secret"), "https://am.skia.org/")
+	require.NoError(t, err)
+
+	link, err := s.URL(Silence, "incident-key", "incident-id", "superman@krypton.com", now)
+	require.NoError(t, err)
+	u, err := url.Parse(link)
+	require.NoError(t, err)
+	assert.Equal(t, "am.skia.org", u.Host)
+	assert.Equal(t, Path, u.Path)
+
+	a, err := s.Verify(TokenFromURL(u), now.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, &Action{
+		Type:        Silence,
+		IncidentKey: "incident-key",
+		IncidentID:  "incident-id",
+		User:        "superman@krypton.com",
+		Expires:     now.Add(defaultExpiry).Unix(),
+	}, a)
+
+	// Links expire.
+	_, err = s.Verify(TokenFromURL(u), now.Add(defaultExpiry+time.Second))
+	assert.Error(t, err)
+}
+
+func TestVerify_RejectsInvalidTokens(t *testing.T) {
+
+	now := time.Date(2011, 11, 30, 16, 0, 0, 0, time.UTC)
+	s, err := NewSigner([]byte("secret"), "https://am.skia.org")
+	require.NoError(t, err)
+	other, err := NewSigner([]byte("other secret"), "https://am.skia.org")
+	require.NoError(t, err)
+
+	token, err := other.Token(Action{Type: Unassign, Expires: now.Add(time.Hour).Unix()})
+	require.NoError(t, err)
+	_, err = s.Verify(token, now)
+	assert.Error(t, err)
+
+	token, err = s.Token(Action{Type: "delete", Expires: now.Add(time.Hour).Unix()})
+	require.NoError(t, err)
+	_, err = s.Verify(token, now)
+	assert.Error(t, err)
+
+	_, err = s.Verify("not-a-token", now)
+	assert.Error(t, err)
+
+	// Tampering with the payload invalidates the signature.
+	token, err = s.Token(Action{Type: Unassign, User: "batman@gotham.com", Expires: now.Add(time.Hour).Unix()})
+	require.NoError(t, err)
+	tampered, err := s.Token(Action{Type: Unassign, User: "robin@gotham.com", Expires: now.Add(time.Hour).Unix()})
+	require.NoError(t, err)
+	_, err = s.Verify(tampered[:len(tampered)/2]+token[len(token)/2:], now)
+	assert.Error(t, err)
+
+	_, err = NewSigner(nil, "https://am.skia.org")
+	assert.Error(t, err)
+}
+
+func TestCheckUser(t *testing.T) {
+
+	a := &Action{Type: Silence, User: "superman@krypton.com"}
+	assert.NoError(t, a.CheckUser("superman@krypton.com"))
+	assert.NoError(t, a.CheckUser("Superman@krypton.com"))
+	assert.Error(t, a.CheckUser("lex@luthorcorp.com"))
+	assert.Error(t, a.CheckUser(""))
+}
+
+func TestWriteConfirmPage_PostsToken(t *testing.T) {
+
+	now := time.Date(2011, 11, 30, 16, 0, 0, 0, time.UTC)
+	s, err := NewSigner([]byte("secret"), "https://am.skia.org")
+	require.NoError(t, err)
+	link, err := s.URL(Unassign, "incident-key", "incident-id", "superman@krypton.com", now)
+	require.NoError(t, err)
+
+	r := httptest.NewRequest("GET", link, nil)
+	token := TokenFromRequest(r)
+	a, err := s.Verify(token, now)
+	require.NoError(t, err)
+
+	var b strings.Builder
+	require.NoError(t, WriteConfirmPage(&b, token, a))
+	page := b.String()
+	assert.Contains(t, page, `<form method="POST" action="/_/email_action">`)
+	assert.Contains(t, page, `name="token" value="`+token+`"`)
+	assert.Contains(t, page, "Unassign alert incident-id from superman@krypton.com?")
+
+	// Submitting the confirmation page yields the same token.
+	r = httptest.NewRequest("POST", Path, strings.NewReader(url.Values{tokenParam: {token}}.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	assert.Equal(t, token, TokenFromRequest(r))
+}
@@ -1,12 +1,14 @@
 package main
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"flag"
 	"fmt"
 	"html/template"
 	"net/http"
+	"os"
 	"path/filepath"
 	"sort"
 	"time"
@@ -17,11 +19,13 @@ import (
 	"golang.org/x/oauth2/google"
 	"google.golang.org/api/option"
 
+	"go.skia.org/infra/am/go/actionlink"
 	"go.skia.org/infra/am/go/audit"
 	"go.skia.org/infra/am/go/incident"
 	"go.skia.org/infra/am/go/note"
 	"go.skia.org/infra/am/go/reminder"
 	"go.skia.org/infra/am/go/silence"
+	"go.skia.org/infra/am/go/suggest"
 	"go.skia.org/infra/am/go/types"
 	"go.skia.org/infra/email/go/emailclient"
 	"go.skia.org/infra/go/alerts"
@@ -30,11 +34,15 @@ import (
 	"go.skia.org/infra/go/alogin/proxylogin"
 	"go.skia.org/infra/go/auth"
 	"go.skia.org/infra/go/baseapp"
+	"go.skia.org/infra/go/chatbot"
+	"go.skia.org/infra/go/common"
 	"go.skia.org/infra/go/ds"
 	"go.skia.org/infra/go/httputils"
 	"go.skia.org/infra/go/metrics2"
+	"go.skia.org/infra/go/paramtools"
 	"go.skia.org/infra/go/pubsub/sub"
 	"go.skia.org/infra/go/roles"
+	"go.skia.org/infra/go/rotations"
 	"go.skia.org/infra/go/skerr"
 	"go.skia.org/infra/go/sklog"
 	"go.skia.org/infra/go/util"
@@ -42,11 +50,17 @@ import (
 
 // flags
 var (
-	assignGroup  = flag.String("assign_group", "google/skia-root@google.com", "The chrome infra auth group to use for users incidents can be assigned to.")
-	host         = flag.String("host", "am.skia.org", "HTTP service host")
-	namespace    = flag.String("namespace", "", "The Cloud Datastore namespace, such as 'alert-manager'.")
-	internalPort = flag.String("internal_port", ":9000", "HTTP internal service address (e.g., ':9000') for unauthenticated in-cluster requests.")
-	project      = flag.String("project", "skia-public", "The Google Cloud project name.")
+	assignGroup      = flag.String("assign_group", "google/skia-root@google.com", "The chrome infra auth group to use for users incidents can be assigned to.")
+	actionLinkSecret = flag.String("action_link_secret_file", "", "Path to a file containing the secret used to sign action links in reminder emails. Action links are disabled if not set.")
+	escalationConfig = flag.String("escalation_config", "", "Path to a JSON file describing when and to whom long-firing alerts are escalated, eg. mounted from a config map. Escalation is disabled if not set.")
+	holidayCalendar  = flag.String("holiday_calendar", "", "Path to a JSON file containing a list of dates in YYYY-MM-DD format on which no reminders, escalations or summaries are sent, eg. mounted from a config map.")
+	host             = flag.String("host", "am.skia.org", "HTTP service host")
+	namespace        = flag.String("namespace", "", "The Cloud Datastore namespace, such as 'alert-manager'.")
+	internalPort     = flag.String("internal_port", ":9000", "HTTP internal service address (e.g., ':9000') for unauthenticated in-cluster requests.")
+	project          = flag.String("project", "skia-public", "The Google Cloud project name.")
+	reminderSchedule = flag.String("reminder_schedule", "", "Standard five field cron expression, evaluated in UTC, of the hours at which reminders, escalations and summaries may be sent, eg. '0 * * * 1-5' for business days only. Any hour is allowed if not set.")
+	rotationURLs     = common.NewMultiStringFlag("rotation_url", []string{rotations.InfraGardenerURL}, "URL from which to load the current members of a rotation, eg. the infra gardener. Rotation members do not receive reminders and instead receive a weekly summary of alert owners. May be repeated.")
+	summaryChatRoom  = flag.String("summary_chat_room", "", "If set, the weekly summary of alert owners is also sent to this chat room.")
 
 	silenceRecentlyExpiredDuration = flag.Duration("recently_expired_duration", 2*time.Hour, "Incidents with silences that recently expired within this duration are shown with an icon.")
 )
@@ -67,6 +81,9 @@ const (
 type server struct {
 	incidentStore *incident.Store
 	silenceStore  *silence.Store
+	prefsStore    *reminder.PreferencesStore
+	signer        *actionlink.Signer // nil if action links are disabled.
+	suggester     *suggest.Suggester
 	templates     *template.Template
 	assign        allowed.Allow // A list of people that incidents can be assigned to.
 	alogin        *proxylogin.ProxyLogin
@@ -110,13 +127,48 @@ func New() (baseapp.App, error) {
 	srv := &server{
 		incidentStore: incident.NewStore(ds.DS, []string{"kubernetes_pod_name", "instance", "pod_template_hash"}),
 		silenceStore:  silence.NewStore(ds.DS),
+		prefsStore:    reminder.NewPreferencesStore(ds.DS),
 		assign:        assign,
 		alogin:        proxylogin.NewWithDefaults(),
 	}
+	srv.suggester = suggest.New(srv.incidentStore)
 	srv.loadTemplates()
 
+	if *actionLinkSecret != "" {
+		secret, err := os.ReadFile(*actionLinkSecret)
+		if err != nil {
+			return nil, skerr.Wrapf(err, "Failed to read action link secret.")
+		}
+		srv.signer, err = actionlink.NewSigner(bytes.TrimSpace(secret), "https://"+*host)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Start goroutine to send reminders to active alert owners.
-	reminder.StartReminderTicker(srv.incidentStore, srv.silenceStore, emailclient.New())
+	var escalationCfg *reminder.EscalationConfig
+	if *escalationConfig != "" {
+		escalationCfg, err = reminder.LoadEscalationConfig(*escalationConfig)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if *summaryChatRoom != "" {
+		chatbot.Init("Alert Manager")
+	}
+	var holidays reminder.HolidayCalendar
+	if *holidayCalendar != "" {
+		holidays, err = reminder.LoadHolidayCalendar(*holidayCalendar)
+		if err != nil {
+			return nil, err
+		}
+	}
+	schedulePolicy, err := reminder.NewSchedulePolicy(*reminderSchedule, holidays)
+	if err != nil {
+		return nil, err
+	}
+	rotationSource := reminder.NewURLRotationSource(httputils.NewTimeoutClient(), *rotationURLs)
+	reminder.StartReminderTicker(srv.incidentStore, srv.silenceStore, srv.prefsStore, emailclient.New(), rotationSource, escalationCfg, *summaryChatRoom, srv.signer, schedulePolicy)
 
 	// livenesses gets populated as notifications arrive.
 	livenesses := map[string]metrics2.Liveness{}
@@ -485,6 +537,62 @@ func (srv *server) auditLogsHandler(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+type ownerSuggestionsRequest struct {
+	Key string `json:"key"`
+}
+
+// ownerSuggestionsHandler returns the likely owners of the active incident
+// with the given key, based on the owners of past incidents of the same alert.
+func (srv *server) ownerSuggestionsHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	var req ownerSuggestionsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputils.ReportError(w, err, "Failed to decode owner suggestions request.", http.StatusBadRequest)
+		return
+	}
+	ins, err := srv.incidentStore.GetAll()
+	if err != nil {
+		httputils.ReportError(w, err, "Failed to load incidents.", http.StatusInternalServerError)
+		return
+	}
+	var target []incident.Incident
+	for _, in := range ins {
+		if in.Key == req.Key {
+			target = append(target, in)
+			break
+		}
+	}
+	if len(target) == 0 {
+		httputils.ReportError(w, nil, "No such active incident.", http.StatusNotFound)
+		return
+	}
+	suggestions, err := srv.suggester.Suggest(target)
+	if err != nil {
+		httputils.ReportError(w, err, "Failed to suggest owners.", http.StatusInternalServerError)
+		return
+	}
+	resp := suggestions[req.Key]
+	if resp == nil {
+		resp = []suggest.Suggestion{}
+	}
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		sklog.Errorf("Failed to send response: %s", err)
+	}
+}
+
+// reminderDeliveriesHandler returns the most recent reminder delivery records.
+func (srv *server) reminderDeliveriesHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	deliveries, err := reminder.GetRecentDeliveries(r.Context())
+	if err != nil {
+		httputils.ReportError(w, err, "Failed to load reminder deliveries.", http.StatusInternalServerError)
+		return
+	}
+	if err := json.NewEncoder(w).Encode(deliveries); err != nil {
+		sklog.Errorf("Failed to send response: %s", err)
+	}
+}
+
 func (srv *server) incidentHandler(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	ins, err := srv.getActiveAndRecentlyResolvedIncidents()
@@ -629,6 +737,112 @@ func (srv *server) deleteSilenceHandler(w http.ResponseWriter, r *http.Request)
 	}
 }
 
+// reminderPreferencesHandler returns the reminder preferences of the logged in
+// user.
+func (srv *server) reminderPreferencesHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	prefs, err := srv.prefsStore.Get(r.Context(), srv.user(r))
+	if err != nil {
+		httputils.ReportError(w, err, "Failed to load reminder preferences.", http.StatusInternalServerError)
+		return
+	}
+	if err := json.NewEncoder(w).Encode(prefs); err != nil {
+		sklog.Errorf("Failed to send response: %s", err)
+	}
+}
+
+// saveReminderPreferencesHandler saves the reminder preferences of the logged
+// in user.
+func (srv *server) saveReminderPreferencesHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	var prefs reminder.Preferences
+	if err := json.NewDecoder(r.Body).Decode(&prefs); err != nil {
+		httputils.ReportError(w, err, "Failed to decode reminder preferences.", http.StatusBadRequest)
+		return
+	}
+	// Users may only change their own preferences.
+	prefs.Email = srv.user(r)
+	if err := prefs.Validate(); err != nil {
+		httputils.ReportError(w, err, "Invalid reminder preferences.", http.StatusBadRequest)
+		return
+	}
+	audit.Log(r, "save-reminder-preferences", prefs, srv.alogin)
+	if err := srv.prefsStore.Put(r.Context(), &prefs); err != nil {
+		httputils.ReportError(w, err, "Failed to save reminder preferences.", http.StatusInternalServerError)
+		return
+	}
+	if err := json.NewEncoder(w).Encode(prefs); err != nil {
+		sklog.Errorf("Failed to send response: %s", err)
+	}
+}
+
+// verifyEmailAction returns the action and token of a signed action link from
+// a reminder email. It reports an error and returns false if the token is
+// invalid or the link was not sent to the logged in user.
+func (srv *server) verifyEmailAction(w http.ResponseWriter, r *http.Request) (*actionlink.Action, string, bool) {
+	if srv.signer == nil {
+		httputils.ReportError(w, nil, "Action links are disabled.", http.StatusNotFound)
+		return nil, "", false
+	}
+	token := actionlink.TokenFromRequest(r)
+	a, err := srv.signer.Verify(token, time.Now())
+	if err != nil {
+		httputils.ReportError(w, err, "Invalid action link.", http.StatusForbidden)
+		return nil, "", false
+	}
+	if err := a.CheckUser(srv.user(r)); err != nil {
+		httputils.ReportError(w, err, "This link was sent to another user.", http.StatusForbidden)
+		return nil, "", false
+	}
+	return a, token, true
+}
+
+// emailActionHandler shows a page which asks the user to confirm the action
+// encoded in a signed action link from a reminder email. Nothing is changed
+// until the page is submitted to confirmEmailActionHandler.
+func (srv *server) emailActionHandler(w http.ResponseWriter, r *http.Request) {
+	a, token, ok := srv.verifyEmailAction(w, r)
+	if !ok {
+		return
+	}
+	w.Header().Set("Content-Type", "text/html")
+	if err := actionlink.WriteConfirmPage(w, token, a); err != nil {
+		sklog.Errorf("Failed to write confirmation page: %s", err)
+	}
+}
+
+// confirmEmailActionHandler performs the action encoded in a signed action
+// link from a reminder email and redirects to the main page.
+func (srv *server) confirmEmailActionHandler(w http.ResponseWriter, r *http.Request) {
+	a, _, ok := srv.verifyEmailAction(w, r)
+	if !ok {
+		return
+	}
+	user := srv.user(r)
+	audit.Log(r, "email-action", a, srv.alogin)
+	switch a.Type {
+	case actionlink.Silence:
+		sil := silence.New(user)
+		sil.ParamSet = paramtools.ParamSet{incident.ID: []string{a.IncidentID}}
+		sil.Duration = actionlink.SilenceDuration
+		sil.Notes = append(sil.Notes, note.Note{
+			Text:   "Silenced from a reminder email.",
+			TS:     time.Now().Unix(),
+			Author: user,
+		})
+		if _, err := srv.silenceStore.Put(sil); err != nil {
+			httputils.ReportError(w, err, "Failed to create silence.", http.StatusInternalServerError)
+			return
+		}
+	case actionlink.Unassign:
+		if _, err := srv.incidentStore.Unassign(a.IncidentKey, a.User); err != nil {
+			httputils.ReportError(w, err, "Failed to unassign.", http.StatusInternalServerError)
+			return
+		}
+	}
+	http.Redirect(w, r, "/?tab=0", http.StatusSeeOther)
+}
+
 // newSilenceHandler creates and returns a new Silence pre-populated with good defaults.
 func (srv *server) newSilenceHandler(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
@@ -644,13 +858,17 @@ func (srv *server) AddHandlers(r chi.Router) {
 	r.HandleFunc(("/_/login/status"), alogin.LoginStatusHandler(srv.alogin))
 
 	// GETs
+	r.Get(actionlink.Path, srv.emailActionHandler)
 	r.Get("/_/emails", srv.emailsHandler)
 	r.Get("/_/incidents", srv.incidentHandler)
 	r.Get("/_/new_silence", srv.newSilenceHandler)
 	r.Get("/_/recent_incidents", srv.recentIncidentsHandler)
+	r.Get("/_/reminder_deliveries", srv.reminderDeliveriesHandler)
+	r.Get("/_/reminder_preferences", srv.reminderPreferencesHandler)
 	r.Get("/_/silences", srv.silencesHandler)
 
 	// POSTs
+	r.Post(actionlink.Path, srv.confirmEmailActionHandler)
 	r.Post("/_/add_note", srv.addNoteHandler)
 	r.Post("/_/add_silence_note", srv.addSilenceNoteHandler)
 	r.Post("/_/archive_silence", srv.archiveSilenceHandler)
@@ -661,10 +879,12 @@ func (srv *server) AddHandlers(r chi.Router) {
 	r.Post("/_/del_silence_note", srv.delSilenceNoteHandler)
 	r.Post("/_/del_silence", srv.deleteSilenceHandler)
 	r.Post("/_/reactivate_silence", srv.reactivateSilenceHandler)
+	r.Post("/_/save_reminder_preferences", srv.saveReminderPreferencesHandler)
 	r.Post("/_/save_silence", srv.saveSilenceHandler)
 	r.Post("/_/take", srv.takeHandler)
 	r.Post("/_/stats", srv.statsHandler)
 	r.Post("/_/incidents_in_range", srv.incidentsInRangeHandler)
+	r.Post("/_/owner_suggestions", srv.ownerSuggestionsHandler)
 }
 
 // See baseapp.App.
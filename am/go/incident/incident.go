@@ -417,6 +417,17 @@ func (s *Store) Assign(encodedKey string, user string) (*Incident, error) {
 	})
 }
 
+// Unassign unassigns the Incident if it is currently assigned to the given
+// user, and is a no-op otherwise.
+func (s *Store) Unassign(encodedKey string, user string) (*Incident, error) {
+	return s._mutateIncident(encodedKey, func(in *Incident) error {
+		if in.Params[ASSIGNED_TO] == user {
+			delete(in.Params, ASSIGNED_TO)
+		}
+		return nil
+	})
+}
+
 func (s *Store) Archive(encodedKey string) (*Incident, error) {
 	return s._mutateIncident(encodedKey, func(in *Incident) error {
 		in.Active = false
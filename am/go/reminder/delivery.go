@@ -0,0 +1,74 @@
+package reminder
+
+import (
+	"context"
+	"time"
+
+	"go.skia.org/infra/go/ds"
+	"go.skia.org/infra/go/metrics2"
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/go/sklog"
+)
+
+// Valid values for Delivery.Status.
+const (
+	DeliveryStatusSent   = "sent"
+	DeliveryStatusFailed = "failed"
+)
+
+// getDeliveriesLimit is the maximum number of Deliveries returned by
+// GetRecentDeliveries.
+const getDeliveriesLimit = 200
+
+// Delivery is an audit record of an attempt to send a reminder to an alert
+// owner.
+type Delivery struct {
+	Owner     string `json:"owner" datastore:"owner"`
+	Channel   string `json:"channel" datastore:"channel"`
+	NumAlerts int    `json:"num_alerts" datastore:"num_alerts,noindex"`
+	Timestamp int64  `json:"timestamp" datastore:"timestamp"` // Time in seconds since the epoch.
+	Status    string `json:"status" datastore:"status"`
+	Error     string `json:"error" datastore:"error,noindex"`
+}
+
+// newDelivery returns a Delivery describing the result of sending a reminder
+// about the given number of alerts to the owner via the given channel.
+func newDelivery(owner, channel string, numAlerts int, ts time.Time, sendErr error) *Delivery {
+	d := &Delivery{
+		Owner:     owner,
+		Channel:   channel,
+		NumAlerts: numAlerts,
+		Timestamp: ts.Unix(),
+		Status:    DeliveryStatusSent,
+	}
+	if sendErr != nil {
+		d.Status = DeliveryStatusFailed
+		d.Error = sendErr.Error()
+	}
+	return d
+}
+
+// recordDelivery updates the reminder metrics for the given Delivery and
+// persists it in the Datastore.
+func recordDelivery(ctx context.Context, d *Delivery) {
+	tags := map[string]string{"channel": d.Channel}
+	metrics2.GetCounter("am_reminder_attempted", tags).Inc(1)
+	if d.Status == DeliveryStatusSent {
+		metrics2.GetCounter("am_reminder_sent", tags).Inc(1)
+	} else {
+		metrics2.GetCounter("am_reminder_failed", tags).Inc(1)
+	}
+	if _, err := ds.DS.Put(ctx, ds.NewKey(ds.REMINDER_DELIVERY_AM), d); err != nil {
+		sklog.Errorf("[reminder] Could not persist delivery record: %s", err)
+	}
+}
+
+// GetRecentDeliveries returns the most recent reminder Deliveries.
+func GetRecentDeliveries(ctx context.Context) ([]*Delivery, error) {
+	deliveries := []*Delivery{}
+	q := ds.NewQuery(ds.REMINDER_DELIVERY_AM).Order("-timestamp").Limit(getDeliveriesLimit)
+	if _, err := ds.DS.GetAll(ctx, q, &deliveries); err != nil {
+		return nil, skerr.Wrap(err)
+	}
+	return deliveries, nil
+}
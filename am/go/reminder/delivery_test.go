@@ -0,0 +1,52 @@
+package reminder
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.skia.org/infra/go/ds"
+	"go.skia.org/infra/go/ds/testutil"
+)
+
+func TestNewDelivery(t *testing.T) {
+
+	now := time.Date(2011, 11, 30, 16, 0, 0, 0, time.UTC)
+	assert.Equal(t, &Delivery{
+		Owner:     "superman@krypton.com",
+		Channel:   ChannelEmail,
+		NumAlerts: 2,
+		Timestamp: now.Unix(),
+		Status:    DeliveryStatusSent,
+	}, newDelivery("superman@krypton.com", ChannelEmail, 2, now, nil))
+
+	assert.Equal(t, &Delivery{
+		Owner:     "superman@krypton.com",
+		Channel:   ChannelEmail,
+		NumAlerts: 2,
+		Timestamp: now.Unix(),
+		Status:    DeliveryStatusFailed,
+		Error:     "kryptonite",
+	}, newDelivery("superman@krypton.com", ChannelEmail, 2, now, errors.New("kryptonite")))
+}
+
+func TestRecordDelivery(t *testing.T) {
+
+	cleanup := testutil.InitDatastore(t, ds.REMINDER_DELIVERY_AM)
+	defer cleanup()
+
+	ctx := context.Background()
+	now := time.Date(2011, 11, 30, 16, 0, 0, 0, time.UTC)
+	sent := newDelivery("superman@krypton.com", ChannelEmail, 2, now, nil)
+	failed := newDelivery("batman@gotham.com", ChannelEmail, 1, now.Add(time.Hour), errors.New("no such user"))
+	recordDelivery(ctx, sent)
+	recordDelivery(ctx, failed)
+
+	deliveries, err := GetRecentDeliveries(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []*Delivery{failed, sent}, deliveries)
+}
@@ -0,0 +1,228 @@
+package reminder
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/datastore"
+
+	"go.skia.org/infra/am/go/incident"
+	"go.skia.org/infra/am/go/silence"
+	"go.skia.org/infra/go/ds"
+	"go.skia.org/infra/go/email"
+	"go.skia.org/infra/go/human"
+	"go.skia.org/infra/go/sklog"
+)
+
+const (
+	// escalationInterval is the minimum time between two escalations of the
+	// same alert.
+	escalationInterval = 7 * 24 * time.Hour
+
+	escalationEmailTemplate = `
+Hi {{.Owner}},
+<br/><br/>
+
+The following alert on am.skia.org has been firing for {{.Age}} without being silenced or resolved:
+<ul>
+  <li>{{.Alert}}</li>
+</ul>
+
+{{.Lead}} has been CC'd on this email as the escalation contact for {{.Team}}.
+Please add a silence or resolve the alert as soon as possible.
+<br/><br/>
+
+Thanks!
+`
+)
+
+var (
+	escalationEmailTemplateParsed = template.Must(template.New("escalation_email").Parse(escalationEmailTemplate))
+)
+
+// EscalationConfig describes when and to whom long-firing alerts are
+// escalated.
+type EscalationConfig struct {
+	// Threshold is how long an alert must have been firing without being
+	// silenced before it is escalated, eg. "3d".
+	Threshold string `json:"threshold"`
+	// Label is the alert label which identifies the team responsible for an
+	// alert, eg. "category".
+	Label string `json:"label"`
+	// Leads maps values of Label to the address to which alerts for that team
+	// are escalated.
+	Leads map[string]string `json:"leads"`
+
+	threshold time.Duration
+}
+
+// LoadEscalationConfig reads an EscalationConfig from the given JSON file,
+// eg. one mounted from a config map.
+func LoadEscalationConfig(path string) (*EscalationConfig, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read escalation config: %s", err)
+	}
+	var cfg EscalationConfig
+	if err := json.Unmarshal(b, &cfg); err != nil {
+		return nil, fmt.Errorf("Failed to parse escalation config: %s", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+// Validate returns an error if the EscalationConfig is not valid.
+func (c *EscalationConfig) Validate() error {
+	d, err := human.ParseDuration(c.Threshold)
+	if err != nil {
+		return fmt.Errorf("Invalid escalation threshold %q: %s", c.Threshold, err)
+	}
+	if d <= 0 {
+		return fmt.Errorf("Escalation threshold must be positive.")
+	}
+	c.threshold = d
+	if c.Label == "" {
+		return fmt.Errorf("Escalation label is required.")
+	}
+	for team, lead := range c.Leads {
+		if lead == "" {
+			return fmt.Errorf("No escalation address for %q.", team)
+		}
+	}
+	return nil
+}
+
+// escalation is an alert which needs to be escalated.
+type escalation struct {
+	incident incident.Incident
+	owner    string
+	team     string
+	lead     string
+}
+
+// getEscalations returns the alerts which are owned, unsilenced and have been
+// firing for longer than the configured threshold, have a lead for their team
+// and have not been escalated within the last escalationInterval.
+// lastEscalated maps incident keys to the time, in seconds since the epoch, of
+// their last escalation.
+func getEscalations(ins []incident.Incident, silences []silence.Silence, cfg *EscalationConfig, lastEscalated map[string]int64, nowUTC time.Time) []escalation {
+	ret := []escalation{}
+	for owner, alerts := range getOwnersToAlerts(ins, silences) {
+		for _, a := range alerts {
+			if nowUTC.Sub(time.Unix(a.Start, 0)) < cfg.threshold {
+				continue
+			}
+			team := a.Params[cfg.Label]
+			lead, ok := cfg.Leads[team]
+			if !ok {
+				continue
+			}
+			if last, ok := lastEscalated[a.Key]; ok && nowUTC.Sub(time.Unix(last, 0)) < escalationInterval {
+				continue
+			}
+			ret = append(ret, escalation{
+				incident: a,
+				owner:    owner,
+				team:     team,
+				lead:     lead,
+			})
+		}
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].incident.Key < ret[j].incident.Key
+	})
+	return ret
+}
+
+// escalationRecord keeps track of when an incident was last escalated. Uses
+// the incident's key as the name of its key.
+type escalationRecord struct {
+	LastEscalated int64 `datastore:"last_escalated"`
+}
+
+// getLastEscalated returns a map of incident keys to the time, in seconds since
+// the epoch, of their last escalation.
+func getLastEscalated(ctx context.Context, client *datastore.Client) (map[string]int64, error) {
+	var records []escalationRecord
+	keys, err := client.GetAll(ctx, ds.NewQuery(ds.ESCALATION_AM), &records)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to load escalations: %s", err)
+	}
+	ret := make(map[string]int64, len(records))
+	for i, key := range keys {
+		ret[key.Name] = records[i].LastEscalated
+	}
+	return ret, nil
+}
+
+// setLastEscalated records that the incident with the given key was escalated
+// at the given time.
+func setLastEscalated(ctx context.Context, client *datastore.Client, incidentKey string, ts time.Time) error {
+	key := ds.NewKey(ds.ESCALATION_AM)
+	key.Name = incidentKey
+	if _, err := client.Put(ctx, key, &escalationRecord{LastEscalated: ts.Unix()}); err != nil {
+		return fmt.Errorf("Failed to record escalation: %s", err)
+	}
+	return nil
+}
+
+// escalateAlerts sends an escalation email for each alert returned by
+// getEscalations to its owner, CCing the lead of the alert's team.
+func (et emailTicker) escalateAlerts(ctx context.Context, nowUTC time.Time) error {
+	ins, err := et.iStore.GetAll()
+	if err != nil {
+		return fmt.Errorf("Failed to load incidents: %s", err)
+	}
+	silences, err := et.sStore.GetAll()
+	if err != nil {
+		return fmt.Errorf("Failed to load silences: %s", err)
+	}
+	lastEscalated, err := getLastEscalated(ctx, ds.DS)
+	if err != nil {
+		return err
+	}
+
+	for _, e := range getEscalations(ins, silences, et.escalationCfg, lastEscalated, nowUTC) {
+		alert := fmt.Sprintf("%s - %s", e.incident.Params[incident.ALERT_NAME], e.incident.Params[incident.ABBR])
+		age := strings.TrimSpace(human.Duration(nowUTC.Sub(time.Unix(e.incident.Start, 0))))
+		sklog.Infof("Escalating %q owned by %s to %s", alert, e.owner, e.lead)
+		emailBytes := new(bytes.Buffer)
+		if err := escalationEmailTemplateParsed.Execute(emailBytes, struct {
+			Owner string
+			Alert string
+			Age   string
+			Team  string
+			Lead  string
+		}{
+			Owner: e.owner,
+			Alert: alert,
+			Age:   age,
+			Team:  e.team,
+			Lead:  e.lead,
+		}); err != nil {
+			return fmt.Errorf("Failed to execute escalation email template: %s", err)
+		}
+
+		emailSubject := fmt.Sprintf("Escalation: %s has been firing for %s on am.skia.org", e.incident.Params[incident.ALERT_NAME], age)
+		viewActionMarkup, err := email.GetViewActionMarkup("am.skia.org/?tab=0", "View Alerts", "View alerts owned by you")
+		if err != nil {
+			return fmt.Errorf("Failed to get view action markup: %s", err)
+		}
+		if _, err := et.email.SendWithMarkup("Alert Manager", "alertserver@skia.org", []string{e.owner, e.lead}, emailSubject, emailBytes.String(), viewActionMarkup, ""); err != nil {
+			return fmt.Errorf("Could not send escalation email: %s", err)
+		}
+		if err := setLastEscalated(ctx, ds.DS, e.incident.Key, nowUTC); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package reminder
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.skia.org/infra/am/go/incident"
+	"go.skia.org/infra/am/go/silence"
+	"go.skia.org/infra/go/paramtools"
+)
+
+func TestLoadEscalationConfig(t *testing.T) {
+
+	path := filepath.Join(t.TempDir(), "escalation.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"threshold": "3d", "label": "category", "leads": {"infra": "lead@example.org"}}`), 0644))
+	cfg, err := LoadEscalationConfig(path)
+	require.NoError(t, err)
+	assert.Equal(t, 3*24*time.Hour, cfg.threshold)
+	assert.Equal(t, "category", cfg.Label)
+	assert.Equal(t, map[string]string{"infra": "lead@example.org"}, cfg.Leads)
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"threshold": "3x", "label": "category"}`), 0644))
+	_, err = LoadEscalationConfig(path)
+	assert.Error(t, err)
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"threshold": "3d"}`), 0644))
+	_, err = LoadEscalationConfig(path)
+	assert.Error(t, err)
+}
+
+func TestGetEscalations(t *testing.T) {
+
+	now := time.Date(2011, 11, 30, 16, 0, 0, 0, time.UTC)
+	cfg := &EscalationConfig{
+		Threshold: "3d",
+		Label:     "category",
+		Leads:     map[string]string{"infra": "lead@example.org"},
+	}
+	require.NoError(t, cfg.Validate())
+
+	old := incident.Incident{
+		Key:   "old",
+		Start: now.Add(-4 * 24 * time.Hour).Unix(),
+		Params: map[string]string{
+			"id":       "1",
+			"category": "infra",
+			"owner":    "superman@krypton.com",
+		},
+	}
+	recent := incident.Incident{
+		Key:   "recent",
+		Start: now.Add(-2 * 24 * time.Hour).Unix(),
+		Params: map[string]string{
+			"id":       "2",
+			"category": "infra",
+			"owner":    "superman@krypton.com",
+		},
+	}
+	noLead := incident.Incident{
+		Key:   "noLead",
+		Start: now.Add(-4 * 24 * time.Hour).Unix(),
+		Params: map[string]string{
+			"id":       "3",
+			"category": "general",
+			"owner":    "superman@krypton.com",
+		},
+	}
+	assigned := incident.Incident{
+		Key:   "assigned",
+		Start: now.Add(-5 * 24 * time.Hour).Unix(),
+		Params: map[string]string{
+			"id":          "4",
+			"category":    "infra",
+			"owner":       "superman@krypton.com",
+			"assigned_to": "batman@gotham.com",
+		},
+	}
+	ins := []incident.Incident{old, recent, noLead, assigned}
+
+	escalations := getEscalations(ins, nil, cfg, nil, now)
+	require.Len(t, escalations, 2)
+	assert.Equal(t, "assigned", escalations[0].incident.Key)
+	assert.Equal(t, "batman@gotham.com", escalations[0].owner)
+	assert.Equal(t, "lead@example.org", escalations[0].lead)
+	assert.Equal(t, "old", escalations[1].incident.Key)
+	assert.Equal(t, "superman@krypton.com", escalations[1].owner)
+
+	// Alerts are escalated at most once per week.
+	lastEscalated := map[string]int64{
+		"old":      now.Add(-6 * 24 * time.Hour).Unix(),
+		"assigned": now.Add(-8 * 24 * time.Hour).Unix(),
+	}
+	escalations = getEscalations(ins, nil, cfg, lastEscalated, now)
+	require.Len(t, escalations, 1)
+	assert.Equal(t, "assigned", escalations[0].incident.Key)
+
+	// Silenced alerts are not escalated.
+	silences := []silence.Silence{
+		{
+			Active:   true,
+			ParamSet: paramtools.ParamSet{"id": []string{"4"}},
+		},
+	}
+	escalations = getEscalations(ins, silences, cfg, nil, now)
+	require.Len(t, escalations, 1)
+	assert.Equal(t, "old", escalations[0].incident.Key)
+}
@@ -0,0 +1,153 @@
+package reminder
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/datastore"
+
+	"go.skia.org/infra/go/ds"
+	"go.skia.org/infra/go/util"
+)
+
+// Valid values for Preferences.Frequency.
+const (
+	FrequencyDaily  = "daily"
+	FrequencyWeekly = "weekly"
+	FrequencyNever  = "never"
+)
+
+// Valid values for Preferences.Channel.
+const (
+	ChannelEmail = "email"
+)
+
+const (
+	// defaultReminderHourUTC is the hour at which reminders are sent to users
+	// who have not set their preferences.
+	defaultReminderHourUTC = 4
+
+	// weeklyReminderDay is the day on which weekly reminders are sent.
+	weeklyReminderDay = time.Monday
+)
+
+var (
+	validFrequencies = []string{FrequencyDaily, FrequencyWeekly, FrequencyNever}
+	validChannels    = []string{ChannelEmail}
+)
+
+// Preferences are a user's preferences for receiving reminders about the
+// alerts they own. They are stored in the Datastore using the user's email
+// address as the key name.
+type Preferences struct {
+	Email     string `json:"email" datastore:"-"`
+	Frequency string `json:"frequency" datastore:"frequency"`
+	// Hour is the hour of the day, in UTC, at which reminders are sent.
+	Hour    int    `json:"hour" datastore:"hour"`
+	Channel string `json:"channel" datastore:"channel"`
+}
+
+// DefaultPreferences returns the Preferences used for users who have not set
+// their own, ie. a daily email at 4am UTC.
+func DefaultPreferences(email string) *Preferences {
+	return &Preferences{
+		Email:     email,
+		Frequency: FrequencyDaily,
+		Hour:      defaultReminderHourUTC,
+		Channel:   ChannelEmail,
+	}
+}
+
+// Validate returns an error if the Preferences are not valid.
+func (p *Preferences) Validate() error {
+	if p.Email == "" {
+		return fmt.Errorf("Email is required.")
+	}
+	if !util.In(p.Frequency, validFrequencies) {
+		return fmt.Errorf("Invalid frequency %q; must be one of %v", p.Frequency, validFrequencies)
+	}
+	if p.Hour < 0 || p.Hour > 23 {
+		return fmt.Errorf("Invalid hour %d; must be between 0 and 23", p.Hour)
+	}
+	if !util.In(p.Channel, validChannels) {
+		return fmt.Errorf("Invalid channel %q; must be one of %v", p.Channel, validChannels)
+	}
+	return nil
+}
+
+// ShouldRemind returns true if a reminder should be sent at the given time
+// according to the Preferences.
+func (p *Preferences) ShouldRemind(nowUTC time.Time) bool {
+	if nowUTC.Hour() != p.Hour {
+		return false
+	}
+	switch p.Frequency {
+	case FrequencyDaily:
+		return true
+	case FrequencyWeekly:
+		return nowUTC.Weekday() == weeklyReminderDay
+	default:
+		return false
+	}
+}
+
+// PreferencesStore saves and retrieves Preferences in Cloud Datastore.
+type PreferencesStore struct {
+	ds *datastore.Client
+}
+
+// NewPreferencesStore creates a new PreferencesStore from the given Datastore
+// client.
+func NewPreferencesStore(ds *datastore.Client) *PreferencesStore {
+	return &PreferencesStore{
+		ds: ds,
+	}
+}
+
+func preferencesKey(email string) *datastore.Key {
+	key := ds.NewKey(ds.REMINDER_PREFERENCES_AM)
+	key.Name = email
+	return key
+}
+
+// Get returns the Preferences for the given user, or the default Preferences
+// if the user has not set any.
+func (s *PreferencesStore) Get(ctx context.Context, email string) (*Preferences, error) {
+	var p Preferences
+	if err := s.ds.Get(ctx, preferencesKey(email), &p); err != nil {
+		if err == datastore.ErrNoSuchEntity {
+			return DefaultPreferences(email), nil
+		}
+		return nil, fmt.Errorf("Failed to load preferences for %s: %s", email, err)
+	}
+	p.Email = email
+	return &p, nil
+}
+
+// GetAll returns the Preferences of all users who have set them, keyed by
+// email address.
+func (s *PreferencesStore) GetAll(ctx context.Context) (map[string]*Preferences, error) {
+	var prefs []*Preferences
+	keys, err := s.ds.GetAll(ctx, ds.NewQuery(ds.REMINDER_PREFERENCES_AM), &prefs)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to load preferences: %s", err)
+	}
+	ret := make(map[string]*Preferences, len(prefs))
+	for i, key := range keys {
+		prefs[i].Email = key.Name
+		ret[key.Name] = prefs[i]
+	}
+	return ret, nil
+}
+
+// Put saves the given Preferences.
+func (s *PreferencesStore) Put(ctx context.Context, p *Preferences) error {
+	if err := p.Validate(); err != nil {
+		return err
+	}
+	if _, err := s.ds.Put(ctx, preferencesKey(p.Email), p); err != nil {
+		return fmt.Errorf("Failed to save preferences for %s: %s", p.Email, err)
+	}
+	return nil
+}
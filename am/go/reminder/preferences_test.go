@@ -0,0 +1,90 @@
+package reminder
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.skia.org/infra/go/ds"
+	"go.skia.org/infra/go/ds/testutil"
+)
+
+func TestPreferences_Validate(t *testing.T) {
+
+	p := DefaultPreferences("superman@krypton.com")
+	assert.NoError(t, p.Validate())
+
+	p.Frequency = "hourly"
+	assert.Error(t, p.Validate())
+
+	p = DefaultPreferences("superman@krypton.com")
+	p.Hour = 24
+	assert.Error(t, p.Validate())
+
+	p = DefaultPreferences("superman@krypton.com")
+	p.Channel = "carrier-pigeon"
+	assert.Error(t, p.Validate())
+
+	p = DefaultPreferences("")
+	assert.Error(t, p.Validate())
+}
+
+func TestPreferences_ShouldRemind(t *testing.T) {
+
+	// 2011-11-28 is a Monday.
+	monday := time.Date(2011, 11, 28, 4, 0, 0, 0, time.UTC)
+	tuesday := time.Date(2011, 11, 29, 4, 0, 0, 0, time.UTC)
+
+	p := DefaultPreferences("superman@krypton.com")
+	assert.True(t, p.ShouldRemind(monday))
+	assert.True(t, p.ShouldRemind(tuesday))
+	assert.False(t, p.ShouldRemind(tuesday.Add(time.Hour)))
+
+	p.Frequency = FrequencyWeekly
+	assert.True(t, p.ShouldRemind(monday))
+	assert.False(t, p.ShouldRemind(tuesday))
+
+	p.Frequency = FrequencyNever
+	assert.False(t, p.ShouldRemind(monday))
+	assert.False(t, p.ShouldRemind(tuesday))
+
+	p.Frequency = FrequencyDaily
+	p.Hour = 17
+	assert.False(t, p.ShouldRemind(tuesday))
+	assert.True(t, p.ShouldRemind(time.Date(2011, 11, 29, 17, 0, 0, 0, time.UTC)))
+}
+
+func TestPreferencesStore(t *testing.T) {
+
+	cleanup := testutil.InitDatastore(t, ds.REMINDER_PREFERENCES_AM)
+	defer cleanup()
+
+	ctx := context.Background()
+	st := NewPreferencesStore(ds.DS)
+
+	// Users who have not set any preferences get the defaults.
+	p, err := st.Get(ctx, "superman@krypton.com")
+	require.NoError(t, err)
+	assert.Equal(t, DefaultPreferences("superman@krypton.com"), p)
+	all, err := st.GetAll(ctx)
+	require.NoError(t, err)
+	assert.Len(t, all, 0)
+
+	// Invalid preferences are rejected.
+	p.Hour = -1
+	assert.Error(t, st.Put(ctx, p))
+
+	p.Hour = 10
+	p.Frequency = FrequencyWeekly
+	require.NoError(t, st.Put(ctx, p))
+	got, err := st.Get(ctx, "superman@krypton.com")
+	require.NoError(t, err)
+	assert.Equal(t, p, got)
+
+	all, err = st.GetAll(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]*Preferences{"superman@krypton.com": p}, all)
+}
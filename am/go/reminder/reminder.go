@@ -6,24 +6,31 @@ import (
 	"context"
 	"fmt"
 	"html/template"
+	"net/url"
+	"strings"
 	"time"
 
 	"cloud.google.com/go/datastore"
 
+	"go.skia.org/infra/am/go/actionlink"
 	"go.skia.org/infra/am/go/incident"
 	"go.skia.org/infra/am/go/silence"
 	"go.skia.org/infra/email/go/emailclient"
 	"go.skia.org/infra/go/ds"
 	"go.skia.org/infra/go/email"
-	"go.skia.org/infra/go/httputils"
-	"go.skia.org/infra/go/rotations"
+	"go.skia.org/infra/go/human"
+	"go.skia.org/infra/go/metrics2"
 	"go.skia.org/infra/go/sklog"
+	"go.skia.org/infra/go/util"
 )
 
 const (
-	// Currently setup to email reminders daily at 4am UTC time.
-	reminderHourUTC  = 4
-	reminderDuration = 24 * time.Hour
+	// amURL is the base URL of the alert-manager UI.
+	amURL = "https://am.skia.org"
+
+	// Reminders are considered at the start of every hour, and sent to the
+	// owners whose Preferences match that hour.
+	reminderDuration = time.Hour
 
 	emailTemplate = `
 Hi {{.Owner}},
@@ -33,23 +40,53 @@ Hi {{.Owner}},
 You either own or were assigned these alerts on am.skia.org:
 <ul>
   {{range $a := .Alerts}}
-    <li>{{$a}}</li>
+    <li>
+      <a href="{{$a.URL}}">{{$a.Description}}</a>, firing for {{$a.FiringFor}}
+      <br/>
+      <a href="{{$a.CreateSilenceURL}}">create silence</a>
+      {{if $a.SilenceURL}} - <a href="{{$a.SilenceURL}}">silence for 24h</a>{{end}}
+      {{if $a.UnassignURL}} - <a href="{{$a.UnassignURL}}">unassign me</a>{{end}}
+      {{if $a.LastNote}}<br/>Last note: {{$a.LastNote}}{{end}}
+    </li>
   {{end}}
 </ul>
 
 This is a friendly reminder to add a silence or to resolve them whenever possible.
 <br/><br/>
 
+You can change how often you receive these reminders, or stop receiving them, via the reminder preferences on am.skia.org.
+<br/><br/>
+
 Thanks!
 `
 )
 
+// reminderAlert is an alert listed in a reminder email.
+type reminderAlert struct {
+	Description string
+	// URL links to the alert on am.skia.org.
+	URL string
+	// FiringFor is the human readable duration for which the alert has been
+	// firing.
+	FiringFor string
+	// LastNote is the most recent note on the alert, including its author,
+	// if any.
+	LastNote string
+	// CreateSilenceURL links to am.skia.org with a new silence for the alert
+	// prefilled.
+	CreateSilenceURL string
+	// SilenceURL and UnassignURL are signed action links, which are empty
+	// if action links are disabled or the action does not apply.
+	SilenceURL  string
+	UnassignURL string
+}
+
 var (
 	emailTemplateParsed = template.Must(template.New("reminder_email").Parse(emailTemplate))
 )
 
-// Reminder - Keeps track of which days reminders were sent out in the Datastore.
-// Uses named keys which are in "YYYY-MM-DD" format.
+// Reminder - Keeps track of which hours reminders were sent out in the Datastore.
+// Uses named keys which are in "YYYY-MM-DDTHH" format.
 type Reminder struct {
 }
 
@@ -57,17 +94,31 @@ type emailTicker struct {
 	t      *time.Timer
 	iStore *incident.Store
 	sStore *silence.Store
+	pStore *PreferencesStore
 	email  emailclient.Client
+
+	// escalationCfg is nil if escalation is disabled.
+	escalationCfg *EscalationConfig
+	// summaryChatRoom is the chat room to which the weekly summary is also
+	// sent, if any.
+	summaryChatRoom string
+	// rotations provides the rotation members who are excluded from
+	// reminders and receive the weekly summary.
+	rotations RotationSource
+	// policy restricts when reminders are sent. It is nil if reminders may
+	// be sent at any time.
+	policy *SchedulePolicy
+	// liveness is reset every time reminders are sent without errors.
+	liveness metrics2.Liveness
+	// signer is used to create action links in reminder emails. It is nil if
+	// action links are disabled.
+	signer *actionlink.Signer
 }
 
-// getDailyNextTickDuration returns the duration after which the reminder should be sent.
-// If startTimeUTC is after reminderHourUTC then the next day's reminderHourUTC is used.
-// If startTimeUTC is before reminderHourUTC then the current day's reminderHourUTC is used.
-func getDailyNextTickDuration(startTimeUTC time.Time, reminderHourUTC int) time.Duration {
-	nextTick := time.Date(startTimeUTC.Year(), startTimeUTC.Month(), startTimeUTC.Day(), reminderHourUTC, 0, 0, 0, time.UTC)
-	if nextTick.Before(startTimeUTC) {
-		nextTick = nextTick.Add(reminderDuration)
-	}
+// getNextTickDuration returns the duration after which reminders should next
+// be considered, ie. the start of the hour following startTimeUTC.
+func getNextTickDuration(startTimeUTC time.Time) time.Duration {
+	nextTick := startTimeUTC.Truncate(reminderDuration).Add(reminderDuration)
 	sklog.Infof("[reminder] Next tick is %s", nextTick)
 	return nextTick.Sub(startTimeUTC)
 }
@@ -89,13 +140,66 @@ func getOwnersToAlerts(ins []incident.Incident, silences []silence.Silence) map[
 	return ownersToAlerts
 }
 
+// filterOwnersByPreferences returns the subset of ownersToAlerts who should
+// be reminded at the given time according to their Preferences. Owners who
+// have not set any Preferences use DefaultPreferences.
+func filterOwnersByPreferences(ownersToAlerts map[string][]incident.Incident, prefs map[string]*Preferences, nowUTC time.Time) map[string][]incident.Incident {
+	ret := map[string][]incident.Incident{}
+	for o, alerts := range ownersToAlerts {
+		p, ok := prefs[o]
+		if !ok {
+			p = DefaultPreferences(o)
+		}
+		if p.ShouldRemind(nowUTC) {
+			ret[o] = alerts
+		}
+	}
+	return ret
+}
+
+// getReminderAlerts returns the alerts to list in the reminder email to the
+// given owner, including signed action links if signer is not nil.
+func getReminderAlerts(signer *actionlink.Signer, owner string, alerts []incident.Incident, nowUTC time.Time) ([]reminderAlert, error) {
+	ret := make([]reminderAlert, 0, len(alerts))
+	for _, a := range alerts {
+		ra := reminderAlert{
+			Description:      fmt.Sprintf("%s - %s", a.Params["alertname"], a.Params["abbr"]),
+			URL:              amURL + "/?" + url.Values{"tab": []string{"0"}, "alert_id": []string{a.ID}}.Encode(),
+			FiringFor:        strings.TrimSpace(human.Duration(nowUTC.Sub(time.Unix(a.Start, 0)))),
+			CreateSilenceURL: amURL + "/?" + url.Values{"tab": []string{"0"}, "silence_alert_id": []string{a.ID}}.Encode(),
+		}
+		if len(a.Notes) > 0 {
+			n := a.Notes[len(a.Notes)-1]
+			ra.LastNote = fmt.Sprintf("%s (%s)", n.Text, n.Author)
+		}
+		sklog.Infof("\t%s\n", ra.Description)
+		if signer != nil {
+			var err error
+			ra.SilenceURL, err = signer.URL(actionlink.Silence, a.Key, a.ID, owner, nowUTC)
+			if err != nil {
+				return nil, fmt.Errorf("Failed to create silence link: %s", err)
+			}
+			// Only alerts which were assigned to the owner can be unassigned.
+			if a.Params[incident.ASSIGNED_TO] == owner {
+				ra.UnassignURL, err = signer.URL(actionlink.Unassign, a.Key, a.ID, owner, nowUTC)
+				if err != nil {
+					return nil, fmt.Errorf("Failed to create unassign link: %s", err)
+				}
+			}
+		}
+		ret = append(ret, ra)
+	}
+	return ret, nil
+}
+
 func (et emailTicker) updateEmailTicker() {
-	et.t.Reset(getDailyNextTickDuration(time.Now().UTC(), reminderHourUTC))
+	et.t.Reset(getNextTickDuration(time.Now().UTC()))
 }
 
 // remindAlertOwners sends a reminder email with a list of firing alerts to
-// the owners/assignees of the alerts.
-func (et emailTicker) remindAlertOwners() error {
+// the owners/assignees of the alerts whose Preferences call for a reminder at
+// the given time.
+func (et emailTicker) remindAlertOwners(nowUTC time.Time) error {
 	ins, err := et.iStore.GetAll()
 	if err != nil {
 		return fmt.Errorf("Failed to load incidents: %s", err)
@@ -108,37 +212,39 @@ func (et emailTicker) remindAlertOwners() error {
 		silences = []silence.Silence{}
 	}
 
-	// Find the current infra gardener.
-	gardeners, err := rotations.FromURL(httputils.NewTimeoutClient(), rotations.InfraGardenerURL)
+	members, err := et.rotations.Members()
 	if err != nil {
-		return fmt.Errorf("Could not get current gardener: %s", err)
+		return err
 	}
-	if len(gardeners) != 1 {
-		return fmt.Errorf("Expected 1 entry from %s. Instead got %s", rotations.InfraGardenerURL, gardeners)
+	rotationMembers := util.NewStringSet(members)
+
+	prefs, err := et.pStore.GetAll(context.Background())
+	if err != nil {
+		return err
 	}
-	gardener := gardeners[0]
 
-	// Send reminder emails to alert owners (but not to the gardener).
-	ownersToAlerts := getOwnersToAlerts(ins, silences)
+	// Send reminder emails to alert owners (but not to rotation members).
+	ownersToAlerts := filterOwnersByPreferences(getOwnersToAlerts(ins, silences), prefs, nowUTC)
+	// Keep going if sending to an owner fails, so that one bad address does
+	// not prevent everyone else from being reminded.
+	failed := []string{}
 	for o, alerts := range ownersToAlerts {
-		if o == gardener {
-			sklog.Infof("Not going to email %s because they are the current gardener", o)
+		if rotationMembers[o] {
+			sklog.Infof("Not going to email %s because they are a current rotation member", o)
 			continue
 		}
 		sklog.Infof("Going to email %s for these alerts:\n", o)
-		alertDescriptions := []string{}
-		for _, a := range alerts {
-			desc := fmt.Sprintf("%s - %s", a.Params["alertname"], a.Params["abbr"])
-			alertDescriptions = append(alertDescriptions, desc)
-			sklog.Infof("\t%s\n", desc)
+		reminderAlerts, err := getReminderAlerts(et.signer, o, alerts, nowUTC)
+		if err != nil {
+			return err
 		}
 		emailBytes := new(bytes.Buffer)
 		if err := emailTemplateParsed.Execute(emailBytes, struct {
 			Owner  string
-			Alerts []string
+			Alerts []reminderAlert
 		}{
 			Owner:  o,
-			Alerts: alertDescriptions,
+			Alerts: reminderAlerts,
 		}); err != nil {
 			return fmt.Errorf("Failed to execute email template: %s", err)
 		}
@@ -148,32 +254,61 @@ func (et emailTicker) remindAlertOwners() error {
 		if err != nil {
 			return fmt.Errorf("Failed to get view action markup: %s", err)
 		}
-		if _, err := et.email.SendWithMarkup("Alert Manager", "alertserver@skia.org", []string{o}, emailSubject, emailBytes.String(), viewActionMarkup, ""); err != nil {
-			return fmt.Errorf("Could not send email: %s", err)
+		_, sendErr := et.email.SendWithMarkup("Alert Manager", "alertserver@skia.org", []string{o}, emailSubject, emailBytes.String(), viewActionMarkup, "")
+		if sendErr != nil {
+			sklog.Errorf("[reminder] Could not send email to %s: %s", o, sendErr)
+			failed = append(failed, o)
 		}
+		recordDelivery(context.Background(), newDelivery(o, ChannelEmail, len(alerts), nowUTC, sendErr))
 	}
 
+	if len(failed) > 0 {
+		return fmt.Errorf("Could not send reminders to %v", failed)
+	}
 	return nil
 }
 
-// StartReminderTicker sends reminders on a periodic basis.
-func StartReminderTicker(iStore *incident.Store, sStore *silence.Store, email emailclient.Client) {
+// StartReminderTicker sends reminders on a periodic basis, according to the
+// Preferences of each alert owner. If escalationCfg is not nil then alerts
+// which have been firing for too long are also escalated. A weekly summary of
+// alert owners is sent to the members of the rotations provided by rs, who do
+// not receive reminders, and, if summaryChatRoom is not empty, to that chat
+// room. If signer is not nil then reminder emails include signed
+// links which allow owners to act on their alerts directly. If policy is not
+// nil then nothing is sent at the times it does not allow.
+func StartReminderTicker(iStore *incident.Store, sStore *silence.Store, pStore *PreferencesStore, email emailclient.Client, rs RotationSource, escalationCfg *EscalationConfig, summaryChatRoom string, signer *actionlink.Signer, policy *SchedulePolicy) {
 	et := emailTicker{
-		t:      time.NewTimer(getDailyNextTickDuration(time.Now().UTC(), reminderHourUTC)),
-		iStore: iStore,
-		sStore: sStore,
-		email:  email,
+		t:               time.NewTimer(getNextTickDuration(time.Now().UTC())),
+		iStore:          iStore,
+		sStore:          sStore,
+		pStore:          pStore,
+		email:           email,
+		escalationCfg:   escalationCfg,
+		summaryChatRoom: summaryChatRoom,
+		rotations:       rs,
+		policy:          policy,
+		liveness:        metrics2.NewLiveness("am_reminder_sent_without_errors"),
+		signer:          signer,
 	}
 	go func() {
 		for {
 			<-et.t.C
+			// Round to the nearest hour in case the timer fired slightly early.
+			now := time.Now().UTC().Round(reminderDuration)
+			if !et.policy.Allows(now) {
+				sklog.Infof("[reminder] Not sending reminders at %s due to the schedule policy", now)
+				// Nothing is expected to be sent, so this counts as success.
+				et.liveness.Reset()
+				et.updateEmailTicker()
+				continue
+			}
 
 			var err error
 			if _, err = ds.DS.RunInTransaction(context.Background(), func(tx *datastore.Transaction) error {
 				var reminderFromDS Reminder
 				// Construct the key and see if it already exists in the Datastore.
 				k := ds.NewKey(ds.REMINDER_AM)
-				k.Name = time.Now().UTC().Format("2006-01-02")
+				k.Name = now.Format("2006-01-02T15")
 				if err := tx.Get(k, &reminderFromDS); err != nil {
 					if err == datastore.ErrNoSuchEntity {
 						sklog.Info("[reminder] Adding entry to datastore")
@@ -191,8 +326,20 @@ func StartReminderTicker(iStore *incident.Store, sStore *silence.Store, email em
 				sklog.Errorf("[reminder] Error talking to the datastore: %s", err)
 			} else {
 				sklog.Info("[reminder] Going to send reminders")
-				if err := et.remindAlertOwners(); err != nil {
+				if err := et.remindAlertOwners(now); err != nil {
 					sklog.Errorf("[reminder] Error emailing alert owners: %s", err)
+				} else {
+					et.liveness.Reset()
+				}
+				if et.escalationCfg != nil {
+					if err := et.escalateAlerts(context.Background(), now); err != nil {
+						sklog.Errorf("[reminder] Error escalating alerts: %s", err)
+					}
+				}
+				if isSummaryTime(now) {
+					if err := et.sendRotationSummary(now); err != nil {
+						sklog.Errorf("[reminder] Error sending summary to rotation members: %s", err)
+					}
 				}
 			}
 
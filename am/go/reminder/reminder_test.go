@@ -1,33 +1,31 @@
 package reminder
 
 import (
+	"bytes"
+	"net/url"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	"go.skia.org/infra/am/go/actionlink"
 	"go.skia.org/infra/am/go/incident"
+	"go.skia.org/infra/am/go/note"
 	"go.skia.org/infra/am/go/silence"
 	"go.skia.org/infra/go/paramtools"
 )
 
-func TestGetDailyNextTickDuration(t *testing.T) {
+func TestGetNextTickDuration(t *testing.T) {
 
 	fakeNow := time.Date(2011, 11, 30, 16, 0, 0, 0, time.UTC)
-	oneHourLater := time.Date(2011, 11, 30, 17, 0, 0, 0, time.UTC).Hour()
-	assert.Equal(t, time.Hour, getDailyNextTickDuration(fakeNow, oneHourLater))
-
-	fakeNow = time.Date(2011, 11, 30, 16, 0, 0, 0, time.UTC)
-	oneHourEarlier := time.Date(2011, 11, 30, 15, 0, 0, 0, time.UTC).Hour()
-	assert.Equal(t, 23*time.Hour, getDailyNextTickDuration(fakeNow, oneHourEarlier))
+	assert.Equal(t, time.Hour, getNextTickDuration(fakeNow))
 
 	fakeNow = time.Date(2011, 11, 30, 15, 55, 0, 0, time.UTC)
-	fiveMinsLater := time.Date(2011, 11, 30, 16, 0, 0, 0, time.UTC).Hour()
-	assert.Equal(t, 5*time.Minute, getDailyNextTickDuration(fakeNow, fiveMinsLater))
+	assert.Equal(t, 5*time.Minute, getNextTickDuration(fakeNow))
 
-	fakeNow = time.Date(2011, 11, 30, 16, 05, 0, 0, time.UTC)
-	fiveMinsEarlier := time.Date(2011, 11, 30, 16, 0, 0, 0, time.UTC).Hour()
-	assert.Equal(t, 23*time.Hour+55*time.Minute, getDailyNextTickDuration(fakeNow, fiveMinsEarlier))
+	fakeNow = time.Date(2011, 11, 30, 23, 30, 0, 0, time.UTC)
+	assert.Equal(t, 30*time.Minute, getNextTickDuration(fakeNow))
 }
 
 func TestGetOwnersToAlerts(t *testing.T) {
@@ -72,3 +70,121 @@ func TestGetOwnersToAlerts(t *testing.T) {
 	assert.Equal(t, 1, len(ownersToAlerts))
 	assert.Equal(t, 1, len(ownersToAlerts["batman@gotham.com"]))
 }
+
+func TestFilterOwnersByPreferences(t *testing.T) {
+
+	ownersToAlerts := map[string][]incident.Incident{
+		"superman@krypton.com": {{Params: map[string]string{"owner": "superman@krypton.com"}}},
+		"batman@gotham.com":    {{Params: map[string]string{"owner": "batman@gotham.com"}}},
+		"robin@gotham.com":     {{Params: map[string]string{"owner": "robin@gotham.com"}}},
+	}
+	prefs := map[string]*Preferences{
+		"batman@gotham.com": {Email: "batman@gotham.com", Frequency: FrequencyNever, Hour: 4, Channel: ChannelEmail},
+		"robin@gotham.com":  {Email: "robin@gotham.com", Frequency: FrequencyDaily, Hour: 10, Channel: ChannelEmail},
+	}
+
+	// Superman uses the default preferences.
+	filtered := filterOwnersByPreferences(ownersToAlerts, prefs, time.Date(2011, 11, 30, 4, 0, 0, 0, time.UTC))
+	assert.Equal(t, 1, len(filtered))
+	assert.Equal(t, 1, len(filtered["superman@krypton.com"]))
+
+	filtered = filterOwnersByPreferences(ownersToAlerts, prefs, time.Date(2011, 11, 30, 10, 0, 0, 0, time.UTC))
+	assert.Equal(t, 1, len(filtered))
+	assert.Equal(t, 1, len(filtered["robin@gotham.com"]))
+
+	filtered = filterOwnersByPreferences(ownersToAlerts, prefs, time.Date(2011, 11, 30, 12, 0, 0, 0, time.UTC))
+	assert.Equal(t, 0, len(filtered))
+}
+
+func TestGetReminderAlerts(t *testing.T) {
+
+	now := time.Date(2011, 11, 30, 16, 0, 0, 0, time.UTC)
+	alerts := []incident.Incident{
+		{
+			Key:   "owned",
+			ID:    "1",
+			Start: now.Add(-3 * time.Hour).Unix(),
+			Notes: []note.Note{
+				{Text: "Looking into it.", Author: "superman@krypton.com"},
+				{Text: "Still broken.", Author: "batman@gotham.com"},
+			},
+			Params: map[string]string{
+				"alertname": "BotMissing",
+				"abbr":      "skia-rpi-001",
+				"owner":     "superman@krypton.com",
+			},
+		},
+		{
+			Key:   "assigned",
+			ID:    "2",
+			Start: now.Add(-2 * 24 * time.Hour).Unix(),
+			Params: map[string]string{
+				"alertname":   "BotMissing",
+				"abbr":        "skia-rpi-002",
+				"assigned_to": "superman@krypton.com",
+			},
+		},
+	}
+
+	// No action links without a signer.
+	ras, err := getReminderAlerts(nil, "superman@krypton.com", alerts, now)
+	require.NoError(t, err)
+	assert.Equal(t, []reminderAlert{
+		{
+			Description:      "BotMissing - skia-rpi-001",
+			URL:              "https://am.skia.org/?alert_id=1&tab=0",
+			FiringFor:        "3h",
+			LastNote:         "Still broken. (batman@gotham.com)",
+			CreateSilenceURL: "https://am.skia.org/?silence_alert_id=1&tab=0",
+		},
+		{
+			Description:      "BotMissing - skia-rpi-002",
+			URL:              "https://am.skia.org/?alert_id=2&tab=0",
+			FiringFor:        "2d",
+			CreateSilenceURL: "https://am.skia.org/?silence_alert_id=2&tab=0",
+		},
+	}, ras)
+
+	signer, err := actionlink.NewSigner([]byte("secret"), "https://am.skia.org")
+	require.NoError(t, err)
+	ras, err = getReminderAlerts(signer, "superman@krypton.com", alerts, now)
+	require.NoError(t, err)
+	require.Len(t, ras, 2)
+	assert.NotEmpty(t, ras[0].SilenceURL)
+	assert.Empty(t, ras[0].UnassignURL)
+	assert.NotEmpty(t, ras[1].SilenceURL)
+	require.NotEmpty(t, ras[1].UnassignURL)
+
+	u, err := url.Parse(ras[1].UnassignURL)
+	require.NoError(t, err)
+	a, err := signer.Verify(actionlink.TokenFromURL(u), now)
+	require.NoError(t, err)
+	assert.Equal(t, actionlink.Unassign, a.Type)
+	assert.Equal(t, "assigned", a.IncidentKey)
+	assert.Equal(t, "2", a.IncidentID)
+	assert.Equal(t, "superman@krypton.com", a.User)
+}
+
+func TestEmailTemplate(t *testing.T) {
+
+	var b bytes.Buffer
+	require.NoError(t, emailTemplateParsed.Execute(&b, struct {
+		Owner  string
+		Alerts []reminderAlert
+	}{
+		Owner: "superman@krypton.com",
+		Alerts: []reminderAlert{
+			{
+				Description:      "BotMissing - skia-rpi-001",
+				URL:              "https://am.skia.org/?alert_id=1&tab=0",
+				FiringFor:        "3h",
+				LastNote:         "Still broken. (batman@gotham.com)",
+				CreateSilenceURL: "https://am.skia.org/?silence_alert_id=1&tab=0",
+			},
+		},
+	}))
+	assert.Contains(t, b.String(), `<a href="https://am.skia.org/?alert_id=1&amp;tab=0">BotMissing - skia-rpi-001</a>, firing for 3h`)
+	assert.Contains(t, b.String(), `<a href="https://am.skia.org/?silence_alert_id=1&amp;tab=0">create silence</a>`)
+	assert.Contains(t, b.String(), `Last note: Still broken. (batman@gotham.com)`)
+	assert.NotContains(t, b.String(), "unassign me")
+}
@@ -0,0 +1,50 @@
+package reminder
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+
+	"go.skia.org/infra/go/rotations"
+	"go.skia.org/infra/go/util"
+)
+
+// RotationSource provides the current members of the rotations, eg. the infra
+// gardener, whose members do not receive reminders and instead receive the
+// weekly summary of alert owners.
+type RotationSource interface {
+	// Members returns the current members of all rotations.
+	Members() ([]string, error)
+}
+
+// urlRotationSource is a RotationSource which loads the current members of
+// each rotation from a URL.
+type urlRotationSource struct {
+	client *http.Client
+	urls   []string
+}
+
+// NewURLRotationSource returns a RotationSource which loads the current
+// members of each rotation from the given URLs, eg.
+// rotations.InfraGardenerURL.
+func NewURLRotationSource(client *http.Client, urls []string) RotationSource {
+	return &urlRotationSource{
+		client: client,
+		urls:   urls,
+	}
+}
+
+// Members implements RotationSource.
+func (s *urlRotationSource) Members() ([]string, error) {
+	members := util.StringSet{}
+	for _, u := range s.urls {
+		m, err := rotations.FromURL(s.client, u)
+		if err != nil {
+			return nil, fmt.Errorf("Could not get current members of rotation %s: %s", u, err)
+		}
+		members.AddLists(m)
+	}
+	rv := members.Keys()
+	sort.Strings(rv)
+	return rv, nil
+}
@@ -0,0 +1,37 @@
+package reminder
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestURLRotationSource_Members(t *testing.T) {
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/gardener":
+			_, _ = fmt.Fprint(w, `{"emails": ["superman@krypton.com"]}`)
+		case "/trooper":
+			_, _ = fmt.Fprint(w, `{"emails": ["batman@gotham.com", "superman@krypton.com"]}`)
+		default:
+			http.Error(w, "not found", http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	members, err := NewURLRotationSource(srv.Client(), []string{srv.URL + "/gardener", srv.URL + "/trooper"}).Members()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"batman@gotham.com", "superman@krypton.com"}, members)
+
+	members, err = NewURLRotationSource(srv.Client(), nil).Members()
+	require.NoError(t, err)
+	assert.Empty(t, members)
+
+	_, err = NewURLRotationSource(srv.Client(), []string{srv.URL + "/gardener", srv.URL + "/missing"}).Members()
+	assert.Error(t, err)
+}
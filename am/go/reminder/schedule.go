@@ -0,0 +1,91 @@
+package reminder
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/robfig/cron"
+)
+
+// HolidayCalendar reports whether a given day is a holiday, on which no
+// reminders are sent.
+type HolidayCalendar interface {
+	IsHoliday(t time.Time) bool
+}
+
+// fileHolidayCalendar is a HolidayCalendar loaded from a file. The keys are
+// dates in "YYYY-MM-DD" format.
+type fileHolidayCalendar map[string]bool
+
+// IsHoliday implements HolidayCalendar.
+func (c fileHolidayCalendar) IsHoliday(t time.Time) bool {
+	return c[t.Format("2006-01-02")]
+}
+
+// LoadHolidayCalendar reads a HolidayCalendar from the given JSON file, eg. one
+// mounted from a config map, which contains a list of dates in "YYYY-MM-DD"
+// format.
+func LoadHolidayCalendar(path string) (HolidayCalendar, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read holiday calendar: %s", err)
+	}
+	var dates []string
+	if err := json.Unmarshal(b, &dates); err != nil {
+		return nil, fmt.Errorf("Failed to parse holiday calendar: %s", err)
+	}
+	ret := make(fileHolidayCalendar, len(dates))
+	for _, d := range dates {
+		if _, err := time.Parse("2006-01-02", d); err != nil {
+			return nil, fmt.Errorf("Invalid date %q in holiday calendar: %s", d, err)
+		}
+		ret[d] = true
+	}
+	return ret, nil
+}
+
+// SchedulePolicy restricts the times at which reminders, escalations and
+// summaries may be sent, on top of the Preferences of each alert owner.
+type SchedulePolicy struct {
+	schedule cron.Schedule
+	holidays HolidayCalendar
+}
+
+// NewSchedulePolicy returns a SchedulePolicy which allows sending at times
+// matching the given standard five field cron expression, evaluated in UTC at
+// the start of each hour, eg. "0 * * * 1-5" for business days only, and not on
+// any day in the given HolidayCalendar. Either may be empty or nil to allow any
+// time or day respectively.
+func NewSchedulePolicy(spec string, holidays HolidayCalendar) (*SchedulePolicy, error) {
+	p := &SchedulePolicy{
+		holidays: holidays,
+	}
+	if spec != "" {
+		schedule, err := cron.ParseStandard(spec)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid reminder schedule %q: %s", spec, err)
+		}
+		p.schedule = schedule
+	}
+	return p, nil
+}
+
+// Allows returns true if reminders may be sent at the given time. A nil
+// SchedulePolicy allows any time.
+func (p *SchedulePolicy) Allows(nowUTC time.Time) bool {
+	if p == nil {
+		return true
+	}
+	if p.holidays != nil && p.holidays.IsHoliday(nowUTC) {
+		return false
+	}
+	if p.schedule != nil {
+		t := nowUTC.Truncate(time.Minute)
+		if !p.schedule.Next(t.Add(-time.Second)).Equal(t) {
+			return false
+		}
+	}
+	return true
+}
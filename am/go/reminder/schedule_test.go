@@ -0,0 +1,61 @@
+package reminder
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchedulePolicy_Allows(t *testing.T) {
+
+	// 2011-11-25 is a Friday and 2011-11-26 is a Saturday.
+	friday := time.Date(2011, 11, 25, 4, 0, 0, 0, time.UTC)
+	saturday := time.Date(2011, 11, 26, 4, 0, 0, 0, time.UTC)
+
+	var nilPolicy *SchedulePolicy
+	assert.True(t, nilPolicy.Allows(saturday))
+
+	p, err := NewSchedulePolicy("", nil)
+	require.NoError(t, err)
+	assert.True(t, p.Allows(friday))
+	assert.True(t, p.Allows(saturday))
+
+	p, err = NewSchedulePolicy("0 * * * 1-5", nil)
+	require.NoError(t, err)
+	assert.True(t, p.Allows(friday))
+	assert.False(t, p.Allows(saturday))
+
+	p, err = NewSchedulePolicy("0 9-17 * * MON-FRI", nil)
+	require.NoError(t, err)
+	assert.False(t, p.Allows(friday))
+	assert.True(t, p.Allows(friday.Add(5*time.Hour)))
+	assert.True(t, p.Allows(friday.Add(13*time.Hour)))
+	assert.False(t, p.Allows(friday.Add(14*time.Hour)))
+
+	p, err = NewSchedulePolicy("0 * * * 1-5", fileHolidayCalendar{"2011-11-25": true})
+	require.NoError(t, err)
+	assert.False(t, p.Allows(friday))
+	assert.True(t, p.Allows(friday.Add(-24*time.Hour)))
+
+	_, err = NewSchedulePolicy("not a schedule", nil)
+	assert.Error(t, err)
+}
+
+func TestLoadHolidayCalendar(t *testing.T) {
+
+	path := filepath.Join(t.TempDir(), "holidays.json")
+	require.NoError(t, os.WriteFile(path, []byte(`["2011-11-24", "2011-12-25"]`), 0644))
+	c, err := LoadHolidayCalendar(path)
+	require.NoError(t, err)
+	assert.True(t, c.IsHoliday(time.Date(2011, 11, 24, 4, 0, 0, 0, time.UTC)))
+	assert.True(t, c.IsHoliday(time.Date(2011, 12, 25, 23, 0, 0, 0, time.UTC)))
+	assert.False(t, c.IsHoliday(time.Date(2011, 11, 25, 4, 0, 0, 0, time.UTC)))
+
+	require.NoError(t, os.WriteFile(path, []byte(`["Thanksgiving"]`), 0644))
+	_, err = LoadHolidayCalendar(path)
+	assert.Error(t, err)
+}
@@ -0,0 +1,199 @@
+package reminder
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"sort"
+	"strings"
+	"time"
+
+	"go.skia.org/infra/am/go/incident"
+	"go.skia.org/infra/am/go/silence"
+	"go.skia.org/infra/am/go/suggest"
+	"go.skia.org/infra/go/chatbot"
+	"go.skia.org/infra/go/email"
+	"go.skia.org/infra/go/human"
+	"go.skia.org/infra/go/sklog"
+)
+
+const (
+	summaryEmailTemplate = `
+Hi,
+<br/><br/>
+
+These are the owners of active, unsilenced alerts on am.skia.org:
+<table>
+  <tr><th>Owner</th><th>Alerts</th><th>Oldest</th></tr>
+  {{range $s := .Summaries}}
+    <tr><td>{{$s.Owner}}</td><td>{{$s.NumAlerts}}</td><td>{{$s.OldestAge}}</td></tr>
+  {{end}}
+</table>
+<br/>
+{{if .Unowned}}
+These active, unsilenced alerts have no owner:
+<ul>
+  {{range $u := .Unowned}}
+    <li>{{$u.Description}}{{if $u.SuggestedOwners}} - likely owners: {{$u.SuggestedOwners}}{{end}}</li>
+  {{end}}
+</ul>
+{{end}}
+Thanks!
+`
+)
+
+var (
+	summaryEmailTemplateParsed = template.Must(template.New("summary_email").Parse(summaryEmailTemplate))
+)
+
+// ownerSummary describes the active, unsilenced alerts of a single owner.
+type ownerSummary struct {
+	Owner     string
+	NumAlerts int
+	// OldestAge is the human readable age of the owner's oldest alert.
+	OldestAge string
+}
+
+// unownedAlert describes an active, unsilenced alert without an owner.
+type unownedAlert struct {
+	Description string
+	// SuggestedOwners is a human readable list of the likely owners of the
+	// alert, if any.
+	SuggestedOwners string
+}
+
+// isSummaryTime returns true if the weekly summary should be sent at the given
+// time.
+func isSummaryTime(nowUTC time.Time) bool {
+	return nowUTC.Weekday() == weeklyReminderDay && nowUTC.Hour() == defaultReminderHourUTC
+}
+
+// getOwnerSummaries returns a summary of the active, unsilenced alerts of each
+// owner, sorted by decreasing number of alerts.
+func getOwnerSummaries(ins []incident.Incident, silences []silence.Silence, nowUTC time.Time) []ownerSummary {
+	ret := []ownerSummary{}
+	for owner, alerts := range getOwnersToAlerts(ins, silences) {
+		oldest := alerts[0].Start
+		for _, a := range alerts[1:] {
+			if a.Start < oldest {
+				oldest = a.Start
+			}
+		}
+		ret = append(ret, ownerSummary{
+			Owner:     owner,
+			NumAlerts: len(alerts),
+			OldestAge: strings.TrimSpace(human.Duration(nowUTC.Sub(time.Unix(oldest, 0)))),
+		})
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		if ret[i].NumAlerts != ret[j].NumAlerts {
+			return ret[i].NumAlerts > ret[j].NumAlerts
+		}
+		return ret[i].Owner < ret[j].Owner
+	})
+	return ret
+}
+
+// getUnownedAlerts returns the active, unsilenced alerts without an owner,
+// along with the likely owners from suggestions, which are keyed by incident
+// key.
+func getUnownedAlerts(ins []incident.Incident, silences []silence.Silence, suggestions map[string][]suggest.Suggestion) []unownedAlert {
+	ret := []unownedAlert{}
+	for _, in := range ins {
+		if !suggest.IsUnowned(in, silences) {
+			continue
+		}
+		owners := []string{}
+		for _, s := range suggestions[in.Key] {
+			owners = append(owners, s.Owner)
+		}
+		ret = append(ret, unownedAlert{
+			Description:     fmt.Sprintf("%s - %s", in.Params[incident.ALERT_NAME], in.Params[incident.ABBR]),
+			SuggestedOwners: strings.Join(owners, ", "),
+		})
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].Description < ret[j].Description
+	})
+	return ret
+}
+
+// chatSummary returns the weekly summary formatted as a chat message.
+func chatSummary(summaries []ownerSummary, unowned []unownedAlert) string {
+	lines := []string{"Owners of active, unsilenced alerts on am.skia.org:"}
+	for _, s := range summaries {
+		lines = append(lines, fmt.Sprintf("%s: %d alert(s), oldest %s", s.Owner, s.NumAlerts, s.OldestAge))
+	}
+	if len(unowned) > 0 {
+		lines = append(lines, "Alerts without an owner:")
+		for _, u := range unowned {
+			if u.SuggestedOwners != "" {
+				lines = append(lines, fmt.Sprintf("%s (likely owners: %s)", u.Description, u.SuggestedOwners))
+			} else {
+				lines = append(lines, u.Description)
+			}
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// sendRotationSummary sends a summary of who owns how many active alerts to
+// the current rotation members and, if configured, to the summary chat room.
+func (et emailTicker) sendRotationSummary(nowUTC time.Time) error {
+	ins, err := et.iStore.GetAll()
+	if err != nil {
+		return fmt.Errorf("Failed to load incidents: %s", err)
+	}
+	silences, err := et.sStore.GetAll()
+	if err != nil {
+		return fmt.Errorf("Failed to load silences: %s", err)
+	}
+	summaries := getOwnerSummaries(ins, silences, nowUTC)
+	unownedIncidents := []incident.Incident{}
+	for _, in := range ins {
+		if suggest.IsUnowned(in, silences) {
+			unownedIncidents = append(unownedIncidents, in)
+		}
+	}
+	if len(summaries) == 0 && len(unownedIncidents) == 0 {
+		sklog.Info("[reminder] No active alerts; not sending a summary")
+		return nil
+	}
+	suggestions, err := suggest.New(et.iStore).Suggest(unownedIncidents)
+	if err != nil {
+		return err
+	}
+	unowned := getUnownedAlerts(unownedIncidents, silences, suggestions)
+	members, err := et.rotations.Members()
+	if err != nil {
+		return err
+	}
+
+	emailBytes := new(bytes.Buffer)
+	if err := summaryEmailTemplateParsed.Execute(emailBytes, struct {
+		Summaries []ownerSummary
+		Unowned   []unownedAlert
+	}{
+		Summaries: summaries,
+		Unowned:   unowned,
+	}); err != nil {
+		return fmt.Errorf("Failed to execute summary email template: %s", err)
+	}
+	emailSubject := "Weekly summary of alert owners on am.skia.org"
+	viewActionMarkup, err := email.GetViewActionMarkup("am.skia.org/?tab=0", "View Alerts", "View active alerts")
+	if err != nil {
+		return fmt.Errorf("Failed to get view action markup: %s", err)
+	}
+	if len(members) == 0 {
+		sklog.Info("[reminder] No rotation members; not emailing the summary")
+	} else if _, err := et.email.SendWithMarkup("Alert Manager", "alertserver@skia.org", members, emailSubject, emailBytes.String(), viewActionMarkup, ""); err != nil {
+		return fmt.Errorf("Could not send summary email: %s", err)
+	}
+
+	if et.summaryChatRoom != "" {
+		if err := chatbot.Send(chatSummary(summaries, unowned), et.summaryChatRoom, ""); err != nil {
+			return fmt.Errorf("Could not send summary chat message: %s", err)
+		}
+	}
+	return nil
+}
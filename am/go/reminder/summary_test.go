@@ -0,0 +1,99 @@
+package reminder
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.skia.org/infra/am/go/incident"
+	"go.skia.org/infra/am/go/silence"
+	"go.skia.org/infra/am/go/suggest"
+	"go.skia.org/infra/go/paramtools"
+)
+
+func TestIsSummaryTime(t *testing.T) {
+
+	// 2011-11-28 is a Monday.
+	assert.True(t, isSummaryTime(time.Date(2011, 11, 28, 4, 0, 0, 0, time.UTC)))
+	assert.False(t, isSummaryTime(time.Date(2011, 11, 28, 5, 0, 0, 0, time.UTC)))
+	assert.False(t, isSummaryTime(time.Date(2011, 11, 29, 4, 0, 0, 0, time.UTC)))
+}
+
+func TestGetOwnerSummaries(t *testing.T) {
+
+	now := time.Date(2011, 11, 30, 16, 0, 0, 0, time.UTC)
+	incidents := []incident.Incident{
+		{
+			Start:  now.Add(-2 * time.Hour).Unix(),
+			Params: map[string]string{"id": "1", "owner": "superman@krypton.com"},
+		},
+		{
+			Start:  now.Add(-3 * 24 * time.Hour).Unix(),
+			Params: map[string]string{"id": "2", "owner": "superman@krypton.com"},
+		},
+		{
+			Start:  now.Add(-time.Hour).Unix(),
+			Params: map[string]string{"id": "3", "assigned_to": "batman@gotham.com"},
+		},
+		{
+			Start:  now.Add(-time.Hour).Unix(),
+			Params: map[string]string{"id": "4"},
+		},
+	}
+	summaries := getOwnerSummaries(incidents, nil, now)
+	assert.Equal(t, []ownerSummary{
+		{Owner: "superman@krypton.com", NumAlerts: 2, OldestAge: "3d"},
+		{Owner: "batman@gotham.com", NumAlerts: 1, OldestAge: "1h"},
+	}, summaries)
+	assert.Equal(t, "Owners of active, unsilenced alerts on am.skia.org:\nsuperman@krypton.com: 2 alert(s), oldest 3d\nbatman@gotham.com: 1 alert(s), oldest 1h", chatSummary(summaries, nil))
+
+	// Silenced alerts are not included.
+	silences := []silence.Silence{
+		{
+			Active:   true,
+			ParamSet: paramtools.ParamSet{"id": []string{"2"}},
+		},
+	}
+	assert.Equal(t, []ownerSummary{
+		{Owner: "batman@gotham.com", NumAlerts: 1, OldestAge: "1h"},
+		{Owner: "superman@krypton.com", NumAlerts: 1, OldestAge: "2h"},
+	}, getOwnerSummaries(incidents, silences, now))
+}
+
+func TestGetUnownedAlerts(t *testing.T) {
+
+	incidents := []incident.Incident{
+		{
+			Key:    "1",
+			Params: map[string]string{"id": "1", "alertname": "BotMissing", "abbr": "skia-rpi-002"},
+		},
+		{
+			Key:    "2",
+			Params: map[string]string{"id": "2", "alertname": "BotMissing", "abbr": "skia-rpi-001"},
+		},
+		{
+			Key:    "3",
+			Params: map[string]string{"id": "3", "alertname": "BotMissing", "abbr": "skia-rpi-003", "owner": "superman@krypton.com"},
+		},
+		{
+			Key:    "4",
+			Params: map[string]string{"id": "4", "alertname": "BotMissing", "abbr": "skia-rpi-004"},
+		},
+	}
+	silences := []silence.Silence{
+		{
+			Active:   true,
+			ParamSet: paramtools.ParamSet{"id": []string{"4"}},
+		},
+	}
+	suggestions := map[string][]suggest.Suggestion{
+		"1": {{Owner: "batman@gotham.com", Count: 2}, {Owner: "robin@gotham.com", Count: 1}},
+	}
+	unowned := getUnownedAlerts(incidents, silences, suggestions)
+	assert.Equal(t, []unownedAlert{
+		{Description: "BotMissing - skia-rpi-001"},
+		{Description: "BotMissing - skia-rpi-002", SuggestedOwners: "batman@gotham.com, robin@gotham.com"},
+	}, unowned)
+	assert.Equal(t, "Owners of active, unsilenced alerts on am.skia.org:\nAlerts without an owner:\nBotMissing - skia-rpi-001\nBotMissing - skia-rpi-002 (likely owners: batman@gotham.com, robin@gotham.com)", chatSummary(nil, unowned))
+}
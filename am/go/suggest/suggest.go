@@ -0,0 +1,106 @@
+// Package suggest proposes likely owners for alerts which have neither an
+// owner nor an assignee, based on who handled the same alert in the past.
+package suggest
+
+import (
+	"fmt"
+	"sort"
+
+	"go.skia.org/infra/am/go/incident"
+	"go.skia.org/infra/am/go/silence"
+)
+
+const (
+	// historyRange is how far back to look for incidents of the same alert,
+	// in human units.
+	historyRange = "4w"
+
+	// maxSuggestions is the maximum number of owners suggested per alert.
+	maxSuggestions = 3
+)
+
+// Suggestion is a proposed owner of an alert.
+type Suggestion struct {
+	Owner string `json:"owner"`
+	// Count is the number of past incidents of the same alert which were
+	// owned by or assigned to Owner.
+	Count int `json:"count"`
+}
+
+// owner returns the assignee of the incident, falling back to its owner.
+func owner(in incident.Incident) string {
+	if o := in.Params[incident.ASSIGNED_TO]; o != "" {
+		return o
+	}
+	return in.Params[incident.OWNER]
+}
+
+// sameAlert returns true if both incidents are of the same alert, ie. they have
+// the same alertname and abbr.
+func sameAlert(a, b incident.Incident) bool {
+	return a.Params[incident.ALERT_NAME] == b.Params[incident.ALERT_NAME] && a.Params[incident.ABBR] == b.Params[incident.ABBR]
+}
+
+// SuggestOwners returns the most likely owners of the given incident, based on
+// the owners and assignees of incidents of the same alert in history, sorted
+// by decreasing likelihood.
+func SuggestOwners(in incident.Incident, history []incident.Incident) []Suggestion {
+	counts := map[string]int{}
+	for _, h := range history {
+		if h.Key == in.Key || !sameAlert(in, h) {
+			continue
+		}
+		if o := owner(h); o != "" {
+			counts[o]++
+		}
+	}
+	ret := make([]Suggestion, 0, len(counts))
+	for o, c := range counts {
+		ret = append(ret, Suggestion{Owner: o, Count: c})
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		if ret[i].Count != ret[j].Count {
+			return ret[i].Count > ret[j].Count
+		}
+		return ret[i].Owner < ret[j].Owner
+	})
+	if len(ret) > maxSuggestions {
+		ret = ret[:maxSuggestions]
+	}
+	return ret
+}
+
+// IsUnowned returns true if the incident has neither an owner nor an assignee
+// and is not silenced.
+func IsUnowned(in incident.Incident, silences []silence.Silence) bool {
+	return owner(in) == "" && !in.IsSilenced(silences, true)
+}
+
+// Suggester suggests owners for incidents using the history in an
+// incident.Store.
+type Suggester struct {
+	store *incident.Store
+}
+
+// New returns a Suggester which uses the history in the given Store.
+func New(store *incident.Store) *Suggester {
+	return &Suggester{
+		store: store,
+	}
+}
+
+// Suggest returns the most likely owners of the given incidents, keyed by
+// incident key. Incidents without any suggestions are omitted.
+func (s *Suggester) Suggest(ins []incident.Incident) (map[string][]Suggestion, error) {
+	history, err := s.store.GetRecentlyResolvedInRange(historyRange)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to load incident history: %s", err)
+	}
+	ret := map[string][]Suggestion{}
+	for _, in := range ins {
+		if suggestions := SuggestOwners(in, history); len(suggestions) > 0 {
+			ret[in.Key] = suggestions
+		}
+	}
+	return ret, nil
+}
@@ -0,0 +1,70 @@
+package suggest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.skia.org/infra/am/go/incident"
+	"go.skia.org/infra/am/go/silence"
+	"go.skia.org/infra/go/paramtools"
+)
+
+func newIncident(key, alertname, abbr, owner, assignedTo string) incident.Incident {
+	params := map[string]string{
+		"id":        key,
+		"alertname": alertname,
+		"abbr":      abbr,
+	}
+	if owner != "" {
+		params["owner"] = owner
+	}
+	if assignedTo != "" {
+		params["assigned_to"] = assignedTo
+	}
+	return incident.Incident{
+		Key:    key,
+		ID:     key,
+		Params: params,
+	}
+}
+
+func TestSuggestOwners(t *testing.T) {
+
+	in := newIncident("current", "BotMissing", "skia-rpi-001", "", "")
+	history := []incident.Incident{
+		newIncident("1", "BotMissing", "skia-rpi-001", "", "superman@krypton.com"),
+		newIncident("2", "BotMissing", "skia-rpi-001", "batman@gotham.com", "superman@krypton.com"),
+		newIncident("3", "BotMissing", "skia-rpi-001", "batman@gotham.com", ""),
+		newIncident("4", "BotMissing", "skia-rpi-001", "", "robin@gotham.com"),
+		newIncident("5", "BotMissing", "skia-rpi-001", "", "alfred@gotham.com"),
+		newIncident("6", "BotMissing", "skia-rpi-001", "", ""),
+		// Different alerts are ignored.
+		newIncident("7", "BotMissing", "skia-rpi-002", "", "robin@gotham.com"),
+		newIncident("8", "BotQuarantined", "skia-rpi-001", "", "robin@gotham.com"),
+		// The incident itself is ignored.
+		newIncident("current", "BotMissing", "skia-rpi-001", "", "robin@gotham.com"),
+	}
+	assert.Equal(t, []Suggestion{
+		{Owner: "superman@krypton.com", Count: 2},
+		{Owner: "alfred@gotham.com", Count: 1},
+		{Owner: "batman@gotham.com", Count: 1},
+	}, SuggestOwners(in, history))
+
+	assert.Empty(t, SuggestOwners(newIncident("current", "Unknown", "", "", ""), history))
+}
+
+func TestIsUnowned(t *testing.T) {
+
+	assert.True(t, IsUnowned(newIncident("1", "BotMissing", "", "", ""), nil))
+	assert.False(t, IsUnowned(newIncident("1", "BotMissing", "", "superman@krypton.com", ""), nil))
+	assert.False(t, IsUnowned(newIncident("1", "BotMissing", "", "", "superman@krypton.com"), nil))
+
+	silences := []silence.Silence{
+		{
+			Active:   true,
+			ParamSet: paramtools.ParamSet{"id": []string{"1"}},
+		},
+	}
+	assert.False(t, IsUnowned(newIncident("1", "BotMissing", "", "", ""), silences))
+}
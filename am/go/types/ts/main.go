@@ -11,6 +11,7 @@ import (
 	"go.skia.org/infra/am/go/incident"
 	"go.skia.org/infra/am/go/note"
 	"go.skia.org/infra/am/go/silence"
+	"go.skia.org/infra/am/go/suggest"
 	"go.skia.org/infra/am/go/types"
 	"go.skia.org/infra/go/go2ts"
 	"go.skia.org/infra/go/paramtools"
@@ -35,6 +36,7 @@ func main() {
 		types.IncidentsResponse{},
 		types.IncidentsInRangeRequest{},
 		types.AuditLog{},
+		suggest.Suggestion{},
 	)
 
 	err := util.WithWriteFile(*outputPath, func(w io.Writer) error {
@@ -68,6 +68,8 @@ class State {
   tab: number = 0; // The selected tab.
 
   alert_id: string = ''; // The selected alert (if any).
+
+  silence_alert_id: string = ''; // The alert to prefill a new silence for (if any).
 }
 
 // This response structure comes from chrome-ops-rotation-proxy.appspot.com.
@@ -135,6 +137,7 @@ export class AlertManagerSk extends HTMLElement {
   private state: State = {
     tab: 0,
     alert_id: '',
+    silence_alert_id: '',
   };
 
   private favicon: HTMLAnchorElement | null = null;
@@ -933,6 +936,10 @@ export class AlertManagerSk extends HTMLElement {
         this.incidents = json.incidents || [];
         this.incidentsToRecentlyExpired =
           json.ids_to_recently_expired_silences || {};
+        if (this.state.silence_alert_id) {
+          return this.prefillSilence(this.state.silence_alert_id);
+        }
+        return Promise.resolve();
       });
 
     const silences = fetch('/_/silences', {
@@ -1033,6 +1040,26 @@ export class AlertManagerSk extends HTMLElement {
 
   // Update the paramset for a silence as Incidents are checked and unchecked.
   // TODO(jcgregorio) Remove this once checkbox-sk is fixed.
+  // Starts a new silence prefilled with the params of the incident with the
+  // given alert id, eg. when following a "create silence" link from a
+  // reminder email.
+  private async prefillSilence(alertId: string): Promise<void> {
+    this.state.silence_alert_id = '';
+    this.stateHasChanged();
+    const incident = this.incidents.find((i: Incident) => i.id === alertId);
+    if (!incident) {
+      errorMessage(`Alert ${alertId} is no longer active.`);
+      return;
+    }
+    const resp = await fetch('/_/new_silence', {
+      credentials: 'include',
+    });
+    this.selected = null;
+    this.checked.clear();
+    this.current_silence = await jsonOrThrow(resp);
+    this.check_selected_impl(incident.key, true);
+  }
+
   private check_selected_impl(key: string, isChecked: boolean): void {
     if (isChecked) {
       this.last_checked_incident = key;
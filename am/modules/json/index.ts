@@ -60,6 +60,11 @@ export interface AuditLog {
 	timestamp: number;
 }
 
+export interface Suggestion {
+	owner: string;
+	count: number;
+}
+
 export type Params = { [key: string]: string };
 
 export type ParamSet = { [key: string]: string[] };
@@ -6,6 +6,8 @@ import (
 	"flag"
 	"fmt"
 	"io"
+	"net"
+	"net/http"
 	"os"
 	"os/user"
 	"path/filepath"
@@ -18,6 +20,7 @@ import (
 	"cloud.google.com/go/storage"
 	"github.com/go-chi/chi/v5"
 	"go.skia.org/infra/autoroll/go/codereview"
+	"go.skia.org/infra/autoroll/go/commit_msg"
 	"go.skia.org/infra/autoroll/go/config"
 	"go.skia.org/infra/autoroll/go/config/conversion"
 	"go.skia.org/infra/autoroll/go/config/db"
@@ -26,6 +29,8 @@ import (
 	"go.skia.org/infra/autoroll/go/roller"
 	"go.skia.org/infra/autoroll/go/status"
 	"go.skia.org/infra/email/go/emailclient"
+	"go.skia.org/infra/go/allowed"
+	"go.skia.org/infra/go/alogin/proxylogin"
 	"go.skia.org/infra/go/auth"
 	"go.skia.org/infra/go/chatbot"
 	"go.skia.org/infra/go/common"
@@ -41,6 +46,7 @@ import (
 	"go.skia.org/infra/go/github"
 	"go.skia.org/infra/go/httputils"
 	"go.skia.org/infra/go/secret"
+	"go.skia.org/infra/go/skerr"
 	"go.skia.org/infra/go/sklog"
 	"go.skia.org/infra/go/util"
 	"golang.org/x/oauth2"
@@ -69,6 +75,8 @@ var hangOptions = []HangOption{hangNone, hangImmediately, hangBeforeRollerCreati
 
 // flags
 var (
+	adminGroup             = flag.String("admin_group", "google/skia-root@google.com", "The Chrome Infra Auth group whose members may use the admin handlers.")
+	adminPort              = flag.String("admin_port", "", "If set, serve the admin handlers, which expose repo manager operations, on this port of localhost, eg. ':8001'. Use 'kubectl port-forward' to reach them.")
 	configContents         = flag.String("config", "", "Base 64 encoded configuration in JSON format, mutually exclusive with --config_file.")
 	configFile             = flag.String("config_file", "", "Configuration file to use, mutually exclusive with --config.")
 	firestoreInstance      = flag.String("firestore_instance", "", "Firestore instance to use, eg. \"production\"")
@@ -101,6 +109,19 @@ func clientConfig(ts oauth2.TokenSource) httputils.ClientConfig {
 	return c
 }
 
+// adminListenAddr returns the localhost address on which to serve the admin
+// handlers, given the value of --admin_port.
+func adminListenAddr(adminPort string) (string, error) {
+	host, port, err := net.SplitHostPort(adminPort)
+	if err != nil {
+		return "", skerr.Wrapf(err, "invalid --admin_port %q", adminPort)
+	}
+	if host != "" && host != "localhost" && !net.ParseIP(host).IsLoopback() {
+		return "", skerr.Fmt("--admin_port %q must not specify a non-loopback host; the admin handlers are only served on localhost", adminPort)
+	}
+	return net.JoinHostPort("localhost", port), nil
+}
+
 func main() {
 	common.InitWithMust(
 		"autoroll-be",
@@ -136,6 +157,9 @@ func main() {
 	if err := cfg.Validate(); err != nil {
 		sklog.Fatal(err)
 	}
+	if err := commit_msg.ValidateTemplate(cfg.CommitMsg); err != nil {
+		sklog.Fatal(err)
+	}
 	if *validateConfig {
 		return
 	}
@@ -356,6 +380,32 @@ func main() {
 		httputils.RunHealthCheckServer(*port)
 	}
 
+	// Serve the admin handlers, if requested. The login trusts the headers set
+	// by the auth proxy, which does not sit in front of the admin port, so
+	// the handlers are only served on localhost.
+	if *adminPort != "" {
+		adminAddr, err := adminListenAddr(*adminPort)
+		if err != nil {
+			sklog.Fatal(err)
+		}
+		var allow allowed.Allow
+		if *local {
+			allow = allowed.NewAllowedFromList([]string{allowed.AnyDomain})
+		} else {
+			criaAllow, err := allowed.NewAllowedFromChromeInfraAuth(client, *adminGroup)
+			if err != nil {
+				sklog.Fatal(err)
+			}
+			allow = criaAllow
+		}
+		r := chi.NewRouter()
+		r.Use(roller.AdminMiddleware(proxylogin.NewWithDefaults(), allow))
+		arb.AddHandlers(r)
+		go func() {
+			sklog.Fatal(http.ListenAndServe(adminAddr, httputils.LoggingRequestResponse(r)))
+		}()
+	}
+
 	// Start the roller.
 	arb.Start(ctx, time.Minute /* tickFrequency */)
 
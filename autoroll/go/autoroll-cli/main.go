@@ -0,0 +1,235 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+	"go.skia.org/infra/autoroll/go/codereview"
+	"go.skia.org/infra/autoroll/go/commit_msg"
+	"go.skia.org/infra/autoroll/go/config"
+	"go.skia.org/infra/autoroll/go/config_vars"
+	"go.skia.org/infra/autoroll/go/repo_manager"
+	"go.skia.org/infra/autoroll/go/revision"
+	"go.skia.org/infra/autoroll/go/roller"
+	"go.skia.org/infra/autoroll/go/strategy"
+	"go.skia.org/infra/go/auth"
+	"go.skia.org/infra/go/chrome_branch"
+	"go.skia.org/infra/go/gerrit"
+	"go.skia.org/infra/go/github"
+	"go.skia.org/infra/go/httputils"
+	"go.skia.org/infra/go/repo_root"
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/go/sklog"
+	"go.skia.org/infra/go/util"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+	"google.golang.org/protobuf/encoding/prototext"
+)
+
+func main() {
+	const (
+		flagConfig    = "config"
+		flagOut       = "out"
+		flagServerURL = "server-url"
+		flagTo        = "to"
+		flagWorkdir   = "workdir"
+	)
+	app := &cli.App{
+		Name:        "autoroll-cli",
+		Description: `autoroll-cli provides tools for working with autoroller configs.`,
+		Commands: []*cli.Command{
+			{
+				Name:        "preview",
+				Description: "Create a roll locally, exactly as the roller would, and write the resulting changes and commit message as a patch instead of uploading a CL.",
+				Usage:       "preview <options>",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     flagConfig,
+						Usage:    "Roller config file.",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:  flagOut,
+						Usage: "File to which the patch is written. If not set, the patch is written to stdout.",
+					},
+					&cli.StringFlag{
+						Name:  flagServerURL,
+						Usage: "Server URL used in the commit message. If not set, it is derived from the roller name.",
+					},
+					&cli.StringFlag{
+						Name:  flagTo,
+						Usage: "Revision ID to roll to. If not set, the revision is chosen using the roller's default strategy.",
+					},
+					&cli.StringFlag{
+						Name:  flagWorkdir,
+						Usage: "Working directory. If not set, a temporary directory is used.",
+					},
+				},
+				Action: func(ctx *cli.Context) error {
+					return preview(ctx.Context, ctx.String(flagConfig), ctx.String(flagWorkdir), ctx.String(flagServerURL), ctx.String(flagTo), ctx.String(flagOut))
+				},
+			},
+		},
+		Usage: "autoroll-cli <subcommand>",
+	}
+	if err := app.RunContext(context.Background(), os.Args); err != nil {
+		sklog.Fatal(err)
+	}
+}
+
+// preview creates a roll for the given roller config and writes it to the
+// given output file, or stdout if none is provided.
+func preview(ctx context.Context, configFile, workdir, serverURL, toID, outFile string) error {
+	// Read the roller config file.
+	cfgBytes, err := os.ReadFile(configFile)
+	if err != nil {
+		return skerr.Wrapf(err, "failed to read %s", configFile)
+	}
+	var cfg config.Config
+	if err := prototext.Unmarshal(cfgBytes, &cfg); err != nil {
+		return skerr.Wrapf(err, "failed to decode config")
+	}
+	if serverURL == "" {
+		serverURL = fmt.Sprintf("https://autoroll.skia.org/r/%s", cfg.RollerName)
+	}
+	if workdir == "" {
+		wd, err := os.MkdirTemp("", "autoroll-cli")
+		if err != nil {
+			return skerr.Wrap(err)
+		}
+		defer util.RemoveAll(wd)
+		workdir = wd
+	}
+
+	ts, err := google.DefaultTokenSource(ctx, auth.ScopeUserinfoEmail, auth.ScopeGerrit)
+	if err != nil {
+		return skerr.Wrap(err)
+	}
+	client := httputils.DefaultClientConfig().WithTokenSource(ts).With2xxOnly().Client()
+	cr, err := makeCodeReview(ctx, &cfg, client)
+	if err != nil {
+		return skerr.Wrap(err)
+	}
+	reg, err := config_vars.NewRegistry(ctx, chrome_branch.NewClient(client))
+	if err != nil {
+		return skerr.Wrapf(err, "failed to create config var registry")
+	}
+	repoRoot, err := repo_root.Get()
+	if err != nil {
+		return skerr.Wrap(err)
+	}
+	recipesCfgFile := filepath.Join(repoRoot, "infra", "config", "recipes.cfg")
+
+	// Create the RepoManager and find the revisions to roll.
+	rmCfg := cfg.GetRepoManagerConfig()
+	rm, err := repo_manager.New(ctx, rmCfg, reg, workdir, cfg.RollerName, recipesCfgFile, serverURL, cfg.ServiceAccount, client, cr, cfg.IsInternal, true)
+	if err != nil {
+		return skerr.Wrap(err)
+	}
+	previewer, ok := rm.(repo_manager.RollPreviewer)
+	if !ok {
+		return skerr.Fmt("%T does not support previewing rolls", rm)
+	}
+	from, tip, notRolled, err := rm.Update(ctx)
+	if err != nil {
+		return skerr.Wrap(err)
+	}
+	to, err := getRollTarget(ctx, rm, rmCfg.DefaultStrategy(), toID, tip, notRolled)
+	if err != nil {
+		return skerr.Wrap(err)
+	}
+	if to.Id == from.Id {
+		return skerr.Fmt("%s is already rolled", from)
+	}
+	var revs []*revision.Revision
+	found := false
+	for _, rev := range notRolled {
+		if rev.Id == to.Id {
+			found = true
+		}
+		if found {
+			revs = append(revs, rev)
+		}
+	}
+	if !found {
+		revs, err = rm.LogRevisions(ctx, from, to)
+		if err != nil {
+			return skerr.Wrap(err)
+		}
+	}
+
+	// Build the commit message.
+	b, err := commit_msg.NewBuilder(cfg.CommitMsg, reg, cfg.ChildDisplayName, cfg.ParentDisplayName, serverURL, cfg.ChildBugLink, cfg.ParentBugLink, cfg.TransitiveDeps)
+	if err != nil {
+		return skerr.Wrapf(err, "failed to create commit message builder")
+	}
+	reviewers := roller.GetReviewers(client, cfg.RollerName, cfg.Reviewer, cfg.ReviewerBackup)
+	commitMsg, err := b.Build(from, to, revs, reviewers, cfg.Contacts, false, "")
+	if err != nil {
+		return skerr.Wrapf(err, "failed to build commit message")
+	}
+
+	// Create the roll.
+	if outFile == "" {
+		return skerr.Wrap(previewer.PreviewRoll(ctx, from, to, revs, commitMsg, os.Stdout))
+	}
+	return skerr.Wrap(util.WithWriteFile(outFile, func(w io.Writer) error {
+		return previewer.PreviewRoll(ctx, from, to, revs, commitMsg, w)
+	}))
+}
+
+// getRollTarget returns the Revision with the given ID, or, if no ID is
+// provided, the Revision chosen by the given strategy.
+func getRollTarget(ctx context.Context, rm repo_manager.RepoManager, strategyName, toID string, tip *revision.Revision, notRolled []*revision.Revision) (*revision.Revision, error) {
+	if toID != "" {
+		return rm.GetRevision(ctx, toID)
+	}
+	strat, err := strategy.GetNextRollStrategy(strategyName)
+	if err != nil {
+		return nil, skerr.Wrap(err)
+	}
+	to := strat.GetNextRollRev(notRolled)
+	if to == nil {
+		return nil, skerr.Fmt("no valid revision to roll; tip-of-tree is %s", tip)
+	}
+	return to, nil
+}
+
+// makeCodeReview returns a CodeReview instance for the given config.
+func makeCodeReview(ctx context.Context, cfg *config.Config, client *http.Client) (codereview.CodeReview, error) {
+	if gc := cfg.GetGerrit(); gc != nil {
+		gerritClient, err := gerrit.NewGerritWithConfig(codereview.GerritConfigs[gc.Config], gc.Url, client)
+		if err != nil {
+			return nil, skerr.Wrapf(err, "failed to create Gerrit client")
+		}
+		if len(cfg.GetAndroidRepoManager().GetTopicProject()) > 0 || gc.GetMaxFilesPerChange() > 0 {
+			return codereview.NewGerritWithTopicChanges(gc, gerritClient, client)
+		}
+		return codereview.NewGerrit(gc, gerritClient, client)
+	} else if gc := cfg.GetGithub(); gc != nil {
+		usr, err := user.Current()
+		if err != nil {
+			return nil, skerr.Wrap(err)
+		}
+		pathToGithubToken := filepath.Join(usr.HomeDir, github.GITHUB_TOKEN_FILENAME)
+		gBody, err := os.ReadFile(pathToGithubToken)
+		if err != nil {
+			return nil, skerr.Wrapf(err, "couldn't find GitHub token in %s", pathToGithubToken)
+		}
+		gToken := strings.TrimSpace(string(gBody))
+		githubHttpClient := oauth2.NewClient(ctx, oauth2.StaticTokenSource(&oauth2.Token{AccessToken: gToken}))
+		githubClient, err := github.NewGitHub(ctx, gc.RepoOwner, gc.RepoName, githubHttpClient)
+		if err != nil {
+			return nil, skerr.Wrapf(err, "failed to create GitHub client")
+		}
+		return codereview.NewGitHub(gc, githubClient)
+	}
+	return nil, skerr.Fmt("either Gerrit or GitHub is required")
+}
@@ -13,6 +13,7 @@ import (
 	"go.skia.org/infra/go/gerrit"
 	"go.skia.org/infra/go/github"
 	"go.skia.org/infra/go/gitiles"
+	"go.skia.org/infra/go/skerr"
 )
 
 // CodeReview outlines the autoroller's interaction with a code review system.
@@ -25,6 +26,10 @@ type CodeReview interface {
 	// the user.
 	GetFullHistoryUrl() string
 
+	// InitRoll performs any setup needed for a newly-uploaded roll, such as
+	// applying the configured hashtags.
+	InitRoll(ctx context.Context, issue int64) error
+
 	// RetrieveRoll retrieves a RollImpl corresponding to the given issue.
 	// The passed-in AutoRollIssue becomes owned by the RollImpl; it may
 	// modify it, insert it into the RecentRolls DB, etc.
@@ -52,10 +57,29 @@ type gerritCodeReview struct {
 	issueUrlBase   string
 	userEmail      string
 	userName       string
+
+	// trackTopicChanges indicates whether the other changes which share the
+	// Gerrit topic of a roll CL are considered part of the roll.
+	trackTopicChanges bool
 }
 
 // NewGerrit returns a gerritCodeReview instance.
 func NewGerrit(cfg *config.GerritConfig, gerritClient gerrit.GerritInterface, client *http.Client) (CodeReview, error) {
+	return newGerrit(cfg, gerritClient, client, false)
+}
+
+// NewGerritWithTopicChanges returns a gerritCodeReview instance which
+// considers the other open changes which share the Gerrit topic of a roll CL,
+// eg. the CLs created for the topic projects of an Android roll or the chain of
+// CLs of a split roll, to be part of the roll: closing the roll abandons all of
+// them, and, unless they are submitted together with the roll CL as for a split
+// roll, the roll succeeds only if all of them succeed.
+func NewGerritWithTopicChanges(cfg *config.GerritConfig, gerritClient gerrit.GerritInterface, client *http.Client) (CodeReview, error) {
+	return newGerrit(cfg, gerritClient, client, true)
+}
+
+// newGerrit returns a gerritCodeReview instance.
+func newGerrit(cfg *config.GerritConfig, gerritClient gerrit.GerritInterface, client *http.Client, trackTopicChanges bool) (CodeReview, error) {
 	userEmail, err := gerritClient.GetUserEmail(context.TODO())
 	if err != nil {
 		return nil, err
@@ -69,6 +93,8 @@ func NewGerrit(cfg *config.GerritConfig, gerritClient gerrit.GerritInterface, cl
 		issueUrlBase:   cfg.Url + "/c/",
 		userEmail:      userEmail,
 		userName:       userName,
+
+		trackTopicChanges: trackTopicChanges,
 	}, nil
 }
 
@@ -82,9 +108,21 @@ func (c *gerritCodeReview) GetFullHistoryUrl() string {
 	return c.fullHistoryUrl
 }
 
+// InitRoll implements CodeReview.
+func (c *gerritCodeReview) InitRoll(ctx context.Context, issue int64) error {
+	if len(c.cfg.Hashtags) == 0 {
+		return nil
+	}
+	ci, err := c.gerritClient.GetIssueProperties(ctx, issue)
+	if err != nil {
+		return skerr.Wrap(err)
+	}
+	return skerr.Wrap(c.gerritClient.SetHashtags(ctx, ci, c.cfg.Hashtags, nil))
+}
+
 // RetrieveRoll implements CodeReview.
 func (c *gerritCodeReview) RetrieveRoll(ctx context.Context, issue *autoroll.AutoRollIssue, recent *recent_rolls.RecentRolls, rollingFrom *revision.Revision, rollingTo *revision.Revision, finishedCallback func(context.Context, RollImpl) error) (RollImpl, error) {
-	return newGerritRoll(ctx, c.cfg, issue, c.gerritClient, c.client, recent, c.issueUrlBase, rollingFrom, rollingTo, finishedCallback)
+	return newGerritRoll(ctx, c.cfg, issue, c.gerritClient, c.client, recent, c.issueUrlBase, rollingFrom, rollingTo, c.trackTopicChanges, finishedCallback)
 }
 
 // UserEmail implements CodeReview.
@@ -146,6 +184,11 @@ func (c *githubCodeReview) GetFullHistoryUrl() string {
 	return c.fullHistoryUrl
 }
 
+// InitRoll implements CodeReview.
+func (c *githubCodeReview) InitRoll(_ context.Context, _ int64) error {
+	return nil
+}
+
 // RetrieveRoll implements CodeReview.
 func (c *githubCodeReview) RetrieveRoll(ctx context.Context, issue *autoroll.AutoRollIssue, recent *recent_rolls.RecentRolls, rollingFrom, rollingTo *revision.Revision, finishedCallback func(context.Context, RollImpl) error) (RollImpl, error) {
 	return newGithubRoll(ctx, issue, c.githubClient, recent, c.issueUrlBase, c.cfg, rollingFrom, rollingTo, finishedCallback)
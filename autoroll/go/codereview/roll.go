@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"sort"
 	"strings"
 	"time"
 
@@ -28,6 +29,10 @@ const (
 	// GitHubPRDurationForChecks is the duration after a PR is created that
 	// checks should be looked at.
 	GitHubPRDurationForChecks = time.Minute * 15
+
+	// maxTopicChanges is the maximum number of changes which share a Gerrit
+	// topic with a roll CL that we'll consider part of the roll.
+	maxTopicChanges = 20
 )
 
 var (
@@ -64,8 +69,10 @@ type RollImpl interface {
 }
 
 // updateIssueFromGerrit loads details about the issue from the Gerrit API and
-// updates the AutoRollIssue accordingly.
-func updateIssueFromGerrit(ctx context.Context, cfg *config.GerritConfig, a *autoroll.AutoRollIssue, g gerrit.GerritInterface) (*gerrit.ChangeInfo, error) {
+// updates the AutoRollIssue accordingly. If trackTopicChanges is true, the
+// state of the other changes which share the topic of the issue is taken into
+// account as well.
+func updateIssueFromGerrit(ctx context.Context, cfg *config.GerritConfig, a *autoroll.AutoRollIssue, g gerrit.GerritInterface, trackTopicChanges bool) (*gerrit.ChangeInfo, error) {
 	info, err := g.GetIssueProperties(ctx, a.Issue)
 	if err != nil {
 		return nil, fmt.Errorf("Failed to get issue properties: %s", err)
@@ -96,9 +103,94 @@ func updateIssueFromGerrit(ctx context.Context, cfg *config.GerritConfig, a *aut
 	if err := updateIssueFromGerritChangeInfo(a, info, g.Config()); err != nil {
 		return nil, fmt.Errorf("Failed to convert issue format: %s", err)
 	}
+	// The other changes in the chain of a split roll are submitted together
+	// with the roll CL at its tip, so the state of the roll CL reflects that
+	// of the whole chain.
+	if !trackTopicChanges || cfg.GetMaxFilesPerChange() > 0 {
+		return info, nil
+	}
+	topicChanges, err := getTopicChanges(ctx, info, g)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to retrieve changes in topic %q: %s", info.Topic, err)
+	}
+	if err := updateIssueFromGerritTopicChanges(a, topicChanges, g.Config()); err != nil {
+		return nil, fmt.Errorf("Failed to update issue from topic %q: %s", info.Topic, err)
+	}
 	return info, nil
 }
 
+// getTopicChanges returns the other open changes owned by the same user which
+// share the topic of the given change. These are submitted together with the
+// given change, eg. companion changes to other projects for an Android roll.
+// Closed changes are excluded, since a retried roll of the same revision reuses
+// the topic, which is then shared by the changes of the earlier roll as well.
+func getTopicChanges(ctx context.Context, ci *gerrit.ChangeInfo, g gerrit.GerritInterface) ([]*gerrit.ChangeInfo, error) {
+	if ci.Topic == "" || ci.Owner == nil || ci.Owner.Email == "" {
+		return nil, nil
+	}
+	results, err := g.Search(ctx, maxTopicChanges, false, gerrit.SearchTopic(ci.Topic), gerrit.SearchOwner(ci.Owner.Email), gerrit.SearchStatus(gerrit.ChangeStatusOpen))
+	if err != nil {
+		return nil, skerr.Wrap(err)
+	}
+	var rv []*gerrit.ChangeInfo
+	for _, result := range results {
+		if result.Issue == ci.Issue {
+			continue
+		}
+		// Search results don't include the detailed labels needed to
+		// determine the CQ state.
+		other, err := g.GetIssueProperties(ctx, result.Issue)
+		if err != nil {
+			return nil, skerr.Wrap(err)
+		}
+		rv = append(rv, other)
+	}
+	return rv, nil
+}
+
+// updateIssueFromGerritTopicChanges updates the AutoRollIssue to reflect the
+// combined state of the roll CL and the other changes which share its topic:
+// the roll succeeds only if all of the changes succeed, and it is finished as
+// soon as any of them fails. Once the roll CL itself is closed, its own state
+// is final.
+func updateIssueFromGerritTopicChanges(i *autoroll.AutoRollIssue, topicChanges []*gerrit.ChangeInfo, gc *gerrit.Config) error {
+	if len(topicChanges) == 0 || i.Closed {
+		return nil
+	}
+	finished := i.CqFinished
+	success := i.CqSuccess
+	if i.IsDryRun {
+		finished = i.DryRunFinished
+		success = i.DryRunSuccess
+	}
+	anyFailed := finished && !success
+	for _, ci := range topicChanges {
+		var changeFinished, changeSuccess bool
+		if i.IsDryRun {
+			changeFinished = !gc.DryRunRunning(ci)
+			changeSuccess = gc.DryRunSuccess(ci, false)
+		} else {
+			changeFinished = !gc.CqRunning(ci)
+			changeSuccess = gc.CqSuccess(ci)
+		}
+		finished = finished && changeFinished
+		success = success && changeSuccess
+		if changeFinished && !changeSuccess {
+			anyFailed = true
+		}
+	}
+	finished = finished || anyFailed
+	if i.IsDryRun {
+		i.DryRunFinished = finished
+		i.DryRunSuccess = success
+	} else {
+		i.CqFinished = finished
+		i.CqSuccess = success
+	}
+	i.Result = autoroll.RollResult(i)
+	return i.Validate()
+}
+
 // updateIssueFromGerritChangeInfo updates the AutoRollIssue instance based on
 // the given gerrit.ChangeInfo.
 func updateIssueFromGerritChangeInfo(i *autoroll.AutoRollIssue, ci *gerrit.ChangeInfo, gc *gerrit.Config) error {
@@ -131,6 +223,7 @@ func updateIssueFromGerritChangeInfo(i *autoroll.AutoRollIssue, ci *gerrit.Chang
 
 // gerritRoll is an implementation of RollImpl.
 type gerritRoll struct {
+	cfg              *config.GerritConfig
 	ci               *gerrit.ChangeInfo
 	issue            *autoroll.AutoRollIssue
 	issueUrl         string
@@ -142,17 +235,21 @@ type gerritRoll struct {
 	result           string
 	rollingFrom      *revision.Revision
 	rollingTo        *revision.Revision
+	// trackTopicChanges indicates whether the other changes which share the
+	// topic of the roll CL are part of the roll.
+	trackTopicChanges bool
 }
 
 // newGerritRoll obtains a gerritRoll instance from the given Gerrit issue
 // number.
-func newGerritRoll(ctx context.Context, cfg *config.GerritConfig, issue *autoroll.AutoRollIssue, g gerrit.GerritInterface, client *http.Client, recent *recent_rolls.RecentRolls, issueUrlBase string, rollingFrom, rollingTo *revision.Revision, cb func(context.Context, RollImpl) error) (RollImpl, error) {
-	ci, err := updateIssueFromGerrit(ctx, cfg, issue, g)
+func newGerritRoll(ctx context.Context, cfg *config.GerritConfig, issue *autoroll.AutoRollIssue, g gerrit.GerritInterface, client *http.Client, recent *recent_rolls.RecentRolls, issueUrlBase string, rollingFrom, rollingTo *revision.Revision, trackTopicChanges bool, cb func(context.Context, RollImpl) error) (RollImpl, error) {
+	ci, err := updateIssueFromGerrit(ctx, cfg, issue, g, trackTopicChanges)
 	if err != nil {
 		return nil, err
 	}
 	gitiles := gitiles.NewRepo(g.GetRepoUrl()+"/"+ci.Project, client)
 	return &gerritRoll{
+		cfg:              cfg,
 		ci:               ci,
 		issue:            issue,
 		issueUrl:         fmt.Sprintf("%s%d", issueUrlBase, issue.Issue),
@@ -161,10 +258,11 @@ func newGerritRoll(ctx context.Context, cfg *config.GerritConfig, issue *autorol
 		gitiles:          gitiles,
 		recent:           recent,
 		retrieveRoll: func(ctx context.Context) (*gerrit.ChangeInfo, error) {
-			return updateIssueFromGerrit(ctx, cfg, issue, g)
+			return updateIssueFromGerrit(ctx, cfg, issue, g, trackTopicChanges)
 		},
-		rollingFrom: rollingFrom,
-		rollingTo:   rollingTo,
+		rollingFrom:       rollingFrom,
+		rollingTo:         rollingTo,
+		trackTopicChanges: trackTopicChanges,
 	}, nil
 }
 
@@ -202,6 +300,19 @@ func (r *gerritRoll) withModify(ctx context.Context, action string, fn func() er
 func (r *gerritRoll) Close(ctx context.Context, result, msg string) error {
 	sklog.Infof("Closing issue %d (result %q) with message: %s", r.ci.Issue, result, msg)
 	r.result = result
+	if r.trackTopicChanges {
+		// Abandon any other changes in the topic, since they must land
+		// together with the roll CL.
+		topicChanges, err := getTopicChanges(ctx, r.ci, r.g)
+		if err != nil {
+			sklog.Errorf("Failed to retrieve changes in topic %q: %s", r.ci.Topic, err)
+		}
+		for _, ci := range topicChanges {
+			if err := r.g.Abandon(ctx, ci, msg); err != nil {
+				sklog.Errorf("Failed to abandon change %d in topic %q: %s", ci.Issue, r.ci.Topic, err)
+			}
+		}
+	}
 	return r.withModify(ctx, "close the CL", func() error {
 		return r.g.Abandon(ctx, r.ci, msg)
 	})
@@ -347,11 +458,19 @@ func (r *gerritRoll) RetryDryRun(ctx context.Context) error {
 // See documentation for state_machine.RollCLImpl interface.
 func (r *gerritRoll) Update(ctx context.Context) error {
 	alreadyClosed := r.IsClosed()
+	alreadyFailed := r.issue.Failed()
 	ci, err := r.retrieveRoll(ctx)
 	if err != nil {
 		return err
 	}
 	r.ci = ci
+	if r.cfg.GetAttentionOnFailure() && !alreadyFailed && r.issue.Failed() && !r.IsClosed() {
+		// Don't return the error, since the roll has otherwise been updated
+		// successfully.
+		if err := r.requestAttention(ctx); err != nil {
+			sklog.Errorf("Failed to add reviewers to the attention set of %s: %s", r.issueUrl, err)
+		}
+	}
 	if r.result != "" {
 		r.issue.Result = r.result
 	}
@@ -364,6 +483,57 @@ func (r *gerritRoll) Update(ctx context.Context) error {
 	return nil
 }
 
+// requestAttention adds a comment to the CL explaining the failure and adds the
+// CL's reviewers to the attention set.
+func (r *gerritRoll) requestAttention(ctx context.Context) error {
+	var attention []*gerrit.AttentionSetInput
+	for _, reviewer := range r.ci.Reviewers.Reviewer {
+		if reviewer.Email == "" || (r.ci.Owner != nil && reviewer.Email == r.ci.Owner.Email) {
+			continue
+		}
+		attention = append(attention, &gerrit.AttentionSetInput{
+			User:   reviewer.Email,
+			Reason: "The roll failed",
+		})
+	}
+	if len(attention) == 0 {
+		return nil
+	}
+	return r.g.SetReview(ctx, r.ci, failureMessage(r.issue), map[string]int{}, nil, "", nil, "", 0, attention)
+}
+
+// failureMessage returns a comment describing the failure of the given roll.
+func failureMessage(issue *autoroll.AutoRollIssue) string {
+	what := "CQ attempt"
+	if issue.IsDryRun {
+		what = "dry run"
+	}
+	msg := fmt.Sprintf("The %s for this roll failed.", what)
+	if failed := failedTrybots(issue); len(failed) > 0 {
+		msg += "\n\nFailed trybots:\n  " + strings.Join(failed, "\n  ")
+	}
+	return msg
+}
+
+// failedTrybots returns the sorted names of the CQ trybots whose most recent
+// result on the given roll is a failure.
+func failedTrybots(issue *autoroll.AutoRollIssue) []string {
+	bots := map[string]*autoroll.TryResult{}
+	for _, t := range issue.TryResults {
+		if prev, ok := bots[t.Builder]; !ok || prev.Created.Before(t.Created) {
+			bots[t.Builder] = t
+		}
+	}
+	rv := []string{}
+	for name, t := range bots {
+		if t.Category == autoroll.TRYBOT_CATEGORY_CQ && t.Failed() {
+			rv = append(rv, name)
+		}
+	}
+	sort.Strings(rv)
+	return rv
+}
+
 // See documentation for state_machine.RollClImpl interface.
 func (r *gerritRoll) Attempt() int {
 	return r.issue.Attempt
@@ -14,12 +14,14 @@ import (
 	buildbucketpb "go.chromium.org/luci/buildbucket/proto"
 	"go.skia.org/infra/autoroll/go/config"
 	"go.skia.org/infra/autoroll/go/recent_rolls"
+	recent_rolls_mocks "go.skia.org/infra/autoroll/go/recent_rolls/mocks"
 	"go.skia.org/infra/autoroll/go/revision"
 	"go.skia.org/infra/go/autoroll"
 	"go.skia.org/infra/go/deepequal/assertdeep"
 	"go.skia.org/infra/go/ds"
 	"go.skia.org/infra/go/ds/testutil"
 	"go.skia.org/infra/go/gerrit"
+	gerrit_mocks "go.skia.org/infra/go/gerrit/mocks"
 	gerrit_testutils "go.skia.org/infra/go/gerrit/testutils"
 	"go.skia.org/infra/go/github"
 	"go.skia.org/infra/go/mockhttpclient"
@@ -116,7 +118,7 @@ func testGerritRoll(t *testing.T, cfg *config.GerritConfig) {
 	}
 	urlMock := mockhttpclient.NewURLMock()
 	client := urlMock.Client()
-	gr, err := newGerritRoll(ctx, cfg, issue, g.Gerrit, client, recent, "http://issue/", fromRev, toRev, nil)
+	gr, err := newGerritRoll(ctx, cfg, issue, g.Gerrit, client, recent, "http://issue/", fromRev, toRev, false, nil)
 	require.NoError(t, err)
 	require.False(t, issue.IsDryRun)
 	require.False(t, gr.IsFinished())
@@ -227,7 +229,7 @@ func testGerritRoll(t *testing.T, cfg *config.GerritConfig) {
 		}
 		g.MockGetTrybotResults(ci, 1, []*buildbucketpb.Build{tryjob})
 	}
-	gr, err = newGerritRoll(ctx, cfg, issue, g.Gerrit, client, recent, "http://issue/", fromRev, toRev, nil)
+	gr, err = newGerritRoll(ctx, cfg, issue, g.Gerrit, client, recent, "http://issue/", fromRev, toRev, false, nil)
 	require.NoError(t, err)
 	require.True(t, issue.IsDryRun)
 	require.False(t, gr.IsFinished())
@@ -281,7 +283,7 @@ func testGerritRoll(t *testing.T, cfg *config.GerritConfig) {
 	if cfg.CanQueryTrybots() {
 		g.MockGetTrybotResults(ci, 1, nil)
 	}
-	gr, err = newGerritRoll(ctx, cfg, issue, g.Gerrit, client, recent, "http://issue/", fromRev, toRev, nil)
+	gr, err = newGerritRoll(ctx, cfg, issue, g.Gerrit, client, recent, "http://issue/", fromRev, toRev, false, nil)
 	require.NoError(t, err)
 	require.NoError(t, gr.InsertIntoDB(ctx))
 	url, reqBytes := g.MakePostRequest(ci, "Mode was changed to dry run", gc.SetDryRunLabels, nil)
@@ -300,7 +302,7 @@ func testGerritRoll(t *testing.T, cfg *config.GerritConfig) {
 	if cfg.CanQueryTrybots() {
 		g.MockGetTrybotResults(ci, 1, nil)
 	}
-	gr, err = newGerritRoll(ctx, cfg, issue, g.Gerrit, client, recent, "http://issue/", fromRev, toRev, nil)
+	gr, err = newGerritRoll(ctx, cfg, issue, g.Gerrit, client, recent, "http://issue/", fromRev, toRev, false, nil)
 	require.NoError(t, err)
 	require.NoError(t, gr.InsertIntoDB(ctx))
 	url, reqBytes = g.MakePostRequest(ci, "Mode was changed to normal", gc.SetCqLabels, nil)
@@ -319,7 +321,7 @@ func testGerritRoll(t *testing.T, cfg *config.GerritConfig) {
 	if cfg.CanQueryTrybots() {
 		g.MockGetTrybotResults(ci, 1, nil)
 	}
-	gr, err = newGerritRoll(ctx, cfg, issue, g.Gerrit, client, recent, "http://issue/", fromRev, toRev, nil)
+	gr, err = newGerritRoll(ctx, cfg, issue, g.Gerrit, client, recent, "http://issue/", fromRev, toRev, false, nil)
 	require.NoError(t, err)
 	require.NoError(t, gr.InsertIntoDB(ctx))
 	url = fmt.Sprintf("%s/a/changes/%d/abandon", gerrit_testutils.FakeGerritURL, ci.Issue)
@@ -343,7 +345,7 @@ func testGerritRoll(t *testing.T, cfg *config.GerritConfig) {
 	if cfg.CanQueryTrybots() {
 		g.MockGetTrybotResults(ci, 1, nil)
 	}
-	gr, err = newGerritRoll(ctx, cfg, issue, g.Gerrit, client, recent, "http://issue/", fromRev, toRev, nil)
+	gr, err = newGerritRoll(ctx, cfg, issue, g.Gerrit, client, recent, "http://issue/", fromRev, toRev, false, nil)
 	require.NoError(t, err)
 	require.NoError(t, gr.InsertIntoDB(ctx))
 	url = fmt.Sprintf("%s/a/changes/%d/abandon", gerrit_testutils.FakeGerritURL, ci.Issue)
@@ -576,6 +578,226 @@ func TestUpdateFromGerritChangeInfoChromiumNoCQ(t *testing.T) {
 	testUpdateFromGerritChangeInfo(t, gerrit.ConfigChromiumNoCQ)
 }
 
+func TestUpdateIssueFromGerritTopicChanges(t *testing.T) {
+	gc := gerrit.ConfigAndroid
+	inProgress := func() *autoroll.AutoRollIssue {
+		return &autoroll.AutoRollIssue{
+			Issue:  123,
+			Result: autoroll.ROLL_RESULT_IN_PROGRESS,
+		}
+	}
+
+	// No other changes in the topic.
+	a := inProgress()
+	require.NoError(t, updateIssueFromGerritTopicChanges(a, nil, gc))
+	require.Equal(t, inProgress(), a)
+
+	// The other change merged but the roll CL is still in progress.
+	a = inProgress()
+	require.NoError(t, updateIssueFromGerritTopicChanges(a, []*gerrit.ChangeInfo{
+		{Issue: 456, Status: gerrit.ChangeStatusMerged},
+	}, gc))
+	require.Equal(t, inProgress(), a)
+
+	// The other change was abandoned, so the roll has failed.
+	a = inProgress()
+	require.NoError(t, updateIssueFromGerritTopicChanges(a, []*gerrit.ChangeInfo{
+		{Issue: 456, Status: gerrit.ChangeStatusAbandoned},
+	}, gc))
+	require.True(t, a.CqFinished)
+	require.False(t, a.CqSuccess)
+	require.Equal(t, autoroll.ROLL_RESULT_FAILURE, a.Result)
+
+	// Dry run; the other change was abandoned.
+	a = inProgress()
+	a.IsDryRun = true
+	a.Result = autoroll.ROLL_RESULT_DRY_RUN_IN_PROGRESS
+	require.NoError(t, updateIssueFromGerritTopicChanges(a, []*gerrit.ChangeInfo{
+		{Issue: 456, Status: gerrit.ChangeStatusAbandoned},
+	}, gc))
+	require.True(t, a.DryRunFinished)
+	require.False(t, a.DryRunSuccess)
+	require.Equal(t, autoroll.ROLL_RESULT_DRY_RUN_FAILURE, a.Result)
+
+	// The roll CL is closed; its state is final.
+	a = &autoroll.AutoRollIssue{
+		Issue:      123,
+		Closed:     true,
+		Committed:  true,
+		CqFinished: true,
+		CqSuccess:  true,
+		Result:     autoroll.ROLL_RESULT_SUCCESS,
+	}
+	expect := a.Copy()
+	require.NoError(t, updateIssueFromGerritTopicChanges(a, []*gerrit.ChangeInfo{
+		{Issue: 456, Status: gerrit.ChangeStatusNew},
+	}, gc))
+	require.Equal(t, expect, a)
+}
+
+func TestUpdateIssueFromGerrit_TopicChanges(t *testing.T) {
+	ctx := context.Background()
+	cfg := &config.GerritConfig{
+		Url:     "???",
+		Project: "???",
+		Config:  config.GerritConfig_ANDROID,
+	}
+	from := "abcde12345abcde12345abcde12345abcde12345"
+	to := "fghij67890fghij67890fghij67890fghij67890"
+	roll, issue := makeFakeRoll(t, cfg, 123, from, to, false)
+	roll.Topic = "autoroll-" + to
+	topicChange, _ := makeFakeRoll(t, cfg, 456, from, to, false)
+	topicChange.Topic = roll.Topic
+	topicChange.Project = "build/make"
+
+	// Topic changes are not tracked; don't search for them.
+	g := &gerrit_mocks.GerritInterface{}
+	g.On("GetIssueProperties", ctx, int64(123)).Return(roll, nil)
+	g.On("Config").Return(gerrit.ConfigAndroid)
+	_, err := updateIssueFromGerrit(ctx, cfg, issue, g, false)
+	require.NoError(t, err)
+	require.Equal(t, autoroll.ROLL_RESULT_IN_PROGRESS, issue.Result)
+	g.AssertExpectations(t)
+
+	// The roll is a retry of an earlier roll to the same revision, which
+	// shares its topic. Only open changes are considered part of the roll,
+	// so the abandoned CLs of the earlier roll don't cause it to fail.
+	g = &gerrit_mocks.GerritInterface{}
+	g.On("GetIssueProperties", ctx, int64(123)).Return(roll, nil)
+	g.On("Config").Return(gerrit.ConfigAndroid)
+	g.On("Search", ctx, maxTopicChanges, false, gerrit.SearchTopic(roll.Topic), gerrit.SearchOwner(roll.Owner.Email), gerrit.SearchStatus(gerrit.ChangeStatusOpen)).Return([]*gerrit.ChangeInfo{roll, topicChange}, nil)
+	g.On("GetIssueProperties", ctx, int64(456)).Return(topicChange, nil)
+	_, err = updateIssueFromGerrit(ctx, cfg, issue, g, true)
+	require.NoError(t, err)
+	require.False(t, issue.CqFinished)
+	require.Equal(t, autoroll.ROLL_RESULT_IN_PROGRESS, issue.Result)
+	g.AssertExpectations(t)
+
+	// The CQ attempt failed for the open change in the topic.
+	failedTopicChange := *topicChange
+	failedTopicChange.Labels = map[string]*gerrit.LabelEntry{}
+	g = &gerrit_mocks.GerritInterface{}
+	g.On("GetIssueProperties", ctx, int64(123)).Return(roll, nil)
+	g.On("Config").Return(gerrit.ConfigAndroid)
+	g.On("Search", ctx, maxTopicChanges, false, gerrit.SearchTopic(roll.Topic), gerrit.SearchOwner(roll.Owner.Email), gerrit.SearchStatus(gerrit.ChangeStatusOpen)).Return([]*gerrit.ChangeInfo{roll, &failedTopicChange}, nil)
+	g.On("GetIssueProperties", ctx, int64(456)).Return(&failedTopicChange, nil)
+	_, err = updateIssueFromGerrit(ctx, cfg, issue, g, true)
+	require.NoError(t, err)
+	require.True(t, issue.CqFinished)
+	require.False(t, issue.CqSuccess)
+	require.Equal(t, autoroll.ROLL_RESULT_FAILURE, issue.Result)
+	g.AssertExpectations(t)
+}
+
+func TestGerritRoll_Close_AbandonsTopicChanges(t *testing.T) {
+	ctx := context.Background()
+	cfg := &config.GerritConfig{
+		Url:     "???",
+		Project: "???",
+		Config:  config.GerritConfig_ANDROID,
+	}
+	from := "abcde12345abcde12345abcde12345abcde12345"
+	to := "fghij67890fghij67890fghij67890fghij67890"
+	roll, issue := makeFakeRoll(t, cfg, 123, from, to, false)
+	roll.Topic = "autoroll-" + to
+	topicChange, _ := makeFakeRoll(t, cfg, 456, from, to, false)
+	topicChange.Topic = roll.Topic
+	topicChange.Project = "build/make"
+
+	db := &recent_rolls_mocks.DB{}
+	db.On("GetRolls", testutils.AnyContext, "test-roller", "").Return(nil, "", nil)
+	db.On("Put", testutils.AnyContext, "test-roller", issue).Return(nil)
+	recent, err := recent_rolls.NewRecentRolls(ctx, db, "test-roller")
+	require.NoError(t, err)
+
+	g := &gerrit_mocks.GerritInterface{}
+	g.On("Search", ctx, maxTopicChanges, false, gerrit.SearchTopic(roll.Topic), gerrit.SearchOwner(roll.Owner.Email), gerrit.SearchStatus(gerrit.ChangeStatusOpen)).Return([]*gerrit.ChangeInfo{roll, topicChange}, nil)
+	g.On("GetIssueProperties", ctx, int64(456)).Return(topicChange, nil)
+	g.On("Abandon", ctx, topicChange, "close it!").Return(nil)
+	g.On("Abandon", ctx, roll, "close it!").Return(nil)
+	r := &gerritRoll{
+		cfg:    cfg,
+		ci:     roll,
+		g:      g,
+		issue:  issue,
+		recent: recent,
+		retrieveRoll: func(ctx context.Context) (*gerrit.ChangeInfo, error) {
+			issue.Closed = true
+			issue.CqFinished = true
+			return roll, nil
+		},
+		trackTopicChanges: true,
+	}
+	require.NoError(t, r.Close(ctx, autoroll.ROLL_RESULT_FAILURE, "close it!"))
+	require.Equal(t, autoroll.ROLL_RESULT_FAILURE, issue.Result)
+	g.AssertExpectations(t)
+	db.AssertExpectations(t)
+}
+
+func TestGerritRoll_SplitRoll_Close_AbandonsChain(t *testing.T) {
+	ctx := context.Background()
+	cfg := &config.GerritConfig{
+		Url:               "???",
+		Project:           "???",
+		Config:            config.GerritConfig_CHROMIUM,
+		MaxFilesPerChange: 2,
+	}
+	from := "abcde12345abcde12345abcde12345abcde12345"
+	to := "fghij67890fghij67890fghij67890fghij67890"
+	// The roll CL is at the tip of the chain; only it has the CQ labels.
+	roll, issue := makeFakeRoll(t, cfg, 123, from, to, false)
+	roll.Topic = "autoroll_chain_121"
+	var chain []*gerrit.ChangeInfo
+	for _, issueNum := range []int64{121, 122} {
+		ci, _ := makeFakeRoll(t, cfg, issueNum, from, to, false)
+		ci.Labels = map[string]*gerrit.LabelEntry{}
+		ci.Topic = roll.Topic
+		chain = append(chain, ci)
+	}
+
+	// The rest of the chain lands together with the roll CL, so its state
+	// doesn't affect that of the roll.
+	g := &gerrit_mocks.GerritInterface{}
+	g.On("GetIssueProperties", ctx, int64(123)).Return(roll, nil)
+	g.On("GetTrybotResults", ctx, int64(123), int64(1)).Return([]*buildbucketpb.Build{}, nil)
+	g.On("Config").Return(gerrit.ConfigChromium)
+	_, err := updateIssueFromGerrit(ctx, cfg, issue, g, true)
+	require.NoError(t, err)
+	require.False(t, issue.CqFinished)
+	require.Equal(t, autoroll.ROLL_RESULT_IN_PROGRESS, issue.Result)
+	g.AssertExpectations(t)
+
+	// Closing the roll abandons the whole chain.
+	db := &recent_rolls_mocks.DB{}
+	db.On("GetRolls", testutils.AnyContext, "test-roller", "").Return(nil, "", nil)
+	db.On("Put", testutils.AnyContext, "test-roller", issue).Return(nil)
+	recent, err := recent_rolls.NewRecentRolls(ctx, db, "test-roller")
+	require.NoError(t, err)
+	g = &gerrit_mocks.GerritInterface{}
+	g.On("Search", ctx, maxTopicChanges, false, gerrit.SearchTopic(roll.Topic), gerrit.SearchOwner(roll.Owner.Email), gerrit.SearchStatus(gerrit.ChangeStatusOpen)).Return([]*gerrit.ChangeInfo{chain[0], chain[1], roll}, nil)
+	for _, ci := range chain {
+		g.On("GetIssueProperties", ctx, ci.Issue).Return(ci, nil)
+		g.On("Abandon", ctx, ci, "close it!").Return(nil)
+	}
+	g.On("Abandon", ctx, roll, "close it!").Return(nil)
+	r := &gerritRoll{
+		cfg:    cfg,
+		ci:     roll,
+		g:      g,
+		issue:  issue,
+		recent: recent,
+		retrieveRoll: func(ctx context.Context) (*gerrit.ChangeInfo, error) {
+			issue.Closed = true
+			issue.CqFinished = true
+			return roll, nil
+		},
+		trackTopicChanges: true,
+	}
+	require.NoError(t, r.Close(ctx, autoroll.ROLL_RESULT_FAILURE, "close it!"))
+	g.AssertExpectations(t)
+	db.AssertExpectations(t)
+}
+
 func TestUpdateFromGitHubPullRequest(t *testing.T) {
 
 	now := time.Now()
@@ -736,3 +958,98 @@ func TestUpdateFromGitHubPullRequest(t *testing.T) {
 	require.NoError(t, updateIssueFromGitHubPullRequest(a, pr))
 	assertdeep.Equal(t, expect, a)
 }
+
+func TestFailureMessage(t *testing.T) {
+
+	now := time.Now()
+	issue := &autoroll.AutoRollIssue{
+		TryResults: []*autoroll.TryResult{
+			{
+				Builder:  "flaky-bot",
+				Category: autoroll.TRYBOT_CATEGORY_CQ,
+				Created:  now.Add(-time.Hour),
+				Result:   autoroll.TRYBOT_RESULT_FAILURE,
+				Status:   autoroll.TRYBOT_STATUS_COMPLETED,
+			},
+			{
+				Builder:  "flaky-bot",
+				Category: autoroll.TRYBOT_CATEGORY_CQ,
+				Created:  now,
+				Result:   autoroll.TRYBOT_RESULT_SUCCESS,
+				Status:   autoroll.TRYBOT_STATUS_COMPLETED,
+			},
+			{
+				Builder:  "z-bot",
+				Category: autoroll.TRYBOT_CATEGORY_CQ,
+				Created:  now,
+				Result:   autoroll.TRYBOT_RESULT_FAILURE,
+				Status:   autoroll.TRYBOT_STATUS_COMPLETED,
+			},
+			{
+				Builder:  "a-bot",
+				Category: autoroll.TRYBOT_CATEGORY_CQ,
+				Created:  now,
+				Result:   autoroll.TRYBOT_RESULT_FAILURE,
+				Status:   autoroll.TRYBOT_STATUS_COMPLETED,
+			},
+			{
+				Builder:  "non-cq-bot",
+				Category: "other",
+				Created:  now,
+				Result:   autoroll.TRYBOT_RESULT_FAILURE,
+				Status:   autoroll.TRYBOT_STATUS_COMPLETED,
+			},
+		},
+	}
+	require.Equal(t, "The CQ attempt for this roll failed.\n\nFailed trybots:\n  a-bot\n  z-bot", failureMessage(issue))
+
+	issue.IsDryRun = true
+	issue.TryResults = nil
+	require.Equal(t, "The dry run for this roll failed.", failureMessage(issue))
+}
+
+func TestGerritRoll_RequestAttention(t *testing.T) {
+
+	ctx := context.Background()
+	ci := &gerrit.ChangeInfo{
+		Issue: 123,
+		Owner: &gerrit.Person{Email: "roller@example.com"},
+	}
+	ci.Reviewers.Reviewer = []*gerrit.Person{
+		{Email: "roller@example.com"},
+		{Email: "sheriff@example.com"},
+	}
+	g := &gerrit_mocks.GerritInterface{}
+	g.On("SetReview", ctx, ci, "The CQ attempt for this roll failed.", map[string]int{}, []string(nil), gerrit.NotifyOption(""), gerrit.NotifyDetails(nil), "", 0, []*gerrit.AttentionSetInput{
+		{
+			User:   "sheriff@example.com",
+			Reason: "The roll failed",
+		},
+	}).Return(nil)
+	r := &gerritRoll{
+		ci:    ci,
+		g:     g,
+		issue: &autoroll.AutoRollIssue{Issue: 123},
+	}
+	require.NoError(t, r.requestAttention(ctx))
+	g.AssertExpectations(t)
+}
+
+func TestGerritCodeReview_InitRoll(t *testing.T) {
+
+	ctx := context.Background()
+	ci := &gerrit.ChangeInfo{Issue: 123}
+	g := &gerrit_mocks.GerritInterface{}
+	g.On("GetIssueProperties", ctx, int64(123)).Return(ci, nil)
+	g.On("SetHashtags", ctx, ci, []string{"autoroll", "skia"}, []string(nil)).Return(nil)
+	cr := &gerritCodeReview{
+		cfg:          &config.GerritConfig{Hashtags: []string{"autoroll", "skia"}},
+		gerritClient: g,
+	}
+	require.NoError(t, cr.InitRoll(ctx, 123))
+	g.AssertExpectations(t)
+
+	// No hashtags configured; nothing to do.
+	cr.cfg.Hashtags = nil
+	require.NoError(t, cr.InitRoll(ctx, 123))
+}
@@ -86,6 +86,9 @@ func NewBuilder(c *config.CommitMsgConfig, reg *config_vars.Registry, childName,
 			return nil, skerr.Wrap(err)
 		}
 	}
+	if err := ValidateTemplate(c); err != nil {
+		return nil, skerr.Wrap(err)
+	}
 	return &Builder{
 		cfg:            c,
 		childName:      childName,
@@ -99,6 +102,18 @@ func NewBuilder(c *config.CommitMsgConfig, reg *config_vars.Registry, childName,
 	}, nil
 }
 
+// ValidateTemplate returns an error if the commit message template specified by
+// the given CommitMsgConfig cannot be executed. The template is executed using
+// fake data, so that errors in custom templates are found when the config is
+// loaded rather than when the roller first uploads a CL.
+func ValidateTemplate(c *config.CommitMsgConfig) error {
+	from, to, revs, reviewers, contacts, canary, manualRollRequester := FakeCommitMsgInputs()
+	if _, err := buildCommitMsg(c, config_vars.FakeVars(), "fake/child", "fake/parent", "https://fake.server.com", "", "", nil, from, to, revs, reviewers, contacts, canary, manualRollRequester, int(c.WordWrap)); err != nil {
+		return skerr.Wrapf(err, "invalid commit message template")
+	}
+	return nil
+}
+
 // Build a commit message for the given roll.
 func (b *Builder) Build(from, to *revision.Revision, rolling []*revision.Revision, reviewers, contacts []string, canary bool, manualRollRequester string) (string, error) {
 	return buildCommitMsg(b.cfg, b.reg.Vars(), b.childName, b.parentName, b.serverURL, b.childBugLink, b.parentBugLink, b.transitiveDeps, from, to, rolling, reviewers, contacts, canary, manualRollRequester, b.wordWrapChars)
@@ -127,6 +142,23 @@ func buildCommitMsg(c *config.CommitMsgConfig, cv *config_vars.Vars, childName,
 			return "", skerr.Wrap(err)
 		}
 	}
+	return executeCommitMsgTemplate(commitMsgTmpl, vars, wordWrapChars)
+}
+
+// BuildRollback builds a commit message for a roll which moves the Child
+// backwards from a later revision to a previously-rolled revision. Custom
+// commit message templates are not used for rollbacks.
+func (b *Builder) BuildRollback(from, to *revision.Revision, reviewers, contacts []string, manualRollRequester string) (string, error) {
+	vars, err := makeVars(b.cfg, b.reg.Vars(), b.childName, b.parentName, b.serverURL, b.childBugLink, b.parentBugLink, b.transitiveDeps, from, to, nil, reviewers, contacts, manualRollRequester)
+	if err != nil {
+		return "", skerr.Wrap(err)
+	}
+	return executeCommitMsgTemplate(tmplRollback, vars, b.wordWrapChars)
+}
+
+// executeCommitMsgTemplate executes the given commit message template using
+// the given vars and cleans up the result.
+func executeCommitMsgTemplate(commitMsgTmpl *template.Template, vars *commitMsgVars, wordWrapChars int) (string, error) {
 	var buf bytes.Buffer
 	if err := commitMsgTmpl.ExecuteTemplate(&buf, tmplNameCommitMsg, vars); err != nil {
 		return "", skerr.Wrap(err)
@@ -156,12 +188,47 @@ func fixupRevision(rev *revision.Revision) *revision.Revision {
 	return cpy
 }
 
+// makeBugs returns the bugs referenced by the given revisions which belong to
+// the projects configured in the CommitMsgConfig, formatted for use in a "Bug:"
+// footer of the roll.
+func makeBugs(c *config.CommitMsgConfig, revisions []*revision.Revision) []string {
+	projects := c.ExtraBugProjects
+	if c.BugProject != "" {
+		projects = append([]string{c.BugProject}, projects...)
+	}
+	bugMap := map[string]bool{}
+	for _, project := range projects {
+		for _, rev := range revisions {
+			for _, bug := range rev.Bugs[project] {
+				bugMap[revision.FormatBug(project, bug, c.ParentBugProject, c.BugUrls)] = true
+			}
+		}
+	}
+	if len(bugMap) == 0 {
+		return nil
+	}
+	bugs := make([]string, 0, len(bugMap))
+	for bug := range bugMap {
+		bugs = append(bugs, bug)
+	}
+	sort.Strings(bugs)
+	return bugs
+}
+
 // makeVars derives commitMsgVars from the CommitMsgConfig for the given roll.
 func makeVars(c *config.CommitMsgConfig, cv *config_vars.Vars, childName, parentName, serverURL, childBugLink, parentBugLink string, transitiveDeps []*config.TransitiveDepConfig, from, to *revision.Revision, revisions []*revision.Revision, reviewers, contacts []string, manualRollRequester string) (*commitMsgVars, error) {
 	// Create the commitMsgVars object to be used as input to the template.
 	revsCopy := make([]*revision.Revision, 0, len(revisions))
+	var skipped []*revision.Revision
 	for _, rev := range revisions {
-		revsCopy = append(revsCopy, fixupRevision(rev))
+		cpy := fixupRevision(rev)
+		revsCopy = append(revsCopy, cpy)
+		// Revisions which were rejected as roll targets, eg. by a
+		// RevisionFilter, are listed separately so that reviewers know why
+		// the roller didn't stop at them.
+		if cpy.InvalidReason != "" && cpy.Id != to.Id {
+			skipped = append(skipped, cpy)
+		}
 	}
 	vars := &commitMsgVars{
 		CommitMsgConfig:     c,
@@ -178,30 +245,11 @@ func makeVars(c *config.CommitMsgConfig, cv *config_vars.Vars, childName, parent
 		RollingFrom:         fixupRevision(from),
 		RollingTo:           fixupRevision(to),
 		ServerURL:           serverURL,
+		SkippedRevisions:    skipped,
 	}
 
 	// Bugs.
-	vars.Bugs = nil
-	if c.BugProject != "" {
-		// TODO(borenet): Move this to a util.MakeBugLines utility?
-		bugMap := map[string]bool{}
-		for _, rev := range revisions {
-			for _, bug := range rev.Bugs[c.BugProject] {
-				bugMap[bug] = true
-			}
-		}
-		if len(bugMap) > 0 {
-			vars.Bugs = make([]string, 0, len(bugMap))
-			for bug := range bugMap {
-				bugStr := fmt.Sprintf("%s:%s", c.BugProject, bug)
-				if c.BugProject == revision.BugProjectBuganizer {
-					bugStr = fmt.Sprintf("b/%s", bug)
-				}
-				vars.Bugs = append(vars.Bugs, bugStr)
-			}
-			sort.Strings(vars.Bugs)
-		}
-	}
+	vars.Bugs = makeBugs(c, revisions)
 
 	// CqExtraTrybots.
 	if c.CqExtraTrybots != nil {
@@ -315,6 +363,7 @@ type commitMsgVars struct {
 	RollingFrom         *revision.Revision
 	RollingTo           *revision.Revision
 	ServerURL           string
+	SkippedRevisions    []*revision.Revision
 	Tests               []string
 	TransitiveDeps      []*transitiveDepUpdate
 }
@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.skia.org/infra/autoroll/go/config"
 	"go.skia.org/infra/autoroll/go/config_vars"
+	"go.skia.org/infra/autoroll/go/revision"
 	"go.skia.org/infra/go/chrome_branch"
 	"go.skia.org/infra/go/chrome_branch/mocks"
 	"go.skia.org/infra/go/deepequal/assertdeep"
@@ -248,3 +249,48 @@ My-Footer: BlahBlah
 My-Other-Footer: Blah
 `, msg)
 }
+
+func TestMakeBugs(t *testing.T) {
+
+	revs := []*revision.Revision{
+		{
+			Id: "a",
+			Bugs: map[string][]string{
+				"chromium":                   {"123", "456"},
+				"skia":                       {"789"},
+				revision.BugProjectBuganizer: {"321"},
+			},
+		},
+		{
+			Id: "b",
+			Bugs: map[string][]string{
+				"chromium": {"123"},
+				"dawn":     {"654"},
+			},
+		},
+	}
+	test := func(name string, c *config.CommitMsgConfig, expect []string) {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, expect, makeBugs(c, revs))
+		})
+	}
+	test("none", &config.CommitMsgConfig{}, nil)
+	test("bug_project", &config.CommitMsgConfig{
+		BugProject: "chromium",
+	}, []string{"chromium:123", "chromium:456"})
+	test("buganizer", &config.CommitMsgConfig{
+		BugProject: revision.BugProjectBuganizer,
+	}, []string{"b/321"})
+	test("extra_projects", &config.CommitMsgConfig{
+		BugProject:       "chromium",
+		ExtraBugProjects: []string{"skia", revision.BugProjectBuganizer},
+		ParentBugProject: "chromium",
+	}, []string{"123", "456", "b/321", "skia:789"})
+	test("urls", &config.CommitMsgConfig{
+		ExtraBugProjects: []string{"chromium", "dawn", revision.BugProjectBuganizer},
+		BugUrls:          true,
+	}, []string{"b/321", "https://crbug.com/123", "https://crbug.com/456", "https://crbug.com/dawn/654"})
+	test("no_matching_bugs", &config.CommitMsgConfig{
+		BugProject: "angleproject",
+	}, nil)
+}
@@ -56,6 +56,11 @@ var (
 {{ range .Revisions }}{{ .Timestamp.Format "2006-01-02" }} {{ .Author }} {{ .Description }}
 {{ end }}
 {{ end -}}
+{{ if .SkippedRevisions -}}
+Revisions skipped as roll targets:
+{{ range .SkippedRevisions }}  {{ .String }}: {{ .InvalidReason }}
+{{ end }}
+{{ end -}}
 {{ if len .TransitiveDeps -}}
 Also rolling transitive DEPS:
 {{ range .TransitiveDeps }}  {{ .String }}
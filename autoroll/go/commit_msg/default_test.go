@@ -162,6 +162,27 @@ My-Other-Footer: Blah
 `, result)
 }
 
+func TestNamedTemplateDefault_SkippedRevisions(t *testing.T) {
+
+	b := fakeBuilder(t)
+	from, to, revs, emails, canary, contacts, manualRollRequester := FakeCommitMsgInputs()
+	for _, rev := range revs {
+		rev.InvalidReason = "commit message matches \"^Reland\""
+	}
+	result, err := b.Build(from, to, revs, emails, canary, contacts, manualRollRequester)
+	require.NoError(t, err)
+	require.Contains(t, result, `2020-04-17 c@google.com Commit C
+2020-04-16 b@google.com Commit B
+
+Revisions skipped as roll targets:
+  bbbbbbbbbbbb: commit message matches "^Reland"
+
+Also rolling transitive DEPS:
+`)
+	// The roll target is never listed as skipped.
+	require.NotContains(t, result, "  cccccccccccc:")
+}
+
 func TestNamedTemplateDefault_Minimal(t *testing.T) {
 
 	b := fakeBuilder(t)
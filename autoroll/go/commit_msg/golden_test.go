@@ -0,0 +1,58 @@
+package commit_msg
+
+import (
+	"flag"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.skia.org/infra/autoroll/go/config"
+	"go.skia.org/infra/go/testutils"
+)
+
+var updateGoldens = flag.Bool("update-goldens", false, "If true, overwrite the golden files in testdata with the generated commit messages.")
+
+// checkGolden compares the given commit message against the contents of the
+// given golden file in testdata.
+func checkGolden(t *testing.T, name, actual string) {
+	if *updateGoldens {
+		require.NoError(t, os.WriteFile(testutils.TestDataFilename(t, name), []byte(actual), 0644))
+		return
+	}
+	require.Equal(t, testutils.ReadFile(t, name), actual)
+}
+
+func TestBuiltInTemplates_Golden(t *testing.T) {
+
+	for builtIn := range namedCommitMsgTemplates {
+		name := strings.ToLower(builtIn.String())
+		t.Run(name, func(t *testing.T) {
+			b := fakeBuilder(t)
+			b.cfg.BuiltIn = builtIn
+			result, err := b.Build(FakeCommitMsgInputs())
+			require.NoError(t, err)
+			checkGolden(t, name+".txt", result)
+		})
+	}
+}
+
+func TestValidateTemplate(t *testing.T) {
+
+	c := fakeCommitMsgConfig(t)
+	require.NoError(t, ValidateTemplate(c))
+
+	c.Custom = `{{- define "subject" }}Roll {{ .ChildName }} to {{ .RollingTo }}{{ end -}}`
+	require.NoError(t, ValidateTemplate(c))
+
+	// Unknown fields are only detected when the template is executed.
+	c.Custom = `{{- define "subject" }}Roll {{ .NoSuchField }}{{ end -}}`
+	require.ErrorContains(t, ValidateTemplate(c), "invalid commit message template")
+
+	c.Custom = `{{- define "subject" }}Roll {{ .ChildName }{{ end -}}`
+	require.ErrorContains(t, ValidateTemplate(c), "invalid commit message template")
+
+	c.Custom = ""
+	c.BuiltIn = config.CommitMsgConfig_BuiltIn(1000)
+	require.ErrorContains(t, ValidateTemplate(c), "Unknown built-in config")
+}
@@ -0,0 +1,20 @@
+package commit_msg
+
+import "text/template"
+
+var (
+	// tmplRollback is the commit message template used by rolls which move
+	// the Child backwards to a previously-rolled revision.
+	tmplRollback = template.Must(parseCommitMsgTemplate(tmplCommitMsg, "rollback",
+		`{{- define "subject" }}Roll back {{ .ChildName }} from {{ .RollingFrom }} to {{ .RollingTo }}{{ end -}}
+{{- define "manual" }}{{ if .ManualRollRequester }}Rollback requested by {{ .ManualRollRequester }}{{ end }}{{ end -}}
+{{- define "revisions" }}This CL moves {{ .ChildName }} backwards to a previously-rolled
+revision. Stop the roller before landing this CL to prevent it from rolling
+forward again.
+
+{{ if len .TransitiveDeps -}}
+Also rolling back transitive DEPS:
+{{ range .TransitiveDeps }}  {{ .String }}
+{{ end }}
+{{- end }}{{ end -}}`))
+)
@@ -0,0 +1,46 @@
+package commit_msg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildRollback(t *testing.T) {
+
+	b := fakeBuilder(t)
+	from, to, _, reviewers, contacts, _, _ := FakeCommitMsgInputs()
+	result, err := b.BuildRollback(to, from, reviewers, contacts, "sheriff@google.com")
+	require.NoError(t, err)
+	require.Equal(t, `Roll back fake/child/src from cccccccccccc to aaaaaaaaaaaa
+
+Rollback requested by sheriff@google.com
+
+This CL moves fake/child/src backwards to a previously-rolled
+revision. Stop the roller before landing this CL to prevent it from rolling
+forward again.
+
+Also rolling back transitive DEPS:
+  https://fake-dep1/+log/eeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeee..dddddddddddddddddddddddddddddddddddddddd
+  parent/dep3 from cccccccccccc to aaaaaaaaaaaa
+
+If this roll has caused a breakage, revert this CL and stop the roller
+using the controls here:
+https://fake.server.com/r/fake-autoroll
+Please CC contact@google.com,reviewer@google.com on the revert to ensure that a human
+is aware of the problem.
+
+To report a problem with the AutoRoller itself, please file a bug:
+https://issues.skia.org/issues/new?component=1389291&template=1850622
+
+Documentation for the AutoRoller is here:
+https://skia.googlesource.com/buildbot/+doc/main/autoroll/README.md
+
+Cq-Include-Trybots: some-trybot-on-m92
+Cq-Do-Not-Cancel-Tryjobs: true
+Bug: None
+Tbr: reviewer@google.com
+My-Footer: BlahBlah
+My-Other-Footer: Blah
+`, result)
+}
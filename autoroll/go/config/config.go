@@ -8,11 +8,13 @@ package config
 
 import (
 	"fmt"
+	"path"
 	"regexp"
 	"strings"
 
 	"go.skia.org/infra/autoroll/go/strategy"
 	"go.skia.org/infra/autoroll/go/time_window"
+	"go.skia.org/infra/go/human"
 	"go.skia.org/infra/go/skerr"
 	"go.skia.org/infra/go/util"
 )
@@ -78,6 +80,84 @@ func (s PreUploadStep) Validate() error {
 	return nil
 }
 
+// Validate implements util.Validator.
+func (c *PreUploadConfig) Validate() error {
+	if c.MaxParallelism < 0 {
+		return skerr.Fmt("MaxParallelism cannot be negative.")
+	}
+	names := make(map[string]*PreUploadCommandConfig, len(c.Command))
+	for _, cmd := range c.Command {
+		if err := cmd.Validate(); err != nil {
+			return skerr.Wrap(err)
+		}
+		if _, ok := names[cmd.StepName()]; ok {
+			return skerr.Fmt("Duplicate command name %q.", cmd.StepName())
+		}
+		names[cmd.StepName()] = cmd
+	}
+	for _, cmd := range c.Command {
+		for _, dep := range cmd.DependsOn {
+			if _, ok := names[dep]; !ok {
+				return skerr.Fmt("Command %q depends on unknown command %q.", cmd.StepName(), dep)
+			}
+		}
+	}
+	// Check for dependency cycles.
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	state := make(map[string]int, len(names))
+	var visit func(name string) error
+	visit = func(name string) error {
+		switch state[name] {
+		case visiting:
+			return skerr.Fmt("Command %q has a dependency cycle.", name)
+		case visited:
+			return nil
+		}
+		state[name] = visiting
+		for _, dep := range names[name].DependsOn {
+			if err := visit(dep); err != nil {
+				return err
+			}
+		}
+		state[name] = visited
+		return nil
+	}
+	for _, cmd := range c.Command {
+		if err := visit(cmd.StepName()); err != nil {
+			return skerr.Wrap(err)
+		}
+	}
+	return nil
+}
+
+// Validate implements util.Validator.
+func (c *PreUploadCommandConfig) Validate() error {
+	if c.Command == "" {
+		return skerr.Fmt("Command is required.")
+	}
+	if c.Timeout != "" {
+		if _, err := human.ParseDuration(c.Timeout); err != nil {
+			return skerr.Wrapf(err, "invalid Timeout for command %q", c.StepName())
+		}
+	}
+	if c.Retries < 0 {
+		return skerr.Fmt("Retries cannot be negative.")
+	}
+	return nil
+}
+
+// StepName returns the name used to identify the command, which defaults to
+// the command itself.
+func (c *PreUploadCommandConfig) StepName() string {
+	if c.Name != "" {
+		return c.Name
+	}
+	return c.Command
+}
+
 // Validate implements util.Validator.
 func (c CommitMsgConfig_BuiltIn) Validate() error {
 	if _, ok := CommitMsgConfig_BuiltIn_name[int32(c)]; !ok {
@@ -278,9 +358,14 @@ func (c *Config) DefaultStrategy() string {
 
 // Validate implements util.Validator.
 func (c *CommitMsgConfig) Validate() error {
-	// TODO(borenet): The commit_msg package has a test which uses fake data to
-	// execute the template and ensure that it is valid. We should make use of
-	// that somehow.
+	// Note that the commit message template itself is validated by
+	// commit_msg.ValidateTemplate, which cannot be called from here because
+	// the commit_msg package depends on this one.
+	for _, project := range c.ExtraBugProjects {
+		if project == "" {
+			return skerr.Fmt("ExtraBugProjects must not contain empty strings.")
+		}
+	}
 	return nil
 }
 
@@ -295,6 +380,17 @@ func (c *GerritConfig) Validate() error {
 	if _, ok := GerritConfig_Config_name[int32(c.Config)]; !ok {
 		return skerr.Fmt("Unknown config: %v", c.Config)
 	}
+	for _, hashtag := range c.Hashtags {
+		if hashtag == "" || strings.ContainsAny(hashtag, ", \t\n") {
+			return skerr.Fmt("Invalid hashtag: %q", hashtag)
+		}
+	}
+	if c.MaxFilesPerChange < 0 {
+		return skerr.Fmt("MaxFilesPerChange must not be negative.")
+	}
+	if c.SplitByDirectory && c.MaxFilesPerChange == 0 {
+		return skerr.Fmt("SplitByDirectory requires MaxFilesPerChange.")
+	}
 	return nil
 }
 
@@ -500,11 +596,24 @@ func (c *AndroidRepoManagerConfig) Validate() error {
 			return skerr.Wrap(err)
 		}
 	}
+	if c.PreUploadCommands != nil {
+		if err := c.PreUploadCommands.Validate(); err != nil {
+			return skerr.Wrap(err)
+		}
+	}
 	if c.Metadata != nil {
 		if err := c.Metadata.Validate(); err != nil {
 			return skerr.Wrap(err)
 		}
 	}
+	for _, project := range c.TopicProject {
+		if project == "" {
+			return skerr.Fmt("TopicProject cannot be empty.")
+		}
+		if project == c.ChildPath {
+			return skerr.Fmt("TopicProject cannot include ChildPath %q.", c.ChildPath)
+		}
+	}
 	return nil
 }
 
@@ -703,6 +812,11 @@ func (c *ParentChildRepoManagerConfig) Validate() error {
 			return skerr.Wrap(err)
 		}
 	}
+	for _, rf := range c.GetCommitRevisionFilter() {
+		if err := rf.Validate(); err != nil {
+			return skerr.Wrap(err)
+		}
+	}
 	return nil
 }
 
@@ -873,6 +987,11 @@ func (c *GoModParentConfig) Validate() error {
 			return skerr.Wrap(err)
 		}
 	}
+	if c.PreUploadCommands != nil {
+		if err := c.PreUploadCommands.Validate(); err != nil {
+			return skerr.Wrap(err)
+		}
+	}
 	return nil
 }
 
@@ -922,6 +1041,19 @@ func (c *CIPDChildConfig) Validate() error {
 			return skerr.Wrap(err)
 		}
 	}
+	if len(c.Platform) > 0 {
+		if c.RevisionIdTag == "" {
+			return skerr.Fmt("RevisionIdTag is required when Platform is set.")
+		}
+		if c.SourceRepo != nil || c.GitilesRepo != "" {
+			return skerr.Fmt("SourceRepo and GitilesRepo are not supported when Platform is set.")
+		}
+		for _, platform := range c.Platform {
+			if platform == "" {
+				return skerr.Fmt("Platform cannot be empty.")
+			}
+		}
+	}
 	return nil
 }
 
@@ -1024,6 +1156,11 @@ func (c *DEPSLocalParentConfig) Validate() error {
 			return skerr.Wrap(err)
 		}
 	}
+	if c.PreUploadCommands != nil {
+		if err := c.PreUploadCommands.Validate(); err != nil {
+			return skerr.Wrap(err)
+		}
+	}
 	return nil
 }
 
@@ -1074,6 +1211,11 @@ func (c *GitCheckoutGitHubFileParentConfig) Validate() error {
 			return skerr.Wrap(err)
 		}
 	}
+	if c.PreUploadCommands != nil {
+		if err := c.PreUploadCommands.Validate(); err != nil {
+			return skerr.Wrap(err)
+		}
+	}
 	return nil
 }
 
@@ -1119,6 +1261,35 @@ func (c *CIPDRevisionFilterConfig) Validate() error {
 	return nil
 }
 
+// Validate implements util.Validator.
+func (c *CommitRevisionFilterConfig) Validate() error {
+	if len(c.CommitMsgRegex) == 0 && len(c.Author) == 0 && len(c.PathGlob) == 0 {
+		return skerr.Fmt("At least one of CommitMsgRegex, Author, or PathGlob is required.")
+	}
+	for _, r := range c.CommitMsgRegex {
+		if _, err := regexp.Compile(r); err != nil {
+			return skerr.Wrapf(err, "invalid CommitMsgRegex %q", r)
+		}
+	}
+	for _, author := range c.Author {
+		if author == "" {
+			return skerr.Fmt("Author cannot be empty.")
+		}
+	}
+	for _, glob := range c.PathGlob {
+		if glob == "" {
+			return skerr.Fmt("PathGlob cannot be empty.")
+		}
+		if _, err := path.Match(glob, ""); err != nil {
+			return skerr.Wrapf(err, "invalid PathGlob %q", glob)
+		}
+	}
+	if len(c.PathGlob) > 0 && c.RepoUrl == "" {
+		return skerr.Fmt("RepoUrl is required when PathGlob is set.")
+	}
+	return nil
+}
+
 // Validate implements util.Validator.
 func (c *DockerChildConfig) Validate() error {
 	if c.Registry == "" {
@@ -1127,8 +1298,20 @@ func (c *DockerChildConfig) Validate() error {
 	if c.Repository == "" {
 		return skerr.Fmt("Repository is required.")
 	}
-	if c.Tag == "" {
-		return skerr.Fmt("Tag is required.")
+	if c.Tag == "" && c.SemverRegex == "" {
+		return skerr.Fmt("Exactly one of Tag or SemverRegex is required.")
+	}
+	if c.Tag != "" && c.SemverRegex != "" {
+		return skerr.Fmt("Tag and SemverRegex are mutually exclusive.")
+	}
+	if c.SemverRegex != "" {
+		re, err := regexp.Compile(c.SemverRegex)
+		if err != nil {
+			return skerr.Wrapf(err, "SemverRegex is invalid")
+		}
+		if re.NumSubexp() == 0 {
+			return skerr.Fmt("SemverRegex must contain at least one capture group.")
+		}
 	}
 	return nil
 }
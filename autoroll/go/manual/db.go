@@ -119,6 +119,10 @@ type ManualRollRequest struct {
 	// roll. The ID is defined by the repo_manager.
 	// Eg: CL num for Chromium, PR num for Github, Topic name for Android.
 	ExternalChangeId string `json:"external_change_id,omitempty"`
+	// Roll backwards to a previously-rolled revision if this is true. The
+	// roller verifies that Revision was previously rolled before creating
+	// the roll.
+	Rollback bool `json:"rollback"`
 }
 
 // Return a copy of the ManualRollRequest.
@@ -140,6 +144,7 @@ func (r *ManualRollRequest) Copy() *ManualRollRequest {
 		NoResolveRevision: r.NoResolveRevision,
 		Canary:            r.Canary,
 		ExternalChangeId:  r.ExternalChangeId,
+		Rollback:          r.Rollback,
 	}
 }
 
@@ -28,6 +28,7 @@ func req() *ManualRollRequest {
 		Requester:         "user@google.com",
 		Result:            RESULT_FAILURE,
 		ResultDetails:     "blah blah",
+		Rollback:          true,
 		Revision:          "abc123",
 		RollerName:        rollerName,
 		Status:            STATUS_COMPLETE,
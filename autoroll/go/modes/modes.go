@@ -49,14 +49,10 @@ type ModeHistory interface {
 	Update(ctx context.Context) error
 }
 
-// Fake ancestor we supply for all ModeChanges, to force consistency.
+// Entity group of all ModeChanges, to force consistency.
 // We lose some performance this way but it keeps our tests from
 // flaking.
-func fakeAncestor() *datastore.Key {
-	rv := ds.NewKey(ds.KIND_AUTOROLL_MODE_ANCESTOR)
-	rv.ID = 13 // Bogus ID.
-	return rv
-}
+var entityGroup = ds.NewEntityGroup(ds.KIND_AUTOROLL_MODE_ANCESTOR, 13) // Bogus ID.
 
 // ModeChange is a struct used for describing a change in the AutoRoll mode.
 type ModeChange struct {
@@ -117,7 +113,7 @@ func (mh *DatastoreModeHistory) Add(ctx context.Context, mode, user, message str
 // put inserts the ModeChange into the datastore.
 func (mh *DatastoreModeHistory) put(ctx context.Context, m *ModeChange) error {
 	key := ds.NewKey(ds.KIND_AUTOROLL_MODE)
-	key.Parent = fakeAncestor()
+	key.Parent = entityGroup.Ancestor()
 	_, err := ds.DS.RunInTransaction(ctx, func(tx *datastore.Transaction) error {
 		_, err := tx.Put(key, m)
 		return err
@@ -138,7 +134,7 @@ func (mh *DatastoreModeHistory) CurrentMode() *ModeChange {
 
 // GetHistory returns a slice of the most recent ModeChanges, most recent first.
 func (mh *DatastoreModeHistory) GetHistory(ctx context.Context, offset int) ([]*ModeChange, int, error) {
-	query := ds.NewQuery(ds.KIND_AUTOROLL_MODE).Ancestor(fakeAncestor()).Filter("roller =", mh.roller).Order("-time").Limit(ModeHistoryLength).Offset(offset)
+	query := entityGroup.NewQuery(ds.KIND_AUTOROLL_MODE).Filter("roller =", mh.roller).Order("-time").Limit(ModeHistoryLength).Offset(offset)
 	var history []*ModeChange
 	if _, err := ds.DS.GetAll(ctx, query, &history); err != nil {
 		return nil, offset, skerr.Wrap(err)
@@ -152,7 +148,7 @@ func (mh *DatastoreModeHistory) GetHistory(ctx context.Context, offset int) ([]*
 
 // Update refreshes the mode history from the datastore.
 func (mh *DatastoreModeHistory) Update(ctx context.Context) error {
-	query := ds.NewQuery(ds.KIND_AUTOROLL_MODE).Ancestor(fakeAncestor()).Filter("roller =", mh.roller).Order("-time").Limit(1)
+	query := entityGroup.NewQuery(ds.KIND_AUTOROLL_MODE).Filter("roller =", mh.roller).Order("-time").Limit(1)
 	var history []*ModeChange
 	if _, err := ds.DS.GetAll(ctx, query, &history); err != nil {
 		return skerr.Wrap(err)
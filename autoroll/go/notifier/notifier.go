@@ -3,6 +3,7 @@ package notifier
 import (
 	"bytes"
 	"context"
+	"fmt"
 	"html/template"
 	"net/http"
 	"time"
@@ -187,9 +188,20 @@ func New(ctx context.Context, childName, parentName, serverURL string, client *h
 }
 
 func (a *AutoRollNotifier) ReloadConfigs(ctx context.Context, configs []*notifier.Config) error {
-	// Create a new router and add the specified configs to it.
+	for _, c := range configs {
+		if err := c.Validate(); err != nil {
+			return err
+		}
+	}
+	// Combine the configs which filter messages the same way into rules, so
+	// that each message is matched once per rule rather than once per config.
+	rules, err := notifier.ConfigsToRules(configs)
+	if err != nil {
+		return err
+	}
+	// Create a new router and add the rules to it.
 	n := notifier.NewRouter(a.client, a.emailer, a.configReader)
-	if err := n.AddFromConfigs(ctx, configs); err != nil {
+	if err := n.AddFromRuleConfigs(ctx, rules); err != nil {
 		return err
 	}
 	a.n = n
@@ -220,12 +232,18 @@ func (a *AutoRollNotifier) send(ctx context.Context, vars *tmplVars, subjectTmpl
 		sklog.Errorf("Failed to send notification; failed to execute footer template: %s", err)
 		return
 	}
+	// Group the messages about the same roll CL.
+	threadID := ""
+	if vars.IssueID != "" {
+		threadID = fmt.Sprintf("%s into %s roll %s", a.childName, a.parentName, vars.IssueID)
+	}
 	if err := a.n.Send(ctx, &notifier.Message{
 		Subject:         subjectBytes.String(),
 		Body:            bodyBytes.String(),
 		Severity:        severity,
 		Type:            msgType,
 		ExtraRecipients: extraRecipients,
+		ThreadID:        threadID,
 	}); err != nil {
 		// We don't want to block the roller on failure to send
 		// notifications. Log the error and move on.
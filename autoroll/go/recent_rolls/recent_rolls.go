@@ -36,14 +36,10 @@ type DB interface {
 	GetRolls(ctx context.Context, roller string, cursor string) ([]*autoroll.AutoRollIssue, string, error)
 }
 
-// Fake ancestor we supply for all ModeChanges, to force consistency.
+// Entity group of all rolls, to force consistency.
 // We lose some performance this way but it keeps our tests from
 // flaking.
-func fakeAncestor() *datastore.Key {
-	rv := ds.NewKey(ds.KIND_AUTOROLL_ROLL_ANCESTOR)
-	rv.ID = 13 // Bogus ID.
-	return rv
-}
+var entityGroup = ds.NewEntityGroup(ds.KIND_AUTOROLL_ROLL_ANCESTOR, 13) // Bogus ID.
 
 // DsRoll is a struct used for storing autoroll.AutoRollIssue objects in
 // datastore. The AutoRollIssue is gob-serialized before and after inserting
@@ -268,7 +264,7 @@ func NewDatastoreRollsDB(ctx context.Context) *DatastoreRollsDB {
 
 // Get implements RollsDB.
 func (d *DatastoreRollsDB) Get(ctx context.Context, roller string, issue int64) (*autoroll.AutoRollIssue, error) {
-	query := ds.NewQuery(ds.KIND_AUTOROLL_ROLL).Ancestor(fakeAncestor()).Filter("rollerIssue =", fmt.Sprintf("%s_%d", roller, issue))
+	query := entityGroup.NewQuery(ds.KIND_AUTOROLL_ROLL).Filter("rollerIssue =", fmt.Sprintf("%s_%d", roller, issue))
 	var results []*DsRoll
 	if _, err := ds.DS.GetAll(ctx, query, &results); err != nil {
 		return nil, err
@@ -300,7 +296,7 @@ func (d *DatastoreRollsDB) Put(ctx context.Context, roller string, roll *autorol
 	}
 	key := ds.NewKey(ds.KIND_AUTOROLL_ROLL)
 	key.Name = obj.RollerIssue
-	key.Parent = fakeAncestor()
+	key.Parent = entityGroup.Ancestor()
 	_, err := ds.DS.RunInTransaction(ctx, func(tx *datastore.Transaction) error {
 		_, err := tx.Put(key, obj)
 		return err
@@ -313,7 +309,7 @@ func (d *DatastoreRollsDB) Put(ctx context.Context, roller string, roll *autorol
 
 // LoadRolls implements RollsDB.
 func (d *DatastoreRollsDB) GetRolls(ctx context.Context, roller, cursor string) ([]*autoroll.AutoRollIssue, string, error) {
-	query := ds.NewQuery(ds.KIND_AUTOROLL_ROLL).Ancestor(fakeAncestor()).Filter("roller =", roller).Order("-rollerCreated").Limit(loadRollsPageSize)
+	query := entityGroup.NewQuery(ds.KIND_AUTOROLL_ROLL).Filter("roller =", roller).Order("-rollerCreated").Limit(loadRollsPageSize)
 	if cursor != "" {
 		c, err := datastore.DecodeCursor(cursor)
 		if err != nil {
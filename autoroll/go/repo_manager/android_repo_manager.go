@@ -75,12 +75,34 @@ type androidRepoManager struct {
 	childRevLinkTmpl string
 	g                gerrit.GerritInterface
 	httpClient       *http.Client
+	lastUpdate       *androidUpdateState
 	parentBranch     *config_vars.Template
 	preUploadSteps   []parent.PreUploadStep
 	repoMtx          sync.RWMutex
+	topicProjects    []string
 	workdir          string
 }
 
+// androidUpdateState records the results of the most recent successful call to
+// androidRepoManager.Update, along with the commit hash of the parent branch
+// from which they were derived.
+type androidUpdateState struct {
+	parentHash    string
+	lastRollRev   *revision.Revision
+	tipRev        *revision.Revision
+	notRolledRevs []*revision.Revision
+}
+
+// results returns copies of the recorded results, so that callers may not
+// modify the recorded state.
+func (s *androidUpdateState) results() (*revision.Revision, *revision.Revision, []*revision.Revision) {
+	notRolledRevs := make([]*revision.Revision, 0, len(s.notRolledRevs))
+	for _, rev := range s.notRolledRevs {
+		notRolledRevs = append(notRolledRevs, rev.Copy())
+	}
+	return s.lastRollRev.Copy(), s.tipRev.Copy(), notRolledRevs
+}
+
 // NewAndroidRepoManager returns an androidRepoManager instance.
 func NewAndroidRepoManager(ctx context.Context, c *config.AndroidRepoManagerConfig, reg *config_vars.Registry, workdir string, serverURL, serviceAccount string, client *http.Client, cr codereview.CodeReview, isInternal, local bool) (RepoManager, error) {
 	if err := c.Validate(); err != nil {
@@ -190,11 +212,17 @@ func NewAndroidRepoManager(ctx context.Context, c *config.AndroidRepoManagerConf
 		httpClient:       client,
 		parentBranch:     parentBranch,
 		preUploadSteps:   preUploadSteps,
+		topicProjects:    c.TopicProject,
 		workdir:          workdir,
 	}
 	return r, nil
 }
 
+// topicProjectCheckout returns a git.Checkout for the given topic project.
+func (r *androidRepoManager) topicProjectCheckout(project string) *git.Checkout {
+	return &git.Checkout{GitDir: git.GitDir(path.Join(r.workdir, project))}
+}
+
 // GetRevision implements RepoManager.
 func (r *androidRepoManager) GetRevision(ctx context.Context, id string) (*revision.Revision, error) {
 	r.repoMtx.RLock()
@@ -239,9 +267,12 @@ func (r *androidRepoManager) updateAndroidCheckout(ctx context.Context) error {
 		}
 	}
 
-	// Sync only the child path and the repohooks directory (needed to upload changes).
+	// Sync only the child path, any topic projects, and the repohooks
+	// directory (needed to upload changes).
 	const repoHooksDir = "tools/repohooks"
-	syncCmd := []string{"python3", r.repoToolPath, "sync", "--force-sync", r.childPath, repoHooksDir, "-j32"}
+	syncCmd := []string{"python3", r.repoToolPath, "sync", "--force-sync", r.childPath}
+	syncCmd = append(syncCmd, r.topicProjects...)
+	syncCmd = append(syncCmd, repoHooksDir, "-j32")
 	if _, err := exec.RunCwd(ctx, r.workdir, syncCmd...); err != nil {
 		sklog.Warningf("repo sync error: %s", err)
 
@@ -276,14 +307,20 @@ func (r *androidRepoManager) updateAndroidCheckout(ctx context.Context) error {
 		}
 	}
 
-	// Set color.ui=true so that the repo tool does not prompt during upload.
-	if _, err := r.childRepo.Git(ctx, "config", "color.ui", "true"); err != nil {
-		return err
+	checkouts := []*git.Checkout{r.childRepo}
+	for _, project := range r.topicProjects {
+		checkouts = append(checkouts, r.topicProjectCheckout(project))
 	}
+	for _, co := range checkouts {
+		// Set color.ui=true so that the repo tool does not prompt during upload.
+		if _, err := co.Git(ctx, "config", "color.ui", "true"); err != nil {
+			return err
+		}
 
-	// Fix the review config to a URL which will work outside prod.
-	if _, err := r.childRepo.Git(ctx, "config", fmt.Sprintf("remote.%s.review", r.androidRemoteName), fmt.Sprintf("%s/", r.parentRepoURL)); err != nil {
-		return err
+		// Fix the review config to a URL which will work outside prod.
+		if _, err := co.Git(ctx, "config", fmt.Sprintf("remote.%s.review", r.androidRemoteName), fmt.Sprintf("%s/", r.parentRepoURL)); err != nil {
+			return err
+		}
 	}
 
 	// Check to see whether there is an upstream yet.
@@ -306,13 +343,34 @@ func (r *androidRepoManager) updateAndroidCheckout(ctx context.Context) error {
 
 // Update implements RepoManager.
 func (r *androidRepoManager) Update(ctx context.Context) (*revision.Revision, *revision.Revision, []*revision.Revision, error) {
-	// Sync the projects.
 	r.repoMtx.Lock()
 	defer r.repoMtx.Unlock()
+
+	// If neither the parent nor the child branch has moved since the last
+	// update, skip syncing the projects and return the previous results.
+	if r.lastUpdate != nil {
+		unchanged, err := r.remoteUnchanged(ctx)
+		if err != nil {
+			sklog.Warningf("Failed to check for changes to the remote branches; performing a full update: %s", err)
+		} else if unchanged {
+			sklog.Info("Parent and child branches have not changed since the last update.")
+			lastRollRev, tipRev, notRolledRevs := r.lastUpdate.results()
+			return lastRollRev, tipRev, notRolledRevs, nil
+		}
+	}
+
+	// Sync the projects.
 	if err := r.updateAndroidCheckout(ctx); err != nil {
 		return nil, nil, nil, skerr.Wrapf(err, "failed to update Android checkout")
 	}
 
+	// Record the state of the parent branch from which we'll derive the last
+	// roll revision.
+	parentHash, err := r.childRepo.Git(ctx, "rev-parse", fmt.Sprintf("refs/remotes/%s/%s", r.androidRemoteName, r.parentBranch))
+	if err != nil {
+		return nil, nil, nil, skerr.Wrap(err)
+	}
+
 	// Get the last roll revision.
 	lastRollRev, err := r.getLastRollRev(ctx)
 	if err != nil {
@@ -326,14 +384,88 @@ func (r *androidRepoManager) Update(ctx context.Context) (*revision.Revision, *r
 	}
 
 	// Find the not-rolled child repo commits.
-	notRolledRevs, err := r.LogRevisions(ctx, lastRollRev, tipRev)
+	notRolledRevs, err := r.getNotRolledRevs(ctx, lastRollRev, tipRev)
 	if err != nil {
 		return nil, nil, nil, err
 	}
 
+	r.lastUpdate = &androidUpdateState{
+		parentHash:    strings.TrimSpace(parentHash),
+		lastRollRev:   lastRollRev,
+		tipRev:        tipRev,
+		notRolledRevs: notRolledRevs,
+	}
+	lastRollRev, tipRev, notRolledRevs = r.lastUpdate.results()
 	return lastRollRev, tipRev, notRolledRevs, nil
 }
 
+// InvalidateCache implements CacheInvalidator.
+func (r *androidRepoManager) InvalidateCache() {
+	r.repoMtx.Lock()
+	defer r.repoMtx.Unlock()
+	r.lastUpdate = nil
+}
+
+// remoteUnchanged returns true if neither the parent nor the child branch has
+// moved since the last update.
+func (r *androidRepoManager) remoteUnchanged(ctx context.Context) (bool, error) {
+	parentHash, err := r.lsRemote(ctx, r.androidRemoteName, fmt.Sprintf("refs/heads/%s", r.parentBranch))
+	if err != nil {
+		return false, skerr.Wrap(err)
+	}
+	if parentHash != r.lastUpdate.parentHash {
+		return false, nil
+	}
+	tipHash, err := r.lsRemote(ctx, androidUpstreamRemoteName, fmt.Sprintf("refs/heads/%s", r.childBranch))
+	if err != nil {
+		return false, skerr.Wrap(err)
+	}
+	return tipHash == r.lastUpdate.tipRev.Id, nil
+}
+
+// getNotRolledRevs returns the not-rolled child repo commits, reusing the
+// results of the last update where possible.
+func (r *androidRepoManager) getNotRolledRevs(ctx context.Context, lastRollRev, tipRev *revision.Revision) ([]*revision.Revision, error) {
+	prev := r.lastUpdate
+	if prev == nil {
+		return r.LogRevisions(ctx, lastRollRev, tipRev)
+	}
+	notRolledRevs := prev.notRolledRevs
+	if tipRev.Id != prev.tipRev.Id {
+		// If the child branch was rewritten, we can't reuse the previous
+		// results.
+		isAncestor, err := r.childRepo.IsAncestor(ctx, prev.tipRev.Id, tipRev.Id)
+		if err != nil {
+			return nil, skerr.Wrap(err)
+		}
+		if !isAncestor {
+			return r.LogRevisions(ctx, lastRollRev, tipRev)
+		}
+		newRevs, err := r.LogRevisions(ctx, prev.tipRev, tipRev)
+		if err != nil {
+			return nil, skerr.Wrap(err)
+		}
+		notRolledRevs = append(newRevs, notRolledRevs...)
+	}
+	if lastRollRev.Id != prev.lastRollRev.Id {
+		// Drop the revisions which have since been rolled. If the new last
+		// roll revision isn't one of the previously not-rolled revisions, eg.
+		// because a roll was reverted, start over.
+		idx := -1
+		for i, rev := range notRolledRevs {
+			if rev.Id == lastRollRev.Id {
+				idx = i
+				break
+			}
+		}
+		if idx < 0 {
+			return r.LogRevisions(ctx, lastRollRev, tipRev)
+		}
+		notRolledRevs = notRolledRevs[:idx]
+	}
+	return notRolledRevs, nil
+}
+
 // LogRevisions implements RepoManager.
 func (r *androidRepoManager) LogRevisions(ctx context.Context, from, to *revision.Revision) ([]*revision.Revision, error) {
 	if from.Id == to.Id {
@@ -375,19 +507,117 @@ func (r *androidRepoManager) abortMerge(ctx context.Context) error {
 
 // abandonRepoBranchAndCleanup abandons the repo branch and cleans up the local
 // checkout to make sure there are no leftover untracked files/directories.
-func (r *androidRepoManager) abandonRepoBranchAndCleanup(ctx context.Context) error {
-	if _, err := exec.RunCwd(ctx, r.childRepo.Dir(), "python3", r.repoToolPath, "abandon", androidRepoBranchName); err != nil {
+func (r *androidRepoManager) abandonRepoBranchAndCleanup(ctx context.Context, co *git.Checkout) error {
+	if _, err := exec.RunCwd(ctx, co.Dir(), "python3", r.repoToolPath, "abandon", androidRepoBranchName); err != nil {
 		sklog.Errorf("Failed to abandon merge; ignoring: %s", err)
 	}
-	if _, err := r.childRepo.Git(ctx, "reset", "--hard", "HEAD"); err != nil {
+	return cleanupCheckout(ctx, co)
+}
+
+// cleanupCheckout discards any uncommitted changes and untracked
+// files/directories in the given checkout.
+func cleanupCheckout(ctx context.Context, co *git.Checkout) error {
+	if _, err := co.Git(ctx, "reset", "--hard", "HEAD"); err != nil {
 		return err
 	}
-	if _, err := r.childRepo.Git(ctx, "clean", "-d", "-f", "-f"); err != nil {
+	if _, err := co.Git(ctx, "clean", "-d", "-f", "-f"); err != nil {
 		return err
 	}
 	return nil
 }
 
+// uploadChange uploads the commit at HEAD of the given checkout to Gerrit
+// using the repo tool, abandons the local repo branch, and returns the
+// resulting change.
+func (r *androidRepoManager) uploadChange(ctx context.Context, co *git.Checkout, rollEmails []string) (*gerrit.ChangeInfo, error) {
+	// Bypass the repo upload prompt by setting autoupload config to true.
+	// Strip "-review" from the upload URL else autoupload does not work.
+	uploadUrl := strings.Replace(r.parentRepoURL, "-review", "", 1)
+	if _, configErr := co.Git(ctx, "config", fmt.Sprintf("review.%s/.autoupload", uploadUrl), "true"); configErr != nil {
+		util.LogErr(r.abandonRepoBranchAndCleanup(ctx, co))
+		return nil, skerr.Wrapf(configErr, "could not set autoupload config")
+	}
+
+	// Upload the CL to Gerrit.
+	uploadArgs := []string{r.repoToolPath, "upload", "--no-verify"}
+	if rollEmails != nil && len(rollEmails) > 0 {
+		uploadArgs = append(uploadArgs, fmt.Sprintf("--re=%s", strings.Join(rollEmails, ",")))
+	}
+	uploadCommand := &exec.Command{
+		Name: "python3",
+		Args: uploadArgs,
+		Dir:  co.Dir(),
+		// The below is to bypass the blocking
+		// "ATTENTION: You are uploading an unusually high number of commits."
+		// prompt which shows up when a merge contains more than 5 commits.
+		Stdin: strings.NewReader("yes"),
+	}
+	if uploadOutput, uploadErr := exec.RunCommand(ctx, uploadCommand); uploadErr != nil {
+		util.LogErr(r.abandonRepoBranchAndCleanup(ctx, co))
+		return nil, skerr.Wrapf(uploadErr, "could not upload to Gerrit")
+	} else {
+		sklog.Info(uploadOutput)
+	}
+
+	// Get latest hash to find Gerrit change number with.
+	commitHashOutput, revParseErr := co.Git(ctx, "rev-parse", "HEAD")
+	if revParseErr != nil {
+		util.LogErr(r.abandonRepoBranchAndCleanup(ctx, co))
+		return nil, revParseErr
+	}
+	commitHash := strings.Split(commitHashOutput, "\n")[0]
+	// We no longer need the local branch. Abandon the repo.
+	util.LogErr(r.abandonRepoBranchAndCleanup(ctx, co))
+
+	// Get the change number.
+	change, err := r.getChangeForHash(commitHash)
+	if err != nil {
+		util.LogErr(r.abandonRepoBranchAndCleanup(ctx, co))
+		return nil, err
+	}
+	return change, nil
+}
+
+// createTopicProjectChange commits any changes in the given topic project and
+// uploads them to Gerrit. Returns nil if the project has no changes.
+func (r *androidRepoManager) createTopicProjectChange(ctx context.Context, project string, rollEmails []string, commitMsg string) (*gerrit.ChangeInfo, error) {
+	co := r.topicProjectCheckout(project)
+	status, err := co.Git(ctx, "status", "--porcelain")
+	if err != nil {
+		return nil, skerr.Wrap(err)
+	}
+	if strings.TrimSpace(status) == "" {
+		sklog.Infof("No changes in topic project %s; not creating a CL.", project)
+		return nil, nil
+	}
+	if _, err := exec.RunCwd(ctx, co.Dir(), "python3", r.repoToolPath, "start", androidRepoBranchName, "."); err != nil {
+		util.LogErr(cleanupCheckout(ctx, co))
+		return nil, skerr.Wrapf(err, "failed to create repo branch in %s", project)
+	}
+	if _, err := co.Git(ctx, "add", "-A"); err != nil {
+		util.LogErr(r.abandonRepoBranchAndCleanup(ctx, co))
+		return nil, skerr.Wrap(err)
+	}
+	if _, err := co.Git(ctx, "commit", "-m", commitMsg); err != nil {
+		util.LogErr(r.abandonRepoBranchAndCleanup(ctx, co))
+		return nil, skerr.Wrapf(err, "failed to commit changes in %s", project)
+	}
+	change, err := r.uploadChange(ctx, co, rollEmails)
+	if err != nil {
+		return nil, skerr.Wrapf(err, "failed to upload changes in %s", project)
+	}
+	return change, nil
+}
+
+// abandonChanges abandons all of the given changes, logging any errors.
+func (r *androidRepoManager) abandonChanges(ctx context.Context, changes []*gerrit.ChangeInfo, msg string) {
+	for _, change := range changes {
+		if err := r.g.Abandon(ctx, change, msg); err != nil {
+			sklog.Errorf("Failed to abandon change %d: %s", change.Issue, err)
+		}
+	}
+}
+
 // getChangeNumForHash returns the corresponding change number for the provided commit hash by querying Gerrit's search API.
 func (r *androidRepoManager) getChangeForHash(hash string) (*gerrit.ChangeInfo, error) {
 	issues, err := r.g.Search(context.TODO(), 1, false, gerrit.SearchCommit(hash))
@@ -401,15 +631,32 @@ func (r *androidRepoManager) getChangeForHash(hash string) (*gerrit.ChangeInfo,
 }
 
 // See documentation for RepoManager interface.
-func (r *androidRepoManager) CreateNewRoll(ctx context.Context, from *revision.Revision, to *revision.Revision, rolling []*revision.Revision, emails []string, dryRun bool, commitMsg string) (int64, error) {
+func (r *androidRepoManager) CreateNewRoll(ctx context.Context, from *revision.Revision, to *revision.Revision, rolling []*revision.Revision, emails []string, dryRun bool, commitMsg string) (_ int64, rvErr error) {
 	r.repoMtx.Lock()
 	defer r.repoMtx.Unlock()
 
+	// A failed roll may leave the checkout in a bad state, eg. with a merge
+	// in progress. Force the next Update to sync the checkout, which cleans
+	// it up, even if the remote branches have not changed.
+	defer func() {
+		if rvErr != nil {
+			r.lastUpdate = nil
+		}
+	}()
+
 	// Update the upstream remote.
 	if _, err := r.childRepo.Git(ctx, "fetch", androidUpstreamRemoteName); err != nil {
 		return 0, err
 	}
 
+	// Ensure that the topic projects contain only the changes made by the
+	// pre-upload steps for this roll.
+	for _, project := range r.topicProjects {
+		if err := cleanupCheckout(ctx, r.topicProjectCheckout(project)); err != nil {
+			return 0, skerr.Wrapf(err, "failed to clean up topic project %s", project)
+		}
+	}
+
 	// Create the roll CL.
 
 	// Start the merge.
@@ -543,53 +790,13 @@ third_party {
 
 	// Commit the change with the above message.
 	if _, commitErr := r.childRepo.Git(ctx, "commit", "-a", "-m", commitMsg); commitErr != nil {
-		util.LogErr(r.abandonRepoBranchAndCleanup(ctx))
+		util.LogErr(r.abandonRepoBranchAndCleanup(ctx, r.childRepo))
 		return 0, skerr.Wrapf(commitErr, "nothing to merge; did someone already merge %s..%s?", from, to)
 	}
 
-	// Bypass the repo upload prompt by setting autoupload config to true.
-	// Strip "-review" from the upload URL else autoupload does not work.
-	uploadUrl := strings.Replace(r.parentRepoURL, "-review", "", 1)
-	if _, configErr := r.childRepo.Git(ctx, "config", fmt.Sprintf("review.%s/.autoupload", uploadUrl), "true"); configErr != nil {
-		util.LogErr(r.abandonRepoBranchAndCleanup(ctx))
-		return 0, skerr.Wrapf(configErr, "could not set autoupload config")
-	}
-
 	// Upload the CL to Gerrit.
-	uploadArgs := []string{r.repoToolPath, "upload", "--no-verify"}
-	if rollEmails != nil && len(rollEmails) > 0 {
-		uploadArgs = append(uploadArgs, fmt.Sprintf("--re=%s", strings.Join(rollEmails, ",")))
-	}
-	uploadCommand := &exec.Command{
-		Name: "python3",
-		Args: uploadArgs,
-		Dir:  r.childDir,
-		// The below is to bypass the blocking
-		// "ATTENTION: You are uploading an unusually high number of commits."
-		// prompt which shows up when a merge contains more than 5 commits.
-		Stdin: strings.NewReader("yes"),
-	}
-	if uploadOutput, uploadErr := exec.RunCommand(ctx, uploadCommand); uploadErr != nil {
-		util.LogErr(r.abandonRepoBranchAndCleanup(ctx))
-		return 0, skerr.Wrapf(uploadErr, "could not upload to Gerrit")
-	} else {
-		sklog.Info(uploadOutput)
-	}
-
-	// Get latest hash to find Gerrit change number with.
-	commitHashOutput, revParseErr := r.childRepo.Git(ctx, "rev-parse", "HEAD")
-	if revParseErr != nil {
-		util.LogErr(r.abandonRepoBranchAndCleanup(ctx))
-		return 0, revParseErr
-	}
-	commitHash := strings.Split(commitHashOutput, "\n")[0]
-	// We no longer need the local branch. Abandon the repo.
-	util.LogErr(r.abandonRepoBranchAndCleanup(ctx))
-
-	// Get the change number.
-	change, err := r.getChangeForHash(commitHash)
+	change, err := r.uploadChange(ctx, r.childRepo, rollEmails)
 	if err != nil {
-		util.LogErr(r.abandonRepoBranchAndCleanup(ctx))
 		return 0, err
 	}
 	// Set the topic of the merge change. By default use the name of the child
@@ -598,9 +805,33 @@ third_party {
 	if to.ExternalChangeId != "" {
 		topicName = to.ExternalChangeId
 	}
-	if err := r.g.SetTopic(ctx, topicName, change.Issue); err != nil {
+	changes := []*gerrit.ChangeInfo{change}
+	// If any of the topic changes fail to be created, abandon all of them,
+	// since they must land together.
+	failed := func(err error) (int64, error) {
+		if len(r.topicProjects) > 0 {
+			r.abandonChanges(ctx, changes, fmt.Sprintf("Failed to create roll: %s", err))
+		}
 		return 0, err
 	}
+	if err := r.g.SetTopic(ctx, topicName, change.Issue); err != nil {
+		return failed(err)
+	}
+
+	// Create the CLs for the topic projects, if any.
+	for _, project := range r.topicProjects {
+		topicChange, err := r.createTopicProjectChange(ctx, project, rollEmails, commitMsg)
+		if err != nil {
+			return failed(err)
+		}
+		if topicChange == nil {
+			continue
+		}
+		changes = append(changes, topicChange)
+		if err := r.g.SetTopic(ctx, topicName, topicChange.Issue); err != nil {
+			return failed(err)
+		}
+	}
 
 	// Set labels.
 	labels := r.g.Config().SetCqLabels
@@ -608,36 +839,49 @@ third_party {
 		labels = r.g.Config().SetDryRunLabels
 	}
 	labels = gerrit.MergeLabels(labels, r.g.Config().SelfApproveLabels)
-	if err = r.g.SetReview(ctx, change, "Roller setting labels to auto-land change.", labels, rollEmails, "", nil, "", 0, nil); err != nil {
-		return 0, err
-	}
+	for _, c := range changes {
+		if err := r.g.SetReview(ctx, c, "Roller setting labels to auto-land change.", labels, rollEmails, "", nil, "", 0, nil); err != nil {
+			return failed(err)
+		}
 
-	// Mark the change as ready for review, if necessary.
-	if err := gerrit_common.UnsetWIP(ctx, r.g, change, 0); err != nil {
-		return 0, err
-	}
+		// Mark the change as ready for review, if necessary.
+		if err := gerrit_common.UnsetWIP(ctx, r.g, c, 0); err != nil {
+			return failed(err)
+		}
 
-	// Use the second account to auto-approve the CL from the first account.
-	if r.autoApproverGerrit != nil {
-		if err := r.autoApproverGerrit.SetReview(ctx, change, "Auto-approving AutoRoll CL", r.g.Config().SelfApproveLabels, nil, "", nil, "", 0, nil); err != nil {
-			return 0, skerr.Wrap(err)
+		// Use the second account to auto-approve the CL from the first account.
+		if r.autoApproverGerrit != nil {
+			if err := r.autoApproverGerrit.SetReview(ctx, c, "Auto-approving AutoRoll CL", r.g.Config().SelfApproveLabels, nil, "", nil, "", 0, nil); err != nil {
+				return failed(skerr.Wrap(err))
+			}
 		}
 	}
 	return change.Issue, nil
 }
 
-func (r *androidRepoManager) getTipRev(ctx context.Context) (*revision.Revision, error) {
+// lsRemote returns the commit hash of the given ref in the given remote.
+func (r *androidRepoManager) lsRemote(ctx context.Context, remote, ref string) (string, error) {
 	// "ls-remote" can get stuck indefinitely if GoB is having problems. Call it with a timeout.
 	ctxWithTimeout, cancel := context.WithTimeout(ctx, 10*time.Minute)
 	defer cancel() // Releases resources if "ls-remote" completes before timeout.
-	output, err := r.childRepo.Git(ctxWithTimeout, "ls-remote", androidUpstreamRemoteName, fmt.Sprintf("refs/heads/%s", r.childBranch), "-1")
+	output, err := r.childRepo.Git(ctxWithTimeout, "ls-remote", remote, ref, "-1")
+	if err != nil {
+		return "", skerr.Wrap(err)
+	}
+	return strings.TrimSpace(strings.Split(output, "\t")[0]), nil
+}
+
+func (r *androidRepoManager) getTipRev(ctx context.Context) (*revision.Revision, error) {
+	hash, err := r.lsRemote(ctx, androidUpstreamRemoteName, fmt.Sprintf("refs/heads/%s", r.childBranch))
 	if err != nil {
 		return nil, skerr.Wrap(err)
 	}
-	hash := strings.Split(output, "\t")[0]
 	details, err := r.childRepo.Details(ctx, hash)
 	if err != nil {
 		return nil, skerr.Wrap(err)
 	}
 	return revision.FromLongCommit(r.childRevLinkTmpl, r.defaultBugProject, details), nil
 }
+
+var _ RepoManager = &androidRepoManager{}
+var _ CacheInvalidator = &androidRepoManager{}
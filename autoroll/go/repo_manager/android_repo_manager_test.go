@@ -2,9 +2,11 @@ package repo_manager
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"os"
+	"path"
 	"strings"
 	"testing"
 
@@ -108,6 +110,8 @@ func setupAndroid(t *testing.T) (context.Context, *config_vars.Registry, string,
 				output = childCommits[0]
 			} else if cmd.Args[0] == "merge-base" {
 				output = childCommits[1]
+			} else if cmd.Args[0] == "rev-parse" && strings.HasPrefix(cmd.Args[1], "refs/remotes/") {
+				output = childCommits[0]
 			} else if cmd.Args[0] == "rev-list" {
 				split := strings.Split(cmd.Args[len(cmd.Args)-1], "..")
 				require.Equal(t, 2, len(split))
@@ -163,6 +167,83 @@ func TestAndroidRepoManager(t *testing.T) {
 	require.Equal(t, childCommits[0], tipRev.Id)
 }
 
+// TestAndroidRepoManager_UpdateUnchanged verifies that Update skips syncing
+// the checkout when the remote branches have not changed.
+func TestAndroidRepoManager_UpdateUnchanged(t *testing.T) {
+	ctx, reg, wd, cleanup := setupAndroid(t)
+	defer cleanup()
+	syncs := 0
+	run := exec.NewContext(ctx, func(_ context.Context, cmd *exec.Command) error {
+		if cmd.Name == "python3" && len(cmd.Args) >= 2 && cmd.Args[1] == "sync" {
+			syncs++
+		}
+		return exec.Run(ctx, cmd)
+	})
+	g := &mocks.GerritInterface{}
+	g.On("GetUserEmail", testutils.AnyContext).Return("fake-service-account", nil)
+	g.On("GetRepoUrl").Return(androidCfg().ParentRepoUrl)
+	g.On("Config").Return(gerrit.ConfigAndroid)
+	mockGerrit, _ := androidGerrit(t, g)
+	rm, err := NewAndroidRepoManager(run, androidCfg(), reg, wd, "fake.server.com", "fake-service-account", nil, mockGerrit, true, true)
+	require.NoError(t, err)
+	lastRollRev, tipRev, notRolledRevs, err := rm.Update(run)
+	require.NoError(t, err)
+	require.Equal(t, 1, syncs)
+
+	// Nothing has changed, so we shouldn't sync again.
+	lastRollRev2, tipRev2, notRolledRevs2, err := rm.Update(run)
+	require.NoError(t, err)
+	require.Equal(t, 1, syncs)
+	require.Equal(t, lastRollRev, lastRollRev2)
+	require.Equal(t, tipRev, tipRev2)
+	require.Equal(t, notRolledRevs, notRolledRevs2)
+
+	// The parent branch has moved, so we should sync.
+	rm.(*androidRepoManager).lastUpdate.parentHash = childCommits[1]
+	_, _, _, err = rm.Update(run)
+	require.NoError(t, err)
+	require.Equal(t, 2, syncs)
+}
+
+// TestAndroidRepoManager_UpdateAfterFailedRoll verifies that Update syncs the
+// checkout after a failed roll, even if the remote branches have not changed,
+// in order to clean up the checkout.
+func TestAndroidRepoManager_UpdateAfterFailedRoll(t *testing.T) {
+	ctx, reg, wd, cleanup := setupAndroid(t)
+	defer cleanup()
+	syncs := 0
+	run := exec.NewContext(ctx, func(_ context.Context, cmd *exec.Command) error {
+		if cmd.Name == "python3" && len(cmd.Args) >= 2 && cmd.Args[1] == "sync" {
+			syncs++
+		}
+		return exec.Run(ctx, cmd)
+	})
+	g := &mocks.GerritInterface{}
+	g.On("GetUserEmail", testutils.AnyContext).Return("fake-service-account", nil)
+	g.On("GetRepoUrl").Return(androidCfg().ParentRepoUrl)
+	g.On("Config").Return(gerrit.ConfigAndroid)
+	mockGerrit, _ := androidGerrit(t, g)
+	rm, err := NewAndroidRepoManager(run, androidCfg(), reg, wd, "fake.server.com", "fake-service-account", nil, mockGerrit, true, true)
+	require.NoError(t, err)
+	lastRollRev, tipRev, notRolledRevs, err := rm.Update(run)
+	require.NoError(t, err)
+	require.Equal(t, 1, syncs)
+
+	// Fail in the middle of creating the roll.
+	rm.(*androidRepoManager).preUploadSteps = []parent.PreUploadStep{
+		func(context.Context, []string, *http.Client, string, *revision.Revision, *revision.Revision) error {
+			return errors.New("pre-upload step failed")
+		},
+	}
+	_, err = rm.CreateNewRoll(run, lastRollRev, tipRev, notRolledRevs, androidEmails, false, fakeCommitMsg)
+	require.ErrorContains(t, err, "pre-upload step failed")
+
+	// Nothing has changed, but we should sync to clean up the checkout.
+	_, _, _, err = rm.Update(run)
+	require.NoError(t, err)
+	require.Equal(t, 2, syncs)
+}
+
 // TestCreateNewAndroidRoll tests creating a new roll.
 func TestCreateNewAndroidRoll(t *testing.T) {
 	ctx, reg, wd, cleanup := setupAndroid(t)
@@ -216,6 +297,97 @@ func TestCreateNewAndroidRollWithExternalChangeId(t *testing.T) {
 	require.Equal(t, issueNum, issue)
 }
 
+// withTopicProjectChanges returns a context which fakes the results of the
+// commands run in the given topic project directory, such that the project has
+// uncommitted changes and its HEAD is at the given hash. If uploadErr is not
+// nil, uploading the change in the topic project fails with it.
+func withTopicProjectChanges(ctx context.Context, topicDir, hash string, uploadErr error) context.Context {
+	return exec.NewContext(ctx, func(_ context.Context, cmd *exec.Command) error {
+		if cmd.Dir == topicDir {
+			if strings.HasSuffix(cmd.Name, "/git") && len(cmd.Args) >= 2 {
+				if cmd.Args[0] == "status" && cmd.Args[1] == "--porcelain" {
+					_, err := cmd.CombinedOutput.Write([]byte(" M core/Makefile\n"))
+					return err
+				}
+				if cmd.Args[0] == "rev-parse" && cmd.Args[1] == "HEAD" {
+					_, err := cmd.CombinedOutput.Write([]byte(hash + "\n"))
+					return err
+				}
+			}
+			if cmd.Name == "python3" && len(cmd.Args) >= 2 && cmd.Args[1] == "upload" && uploadErr != nil {
+				return uploadErr
+			}
+		}
+		return exec.Run(ctx, cmd)
+	})
+}
+
+// TestCreateNewAndroidRollWithTopicProject tests creating a new roll which
+// includes a change to a topic project.
+func TestCreateNewAndroidRollWithTopicProject(t *testing.T) {
+	ctx, reg, wd, cleanup := setupAndroid(t)
+	defer cleanup()
+	const topicIssueNum = int64(67890)
+	topicHash := childCommits[1]
+	ctx = withTopicProjectChanges(ctx, path.Join(wd, "build/make"), topicHash, nil)
+
+	rollChange := &gerrit.ChangeInfo{Issue: androidIssueNum}
+	topicChange := &gerrit.ChangeInfo{Issue: topicIssueNum}
+	g := &mocks.GerritInterface{}
+	g.On("GetUserEmail", testutils.AnyContext).Return("fake-service-account", nil)
+	g.On("GetRepoUrl").Return(androidCfg().ParentRepoUrl)
+	g.On("Config").Return(gerrit.ConfigAndroid)
+	g.On("Search", testutils.AnyContext, 1, false, gerrit.SearchCommit("")).Return([]*gerrit.ChangeInfo{{Issue: androidIssueNum}}, nil)
+	g.On("GetIssueProperties", testutils.AnyContext, androidIssueNum).Return(rollChange, nil)
+	g.On("Search", testutils.AnyContext, 1, false, gerrit.SearchCommit(topicHash)).Return([]*gerrit.ChangeInfo{{Issue: topicIssueNum}}, nil)
+	g.On("GetIssueProperties", testutils.AnyContext, topicIssueNum).Return(topicChange, nil)
+	g.On("SetTopic", testutils.AnyContext, "child_merge_12345", androidIssueNum).Return(nil)
+	g.On("SetTopic", testutils.AnyContext, "child_merge_12345", topicIssueNum).Return(nil)
+	g.On("SetReview", testutils.AnyContext, rollChange, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	g.On("SetReview", testutils.AnyContext, topicChange, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mockGerrit, _ := androidGerrit(t, g)
+	cfg := androidCfg()
+	cfg.TopicProject = []string{"build/make"}
+	rm, err := NewAndroidRepoManager(ctx, cfg, reg, wd, "fake.server.com", "fake-service-account", nil, mockGerrit, true, true)
+	require.NoError(t, err)
+	lastRollRev, tipRev, notRolledRevs, err := rm.Update(ctx)
+	require.NoError(t, err)
+
+	issue, err := rm.CreateNewRoll(ctx, lastRollRev, tipRev, notRolledRevs, androidEmails, false, fakeCommitMsg)
+	require.NoError(t, err)
+	require.Equal(t, issueNum, issue)
+	g.AssertExpectations(t)
+}
+
+// TestCreateNewAndroidRollWithTopicProject_UploadFails verifies that the roll
+// CL is abandoned if the change to a topic project fails to upload.
+func TestCreateNewAndroidRollWithTopicProject_UploadFails(t *testing.T) {
+	ctx, reg, wd, cleanup := setupAndroid(t)
+	defer cleanup()
+	ctx = withTopicProjectChanges(ctx, path.Join(wd, "build/make"), childCommits[1], errors.New("upload failed"))
+
+	rollChange := &gerrit.ChangeInfo{Issue: androidIssueNum}
+	g := &mocks.GerritInterface{}
+	g.On("GetUserEmail", testutils.AnyContext).Return("fake-service-account", nil)
+	g.On("GetRepoUrl").Return(androidCfg().ParentRepoUrl)
+	g.On("Search", testutils.AnyContext, 1, false, gerrit.SearchCommit("")).Return([]*gerrit.ChangeInfo{{Issue: androidIssueNum}}, nil)
+	g.On("GetIssueProperties", testutils.AnyContext, androidIssueNum).Return(rollChange, nil)
+	g.On("SetTopic", testutils.AnyContext, "child_merge_12345", androidIssueNum).Return(nil)
+	g.On("Abandon", testutils.AnyContext, rollChange, mock.AnythingOfType("string")).Return(nil)
+	mockGerrit, _ := androidGerrit(t, g)
+	cfg := androidCfg()
+	cfg.TopicProject = []string{"build/make"}
+	rm, err := NewAndroidRepoManager(ctx, cfg, reg, wd, "fake.server.com", "fake-service-account", nil, mockGerrit, true, true)
+	require.NoError(t, err)
+	lastRollRev, tipRev, notRolledRevs, err := rm.Update(ctx)
+	require.NoError(t, err)
+
+	_, err = rm.CreateNewRoll(ctx, lastRollRev, tipRev, notRolledRevs, androidEmails, false, fakeCommitMsg)
+	require.ErrorContains(t, err, "upload failed")
+	g.AssertExpectations(t)
+	g.AssertNotCalled(t, "SetReview", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
 // Verify that we ran the PreUploadSteps.
 func TestRanPreUploadStepsAndroid(t *testing.T) {
 	ctx, reg, wd, cleanup := setupAndroid(t)
@@ -258,4 +430,11 @@ func TestAndroidConfigValidation(t *testing.T) {
 	// verify that we fail validation.
 	cfg = &config.AndroidRepoManagerConfig{}
 	require.Error(t, cfg.Validate())
+
+	// Topic projects must not include the child path.
+	cfg = androidCfg()
+	cfg.TopicProject = []string{"build/make"}
+	require.NoError(t, cfg.Validate())
+	cfg.TopicProject = append(cfg.TopicProject, cfg.ChildPath)
+	require.Error(t, cfg.Validate())
 }
@@ -0,0 +1,120 @@
+package child
+
+import (
+	"context"
+	"sync"
+
+	"github.com/golang/groupcache/lru"
+	"go.skia.org/infra/autoroll/go/revision"
+	"go.skia.org/infra/go/skerr"
+)
+
+// CachingChild wraps a Child and caches the Revisions it returns, so that
+// callers which repeatedly retrieve the same revisions, eg. strategies and
+// revision filters, don't have to make the same requests over and over.
+// Revisions are only cached when requested by their full ID, since other
+// identifiers, eg. branch names, may refer to different revisions over time.
+type CachingChild struct {
+	Child
+	cache *lru.Cache
+	mtx   sync.Mutex
+}
+
+// NewCachingChild returns a CachingChild which wraps the given Child and
+// caches up to the given number of Revisions.
+func NewCachingChild(c Child, size int) *CachingChild {
+	return &CachingChild{
+		Child: c,
+		cache: lru.New(size),
+	}
+}
+
+// get returns a copy of the cached Revision with the given ID, if any.
+func (c *CachingChild) get(id string) (*revision.Revision, bool) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if rev, ok := c.cache.Get(id); ok {
+		return rev.(*revision.Revision).Copy(), true
+	}
+	return nil, false
+}
+
+// add adds copies of the given Revisions to the cache.
+func (c *CachingChild) add(revs ...*revision.Revision) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	for _, rev := range revs {
+		c.cache.Add(rev.Id, rev.Copy())
+	}
+}
+
+// Update implements Child.
+func (c *CachingChild) Update(ctx context.Context, lastRollRev *revision.Revision) (*revision.Revision, []*revision.Revision, error) {
+	tipRev, notRolledRevs, err := c.Child.Update(ctx, lastRollRev)
+	if err != nil {
+		return nil, nil, skerr.Wrap(err)
+	}
+	c.add(notRolledRevs...)
+	return tipRev, notRolledRevs, nil
+}
+
+// GetRevision implements Child.
+func (c *CachingChild) GetRevision(ctx context.Context, id string) (*revision.Revision, error) {
+	if rev, ok := c.get(id); ok {
+		return rev, nil
+	}
+	rev, err := c.Child.GetRevision(ctx, id)
+	if err != nil {
+		return nil, skerr.Wrap(err)
+	}
+	if rev.Id == id {
+		c.add(rev)
+	}
+	return rev, nil
+}
+
+// GetRevisions implements Child. Only the revisions which aren't already
+// cached are requested from the wrapped Child.
+func (c *CachingChild) GetRevisions(ctx context.Context, ids []string) ([]*revision.Revision, error) {
+	revs := make([]*revision.Revision, len(ids))
+	var missingIdx []int
+	var missingIDs []string
+	for idx, id := range ids {
+		if rev, ok := c.get(id); ok {
+			revs[idx] = rev
+		} else {
+			missingIdx = append(missingIdx, idx)
+			missingIDs = append(missingIDs, id)
+		}
+	}
+	if len(missingIDs) == 0 {
+		return revs, nil
+	}
+	missingRevs, err := c.Child.GetRevisions(ctx, missingIDs)
+	if err != nil {
+		return nil, skerr.Wrap(err)
+	}
+	if len(missingRevs) != len(missingIDs) {
+		return nil, skerr.Fmt("requested %d revisions but got %d", len(missingIDs), len(missingRevs))
+	}
+	for i, rev := range missingRevs {
+		revs[missingIdx[i]] = rev
+		if rev.Id == missingIDs[i] {
+			c.add(rev)
+		}
+	}
+	return revs, nil
+}
+
+// LogRevisions implements Child.
+func (c *CachingChild) LogRevisions(ctx context.Context, from, to *revision.Revision) ([]*revision.Revision, error) {
+	revs, err := c.Child.LogRevisions(ctx, from, to)
+	if err != nil {
+		return nil, skerr.Wrap(err)
+	}
+	c.add(revs...)
+	return revs, nil
+}
+
+// CachingChild implements Child.
+var _ Child = &CachingChild{}
@@ -0,0 +1,106 @@
+package child
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.skia.org/infra/autoroll/go/revision"
+)
+
+// fakeChild is a Child which resolves revision IDs from a map and counts the
+// requests it receives.
+type fakeChild struct {
+	Child
+	revs     map[string]string
+	requests []string
+}
+
+func (c *fakeChild) GetRevision(_ context.Context, id string) (*revision.Revision, error) {
+	c.requests = append(c.requests, id)
+	resolved, ok := c.revs[id]
+	if !ok {
+		return nil, errors.New("no such revision")
+	}
+	return &revision.Revision{Id: resolved}, nil
+}
+
+func (c *fakeChild) GetRevisions(ctx context.Context, ids []string) ([]*revision.Revision, error) {
+	return getRevisionsSequential(ctx, c.GetRevision, ids)
+}
+
+func TestCachingChild_GetRevision(t *testing.T) {
+
+	ctx := context.Background()
+	fake := &fakeChild{revs: map[string]string{
+		"abc":  "abc",
+		"main": "abc",
+	}}
+	c := NewCachingChild(fake, 10)
+
+	rev, err := c.GetRevision(ctx, "abc")
+	require.NoError(t, err)
+	require.Equal(t, "abc", rev.Id)
+	rev.Author = "modified"
+	rev, err = c.GetRevision(ctx, "abc")
+	require.NoError(t, err)
+	require.Equal(t, "abc", rev.Id)
+	require.Equal(t, "", rev.Author)
+	require.Equal(t, []string{"abc"}, fake.requests)
+
+	// Branch names are never cached.
+	_, err = c.GetRevision(ctx, "main")
+	require.NoError(t, err)
+	_, err = c.GetRevision(ctx, "main")
+	require.NoError(t, err)
+	require.Equal(t, []string{"abc", "main", "main"}, fake.requests)
+
+	// Errors are never cached.
+	_, err = c.GetRevision(ctx, "def")
+	require.Error(t, err)
+	_, err = c.GetRevision(ctx, "def")
+	require.Error(t, err)
+	require.Equal(t, []string{"abc", "main", "main", "def", "def"}, fake.requests)
+}
+
+func TestCachingChild_GetRevisions(t *testing.T) {
+
+	ctx := context.Background()
+	fake := &fakeChild{revs: map[string]string{
+		"a": "a",
+		"b": "b",
+		"c": "c",
+	}}
+	c := NewCachingChild(fake, 10)
+
+	_, err := c.GetRevision(ctx, "b")
+	require.NoError(t, err)
+	revs, err := c.GetRevisions(ctx, []string{"a", "b", "c"})
+	require.NoError(t, err)
+	require.Equal(t, []*revision.Revision{{Id: "a"}, {Id: "b"}, {Id: "c"}}, revs)
+	require.Equal(t, []string{"b", "a", "c"}, fake.requests)
+
+	revs, err = c.GetRevisions(ctx, []string{"c", "a"})
+	require.NoError(t, err)
+	require.Equal(t, []*revision.Revision{{Id: "c"}, {Id: "a"}}, revs)
+	require.Equal(t, []string{"b", "a", "c"}, fake.requests)
+}
+
+func TestCachingChild_Eviction(t *testing.T) {
+
+	ctx := context.Background()
+	fake := &fakeChild{revs: map[string]string{
+		"a": "a",
+		"b": "b",
+	}}
+	c := NewCachingChild(fake, 1)
+
+	_, err := c.GetRevision(ctx, "a")
+	require.NoError(t, err)
+	_, err = c.GetRevision(ctx, "b")
+	require.NoError(t, err)
+	_, err = c.GetRevision(ctx, "a")
+	require.NoError(t, err)
+	require.Equal(t, []string{"a", "b", "a"}, fake.requests)
+}
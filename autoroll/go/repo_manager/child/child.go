@@ -8,6 +8,7 @@ import (
 	"context"
 
 	"go.skia.org/infra/autoroll/go/revision"
+	"go.skia.org/infra/go/skerr"
 	"go.skia.org/infra/go/vfs"
 )
 
@@ -23,6 +24,12 @@ type Child interface {
 	// revision ID.
 	GetRevision(context.Context, string) (*revision.Revision, error)
 
+	// GetRevisions returns Revision instances associated with the given
+	// revision IDs, in the same order. Implementations which can't retrieve
+	// multiple revisions more efficiently than one at a time may use
+	// getRevisionsSequential.
+	GetRevisions(context.Context, []string) ([]*revision.Revision, error)
+
 	// LogRevisions returns a list of Revision instances between two revisions.
 	LogRevisions(context.Context, *revision.Revision, *revision.Revision) ([]*revision.Revision, error)
 
@@ -30,3 +37,17 @@ type Child interface {
 	// Revision.
 	VFS(context.Context, *revision.Revision) (vfs.FS, error)
 }
+
+// getRevisionsSequential is the default implementation of Child.GetRevisions,
+// which retrieves each of the given revisions in turn.
+func getRevisionsSequential(ctx context.Context, getRevision func(context.Context, string) (*revision.Revision, error), ids []string) ([]*revision.Revision, error) {
+	revs := make([]*revision.Revision, 0, len(ids))
+	for _, id := range ids {
+		rev, err := getRevision(ctx, id)
+		if err != nil {
+			return nil, skerr.Wrap(err)
+		}
+		revs = append(revs, rev)
+	}
+	return revs, nil
+}
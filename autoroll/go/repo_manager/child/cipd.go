@@ -27,9 +27,8 @@ import (
 )
 
 const (
-	cipdPackageUrlTmpl  = "%s/p/%s/+/%s"
-	cipdBuganizerPrefix = "b/"
-	gitRevisionTag      = "git_revision"
+	cipdPackageUrlTmpl = "%s/p/%s/+/%s"
+	gitRevisionTag     = "git_revision"
 )
 
 var (
@@ -127,6 +126,11 @@ func (c *CIPDChild) GetRevision(ctx context.Context, id string) (*revision.Revis
 	return rev, nil
 }
 
+// GetRevisions implements Child.
+func (c *CIPDChild) GetRevisions(ctx context.Context, ids []string) ([]*revision.Revision, error) {
+	return getRevisionsSequential(ctx, c.GetRevision, ids)
+}
+
 // LogRevisions implements Child.
 func (c *CIPDChild) LogRevisions(ctx context.Context, from, to *revision.Revision) ([]*revision.Revision, error) {
 	revs := []*revision.Revision{}
@@ -258,15 +262,13 @@ func CIPDInstanceToRevision(name string, instance *cipd_api.InstanceDescription,
 			foundRevisionTag = true
 		}
 		if key == "bug" {
-			// For bugs, we expect either eg. "chromium:1234" or "b/1234".
-			split := strings.SplitN(val, ":", 2)
-			if rev.Bugs == nil {
-				rev.Bugs = map[string][]string{}
-			}
-			if len(split) == 2 {
-				rev.Bugs[split[0]] = append(rev.Bugs[split[0]], split[1])
-			} else if strings.HasPrefix(val, cipdBuganizerPrefix) {
-				rev.Bugs[revision.BugProjectBuganizer] = append(rev.Bugs[revision.BugProjectBuganizer], val[len(cipdBuganizerPrefix):])
+			// For bugs, we expect a reference which includes the project, eg.
+			// "chromium:1234", "b/1234" or "https://crbug.com/skia/1234".
+			if project, bug, ok := revision.ParseBug(val, ""); ok {
+				if rev.Bugs == nil {
+					rev.Bugs = map[string][]string{}
+				}
+				rev.Bugs[project] = append(rev.Bugs[project], bug)
 			} else {
 				sklog.Errorf("Invalid format for \"bug\" tag: %s", tag.Tag)
 			}
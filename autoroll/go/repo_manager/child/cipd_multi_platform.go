@@ -0,0 +1,199 @@
+package child
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	cipd_api "go.chromium.org/luci/cipd/client/cipd"
+	"go.chromium.org/luci/cipd/client/cipd/pkg"
+	"go.chromium.org/luci/cipd/common"
+
+	"go.skia.org/infra/autoroll/go/config"
+	"go.skia.org/infra/autoroll/go/revision"
+	"go.skia.org/infra/go/cipd"
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/go/sklog"
+	"go.skia.org/infra/go/vfs"
+)
+
+// NewCIPDMultiPlatform returns an implementation of Child which deals with a
+// CIPD package which is built for multiple platforms. The provided config
+// must have at least one Platform.
+func NewCIPDMultiPlatform(ctx context.Context, c *config.CIPDChildConfig, client *http.Client, workdir string) (*CIPDMultiPlatformChild, error) {
+	if err := c.Validate(); err != nil {
+		return nil, skerr.Wrap(err)
+	}
+	if len(c.Platform) == 0 {
+		return nil, skerr.Fmt("at least one Platform is required")
+	}
+	cipdClient, err := cipd.NewClient(client, workdir, cipd.DefaultServiceURL)
+	if err != nil {
+		return nil, skerr.Wrap(err)
+	}
+	return &CIPDMultiPlatformChild{
+		client:                cipdClient,
+		name:                  c.Name,
+		platforms:             c.Platform,
+		root:                  workdir,
+		tag:                   c.Tag,
+		revisionIdTag:         c.RevisionIdTag,
+		revisionIdTagStripKey: c.RevisionIdTagStripKey,
+	}, nil
+}
+
+// CIPDMultiPlatformChild is an implementation of Child which deals with a CIPD
+// package which is built for multiple platforms. Revisions are identified by
+// the value of revisionIdTag, which must be consistent across all platforms,
+// and the instance ID for each platform is provided in the Revision's
+// Dependencies, keyed by full package name.
+type CIPDMultiPlatformChild struct {
+	client                cipd.CIPDClient
+	name                  string
+	platforms             []string
+	root                  string
+	tag                   string
+	revisionIdTag         string
+	revisionIdTagStripKey bool
+}
+
+// packages returns the full names of the packages for all platforms.
+func (c *CIPDMultiPlatformChild) packages() []string {
+	pkgs := make([]string, 0, len(c.platforms))
+	for _, platform := range c.platforms {
+		pkgs = append(pkgs, c.name+"/"+platform)
+	}
+	return pkgs
+}
+
+// GetRevision implements Child. The ID is the value of the revision ID tag,
+// including the key unless revisionIdTagStripKey is set.
+func (c *CIPDMultiPlatformChild) GetRevision(ctx context.Context, id string) (*revision.Revision, error) {
+	tag := id
+	if c.revisionIdTagStripKey {
+		tag = joinCIPDTag(c.revisionIdTag, id)
+	}
+	var rev *revision.Revision
+	instanceIDs := make(map[string]string, len(c.platforms))
+	var missing []string
+	for _, pkgName := range c.packages() {
+		pins, err := c.client.SearchInstances(ctx, pkgName, []string{tag})
+		if err != nil {
+			return nil, skerr.Wrapf(err, "failed to search for %q in %s", tag, pkgName)
+		}
+		if len(pins) == 0 {
+			missing = append(missing, pkgName)
+			continue
+		}
+		if len(pins) > 1 {
+			sklog.Errorf("Found more than one instance of %s with tag %q; arbitrarily using the first.", pkgName, tag)
+		}
+		instanceIDs[pkgName] = pins[0].InstanceID
+		if rev == nil {
+			// The remaining Revision details are taken from the first
+			// platform which has a matching instance.
+			instance, err := c.client.Describe(ctx, pkgName, pins[0].InstanceID)
+			if err != nil {
+				return nil, skerr.Wrap(err)
+			}
+			rev, err = CIPDInstanceToRevision(pkgName, instance, c.revisionIdTag, c.revisionIdTagStripKey)
+			if err != nil {
+				return nil, skerr.Wrap(err)
+			}
+		}
+	}
+	if rev == nil {
+		return nil, skerr.Fmt("found no instances of %s with tag %q for any platform", c.name, tag)
+	}
+	// The checksum is only meaningful for a single instance; parents should
+	// use the per-platform instance IDs instead.
+	rev.Checksum = ""
+	rev.Dependencies = instanceIDs
+	rev.Description = joinCIPDTag(c.name, tag)
+	if len(missing) > 0 {
+		rev.InvalidReason = fmt.Sprintf("No instance with tag %q for: %s", tag, strings.Join(missing, ", "))
+	}
+	return rev, nil
+}
+
+// GetRevisions implements Child.
+func (c *CIPDMultiPlatformChild) GetRevisions(ctx context.Context, ids []string) ([]*revision.Revision, error) {
+	return getRevisionsSequential(ctx, c.GetRevision, ids)
+}
+
+// LogRevisions implements Child.
+func (c *CIPDMultiPlatformChild) LogRevisions(ctx context.Context, from, to *revision.Revision) ([]*revision.Revision, error) {
+	revs := []*revision.Revision{}
+	if from.Id != to.Id {
+		revs = append(revs, to)
+	}
+	return revs, nil
+}
+
+// Update implements Child. The tracked tag is resolved using the package for
+// the first platform, and the resulting Revision is only valid if the other
+// platforms have a matching instance.
+func (c *CIPDMultiPlatformChild) Update(ctx context.Context, lastRollRev *revision.Revision) (*revision.Revision, []*revision.Revision, error) {
+	pkgName := c.packages()[0]
+	head, err := c.client.ResolveVersion(ctx, pkgName, c.tag)
+	if err != nil {
+		return nil, nil, skerr.Wrap(err)
+	}
+	instance, err := c.client.Describe(ctx, pkgName, head.InstanceID)
+	if err != nil {
+		return nil, nil, skerr.Wrap(err)
+	}
+	headRev, err := CIPDInstanceToRevision(pkgName, instance, c.revisionIdTag, c.revisionIdTagStripKey)
+	if err != nil {
+		return nil, nil, skerr.Wrap(err)
+	}
+	if headRev.InvalidReason != "" {
+		return nil, nil, skerr.Fmt("instance %s of %s: %s", head.InstanceID, pkgName, headRev.InvalidReason)
+	}
+	tipRev, err := c.GetRevision(ctx, headRev.Id)
+	if err != nil {
+		return nil, nil, skerr.Wrap(err)
+	}
+	notRolledRevs, err := c.LogRevisions(ctx, lastRollRev, tipRev)
+	if err != nil {
+		return nil, nil, skerr.Wrap(err)
+	}
+	return tipRev, notRolledRevs, nil
+}
+
+// VFS implements the Child interface. Only the package for the first platform
+// is installed.
+func (c *CIPDMultiPlatformChild) VFS(ctx context.Context, rev *revision.Revision) (vfs.FS, error) {
+	pkgName := c.packages()[0]
+	instanceID, ok := rev.Dependencies[pkgName]
+	if !ok {
+		return nil, skerr.Fmt("revision %s has no instance of %s", rev.Id, pkgName)
+	}
+	fs, err := vfs.TempDir(ctx, c.root, "tmp")
+	if err != nil {
+		return nil, skerr.Wrap(err)
+	}
+	dest, err := filepath.Rel(c.root, fs.Dir())
+	if err != nil {
+		return nil, skerr.Wrap(err)
+	}
+	if _, err := c.client.EnsurePackages(ctx, common.PinSliceBySubdir{
+		dest: []common.Pin{
+			{
+				PackageName: pkgName,
+				InstanceID:  instanceID,
+			},
+		},
+	}, &cipd_api.EnsureOptions{
+		Paranoia:            cipd_api.CheckPresence,
+		DryRun:              false,
+		OverrideInstallMode: pkg.InstallModeCopy,
+	}); err != nil {
+		return nil, skerr.Wrap(err)
+	}
+	return fs, nil
+}
+
+var _ Child = &CIPDMultiPlatformChild{}
@@ -0,0 +1,117 @@
+package child
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.chromium.org/luci/cipd/client/cipd"
+	"go.chromium.org/luci/cipd/common"
+	"go.skia.org/infra/autoroll/go/revision"
+	"go.skia.org/infra/go/cipd/mocks"
+	"go.skia.org/infra/go/testutils"
+)
+
+const (
+	fakeMultiPlatformPkg      = "some/package"
+	fakeMultiPlatformLinuxPkg = "some/package/linux-amd64"
+	fakeMultiPlatformMacPkg   = "some/package/mac-arm64"
+	fakeMultiPlatformLinuxID  = "8ECbL8K2HVu1GGLRMtnzdXr5IG-ky0QnA-gU44BViPYC"
+	fakeMultiPlatformMacID    = "ZHEgAVQbUyrmbjrp6nVrJvd3N8B1VLFKbQ1yWbOoUn4C"
+	fakeMultiPlatformTag      = "version:5"
+)
+
+func setupCIPDMultiPlatform(t *testing.T) (*CIPDMultiPlatformChild, *mocks.CIPDClient, time.Time) {
+	mockCipdClient := &mocks.CIPDClient{}
+	t.Cleanup(func() {
+		mockCipdClient.AssertExpectations(t)
+	})
+	c := &CIPDMultiPlatformChild{
+		client:        mockCipdClient,
+		name:          fakeMultiPlatformPkg,
+		platforms:     []string{"linux-amd64", "mac-arm64"},
+		tag:           "latest",
+		revisionIdTag: "version",
+	}
+	ts := time.Unix(1615384545, 0)
+	mockCipdClient.On("SearchInstances", testutils.AnyContext, fakeMultiPlatformLinuxPkg, []string{fakeMultiPlatformTag}).Return(common.PinSlice([]common.Pin{
+		{
+			PackageName: fakeMultiPlatformLinuxPkg,
+			InstanceID:  fakeMultiPlatformLinuxID,
+		},
+	}), nil)
+	mockCipdClient.On("Describe", testutils.AnyContext, fakeMultiPlatformLinuxPkg, fakeMultiPlatformLinuxID).Return(&cipd.InstanceDescription{
+		InstanceInfo: cipd.InstanceInfo{
+			Pin: common.Pin{
+				PackageName: fakeMultiPlatformLinuxPkg,
+				InstanceID:  fakeMultiPlatformLinuxID,
+			},
+			RegisteredBy: "me@google.com",
+			RegisteredTs: cipd.UnixTime(ts),
+		},
+		Tags: []cipd.TagInfo{
+			{
+				Tag: fakeMultiPlatformTag,
+			},
+		},
+	}, nil)
+	return c, mockCipdClient, ts
+}
+
+func TestCIPDMultiPlatformChild_GetRevision(t *testing.T) {
+	c, mockCipdClient, ts := setupCIPDMultiPlatform(t)
+	mockCipdClient.On("SearchInstances", testutils.AnyContext, fakeMultiPlatformMacPkg, []string{fakeMultiPlatformTag}).Return(common.PinSlice([]common.Pin{
+		{
+			PackageName: fakeMultiPlatformMacPkg,
+			InstanceID:  fakeMultiPlatformMacID,
+		},
+	}), nil)
+
+	rev, err := c.GetRevision(context.Background(), fakeMultiPlatformTag)
+	require.NoError(t, err)
+	require.Equal(t, &revision.Revision{
+		Id:     fakeMultiPlatformTag,
+		Author: "me@google.com",
+		Dependencies: map[string]string{
+			fakeMultiPlatformLinuxPkg: fakeMultiPlatformLinuxID,
+			fakeMultiPlatformMacPkg:   fakeMultiPlatformMacID,
+		},
+		Description: "some/package:version:5",
+		Display:     "5",
+		Timestamp:   ts,
+		URL:         "https://chrome-infra-packages.appspot.com/p/some/package/linux-amd64/+/8ECbL8K2HVu1GGLRMtnzdXr5IG-ky0QnA-gU44BViPYC",
+	}, rev)
+}
+
+func TestCIPDMultiPlatformChild_GetRevision_MissingPlatform(t *testing.T) {
+	c, mockCipdClient, _ := setupCIPDMultiPlatform(t)
+	mockCipdClient.On("SearchInstances", testutils.AnyContext, fakeMultiPlatformMacPkg, []string{fakeMultiPlatformTag}).Return(common.PinSlice(nil), nil)
+
+	rev, err := c.GetRevision(context.Background(), fakeMultiPlatformTag)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		fakeMultiPlatformLinuxPkg: fakeMultiPlatformLinuxID,
+	}, rev.Dependencies)
+	require.Equal(t, `No instance with tag "version:5" for: some/package/mac-arm64`, rev.InvalidReason)
+}
+
+func TestCIPDMultiPlatformChild_Update(t *testing.T) {
+	c, mockCipdClient, _ := setupCIPDMultiPlatform(t)
+	mockCipdClient.On("SearchInstances", testutils.AnyContext, fakeMultiPlatformMacPkg, []string{fakeMultiPlatformTag}).Return(common.PinSlice([]common.Pin{
+		{
+			PackageName: fakeMultiPlatformMacPkg,
+			InstanceID:  fakeMultiPlatformMacID,
+		},
+	}), nil)
+	mockCipdClient.On("ResolveVersion", testutils.AnyContext, fakeMultiPlatformLinuxPkg, c.tag).Return(common.Pin{
+		PackageName: fakeMultiPlatformLinuxPkg,
+		InstanceID:  fakeMultiPlatformLinuxID,
+	}, nil)
+
+	tipRev, notRolledRevs, err := c.Update(context.Background(), &revision.Revision{Id: "version:4"})
+	require.NoError(t, err)
+	require.Equal(t, fakeMultiPlatformTag, tipRev.Id)
+	require.Empty(t, tipRev.InvalidReason)
+	require.Equal(t, []*revision.Revision{tipRev}, notRolledRevs)
+}
@@ -2,6 +2,8 @@ package child
 
 import (
 	"context"
+	"regexp"
+	"sort"
 	"strings"
 
 	"go.skia.org/infra/autoroll/go/config"
@@ -21,20 +23,31 @@ func NewDocker(ctx context.Context, c *config.DockerChildConfig) (*DockerChild,
 	if err != nil {
 		return nil, skerr.Wrap(err)
 	}
+	var semverRegex *regexp.Regexp
+	if c.SemverRegex != "" {
+		semverRegex, err = regexp.Compile(c.SemverRegex)
+		if err != nil {
+			return nil, skerr.Wrap(err)
+		}
+	}
 	return &DockerChild{
-		client:   dockerClient,
-		registry: c.Registry,
-		repo:     c.Repository,
-		tag:      c.Tag,
+		client:      dockerClient,
+		registry:    c.Registry,
+		repo:        c.Repository,
+		tag:         c.Tag,
+		semverRegex: semverRegex,
 	}, nil
 }
 
 // DockerChild is an implementation of Child which deals with Docker images.
+// It either tracks a single tag, eg. "latest", or, if semverRegex is set, the
+// image whose tag has the highest semantic version.
 type DockerChild struct {
-	client   docker.Client
-	registry string
-	repo     string
-	tag      string
+	client      docker.Client
+	registry    string
+	repo        string
+	tag         string
+	semverRegex *regexp.Regexp
 }
 
 // GetRevision implements Child.
@@ -48,11 +61,26 @@ func (c *DockerChild) GetRevision(ctx context.Context, id string) (*revision.Rev
 		return nil, skerr.Wrap(err)
 	}
 
-	// Use a shortened digest for display, without the "sha256:" prefix.
+	// Use a shortened digest for display, without the "sha256:" prefix. When
+	// tracking semantic versions, use the same tag as listSemverRevisions
+	// instead, so that the image is displayed consistently regardless of how
+	// its Revision was retrieved.
 	display := strings.TrimPrefix(manifest.Digest, "sha256:")
 	if len(display) > 12 {
 		display = display[:12]
 	}
+	if c.semverRegex != nil {
+		revs, err := c.listSemverRevisions(ctx)
+		if err != nil {
+			return nil, skerr.Wrap(err)
+		}
+		for _, rev := range revs {
+			if rev.Id == manifest.Digest {
+				display = rev.Display
+				break
+			}
+		}
+	}
 
 	// Sometimes creation timestamps are zero. I'm not sure why this is, but if
 	// we dig into the image history we can find some which are non-zero. The
@@ -74,8 +102,20 @@ func (c *DockerChild) GetRevision(ctx context.Context, id string) (*revision.Rev
 	}, nil
 }
 
+// GetRevisions implements Child.
+func (c *DockerChild) GetRevisions(ctx context.Context, ids []string) ([]*revision.Revision, error) {
+	return getRevisionsSequential(ctx, c.GetRevision, ids)
+}
+
 // LogRevisions implements Child.
 func (c *DockerChild) LogRevisions(ctx context.Context, from, to *revision.Revision) ([]*revision.Revision, error) {
+	if c.semverRegex != nil {
+		revs, err := c.listSemverRevisions(ctx)
+		if err != nil {
+			return nil, skerr.Wrap(err)
+		}
+		return semverRevisionsBetween(revs, from, to), nil
+	}
 	var revs []*revision.Revision
 	if from.Id != to.Id {
 		revs = append(revs, to)
@@ -85,6 +125,17 @@ func (c *DockerChild) LogRevisions(ctx context.Context, from, to *revision.Revis
 
 // Update implements Child.
 func (c *DockerChild) Update(ctx context.Context, lastRollRev *revision.Revision) (*revision.Revision, []*revision.Revision, error) {
+	if c.semverRegex != nil {
+		revs, err := c.listSemverRevisions(ctx)
+		if err != nil {
+			return nil, nil, skerr.Wrap(err)
+		}
+		if len(revs) == 0 {
+			return nil, nil, skerr.Fmt("no tags in %s/%s match %q", c.registry, c.repo, c.semverRegex.String())
+		}
+		tipRev := revs[0]
+		return tipRev, semverRevisionsBetween(revs, lastRollRev, tipRev), nil
+	}
 	tipRev, err := c.GetRevision(ctx, c.tag)
 	if err != nil {
 		return nil, nil, skerr.Wrap(err)
@@ -96,6 +147,74 @@ func (c *DockerChild) Update(ctx context.Context, lastRollRev *revision.Revision
 	return tipRev, notRolledRevs, nil
 }
 
+// listSemverRevisions returns Revisions for all image instances which have a
+// tag matching semverRegex, ordered newest version first. Each Revision is
+// identified by its digest and displayed using its highest-versioned tag.
+func (c *DockerChild) listSemverRevisions(ctx context.Context) ([]*revision.Revision, error) {
+	instances, err := c.client.ListInstances(ctx, c.registry, c.repo)
+	if err != nil {
+		return nil, skerr.Wrap(err)
+	}
+	type versionedInstance struct {
+		inst    *docker.ImageInstance
+		tag     string
+		version []int
+	}
+	versioned := make([]*versionedInstance, 0, len(instances))
+	for _, inst := range instances {
+		var best *versionedInstance
+		for _, tag := range inst.Tags {
+			version, err := parseSemanticVersion(c.semverRegex, tag)
+			if err == errInvalidGCSVersion {
+				continue
+			} else if err != nil {
+				return nil, skerr.Wrapf(err, "failed to parse version from tag %q", tag)
+			}
+			if best == nil || compareSemanticVersions(best.version, version) == 1 {
+				best = &versionedInstance{inst: inst, tag: tag, version: version}
+			}
+		}
+		if best != nil {
+			versioned = append(versioned, best)
+		}
+	}
+	sort.Slice(versioned, func(i, j int) bool {
+		return compareSemanticVersions(versioned[i].version, versioned[j].version) == -1
+	})
+	revs := make([]*revision.Revision, 0, len(versioned))
+	for _, v := range versioned {
+		revs = append(revs, &revision.Revision{
+			Id:        v.inst.Digest,
+			Checksum:  v.inst.Digest,
+			Display:   v.tag,
+			Timestamp: v.inst.Created,
+		})
+	}
+	return revs, nil
+}
+
+// semverRevisionsBetween returns the Revisions from the given newest-first
+// slice which come after from, up to and including to. If from is unknown,
+// only to is returned.
+func semverRevisionsBetween(revs []*revision.Revision, from, to *revision.Revision) []*revision.Revision {
+	if from.Id == to.Id {
+		return []*revision.Revision{}
+	}
+	toIdx, fromIdx := -1, -1
+	for idx, rev := range revs {
+		if rev.Id == to.Id {
+			toIdx = idx
+		}
+		if rev.Id == from.Id {
+			fromIdx = idx
+		}
+	}
+	if toIdx == -1 || fromIdx == -1 || fromIdx < toIdx {
+		return []*revision.Revision{to}
+	}
+	return revs[toIdx:fromIdx]
+}
+
 // VFS implements the Child interface.
 func (c *DockerChild) VFS(ctx context.Context, rev *revision.Revision) (vfs.FS, error) {
 	return nil, skerr.Fmt("VFS not implemented for DockerChild")
@@ -2,6 +2,7 @@ package child
 
 import (
 	"context"
+	"regexp"
 	"testing"
 	"time"
 
@@ -60,6 +61,51 @@ func TestDockerChild_GetRevision(t *testing.T) {
 	}, rev)
 }
 
+func TestDockerChild_GetRevision_Semver_DisplaysTag(t *testing.T) {
+	ctx := context.Background()
+	ts := time.Unix(1682445445, 0).UTC()
+	manifest := &docker.Manifest{
+		Digest: "sha256:ccc",
+		Config: docker.MediaConfig{
+			Digest: fakeDockerConfigDigest,
+		},
+	}
+	client := &mocks.Client{}
+	client.On("GetManifest", testutils.AnyContext, fakeDockerRegistry, fakeDockerRepo, "sha256:ccc").Return(manifest, nil)
+	client.On("GetManifest", testutils.AnyContext, fakeDockerRegistry, fakeDockerRepo, "v1.9.0").Return(manifest, nil)
+	client.On("GetConfig", testutils.AnyContext, fakeDockerRegistry, fakeDockerRepo, fakeDockerConfigDigest).Return(fakeDockerConfig, nil)
+	client.On("ListInstances", testutils.AnyContext, fakeDockerRegistry, fakeDockerRepo).Return(map[string]*docker.ImageInstance{
+		"sha256:aaa": {Digest: "sha256:aaa", Tags: []string{"v1.2.3"}, Created: ts},
+		"sha256:ccc": {Digest: "sha256:ccc", Tags: []string{"v1.9.0", "v1.9.1"}, Created: ts.Add(time.Hour)},
+	}, nil)
+	c := &DockerChild{
+		client:      client,
+		registry:    fakeDockerRegistry,
+		repo:        fakeDockerRepo,
+		semverRegex: regexp.MustCompile(`^v(\d+)\.(\d+)\.(\d+)$`),
+	}
+	expected := &revision.Revision{
+		Id:        "sha256:ccc",
+		Checksum:  "sha256:ccc",
+		Author:    "Bazel",
+		Display:   "v1.9.1",
+		Timestamp: fakeDockerConfig.History[0].Created,
+	}
+
+	// The Revision is displayed using the same tag as in listSemverRevisions,
+	// whether it is retrieved by digest or by any of its tags.
+	rev, err := c.GetRevision(ctx, "sha256:ccc")
+	require.NoError(t, err)
+	require.Equal(t, expected, rev)
+	rev, err = c.GetRevision(ctx, "v1.9.0")
+	require.NoError(t, err)
+	require.Equal(t, expected, rev)
+
+	revs, err := c.listSemverRevisions(ctx)
+	require.NoError(t, err)
+	require.Equal(t, expected.Display, revs[0].Display)
+}
+
 func TestDockerChild_Update(t *testing.T) {
 	ctx := context.Background()
 	client := &mocks.Client{}
@@ -82,3 +128,57 @@ func TestDockerChild_Update(t *testing.T) {
 	}, tipRev)
 	require.Equal(t, []*revision.Revision{tipRev}, notRolledRevs)
 }
+
+func TestDockerChild_Update_Semver(t *testing.T) {
+	ctx := context.Background()
+	ts := time.Unix(1682445445, 0).UTC()
+	client := &mocks.Client{}
+	client.On("ListInstances", testutils.AnyContext, fakeDockerRegistry, fakeDockerRepo).Return(map[string]*docker.ImageInstance{
+		"sha256:aaa": {Digest: "sha256:aaa", Tags: []string{"v1.2.3"}, Created: ts},
+		"sha256:bbb": {Digest: "sha256:bbb", Tags: []string{"latest", "v1.10.0"}, Created: ts.Add(time.Hour)},
+		"sha256:ccc": {Digest: "sha256:ccc", Tags: []string{"v1.9.0", "v1.9.1"}, Created: ts.Add(2 * time.Hour)},
+		"sha256:ddd": {Digest: "sha256:ddd", Tags: []string{"untagged-build"}, Created: ts.Add(3 * time.Hour)},
+	}, nil)
+	c := &DockerChild{
+		client:      client,
+		registry:    fakeDockerRegistry,
+		repo:        fakeDockerRepo,
+		semverRegex: regexp.MustCompile(`^v(\d+)\.(\d+)\.(\d+)$`),
+	}
+	rev123 := &revision.Revision{Id: "sha256:aaa", Checksum: "sha256:aaa", Display: "v1.2.3", Timestamp: ts}
+	rev1100 := &revision.Revision{Id: "sha256:bbb", Checksum: "sha256:bbb", Display: "v1.10.0", Timestamp: ts.Add(time.Hour)}
+	rev191 := &revision.Revision{Id: "sha256:ccc", Checksum: "sha256:ccc", Display: "v1.9.1", Timestamp: ts.Add(2 * time.Hour)}
+
+	tipRev, notRolledRevs, err := c.Update(ctx, rev123)
+	require.NoError(t, err)
+	require.Equal(t, rev1100, tipRev)
+	require.Equal(t, []*revision.Revision{rev1100, rev191}, notRolledRevs)
+
+	// Already up to date.
+	tipRev, notRolledRevs, err = c.Update(ctx, rev1100)
+	require.NoError(t, err)
+	require.Equal(t, rev1100, tipRev)
+	require.Empty(t, notRolledRevs)
+
+	// Unknown last-rolled revision.
+	tipRev, notRolledRevs, err = c.Update(ctx, &revision.Revision{Id: "sha256:bbad"})
+	require.NoError(t, err)
+	require.Equal(t, rev1100, tipRev)
+	require.Equal(t, []*revision.Revision{rev1100}, notRolledRevs)
+}
+
+func TestDockerChild_Update_Semver_NoMatchingTags(t *testing.T) {
+	ctx := context.Background()
+	client := &mocks.Client{}
+	client.On("ListInstances", testutils.AnyContext, fakeDockerRegistry, fakeDockerRepo).Return(map[string]*docker.ImageInstance{
+		"sha256:aaa": {Digest: "sha256:aaa", Tags: []string{"latest"}},
+	}, nil)
+	c := &DockerChild{
+		client:      client,
+		registry:    fakeDockerRegistry,
+		repo:        fakeDockerRepo,
+		semverRegex: regexp.MustCompile(`^v(\d+)\.(\d+)\.(\d+)$`),
+	}
+	_, _, err := c.Update(ctx, &revision.Revision{Id: "sha256:aaa"})
+	require.ErrorContains(t, err, "no tags in")
+}
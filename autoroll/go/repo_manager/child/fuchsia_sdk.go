@@ -53,6 +53,11 @@ func (c *FuchsiaSDKChild) GetRevision(ctx context.Context, id string) (*revision
 	return fuchsiaSDKVersionToRevision(id), nil
 }
 
+// GetRevisions implements Child.
+func (c *FuchsiaSDKChild) GetRevisions(ctx context.Context, ids []string) ([]*revision.Revision, error) {
+	return getRevisionsSequential(ctx, c.GetRevision, ids)
+}
+
 // LogRevisions implements Child.
 func (c *FuchsiaSDKChild) LogRevisions(ctx context.Context, from, to *revision.Revision) ([]*revision.Revision, error) {
 	// We cannot compute LogRevisions correctly because there are things
@@ -167,6 +167,11 @@ func (c *gcsChild) GetRevision(ctx context.Context, id string) (*revision.Revisi
 	return rv, nil
 }
 
+// See documentation for Child interface.
+func (c *gcsChild) GetRevisions(ctx context.Context, ids []string) ([]*revision.Revision, error) {
+	return getRevisionsSequential(ctx, c.GetRevision, ids)
+}
+
 // getAllRevisions returns all available revisions in the bucket, sorted newest to oldest.
 func (c *gcsChild) getAllRevisions(ctx context.Context) ([]*revision.Revision, error) {
 	versions := []gcsVersion{}
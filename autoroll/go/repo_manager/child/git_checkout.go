@@ -42,6 +42,11 @@ func (c *GitCheckoutChild) Update(ctx context.Context, lastRollRev *revision.Rev
 	return tipRev, notRolledRevs, nil
 }
 
+// GetRevisions implements Child.
+func (c *GitCheckoutChild) GetRevisions(ctx context.Context, ids []string) ([]*revision.Revision, error) {
+	return getRevisionsSequential(ctx, c.GetRevision, ids)
+}
+
 // VFS implements the Child interface.
 func (c *GitCheckoutChild) VFS(ctx context.Context, rev *revision.Revision) (vfs.FS, error) {
 	return c.Checkout.VFS(ctx, rev.Id)
@@ -65,6 +65,11 @@ func (c *GitCheckoutGithubChild) GetRevision(ctx context.Context, id string) (*r
 	return rev, nil
 }
 
+// GetRevisions implements Child.
+func (c *GitCheckoutGithubChild) GetRevisions(ctx context.Context, ids []string) ([]*revision.Revision, error) {
+	return getRevisionsSequential(ctx, c.GetRevision, ids)
+}
+
 // Update implements Child.
 func (c *GitCheckoutGithubChild) Update(ctx context.Context, lastRollRev *revision.Revision) (*revision.Revision, []*revision.Revision, error) {
 	tipRev, notRolledRevs, err := c.GitCheckoutChild.Update(ctx, lastRollRev)
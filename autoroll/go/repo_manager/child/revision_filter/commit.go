@@ -0,0 +1,160 @@
+package revision_filter
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"path"
+	"regexp"
+	"strings"
+
+	"go.skia.org/infra/autoroll/go/config"
+	"go.skia.org/infra/autoroll/go/revision"
+	"go.skia.org/infra/go/gitiles"
+	"go.skia.org/infra/go/skerr"
+)
+
+// devNull is used by Gitiles as the path for the missing side of an added or
+// deleted file.
+const devNull = "/dev/null"
+
+// CommitRevisionFilter is a RevisionFilter which skips Revisions based on their
+// commit messages, their authors, or the files they modify. Path filtering
+// only works with rollers which use a commit hash as the Revision ID.
+type CommitRevisionFilter struct {
+	commitMsgRegexes []*regexp.Regexp
+	authors          []string
+	pathGlobs        []string
+	getTreeDiffs     func(ctx context.Context, ref string) ([]*gitiles.TreeDiff, error)
+}
+
+// NewCommitRevisionFilter returns a RevisionFilter which skips Revisions based
+// on their commit messages, their authors, or the files they modify.
+func NewCommitRevisionFilter(client *http.Client, cfg *config.CommitRevisionFilterConfig) (*CommitRevisionFilter, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, skerr.Wrap(err)
+	}
+	regexes := make([]*regexp.Regexp, 0, len(cfg.CommitMsgRegex))
+	for _, r := range cfg.CommitMsgRegex {
+		regex, err := regexp.Compile(r)
+		if err != nil {
+			return nil, skerr.Wrap(err)
+		}
+		regexes = append(regexes, regex)
+	}
+	var getTreeDiffs func(ctx context.Context, ref string) ([]*gitiles.TreeDiff, error)
+	if cfg.RepoUrl != "" {
+		getTreeDiffs = gitiles.NewRepo(cfg.RepoUrl, client).GetTreeDiffs
+	}
+	return &CommitRevisionFilter{
+		commitMsgRegexes: regexes,
+		authors:          cfg.Author,
+		pathGlobs:        cfg.PathGlob,
+		getTreeDiffs:     getTreeDiffs,
+	}, nil
+}
+
+// Skip implements RevisionFilter.
+func (f *CommitRevisionFilter) Skip(ctx context.Context, r revision.Revision) (string, error) {
+	commitMsg := r.Description
+	if r.Details != "" {
+		commitMsg += "\n\n" + r.Details
+	}
+	for _, regex := range f.commitMsgRegexes {
+		if regex.MatchString(commitMsg) {
+			return fmt.Sprintf("commit message matches %q", regex.String()), nil
+		}
+	}
+	for _, author := range f.authors {
+		if authorMatches(r.Author, author) {
+			return fmt.Sprintf("author %q is excluded", author), nil
+		}
+	}
+	if len(f.pathGlobs) > 0 {
+		diffs, err := f.getTreeDiffs(ctx, r.Id)
+		if err != nil {
+			return "", skerr.Wrapf(err, "failed to retrieve modified files for %s", r.Id)
+		}
+		if onlyModifiesMatchingPaths(diffs, f.pathGlobs) {
+			return fmt.Sprintf("only modifies files matching %s", strings.Join(f.pathGlobs, ", ")), nil
+		}
+	}
+	return "", nil
+}
+
+// Update implements RevisionFilter.
+func (f *CommitRevisionFilter) Update(_ context.Context) error {
+	return nil
+}
+
+// authorMatches returns true if the given Revision author, which may be either
+// a bare email address or of the form "Name <email>", refers to author.
+func authorMatches(revAuthor, author string) bool {
+	return revAuthor == author || strings.Contains(revAuthor, "<"+author+">")
+}
+
+// onlyModifiesMatchingPaths returns true if the given TreeDiffs are non-empty
+// and every modified path matches at least one of the globs.
+func onlyModifiesMatchingPaths(diffs []*gitiles.TreeDiff, globs []string) bool {
+	found := false
+	for _, diff := range diffs {
+		for _, p := range []string{diff.OldPath, diff.NewPath} {
+			if p == "" || p == devNull {
+				continue
+			}
+			found = true
+			if !matchesAnyGlob(p, globs) {
+				return false
+			}
+		}
+	}
+	return found
+}
+
+// matchesAnyGlob returns true if the given path matches any of the globs.
+func matchesAnyGlob(p string, globs []string) bool {
+	for _, glob := range globs {
+		if matchGlob(glob, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchGlob returns true if the given path matches the glob. Globs use the
+// syntax of path.Match, with the addition that a "**" path element matches
+// any number of directories. Globs which contain no "/" are matched against
+// the base name of the path, so that eg. "*.md" matches files in any
+// directory.
+func matchGlob(glob, p string) bool {
+	if !strings.Contains(glob, "/") && glob != "**" {
+		match, _ := path.Match(glob, path.Base(p))
+		return match
+	}
+	return matchGlobElems(strings.Split(glob, "/"), strings.Split(p, "/"))
+}
+
+// matchGlobElems is a helper for matchGlob which matches the glob against the
+// path one element at a time.
+func matchGlobElems(glob, p []string) bool {
+	if len(glob) == 0 {
+		return len(p) == 0
+	}
+	if glob[0] == "**" {
+		for i := 0; i <= len(p); i++ {
+			if matchGlobElems(glob[1:], p[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(p) == 0 {
+		return false
+	}
+	if match, _ := path.Match(glob[0], p[0]); !match {
+		return false
+	}
+	return matchGlobElems(glob[1:], p[1:])
+}
+
+var _ RevisionFilter = &CommitRevisionFilter{}
@@ -0,0 +1,107 @@
+package revision_filter
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.skia.org/infra/autoroll/go/config"
+	"go.skia.org/infra/autoroll/go/revision"
+	"go.skia.org/infra/go/gitiles"
+)
+
+func TestCommitRevisionFilter(t *testing.T) {
+
+	ctx := context.Background()
+	treeDiffs := map[string][]*gitiles.TreeDiff{
+		"docs-only": {
+			{Type: "modify", OldPath: "README.md", NewPath: "README.md"},
+			{Type: "add", OldPath: "/dev/null", NewPath: "docs/guide/intro.md"},
+		},
+		"infra-only": {
+			{Type: "delete", OldPath: "infra/bots/tasks.json", NewPath: "/dev/null"},
+		},
+		"mixed": {
+			{Type: "modify", OldPath: "README.md", NewPath: "README.md"},
+			{Type: "modify", OldPath: "src/main.cpp", NewPath: "src/main.cpp"},
+		},
+		"empty": {},
+	}
+	rf := &CommitRevisionFilter{
+		commitMsgRegexes: []*regexp.Regexp{regexp.MustCompile(`^Revert "`)},
+		authors:          []string{"some-bot@example.com"},
+		pathGlobs:        []string{"*.md", "infra/**"},
+		getTreeDiffs: func(_ context.Context, ref string) ([]*gitiles.TreeDiff, error) {
+			diffs, ok := treeDiffs[ref]
+			if !ok {
+				return nil, errors.New("unknown ref")
+			}
+			return diffs, nil
+		},
+	}
+
+	test := func(name string, rev revision.Revision, expectReason string) {
+		t.Run(name, func(t *testing.T) {
+			skipReason, err := rf.Skip(ctx, rev)
+			require.NoError(t, err)
+			require.Equal(t, expectReason, skipReason)
+		})
+	}
+
+	test("commit_msg_match", revision.Revision{
+		Id:          "mixed",
+		Description: `Revert "Add a feature"`,
+	}, `commit message matches "^Revert \""`)
+	test("author_match_bare", revision.Revision{
+		Id:     "mixed",
+		Author: "some-bot@example.com",
+	}, `author "some-bot@example.com" is excluded`)
+	test("author_match_name", revision.Revision{
+		Id:     "mixed",
+		Author: "Some Bot <some-bot@example.com>",
+	}, `author "some-bot@example.com" is excluded`)
+	test("author_no_match", revision.Revision{
+		Id:     "mixed",
+		Author: "other-some-bot@example.com",
+	}, "")
+	test("docs_only", revision.Revision{Id: "docs-only"}, "only modifies files matching *.md, infra/**")
+	test("infra_only", revision.Revision{Id: "infra-only"}, "only modifies files matching *.md, infra/**")
+	test("mixed", revision.Revision{Id: "mixed"}, "")
+	test("empty", revision.Revision{Id: "empty"}, "")
+
+	t.Run("tree_diff_error", func(t *testing.T) {
+		_, err := rf.Skip(ctx, revision.Revision{Id: "unknown"})
+		require.ErrorContains(t, err, "unknown ref")
+	})
+}
+
+func TestMatchGlob(t *testing.T) {
+
+	test := func(glob, p string, expect bool) {
+		require.Equal(t, expect, matchGlob(glob, p), "%s %s", glob, p)
+	}
+	test("*.md", "README.md", true)
+	test("*.md", "a/b/README.md", true)
+	test("*.md", "README.txt", false)
+	test("docs/*.md", "docs/README.md", true)
+	test("docs/*.md", "docs/a/README.md", false)
+	test("docs/**/*.md", "docs/README.md", true)
+	test("docs/**/*.md", "docs/a/b/README.md", true)
+	test("infra/**", "infra/bots/tasks.json", true)
+	test("infra/**", "src/infra/bots/tasks.json", false)
+	test("**", "any/path", true)
+}
+
+func TestNewCommitRevisionFilter_InvalidConfig(t *testing.T) {
+
+	_, err := NewCommitRevisionFilter(nil, &config.CommitRevisionFilterConfig{
+		PathGlob: []string{"*.md"},
+	})
+	require.ErrorContains(t, err, "RepoUrl is required")
+	_, err = NewCommitRevisionFilter(nil, &config.CommitRevisionFilterConfig{
+		CommitMsgRegex: []string{"("},
+	})
+	require.ErrorContains(t, err, "invalid CommitMsgRegex")
+}
@@ -16,25 +16,38 @@ import (
 // ready for review and starting the commit queue (or submitting the change
 // outright, if there is no configured commit queue).
 func SetChangeLabels(ctx context.Context, g gerrit.GerritInterface, ci *gerrit.ChangeInfo, emails []string, dryRun bool) error {
-	// Mark the change as ready for review, if necessary.
-	if err := UnsetWIP(ctx, g, ci, 0); err != nil {
-		return skerr.Wrapf(err, "failed to unset WIP")
-	}
+	return SetChainLabels(ctx, g, []*gerrit.ChangeInfo{ci}, emails, dryRun)
+}
 
+// SetChainLabels is like SetChangeLabels, but for a chain of dependent
+// changes, ordered from the base of the chain to its tip. Only the tip of the
+// chain is sent to the commit queue (or submitted, if there is no configured
+// commit queue), which lands the whole chain at once; the other changes are
+// only approved, so that none of them can land on its own.
+func SetChainLabels(ctx context.Context, g gerrit.GerritInterface, chain []*gerrit.ChangeInfo, emails []string, dryRun bool) error {
 	// Set the CQ bit as appropriate.
-	labels := g.Config().SetCqLabels
+	cqLabels := g.Config().SetCqLabels
 	if dryRun {
-		labels = g.Config().SetDryRunLabels
+		cqLabels = g.Config().SetDryRunLabels
 	}
-	labels = gerrit.MergeLabels(labels, g.Config().SelfApproveLabels)
-	if err := g.SetReview(ctx, ci, "", labels, emails, "", nil, "", 0, nil); err != nil {
-		// TODO(borenet): Should we try to abandon the CL?
-		return skerr.Wrapf(err, "failed to set review")
+	for idx, ci := range chain {
+		// Mark the change as ready for review, if necessary.
+		if err := UnsetWIP(ctx, g, ci, 0); err != nil {
+			return skerr.Wrapf(err, "failed to unset WIP")
+		}
+		labels := g.Config().SelfApproveLabels
+		if idx == len(chain)-1 {
+			labels = gerrit.MergeLabels(cqLabels, labels)
+		}
+		if err := g.SetReview(ctx, ci, "", labels, emails, "", nil, "", 0, nil); err != nil {
+			// TODO(borenet): Should we try to abandon the CL?
+			return skerr.Wrapf(err, "failed to set review")
+		}
 	}
 
 	// Manually submit if necessary.
-	if !g.Config().HasCq {
-		if err := g.Submit(ctx, ci); err != nil {
+	if !g.Config().HasCq && len(chain) > 0 {
+		if err := g.Submit(ctx, chain[len(chain)-1]); err != nil {
 			// TODO(borenet): Should we try to abandon the CL?
 			return skerr.Wrapf(err, "failed to submit")
 		}
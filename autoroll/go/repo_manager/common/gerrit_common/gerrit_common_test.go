@@ -0,0 +1,42 @@
+package gerrit_common
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.skia.org/infra/go/gerrit"
+	"go.skia.org/infra/go/gerrit/mocks"
+)
+
+func TestSetChainLabels_OnlyTipIsSentToCQ(t *testing.T) {
+	ctx := context.Background()
+	base := &gerrit.ChangeInfo{Issue: 121}
+	middle := &gerrit.ChangeInfo{Issue: 122}
+	tip := &gerrit.ChangeInfo{Issue: 123}
+	emails := []string{"reviewer@google.com"}
+	approve := map[string]int{gerrit.LabelCodeReview: gerrit.LabelCodeReviewApprove}
+	g := &mocks.GerritInterface{}
+	g.On("Config").Return(gerrit.ConfigChromium)
+	g.On("SetReview", ctx, base, "", approve, emails, gerrit.NotifyOption(""), gerrit.NotifyDetails(nil), "", 0, []*gerrit.AttentionSetInput(nil)).Return(nil)
+	g.On("SetReview", ctx, middle, "", approve, emails, gerrit.NotifyOption(""), gerrit.NotifyDetails(nil), "", 0, []*gerrit.AttentionSetInput(nil)).Return(nil)
+	g.On("SetReview", ctx, tip, "", map[string]int{
+		gerrit.LabelCodeReview:  gerrit.LabelCodeReviewApprove,
+		gerrit.LabelCommitQueue: gerrit.LabelCommitQueueSubmit,
+	}, emails, gerrit.NotifyOption(""), gerrit.NotifyDetails(nil), "", 0, []*gerrit.AttentionSetInput(nil)).Return(nil)
+	require.NoError(t, SetChainLabels(ctx, g, []*gerrit.ChangeInfo{base, middle, tip}, emails, false))
+	g.AssertExpectations(t)
+}
+
+func TestSetChainLabels_NoCQ_SubmitsTip(t *testing.T) {
+	ctx := context.Background()
+	base := &gerrit.ChangeInfo{Issue: 121}
+	tip := &gerrit.ChangeInfo{Issue: 122}
+	g := &mocks.GerritInterface{}
+	g.On("Config").Return(gerrit.ConfigChromiumNoCQ)
+	g.On("SetReview", ctx, base, "", gerrit.ConfigChromiumNoCQ.SelfApproveLabels, []string(nil), gerrit.NotifyOption(""), gerrit.NotifyDetails(nil), "", 0, []*gerrit.AttentionSetInput(nil)).Return(nil)
+	g.On("SetReview", ctx, tip, "", gerrit.MergeLabels(gerrit.ConfigChromiumNoCQ.SetCqLabels, gerrit.ConfigChromiumNoCQ.SelfApproveLabels), []string(nil), gerrit.NotifyOption(""), gerrit.NotifyDetails(nil), "", 0, []*gerrit.AttentionSetInput(nil)).Return(nil)
+	g.On("Submit", ctx, tip).Return(nil)
+	require.NoError(t, SetChainLabels(ctx, g, []*gerrit.ChangeInfo{base, tip}, nil, false))
+	g.AssertExpectations(t)
+}
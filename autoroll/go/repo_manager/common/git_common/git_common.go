@@ -3,6 +3,7 @@ package git_common
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
@@ -28,6 +29,7 @@ type Checkout struct {
 	Dependencies      []*config.VersionFileConfig
 	RepoURL           string
 	RevLinkTmpl       string
+	TransitiveDeps    bool
 }
 
 // NewCheckout returns a Checkout instance.
@@ -66,6 +68,7 @@ func NewCheckout(ctx context.Context, c *config.GitCheckoutConfig, reg *config_v
 		Dependencies:      c.Dependencies,
 		RepoURL:           c.RepoUrl,
 		RevLinkTmpl:       c.RevLinkTmpl,
+		TransitiveDeps:    c.TransitiveDependencies,
 	}, nil
 }
 
@@ -76,15 +79,13 @@ func (c *Checkout) GetRevision(ctx context.Context, id string) (*revision.Revisi
 		return nil, skerr.Wrap(err)
 	}
 	rev := revision.FromLongCommit(c.RevLinkTmpl, c.defaultBugProject, details)
-	if len(c.Dependencies) > 0 {
-		deps, err := version_file_common.GetPinnedRevs(ctx, c.Dependencies, func(ctx context.Context, path string) (string, error) {
-			return c.GetFile(ctx, path, rev.Id)
-		})
-		if err != nil {
-			return nil, skerr.Wrap(err)
-		}
-		rev.Dependencies = deps
+	deps, err := version_file_common.GetDependencies(ctx, c.Dependencies, c.TransitiveDeps, func(ctx context.Context, path string) (string, error) {
+		return c.GetFile(ctx, path, rev.Id)
+	})
+	if err != nil {
+		return nil, skerr.Wrap(err)
 	}
+	rev.Dependencies = deps
 	return rev, nil
 }
 
@@ -143,47 +144,76 @@ type UploadRollFunc func(context.Context, *git.Checkout, string, string, []strin
 // functions.
 // See documentation for the Parent interface for more details.
 func (c *Checkout) CreateNewRoll(ctx context.Context, from, to *revision.Revision, rolling []*revision.Revision, emails []string, dryRun bool, commitMsg string, createRoll CreateRollFunc, uploadRoll UploadRollFunc) (int64, error) {
+	upstreamBranch, hash, err := c.createRollCommit(ctx, from, to, rolling, commitMsg, createRoll)
+	if err != nil {
+		return 0, skerr.Wrap(err)
+	}
+
+	// Upload the CL.
+	return uploadRoll(ctx, c.Checkout, upstreamBranch, hash, emails, dryRun, commitMsg)
+}
+
+// PreviewRoll creates a new roll using the given createRoll function, exactly
+// as CreateNewRoll would, but instead of uploading a CL it writes the resulting
+// commit(s) to w as a patch.
+func (c *Checkout) PreviewRoll(ctx context.Context, from, to *revision.Revision, rolling []*revision.Revision, commitMsg string, createRoll CreateRollFunc, w io.Writer) error {
+	upstreamBranch, hash, err := c.createRollCommit(ctx, from, to, rolling, commitMsg, createRoll)
+	if err != nil {
+		return skerr.Wrap(err)
+	}
+	patch, err := c.Git(ctx, "format-patch", "--stdout", fmt.Sprintf("%s..%s", upstreamBranch, hash))
+	if err != nil {
+		return skerr.Wrap(err)
+	}
+	if _, err := io.WriteString(w, patch); err != nil {
+		return skerr.Wrap(err)
+	}
+	return nil
+}
+
+// createRollCommit creates the roll branch and runs the given createRoll
+// function on it. Returns the upstream branch and the hash of the commit to be
+// uploaded.
+func (c *Checkout) createRollCommit(ctx context.Context, from, to *revision.Revision, rolling []*revision.Revision, commitMsg string, createRoll CreateRollFunc) (string, string, error) {
 	// Create the roll branch.
 	_, upstreamBranch, err := c.Update(ctx)
 	if err != nil {
-		return 0, skerr.Wrap(err)
+		return "", "", skerr.Wrap(err)
 	}
 	_, _ = c.Git(ctx, "branch", "-D", RollBranch) // Fails if the branch does not exist.
 	if _, err := c.Git(ctx, "checkout", "-b", RollBranch, "-t", fmt.Sprintf("origin/%s", upstreamBranch)); err != nil {
-		return 0, skerr.Wrap(err)
+		return "", "", skerr.Wrap(err)
 	}
 	if _, err := c.Git(ctx, "reset", "--hard", upstreamBranch); err != nil {
-		return 0, skerr.Wrap(err)
+		return "", "", skerr.Wrap(err)
 	}
 
 	// Run the provided function to create the changes for the roll.
 	hash, err := createRoll(ctx, c.Checkout, from, to, rolling, commitMsg)
 	if err != nil {
-		return 0, skerr.Wrap(err)
+		return "", "", skerr.Wrap(err)
 	}
 
 	// Ensure that createRoll generated at least one commit downstream of
 	// p.baseCommit, and that it did not leave uncommitted changes.
 	commits, err := c.RevList(ctx, "--ancestry-path", "--first-parent", fmt.Sprintf("%s..%s", upstreamBranch, hash))
 	if err != nil {
-		return 0, skerr.Wrap(err)
+		return "", "", skerr.Wrap(err)
 	}
 	if len(commits) == 0 {
-		return 0, skerr.Fmt("createRoll generated no commits!")
+		return "", "", skerr.Fmt("createRoll generated no commits!")
 	}
 	if _, err := c.Git(ctx, "diff", "--quiet"); err != nil {
-		return 0, skerr.Wrapf(err, "createRoll left uncommitted changes")
+		return "", "", skerr.Wrapf(err, "createRoll left uncommitted changes")
 	}
 	out, err := c.Git(ctx, "ls-files", "--others", "--exclude-standard")
 	if err != nil {
-		return 0, skerr.Wrap(err)
+		return "", "", skerr.Wrap(err)
 	}
 	if len(strings.Fields(out)) > 0 {
-		return 0, skerr.Fmt("createRoll left untracked files:\n%s", out)
+		return "", "", skerr.Fmt("createRoll left untracked files:\n%s", out)
 	}
-
-	// Upload the CL.
-	return uploadRoll(ctx, c.Checkout, upstreamBranch, hash, emails, dryRun, commitMsg)
+	return upstreamBranch, hash, nil
 }
 
 // Clone clones the given repo into the given destination and syncs it to the
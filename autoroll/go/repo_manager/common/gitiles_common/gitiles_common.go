@@ -14,14 +14,20 @@ import (
 	"go.skia.org/infra/go/vcsinfo"
 	"go.skia.org/infra/go/vfs"
 	gitiles_vfs "go.skia.org/infra/go/vfs/gitiles"
+	"golang.org/x/sync/errgroup"
 )
 
+// getRevisionsConcurrency is the maximum number of concurrent requests made
+// by GetRevisions.
+const getRevisionsConcurrency = 10
+
 // GitilesRepo provides helpers for dealing with repos which use Gitiles.
 type GitilesRepo struct {
 	gitiles.GitilesRepo
 	branch            *config_vars.Template
 	defaultBugProject string
 	deps              []*config.VersionFileConfig
+	transitiveDeps    bool
 }
 
 // NewGitilesRepo returns a GitilesRepo instance.
@@ -42,6 +48,7 @@ func NewGitilesRepo(ctx context.Context, c *config.GitilesConfig, reg *config_va
 		branch:            branch,
 		deps:              c.Dependencies,
 		defaultBugProject: c.DefaultBugProject,
+		transitiveDeps:    c.TransitiveDependencies,
 	}, nil
 }
 
@@ -62,15 +69,13 @@ func (r *GitilesRepo) GetRevision(ctx context.Context, id string) (*revision.Rev
 	rev := revision.FromLongCommit(revLinkTmpl, r.defaultBugProject, details)
 
 	// Optionally load any dependencies.
-	if len(r.deps) > 0 {
-		deps, err := version_file_common.GetPinnedRevs(ctx, r.deps, func(ctx context.Context, path string) (string, error) {
-			return r.GetFile(ctx, path, rev.Id)
-		})
-		if err != nil {
-			return nil, skerr.Wrap(err)
-		}
-		rev.Dependencies = deps
+	deps, err := version_file_common.GetDependencies(ctx, r.deps, r.transitiveDeps, func(ctx context.Context, path string) (string, error) {
+		return r.GetFile(ctx, path, rev.Id)
+	})
+	if err != nil {
+		return nil, skerr.Wrap(err)
 	}
+	rev.Dependencies = deps
 	return rev, nil
 }
 
@@ -93,15 +98,39 @@ func (r *GitilesRepo) GetTipRevision(ctx context.Context) (*revision.Revision, e
 	return r.GetRevision(ctx, r.branch.String())
 }
 
+// GetRevisions returns revision.Revision instances associated with the given
+// revision IDs, in the same order. Gitiles has no batch API, so the revisions
+// are retrieved concurrently.
+func (r *GitilesRepo) GetRevisions(ctx context.Context, ids []string) ([]*revision.Revision, error) {
+	revs := make([]*revision.Revision, len(ids))
+	eg, ctx := errgroup.WithContext(ctx)
+	eg.SetLimit(getRevisionsConcurrency)
+	for idx, id := range ids {
+		idx, id := idx, id
+		eg.Go(func() error {
+			rev, err := r.GetRevision(ctx, id)
+			if err != nil {
+				return skerr.Wrap(err)
+			}
+			revs[idx] = rev
+			return nil
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+	return revs, nil
+}
+
 // ConvertRevisions converts the given slice of LongCommits to Revisions.
 func (r *GitilesRepo) ConvertRevisions(ctx context.Context, commits []*vcsinfo.LongCommit) ([]*revision.Revision, error) {
-	revs := make([]*revision.Revision, 0, len(commits))
+	ids := make([]string, 0, len(commits))
 	for _, commit := range commits {
-		rev, err := r.GetRevision(ctx, commit.Hash)
-		if err != nil {
-			return nil, skerr.Wrapf(err, "Failed to retrieve revision")
-		}
-		revs = append(revs, rev)
+		ids = append(ids, commit.Hash)
+	}
+	revs, err := r.GetRevisions(ctx, ids)
+	if err != nil {
+		return nil, skerr.Wrapf(err, "Failed to retrieve revisions")
 	}
 	return revs, nil
 }
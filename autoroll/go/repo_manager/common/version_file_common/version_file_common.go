@@ -87,6 +87,46 @@ func GetPinnedRevs(ctx context.Context, deps []*config.VersionFileConfig, getFil
 	return rv, nil
 }
 
+// GetDependencies reads files using the given GetFileFunc to retrieve the
+// revisions of the given dependencies. If transitive is true, all of the
+// dependencies pinned in the DEPS file are also included, keyed by normalized
+// dependency ID. Returns nil if there are no dependencies to retrieve.
+func GetDependencies(ctx context.Context, deps []*config.VersionFileConfig, transitive bool, getFile GetFileFunc) (map[string]string, error) {
+	if len(deps) == 0 && !transitive {
+		return nil, nil
+	}
+	rv := map[string]string{}
+	if transitive {
+		contents, err := getFile(ctx, deps_parser.DepsFileName)
+		if err != nil {
+			return nil, skerr.Wrap(err)
+		}
+		entries, err := deps_parser.ParseDeps(contents)
+		if err != nil {
+			return nil, skerr.Wrap(err)
+		}
+		for id, entry := range entries {
+			rv[id] = entry.Version
+		}
+		// Avoid retrieving the DEPS file again below.
+		getFileOrig := getFile
+		getFile = func(ctx context.Context, path string) (string, error) {
+			if path == deps_parser.DepsFileName {
+				return contents, nil
+			}
+			return getFileOrig(ctx, path)
+		}
+	}
+	pinned, err := GetPinnedRevs(ctx, deps, getFile)
+	if err != nil {
+		return nil, skerr.Wrap(err)
+	}
+	for id, version := range pinned {
+		rv[id] = version
+	}
+	return rv, nil
+}
+
 // SetPinnedRev updates the given dependency pin in the given file, returning
 // the new contents.
 func SetPinnedRev(dep *config.VersionFileConfig, newRev *revision.Revision, oldContents string) (string, error) {
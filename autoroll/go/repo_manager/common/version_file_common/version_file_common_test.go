@@ -332,3 +332,54 @@ Transitive-dep-version: transitive-dep-new-rev;
 `,
 	}, changes)
 }
+
+func TestGetDependencies(t *testing.T) {
+
+	ctx := context.Background()
+	files := map[string]string{
+		deps_parser.DepsFileName: `deps = {
+			"a": "https://a.googlesource.com/a.git@a-rev",
+			"b": "https://b.googlesource.com/b.git@b-rev",
+		}`,
+		"version-file": "c-rev",
+	}
+	gets := map[string]int{}
+	getFile := func(ctx context.Context, path string) (string, error) {
+		gets[path]++
+		contents, ok := files[path]
+		if !ok {
+			return "", fmt.Errorf("unknown file %q", path)
+		}
+		return contents, nil
+	}
+	deps := []*config.VersionFileConfig{
+		{Id: "a", Path: deps_parser.DepsFileName, Regex: `"a": "https://a.googlesource.com/a.git@([a-z-]+)"`},
+		{Id: "c", Path: "version-file"},
+	}
+
+	// No dependencies.
+	actual, err := GetDependencies(ctx, nil, false, getFile)
+	require.NoError(t, err)
+	require.Nil(t, actual)
+	require.Empty(t, gets)
+
+	// Only the configured dependencies.
+	actual, err = GetDependencies(ctx, deps, false, getFile)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		"a": "a-rev",
+		"c": "c-rev",
+	}, actual)
+
+	// Transitive dependencies from the DEPS file.
+	gets = map[string]int{}
+	actual, err = GetDependencies(ctx, deps, true, getFile)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		"a":                    "a-rev",
+		"a.googlesource.com/a": "a-rev",
+		"b.googlesource.com/b": "b-rev",
+		"c":                    "c-rev",
+	}, actual)
+	require.Equal(t, 1, gets[deps_parser.DepsFileName])
+}
@@ -6,6 +6,7 @@ package parent
 
 import (
 	"context"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
@@ -76,6 +77,11 @@ func (p *GitCheckoutParent) CreateNewRoll(ctx context.Context, from, to *revisio
 	return p.Checkout.CreateNewRoll(ctx, from, to, rolling, emails, dryRun, commitMsg, p.createRoll, p.uploadRoll)
 }
 
+// PreviewRoll implements Previewer.
+func (p *GitCheckoutParent) PreviewRoll(ctx context.Context, from, to *revision.Revision, rolling []*revision.Revision, commitMsg string, w io.Writer) error {
+	return p.Checkout.PreviewRoll(ctx, from, to, rolling, commitMsg, p.createRoll, w)
+}
+
 // gitCheckoutFileCreateRollFunc returns a GitCheckoutCreateRollFunc which uses
 // a local Git checkout and pins dependencies using a file checked into the
 // repo.
@@ -134,3 +140,4 @@ func writeFile(ctx context.Context, co *git.Checkout, path, contents string) err
 }
 
 var _ Parent = &GitCheckoutParent{}
+var _ Previewer = &GitCheckoutParent{}
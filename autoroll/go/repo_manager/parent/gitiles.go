@@ -7,10 +7,15 @@ package parent
 import (
 	"context"
 	"fmt"
+	"io"
 	"net/http"
+	"path"
+	"sort"
 	"strconv"
+	"strings"
 	"sync"
 
+	"github.com/pmezard/go-difflib/difflib"
 	"go.skia.org/infra/autoroll/go/codereview"
 	"go.skia.org/infra/autoroll/go/config"
 	"go.skia.org/infra/autoroll/go/config_vars"
@@ -19,6 +24,7 @@ import (
 	"go.skia.org/infra/autoroll/go/revision"
 	"go.skia.org/infra/go/gerrit"
 	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/go/sklog"
 )
 
 // gitilesGetChangesForRollFunc computes the changes to be made in the next
@@ -101,9 +107,54 @@ func (p *gitilesParent) CreateNewRoll(ctx context.Context, from, to *revision.Re
 	p.baseCommitMtx.Lock()
 	defer p.baseCommitMtx.Unlock()
 
+	nextRollChanges, err := p.getNextRollChanges(ctx, from, to, rolling)
+	if err != nil {
+		return 0, skerr.Wrap(err)
+	}
+	return CreateNewGerritRoll(ctx, p.gerrit, p.gerritConfig, p.Branch(), commitMsg, p.baseCommit, nextRollChanges, emails, dryRun)
+}
+
+// PreviewRoll implements Previewer. The changes are written to w as a unified
+// diff against the base commit, preceded by the commit message.
+func (p *gitilesParent) PreviewRoll(ctx context.Context, from, to *revision.Revision, rolling []*revision.Revision, commitMsg string, w io.Writer) error {
+	p.baseCommitMtx.Lock()
+	defer p.baseCommitMtx.Unlock()
+
+	nextRollChanges, err := p.getNextRollChanges(ctx, from, to, rolling)
+	if err != nil {
+		return skerr.Wrap(err)
+	}
+	if _, err := fmt.Fprintf(w, "%s\n\n", strings.TrimSpace(commitMsg)); err != nil {
+		return skerr.Wrap(err)
+	}
+	files := make([]string, 0, len(nextRollChanges))
+	for file := range nextRollChanges {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+	for _, file := range files {
+		oldContents, err := p.readFileAtBaseCommit(ctx, file)
+		if err != nil {
+			return skerr.Wrap(err)
+		}
+		diff, err := unifiedDiff(file, oldContents, nextRollChanges[file])
+		if err != nil {
+			return skerr.Wrap(err)
+		}
+		if _, err := io.WriteString(w, diff); err != nil {
+			return skerr.Wrap(err)
+		}
+	}
+	return nil
+}
+
+// getNextRollChanges computes the changes to be made in the next roll,
+// including those from the external change, if any. The caller must hold
+// baseCommitMtx.
+func (p *gitilesParent) getNextRollChanges(ctx context.Context, from, to *revision.Revision, rolling []*revision.Revision) (map[string]string, error) {
 	nextRollChanges, err := p.getChangesForRoll(ctx, p.GitilesRepo, p.baseCommit, from, to, rolling)
 	if err != nil {
-		return 0, skerr.Wrapf(err, "getChangesForRoll func failed")
+		return nil, skerr.Wrapf(err, "getChangesForRoll func failed")
 	}
 
 	// If the revision contains an external change ID then download the
@@ -111,11 +162,59 @@ func (p *gitilesParent) CreateNewRoll(ctx context.Context, from, to *revision.Re
 	// roll changes.
 	if to.ExternalChangeId != "" {
 		if err := handleExternalChangeId(ctx, nextRollChanges, to.ExternalChangeId, p.gerrit); err != nil {
-			return 0, skerr.Wrapf(err, "handleExternalChangeId func failed")
+			return nil, skerr.Wrapf(err, "handleExternalChangeId func failed")
 		}
 	}
+	return nextRollChanges, nil
+}
 
-	return CreateNewGerritRoll(ctx, p.gerrit, p.gerritConfig.Project, p.Branch(), commitMsg, p.baseCommit, nextRollChanges, emails, dryRun)
+// readFileAtBaseCommit returns the contents of the given file at the base
+// commit, or the empty string if the file does not exist. The caller must hold
+// baseCommitMtx.
+func (p *gitilesParent) readFileAtBaseCommit(ctx context.Context, file string) (string, error) {
+	// Gitiles doesn't distinguish a missing file from any other failure, so
+	// check for the file's existence first.
+	infos, err := p.ListDirAtRef(ctx, path.Dir(file), p.baseCommit)
+	if err != nil {
+		// The directory itself may not exist yet.
+		sklog.Warningf("Failed to list %s at %s; assuming %s is a new file: %s", path.Dir(file), p.baseCommit, file, err)
+		return "", nil
+	}
+	for _, info := range infos {
+		if info.Name() == path.Base(file) {
+			contents, err := p.ReadFileAtRef(ctx, file, p.baseCommit)
+			if err != nil {
+				return "", skerr.Wrap(err)
+			}
+			return string(contents), nil
+		}
+	}
+	return "", nil
+}
+
+// unifiedDiff returns a unified diff of the given file between the old and new
+// contents. An empty string for either indicates that the file does not exist.
+func unifiedDiff(file, oldContents, newContents string) (string, error) {
+	fromFile := "a/" + file
+	if oldContents == "" {
+		fromFile = "/dev/null"
+	}
+	toFile := "b/" + file
+	if newContents == "" {
+		toFile = "/dev/null"
+	}
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        splitLines(oldContents),
+		B:        splitLines(newContents),
+		FromFile: fromFile,
+		ToFile:   toFile,
+		Context:  3,
+		Eol:      "\n",
+	})
+	if err != nil {
+		return "", skerr.Wrap(err)
+	}
+	return diff, nil
 }
 
 // handleExternalChangeId handles the specified externalChangeId as a CL
@@ -146,37 +245,86 @@ func handleExternalChangeId(ctx context.Context, changes map[string]string, exte
 }
 
 // CreateNewGerritRoll uploads a Gerrit CL with the given changes and returns
-// the issue number or any error which occurred.
-func CreateNewGerritRoll(ctx context.Context, g gerrit.GerritInterface, project, branch, commitMsg, baseCommit string, changes map[string]string, emails []string, dryRun bool) (int64, error) {
-	// Create the change.
-	const baseChangeID = ""
-
-	ci, err := gerrit.CreateAndEditChange(ctx, g, project, branch, commitMsg, baseCommit, baseChangeID, func(ctx context.Context, g gerrit.GerritInterface, ci *gerrit.ChangeInfo) error {
-		for file, contents := range changes {
-			if contents == "" {
-				if err := g.DeleteFile(ctx, ci, file); err != nil {
-					return skerr.Wrapf(err, "failed to delete file %s", file)
-				}
-			} else {
-				if err := g.EditFile(ctx, ci, file, contents); err != nil {
-					return skerr.Wrapf(err, "failed to edit file %s", file)
-				}
-			}
-		}
-		return nil
-	})
-	if err != nil {
-		if ci != nil {
+// the issue number or any error which occurred. If the changes modify more
+// files than allowed by the GerritConfig, they are split into a chain of
+// dependent CLs which share a topic, and the issue number of the CL at the tip
+// of the chain is returned.
+func CreateNewGerritRoll(ctx context.Context, g gerrit.GerritInterface, cfg *config.GerritConfig, branch, commitMsg, baseCommit string, changes map[string]string, emails []string, dryRun bool) (int64, error) {
+	groups := splitChanges(changes, int(cfg.MaxFilesPerChange), cfg.SplitByDirectory)
+	chain := make([]*gerrit.ChangeInfo, 0, len(groups))
+	// If we fail to create any of the CLs in the chain, abandon all of them,
+	// since they must land together.
+	failed := func(err error) (int64, error) {
+		for _, ci := range chain {
 			if err2 := g.Abandon(ctx, ci, "Failed to create roll CL"); err2 != nil {
 				return 0, fmt.Errorf("Failed to create roll with: %s\nAnd failed to abandon the change with: %s", err, err2)
 			}
 		}
 		return 0, err
 	}
-	if err := gerrit_common.SetChangeLabels(ctx, g, ci, emails, dryRun); err != nil {
+
+	// Create the change(s). Each change after the first is based on the
+	// previous change in the chain.
+	baseChangeID := ""
+	for idx, files := range groups {
+		msg := commitMsg
+		if len(groups) > 1 {
+			msg = chainCommitMsg(commitMsg, idx, len(groups))
+		}
+		ci, err := gerrit.CreateAndEditChange(ctx, g, cfg.Project, branch, msg, baseCommit, baseChangeID, func(ctx context.Context, g gerrit.GerritInterface, ci *gerrit.ChangeInfo) error {
+			for _, file := range files {
+				contents := changes[file]
+				if contents == "" {
+					if err := g.DeleteFile(ctx, ci, file); err != nil {
+						return skerr.Wrapf(err, "failed to delete file %s", file)
+					}
+				} else {
+					if err := g.EditFile(ctx, ci, file, contents); err != nil {
+						return skerr.Wrapf(err, "failed to edit file %s", file)
+					}
+				}
+			}
+			return nil
+		})
+		if ci != nil {
+			chain = append(chain, ci)
+		}
+		if err != nil {
+			return failed(err)
+		}
+		baseCommit = ""
+		baseChangeID = ci.Id
+	}
+
+	// Use a shared topic so that the chain is tracked as a single roll.
+	if len(chain) > 1 {
+		topic := fmt.Sprintf("autoroll_chain_%d", chain[0].Issue)
+		for _, ci := range chain {
+			if err := g.SetTopic(ctx, topic, ci.Issue); err != nil {
+				return failed(skerr.Wrapf(err, "failed to set topic"))
+			}
+		}
+	}
+	if err := gerrit_common.SetChainLabels(ctx, g, chain, emails, dryRun); err != nil {
 		return 0, skerr.Wrap(err)
 	}
-	return ci.Issue, nil
+	return chain[len(chain)-1].Issue, nil
+}
+
+// splitLines splits the given file contents into newline-terminated lines for
+// use by difflib.
+func splitLines(contents string) []string {
+	if contents == "" {
+		return nil
+	}
+	lines := strings.SplitAfter(contents, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	} else {
+		lines[len(lines)-1] += "\n"
+	}
+	return lines
 }
 
 var _ Parent = &gitilesParent{}
+var _ Previewer = &gitilesParent{}
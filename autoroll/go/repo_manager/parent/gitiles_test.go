@@ -68,3 +68,35 @@ func TestHandleExternalChange_MergeConflict(t *testing.T) {
 	assert.Error(t, err)
 	assert.True(t, strings.Contains(err.Error(), "DEPS already modified by the roll"))
 }
+
+func TestUnifiedDiff(t *testing.T) {
+
+	diff, err := unifiedDiff("DEPS", "a\nb\nc\n", "a\nB\nc\n")
+	assert.NoError(t, err)
+	assert.Equal(t, `--- a/DEPS
++++ b/DEPS
+@@ -1,3 +1,3 @@
+ a
+-b
++B
+ c
+`, diff)
+
+	// New file.
+	diff, err = unifiedDiff("new/file", "", "contents")
+	assert.NoError(t, err)
+	assert.Equal(t, `--- /dev/null
++++ b/new/file
+@@ -0,0 +1 @@
++contents
+`, diff)
+
+	// Deleted file.
+	diff, err = unifiedDiff("old/file", "contents\n", "")
+	assert.NoError(t, err)
+	assert.Equal(t, `--- a/old/file
++++ /dev/null
+@@ -1 +0,0 @@
+-contents
+`, diff)
+}
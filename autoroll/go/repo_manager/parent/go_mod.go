@@ -3,6 +3,7 @@ package parent
 import (
 	"context"
 	"fmt"
+	"io"
 	"net/http"
 	"os"
 	"path/filepath"
@@ -168,4 +169,10 @@ func (p *goModParent) CreateNewRoll(ctx context.Context, from, to *revision.Revi
 	return p.Checkout.CreateNewRoll(ctx, from, to, rolling, emails, dryRun, commitMsg, p.createRoll, p.uploadRoll)
 }
 
+// PreviewRoll implements Previewer.
+func (p *goModParent) PreviewRoll(ctx context.Context, from, to *revision.Revision, rolling []*revision.Revision, commitMsg string, w io.Writer) error {
+	return p.Checkout.PreviewRoll(ctx, from, to, rolling, commitMsg, p.createRoll, w)
+}
+
 var _ Parent = &goModParent{}
+var _ Previewer = &goModParent{}
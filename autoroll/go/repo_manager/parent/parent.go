@@ -6,6 +6,7 @@ package parent
 
 import (
 	"context"
+	"io"
 
 	"go.skia.org/infra/autoroll/go/revision"
 )
@@ -22,3 +23,11 @@ type Parent interface {
 	// dependency to the given Revision.
 	CreateNewRoll(ctx context.Context, from, to *revision.Revision, rolling []*revision.Revision, emails []string, dryRun bool, commitMsg string) (int64, error)
 }
+
+// Previewer is implemented by Parents which are able to produce a roll without
+// uploading it.
+type Previewer interface {
+	// PreviewRoll performs the same steps as CreateNewRoll, but instead of
+	// uploading a CL it writes the resulting changes and commit message to w.
+	PreviewRoll(ctx context.Context, from, to *revision.Revision, rolling []*revision.Revision, commitMsg string, w io.Writer) error
+}
@@ -0,0 +1,175 @@
+package parent
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.skia.org/infra/autoroll/go/config"
+	"go.skia.org/infra/go/autoroll"
+	"go.skia.org/infra/go/now"
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/go/sklog"
+)
+
+// preUploadStepResultsContextKey is used to store a PreUploadStepResults in a
+// context.Context.
+type preUploadStepResultsContextKey struct{}
+
+// PreUploadStepResults collects the results of pre-upload steps. It is safe for
+// concurrent use.
+type PreUploadStepResults struct {
+	mtx     sync.Mutex
+	results []*autoroll.PreUploadStepResult
+}
+
+// WithPreUploadStepResults returns a context.Context which causes the results
+// of any pre-upload steps run using it to be collected in the returned
+// PreUploadStepResults.
+func WithPreUploadStepResults(ctx context.Context) (context.Context, *PreUploadStepResults) {
+	results := &PreUploadStepResults{}
+	return context.WithValue(ctx, preUploadStepResultsContextKey{}, results), results
+}
+
+// Results returns the collected results, in the order in which the steps
+// finished.
+func (r *PreUploadStepResults) Results() []*autoroll.PreUploadStepResult {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	rv := make([]*autoroll.PreUploadStepResult, 0, len(r.results))
+	for _, result := range r.results {
+		rv = append(rv, result.Copy())
+	}
+	return rv
+}
+
+// add records the given result.
+func (r *PreUploadStepResults) add(result *autoroll.PreUploadStepResult) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.results = append(r.results, result)
+}
+
+// recordPreUploadStepResult records the given result if the context has an
+// associated PreUploadStepResults.
+func recordPreUploadStepResult(ctx context.Context, result *autoroll.PreUploadStepResult) {
+	if results, ok := ctx.Value(preUploadStepResultsContextKey{}).(*PreUploadStepResults); ok {
+		results.add(result)
+	}
+}
+
+// runPreUploadStep runs the given function, retrying up to the given number of
+// times on failure, and records the result. If timeout is non-zero, each
+// attempt is cancelled after the given duration. If ignoreFailure is true, the
+// failure is logged and recorded but not returned.
+func runPreUploadStep(ctx context.Context, name string, timeout time.Duration, retries int, ignoreFailure bool, fn func(context.Context) error) error {
+	start := now.Now(ctx)
+	result := &autoroll.PreUploadStepResult{Name: name}
+	var err error
+	for attempt := 0; attempt <= retries; attempt++ {
+		result.Attempts++
+		attemptCtx, cancel := ctx, func() {}
+		if timeout > 0 {
+			attemptCtx, cancel = context.WithTimeout(ctx, timeout)
+		}
+		err = fn(attemptCtx)
+		if err != nil && attemptCtx.Err() == context.DeadlineExceeded && ctx.Err() == nil {
+			err = skerr.Wrapf(err, "timed out after %s", timeout)
+		}
+		cancel()
+		if err == nil || ctx.Err() != nil {
+			break
+		}
+		if attempt < retries {
+			sklog.Warningf("Pre-upload step %q failed on attempt %d; retrying: %s", name, result.Attempts, err)
+		}
+	}
+	result.Duration = now.Now(ctx).Sub(start)
+	if err != nil {
+		result.Error = err.Error()
+		result.IgnoredFailure = ignoreFailure
+	}
+	recordPreUploadStepResult(ctx, result)
+	if err != nil {
+		if ignoreFailure {
+			sklog.Errorf("Ignoring failure of pre-upload step %q: %s", name, err)
+			return nil
+		}
+		return skerr.Wrapf(err, "pre-upload step %q failed", name)
+	}
+	return nil
+}
+
+// runPreUploadCommands runs the given commands using the given function. Each
+// command is started once all of the commands it depends on have finished, and
+// at most maxParallelism commands run at a time. If any command fails (and its
+// failure is not ignored), no further commands are started, any which are
+// still running are cancelled, and the first error is returned.
+func runPreUploadCommands(ctx context.Context, cmds []*config.PreUploadCommandConfig, maxParallelism int, run func(context.Context, *config.PreUploadCommandConfig) error) error {
+	if maxParallelism < 1 {
+		maxParallelism = 1
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type finished struct {
+		idx int
+		err error
+	}
+	finishedCh := make(chan finished)
+	started := make([]bool, len(cmds))
+	done := make(map[string]bool, len(cmds))
+	running := 0
+	remaining := len(cmds)
+	var firstErr error
+	for remaining > 0 {
+		// Start any commands whose dependencies are satisfied, in the order in
+		// which they are configured.
+		if firstErr == nil {
+			for idx, cmd := range cmds {
+				if running >= maxParallelism {
+					break
+				}
+				if started[idx] || !allDone(cmd.DependsOn, done) {
+					continue
+				}
+				started[idx] = true
+				running++
+				go func(idx int, cmd *config.PreUploadCommandConfig) {
+					finishedCh <- finished{idx: idx, err: run(ctx, cmd)}
+				}(idx, cmd)
+			}
+		}
+		if running == 0 {
+			break
+		}
+		f := <-finishedCh
+		running--
+		remaining--
+		if f.err != nil {
+			if firstErr == nil {
+				firstErr = f.err
+				cancel()
+			}
+		} else {
+			done[cmds[f.idx].StepName()] = true
+		}
+	}
+	if firstErr != nil {
+		return firstErr
+	}
+	if remaining > 0 {
+		return skerr.Fmt("%d pre-upload commands have unsatisfiable dependencies", remaining)
+	}
+	return nil
+}
+
+// allDone returns true iff all of the given names are marked as done.
+func allDone(names []string, done map[string]bool) bool {
+	for _, name := range names {
+		if !done[name] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,162 @@
+package parent
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.skia.org/infra/autoroll/go/config"
+	"go.skia.org/infra/go/autoroll"
+)
+
+func TestRunPreUploadStep_Retries(t *testing.T) {
+
+	ctx, results := WithPreUploadStepResults(context.Background())
+	calls := 0
+	err := runPreUploadStep(ctx, "flaky", 0, 2, false, func(context.Context) error {
+		calls++
+		if calls < 2 {
+			return errors.New("flaked")
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, calls)
+	res := results.Results()
+	require.Len(t, res, 1)
+	require.Equal(t, "flaky", res[0].Name)
+	require.Equal(t, 2, res[0].Attempts)
+	require.True(t, res[0].Succeeded())
+}
+
+func TestRunPreUploadStep_Timeout(t *testing.T) {
+
+	ctx, results := WithPreUploadStepResults(context.Background())
+	err := runPreUploadStep(ctx, "hung", time.Millisecond, 1, false, func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	require.ErrorContains(t, err, `pre-upload step "hung" failed`)
+	require.ErrorContains(t, err, "timed out after 1ms")
+	res := results.Results()
+	require.Len(t, res, 1)
+	require.Equal(t, 2, res[0].Attempts)
+	require.False(t, res[0].Succeeded())
+	require.False(t, res[0].IgnoredFailure)
+}
+
+func TestRunPreUploadStep_IgnoreFailure(t *testing.T) {
+
+	ctx, results := WithPreUploadStepResults(context.Background())
+	err := runPreUploadStep(ctx, "ignored", 0, 0, true, func(context.Context) error {
+		return errors.New("failed")
+	})
+	require.NoError(t, err)
+	require.Equal(t, []*autoroll.PreUploadStepResult{
+		{
+			Name:           "ignored",
+			Attempts:       1,
+			Duration:       results.Results()[0].Duration,
+			Error:          "failed",
+			IgnoredFailure: true,
+		},
+	}, results.Results())
+}
+
+func TestRunPreUploadStep_NoResults(t *testing.T) {
+
+	// Results are only recorded when requested.
+	err := runPreUploadStep(context.Background(), "step", 0, 0, false, func(context.Context) error {
+		return nil
+	})
+	require.NoError(t, err)
+}
+
+func TestRunPreUploadCommands_Dependencies(t *testing.T) {
+
+	cmds := []*config.PreUploadCommandConfig{
+		{Name: "c", Command: "c", DependsOn: []string{"a", "b"}},
+		{Name: "a", Command: "a"},
+		{Name: "b", Command: "b", DependsOn: []string{"a"}},
+		{Command: "d"},
+	}
+	var mtx sync.Mutex
+	var order []string
+	err := runPreUploadCommands(context.Background(), cmds, 1, func(_ context.Context, cmd *config.PreUploadCommandConfig) error {
+		mtx.Lock()
+		defer mtx.Unlock()
+		order = append(order, cmd.StepName())
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"a", "b", "c", "d"}, order)
+}
+
+func TestRunPreUploadCommands_Parallel(t *testing.T) {
+
+	cmds := []*config.PreUploadCommandConfig{
+		{Command: "a"},
+		{Command: "b"},
+		{Command: "c"},
+		{Command: "d", DependsOn: []string{"a", "b", "c"}},
+	}
+	var mtx sync.Mutex
+	running := 0
+	maxRunning := 0
+	started := make(chan struct{})
+	var once sync.Once
+	err := runPreUploadCommands(context.Background(), cmds, 2, func(_ context.Context, cmd *config.PreUploadCommandConfig) error {
+		mtx.Lock()
+		running++
+		if running > maxRunning {
+			maxRunning = running
+		}
+		if running == 2 {
+			once.Do(func() { close(started) })
+		}
+		mtx.Unlock()
+		// Wait for two commands to be running at once before finishing.
+		if cmd.Command != "d" {
+			select {
+			case <-started:
+			case <-time.After(5 * time.Second):
+			}
+		}
+		mtx.Lock()
+		running--
+		mtx.Unlock()
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, maxRunning)
+}
+
+func TestRunPreUploadCommands_Failure(t *testing.T) {
+
+	cmds := []*config.PreUploadCommandConfig{
+		{Name: "slow", Command: "slow"},
+		{Name: "fails", Command: "fails"},
+		{Name: "after", Command: "after", DependsOn: []string{"fails"}},
+	}
+	var mtx sync.Mutex
+	var ran []string
+	err := runPreUploadCommands(context.Background(), cmds, 2, func(ctx context.Context, cmd *config.PreUploadCommandConfig) error {
+		mtx.Lock()
+		ran = append(ran, cmd.Name)
+		mtx.Unlock()
+		switch cmd.Name {
+		case "slow":
+			// This command should be cancelled when the other fails.
+			<-ctx.Done()
+			return ctx.Err()
+		case "fails":
+			return errors.New("failed")
+		}
+		return nil
+	})
+	require.EqualError(t, err, "failed")
+	require.ElementsMatch(t, []string{"slow", "fails"}, ran)
+}
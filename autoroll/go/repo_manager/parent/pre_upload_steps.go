@@ -12,6 +12,7 @@ import (
 	"path"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"go.skia.org/infra/autoroll/go/config"
 	"go.skia.org/infra/autoroll/go/revision"
@@ -22,6 +23,7 @@ import (
 	"go.skia.org/infra/go/exec"
 	"go.skia.org/infra/go/git"
 	"go.skia.org/infra/go/go_install"
+	"go.skia.org/infra/go/human"
 	"go.skia.org/infra/go/skerr"
 	"go.skia.org/infra/go/sklog"
 
@@ -76,11 +78,20 @@ func GetPreUploadSteps(steps []config.PreUploadStep, generic *config.PreUploadCo
 		if err != nil {
 			return nil, err
 		}
-		rv = append(rv, step)
+		rv = append(rv, withResult(s.String(), step))
 	}
 	return rv, nil
 }
 
+// withResult wraps the given PreUploadStep so that its result is recorded.
+func withResult(name string, step PreUploadStep) PreUploadStep {
+	return func(ctx context.Context, env []string, client *http.Client, parentRepoDir string, from *revision.Revision, to *revision.Revision) error {
+		return runPreUploadStep(ctx, name, 0, 0, false, func(ctx context.Context) error {
+			return step(ctx, env, client, parentRepoDir, from, to)
+		})
+	}
+}
+
 // AddPreUploadStepForTesting adds the given PreUploadStep to the global
 // registry to be used for testing. Returns a unique name for the step. Not safe
 // to be used concurrently with GetPreUploadStep(s).
@@ -454,6 +465,9 @@ func GetGenericPreUploadStep(cfg *config.PreUploadConfig) PreUploadStep {
 }
 
 // GenericPreUploadStep runs a pre-upload step as specified by the given config.
+// Commands are run in parallel, up to cfg.MaxParallelism at a time, subject to
+// their declared dependencies. The result of each command is recorded if the
+// context was created using WithPreUploadStepResults.
 func GenericPreUploadStep(ctx context.Context, cfg *config.PreUploadConfig, env []string, client *http.Client, parentRepoDir string, from *revision.Revision, to *revision.Revision) error {
 	defer metrics2.FuncTimer().Stop()
 	preUploadStepFailure := int64(1)
@@ -502,7 +516,7 @@ func GenericPreUploadStep(ctx context.Context, cfg *config.PreUploadConfig, env
 			return skerr.Wrap(err)
 		}
 	}
-	for _, cmd := range cfg.Command {
+	runCommand := func(ctx context.Context, cmd *config.PreUploadCommandConfig) error {
 		cmdEnv := make([]string, len(env)+len(cmd.Env))
 		for _, envVar := range env {
 			cmdEnv = append(cmdEnv, replaceMagicVars(envVar))
@@ -525,20 +539,26 @@ func GenericPreUploadStep(ctx context.Context, cfg *config.PreUploadConfig, env
 		if err != nil {
 			return skerr.Wrap(err)
 		}
-
-		sklog.Infof("Running command: %s", strings.Join(split, " "))
-		if _, err := exec.RunCommand(ctx, &exec.Command{
-			Name: executable,
-			Args: split[1:],
-			Dir:  replaceMagicVars(cmd.Cwd),
-			Env:  cmdEnv,
-		}); err != nil {
-			if cmd.IgnoreFailure {
-				sklog.Errorf("%s", err)
-			} else {
-				return skerr.Wrap(err)
+		var timeout time.Duration
+		if cmd.Timeout != "" {
+			timeout, err = human.ParseDuration(cmd.Timeout)
+			if err != nil {
+				return skerr.Wrapf(err, "invalid timeout for %q", cmd.StepName())
 			}
 		}
+		return runPreUploadStep(ctx, cmd.StepName(), timeout, int(cmd.Retries), cmd.IgnoreFailure, func(ctx context.Context) error {
+			sklog.Infof("Running command: %s", strings.Join(split, " "))
+			_, err := exec.RunCommand(ctx, &exec.Command{
+				Name: executable,
+				Args: split[1:],
+				Dir:  replaceMagicVars(cmd.Cwd),
+				Env:  cmdEnv,
+			})
+			return err
+		})
+	}
+	if err := runPreUploadCommands(ctx, cfg.Command, int(cfg.MaxParallelism), runCommand); err != nil {
+		return skerr.Wrap(err)
 	}
 	preUploadStepFailure = 0
 	return nil
@@ -0,0 +1,72 @@
+package parent
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// splitChanges partitions the files modified by the given changes into groups,
+// each of which is uploaded as a separate CL in a relation chain. If maxFiles
+// is zero or no more than maxFiles files are modified, a single group is
+// returned. If byDirectory is true, files in the same top-level directory are
+// kept in the same group, even if the group then exceeds maxFiles.
+func splitChanges(changes map[string]string, maxFiles int, byDirectory bool) [][]string {
+	files := make([]string, 0, len(changes))
+	for file := range changes {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+	if maxFiles <= 0 || len(files) <= maxFiles {
+		return [][]string{files}
+	}
+
+	// Find the units which may not be split across CLs.
+	var units [][]string
+	if byDirectory {
+		byDir := map[string][]string{}
+		dirs := []string{}
+		for _, file := range files {
+			dir := ""
+			if idx := strings.Index(file, "/"); idx >= 0 {
+				dir = file[:idx]
+			}
+			if _, ok := byDir[dir]; !ok {
+				dirs = append(dirs, dir)
+			}
+			byDir[dir] = append(byDir[dir], file)
+		}
+		sort.Strings(dirs)
+		for _, dir := range dirs {
+			units = append(units, byDir[dir])
+		}
+	} else {
+		for _, file := range files {
+			units = append(units, []string{file})
+		}
+	}
+
+	// Pack the units into groups of at most maxFiles files.
+	var groups [][]string
+	var group []string
+	for _, unit := range units {
+		if len(group) > 0 && len(group)+len(unit) > maxFiles {
+			groups = append(groups, group)
+			group = nil
+		}
+		group = append(group, unit...)
+	}
+	if len(group) > 0 {
+		groups = append(groups, group)
+	}
+	return groups
+}
+
+// chainCommitMsg returns the commit message for the CL at the given index in a
+// chain of the given length, which is the roll commit message with the
+// position of the CL in the chain appended to the subject line.
+func chainCommitMsg(commitMsg string, idx, length int) string {
+	lines := strings.SplitN(commitMsg, "\n", 2)
+	lines[0] = fmt.Sprintf("%s (%d/%d)", lines[0], idx+1, length)
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,60 @@
+package parent
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitChanges_NoSplit(t *testing.T) {
+
+	changes := map[string]string{
+		"b/file": "b",
+		"a/file": "a",
+		"DEPS":   "deps",
+	}
+	expect := [][]string{{"DEPS", "a/file", "b/file"}}
+	require.Equal(t, expect, splitChanges(changes, 0, false))
+	require.Equal(t, expect, splitChanges(changes, 3, false))
+	require.Equal(t, expect, splitChanges(changes, 3, true))
+}
+
+func TestSplitChanges_FileCount(t *testing.T) {
+
+	changes := map[string]string{
+		"a/1":  "",
+		"a/2":  "",
+		"a/3":  "",
+		"b/1":  "",
+		"DEPS": "",
+	}
+	require.Equal(t, [][]string{
+		{"DEPS", "a/1"},
+		{"a/2", "a/3"},
+		{"b/1"},
+	}, splitChanges(changes, 2, false))
+}
+
+func TestSplitChanges_ByDirectory(t *testing.T) {
+
+	changes := map[string]string{
+		"a/1":    "",
+		"a/2":    "",
+		"a/3/4":  "",
+		"b/1":    "",
+		"c/1":    "",
+		"DEPS":   "",
+		"README": "",
+	}
+	require.Equal(t, [][]string{
+		{"DEPS", "README"},
+		{"a/1", "a/2", "a/3/4"},
+		{"b/1", "c/1"},
+	}, splitChanges(changes, 2, true))
+}
+
+func TestChainCommitMsg(t *testing.T) {
+
+	require.Equal(t, "Roll skia from a to b (1/3)\n\nBody\n", chainCommitMsg("Roll skia from a to b\n\nBody\n", 0, 3))
+	require.Equal(t, "Roll skia from a to b (3/3)", chainCommitMsg("Roll skia from a to b", 2, 3))
+}
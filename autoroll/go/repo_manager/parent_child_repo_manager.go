@@ -2,6 +2,7 @@ package repo_manager
 
 import (
 	"context"
+	"io"
 	"net/http"
 	"path/filepath"
 
@@ -17,6 +18,10 @@ import (
 	"go.skia.org/infra/go/sklog"
 )
 
+// revisionCacheSize is the number of Revisions cached for each git-based
+// Child.
+const revisionCacheSize = 1000
+
 // parentChildRepoManager combines a Parent and a Child to implement the
 // RepoManager interface.
 type parentChildRepoManager struct {
@@ -65,7 +70,9 @@ func newParentChildRepoManager(ctx context.Context, c *config.ParentChildRepoMan
 	}
 
 	// Create the Child.
-	if c.GetCipdChild() != nil {
+	if c.GetCipdChild() != nil && len(c.GetCipdChild().GetPlatform()) > 0 {
+		childRM, err = child.NewCIPDMultiPlatform(ctx, c.GetCipdChild(), client, workdir)
+	} else if c.GetCipdChild() != nil {
 		childRM, err = child.NewCIPD(ctx, c.GetCipdChild(), reg, client, workdir)
 	} else if c.GetFuchsiaSdkChild() != nil {
 		childRM, err = child.NewFuchsiaSDK(ctx, c.GetFuchsiaSdkChild(), client)
@@ -86,6 +93,11 @@ func newParentChildRepoManager(ctx context.Context, c *config.ParentChildRepoMan
 	if childRM == nil {
 		return nil, skerr.Fmt("missing child")
 	}
+	// Git commit hashes are immutable, so it's safe to cache the Revisions of
+	// git-based Children. Other Children may invalidate or remove revisions.
+	if c.GetGitilesChild() != nil || c.GetGitCheckoutChild() != nil || c.GetGitCheckoutGithubChild() != nil {
+		childRM = child.NewCachingChild(childRM, revisionCacheSize)
+	}
 
 	// Some Parent implementations require a Child to be passed in.
 	if c.GetCopyParent() != nil {
@@ -121,6 +133,13 @@ func newParentChildRepoManager(ctx context.Context, c *config.ParentChildRepoMan
 		}
 		revFilters = append(revFilters, revFilter)
 	}
+	for _, rfConfig := range c.GetCommitRevisionFilter() {
+		revFilter, err := revision_filter.NewCommitRevisionFilter(client, rfConfig)
+		if err != nil {
+			return nil, skerr.Wrap(err)
+		}
+		revFilters = append(revFilters, revFilter)
+	}
 
 	return &parentChildRepoManager{
 		Child:      childRM,
@@ -168,5 +187,15 @@ func (rm *parentChildRepoManager) LogRevisions(ctx context.Context, from, to *re
 	return rm.Child.LogRevisions(ctx, from, to)
 }
 
+// PreviewRoll implements RollPreviewer.
+func (rm *parentChildRepoManager) PreviewRoll(ctx context.Context, from, to *revision.Revision, rolling []*revision.Revision, commitMsg string, w io.Writer) error {
+	previewer, ok := rm.Parent.(parent.Previewer)
+	if !ok {
+		return skerr.Fmt("parent %T does not support previewing rolls", rm.Parent)
+	}
+	return previewer.PreviewRoll(ctx, from, to, rolling, commitMsg, w)
+}
+
 // parentChildRepoManager implements RepoManager.
 var _ RepoManager = &parentChildRepoManager{}
+var _ RollPreviewer = &parentChildRepoManager{}
@@ -2,6 +2,7 @@ package repo_manager
 
 import (
 	"context"
+	"io"
 	"net/http"
 
 	"go.skia.org/infra/autoroll/go/codereview"
@@ -33,6 +34,22 @@ type RepoManager interface {
 	LogRevisions(context.Context, *revision.Revision, *revision.Revision) ([]*revision.Revision, error)
 }
 
+// RollPreviewer is implemented by RepoManagers which are able to produce a roll
+// without uploading it, eg. for debugging commit message templates.
+type RollPreviewer interface {
+	// PreviewRoll performs the same steps as CreateNewRoll, but instead of
+	// uploading a CL it writes the resulting changes and commit message to w.
+	PreviewRoll(ctx context.Context, rollingFrom *revision.Revision, rollingTo *revision.Revision, revisions []*revision.Revision, commitMsg string, w io.Writer) error
+}
+
+// CacheInvalidator is implemented by RepoManagers which cache state between
+// calls to Update, eg. to avoid redundant requests.
+type CacheInvalidator interface {
+	// InvalidateCache discards any cached state, so that the next call to
+	// Update performs a full update.
+	InvalidateCache()
+}
+
 // New returns a RepoManager instance based on the given RepoManagerConfig.
 func New(ctx context.Context, c config.RepoManagerConfig, reg *config_vars.Registry, workdir, rollerName, recipeCfgFile, serverURL, serviceAccount string, client *http.Client, cr codereview.CodeReview, isInternal bool, local bool) (RepoManager, error) {
 	if c == nil {
@@ -0,0 +1,110 @@
+package revision
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+var (
+	// bugFooterRegex matches "Bug:" and "BUG=" footers in commit messages.
+	bugFooterRegex = regexp.MustCompile(`^(?:BUG=|Bug:)\s*(.*?)\s*$`)
+
+	// bugRefRegexes match the supported forms of bug references. The first
+	// submatch is the bug project, if any, and the second is the bug ID.
+	bugRefRegexes = []*regexp.Regexp{
+		// eg. "1234"
+		regexp.MustCompile(`^()(\d+)$`),
+		// eg. "skia:1234"
+		regexp.MustCompile(`^([\w-]+):(\d+)$`),
+		// eg. "https://crbug.com/1234" or "https://crbug.com/skia/1234"
+		regexp.MustCompile(`^(?:https?://)?crbug\.com/(?:([\w-]+)/)?(\d+)$`),
+		// eg. "https://bugs.chromium.org/p/skia/issues/detail?id=1234"
+		regexp.MustCompile(`^(?:https?://)?bugs\.chromium\.org/p/([\w-]+)/issues/detail\?id=(\d+)$`),
+	}
+
+	// buganizerRefRegex matches references to Buganizer bugs, eg. "b/1234"
+	// or "https://issues.chromium.org/issues/1234".
+	buganizerRefRegex = regexp.MustCompile(`^(?:(?:https?://)?(?:issues\.chromium\.org/issues|b\.corp\.google\.com/issues)|b)/(\d+)$`)
+
+	// skbugRefRegex matches references to Skia bugs using the skbug.com
+	// shortener, eg. "https://skbug.com/1234".
+	skbugRefRegex = regexp.MustCompile(`^(?:https?://)?skbug\.com/(\d+)$`)
+)
+
+// ParseBug parses a single bug reference and returns the bug project and ID.
+// Supported references include "1234", "skia:1234", "b/1234", and crbug.com,
+// skbug.com, bugs.chromium.org and issues.chromium.org URLs. References which
+// don't specify a project use defaultProject; if defaultProject is empty, such
+// references are not valid. Returns false if the reference is not valid.
+func ParseBug(ref, defaultProject string) (string, string, bool) {
+	if m := buganizerRefRegex.FindStringSubmatch(ref); m != nil {
+		return BugProjectBuganizer, m[1], true
+	}
+	if m := skbugRefRegex.FindStringSubmatch(ref); m != nil {
+		return projectSkia, m[1], true
+	}
+	for _, re := range bugRefRegexes {
+		m := re.FindStringSubmatch(ref)
+		if m == nil {
+			continue
+		}
+		project := m[1]
+		if project == "" {
+			if strings.Contains(ref, "crbug.com/") {
+				project = projectChromium
+			} else {
+				project = defaultProject
+			}
+		}
+		if project == "" {
+			return "", "", false
+		}
+		return project, m[2], true
+	}
+	return "", "", false
+}
+
+// FormatBug returns a reference to the given bug, suitable for use in a "Bug:"
+// footer. Buganizer bugs are always formatted as eg. "b/1234". Otherwise, if
+// url is true, the bug is formatted as a crbug.com URL; if not, the bug is
+// formatted as eg. "skia:1234", omitting the project if it is defaultProject.
+func FormatBug(project, id, defaultProject string, url bool) string {
+	if project == BugProjectBuganizer {
+		return "b/" + id
+	}
+	if url {
+		if project == projectChromium {
+			return "https://crbug.com/" + id
+		}
+		return fmt.Sprintf("https://crbug.com/%s/%s", project, id)
+	}
+	if project == defaultProject {
+		return id
+	}
+	return fmt.Sprintf("%s:%s", project, id)
+}
+
+// bugsFromCommitMsg parses BUG= tags from a commit message and returns them.
+func bugsFromCommitMsg(msg, defaultBugProject string) map[string][]string {
+	rv := map[string][]string{}
+	if defaultBugProject == "" {
+		defaultBugProject = bugProjectFallback
+	}
+	for _, line := range strings.Split(msg, "\n") {
+		m := bugFooterRegex.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		refs := strings.FieldsFunc(m[1], func(r rune) bool {
+			return r == ',' || unicode.IsSpace(r)
+		})
+		for _, ref := range refs {
+			if project, bug, ok := ParseBug(ref, defaultBugProject); ok {
+				rv[project] = append(rv[project], bug)
+			}
+		}
+	}
+	return rv
+}
@@ -0,0 +1,55 @@
+package revision
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseBug(t *testing.T) {
+
+	test := func(ref, defaultProject, expectProject, expectID string, expectOK bool) {
+		project, id, ok := ParseBug(ref, defaultProject)
+		require.Equal(t, expectOK, ok, ref)
+		require.Equal(t, expectProject, project, ref)
+		require.Equal(t, expectID, id, ref)
+	}
+	test("1234", "skia", "skia", "1234", true)
+	test("1234", "", "", "", false)
+	test("skia:1234", "", "skia", "1234", true)
+	test("angleproject:1234", "chromium", "angleproject", "1234", true)
+	test("b/1234", "", BugProjectBuganizer, "1234", true)
+	test("https://issues.chromium.org/issues/1234", "", BugProjectBuganizer, "1234", true)
+	test("b.corp.google.com/issues/1234", "", BugProjectBuganizer, "1234", true)
+	test("https://crbug.com/1234", "skia", "chromium", "1234", true)
+	test("crbug.com/skia/1234", "", "skia", "1234", true)
+	test("https://skbug.com/1234", "", "skia", "1234", true)
+	test("https://bugs.chromium.org/p/dawn/issues/detail?id=1234", "", "dawn", "1234", true)
+	test("None", "chromium", "", "", false)
+	test("skia:", "chromium", "", "", false)
+	test("bb/1234", "chromium", "", "", false)
+	test("https://example.com/1234", "chromium", "", "", false)
+}
+
+func TestFormatBug(t *testing.T) {
+
+	require.Equal(t, "skia:1234", FormatBug("skia", "1234", "", false))
+	require.Equal(t, "1234", FormatBug("chromium", "1234", "chromium", false))
+	require.Equal(t, "chromium:1234", FormatBug("chromium", "1234", "skia", false))
+	require.Equal(t, "b/1234", FormatBug(BugProjectBuganizer, "1234", "", false))
+	require.Equal(t, "b/1234", FormatBug(BugProjectBuganizer, "1234", "", true))
+	require.Equal(t, "https://crbug.com/1234", FormatBug("chromium", "1234", "", true))
+	require.Equal(t, "https://crbug.com/skia/1234", FormatBug("skia", "1234", "skia", true))
+}
+
+func TestBugsFromCommitMsg_URLs(t *testing.T) {
+
+	require.Equal(t, map[string][]string{
+		"chromium":          {"123"},
+		"skia":              {"456", "789"},
+		BugProjectBuganizer: {"321"},
+	}, bugsFromCommitMsg(`Some change
+
+Bug: https://crbug.com/123, https://crbug.com/skia/456
+Bug: https://issues.chromium.org/issues/321 skbug.com/789`, "skia"))
+}
@@ -12,14 +12,13 @@ import (
 
 const (
 	projectChromium     = "chromium"
+	projectSkia         = "skia"
 	bugProjectFallback  = projectChromium
 	BugProjectBuganizer = "buganizer"
-	bugsPattern         = `^(?:BUG=|Bug:)\s*((?:b\/|\w+\:)?\d*(?:\s*(?:,|\s)\s*(?:b\/|\w+\:)?\d*)*)\s*$`
 )
 
 var (
-	bugsRegex = regexp.MustCompile(bugsPattern)
-	testsRe   = regexp.MustCompile("(?m)^Test: *(.*) *$")
+	testsRe = regexp.MustCompile("(?m)^Test: *(.*) *$")
 )
 
 // Revision is a struct containing information about a given revision.
@@ -157,38 +156,3 @@ func parseTests(details string) []string {
 	}
 	return tests
 }
-
-// bugsFromCommitMsg parses BUG= tags from a commit message and returns them.
-func bugsFromCommitMsg(msg, defaultBugProject string) map[string][]string {
-	rv := map[string][]string{}
-	if defaultBugProject == "" {
-		defaultBugProject = bugProjectFallback
-	}
-	for _, line := range strings.Split(msg, "\n") {
-		m := bugsRegex.FindAllStringSubmatch(line, -1)
-		for _, match := range m {
-			for _, s := range match[1:] {
-				for _, field := range strings.Fields(s) {
-					bugs := strings.Split(field, ",")
-					for _, b := range bugs {
-						b = strings.TrimSpace(b)
-						split := strings.SplitN(strings.Trim(b, " "), ":", 2)
-						project := defaultBugProject
-						bug := split[0]
-						if len(split) > 1 {
-							project = split[0]
-							bug = split[1]
-						} else if strings.HasPrefix(bug, "b/") {
-							project = BugProjectBuganizer
-							bug = strings.TrimPrefix(bug, "b/")
-						}
-						if bug != "" {
-							rv[project] = append(rv[project], bug)
-						}
-					}
-				}
-			}
-		}
-	}
-	return rv
-}
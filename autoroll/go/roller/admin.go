@@ -0,0 +1,183 @@
+package roller
+
+/*
+	HTTP handlers which expose repo manager operations for use by the roller's
+	maintainers, eg. to force an update without restarting the roller.
+*/
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"go.skia.org/infra/autoroll/go/repo_manager"
+	"go.skia.org/infra/autoroll/go/revision"
+	"go.skia.org/infra/go/allowed"
+	"go.skia.org/infra/go/alogin"
+	"go.skia.org/infra/go/autoroll"
+	"go.skia.org/infra/go/httputils"
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/go/sklog"
+)
+
+// AdminRevisions describes the revisions currently known to the roller.
+type AdminRevisions struct {
+	LastRollRev   *revision.Revision   `json:"lastRollRev"`
+	NextRollRev   *revision.Revision   `json:"nextRollRev"`
+	TipRev        *revision.Revision   `json:"tipRev"`
+	NotRolledRevs []*revision.Revision `json:"notRolledRevs"`
+}
+
+// AdminDryRunRequest is the body of a request to upload a dry run.
+type AdminDryRunRequest struct {
+	// Revision is the ID of the revision to roll to. Defaults to the next
+	// roll revision.
+	Revision string `json:"revision"`
+}
+
+// AdminDryRunResponse is the response to a request to upload a dry run.
+type AdminDryRunResponse struct {
+	Issue    int64                   `json:"issue"`
+	IssueURL string                  `json:"issueUrl"`
+	Roll     *autoroll.AutoRollIssue `json:"roll"`
+}
+
+// AdminMiddleware returns middleware which rejects requests from users who
+// are not members of the given allowed list and attaches the login status of
+// those who are to the request context.
+func AdminMiddleware(login alogin.Login, allow allowed.Allow) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		h := alogin.StatusMiddleware(login)(next)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			user := login.LoggedInAs(r)
+			if user == alogin.NotLoggedIn {
+				httputils.ReportError(w, nil, "You must be logged in to complete this action.", http.StatusUnauthorized)
+				return
+			}
+			if !allow.Member(user.String()) {
+				sklog.Warningf("User %q is not allowed to use the admin handlers.", user)
+				httputils.ReportError(w, nil, "You are not allowed to complete this action.", http.StatusForbidden)
+				return
+			}
+			h.ServeHTTP(w, r)
+		})
+	}
+}
+
+// AddHandlers implements main.AutoRollerI. The handlers are intended for use by
+// the roller's maintainers only; the caller is responsible for authentication,
+// eg. using AdminMiddleware.
+func (r *AutoRoller) AddHandlers(router chi.Router) {
+	router.Get("/_/admin/revisions", r.adminRevisionsHandler)
+	router.Post("/_/admin/update", r.adminUpdateHandler)
+	router.Post("/_/admin/invalidate_cache", r.adminInvalidateCacheHandler)
+	router.Post("/_/admin/dry_run", r.adminDryRunHandler)
+}
+
+// getAdminRevisions returns the revisions currently known to the roller.
+func (r *AutoRoller) getAdminRevisions() *AdminRevisions {
+	r.statusMtx.RLock()
+	defer r.statusMtx.RUnlock()
+	return &AdminRevisions{
+		LastRollRev:   r.lastRollRev,
+		NextRollRev:   r.nextRollRev,
+		TipRev:        r.tipRev,
+		NotRolledRevs: r.notRolledRevs,
+	}
+}
+
+// adminUpdate updates the repos, optionally discarding any state cached by the
+// RepoManager first.
+func (r *AutoRoller) adminUpdate(ctx context.Context, invalidateCache bool) error {
+	// Prevent the roller from running while we work.
+	r.runningMtx.Lock()
+	defer r.runningMtx.Unlock()
+	if invalidateCache {
+		if ci, ok := r.rm.(repo_manager.CacheInvalidator); ok {
+			ci.InvalidateCache()
+		}
+	}
+	return skerr.Wrap(r.UpdateRepos(ctx))
+}
+
+// adminDryRun uploads a dry run to the given revision, or to the next roll
+// revision if none is provided. The roll is not tracked by the roller.
+func (r *AutoRoller) adminDryRun(ctx context.Context, revID, requester string) (*autoroll.AutoRollIssue, error) {
+	// Prevent the roller from running while we work.
+	r.runningMtx.Lock()
+	defer r.runningMtx.Unlock()
+	to := r.GetNextRollRev()
+	if revID != "" {
+		var err error
+		to, err = r.getRevision(ctx, revID)
+		if err != nil {
+			return nil, skerr.Wrapf(err, "failed to resolve revision %q", revID)
+		}
+	}
+	from := r.GetCurrentRev()
+	if from == nil || to == nil {
+		return nil, skerr.Fmt("The roller has not yet finished updating the repos")
+	}
+	if to.Id == from.Id {
+		return nil, skerr.Fmt("Already at revision %q", from.Id)
+	}
+	sklog.Infof("Creating dry run to %s as requested by %s...", to.Id, requester)
+	return r.createNewRoll(ctx, from, to, []string{requester}, true, false, requester)
+}
+
+// writeJSON writes the given value as JSON to the ResponseWriter.
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		httputils.ReportError(w, err, "Failed to encode response.", http.StatusInternalServerError)
+	}
+}
+
+// adminRevisionsHandler returns the revisions currently known to the roller.
+func (r *AutoRoller) adminRevisionsHandler(w http.ResponseWriter, req *http.Request) {
+	writeJSON(w, r.getAdminRevisions())
+}
+
+// adminUpdateHandler forces an update of the repos and returns the resulting
+// revisions.
+func (r *AutoRoller) adminUpdateHandler(w http.ResponseWriter, req *http.Request) {
+	if err := r.adminUpdate(req.Context(), false); err != nil {
+		httputils.ReportError(w, err, "Failed to update repos.", http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, r.getAdminRevisions())
+}
+
+// adminInvalidateCacheHandler discards any state cached by the RepoManager,
+// forces a full update of the repos and returns the resulting revisions.
+func (r *AutoRoller) adminInvalidateCacheHandler(w http.ResponseWriter, req *http.Request) {
+	if err := r.adminUpdate(req.Context(), true); err != nil {
+		httputils.ReportError(w, err, "Failed to update repos.", http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, r.getAdminRevisions())
+}
+
+// adminDryRunHandler uploads a dry run, which also runs any pre-upload steps,
+// and returns the resulting roll.
+func (r *AutoRoller) adminDryRunHandler(w http.ResponseWriter, req *http.Request) {
+	var body AdminDryRunRequest
+	// The request body is optional.
+	if err := json.NewDecoder(req.Body).Decode(&body); err != nil && err != io.EOF {
+		httputils.ReportError(w, err, "Failed to parse request.", http.StatusBadRequest)
+		return
+	}
+	roll, err := r.adminDryRun(req.Context(), body.Revision, alogin.GetStatus(req.Context()).EMail.String())
+	if err != nil {
+		httputils.ReportError(w, err, "Failed to upload dry run.", http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, &AdminDryRunResponse{
+		Issue:    roll.Issue,
+		IssueURL: fmt.Sprintf("%s%d", r.codereview.GetIssueUrlBase(), roll.Issue),
+		Roll:     roll,
+	})
+}
@@ -0,0 +1,38 @@
+package roller
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.skia.org/infra/go/allowed"
+	"go.skia.org/infra/go/alogin"
+	"go.skia.org/infra/go/alogin/mocks"
+	"go.skia.org/infra/go/roles"
+)
+
+func TestAdminMiddleware(t *testing.T) {
+
+	test := func(user alogin.EMail, expectCode int) {
+		login := mocks.NewLogin(t)
+		login.On("LoggedInAs", mock.Anything).Return(user)
+		login.On("Roles", mock.Anything).Return(roles.Roles{}).Maybe()
+		var gotUser alogin.EMail
+		h := AdminMiddleware(login, allowed.NewAllowedFromList([]string{"admin@google.com"}))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			gotUser = alogin.GetStatus(r.Context()).EMail
+		}))
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/_/admin/update", nil))
+		require.Equal(t, expectCode, w.Code, user)
+		if expectCode == http.StatusOK {
+			require.Equal(t, user, gotUser)
+		} else {
+			require.Equal(t, alogin.NotLoggedIn, gotUser)
+		}
+	}
+	test("admin@google.com", http.StatusOK)
+	test("someone@google.com", http.StatusForbidden)
+	test(alogin.NotLoggedIn, http.StatusUnauthorized)
+}
@@ -9,7 +9,6 @@ import (
 	"sync"
 	"time"
 
-	"github.com/go-chi/chi/v5"
 	"go.skia.org/infra/autoroll/go/codereview"
 	"go.skia.org/infra/autoroll/go/commit_msg"
 	"go.skia.org/infra/autoroll/go/config"
@@ -19,6 +18,7 @@ import (
 	arb_notifier "go.skia.org/infra/autoroll/go/notifier"
 	"go.skia.org/infra/autoroll/go/recent_rolls"
 	"go.skia.org/infra/autoroll/go/repo_manager"
+	"go.skia.org/infra/autoroll/go/repo_manager/parent"
 	"go.skia.org/infra/autoroll/go/revision"
 	"go.skia.org/infra/autoroll/go/state_machine"
 	"go.skia.org/infra/autoroll/go/status"
@@ -109,7 +109,11 @@ func NewAutoRoller(ctx context.Context, c *config.Config, emailer emailclient.Cl
 	var cr codereview.CodeReview
 	var err error
 	if c.GetGerrit() != nil {
-		cr, err = codereview.NewGerrit(c.GetGerrit(), g, client)
+		if len(c.GetAndroidRepoManager().GetTopicProject()) > 0 || c.GetGerrit().GetMaxFilesPerChange() > 0 {
+			cr, err = codereview.NewGerritWithTopicChanges(c.GetGerrit(), g, client)
+		} else {
+			cr, err = codereview.NewGerrit(c.GetGerrit(), g, client)
+		}
 	} else if c.GetGithub() != nil {
 		cr, err = codereview.NewGitHub(c.GetGithub(), githubClient)
 	} else {
@@ -520,21 +524,76 @@ func (r *AutoRoller) createNewRoll(ctx context.Context, from, to *revision.Revis
 		return nil, skerr.Wrap(err)
 	}
 	sklog.Infof("Creating new roll with commit message: \n%s", commitMsg)
+	return r.uploadRoll(ctx, from, to, revs, emails, dryRun, manualRollRequester != "", commitMsg)
+}
+
+// uploadRoll uploads a roll CL with the given commit message.
+func (r *AutoRoller) uploadRoll(ctx context.Context, from, to *revision.Revision, revs []*revision.Revision, emails []string, dryRun, manual bool, commitMsg string) (*autoroll.AutoRollIssue, error) {
+	ctx, preUploadStepResults := parent.WithPreUploadStepResults(ctx)
 	issueNum, err := r.rm.CreateNewRoll(ctx, from, to, revs, emails, dryRun, commitMsg)
 	if err != nil {
 		return nil, skerr.Wrap(err)
 	}
+	// The roll has already been uploaded, so don't return the error; doing so
+	// would cause us to upload a duplicate roll.
+	if err := r.codereview.InitRoll(ctx, issueNum); err != nil {
+		sklog.Errorf("Failed to initialize roll %d: %s", issueNum, err)
+	}
 	issue := &autoroll.AutoRollIssue{
-		AttemptStart: time.Now(),
-		IsDryRun:     dryRun,
-		Issue:        issueNum,
-		Manual:       manualRollRequester != "",
-		RollingFrom:  from.Id,
-		RollingTo:    to.Id,
+		AttemptStart:         time.Now(),
+		IsDryRun:             dryRun,
+		Issue:                issueNum,
+		Manual:               manual,
+		PreUploadStepResults: preUploadStepResults.Results(),
+		RollingFrom:          from.Id,
+		RollingTo:            to.Id,
 	}
 	return issue, nil
 }
 
+// Rollback creates a roll CL which moves the Child backwards from the current
+// revision to the given revision, which must have been the result of a recent
+// successful roll.
+func (r *AutoRoller) Rollback(ctx context.Context, to *revision.Revision, emails []string, dryRun bool, requester string) (rv *autoroll.AutoRollIssue, rvErr error) {
+	from := r.GetCurrentRev()
+	if err := checkRollback(r.recent.GetRecentRolls(), from, to); err != nil {
+		return nil, skerr.Wrap(err)
+	}
+	// Track roll CL upload attempts vs failures.
+	defer func() {
+		r.rollUploadAttempts.Inc(1)
+		if rvErr == nil {
+			r.rollUploadFailures.Reset()
+		} else {
+			r.rollUploadFailures.Inc(1)
+		}
+	}()
+	commitMsg, err := r.commitMsgBuilder.BuildRollback(from, to, emails, r.cfg.Contacts, requester)
+	if err != nil {
+		return nil, skerr.Wrap(err)
+	}
+	sklog.Infof("Creating rollback with commit message: \n%s", commitMsg)
+	return r.uploadRoll(ctx, from, to, nil, emails, dryRun, true, commitMsg)
+}
+
+// checkRollback returns an error if it is not safe to roll back from the given
+// current revision to the given revision, ie. if the target revision was not
+// the result of one of the given recent rolls.
+func checkRollback(recent []*autoroll.AutoRollIssue, from, to *revision.Revision) error {
+	if to.Id == from.Id {
+		return skerr.Fmt("Already at revision %q", from.Id)
+	}
+	for _, roll := range recent {
+		if roll.IsDryRun || roll.Result != autoroll.ROLL_RESULT_SUCCESS {
+			continue
+		}
+		if roll.RollingTo == to.Id || roll.RollingFrom == to.Id {
+			return nil
+		}
+	}
+	return skerr.Fmt("Revision %q was not previously rolled by any of the %d most recent rolls; refusing to roll back", to.Id, len(recent))
+}
+
 // FailureThrottle returns a state_machine.Throttler indicating that we have
 // failed to roll too many times within a time period.
 func (r *AutoRoller) FailureThrottle() *state_machine.Throttler {
@@ -863,9 +922,6 @@ func (r *AutoRoller) AddComment(ctx context.Context, issueNum int64, message, us
 	return r.recent.Update(ctx, roll)
 }
 
-// AddHandlers implements main.AutoRollerI.
-func (r *AutoRoller) AddHandlers(chi.Router) {}
-
 // Callback function which runs when roll CLs are closed.
 func (r *AutoRoller) rollFinished(ctx context.Context, justFinished codereview.RollImpl) error {
 	recent := r.recent.GetRecentRolls()
@@ -993,9 +1049,13 @@ func (r *AutoRoller) handleManualRolls(ctx context.Context) error {
 				}
 			}
 			var err error
-			sklog.Infof("Creating manual roll to %s as requested by %s...", req.Revision, req.Requester)
-
-			issue, err = r.createNewRoll(ctx, from, to, emails, req.DryRun, req.Canary, req.Requester)
+			if req.Rollback {
+				sklog.Infof("Creating rollback to %s as requested by %s...", req.Revision, req.Requester)
+				issue, err = r.Rollback(ctx, to, emails, req.DryRun, req.Requester)
+			} else {
+				sklog.Infof("Creating manual roll to %s as requested by %s...", req.Revision, req.Requester)
+				issue, err = r.createNewRoll(ctx, from, to, emails, req.DryRun, req.Canary, req.Requester)
+			}
 			if err != nil {
 				err := skerr.Wrapf(err, "failed to create manual roll for %s", req.Id)
 				req.Status = manual.STATUS_COMPLETE
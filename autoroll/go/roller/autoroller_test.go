@@ -6,6 +6,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 	"go.skia.org/infra/autoroll/go/revision"
+	"go.skia.org/infra/go/autoroll"
 )
 
 func TestAutoRollerRolledPast(t *testing.T) {
@@ -40,3 +41,24 @@ func TestAutoRollerRolledPast(t *testing.T) {
 	check("5", false)             // tipRev
 	check("some other rev", true) // everything else
 }
+
+func TestCheckRollback(t *testing.T) {
+
+	rev := func(id string) *revision.Revision {
+		return &revision.Revision{Id: id}
+	}
+	recent := []*autoroll.AutoRollIssue{
+		{RollingFrom: "4", RollingTo: "5", Result: autoroll.ROLL_RESULT_FAILURE},
+		{RollingFrom: "3", RollingTo: "4", Result: autoroll.ROLL_RESULT_DRY_RUN_SUCCESS, IsDryRun: true},
+		{RollingFrom: "2", RollingTo: "3", Result: autoroll.ROLL_RESULT_SUCCESS},
+		{RollingFrom: "1", RollingTo: "2", Result: autoroll.ROLL_RESULT_SUCCESS},
+	}
+	require.NoError(t, checkRollback(recent, rev("3"), rev("2")))
+	require.NoError(t, checkRollback(recent, rev("3"), rev("1")))
+	require.ErrorContains(t, checkRollback(recent, rev("3"), rev("3")), `Already at revision "3"`)
+	require.ErrorContains(t, checkRollback(recent, rev("3"), rev("0")), `Revision "0" was not previously rolled by any of the 4 most recent rolls; refusing to roll back`)
+	// Revisions which were only the target of failed rolls or dry runs are
+	// not safe to roll back to.
+	require.Error(t, checkRollback(recent, rev("3"), rev("5")))
+	require.Error(t, checkRollback(recent, rev("3"), rev("4")))
+}
@@ -415,6 +415,7 @@ func (s *AutoRollServer) CreateManualRoll(ctx context.Context, req *CreateManual
 		Revision:   req.Revision,
 		Requester:  user,
 		DryRun:     req.DryRun,
+		Rollback:   req.Rollback,
 	}
 	m.Status = manual.STATUS_PENDING
 	m.Timestamp = firestore.FixTimestamp(timeNowFunc())
@@ -737,6 +738,7 @@ func convertManualRollRequest(inp *manual.ManualRollRequest) (*ManualRoll, error
 		DryRun:            inp.DryRun,
 		NoEmail:           inp.NoEmail,
 		NoResolveRevision: inp.NoResolveRevision,
+		Rollback:          inp.Rollback,
 	}, nil
 }
 
@@ -526,6 +526,15 @@ func TestCreateManualRoll(t *testing.T) {
 	assertdeep.Equal(t, &CreateManualRollResponse{
 		Roll: expect,
 	}, res)
+
+	// Check rollback requests.
+	req.Rollback = true
+	rollbackReq := manualReq.Copy()
+	rollbackReq.Rollback = true
+	srv.manualRollDB.(*manual_mocks.DB).On("Put", rollbackReq).Return(nil)
+	res, err = srv.CreateManualRoll(ctx, req)
+	require.NoError(t, err)
+	require.True(t, res.Roll.Rollback)
 }
 
 func TestUnthrottle(t *testing.T) {
@@ -728,6 +737,7 @@ func TestConvertManualRollRequest(t *testing.T) {
 		Canary:            true,
 		NoEmail:           true,
 		NoResolveRevision: true,
+		Rollback:          true,
 	}
 
 	// Use Copy to ensure that the test checks all of the fields. Note that it
@@ -751,6 +761,7 @@ func TestConvertManualRollRequest(t *testing.T) {
 		Canary:            true,
 		NoEmail:           true,
 		NoResolveRevision: true,
+		Rollback:          req.Rollback,
 	}, actual)
 }
 
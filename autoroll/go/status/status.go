@@ -141,13 +141,9 @@ type DB interface {
 	Close() error
 }
 
-// Fake ancestor we supply for all AutoRollStatus, to force strong consistency.
+// Entity group of all AutoRollStatus, to force strong consistency.
 // We lose some performance this way but it keeps our tests from flaking.
-func fakeAncestor() *datastore.Key {
-	rv := ds.NewKey(ds.KIND_AUTOROLL_STATUS_ANCESTOR)
-	rv.ID = 13 // Bogus ID.
-	return rv
-}
+var entityGroup = ds.NewEntityGroup(ds.KIND_AUTOROLL_STATUS_ANCESTOR, 13) // Bogus ID.
 
 // DsStatusWrapper is a helper struct used for storing an AutoRollStatus in the
 // datastore.
@@ -160,7 +156,7 @@ type DsStatusWrapper struct {
 func key(rollerName string) *datastore.Key {
 	key := ds.NewKey(ds.KIND_AUTOROLL_STATUS)
 	key.Name = rollerName
-	key.Parent = fakeAncestor()
+	key.Parent = entityGroup.Ancestor()
 	return key
 }
 
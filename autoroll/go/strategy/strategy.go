@@ -18,14 +18,10 @@ const (
 	StrategyHistoryLength = 25
 )
 
-// Fake ancestor we supply for all ModeChanges, to force consistency.
+// Entity group of all StrategyChanges, to force consistency.
 // We lose some performance this way but it keeps our tests from
 // flaking.
-func fakeAncestor() *datastore.Key {
-	rv := ds.NewKey(ds.KIND_AUTOROLL_STRATEGY_ANCESTOR)
-	rv.ID = 13 // Bogus ID.
-	return rv
-}
+var entityGroup = ds.NewEntityGroup(ds.KIND_AUTOROLL_STRATEGY_ANCESTOR, 13) // Bogus ID.
 
 // StrategyChange is a struct used for describing a change in the AutoRoll strategy.
 type StrategyChange struct {
@@ -101,7 +97,7 @@ func (sh *DatastoreStrategyHistory) Add(ctx context.Context, s, user, message st
 // put inserts the StrategyChange into the datastore.
 func (sh *DatastoreStrategyHistory) put(ctx context.Context, s *StrategyChange) error {
 	key := ds.NewKey(ds.KIND_AUTOROLL_STRATEGY)
-	key.Parent = fakeAncestor()
+	key.Parent = entityGroup.Ancestor()
 	_, err := ds.DS.RunInTransaction(ctx, func(tx *datastore.Transaction) error {
 		_, err := tx.Put(key, s)
 		return err
@@ -122,7 +118,7 @@ func (sh *DatastoreStrategyHistory) CurrentStrategy() *StrategyChange {
 
 // GetHistory returns a slice of the most recent StrategyChanges, most recent first.
 func (sh *DatastoreStrategyHistory) GetHistory(ctx context.Context, offset int) ([]*StrategyChange, int, error) {
-	query := ds.NewQuery(ds.KIND_AUTOROLL_STRATEGY).Ancestor(fakeAncestor()).Filter("roller =", sh.roller).Order("-time").Limit(StrategyHistoryLength).Offset(offset)
+	query := entityGroup.NewQuery(ds.KIND_AUTOROLL_STRATEGY).Filter("roller =", sh.roller).Order("-time").Limit(StrategyHistoryLength).Offset(offset)
 	var history []*StrategyChange
 	if _, err := ds.DS.GetAll(ctx, query, &history); err != nil {
 		return nil, offset, skerr.Wrap(err)
@@ -136,7 +132,7 @@ func (sh *DatastoreStrategyHistory) GetHistory(ctx context.Context, offset int)
 
 // Update refreshes the strategy history from the datastore.
 func (sh *DatastoreStrategyHistory) Update(ctx context.Context) error {
-	query := ds.NewQuery(ds.KIND_AUTOROLL_STRATEGY).Ancestor(fakeAncestor()).Filter("roller =", sh.roller).Order("-time").Limit(1)
+	query := entityGroup.NewQuery(ds.KIND_AUTOROLL_STRATEGY).Filter("roller =", sh.roller).Order("-time").Limit(1)
 	var history []*StrategyChange
 	if _, err := ds.DS.GetAll(ctx, query, &history); err != nil {
 		return skerr.Wrap(err)
@@ -25,21 +25,14 @@ type entry struct {
 	ShouldUnthrottle bool `datastore:"shouldUnthrottle,noindex"`
 }
 
-// Fake ancestor we supply for unthrottling, to force consistency.
+// Entity group of all unthrottle entries, to force consistency.
 // We lose some performance this way but it keeps our tests from
 // flaking.
-func fakeAncestor() *datastore.Key {
-	rv := ds.NewKey(ds.KIND_AUTOROLL_UNTHROTTLE_ANCESTOR)
-	rv.ID = 13 // Bogus ID.
-	return rv
-}
+var entityGroup = ds.NewEntityGroup(ds.KIND_AUTOROLL_UNTHROTTLE_ANCESTOR, 13) // Bogus ID.
 
 // Return a datastore key for the given roller.
 func key(roller string) *datastore.Key {
-	k := ds.NewKey(ds.KIND_AUTOROLL_UNTHROTTLE)
-	k.Parent = fakeAncestor()
-	k.Name = roller + "_unthrottle"
-	return k
+	return entityGroup.NameKey(ds.KIND_AUTOROLL_UNTHROTTLE, roller+"_unthrottle")
 }
 
 // DatastoreThrottle is an implementation of Throttle which uses Datastore.
@@ -62,12 +55,9 @@ func (t *DatastoreThrottle) Reset(ctx context.Context, roller string) error {
 
 // Set whether the given roller should be unthrottled.
 func set(ctx context.Context, roller string, shouldUnthrottle bool) error {
-	e := &entry{
-		ShouldUnthrottle: shouldUnthrottle,
-	}
-	_, err := ds.DS.RunInTransaction(ctx, func(tx *datastore.Transaction) error {
-		_, err := tx.Put(key(roller), e)
-		return err
+	_, err := ds.ReadModifyWrite(ctx, ds.DS, key(roller), func(e *entry, found bool) error {
+		e.ShouldUnthrottle = shouldUnthrottle
+		return nil
 	})
 	return err
 }
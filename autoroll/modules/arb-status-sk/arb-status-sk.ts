@@ -531,6 +531,24 @@ export class ARBStatusSk extends ElementSk {
                   }">
                 Request Roll
               </button>
+
+              <button
+                  @click="${() => {
+                    ele.requestManualRoll(
+                      $$<HTMLInputElement>('#manualRollRevInput')!.value,
+                      false,
+                      true
+                    );
+                  }}"
+                  class="requestRoll"
+                  ?disabled=${!ele.editRights}
+                  title="${
+                    ele.editRights
+                      ? 'Request a rollback to this previously-rolled revision.'
+                      : ele.pleaseLoginMsg
+                  }">
+                Request Rollback
+              </button>
             </td>
           </tr>
         `
@@ -984,10 +1002,12 @@ export class ARBStatusSk extends ElementSk {
     }
   }
 
-  private requestManualRoll(rev: string, dryRun: boolean) {
+  private requestManualRoll(rev: string, dryRun: boolean, rollback = false) {
     // Make sure the user wants to proceed.
     let confirmMsg = 'Proceed with requesting manual ';
-    if (dryRun) {
+    if (rollback) {
+      confirmMsg += 'rollback?';
+    } else if (dryRun) {
       confirmMsg += 'dry-run?';
     } else {
       confirmMsg += 'roll?';
@@ -1002,6 +1022,7 @@ export class ARBStatusSk extends ElementSk {
         revision: rev,
         rollerId: this.roller,
         dryRun: dryRun,
+        rollback: rollback,
       })
       .then((resp: CreateManualRollResponse) => {
         const exist = this.rollCandidates.find(
@@ -1155,6 +1176,7 @@ export class ARBStatusSk extends ElementSk {
           req = {
             dryRun: false,
             canary: false,
+            rollback: false,
             id: '',
             noEmail: false,
             noResolveRevision: false,
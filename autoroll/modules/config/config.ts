@@ -151,6 +151,9 @@ export interface CommitMsgConfig {
   wordWrap: number;
   builtIn: CommitMsgConfig_BuiltIn;
   custom: string;
+  extraBugProjects?: string[];
+  parentBugProject: string;
+  bugUrls: boolean;
 }
 
 interface CommitMsgConfigJSON {
@@ -166,18 +169,29 @@ interface CommitMsgConfigJSON {
   word_wrap?: number;
   built_in?: string;
   custom?: string;
+  extra_bug_projects?: string[];
+  parent_bug_project?: string;
+  bug_urls?: boolean;
 }
 
 export interface GerritConfig {
   url: string;
   project: string;
   config: GerritConfig_Config;
+  hashtags?: string[];
+  attentionOnFailure: boolean;
+  maxFilesPerChange: number;
+  splitByDirectory: boolean;
 }
 
 interface GerritConfigJSON {
   url?: string;
   project?: string;
   config?: string;
+  hashtags?: string[];
+  attention_on_failure?: boolean;
+  max_files_per_change?: number;
+  split_by_directory?: boolean;
 }
 
 export interface GitHubConfig {
@@ -256,6 +270,7 @@ export interface AndroidRepoManagerConfig {
   preUploadCommands?: PreUploadConfig;
   autoApproverSecret: string;
   defaultBugProject: string;
+  topicProject?: string[];
 }
 
 interface AndroidRepoManagerConfigJSON {
@@ -272,6 +287,7 @@ interface AndroidRepoManagerConfigJSON {
   pre_upload_commands?: PreUploadConfigJSON;
   auto_approver_secret?: string;
   default_bug_project?: string;
+  topic_project?: string[];
 }
 
 export interface CommandRepoManagerConfig_CommandConfig {
@@ -339,6 +355,7 @@ export interface ParentChildRepoManagerConfig {
   buildbucketRevisionFilter?: BuildbucketRevisionFilterConfig[];
   cipdRevisionFilter?: CIPDRevisionFilterConfig[];
   validHttpRevisionFilter?: ValidHttpRevisionFilterConfig[];
+  commitRevisionFilter?: CommitRevisionFilterConfig[];
 }
 
 interface ParentChildRepoManagerConfigJSON {
@@ -358,6 +375,7 @@ interface ParentChildRepoManagerConfigJSON {
   buildbucket_revision_filter?: BuildbucketRevisionFilterConfigJSON[];
   cipd_revision_filter?: CIPDRevisionFilterConfigJSON[];
   valid_http_revision_filter?: ValidHttpRevisionFilterConfigJSON[];
+  commit_revision_filter?: CommitRevisionFilterConfigJSON[];
 }
 
 export interface CopyParentConfig_CopyEntry {
@@ -441,6 +459,7 @@ export interface GitilesConfig {
   repoUrl: string;
   dependencies?: VersionFileConfig[];
   defaultBugProject: string;
+  transitiveDependencies: boolean;
 }
 
 interface GitilesConfigJSON {
@@ -448,6 +467,7 @@ interface GitilesConfigJSON {
   repo_url?: string;
   dependencies?: VersionFileConfigJSON[];
   default_bug_project?: string;
+  transitive_dependencies?: boolean;
 }
 
 export interface GoModGerritParentConfig {
@@ -525,6 +545,7 @@ export interface CIPDChildConfig {
   revisionIdTag: string;
   revisionIdTagStripKey: boolean;
   sourceRepo?: GitilesConfig;
+  platform?: string[];
 }
 
 interface CIPDChildConfigJSON {
@@ -534,6 +555,7 @@ interface CIPDChildConfigJSON {
   revision_id_tag?: string;
   revision_id_tag_strip_key?: boolean;
   source_repo?: GitilesConfigJSON;
+  platform?: string[];
 }
 
 export interface FuchsiaSDKChildConfig {
@@ -610,12 +632,14 @@ export interface DockerChildConfig {
   registry: string;
   repository: string;
   tag: string;
+  semverRegex: string;
 }
 
 interface DockerChildConfigJSON {
   registry?: string;
   repository?: string;
   tag?: string;
+  semver_regex?: string;
 }
 
 export interface NotifierConfig {
@@ -730,6 +754,7 @@ export interface GitCheckoutConfig {
   revLinkTmpl: string;
   dependencies?: VersionFileConfig[];
   defaultBugProject: string;
+  transitiveDependencies: boolean;
 }
 
 interface GitCheckoutConfigJSON {
@@ -738,6 +763,7 @@ interface GitCheckoutConfigJSON {
   rev_link_tmpl?: string;
   dependencies?: VersionFileConfigJSON[];
   default_bug_project?: string;
+  transitive_dependencies?: boolean;
 }
 
 export interface BuildbucketRevisionFilterConfig {
@@ -776,14 +802,30 @@ interface ValidHttpRevisionFilterConfigJSON {
   regex?: string;
 }
 
+export interface CommitRevisionFilterConfig {
+  commitMsgRegex?: string[];
+  author?: string[];
+  pathGlob?: string[];
+  repoUrl: string;
+}
+
+interface CommitRevisionFilterConfigJSON {
+  commit_msg_regex?: string[];
+  author?: string[];
+  path_glob?: string[];
+  repo_url?: string;
+}
+
 export interface PreUploadConfig {
   cipdPackage?: PreUploadCIPDPackageConfig[];
   command?: PreUploadCommandConfig[];
+  maxParallelism: number;
 }
 
 interface PreUploadConfigJSON {
   cipd_package?: PreUploadCIPDPackageConfigJSON[];
   command?: PreUploadCommandConfigJSON[];
+  max_parallelism?: number;
 }
 
 export interface PreUploadCommandConfig {
@@ -791,6 +833,10 @@ export interface PreUploadCommandConfig {
   cwd: string;
   env?: string[];
   ignoreFailure: boolean;
+  name: string;
+  dependsOn?: string[];
+  timeout: string;
+  retries: number;
 }
 
 interface PreUploadCommandConfigJSON {
@@ -798,6 +844,10 @@ interface PreUploadCommandConfigJSON {
   cwd?: string;
   env?: string[];
   ignore_failure?: boolean;
+  name?: string;
+  depends_on?: string[];
+  timeout?: string;
+  retries?: number;
 }
 
 export interface PreUploadCIPDPackageConfig {
@@ -40,11 +40,16 @@ export function GetFakeConfig(): Config {
       builtIn: CommitMsgConfig_BuiltIn.DEFAULT,
       custom: '',
       wordWrap: 72,
+      parentBugProject: '',
+      bugUrls: false,
     },
     gerrit: {
       url: 'https://skia-review.googlesource.com',
       project: 'skiabot-test',
       config: GerritConfig_Config.CHROMIUM,
+      attentionOnFailure: false,
+      maxFilesPerChange: 0,
+      splitByDirectory: false,
     },
     kubernetes: {
       cpu: '1',
@@ -61,6 +66,7 @@ export function GetFakeConfig(): Config {
           branch: 'master',
           repoUrl: 'https://skia.googlesource.com/skiabot-test.git',
           defaultBugProject: 'skia',
+          transitiveDependencies: false,
         },
         dep: {
           primary: {
@@ -73,6 +79,9 @@ export function GetFakeConfig(): Config {
           url: 'https://skia-review.googlesource.com',
           project: 'skiabot-test',
           config: GerritConfig_Config.CHROMIUM,
+          attentionOnFailure: false,
+          maxFilesPerChange: 0,
+          splitByDirectory: false,
         },
       },
       gitilesChild: {
@@ -80,6 +89,7 @@ export function GetFakeConfig(): Config {
           branch: 'master',
           repoUrl: 'https://skia.googlesource.com/skia.git',
           defaultBugProject: 'skia',
+          transitiveDependencies: false,
         },
         path: '',
       },
@@ -42,6 +42,7 @@ export function GetFakeStatus(): AutoRollStatus {
       {
         dryRun: false,
         canary: false,
+        rollback: false,
         id: 'dEj3GAGLfIFn67MirRhC',
         noEmail: false,
         noResolveRevision: false,
@@ -184,6 +184,7 @@ class FakeAutoRollService implements AutoRollService {
       id: id,
       dryRun: false,
       canary: false,
+      rollback: req.rollback,
       noEmail: false,
       noResolveRevision: false,
       rollerId: req.rollerId,
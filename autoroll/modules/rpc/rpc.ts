@@ -275,6 +275,7 @@ export interface ManualRoll {
   noEmail: boolean;
   noResolveRevision: boolean;
   canary: boolean;
+  rollback: boolean;
 }
 
 interface ManualRollJSON {
@@ -290,6 +291,7 @@ interface ManualRollJSON {
   no_email?: boolean;
   no_resolve_revision?: boolean;
   canary?: boolean;
+  rollback?: boolean;
 }
 
 const JSONToManualRoll = (m: ManualRollJSON): ManualRoll => {
@@ -306,6 +308,7 @@ const JSONToManualRoll = (m: ManualRollJSON): ManualRoll => {
     noEmail: m.no_email || false,
     noResolveRevision: m.no_resolve_revision || false,
     canary: m.canary || false,
+    rollback: m.rollback || false,
   };
 };
 
@@ -617,12 +620,14 @@ export interface CreateManualRollRequest {
   rollerId: string;
   revision: string;
   dryRun: boolean;
+  rollback: boolean;
 }
 
 interface CreateManualRollRequestJSON {
   roller_id?: string;
   revision?: string;
   dry_run?: boolean;
+  rollback?: boolean;
 }
 
 const CreateManualRollRequestToJSON = (m: CreateManualRollRequest): CreateManualRollRequestJSON => {
@@ -630,6 +635,7 @@ const CreateManualRollRequestToJSON = (m: CreateManualRollRequest): CreateManual
     roller_id: m.rollerId,
     revision: m.revision,
     dry_run: m.dryRun,
+    rollback: m.rollback,
   };
 };
 
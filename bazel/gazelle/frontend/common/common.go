@@ -1,6 +1,8 @@
 // Package common contains any code used by two or more packages. It avoid circular dependencies.
 package common
 
+import "strings"
+
 // ImportsParsedFromRuleSources is the "imports" interface returned by Language.GenerateRules(), and
 // passed by Gazelle to Resolver.Resolve().
 //
@@ -19,3 +21,35 @@ type ImportsParsedFromRuleSources interface {
 	// karma_test, etc.).
 	GetTypeScriptImports() []string
 }
+
+// TypeScriptExtensions are the file extensions of TypeScript sources, including TSX sources and
+// declaration files. Longer extensions come first, so that e.g. "foo.d.ts" is not mistaken for a
+// regular TypeScript file named "foo.d".
+var TypeScriptExtensions = []string{".d.ts", ".tsx", ".ts"}
+
+// TrimTypeScriptExtension returns the given file name without its TypeScript extension (e.g.
+// "foo.d.ts" becomes "foo"), and whether the file has one of the TypeScriptExtensions.
+func TrimTypeScriptExtension(file string) (string, bool) {
+	for _, ext := range TypeScriptExtensions {
+		if strings.HasSuffix(file, ext) {
+			return strings.TrimSuffix(file, ext), true
+		}
+	}
+	return file, false
+}
+
+// AssetExtensions are the file extensions of static assets that may be imported from TypeScript
+// tests, such as JSON or PNG fixtures. Imports of these files are resolved to filegroup targets,
+// which are added to the data attribute of the importing test.
+var AssetExtensions = []string{".gif", ".jpeg", ".jpg", ".json", ".png", ".svg", ".txt"}
+
+// IsAssetFile returns true if the given file name or import path refers to a static asset, based
+// on its extension.
+func IsAssetFile(file string) bool {
+	for _, ext := range AssetExtensions {
+		if strings.HasSuffix(file, ext) {
+			return true
+		}
+	}
+	return false
+}
@@ -2,25 +2,134 @@ package configurer
 
 import (
 	"flag"
+	"log"
+	"strconv"
+	"strings"
 
 	"github.com/bazelbuild/bazel-gazelle/config"
+	"github.com/bazelbuild/bazel-gazelle/label"
 	"github.com/bazelbuild/bazel-gazelle/rule"
 )
 
+const (
+	// configKey is the key under which the per-directory FrontendConfig is stored in the
+	// config.Config's Exts map.
+	configKey = "frontend"
+
+	// strictDirective enables or disables strict mode for a directory and its subdirectories, e.g.
+	//
+	//     # gazelle:frontend_strict true
+	//
+	// This directive overrides the value of the --frontend_strict flag.
+	strictDirective = "frontend_strict"
+
+	// ProvidesDirective declares additional imports provided by a rule in the same BUILD file, e.g.
+	// imports of generated files which are not listed in the rule's sources as file names:
+	//
+	//     # gazelle:frontend_provides json_ts_lib index
+	//
+	// The first argument is the name of the rule, and the second argument is the path of the import
+	// without file extension, relative to the directory of the BUILD file.
+	ProvidesDirective = "frontend_provides"
+
+	// resolveDirective overrides the resolution of any imports matching a prefix for a directory and
+	// its subdirectories, e.g.
+	//
+	//     # gazelle:frontend_resolve ts vendored-lib //third_party/vendored-lib:vendored-lib_ts_lib
+	//
+	// The first argument is the language of the import ("ts" or "sass"), the second argument is the
+	// import prefix, as written in the source files, and the third argument is the label of the
+	// dependency to use instead. This is analogous to the "resolve" directive for Go.
+	resolveDirective = "frontend_resolve"
+)
+
 // Configurer implements the config.Configurer interface.
 type Configurer struct {
 	// IsUnitTest will be true if flag --frontend_unit_test is passed. If set,
 	// Language.GenerateRules() will not limit rule generation to the list of known good directories.
 	IsUnitTest bool
+
+	// Strict will be true if flag --frontend_strict is passed. It determines the default value of
+	// FrontendConfig.Strict, which can be overridden on a per-directory basis via the
+	// "frontend_strict" directive.
+	Strict bool
+
+	// UnresolvedImportsReportPath is the value of flag --frontend_unresolved_imports_report. In
+	// strict mode, the report of unresolved imports will be written to this file, or to stdout if
+	// empty.
+	UnresolvedImportsReportPath string
+
+	// ImportsCachePath is the value of flag --frontend_imports_cache. If set, the imports parsed from
+	// source files will be cached in this file across Gazelle runs.
+	ImportsCachePath string
+}
+
+// FrontendConfig contains the per-directory configuration of this Gazelle extension.
+type FrontendConfig struct {
+	// Strict is true if any imports that cannot be resolved from the rules in this directory should
+	// cause Gazelle to exit with a non-zero exit code.
+	Strict bool
+
+	// ImportOverrides are the import resolution overrides declared via the "frontend_resolve"
+	// directive in this directory and its parent directories.
+	ImportOverrides []ImportOverride
+}
+
+// ImportOverride maps imports matching a prefix to a specific dependency.
+type ImportOverride struct {
+	// Lang is the language of the import, i.e. "ts" or "sass".
+	Lang string
+
+	// ImportPrefix matches imports that are equal to it, or that start with it followed by a "/".
+	ImportPrefix string
+
+	// Dep is the dependency that any matching imports resolve to.
+	Dep label.Label
+}
+
+// FindImportOverride returns the dependency that the given import resolves to, if it matches any
+// of the import resolution overrides. If multiple overrides match, the one with the longest prefix
+// wins, and ties are broken in favor of overrides declared in subdirectories.
+func (fc *FrontendConfig) FindImportOverride(lang, importPath string) (label.Label, bool) {
+	found := false
+	var best ImportOverride
+	for _, o := range fc.ImportOverrides {
+		if o.Lang != lang {
+			continue
+		}
+		if importPath != o.ImportPrefix && !strings.HasPrefix(importPath, strings.TrimSuffix(o.ImportPrefix, "/")+"/") {
+			continue
+		}
+		if !found || len(o.ImportPrefix) >= len(best.ImportPrefix) {
+			best = o
+			found = true
+		}
+	}
+	return best.Dep, found
+}
+
+// GetFrontendConfig returns the FrontendConfig for the directory associated with the given
+// config.Config.
+func GetFrontendConfig(cc *config.Config) *FrontendConfig {
+	if fc, ok := cc.Exts[configKey].(*FrontendConfig); ok {
+		return fc
+	}
+	return &FrontendConfig{}
 }
 
 // RegisterFlags implements the config.Configurer interface.
 func (c *Configurer) RegisterFlags(fs *flag.FlagSet, cmd string, cc *config.Config) {
 	fs.BoolVar(&c.IsUnitTest, "frontend_unit_test", false, "DO NOT USE. This flag is passed to Gazelle from unit tests.")
+	fs.BoolVar(&c.Strict, "frontend_strict", false, "If set, Gazelle will exit with a non-zero exit code if any TypeScript or Sass imports cannot be resolved. Can be overridden per directory via the frontend_strict directive.")
+	fs.StringVar(&c.ImportsCachePath, "frontend_imports_cache", "", "Path to a file where the imports parsed from TypeScript and Sass sources will be cached across runs (e.g. ~/.cache/gazelle_frontend/imports.json). Unchanged files are not parsed again. Disabled if empty.")
+	fs.StringVar(&c.UnresolvedImportsReportPath, "frontend_unresolved_imports_report", "", "Path to a file where a JSON report of any unresolved imports will be written in strict mode. Defaults to stdout.")
 }
 
 // CheckFlags implements the config.Configurer interface.
-func (c *Configurer) CheckFlags(fs *flag.FlagSet, cc *config.Config) error { return nil }
+func (c *Configurer) CheckFlags(fs *flag.FlagSet, cc *config.Config) error {
+	cc.Exts[configKey] = &FrontendConfig{Strict: c.Strict}
+	return nil
+}
 
 // KnownDirectives implements the config.Configurer interface.
 //
@@ -30,10 +139,61 @@ func (c *Configurer) CheckFlags(fs *flag.FlagSet, cc *config.Config) error { ret
 // interpret. Gazelle prints errors for directives that are not recoginized by
 // any Configurer.
 func (c *Configurer) KnownDirectives() []string {
-	return []string{"karma_test", "nodejs_test", "sass_library", "sk_demo_page_server", "sk_element", "sk_element_puppeteer_test", "sk_page", "ts_library"}
+	return []string{"karma_test", "nodejs_test", "sass_library", "sk_demo_page_server", "sk_element", "sk_element_puppeteer_test", "sk_page", "ts_library", strictDirective, ProvidesDirective, resolveDirective}
 }
 
 // Configure implements the config.Configurer interface.
-func (c *Configurer) Configure(cc *config.Config, rel string, f *rule.File) {}
+//
+// Interface documentation:
+//
+// Configure modifies the configuration using directives and other information
+// extracted from a build file. Configure is called in each directory.
+func (c *Configurer) Configure(cc *config.Config, rel string, f *rule.File) {
+	// Subdirectories inherit the configuration of their parent directory.
+	fc := &FrontendConfig{}
+	*fc = *GetFrontendConfig(cc)
+	fc.ImportOverrides = append([]ImportOverride{}, fc.ImportOverrides...)
+	cc.Exts[configKey] = fc
+
+	if f == nil {
+		return
+	}
+	for _, d := range f.Directives {
+		if d.Key == strictDirective {
+			strict, err := strconv.ParseBool(d.Value)
+			if err != nil {
+				log.Printf("Invalid value for directive %q in %s: %q (expected true or false).", d.Key, f.Path, d.Value)
+				continue
+			}
+			fc.Strict = strict
+		}
+		if d.Key == resolveDirective {
+			if o, ok := parseResolveDirective(d.Value, rel, f.Path); ok {
+				fc.ImportOverrides = append(fc.ImportOverrides, o)
+			}
+		}
+	}
+}
+
+// parseResolveDirective parses the value of a "frontend_resolve" directive found in the BUILD file
+// of the given directory. Relative labels are interpreted relative to that directory.
+func parseResolveDirective(value, rel, buildFilePath string) (ImportOverride, bool) {
+	fields := strings.Fields(value)
+	if len(fields) != 3 {
+		log.Printf("Invalid value for directive %q in %s: %q (expected <lang> <import-prefix> <label>).", resolveDirective, buildFilePath, value)
+		return ImportOverride{}, false
+	}
+	lang, importPrefix, depStr := fields[0], fields[1], fields[2]
+	if lang != "ts" && lang != "sass" {
+		log.Printf("Invalid language for directive %q in %s: %q (expected ts or sass).", resolveDirective, buildFilePath, lang)
+		return ImportOverride{}, false
+	}
+	dep, err := label.Parse(depStr)
+	if err != nil {
+		log.Printf("Invalid label for directive %q in %s: %q: %v", resolveDirective, buildFilePath, depStr, err)
+		return ImportOverride{}, false
+	}
+	return ImportOverride{Lang: lang, ImportPrefix: importPrefix, Dep: dep.Abs("", rel)}, true
+}
 
 var _ config.Configurer = &Configurer{}
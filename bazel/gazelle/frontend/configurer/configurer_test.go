@@ -0,0 +1,92 @@
+package configurer
+
+import (
+	"testing"
+
+	"github.com/bazelbuild/bazel-gazelle/config"
+	"github.com/bazelbuild/bazel-gazelle/rule"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigure_StrictDirective_InheritedBySubdirectories(t *testing.T) {
+	c := &Configurer{}
+	cc := config.New()
+	require.NoError(t, c.CheckFlags(nil, cc))
+	assert.False(t, GetFrontendConfig(cc).Strict)
+
+	// Enable strict mode in the "a" directory.
+	f, err := rule.LoadData("a/BUILD.bazel", "a", []byte("# gazelle:frontend_strict true\n"))
+	require.NoError(t, err)
+	c.Configure(cc, "a", f)
+	assert.True(t, GetFrontendConfig(cc).Strict)
+
+	// Subdirectories without a BUILD file or directives inherit the parent's configuration.
+	parent := cc
+	cc = parent.Clone()
+	c.Configure(cc, "a/b", nil)
+	assert.True(t, GetFrontendConfig(cc).Strict)
+
+	// Strict mode can be disabled again, without affecting the parent directory.
+	cc = parent.Clone()
+	f, err = rule.LoadData("a/c/BUILD.bazel", "a/c", []byte("# gazelle:frontend_strict false\n"))
+	require.NoError(t, err)
+	c.Configure(cc, "a/c", f)
+	assert.False(t, GetFrontendConfig(cc).Strict)
+	assert.True(t, GetFrontendConfig(parent).Strict)
+}
+
+func TestConfigure_StrictFlag_SetsDefault(t *testing.T) {
+	c := &Configurer{Strict: true}
+	cc := config.New()
+	require.NoError(t, c.CheckFlags(nil, cc))
+	c.Configure(cc, "", nil)
+	assert.True(t, GetFrontendConfig(cc).Strict)
+}
+
+func TestConfigure_ResolveDirective_OverridesMatchingImports(t *testing.T) {
+	c := &Configurer{}
+	cc := config.New()
+	require.NoError(t, c.CheckFlags(nil, cc))
+
+	f, err := rule.LoadData("a/BUILD.bazel", "a", []byte(`
+# gazelle:frontend_resolve ts vendored //third_party/vendored:vendored_ts_lib
+# gazelle:frontend_resolve sass vendored/styles :styles_sass_lib
+# gazelle:frontend_resolve ts missing-label
+# gazelle:frontend_resolve go vendored //third_party/vendored:vendored_go_lib
+`))
+	require.NoError(t, err)
+	c.Configure(cc, "a", f)
+	parent := cc
+
+	cc = parent.Clone()
+	f, err = rule.LoadData("a/b/BUILD.bazel", "a/b", []byte(`
+# gazelle:frontend_resolve ts vendored/special //a/b:special_ts_lib
+`))
+	require.NoError(t, err)
+	c.Configure(cc, "a/b", f)
+
+	find := func(cc *config.Config, lang, importPath string) string {
+		dep, ok := GetFrontendConfig(cc).FindImportOverride(lang, importPath)
+		if !ok {
+			return ""
+		}
+		return dep.String()
+	}
+
+	assert.Equal(t, "//third_party/vendored:vendored_ts_lib", find(parent, "ts", "vendored"))
+	assert.Equal(t, "//third_party/vendored:vendored_ts_lib", find(parent, "ts", "vendored/foo"))
+	assert.Equal(t, "", find(parent, "ts", "vendored-other"))
+	// Relative labels are resolved relative to the directory of the BUILD file.
+	assert.Equal(t, "//a:styles_sass_lib", find(parent, "sass", "vendored/styles"))
+	assert.Equal(t, "", find(parent, "sass", "vendored"))
+	// The longest matching prefix wins, and overrides are inherited by subdirectories.
+	assert.Equal(t, "//a/b:special_ts_lib", find(cc, "ts", "vendored/special/foo"))
+	assert.Equal(t, "//third_party/vendored:vendored_ts_lib", find(cc, "ts", "vendored/foo"))
+	// Overrides declared in subdirectories do not affect the parent directory.
+	assert.Equal(t, "//third_party/vendored:vendored_ts_lib", find(parent, "ts", "vendored/special/foo"))
+	assert.Len(t, GetFrontendConfig(parent).ImportOverrides, 2)
+
+	_, ok := GetFrontendConfig(cc).FindImportOverride("ts", "unrelated")
+	assert.False(t, ok)
+}
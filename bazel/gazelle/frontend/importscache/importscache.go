@@ -0,0 +1,126 @@
+// Package importscache implements a persistent cache of the imports parsed from TypeScript and
+// Sass source files, which allows incremental Gazelle runs to skip re-parsing unchanged files.
+package importscache
+
+import (
+	"encoding/json"
+	"io"
+	"log"
+	"os"
+
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/go/util"
+)
+
+// version should be incremented whenever the import parsers or the cache file format change in a
+// way that invalidates previously cached results.
+const version = 1
+
+// ParseFunc takes the contents of a source file and returns the verbatim paths of its imports.
+type ParseFunc func(source string) []string
+
+// entry is a cached list of imports parsed from a source file. It is only valid as long as the
+// file's modification time and size do not change.
+type entry struct {
+	ModTime int64    `json:"mod_time"` // Nanoseconds since epoch.
+	Size    int64    `json:"size"`
+	Imports []string `json:"imports"`
+}
+
+// cacheFile is the on-disk format of the cache.
+type cacheFile struct {
+	Version int               `json:"version"`
+	Entries map[string]*entry `json:"entries"` // Keyed by absolute file path.
+}
+
+// Cache is a persistent cache of the imports parsed from source files, keyed by file path and
+// invalidated based on the file's modification time and size.
+//
+// A nil *Cache is valid, and simply parses files on every call to GetImports.
+type Cache struct {
+	path    string
+	entries map[string]*entry
+	dirty   bool
+
+	// Hits and misses are exposed for logging and testing purposes.
+	Hits, Misses int
+}
+
+// Load reads the cache from the given file. If the file does not exist, is corrupt, or was written
+// by a different version of this package, an empty cache is returned.
+func Load(path string) *Cache {
+	c := &Cache{path: path, entries: map[string]*entry{}}
+
+	b, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return c
+	}
+	if err != nil {
+		log.Printf("Ignoring imports cache %s: %s", path, err)
+		return c
+	}
+
+	var f cacheFile
+	if err := json.Unmarshal(b, &f); err != nil {
+		log.Printf("Ignoring corrupt imports cache %s: %s", path, err)
+		return c
+	}
+	if f.Version != version || f.Entries == nil {
+		return c
+	}
+	c.entries = f.Entries
+	return c
+}
+
+// GetImports returns the imports parsed from the given file, either from the cache or by reading
+// and parsing the file with the given ParseFunc if the file changed since it was last cached.
+func (c *Cache) GetImports(path string, parse ParseFunc) ([]string, error) {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return nil, skerr.Wrapf(err, "reading file %q", path)
+	}
+	if c != nil {
+		if e := c.entries[path]; e != nil && e.ModTime == fi.ModTime().UnixNano() && e.Size == fi.Size() {
+			c.Hits++
+			return e.Imports, nil
+		}
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, skerr.Wrapf(err, "reading file %q", path)
+	}
+	imports := parse(string(b))
+
+	if c != nil {
+		c.Misses++
+		c.entries[path] = &entry{
+			ModTime: fi.ModTime().UnixNano(),
+			Size:    fi.Size(),
+			Imports: imports,
+		}
+		c.dirty = true
+	}
+	return imports, nil
+}
+
+// Save writes the cache back to the file it was loaded from, if it changed. Entries for files that
+// no longer exist are dropped.
+func (c *Cache) Save() error {
+	if c == nil || !c.dirty {
+		return nil
+	}
+	for path := range c.entries {
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			delete(c.entries, path)
+		}
+	}
+	err := util.WithWriteFile(c.path, func(w io.Writer) error {
+		return json.NewEncoder(w).Encode(cacheFile{Version: version, Entries: c.entries})
+	})
+	if err != nil {
+		return skerr.Wrapf(err, "writing imports cache %q", c.path)
+	}
+	c.dirty = false
+	return nil
+}
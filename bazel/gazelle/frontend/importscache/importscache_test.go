@@ -0,0 +1,124 @@
+package importscache
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// parseLines is a fake ParseFunc which returns each whitespace-separated field of the source file as
+// an import, and
+// counts how many times it was called.
+type parseLines struct {
+	calls int
+}
+
+func (p *parseLines) parse(source string) []string {
+	p.calls++
+	return strings.Fields(source)
+}
+
+func TestCache_GetImports_UnchangedFile_ParsedOnceAcrossRuns(t *testing.T) {
+	dir := t.TempDir()
+	cachePath := filepath.Join(dir, "cache", "imports.json")
+	srcPath := filepath.Join(dir, "foo.ts")
+	require.NoError(t, os.WriteFile(srcPath, []byte("./bar\n./baz\n"), 0644))
+	p := &parseLines{}
+
+	// First run: the file is parsed and the result is cached.
+	c := Load(cachePath)
+	imports, err := c.GetImports(srcPath, p.parse)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"./bar", "./baz"}, imports)
+	assert.Equal(t, 1, c.Misses)
+	require.NoError(t, c.Save())
+
+	// Second run: the file is not parsed again.
+	c = Load(cachePath)
+	imports, err = c.GetImports(srcPath, p.parse)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"./bar", "./baz"}, imports)
+	assert.Equal(t, 1, c.Hits)
+	assert.Equal(t, 1, p.calls)
+}
+
+func TestCache_GetImports_ChangedFile_ParsedAgain(t *testing.T) {
+	dir := t.TempDir()
+	cachePath := filepath.Join(dir, "imports.json")
+	srcPath := filepath.Join(dir, "foo.ts")
+	require.NoError(t, os.WriteFile(srcPath, []byte("./bar\n"), 0644))
+	p := &parseLines{}
+
+	c := Load(cachePath)
+	_, err := c.GetImports(srcPath, p.parse)
+	require.NoError(t, err)
+	require.NoError(t, c.Save())
+
+	// Change the file's contents and modification time.
+	require.NoError(t, os.WriteFile(srcPath, []byte("./bar\n./qux\n"), 0644))
+	later := time.Now().Add(time.Hour)
+	require.NoError(t, os.Chtimes(srcPath, later, later))
+
+	c = Load(cachePath)
+	imports, err := c.GetImports(srcPath, p.parse)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"./bar", "./qux"}, imports)
+	assert.Equal(t, 1, c.Misses)
+	assert.Equal(t, 2, p.calls)
+}
+
+func TestCache_Save_DeletedFile_EntryDropped(t *testing.T) {
+	dir := t.TempDir()
+	cachePath := filepath.Join(dir, "imports.json")
+	srcPath := filepath.Join(dir, "foo.ts")
+	require.NoError(t, os.WriteFile(srcPath, []byte("./bar\n"), 0644))
+
+	c := Load(cachePath)
+	_, err := c.GetImports(srcPath, (&parseLines{}).parse)
+	require.NoError(t, err)
+	require.NoError(t, os.Remove(srcPath))
+	require.NoError(t, c.Save())
+
+	assert.Empty(t, Load(cachePath).entries)
+}
+
+func TestLoad_CorruptOrOutdatedCache_ReturnsEmptyCache(t *testing.T) {
+	dir := t.TempDir()
+
+	corruptPath := filepath.Join(dir, "corrupt.json")
+	require.NoError(t, os.WriteFile(corruptPath, []byte("not json"), 0644))
+	assert.Empty(t, Load(corruptPath).entries)
+
+	outdatedPath := filepath.Join(dir, "outdated.json")
+	require.NoError(t, os.WriteFile(outdatedPath, []byte(`{"version": 0, "entries": {"/foo.ts": {"imports": ["./bar"]}}}`), 0644))
+	assert.Empty(t, Load(outdatedPath).entries)
+
+	assert.Empty(t, Load(filepath.Join(dir, "no-such-file.json")).entries)
+}
+
+func TestCache_Nil_ParsesEveryTime(t *testing.T) {
+	dir := t.TempDir()
+	srcPath := filepath.Join(dir, "foo.ts")
+	require.NoError(t, os.WriteFile(srcPath, []byte("./bar\n"), 0644))
+	p := &parseLines{}
+
+	var c *Cache
+	for i := 0; i < 2; i++ {
+		imports, err := c.GetImports(srcPath, p.parse)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"./bar"}, imports)
+	}
+	assert.Equal(t, 2, p.calls)
+	assert.NoError(t, c.Save())
+}
+
+func TestCache_GetImports_FileDoesNotExist_ReturnsError(t *testing.T) {
+	c := Load(filepath.Join(t.TempDir(), "imports.json"))
+	_, err := c.GetImports("/no/such/file.ts", (&parseLines{}).parse)
+	assert.Error(t, err)
+}
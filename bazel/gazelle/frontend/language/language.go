@@ -1,6 +1,7 @@
 package language
 
 import (
+	"context"
 	"log"
 	"os"
 	"path"
@@ -15,15 +16,45 @@ import (
 	"github.com/bazelbuild/bazel-gazelle/rule"
 	"go.skia.org/infra/bazel/gazelle/frontend/common"
 	"go.skia.org/infra/bazel/gazelle/frontend/configurer"
+	"go.skia.org/infra/bazel/gazelle/frontend/importscache"
 	"go.skia.org/infra/bazel/gazelle/frontend/parsers"
 	"go.skia.org/infra/bazel/gazelle/frontend/resolver"
 	"go.skia.org/infra/go/util"
 )
 
-// Language implements the language.Language interface.
+// Language implements the language.Language and language.LifecycleManager interfaces.
 type Language struct {
 	configurer.Configurer
 	resolver.Resolver
+	language.BaseLifecycleManager
+
+	// importsCache caches the imports parsed from source files across Gazelle runs. It is nil unless
+	// the --frontend_imports_cache flag is set, in which case every source file is parsed.
+	importsCache *importscache.Cache
+}
+
+// Before implements the language.LifecycleManager interface.
+//
+// It loads the imports cache, if enabled via the --frontend_imports_cache flag.
+func (l *Language) Before(ctx context.Context) {
+	if l.ImportsCachePath != "" {
+		l.importsCache = importscache.Load(l.ImportsCachePath)
+	}
+}
+
+// DoneGeneratingRules implements the language.LifecycleManager interface.
+//
+// It persists the imports cache, if enabled, so that the next Gazelle run can skip parsing any
+// unchanged source files.
+func (l *Language) DoneGeneratingRules() {
+	if l.importsCache == nil {
+		return
+	}
+	log.Printf("Imports cache: %d hits, %d misses.", l.importsCache.Hits, l.importsCache.Misses)
+	if err := l.importsCache.Save(); err != nil {
+		// A stale cache only makes the next run slower, so this is not a fatal error.
+		log.Printf("Error saving imports cache: %v", err)
+	}
 }
 
 // Kinds implements the language.Language interface.
@@ -35,6 +66,10 @@ type Language struct {
 // kinds of rules generated for this language may be found here.
 func (l *Language) Kinds() map[string]rule.KindInfo {
 	return map[string]rule.KindInfo{
+		"filegroup": {
+			NonEmptyAttrs:  map[string]bool{"srcs": true},
+			MergeableAttrs: map[string]bool{"srcs": true},
+		},
 		"karma_test": {
 			NonEmptyAttrs:  map[string]bool{"src": true},
 			MergeableAttrs: map[string]bool{"src": true},
@@ -151,10 +186,14 @@ func (l *Language) GenerateRules(args language.GenerateArgs) language.GenerateRe
 	//
 	// - Unclassified directories:
 	//   - Rules generated:
-	//     - One ts_library rule for each *.ts file that does not end with "_test.ts".
-	//     - One nodejs_test rule for each file ending with "_nodejs_test.ts".
-	//     - One karma_test rule for each file ending with "_test.ts" and not "_nodejs_test.ts".
+	//     - One ts_library rule for each *.ts, *.tsx or *.d.ts file that is not a test.
+	//     - One nodejs_test rule for each file ending with "_nodejs_test.ts" or "_nodejs_test.tsx".
+	//     - One karma_test rule for each file ending with "_test.ts" or "_test.tsx" and not
+	//       "_nodejs_test.ts" or "_nodejs_test.tsx".
 	//     - One sass_library rule for each *.scss file.
+	//     - One filegroup rule for each static asset (e.g. *.json, *.png) in the directory that is
+	//       imported from a test (karma_test, nodejs_test or sk_element_puppeteer_test), e.g.
+	//       import './fixture.json'. The filegroup is added to the data attribute of the test.
 	//
 	// - Directories with a custom element:
 	//   - Pattern: //<app name>/modules/*/<custom element name ending in -sk, e.g. my-element-sk>
@@ -219,17 +258,17 @@ func (l *Language) GenerateRules(args language.GenerateArgs) language.GenerateRe
 		for _, page := range pages {
 			// A page is valid if it has an HTML file and a TypeScript file.
 			if page.isValid() {
-				r, i := generateSkPageRule(page, args.Dir)
+				r, i := l.generateSkPageRule(page, args.Dir)
 				rules = append(rules, r)
 				imports = append(imports, i)
 			} else {
 				if page.ts != "" {
-					r, i := generateTSLibraryRule(page.ts, args.Dir)
+					r, i := l.generateTSLibraryRule(page.ts, args.Dir)
 					rules = append(rules, r)
 					imports = append(imports, i)
 				}
 				if page.scss != "" {
-					r, i := generateSassLibraryRule(page.scss, args.Dir)
+					r, i := l.generateSassLibraryRule(page.scss, args.Dir)
 					rules = append(rules, r)
 					imports = append(imports, i)
 				}
@@ -276,12 +315,12 @@ func (l *Language) GenerateRules(args language.GenerateArgs) language.GenerateRe
 
 		// Generate the rules.
 		if customElementSrcs.isValid() {
-			r, i := generateSkElementRule(customElementName, customElementSrcs, args.Dir)
+			r, i := l.generateSkElementRule(customElementName, customElementSrcs, args.Dir)
 			rules = append(rules, r)
 			imports = append(imports, i)
 		}
 		if demoPageSrcs.isValid() {
-			skPage, i := generateSkPageRule(demoPageSrcs, args.Dir)
+			skPage, i := l.generateSkPageRule(demoPageSrcs, args.Dir)
 			rules = append(rules, skPage)
 			imports = append(imports, i)
 
@@ -303,16 +342,16 @@ func (l *Language) GenerateRules(args language.GenerateArgs) language.GenerateRe
 		}
 
 		if strings.HasSuffix(f, ".scss") {
-			r, i := generateSassLibraryRule(f, args.Dir)
+			r, i := l.generateSassLibraryRule(f, args.Dir)
 			rules = append(rules, r)
 			imports = append(imports, i)
-		} else if strings.HasSuffix(f, "_nodejs_test.ts") {
-			r, i := generateNodeJSTestRule(f, args.Dir)
+		} else if isTypeScriptTestFile(f, "_nodejs_test") {
+			r, i := l.generateNodeJSTestRule(f, args.Dir)
 			rules = append(rules, r)
 			imports = append(imports, i)
-		} else if strings.HasSuffix(f, "_puppeteer_test.ts") {
+		} else if isTypeScriptTestFile(f, "_puppeteer_test") {
 			if skDemoPageServerLabel != label.NoLabel {
-				r, i := generateSkElementPuppeteerTestRule(f, args.Dir, skDemoPageServerLabel)
+				r, i := l.generateSkElementPuppeteerTestRule(f, args.Dir, skDemoPageServerLabel)
 				rules = append(rules, r)
 				imports = append(imports, i)
 			} else if isCustomElementDir {
@@ -320,20 +359,51 @@ func (l *Language) GenerateRules(args language.GenerateArgs) language.GenerateRe
 			} else {
 				log.Printf("Not generating an sk_element_puppeteer_test rule for %s because %s does not follow the custom element directory naming convention (<app>/modules/*/<element name>-sk).", filepath.Join(args.Rel, f), args.Rel)
 			}
-		} else if strings.HasSuffix(f, "_test.ts") {
-			r, i := generateKarmaTestRule(f, args.Dir)
+		} else if isTypeScriptTestFile(f, "_test") {
+			r, i := l.generateKarmaTestRule(f, args.Dir)
 			rules = append(rules, r)
 			imports = append(imports, i)
-		} else if strings.HasSuffix(f, ".ts") && !strings.HasSuffix(f, ".d.ts") {
-			r, i := generateTSLibraryRule(f, args.Dir)
+		} else if _, ok := common.TrimTypeScriptExtension(f); ok {
+			r, i := l.generateTSLibraryRule(f, args.Dir)
 			rules = append(rules, r)
 			imports = append(imports, i)
 		}
 	}
 
+	// Generate filegroups for any static assets in the current directory imported from tests.
+	for _, f := range findAssetsImportedFromTests(allFiles, rules, imports) {
+		r, i := generateFilegroupRule(f)
+		rules = append(rules, r)
+		imports = append(imports, i)
+	}
+
 	return makeGenerateResult(args, rules, imports)
 }
 
+// findAssetsImportedFromTests returns the static assets (e.g. "fixture.json") found among the given
+// files which are imported from any of the given test rules via same-directory imports (e.g.
+// "./fixture.json"). The returned slice is sorted.
+func findAssetsImportedFromTests(files []string, rules []*rule.Rule, imports []common.ImportsParsedFromRuleSources) []string {
+	filesSet := util.NewStringSet(files)
+	assetsSet := util.StringSet{}
+	for i, r := range rules {
+		if !util.In(r.Kind(), []string{"karma_test", "nodejs_test", "sk_element_puppeteer_test"}) {
+			continue
+		}
+		for _, importPath := range imports[i].GetTypeScriptImports() {
+			if !strings.HasPrefix(importPath, "./") || !common.IsAssetFile(importPath) {
+				continue
+			}
+			if f := path.Clean(importPath); filesSet[f] {
+				assetsSet[f] = true
+			}
+		}
+	}
+	assets := assetsSet.Keys()
+	sort.Strings(assets)
+	return assets
+}
+
 // makeGenerateResult returns a language.GenerateResult with the results of generating build rules
 // for a directory.
 func makeGenerateResult(args language.GenerateArgs, rules []*rule.Rule, imports []common.ImportsParsedFromRuleSources) language.GenerateResult {
@@ -447,7 +517,7 @@ func (p *skPageSrcs) has(src string) bool {
 }
 
 // generateSkElementRule generates a sk_element rule for the given sources.
-func generateSkElementRule(name string, srcs *skElementSrcs, dir string) (*rule.Rule, common.ImportsParsedFromRuleSources) {
+func (l *Language) generateSkElementRule(name string, srcs *skElementSrcs, dir string) (*rule.Rule, common.ImportsParsedFromRuleSources) {
 	tsSrcs := []string{srcs.ts}
 	if srcs.indexTs != "" {
 		tsSrcs = append(tsSrcs, srcs.indexTs)
@@ -463,17 +533,17 @@ func generateSkElementRule(name string, srcs *skElementSrcs, dir string) (*rule.
 
 	imports := &importsParsedFromRuleSourcesImpl{}
 	for _, tsSrc := range tsSrcs {
-		imports.tsImports = append(imports.tsImports, extractImportsFromTypeScriptFile(filepath.Join(dir, tsSrc))...)
+		imports.tsImports = append(imports.tsImports, l.extractImportsFromTypeScriptFile(filepath.Join(dir, tsSrc))...)
 	}
 	if srcs.scss != "" {
-		imports.sassImports = extractImportsFromSassFile(filepath.Join(dir, srcs.scss))
+		imports.sassImports = l.extractImportsFromSassFile(filepath.Join(dir, srcs.scss))
 	}
 
 	return r, imports
 }
 
 // generateSkPageRule generates a sk_page rule for the given sources.
-func generateSkPageRule(srcs *skPageSrcs, dir string) (*rule.Rule, common.ImportsParsedFromRuleSources) {
+func (l *Language) generateSkPageRule(srcs *skPageSrcs, dir string) (*rule.Rule, common.ImportsParsedFromRuleSources) {
 	r := rule.NewRule("sk_page", makeRuleNameFromFileName(srcs.html, ""))
 	r.SetAttr("html_file", srcs.html)
 	r.SetAttr("ts_entry_point", srcs.ts)
@@ -482,10 +552,10 @@ func generateSkPageRule(srcs *skPageSrcs, dir string) (*rule.Rule, common.Import
 	}
 
 	imports := &importsParsedFromRuleSourcesImpl{
-		tsImports: extractImportsFromTypeScriptFile(filepath.Join(dir, srcs.ts)),
+		tsImports: l.extractImportsFromTypeScriptFile(filepath.Join(dir, srcs.ts)),
 	}
 	if srcs.scss != "" {
-		imports.sassImports = extractImportsFromSassFile(filepath.Join(dir, srcs.scss))
+		imports.sassImports = l.extractImportsFromSassFile(filepath.Join(dir, srcs.scss))
 	}
 
 	return r, imports
@@ -499,42 +569,73 @@ func generateSkDemoPageServerRule(skPage label.Label) (*rule.Rule, common.Import
 }
 
 // generateSassLibraryRule generates a sass_library rule for the given Sass file.
-func generateSassLibraryRule(file, dir string) (*rule.Rule, common.ImportsParsedFromRuleSources) {
+func (l *Language) generateSassLibraryRule(file, dir string) (*rule.Rule, common.ImportsParsedFromRuleSources) {
 	r := rule.NewRule("sass_library", makeRuleNameFromFileName(file, "_sass_lib"))
 	r.SetAttr("srcs", []string{file})
 	r.SetAttr("visibility", []string{"//visibility:public"})
-	return r, &importsParsedFromRuleSourcesImpl{sassImports: extractImportsFromSassFile(filepath.Join(dir, file))}
+	return r, &importsParsedFromRuleSourcesImpl{sassImports: l.extractImportsFromSassFile(filepath.Join(dir, file))}
 }
 
 // generateKarmaTestRule generates a karma_test rule for the given TypeScript file.
-func generateKarmaTestRule(file, dir string) (*rule.Rule, common.ImportsParsedFromRuleSources) {
+func (l *Language) generateKarmaTestRule(file, dir string) (*rule.Rule, common.ImportsParsedFromRuleSources) {
 	r := rule.NewRule("karma_test", makeRuleNameFromFileName(file, ""))
 	r.SetAttr("src", file)
-	return r, &importsParsedFromRuleSourcesImpl{tsImports: extractImportsFromTypeScriptFile(filepath.Join(dir, file))}
+	return r, &importsParsedFromRuleSourcesImpl{tsImports: l.extractImportsFromTypeScriptFile(filepath.Join(dir, file))}
 }
 
 // generateNodeJSTestRule generates a nodejs_test rule for the given TypeScript file.
-func generateNodeJSTestRule(file, dir string) (*rule.Rule, common.ImportsParsedFromRuleSources) {
+func (l *Language) generateNodeJSTestRule(file, dir string) (*rule.Rule, common.ImportsParsedFromRuleSources) {
 	r := rule.NewRule("nodejs_test", makeRuleNameFromFileName(file, ""))
 	r.SetAttr("src", file)
-	return r, &importsParsedFromRuleSourcesImpl{tsImports: extractImportsFromTypeScriptFile(filepath.Join(dir, file))}
+	return r, &importsParsedFromRuleSourcesImpl{tsImports: l.extractImportsFromTypeScriptFile(filepath.Join(dir, file))}
 }
 
 // generateSkElementPuppeteerTestRule generates a sk_element_puppeteer_test rule for the given
 // TypeScript file and sk_demo_page_server.
-func generateSkElementPuppeteerTestRule(file, dir string, skDemoPageServer label.Label) (*rule.Rule, common.ImportsParsedFromRuleSources) {
+func (l *Language) generateSkElementPuppeteerTestRule(file, dir string, skDemoPageServer label.Label) (*rule.Rule, common.ImportsParsedFromRuleSources) {
 	r := rule.NewRule("sk_element_puppeteer_test", makeRuleNameFromFileName(file, ""))
 	r.SetAttr("src", file)
 	r.SetAttr("sk_demo_page_server", skDemoPageServer.String())
-	return r, &importsParsedFromRuleSourcesImpl{tsImports: extractImportsFromTypeScriptFile(filepath.Join(dir, file))}
+	return r, &importsParsedFromRuleSourcesImpl{tsImports: l.extractImportsFromTypeScriptFile(filepath.Join(dir, file))}
+}
+
+// generateFilegroupRule generates a filegroup rule for the given static asset.
+func generateFilegroupRule(file string) (*rule.Rule, common.ImportsParsedFromRuleSources) {
+	r := rule.NewRule("filegroup", makeFilegroupRuleNameFromFileName(file))
+	r.SetAttr("srcs", []string{file})
+	r.SetAttr("visibility", []string{"//visibility:public"})
+	return r, &importsParsedFromRuleSourcesImpl{}
+}
+
+// makeFilegroupRuleNameFromFileName returns e.g. "fixture_json_data" when given "fixture.json". The
+// extension is kept so that e.g. "fixture.json" and "fixture.png" produce different rules.
+func makeFilegroupRuleNameFromFileName(file string) string {
+	return strings.ReplaceAll(strings.ToLower(path.Base(file)), ".", "_") + "_data"
 }
 
 // generateTSLibraryRule generates a ts_library rule for the given TypeScript file.
-func generateTSLibraryRule(file, dir string) (*rule.Rule, common.ImportsParsedFromRuleSources) {
-	r := rule.NewRule("ts_library", makeRuleNameFromFileName(file, "_ts_lib"))
+func (l *Language) generateTSLibraryRule(file, dir string) (*rule.Rule, common.ImportsParsedFromRuleSources) {
+	name := makeRuleNameFromFileName(file, "_ts_lib")
+	if strings.HasSuffix(file, ".d.ts") {
+		// E.g. "foo.d.ts" becomes "foo_d_ts_lib", which does not clash with the rule for "foo.ts".
+		name = strings.ToLower(path.Base(strings.TrimSuffix(file, ".d.ts"))) + "_d_ts_lib"
+	}
+
+	r := rule.NewRule("ts_library", name)
 	r.SetAttr("srcs", []string{file})
 	r.SetAttr("visibility", []string{"//visibility:public"})
-	return r, &importsParsedFromRuleSourcesImpl{tsImports: extractImportsFromTypeScriptFile(filepath.Join(dir, file))}
+	return r, &importsParsedFromRuleSourcesImpl{tsImports: l.extractImportsFromTypeScriptFile(filepath.Join(dir, file))}
+}
+
+// isTypeScriptTestFile returns true if the given file is a TypeScript or TSX source file whose name
+// ends with the given suffix, e.g. "foo_test.ts" or "foo_test.tsx" for suffix "_test". Declaration
+// files are never considered tests.
+func isTypeScriptTestFile(file, suffix string) bool {
+	if strings.HasSuffix(file, ".d.ts") {
+		return false
+	}
+	name, ok := common.TrimTypeScriptExtension(file)
+	return ok && strings.HasSuffix(name, suffix)
 }
 
 // makeRuleNameFromFileName returns e.g. "baz_ts_lib" when given "foo/bar/baz.ts" and "_ts_lib".
@@ -545,22 +646,22 @@ func makeRuleNameFromFileName(file, suffix string) string {
 
 // extractImportsFromSassFile returns the verbatim paths of the import statements found in the given
 // Sass file.
-func extractImportsFromSassFile(path string) []string {
-	b, err := os.ReadFile(path)
+func (l *Language) extractImportsFromSassFile(path string) []string {
+	imports, err := l.importsCache.GetImports(path, parsers.ParseSassImports)
 	if err != nil {
-		log.Panicf("Error reading file %q: %v", path, err)
+		log.Panicf("Error extracting imports from file %q: %v", path, err)
 	}
-	return parsers.ParseSassImports(string(b[:]))
+	return imports
 }
 
 // extractImportsFromTypeScriptFile returns the verbatim paths of the import statements found in the
 // given TypeScript file.
-func extractImportsFromTypeScriptFile(path string) []string {
-	b, err := os.ReadFile(path)
+func (l *Language) extractImportsFromTypeScriptFile(path string) []string {
+	imports, err := l.importsCache.GetImports(path, parsers.ParseTSImports)
 	if err != nil {
-		log.Panicf("Error reading file %q: %v", path, err)
+		log.Panicf("Error extracting imports from file %q: %v", path, err)
 	}
-	return parsers.ParseTSImports(string(b[:]))
+	return imports
 }
 
 // generateEmptyRules returns a list of rules that cannot be built with the files found in the
@@ -672,10 +773,36 @@ func generateEmptyRules(args language.GenerateArgs) []*rule.Rule {
 	allRules = append(allRules, skDemoPageServerRules...)
 	allRules = append(allRules, skElementPuppeteerTestRules...)
 
+	// Rules that provide imports declared via directives are never considered empty, as their
+	// sources might not exist on disk (e.g. generated files).
+	rulesWithDeclaredImports := map[string]bool{}
+	for _, d := range args.File.Directives {
+		if d.Key == configurer.ProvidesDirective {
+			if fields := strings.Fields(d.Value); len(fields) > 0 {
+				rulesWithDeclaredImports[fields[0]] = true
+			}
+		}
+	}
+
 	for _, curRule := range allRules {
+		if rulesWithDeclaredImports[curRule.Name()] {
+			continue
+		}
+
 		var empty bool
 
 		switch curRule.Kind() {
+		case "filegroup":
+			// Only filegroups that look like the ones generated by this extension are considered, i.e.
+			// filegroups whose sources are all static assets in this directory.
+			srcs := curRule.AttrStrings("srcs")
+			isGenerated := len(srcs) > 0
+			for _, src := range srcs {
+				if strings.Contains(src, ":") || strings.Contains(src, "/") || !common.IsAssetFile(src) {
+					isGenerated = false
+				}
+			}
+			empty = isGenerated && !someFilesFound(srcs...)
 		case "karma_test":
 			empty = !someFilesFound(curRule.AttrString("src"))
 		case "nodejs_test":
@@ -704,7 +831,51 @@ func generateEmptyRules(args language.GenerateArgs) []*rule.Rule {
 	return emptyRules
 }
 
+// AfterResolvingDeps implements the language.LifecycleManager interface.
+//
+// It logs a summary of any stale dependencies removed from existing rules. If any imports from
+// rules in strict mode could not be resolved, it writes a report of said imports and exits with a
+// non-zero exit code before Gazelle updates any BUILD files.
+func (l *Language) AfterResolvingDeps(ctx context.Context) {
+	if staleDeps := l.Resolver.StaleDeps(); len(staleDeps) > 0 {
+		rules := map[label.Label]bool{}
+		for _, dep := range staleDeps {
+			rules[dep.Rule] = true
+		}
+		log.Printf("Removed %d stale dependencies from %d rules. Please review the above log messages.", len(staleDeps), len(rules))
+	}
+
+	unresolvedImports := l.Resolver.UnresolvedImports()
+	if len(unresolvedImports) == 0 {
+		return
+	}
+	l.writeUnresolvedImportsReport(unresolvedImports)
+	log.Printf("Found %d unresolved imports in strict mode.", len(unresolvedImports))
+	os.Exit(1)
+}
+
+// writeUnresolvedImportsReport writes a report of the given unresolved imports to the file
+// specified via the --frontend_unresolved_imports_report flag, or to stdout if the flag is unset.
+func (l *Language) writeUnresolvedImportsReport(unresolvedImports []resolver.UnresolvedImport) {
+	if l.UnresolvedImportsReportPath == "" {
+		if err := resolver.WriteUnresolvedImportsReport(os.Stdout, unresolvedImports); err != nil {
+			log.Panicf("Error writing unresolved imports report: %v", err)
+		}
+		return
+	}
+
+	f, err := os.Create(l.UnresolvedImportsReportPath)
+	if err != nil {
+		log.Panicf("Error creating file %q: %v", l.UnresolvedImportsReportPath, err)
+	}
+	defer util.Close(f)
+	if err := resolver.WriteUnresolvedImportsReport(f, unresolvedImports); err != nil {
+		log.Panicf("Error writing unresolved imports report to %q: %v", l.UnresolvedImportsReportPath, err)
+	}
+}
+
 // Fix implements the language.Language interface.
 func (l *Language) Fix(*config.Config, *rule.File) {}
 
 var _ language.Language = &Language{}
+var _ language.LifecycleManager = &Language{}
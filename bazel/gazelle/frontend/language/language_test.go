@@ -1,9 +1,16 @@
 package language
 
 import (
+	"context"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/bazelbuild/bazel-gazelle/language"
+	"github.com/bazelbuild/bazel-gazelle/rule"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.skia.org/infra/bazel/gazelle/frontend/common"
 )
 
 func TestIsAppPageDir_Success(t *testing.T) {
@@ -46,3 +53,129 @@ func TestExtractCustomElementNameFromDir_Success(t *testing.T) {
 	assert.Equal(t, "my-element-sk", name)
 
 }
+
+func TestIsTypeScriptTestFile_Success(t *testing.T) {
+
+	assert.True(t, isTypeScriptTestFile("foo_test.ts", "_test"))
+	assert.True(t, isTypeScriptTestFile("foo_test.tsx", "_test"))
+	assert.True(t, isTypeScriptTestFile("foo_nodejs_test.ts", "_test"))
+	assert.True(t, isTypeScriptTestFile("foo_nodejs_test.tsx", "_nodejs_test"))
+	assert.False(t, isTypeScriptTestFile("foo_test.ts", "_nodejs_test"))
+	assert.False(t, isTypeScriptTestFile("foo.ts", "_test"))
+	assert.False(t, isTypeScriptTestFile("foo_test.d.ts", "_test"))
+	assert.False(t, isTypeScriptTestFile("foo_test.scss", "_test"))
+}
+
+func TestGenerateTSLibraryRule_TSXAndDeclarationFiles_Success(t *testing.T) {
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "foo.tsx"), []byte(`import './bar';`), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "foo.d.ts"), []byte(`import 'lit-html';`), 0644))
+
+	l := &Language{}
+	r, imports := l.generateTSLibraryRule("foo.tsx", dir)
+	assert.Equal(t, "foo_ts_lib", r.Name())
+	assert.Equal(t, []string{"foo.tsx"}, r.AttrStrings("srcs"))
+	assert.Equal(t, []string{"./bar"}, imports.GetTypeScriptImports())
+
+	r, imports = l.generateTSLibraryRule("foo.d.ts", dir)
+	assert.Equal(t, "foo_d_ts_lib", r.Name())
+	assert.Equal(t, []string{"foo.d.ts"}, r.AttrStrings("srcs"))
+	assert.Equal(t, []string{"lit-html"}, imports.GetTypeScriptImports())
+}
+
+func TestGenerateEmptyRules_RuleWithDeclaredImports_NotEmpty(t *testing.T) {
+
+	f, err := rule.LoadData("a/BUILD.bazel", "a", []byte(`
+# gazelle:frontend_provides generated_ts_lib generated
+
+ts_library(
+    name = "generated_ts_lib",
+    srcs = [":generate_ts"],
+)
+
+ts_library(
+    name = "deleted_ts_lib",
+    srcs = ["deleted.ts"],
+)
+`))
+	require.NoError(t, err)
+
+	emptyRules := generateEmptyRules(language.GenerateArgs{File: f})
+	require.Len(t, emptyRules, 1)
+	assert.Equal(t, "deleted_ts_lib", emptyRules[0].Name())
+}
+
+func TestLanguage_ImportsCache_PersistedAcrossRuns(t *testing.T) {
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "foo.ts"), []byte(`import './bar';`), 0644))
+	cachePath := filepath.Join(dir, "cache", "imports.json")
+
+	// First run: the file is parsed and the imports cache is persisted.
+	l := &Language{}
+	l.ImportsCachePath = cachePath
+	l.Before(context.Background())
+	_, imports := l.generateTSLibraryRule("foo.ts", dir)
+	assert.Equal(t, []string{"./bar"}, imports.GetTypeScriptImports())
+	l.DoneGeneratingRules()
+	assert.FileExists(t, cachePath)
+
+	// Second run: the imports are read from the cache.
+	l = &Language{}
+	l.ImportsCachePath = cachePath
+	l.Before(context.Background())
+	_, imports = l.generateTSLibraryRule("foo.ts", dir)
+	assert.Equal(t, []string{"./bar"}, imports.GetTypeScriptImports())
+	assert.Equal(t, 1, l.importsCache.Hits)
+	assert.Equal(t, 0, l.importsCache.Misses)
+}
+
+func TestFindAssetsImportedFromTests_Success(t *testing.T) {
+
+	files := []string{"foo_test.ts", "foo.ts", "fixture.json", "golden.png", "unused.json"}
+	rules := []*rule.Rule{
+		rule.NewRule("karma_test", "foo_test"),
+		rule.NewRule("ts_library", "foo_ts_lib"),
+	}
+	imports := []common.ImportsParsedFromRuleSources{
+		&importsParsedFromRuleSourcesImpl{tsImports: []string{"./foo", "./fixture.json", "./golden.png", "../other/fixture.json", "./missing.json"}},
+		&importsParsedFromRuleSourcesImpl{tsImports: []string{"./unused.json"}}, // Not a test.
+	}
+
+	assert.Equal(t, []string{"fixture.json", "golden.png"}, findAssetsImportedFromTests(files, rules, imports))
+}
+
+func TestGenerateFilegroupRule_Success(t *testing.T) {
+
+	r, imports := generateFilegroupRule("Fixture.json")
+	assert.Equal(t, "filegroup", r.Kind())
+	assert.Equal(t, "fixture_json_data", r.Name())
+	assert.Equal(t, []string{"Fixture.json"}, r.AttrStrings("srcs"))
+	assert.Empty(t, imports.GetTypeScriptImports())
+}
+
+func TestGenerateEmptyRules_Filegroups_OnlyGeneratedFilegroupsAreDeleted(t *testing.T) {
+
+	f, err := rule.LoadData("a/BUILD.bazel", "a", []byte(`
+filegroup(
+    name = "deleted_json_data",
+    srcs = ["deleted.json"],
+)
+
+filegroup(
+    name = "existing_json_data",
+    srcs = ["existing.json"],
+)
+
+filegroup(
+    name = "handwritten",
+    srcs = [":some_genrule"],
+)
+`))
+	require.NoError(t, err)
+
+	emptyRules := generateEmptyRules(language.GenerateArgs{File: f, RegularFiles: []string{"existing.json"}})
+	require.Len(t, emptyRules, 1)
+	assert.Equal(t, "deleted_json_data", emptyRules[0].Name())
+}
@@ -2,6 +2,7 @@ package resolver
 
 import (
 	"encoding/json"
+	"io"
 	"log"
 	"os"
 	"path"
@@ -14,7 +15,10 @@ import (
 	"github.com/bazelbuild/bazel-gazelle/repo"
 	"github.com/bazelbuild/bazel-gazelle/resolve"
 	"github.com/bazelbuild/bazel-gazelle/rule"
+	"github.com/bazelbuild/buildtools/build"
+	"github.com/flynn/json5"
 	"go.skia.org/infra/bazel/gazelle/frontend/common"
+	"go.skia.org/infra/bazel/gazelle/frontend/configurer"
 	"go.skia.org/infra/go/util"
 )
 
@@ -26,9 +30,14 @@ const (
 	//     $ bazel run gazelle -- update --lang go,frontend
 	gazelleExtensionName = "frontend"
 
-	// packageJsonPath is the path to the package.json file used by the npm_install rule in the
-	// workspace file. This path is relative to the workspace root directory.
+	// packageJsonPath is the file name of the package.json files used by the npm_translate_lock
+	// rules in the workspace file. The root package.json file lives in the workspace root
+	// directory, but some subtrees (e.g. puppeteer-tests) might have their own package.json file.
 	packageJsonPath = "package.json"
+
+	// tsConfigJsonPath is the path to the tsconfig.json file from which we read any TypeScript path
+	// aliases (compilerOptions.paths). This path is relative to the workspace root directory.
+	tsConfigJsonPath = "tsconfig.json"
 )
 
 // Resolver implements the resolve.Resolver interface.
@@ -44,8 +53,192 @@ type Resolver struct {
 	// tsImportsToDeps maps TypeScript imports to rules that provide those imports.
 	tsImportsToDeps map[string]map[ruleKindAndLabel]bool
 
-	// npmPackages is the set of NPM dependencies and devDependencies read from the package.json file.
-	npmPackages map[string]bool
+	// assetImportsToDeps maps imports of static assets (e.g. "path/to/fixture.json") to the
+	// filegroup rules that provide those assets.
+	assetImportsToDeps map[string]map[ruleKindAndLabel]bool
+
+	// npmPackages maps the directory of each package.json file read so far, relative to the
+	// workspace root directory, to the set of NPM dependencies and devDependencies found in it.
+	npmPackages map[string]map[string]bool
+
+	// packageJsonDirs caches the result of findNearestPackageJsonDir for each Bazel package.
+	packageJsonDirs map[string]string
+
+	// tsConfigPathAliases are the path aliases read from the tsconfig.json file, sorted by
+	// precedence. It is only meaningful if tsConfigPathAliasesLoaded is true.
+	tsConfigPathAliases []tsConfigPathAlias
+
+	// tsConfigPathAliasesLoaded is true if the tsconfig.json file has already been read.
+	tsConfigPathAliasesLoaded bool
+
+	// unresolvedImports are the imports from rules in strict mode that could not be resolved.
+	unresolvedImports []UnresolvedImport
+
+	// existingDeps maps the labels of existing rules to the contents of their dependency attributes
+	// (e.g. "deps", "ts_deps") before dependency resolution, excluding any entries marked with
+	// "# keep". It is populated by the Imports method.
+	existingDeps map[label.Label]map[string][]string
+
+	// staleDeps are the dependencies that were removed from existing rules because they are no
+	// longer imported from the rules' sources.
+	staleDeps []StaleDep
+}
+
+// depsAttrsByRuleKind maps rule kinds to the dependency attributes populated by Resolve.
+var depsAttrsByRuleKind = map[string][]string{
+	"karma_test":                {"deps"},
+	"nodejs_test":               {"deps"},
+	"sass_library":              {"deps"},
+	"sk_element":                {"sass_deps", "sk_element_deps", "ts_deps"},
+	"sk_element_puppeteer_test": {"deps"},
+	"sk_page":                   {"sass_deps", "sk_element_deps", "ts_deps"},
+	"ts_library":                {"deps"},
+}
+
+// StaleDep is a dependency that was removed from an existing rule because it is no longer imported
+// from the rule's sources.
+type StaleDep struct {
+	// Rule is the label of the rule from which the dependency was removed.
+	Rule label.Label
+
+	// Attr is the attribute from which the dependency was removed, e.g. "deps" or "ts_deps".
+	Attr string
+
+	// Dep is the removed dependency, as it appeared in the BUILD file, e.g. ":foo_ts_lib".
+	Dep string
+}
+
+// StaleDeps returns the dependencies that were removed from existing rules because they are no
+// longer imported, in the order in which they were found.
+func (rslv *Resolver) StaleDeps() []StaleDep {
+	return rslv.staleDeps
+}
+
+// recordExistingDeps records the contents of the dependency attributes of the given rule before
+// dependency resolution, so that they can be later compared against the resolved dependencies (see
+// reconcileDeps). Entries marked with "# keep" are ignored, as Gazelle never removes them.
+func (rslv *Resolver) recordExistingDeps(r *rule.Rule, ruleLabel label.Label) {
+	if r.ShouldKeep() {
+		return
+	}
+	for _, attr := range depsAttrsByRuleKind[r.Kind()] {
+		list, ok := r.Attr(attr).(*build.ListExpr)
+		if !ok || rule.ShouldKeep(list) {
+			continue
+		}
+		var deps []string
+		for _, elem := range list.List {
+			if str, ok := elem.(*build.StringExpr); ok && !rule.ShouldKeep(elem) {
+				deps = append(deps, str.Value)
+			}
+		}
+		if len(deps) == 0 {
+			continue
+		}
+		if rslv.existingDeps == nil {
+			rslv.existingDeps = map[label.Label]map[string][]string{}
+		}
+		if rslv.existingDeps[ruleLabel] == nil {
+			rslv.existingDeps[ruleLabel] = map[string][]string{}
+		}
+		rslv.existingDeps[ruleLabel][attr] = deps
+	}
+}
+
+// reconcileDeps compares the resolved dependencies of the given rule against the dependencies
+// recorded before dependency resolution, and logs any dependencies that are no longer imported.
+//
+// Gazelle removes said dependencies when merging the resolved rule into the existing BUILD file,
+// so this method is only concerned with reporting them for review.
+func (rslv *Resolver) reconcileDeps(r *rule.Rule, ruleLabel label.Label) {
+	for _, attr := range depsAttrsByRuleKind[r.Kind()] {
+		resolvedDeps := util.NewStringSet(r.AttrStrings(attr))
+		for _, dep := range rslv.existingDeps[ruleLabel][attr] {
+			if resolvedDeps[dep] {
+				continue
+			}
+			log.Printf("Removing stale dependency %s from attribute %q of %s (%s): no longer imported.", dep, attr, ruleLabel, r.Kind())
+			rslv.staleDeps = append(rslv.staleDeps, StaleDep{Rule: ruleLabel, Attr: attr, Dep: dep})
+		}
+	}
+}
+
+// UnresolvedImport is an import that could not be resolved to a dependency while in strict mode.
+type UnresolvedImport struct {
+	// Rule is the label of the rule whose sources contain the import, e.g.
+	// "//myapp/modules/my-element-sk:my-element-sk".
+	Rule string `json:"rule"`
+
+	// RuleKind is the kind of the rule whose sources contain the import, e.g. "sk_element".
+	RuleKind string `json:"rule_kind"`
+
+	// Import is the path of the import. Imports of other files in the repository are normalized
+	// relative to the workspace root directory, e.g. "../bar" imported from "myapp/foo" becomes
+	// "myapp/bar".
+	Import string `json:"import"`
+
+	// Candidates are the labels of the rules that provide the import, if there is more than one.
+	Candidates []string `json:"candidates"`
+}
+
+// UnresolvedImports returns the imports from rules in strict mode that could not be resolved, in
+// the order in which they were found.
+func (rslv *Resolver) UnresolvedImports() []UnresolvedImport {
+	return rslv.unresolvedImports
+}
+
+// WriteUnresolvedImportsReport writes a machine-readable JSON report of the given unresolved
+// imports.
+func WriteUnresolvedImportsReport(w io.Writer, unresolvedImports []UnresolvedImport) error {
+	report := struct {
+		UnresolvedImports []UnresolvedImport `json:"unresolved_imports"`
+	}{UnresolvedImports: unresolvedImports}
+	if report.UnresolvedImports == nil {
+		report.UnresolvedImports = []UnresolvedImport{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(report)
+}
+
+// addUnresolvedImport records an import that could not be resolved.
+func (rslv *Resolver) addUnresolvedImport(importPath string, fromRuleKind string, fromRuleLabel label.Label, candidates []ruleKindAndLabel) {
+	u := UnresolvedImport{
+		Rule:       fromRuleLabel.String(),
+		RuleKind:   fromRuleKind,
+		Import:     importPath,
+		Candidates: []string{},
+	}
+	for _, c := range candidates {
+		u.Candidates = append(u.Candidates, c.label.String())
+	}
+	sort.Strings(u.Candidates)
+	rslv.unresolvedImports = append(rslv.unresolvedImports, u)
+}
+
+// tsConfigPathAlias is an entry in the compilerOptions.paths section of a tsconfig.json file, e.g.
+// "modules/*": ["infra-sk/modules/*"].
+type tsConfigPathAlias struct {
+	// pattern is the aliased import path, which may contain at most one "*" wildcard, e.g.
+	// "modules/*".
+	pattern string
+
+	// targets are the paths that the pattern maps to, relative to the workspace root directory and
+	// in the order in which they should be tried, e.g. ["infra-sk/modules/*"].
+	targets []string
+}
+
+// match returns the substring matched by the "*" wildcard in the alias pattern (or the empty
+// string if the pattern has no wildcard), and whether the given import path matches the pattern.
+func (a tsConfigPathAlias) match(importPath string) (string, bool) {
+	prefix, suffix, hasWildcard := strings.Cut(a.pattern, "*")
+	if !hasWildcard {
+		return "", importPath == a.pattern
+	}
+	if len(importPath) < len(prefix)+len(suffix) || !strings.HasPrefix(importPath, prefix) || !strings.HasSuffix(importPath, suffix) {
+		return "", false
+	}
+	return importPath[len(prefix) : len(importPath)-len(suffix)], true
 }
 
 // ruleAKindAndLabel is a (rule kind, rule label) pair (e.g. "ts_library", "//path/to:my_ts_lib").
@@ -61,7 +254,7 @@ var noRuleKindAndLabel = ruleKindAndLabel{}
 // indexImportsProvidedByRule indexes the imports provided by the given rule. The rule can be later
 // obtained from an import via the findRuleThatProvidesImport method.
 func (rslv *Resolver) indexImportsProvidedByRule(lang string, importPaths []string, ruleKind string, ruleLabel label.Label) {
-	if lang != "sass" && lang != "ts" {
+	if lang != "sass" && lang != "ts" && lang != "asset" {
 		log.Panicf("Unknown language: %q.", lang)
 	}
 
@@ -71,12 +264,12 @@ func (rslv *Resolver) indexImportsProvidedByRule(lang string, importPaths []stri
 	if rslv.tsImportsToDeps == nil {
 		rslv.tsImportsToDeps = map[string]map[ruleKindAndLabel]bool{}
 	}
-
-	importsToDeps := rslv.sassImportsToDeps
-	if lang == "ts" {
-		importsToDeps = rslv.tsImportsToDeps
+	if rslv.assetImportsToDeps == nil {
+		rslv.assetImportsToDeps = map[string]map[ruleKindAndLabel]bool{}
 	}
 
+	importsToDeps := rslv.getImportsToDeps(lang)
+
 	for _, importPath := range importPaths {
 		if importsToDeps[importPath] == nil {
 			importsToDeps[importPath] = map[ruleKindAndLabel]bool{}
@@ -86,17 +279,26 @@ func (rslv *Resolver) indexImportsProvidedByRule(lang string, importPaths []stri
 	}
 }
 
+// getImportsToDeps returns the index of imports for the given language ("sass", "ts" or "asset").
+func (rslv *Resolver) getImportsToDeps(lang string) map[string]map[ruleKindAndLabel]bool {
+	switch lang {
+	case "sass":
+		return rslv.sassImportsToDeps
+	case "ts":
+		return rslv.tsImportsToDeps
+	default:
+		return rslv.assetImportsToDeps
+	}
+}
+
 // findRuleThatProvidesImport returns the rule that provides the given import, provided it was
 // indexed via an earlier call to indexImportsProvidedByRule.
 func (rslv *Resolver) findRuleThatProvidesImport(lang string, importPath string, fromRuleKind string, fromRuleLabel label.Label) ruleKindAndLabel {
-	if lang != "sass" && lang != "ts" {
+	if lang != "sass" && lang != "ts" && lang != "asset" {
 		log.Panicf("Unknown language: %q.", lang)
 	}
 
-	importsToDeps := rslv.sassImportsToDeps
-	if lang == "ts" {
-		importsToDeps = rslv.tsImportsToDeps
-	}
+	importsToDeps := rslv.getImportsToDeps(lang)
 
 	var candidates []ruleKindAndLabel
 	if importsToDeps[importPath] != nil {
@@ -111,11 +313,13 @@ func (rslv *Resolver) findRuleThatProvidesImport(lang string, importPath string,
 			gazelleIgnoreMsg = `; if this is expected, add "// gazelle:ignore" at the end of the import statement to make this warning go away`
 		}
 		log.Printf("Could not find any rules that satisfy import %q from %s (%s)%s", importPath, fromRuleLabel, fromRuleKind, gazelleIgnoreMsg)
+		rslv.addUnresolvedImport(importPath, fromRuleKind, fromRuleLabel, nil)
 		return noRuleKindAndLabel
 	}
 
 	if len(candidates) > 1 {
 		log.Printf("Multiple rules satisfy import %q from %s (%s): %s (%s), %s (%s)", importPath, fromRuleLabel, fromRuleKind, candidates[0].label, candidates[0].kind, candidates[1].label, candidates[1].kind)
+		rslv.addUnresolvedImport(importPath, fromRuleKind, fromRuleLabel, candidates)
 		return noRuleKindAndLabel
 	}
 
@@ -165,34 +369,91 @@ func (rslv *Resolver) Name() string {
 func (rslv *Resolver) Imports(c *config.Config, r *rule.Rule, f *rule.File) []resolve.ImportSpec {
 	ruleLabel := label.New(c.RepoName, f.Pkg, r.Name())
 
+	// Imports is called after Gazelle merges the generated rules into the existing BUILD files, but
+	// before dependency resolution, so the rule still has its existing dependencies.
+	rslv.recordExistingDeps(r, ruleLabel)
+
+	// Any imports declared via directives (e.g. imports of generated files) are indexed alongside the
+	// imports derived from the rule's sources. For sk_element rules, these are TypeScript imports.
+	declaredImportPaths := extractImportsDeclaredViaDirectives(f, r)
+
 	switch r.Kind() {
 	case "ts_library":
 		importPaths := extractTypeScriptImportsProvidedByRule(f.Pkg, r, "srcs")
-		rslv.indexImportsProvidedByRule("ts", importPaths, r.Kind(), ruleLabel)
+		rslv.indexImportsProvidedByRule("ts", append(importPaths, declaredImportPaths...), r.Kind(), ruleLabel)
 	case "sass_library":
 		importPaths := extractSassImportsProvidedByRule(f.Pkg, r, "srcs")
-		rslv.indexImportsProvidedByRule("sass", importPaths, r.Kind(), ruleLabel)
+		rslv.indexImportsProvidedByRule("sass", append(importPaths, declaredImportPaths...), r.Kind(), ruleLabel)
 	case "sk_element":
 		tsImportPaths := extractTypeScriptImportsProvidedByRule(f.Pkg, r, "ts_srcs")
 		sassImportPaths := extractSassImportsProvidedByRule(f.Pkg, r, "sass_srcs")
-		rslv.indexImportsProvidedByRule("ts", tsImportPaths, r.Kind(), ruleLabel)
+		rslv.indexImportsProvidedByRule("ts", append(tsImportPaths, declaredImportPaths...), r.Kind(), ruleLabel)
 		rslv.indexImportsProvidedByRule("sass", sassImportPaths, r.Kind(), ruleLabel)
+	case "filegroup":
+		importPaths := extractAssetImportsProvidedByRule(f.Pkg, r)
+		rslv.indexImportsProvidedByRule("asset", importPaths, r.Kind(), ruleLabel)
 	}
 
 	return nil
 }
 
+// extractAssetImportsProvidedByRule takes a filegroup rule and returns the paths of the static
+// asset imports (e.g. "path/to/fixture.json") that its sources may satisfy. Sources that are not
+// static assets (e.g. labels or other kinds of files) are ignored.
+func extractAssetImportsProvidedByRule(pkg string, r *rule.Rule) []string {
+	var importPaths []string
+	for _, src := range r.AttrStrings("srcs") {
+		if strings.HasPrefix(src, ":") || strings.HasPrefix(src, "//") || !common.IsAssetFile(src) {
+			continue
+		}
+		importPaths = append(importPaths, path.Join(pkg, src))
+	}
+	return importPaths
+}
+
+// extractImportsDeclaredViaDirectives returns the paths of the imports that the given rule provides
+// according to any "frontend_provides" directives in its BUILD file (see
+// configurer.ProvidesDirective).
+func extractImportsDeclaredViaDirectives(f *rule.File, r *rule.Rule) []string {
+	var importPaths []string
+	for _, d := range f.Directives {
+		if d.Key != configurer.ProvidesDirective {
+			continue
+		}
+		args := strings.Fields(d.Value)
+		if len(args) != 2 {
+			log.Printf("Invalid %q directive in %s: %q (expected a rule name and an import path).", d.Key, f.Path, d.Value)
+			continue
+		}
+		if args[0] != r.Name() {
+			continue
+		}
+
+		importPath := path.Join(f.Pkg, args[1])
+		importPaths = append(importPaths, importPath)
+
+		// Like index.ts files, imports ending in "index" may also be imported as their parent folder.
+		if path.Base(importPath) == "index" {
+			importPaths = append(importPaths, path.Dir(importPath))
+		}
+	}
+	return importPaths
+}
+
 // extractTypeScriptImportsProvidedByRule takes a rule with TypeScript sources (e.g. "ts_library",
 // "sk_element", etc.) and returns the paths of the imports that the source files may satisfy.
 func extractTypeScriptImportsProvidedByRule(pkg string, r *rule.Rule, srcsAttr string) []string {
 	var importPaths []string
 	for _, src := range r.AttrStrings(srcsAttr) {
-		if !strings.HasSuffix(src, ".ts") {
+		// TSX sources and declaration files are imported just like regular TypeScript sources, e.g.
+		// "foo.tsx" and "foo.d.ts" can both be imported as "foo".
+		srcWithoutExt, ok := common.TrimTypeScriptExtension(src)
+		if !ok {
 			log.Printf("Rule %s of kind %s contains a non-TypeScript file in its %s attribute: %s", label.New("", pkg, r.Name()).String(), r.Kind(), srcsAttr, src)
 			continue
 		}
 
-		importPaths = append(importPaths, path.Join(pkg, strings.TrimSuffix(src, path.Ext(src))))
+		importPaths = append(importPaths, path.Join(pkg, srcWithoutExt))
 
 		// An index.ts file may also be imported as its parent folder's "main" module:
 		//
@@ -202,14 +463,14 @@ func extractTypeScriptImportsProvidedByRule(pkg string, r *rule.Rule, srcsAttr s
 		//
 		// Reference:
 		// https://www.typescriptlang.org/docs/handbook/module-resolution.html#how-typescript-resolves-modules.
-		if src == "index.ts" {
+		if srcWithoutExt == "index" {
 			importPaths = append(importPaths, pkg)
 		}
 	}
 	return importPaths
 }
 
-// extractTypeScriptImportsProvidedByRule takes a rule with Sass sources (e.g. "sass_library",
+// extractSassImportsProvidedByRule takes a rule with Sass sources (e.g. "sass_library",
 // "sk_element", etc.) and returns the paths of the imports that the source files may satisfy.
 func extractSassImportsProvidedByRule(pkg string, r *rule.Rule, srcsAttr string) []string {
 	var importPaths []string
@@ -218,7 +479,31 @@ func extractSassImportsProvidedByRule(pkg string, r *rule.Rule, srcsAttr string)
 			log.Printf("Rule %s of kind %s contains a non-Sass file in its %s attribute: %s", label.New("", pkg, r.Name()).String(), r.Kind(), srcsAttr, src)
 			continue
 		}
-		importPaths = append(importPaths, path.Join(pkg, strings.TrimSuffix(src, path.Ext(src))))
+		srcWithoutExt := strings.TrimSuffix(src, path.Ext(src))
+		importPaths = append(importPaths, path.Join(pkg, srcWithoutExt))
+
+		// A partial (i.e. a file whose name starts with an underscore) may also be imported without
+		// the underscore:
+		//
+		//     // The two following imports are equivalent.
+		//     @use 'path/to/_foo';
+		//     @use 'path/to/foo';
+		//
+		// Reference: https://sass-lang.com/documentation/at-rules/use#partials.
+		if strings.HasPrefix(srcWithoutExt, "_") {
+			importPaths = append(importPaths, path.Join(pkg, strings.TrimPrefix(srcWithoutExt, "_")))
+		}
+
+		// An index file (_index.scss or index.scss) may also be imported as its parent folder:
+		//
+		//     // The two following imports are equivalent.
+		//     @use 'path/to/module/index';
+		//     @use 'path/to/module';
+		//
+		// Reference: https://sass-lang.com/documentation/at-rules/use#index-files.
+		if srcWithoutExt == "_index" || srcWithoutExt == "index" {
+			importPaths = append(importPaths, pkg)
+		}
 	}
 	return importPaths
 }
@@ -240,6 +525,19 @@ func (rslv *Resolver) Embeds(*rule.Rule, label.Label) []label.Label { return nil
 func (rslv *Resolver) Resolve(c *config.Config, _ *resolve.RuleIndex, _ *repo.RemoteCache, r *rule.Rule, imports interface{}, from label.Label) {
 	importsFromRuleSources := imports.(common.ImportsParsedFromRuleSources)
 
+	// Unresolved imports are only reported for rules in strict mode, so we discard any unresolved
+	// imports recorded while resolving the dependencies of rules in non-strict mode.
+	numUnresolvedImports := len(rslv.unresolvedImports)
+	defer func() {
+		if !configurer.GetFrontendConfig(c).Strict {
+			rslv.unresolvedImports = rslv.unresolvedImports[:numUnresolvedImports]
+		}
+	}()
+
+	// Imports matching a "frontend_resolve" directive resolve to the dependency given in the
+	// directive, bypassing the imports index and the package.json file.
+	fc := configurer.GetFrontendConfig(c)
+
 	switch r.Kind() {
 	case "karma_test":
 		fallthrough
@@ -248,17 +546,39 @@ func (rslv *Resolver) Resolve(c *config.Config, _ *resolve.RuleIndex, _ *repo.Re
 	case "sk_element_puppeteer_test":
 		fallthrough
 	case "ts_library":
-		var deps []label.Label
+		// Tests may import static assets (e.g. JSON or PNG fixtures), which are added to their data
+		// attribute. Note that "data" is not a resolvable attribute (see Language.Kinds), so Gazelle
+		// only populates it for tests that do not already have a data attribute, and never overwrites
+		// any manually curated data dependencies.
+		isTest := r.Kind() != "ts_library"
+		var deps, data []label.Label
 		for _, importPath := range importsFromRuleSources.GetTypeScriptImports() {
+			if dep, ok := fc.FindImportOverride("ts", importPath); ok {
+				deps = append(deps, dep)
+				continue
+			}
+			if isTest && common.IsAssetFile(importPath) {
+				if ruleKindAndLabel := rslv.resolveDepForAssetImport(r.Kind(), from, importPath); ruleKindAndLabel != noRuleKindAndLabel {
+					data = append(data, ruleKindAndLabel.label)
+				}
+				continue
+			}
 			for _, ruleKindAndLabel := range rslv.resolveDepsForTypeScriptImport(r.Kind(), from, importPath, c.RepoRoot) {
 				deps = append(deps, ruleKindAndLabel.label)
 			}
 		}
 		setDeps(r, from, "deps", deps)
+		if isTest {
+			setDeps(r, from, "data", data)
+		}
 
 	case "sass_library":
 		var deps []label.Label
 		for _, importPath := range importsFromRuleSources.GetSassImports() {
+			if dep, ok := fc.FindImportOverride("sass", importPath); ok {
+				deps = append(deps, dep)
+				continue
+			}
 			ruleKindAndLabel := rslv.resolveDepForSassImport(r.Kind(), from, importPath)
 			if ruleKindAndLabel == noRuleKindAndLabel {
 				continue // No rule satisfies the current Sass import. A warning has already been logged.
@@ -281,6 +601,10 @@ func (rslv *Resolver) Resolve(c *config.Config, _ *resolve.RuleIndex, _ *repo.Re
 	case "sk_page":
 		var skElementDeps, tsDeps, sassDeps []label.Label
 		for _, importPath := range importsFromRuleSources.GetTypeScriptImports() {
+			if dep, ok := fc.FindImportOverride("ts", importPath); ok {
+				tsDeps = append(tsDeps, dep)
+				continue
+			}
 			for _, ruleKindAndLabel := range rslv.resolveDepsForTypeScriptImport(r.Kind(), from, importPath, c.RepoRoot) {
 				if ruleKindAndLabel.kind == "sk_element" {
 					skElementDeps = append(skElementDeps, ruleKindAndLabel.label)
@@ -290,6 +614,10 @@ func (rslv *Resolver) Resolve(c *config.Config, _ *resolve.RuleIndex, _ *repo.Re
 			}
 		}
 		for _, importPath := range importsFromRuleSources.GetSassImports() {
+			if dep, ok := fc.FindImportOverride("sass", importPath); ok {
+				sassDeps = append(sassDeps, dep)
+				continue
+			}
 			ruleKindAndLabel := rslv.resolveDepForSassImport(r.Kind(), from, importPath)
 			if ruleKindAndLabel == noRuleKindAndLabel {
 				continue // No rule satisfies the current Sass import. A warning has already been logged.
@@ -304,6 +632,8 @@ func (rslv *Resolver) Resolve(c *config.Config, _ *resolve.RuleIndex, _ *repo.Re
 		setDeps(r, from, "ts_deps", tsDeps)
 		setDeps(r, from, "sass_deps", sassDeps)
 	}
+
+	rslv.reconcileDeps(r, from)
 }
 
 // setDeps sets the dependencies of a rule.
@@ -355,11 +685,33 @@ func (rslv *Resolver) resolveDepForSassImport(ruleKind string, ruleLabel label.L
 	// Reference:
 	// https://sass-lang.com/documentation/at-rules/use#load-paths
 	// https://sass-lang.com/documentation/at-rules/import#load-paths
+	//
+	// Note that any namespaces (e.g. "@use 'foo' as bar") are stripped by the Sass imports parser, and
+	// that partials and index files are indexed under all the paths they can be imported as (see
+	// extractSassImportsProvidedByRule), so no further normalization is needed.
 	normalizedImportPath := path.Join(ruleLabel.Pkg, strings.TrimSuffix(importPath, path.Ext(importPath)))
 
 	return rslv.findRuleThatProvidesImport("sass", normalizedImportPath, ruleKind, ruleLabel)
 }
 
+// resolveDepForAssetImport returns the label of the filegroup that provides the static asset
+// imported by the given TypeScript import, e.g. "./fixture.json".
+//
+// Only relative imports are supported, as assets imported from NPM packages are not modeled.
+func (rslv *Resolver) resolveDepForAssetImport(ruleKind string, ruleLabel label.Label, importPath string) ruleKindAndLabel {
+	if !strings.HasPrefix(importPath, "./") && !strings.HasPrefix(importPath, "../") {
+		log.Printf("Unable to resolve import %q from %s (%s): only relative imports of static assets are supported.", importPath, ruleLabel, ruleKind)
+		rslv.addUnresolvedImport(importPath, ruleKind, ruleLabel, nil)
+		return noRuleKindAndLabel
+	}
+
+	// Normalize the import path, e.g. "../bar.json" imported from "myapp/foo" becomes
+	// "myapp/bar.json".
+	normalizedImportPath := path.Join(ruleLabel.Pkg, importPath)
+
+	return rslv.findRuleThatProvidesImport("asset", normalizedImportPath, ruleKind, ruleLabel)
+}
+
 // resolveDepsForTypeScriptImport returns the labels of the rules that resolve the given TypeScript
 // import.
 //
@@ -378,6 +730,15 @@ func (rslv *Resolver) resolveDepsForTypeScriptImport(ruleKind string, ruleLabel
 		return []ruleKindAndLabel{rkal}
 	}
 
+	// Is this an import of another source file in the repository via a tsconfig.json path alias?
+	if aliasedImportPath, ok := rslv.resolveTypeScriptPathAlias(importPath, repoRootDir); ok {
+		rkal := rslv.findRuleThatProvidesImport("ts", aliasedImportPath, ruleKind, ruleLabel)
+		if rkal == noRuleKindAndLabel {
+			return nil
+		}
+		return []ruleKindAndLabel{rkal}
+	}
+
 	// The import must be either an NPM package or a built-in Node.js module.
 	var moduleScope, moduleName, fullyQualifiedModuleName string
 	if strings.HasPrefix(importPath, "@") {
@@ -390,13 +751,15 @@ func (rslv *Resolver) resolveDepsForTypeScriptImport(ruleKind string, ruleLabel
 		fullyQualifiedModuleName = moduleName
 	}
 
-	// Is this an import from an NPM package?
-	if npmPackages := rslv.getNPMPackages(filepath.Join(repoRootDir, packageJsonPath)); npmPackages[fullyQualifiedModuleName] {
+	// Is this an import from an NPM package? We only look at the package.json file nearest to the
+	// importing package, whose node_modules target lives in the same directory as the file.
+	packageJsonDir := rslv.findNearestPackageJsonDir(ruleLabel.Pkg, repoRootDir)
+	if npmPackages := rslv.getNPMPackages(packageJsonDir, repoRootDir); npmPackages[fullyQualifiedModuleName] {
 		var rkals []ruleKindAndLabel
 		// Add as dependencies both the module and its type annotations package, if it exists.
 		rkals = append(rkals, ruleKindAndLabel{
-			kind:  "",                                                                                 // This dependency is not a rule (e.g. ts_library), so we leave the rule kind blank.
-			label: label.New("" /* =repo */, "" /* =pkg */, "node_modules/"+fullyQualifiedModuleName), // e.g. //:node_modules/puppeteer
+			kind:  "",                                                                                  // This dependency is not a rule (e.g. ts_library), so we leave the rule kind blank.
+			label: label.New("" /* =repo */, packageJsonDir, "node_modules/"+fullyQualifiedModuleName), // e.g. //:node_modules/puppeteer
 		})
 
 		// We assume that scoped packages (e.g. @google-web-components/google-chart) include type
@@ -405,8 +768,8 @@ func (rslv *Resolver) resolveDepsForTypeScriptImport(ruleKind string, ruleLabel
 			typesModuleName := "@types/" + moduleName // e.g. @types/my-module
 			if npmPackages[typesModuleName] {
 				rkals = append(rkals, ruleKindAndLabel{
-					kind:  "",                                                                        // This dependency is not a rule (e.g. ts_library), so we leave the rule kind blank.
-					label: label.New("" /* =repo */, "" /* =pkg */, "node_modules/"+typesModuleName), // e.g. //:node_modules/@types/puppeteer
+					kind:  "",                                                                         // This dependency is not a rule (e.g. ts_library), so we leave the rule kind blank.
+					label: label.New("" /* =repo */, packageJsonDir, "node_modules/"+typesModuleName), // e.g. //:node_modules/@types/puppeteer
 				})
 			}
 		}
@@ -421,13 +784,142 @@ func (rslv *Resolver) resolveDepsForTypeScriptImport(ruleKind string, ruleLabel
 	}
 
 	log.Printf("Unable to resolve import %q from %s (%s): no %q NPM package or built-in module found.", importPath, ruleLabel, ruleKind, moduleName)
+	rslv.addUnresolvedImport(importPath, ruleKind, ruleLabel, nil)
 	return nil
 }
 
-// getNPMPackages returns the set of NPM dependencies found in the package.json file.
-func (rslv *Resolver) getNPMPackages(path string) map[string]bool {
-	if rslv.npmPackages != nil {
-		return rslv.npmPackages
+// resolveTypeScriptPathAlias maps the given TypeScript import to a path relative to the workspace
+// root directory (e.g. "modules/foo" to "infra-sk/modules/foo") based on the path aliases in the
+// tsconfig.json file. It returns false if the import does not match any path aliases.
+//
+// Like the TypeScript compiler, it uses the alias with the longest matching prefix, and tries each
+// of its targets in order until one is found in the imports index. If none are found, the first
+// target is returned so that the caller can log a warning.
+//
+// Reference: https://www.typescriptlang.org/tsconfig#paths.
+func (rslv *Resolver) resolveTypeScriptPathAlias(importPath, repoRootDir string) (string, bool) {
+	for _, alias := range rslv.getTSConfigPathAliases(filepath.Join(repoRootDir, tsConfigJsonPath)) {
+		wildcard, ok := alias.match(importPath)
+		if !ok {
+			continue
+		}
+		var candidates []string
+		for _, target := range alias.targets {
+			candidates = append(candidates, strings.Replace(target, "*", wildcard, 1))
+		}
+		for _, candidate := range candidates {
+			if rslv.tsImportsToDeps[candidate] != nil {
+				return candidate, true
+			}
+		}
+		return candidates[0], true
+	}
+	return "", false
+}
+
+// getTSConfigPathAliases returns the path aliases found in the tsconfig.json file, sorted by
+// precedence. It returns an empty slice if the file does not exist or does not define any aliases.
+func (rslv *Resolver) getTSConfigPathAliases(tsConfigPath string) []tsConfigPathAlias {
+	if rslv.tsConfigPathAliasesLoaded {
+		return rslv.tsConfigPathAliases
+	}
+	rslv.tsConfigPathAliasesLoaded = true
+
+	var tsConfigJSON struct {
+		CompilerOptions struct {
+			BaseURL string              `json:"baseUrl"`
+			Paths   map[string][]string `json:"paths"`
+		} `json:"compilerOptions"`
+	}
+
+	// Read in and unmarshall tsconfig.json file. This file may contain comments and trailing commas,
+	// so we parse it as JSON5.
+	b, err := os.ReadFile(tsConfigPath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		log.Panicf("Error reading file %q: %v", tsConfigPath, err)
+	}
+	if err := json5.Unmarshal(b, &tsConfigJSON); err != nil {
+		log.Panicf("Error parsing %s: %v", tsConfigPath, err)
+	}
+
+	// Targets are relative to the baseUrl, which is itself relative to the directory of the
+	// tsconfig.json file (i.e. the workspace root directory).
+	baseURL := tsConfigJSON.CompilerOptions.BaseURL
+	for pattern, targets := range tsConfigJSON.CompilerOptions.Paths {
+		if strings.Count(pattern, "*") > 1 {
+			log.Printf("Ignoring path alias %q in %s: patterns can contain at most one \"*\" wildcard.", pattern, tsConfigPath)
+			continue
+		}
+		alias := tsConfigPathAlias{pattern: pattern}
+		for _, target := range targets {
+			target, _ = common.TrimTypeScriptExtension(path.Join(baseURL, target))
+			if target == ".." || strings.HasPrefix(target, "../") {
+				log.Printf("Ignoring target %q of path alias %q in %s: target is outside of the workspace.", target, pattern, tsConfigPath)
+				continue
+			}
+			alias.targets = append(alias.targets, target)
+		}
+		if len(alias.targets) > 0 {
+			rslv.tsConfigPathAliases = append(rslv.tsConfigPathAliases, alias)
+		}
+	}
+
+	// Exact matches take precedence over wildcard matches, and among the latter, longer prefixes take
+	// precedence over shorter ones. Ties are broken by pattern to guarantee a deterministic result.
+	sort.Slice(rslv.tsConfigPathAliases, func(i, j int) bool {
+		pi, pj := rslv.tsConfigPathAliases[i].pattern, rslv.tsConfigPathAliases[j].pattern
+		iPrefixLen, jPrefixLen := strings.Index(pi, "*"), strings.Index(pj, "*")
+		if (iPrefixLen == -1) != (jPrefixLen == -1) {
+			return iPrefixLen == -1
+		}
+		if iPrefixLen != jPrefixLen {
+			return iPrefixLen > jPrefixLen
+		}
+		return pi < pj
+	})
+
+	return rslv.tsConfigPathAliases
+}
+
+// findNearestPackageJsonDir returns the directory of the package.json file nearest to the given
+// Bazel package, i.e. the package's own directory or its closest ancestor directory containing a
+// package.json file. The returned directory is relative to the workspace root directory, which is
+// returned as "" if no other package.json files are found.
+func (rslv *Resolver) findNearestPackageJsonDir(pkg, repoRootDir string) string {
+	if dir, ok := rslv.packageJsonDirs[pkg]; ok {
+		return dir
+	}
+	if rslv.packageJsonDirs == nil {
+		rslv.packageJsonDirs = map[string]string{}
+	}
+
+	dir := ""
+	if pkg != "" {
+		if _, err := os.Stat(filepath.Join(repoRootDir, filepath.FromSlash(pkg), packageJsonPath)); err == nil {
+			dir = pkg
+		} else if !os.IsNotExist(err) {
+			log.Panicf("Error reading file %q: %v", filepath.Join(pkg, packageJsonPath), err)
+		} else {
+			parent := path.Dir(pkg)
+			if parent == "." {
+				parent = ""
+			}
+			dir = rslv.findNearestPackageJsonDir(parent, repoRootDir)
+		}
+	}
+
+	rslv.packageJsonDirs[pkg] = dir
+	return dir
+}
+
+// getNPMPackages returns the set of NPM dependencies found in the package.json file in the given
+// directory, relative to the workspace root directory.
+func (rslv *Resolver) getNPMPackages(dir, repoRootDir string) map[string]bool {
+	if npmPackages, ok := rslv.npmPackages[dir]; ok {
+		return npmPackages
 	}
 
 	var packageJSON struct {
@@ -436,6 +928,7 @@ func (rslv *Resolver) getNPMPackages(path string) map[string]bool {
 	}
 
 	// Read in and unmarshall package.json file.
+	path := filepath.Join(repoRootDir, filepath.FromSlash(dir), packageJsonPath)
 	b, err := os.ReadFile(path)
 	if err != nil {
 		log.Panicf("Error reading file %q: %v", path, err)
@@ -445,15 +938,19 @@ func (rslv *Resolver) getNPMPackages(path string) map[string]bool {
 	}
 
 	// Extract all NPM packages found in the package.json file.
-	rslv.npmPackages = map[string]bool{}
+	npmPackages := map[string]bool{}
 	for pkg := range packageJSON.Dependencies {
-		rslv.npmPackages[pkg] = true
+		npmPackages[pkg] = true
 	}
 	for pkg := range packageJSON.DevDependencies {
-		rslv.npmPackages[pkg] = true
+		npmPackages[pkg] = true
 	}
 
-	return rslv.npmPackages
+	if rslv.npmPackages == nil {
+		rslv.npmPackages = map[string]map[string]bool{}
+	}
+	rslv.npmPackages[dir] = npmPackages
+	return npmPackages
 }
 
 // builtInNodeJSModules is a set of built-in Node.js modules.
@@ -1,11 +1,17 @@
 package resolver
 
 import (
+	"bytes"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/bazelbuild/bazel-gazelle/config"
 	"github.com/bazelbuild/bazel-gazelle/label"
+	"github.com/bazelbuild/bazel-gazelle/rule"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.skia.org/infra/bazel/gazelle/frontend/configurer"
 )
 
 func TestResolver_ImportsIndex_IndexThenFind_Success(t *testing.T) {
@@ -61,3 +67,430 @@ func TestResolver_ImportsIndex_InvalidLang_Panics(t *testing.T) {
 		rslv.findRuleThatProvidesImport("nosuchlang", "", "", label.NoLabel)
 	}, "Unknown language: nosuchlang.")
 }
+
+func TestResolver_ResolveDepsForTypeScriptImport_TSConfigPathAliases_Success(t *testing.T) {
+	repoRootDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(repoRootDir, "package.json"), []byte(`{"dependencies": {"lit-html": "~1.1.2"}}`), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(repoRootDir, "tsconfig.json"), []byte(`
+{
+  "compilerOptions": {
+    // Comments and trailing commas are allowed in tsconfig.json files.
+    "paths": {
+      "modules/*": ["infra-sk/modules/*"],
+      "modules/special/*": ["special/*", "fallback/*"],
+      "units": ["./measurements/units/index.ts"],
+    },
+  },
+}
+`), 0644))
+
+	rslv := &Resolver{}
+
+	// Index TypeScript imports provided by a few fake targets.
+	foo, err := label.Parse("//infra-sk/modules/foo:foo_ts_lib")
+	require.NoError(t, err)
+	rslv.indexImportsProvidedByRule("ts", []string{"infra-sk/modules/foo"}, "ts_library", foo)
+	bar, err := label.Parse("//fallback:bar_ts_lib")
+	require.NoError(t, err)
+	rslv.indexImportsProvidedByRule("ts", []string{"fallback/bar"}, "ts_library", bar)
+	units, err := label.Parse("//measurements/units:units")
+	require.NoError(t, err)
+	rslv.indexImportsProvidedByRule("ts", []string{"measurements/units/index"}, "ts_library", units)
+
+	from, err := label.Parse("//myapp:myapp_ts_lib")
+	require.NoError(t, err)
+	resolve := func(importPath string) []ruleKindAndLabel {
+		return rslv.resolveDepsForTypeScriptImport("ts_library", from, importPath, repoRootDir)
+	}
+
+	assert.Equal(t, []ruleKindAndLabel{{"ts_library", foo}}, resolve("modules/foo"))
+	// The longest matching prefix wins, and its targets are tried in order.
+	assert.Equal(t, []ruleKindAndLabel{{"ts_library", bar}}, resolve("modules/special/bar"))
+	assert.Equal(t, []ruleKindAndLabel{{"ts_library", units}}, resolve("units"))
+	// Aliases that do not resolve to any indexed rules produce no dependencies.
+	assert.Empty(t, resolve("modules/no-such-module"))
+	// Imports that do not match any aliases are resolved as usual.
+	assert.Equal(t, []ruleKindAndLabel{{"", label.New("", "", "node_modules/lit-html")}}, resolve("lit-html"))
+}
+
+func TestResolver_ResolveDepsForTypeScriptImport_NoTSConfig_NPMImportResolved(t *testing.T) {
+	repoRootDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(repoRootDir, "package.json"), []byte(`{"dependencies": {"lit-html": "~1.1.2"}}`), 0644))
+
+	rslv := &Resolver{}
+	deps := rslv.resolveDepsForTypeScriptImport("ts_library", label.NoLabel, "lit-html", repoRootDir)
+	assert.Equal(t, []ruleKindAndLabel{{"", label.New("", "", "node_modules/lit-html")}}, deps)
+	assert.Empty(t, rslv.tsConfigPathAliases)
+}
+
+func TestResolver_ResolveDepsForTypeScriptImport_NestedPackageJson_NearestManifestUsed(t *testing.T) {
+	repoRootDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(repoRootDir, "package.json"), []byte(`{"dependencies": {"lit-html": "~1.1.2"}}`), 0644))
+	require.NoError(t, os.MkdirAll(filepath.Join(repoRootDir, "puppeteer-tests", "util"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(repoRootDir, "puppeteer-tests", "package.json"), []byte(`{"devDependencies": {"puppeteer": "^19.0.0", "@types/puppeteer": "^7.0.0"}}`), 0644))
+
+	rslv := &Resolver{}
+	resolve := func(from, importPath string) []ruleKindAndLabel {
+		l, err := label.Parse(from)
+		require.NoError(t, err)
+		return rslv.resolveDepsForTypeScriptImport("ts_library", l, importPath, repoRootDir)
+	}
+
+	// Packages within the subtree resolve NPM imports against the nested package.json file.
+	expected := []ruleKindAndLabel{
+		{"", label.New("", "puppeteer-tests", "node_modules/puppeteer")},
+		{"", label.New("", "puppeteer-tests", "node_modules/@types/puppeteer")},
+	}
+	assert.Equal(t, expected, resolve("//puppeteer-tests:util_ts_lib", "puppeteer"))
+	assert.Equal(t, expected, resolve("//puppeteer-tests/util:util_ts_lib", "puppeteer"))
+	// The nearest package.json file is not merged with the root package.json file.
+	assert.Empty(t, resolve("//puppeteer-tests/util:util_ts_lib", "lit-html"))
+
+	// Packages outside the subtree use the root package.json file.
+	assert.Equal(t, []ruleKindAndLabel{{"", label.New("", "", "node_modules/lit-html")}}, resolve("//myapp/modules:foo_ts_lib", "lit-html"))
+	assert.Empty(t, resolve("//myapp/modules:foo_ts_lib", "puppeteer"))
+}
+
+func TestExtractTypeScriptImportsProvidedByRule_TSXAndDeclarationFiles_Success(t *testing.T) {
+	r := rule.NewRule("ts_library", "my_lib")
+	r.SetAttr("srcs", []string{"alfa.ts", "bravo.tsx", "charlie.d.ts", "index.tsx", "delta.scss"})
+
+	assert.Equal(t, []string{
+		"path/to/alfa",
+		"path/to/bravo",
+		"path/to/charlie",
+		"path/to/index",
+		"path/to",
+	}, extractTypeScriptImportsProvidedByRule("path/to", r, "srcs"))
+}
+
+func TestExtractSassImportsProvidedByRule_PartialsAndIndexFiles_Success(t *testing.T) {
+	r := rule.NewRule("sass_library", "my_lib")
+	r.SetAttr("srcs", []string{"alfa.scss", "_bravo.scss", "_index.scss", "delta.ts"})
+
+	assert.Equal(t, []string{
+		"path/to/alfa",
+		"path/to/_bravo",
+		"path/to/bravo",
+		"path/to/_index",
+		"path/to/index",
+		"path/to",
+	}, extractSassImportsProvidedByRule("path/to", r, "srcs"))
+}
+
+func TestResolver_ResolveDepForSassImport_PartialsAndIndexFiles_Success(t *testing.T) {
+	rslv := &Resolver{}
+
+	// Index the Sass imports provided by a fake //shared:styles target with sources "_colors.scss"
+	// and "_index.scss".
+	styles, err := label.Parse("//shared:styles")
+	require.NoError(t, err)
+	r := rule.NewRule("sass_library", "styles")
+	r.SetAttr("srcs", []string{"_colors.scss", "_index.scss"})
+	rslv.indexImportsProvidedByRule("sass", extractSassImportsProvidedByRule("shared", r, "srcs"), "sass_library", styles)
+
+	from, err := label.Parse("//myapp:myapp_sass_lib")
+	require.NoError(t, err)
+	expected := ruleKindAndLabel{"sass_library", styles}
+
+	assert.Equal(t, expected, rslv.resolveDepForSassImport("sass_library", from, "../shared/colors"))
+	assert.Equal(t, expected, rslv.resolveDepForSassImport("sass_library", from, "../shared/_colors"))
+	assert.Equal(t, expected, rslv.resolveDepForSassImport("sass_library", from, "../shared/_colors.scss"))
+	assert.Equal(t, expected, rslv.resolveDepForSassImport("sass_library", from, "../shared/index"))
+	assert.Equal(t, expected, rslv.resolveDepForSassImport("sass_library", from, "../shared"))
+	assert.Equal(t, noRuleKindAndLabel, rslv.resolveDepForSassImport("sass_library", from, "../shared/fonts"))
+}
+
+func TestResolver_Resolve_StrictMode_RecordsUnresolvedImports(t *testing.T) {
+	repoRootDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(repoRootDir, "package.json"), []byte(`{"dependencies": {"lit-html": "~1.1.2"}}`), 0644))
+
+	rslv := &Resolver{}
+
+	// Index TypeScript imports provided by two fake targets that provide the same import.
+	alfa, err := label.Parse("//shared:alfa_ts_lib")
+	require.NoError(t, err)
+	rslv.indexImportsProvidedByRule("ts", []string{"shared/util"}, "ts_library", alfa)
+	bravo, err := label.Parse("//shared:bravo_ts_lib")
+	require.NoError(t, err)
+	rslv.indexImportsProvidedByRule("ts", []string{"shared/util"}, "ts_library", bravo)
+
+	makeConfig := func(strict bool) *config.Config {
+		c := config.New()
+		c.RepoRoot = repoRootDir
+		c.Exts["frontend"] = &configurer.FrontendConfig{Strict: strict}
+		return c
+	}
+	imports := &fakeImportsParsedFromRuleSources{tsImports: []string{"../shared/util", "../shared/no-such-file", "no-such-package", "lit-html"}}
+
+	// Unresolved imports from rules in non-strict mode are not recorded.
+	from, err := label.Parse("//lenient:lenient_ts_lib")
+	require.NoError(t, err)
+	rslv.Resolve(makeConfig(false), nil, nil, rule.NewRule("ts_library", from.Name), imports, from)
+	assert.Empty(t, rslv.UnresolvedImports())
+
+	// Unresolved imports from rules in strict mode are recorded.
+	from, err = label.Parse("//strict:strict_ts_lib")
+	require.NoError(t, err)
+	rslv.Resolve(makeConfig(true), nil, nil, rule.NewRule("ts_library", from.Name), imports, from)
+	assert.Equal(t, []UnresolvedImport{
+		{
+			Rule:       "//strict:strict_ts_lib",
+			RuleKind:   "ts_library",
+			Import:     "shared/util",
+			Candidates: []string{"//shared:alfa_ts_lib", "//shared:bravo_ts_lib"},
+		},
+		{
+			Rule:       "//strict:strict_ts_lib",
+			RuleKind:   "ts_library",
+			Import:     "shared/no-such-file",
+			Candidates: []string{},
+		},
+		{
+			Rule:       "//strict:strict_ts_lib",
+			RuleKind:   "ts_library",
+			Import:     "no-such-package",
+			Candidates: []string{},
+		},
+	}, rslv.UnresolvedImports())
+}
+
+func TestWriteUnresolvedImportsReport_Success(t *testing.T) {
+	var b bytes.Buffer
+	require.NoError(t, WriteUnresolvedImportsReport(&b, []UnresolvedImport{
+		{
+			Rule:       "//strict:strict_ts_lib",
+			RuleKind:   "ts_library",
+			Import:     "shared/util",
+			Candidates: []string{"//shared:alfa_ts_lib", "//shared:bravo_ts_lib"},
+		},
+	}))
+	assert.Equal(t, `{
+  "unresolved_imports": [
+    {
+      "rule": "//strict:strict_ts_lib",
+      "rule_kind": "ts_library",
+      "import": "shared/util",
+      "candidates": [
+        "//shared:alfa_ts_lib",
+        "//shared:bravo_ts_lib"
+      ]
+    }
+  ]
+}
+`, b.String())
+}
+
+// fakeImportsParsedFromRuleSources implements the common.ImportsParsedFromRuleSources interface.
+type fakeImportsParsedFromRuleSources struct {
+	sassImports []string
+	tsImports   []string
+}
+
+func (f *fakeImportsParsedFromRuleSources) GetSassImports() []string {
+	return f.sassImports
+}
+
+func (f *fakeImportsParsedFromRuleSources) GetTypeScriptImports() []string {
+	return f.tsImports
+}
+
+func TestResolver_Imports_ProvidesDirective_IndexesDeclaredImports(t *testing.T) {
+	f, err := rule.LoadData("perf/modules/json/BUILD.bazel", "perf/modules/json", []byte(`
+# gazelle:frontend_provides json_ts_lib index
+# gazelle:frontend_provides json_ts_lib rpc_pb
+# gazelle:frontend_provides styles_sass_lib generated/theme
+
+ts_library(
+    name = "json_ts_lib",
+    srcs = [":generate_json"],
+)
+
+sass_library(
+    name = "styles_sass_lib",
+    srcs = ["styles.scss"],
+)
+
+ts_library(
+    name = "other_ts_lib",
+    srcs = ["other.ts"],
+)
+`))
+	require.NoError(t, err)
+
+	rslv := &Resolver{}
+	c := config.New()
+	for _, r := range f.Rules {
+		assert.Nil(t, rslv.Imports(c, r, f))
+	}
+
+	jsonTSLib := ruleKindAndLabel{"ts_library", label.New("", "perf/modules/json", "json_ts_lib")}
+	stylesSassLib := ruleKindAndLabel{"sass_library", label.New("", "perf/modules/json", "styles_sass_lib")}
+	otherTSLib := ruleKindAndLabel{"ts_library", label.New("", "perf/modules/json", "other_ts_lib")}
+
+	assert.Equal(t, jsonTSLib, rslv.findRuleThatProvidesImport("ts", "perf/modules/json/index", "", label.NoLabel))
+	assert.Equal(t, jsonTSLib, rslv.findRuleThatProvidesImport("ts", "perf/modules/json", "", label.NoLabel))
+	assert.Equal(t, jsonTSLib, rslv.findRuleThatProvidesImport("ts", "perf/modules/json/rpc_pb", "", label.NoLabel))
+	assert.Equal(t, stylesSassLib, rslv.findRuleThatProvidesImport("sass", "perf/modules/json/styles", "", label.NoLabel))
+	assert.Equal(t, stylesSassLib, rslv.findRuleThatProvidesImport("sass", "perf/modules/json/generated/theme", "", label.NoLabel))
+	assert.Equal(t, otherTSLib, rslv.findRuleThatProvidesImport("ts", "perf/modules/json/other", "", label.NoLabel))
+}
+
+func TestResolver_Resolve_TestImportsStaticAssets_PopulatesData(t *testing.T) {
+	repoRootDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(repoRootDir, "package.json"), []byte(`{"dependencies": {}}`), 0644))
+
+	f, err := rule.LoadData("myapp/modules/foo-sk/BUILD.bazel", "myapp/modules/foo-sk", []byte(`
+filegroup(
+    name = "fixture_json_data",
+    srcs = ["fixture.json"],
+)
+
+ts_library(
+    name = "util_ts_lib",
+    srcs = ["util.ts"],
+)
+`))
+	require.NoError(t, err)
+	testdata, err := rule.LoadData("myapp/testdata/BUILD.bazel", "myapp/testdata", []byte(`
+filegroup(
+    name = "images",
+    srcs = ["golden.png", "README.md", ":generated_png"],
+)
+`))
+	require.NoError(t, err)
+
+	rslv := &Resolver{}
+	c := config.New()
+	c.RepoRoot = repoRootDir
+	for _, file := range []*rule.File{f, testdata} {
+		for _, r := range file.Rules {
+			rslv.Imports(c, r, file)
+		}
+	}
+
+	imports := &fakeImportsParsedFromRuleSources{tsImports: []string{"./fixture.json", "../../testdata/golden.png", "./util"}}
+
+	// Static assets imported from tests are added to the data attribute.
+	from := label.New("", "myapp/modules/foo-sk", "foo-sk_test")
+	r := rule.NewRule("karma_test", from.Name)
+	rslv.Resolve(c, nil, nil, r, imports, from)
+	assert.Equal(t, []string{":util_ts_lib"}, r.AttrStrings("deps"))
+	assert.Equal(t, []string{"//myapp/testdata:images", ":fixture_json_data"}, r.AttrStrings("data"))
+
+	// Non-test rules do not get a data attribute.
+	from = label.New("", "myapp/modules/foo-sk", "foo_ts_lib")
+	r = rule.NewRule("ts_library", from.Name)
+	rslv.Resolve(c, nil, nil, r, imports, from)
+	assert.Equal(t, []string{":util_ts_lib"}, r.AttrStrings("deps"))
+	assert.Empty(t, r.AttrStrings("data"))
+}
+
+func TestResolver_Resolve_ImportsRemoved_StaleDepsReported(t *testing.T) {
+	repoRootDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(repoRootDir, "package.json"), []byte(`{"dependencies": {}}`), 0644))
+
+	f, err := rule.LoadData("myapp/BUILD.bazel", "myapp", []byte(`
+ts_library(
+    name = "alfa_ts_lib",
+    srcs = ["alfa.ts"],
+)
+
+ts_library(
+    name = "bravo_ts_lib",
+    srcs = ["bravo.ts"],
+)
+
+ts_library(
+    name = "charlie_ts_lib",
+    srcs = ["charlie.ts"],
+)
+
+ts_library(
+    name = "main_ts_lib",
+    srcs = ["main.ts"],
+    deps = [
+        ":alfa_ts_lib",
+        ":bravo_ts_lib",
+        ":charlie_ts_lib",  # keep
+        "//:node_modules/lit-html",
+    ],
+)
+
+# keep
+ts_library(
+    name = "kept_ts_lib",
+    srcs = ["kept.ts"],
+    deps = [":bravo_ts_lib"],
+)
+`))
+	require.NoError(t, err)
+
+	rslv := &Resolver{}
+	c := config.New()
+	c.RepoRoot = repoRootDir
+	for _, r := range f.Rules {
+		rslv.Imports(c, r, f)
+	}
+
+	// main.ts now only imports alfa.ts.
+	imports := &fakeImportsParsedFromRuleSources{tsImports: []string{"./alfa"}}
+	from := label.New("", "myapp", "main_ts_lib")
+	r := rule.NewRule("ts_library", from.Name)
+	rslv.Resolve(c, nil, nil, r, imports, from)
+	assert.Equal(t, []string{":alfa_ts_lib"}, r.AttrStrings("deps"))
+
+	// Rules marked with "# keep" are never reconciled.
+	from = label.New("", "myapp", "kept_ts_lib")
+	rslv.Resolve(c, nil, nil, rule.NewRule("ts_library", from.Name), &fakeImportsParsedFromRuleSources{}, from)
+
+	main := label.New("", "myapp", "main_ts_lib")
+	assert.Equal(t, []StaleDep{
+		{Rule: main, Attr: "deps", Dep: ":bravo_ts_lib"},
+		{Rule: main, Attr: "deps", Dep: "//:node_modules/lit-html"},
+	}, rslv.StaleDeps())
+}
+
+func TestResolver_Resolve_ResolveDirective_OverridesResolution(t *testing.T) {
+	repoRootDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(repoRootDir, "package.json"), []byte(`{"dependencies": {"lit-html": "~1.1.2"}}`), 0644))
+
+	f, err := rule.LoadData("myapp/BUILD.bazel", "myapp", []byte(`
+# gazelle:frontend_resolve ts lit-html //third_party/lit-html:bundle
+# gazelle:frontend_resolve ts ./generated :generated_bundle
+# gazelle:frontend_resolve sass vendored/theme //third_party/theme:theme_sass_lib
+
+ts_library(
+    name = "util_ts_lib",
+    srcs = ["util.ts"],
+)
+`))
+	require.NoError(t, err)
+
+	rslv := &Resolver{}
+	c := config.New()
+	c.RepoRoot = repoRootDir
+	cfg := &configurer.Configurer{}
+	require.NoError(t, cfg.CheckFlags(nil, c))
+	cfg.Configure(c, "myapp", f)
+	for _, r := range f.Rules {
+		rslv.Imports(c, r, f)
+	}
+
+	imports := &fakeImportsParsedFromRuleSources{
+		tsImports:   []string{"lit-html/directives/repeat", "./generated/api", "./util"},
+		sassImports: []string{"vendored/theme/colors"},
+	}
+
+	from := label.New("", "myapp", "foo_ts_lib")
+	r := rule.NewRule("ts_library", from.Name)
+	rslv.Resolve(c, nil, nil, r, imports, from)
+	assert.Equal(t, []string{"//third_party/lit-html:bundle", ":generated_bundle", ":util_ts_lib"}, r.AttrStrings("deps"))
+
+	from = label.New("", "myapp", "foo-sk")
+	r = rule.NewRule("sk_element", from.Name)
+	rslv.Resolve(c, nil, nil, r, imports, from)
+	assert.Equal(t, []string{"//third_party/lit-html:bundle", ":generated_bundle", ":util_ts_lib"}, r.AttrStrings("ts_deps"))
+	assert.Equal(t, []string{"//third_party/theme:theme_sass_lib"}, r.AttrStrings("sass_deps"))
+	assert.Empty(t, rslv.UnresolvedImports())
+}
@@ -95,6 +95,9 @@ type AutoRollIssue struct {
 	RollingTo      string             `json:"rollingTo"`
 	Subject        string             `json:"subject"`
 	TryResults     []*TryResult       `json:"tryResults"`
+	// PreUploadStepResults are the results of any pre-upload steps which
+	// were run while creating the roll.
+	PreUploadStepResults []*PreUploadStepResult `json:"preUploadStepResults"`
 }
 
 // Validate returns an error iff there is some problem with the issue.
@@ -141,6 +144,13 @@ func (i *AutoRollIssue) Copy() *AutoRollIssue {
 			tryResultsCpy = append(tryResultsCpy, t.Copy())
 		}
 	}
+	var preUploadStepResultsCpy []*PreUploadStepResult
+	if i.PreUploadStepResults != nil {
+		preUploadStepResultsCpy = make([]*PreUploadStepResult, 0, len(i.PreUploadStepResults))
+		for _, r := range i.PreUploadStepResults {
+			preUploadStepResultsCpy = append(preUploadStepResultsCpy, r.Copy())
+		}
+	}
 	return &AutoRollIssue{
 		Attempt:        i.Attempt,
 		AttemptStart:   i.AttemptStart,
@@ -162,6 +172,8 @@ func (i *AutoRollIssue) Copy() *AutoRollIssue {
 		RollingTo:      i.RollingTo,
 		Subject:        i.Subject,
 		TryResults:     tryResultsCpy,
+
+		PreUploadStepResults: preUploadStepResultsCpy,
 	}
 }
 
@@ -420,6 +432,38 @@ func (t *TryResult) Copy() *TryResult {
 	}
 }
 
+// PreUploadStepResult describes the outcome of a pre-upload step which was run
+// while creating a roll.
+type PreUploadStepResult struct {
+	// Name of the step.
+	Name string `json:"name"`
+	// Attempts is the number of times the step was run, including retries.
+	Attempts int `json:"attempts"`
+	// Duration is the total time spent running the step.
+	Duration time.Duration `json:"duration"`
+	// Error from the final attempt, if it failed.
+	Error string `json:"error,omitempty"`
+	// IgnoredFailure indicates that the step failed but was configured not to
+	// fail the roll.
+	IgnoredFailure bool `json:"ignoredFailure,omitempty"`
+}
+
+// Succeeded returns true iff the step completed successfully.
+func (r *PreUploadStepResult) Succeeded() bool {
+	return r.Error == ""
+}
+
+// Copy returns a copy of the PreUploadStepResult.
+func (r *PreUploadStepResult) Copy() *PreUploadStepResult {
+	return &PreUploadStepResult{
+		Name:           r.Name,
+		Attempts:       r.Attempts,
+		Duration:       r.Duration,
+		Error:          r.Error,
+		IgnoredFailure: r.IgnoredFailure,
+	}
+}
+
 type autoRollIssueSlice []*AutoRollIssue
 
 func (s autoRollIssueSlice) Len() int           { return len(s) }
@@ -51,6 +51,15 @@ func TestAutoRollIssueCopy(t *testing.T) {
 				Url:      "http://build/cats",
 			},
 		},
+		PreUploadStepResults: []*PreUploadStepResult{
+			{
+				Name:           "generate",
+				Attempts:       2,
+				Duration:       time.Minute,
+				Error:          "timed out",
+				IgnoredFailure: true,
+			},
+		},
 	}
 	assertdeep.Copy(t, roll, roll.Copy())
 }
@@ -0,0 +1,136 @@
+package ds
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"cloud.google.com/go/datastore"
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/go/util"
+)
+
+// chunkParallelism is the maximum number of chunks that PutMultiChunked and
+// GetMultiChunked send to Datastore at once.
+const chunkParallelism = 4
+
+// PutMultiChunked stores the entities with the given keys, in chunks of at
+// most MAX_MODIFICATIONS entities which are sent in parallel. Keys without a
+// namespace are stored in Namespace. It returns the complete keys, in the same
+// order as the given keys.
+//
+// If some of the entities could not be stored, the returned error is a
+// datastore.MultiError with an entry per key, which is non-nil for the keys
+// which failed. The returned keys are nil at those indices.
+func PutMultiChunked[T any](ctx context.Context, client *datastore.Client, keys []*datastore.Key, src []*T) ([]*datastore.Key, error) {
+	if len(keys) != len(src) {
+		return nil, skerr.Fmt("got %d keys but %d entities", len(keys), len(src))
+	}
+	ret := make([]*datastore.Key, len(keys))
+	errs := make(datastore.MultiError, len(keys))
+	err := runChunked(ctx, len(keys), errs, func(ctx context.Context, start, end int) error {
+		chunk := make([]*datastore.Key, end-start)
+		for i, key := range keys[start:end] {
+			chunk[i] = withNamespace(key)
+		}
+		var putKeys []*datastore.Key
+		err := retry(ctx, func() error {
+			var err error
+			putKeys, err = client.PutMulti(ctx, chunk, src[start:end])
+			return err
+		})
+		if err == nil {
+			copy(ret[start:end], putKeys)
+		}
+		return err
+	})
+	return ret, err
+}
+
+// GetMultiChunked loads the entities with the given keys, in chunks of at most
+// MAX_MODIFICATIONS keys which are sent in parallel. Keys without a namespace
+// are looked up in Namespace. It returns the entities in the same order as the
+// keys, with nil for the keys which have no entity.
+//
+// If some of the entities could not be loaded, the returned error is a
+// datastore.MultiError with an entry per key, which is non-nil for the keys
+// which failed. Missing entities are not errors.
+func GetMultiChunked[T any](ctx context.Context, client *datastore.Client, keys []*datastore.Key) ([]*T, error) {
+	ret := make([]*T, len(keys))
+	errs := make(datastore.MultiError, len(keys))
+	err := runChunked(ctx, len(keys), errs, func(ctx context.Context, start, end int) error {
+		chunk := make([]*datastore.Key, end-start)
+		for i, key := range keys[start:end] {
+			chunk[i] = withNamespace(key)
+		}
+		dst := make([]*T, end-start)
+		for i := range dst {
+			dst[i] = new(T)
+		}
+		err := retry(ctx, func() error {
+			return client.GetMulti(ctx, chunk, dst)
+		})
+		var multiErr datastore.MultiError
+		if err != nil && !errors.As(err, &multiErr) {
+			return err
+		}
+		for i := range dst {
+			if multiErr != nil && multiErr[i] != nil {
+				if errors.Is(multiErr[i], datastore.ErrNoSuchEntity) {
+					multiErr[i] = nil
+				}
+				continue
+			}
+			ret[start+i] = dst[i]
+		}
+		if multiErr != nil {
+			return multiErr
+		}
+		return nil
+	})
+	return ret, err
+}
+
+// runChunked calls fn for chunks of at most MAX_MODIFICATIONS indices in
+// [0, length), with at most chunkParallelism chunks at once, and records the
+// errors of each index in errs. A datastore.MultiError returned by fn has an
+// entry per index of the chunk, while any other error applies to the whole
+// chunk. It returns errs if any of them is non-nil.
+func runChunked(ctx context.Context, length int, errs datastore.MultiError, fn func(ctx context.Context, start, end int) error) error {
+	if length == 0 {
+		return nil
+	}
+	var mtx sync.Mutex
+	failed := false
+	err := util.ChunkIterParallelPool(ctx, length, MAX_MODIFICATIONS, chunkParallelism, func(ctx context.Context, start, end int) error {
+		err := fn(ctx, start, end)
+		if err == nil {
+			return nil
+		}
+		mtx.Lock()
+		defer mtx.Unlock()
+		var multiErr datastore.MultiError
+		if errors.As(err, &multiErr) && len(multiErr) == end-start {
+			for i, err := range multiErr {
+				if err != nil {
+					errs[start+i] = err
+					failed = true
+				}
+			}
+			return nil
+		}
+		for i := start; i < end; i++ {
+			errs[i] = err
+		}
+		failed = true
+		// Keep going, so that the errors of all of the chunks are known.
+		return nil
+	})
+	if err != nil {
+		return skerr.Wrap(err)
+	}
+	if failed {
+		return errs
+	}
+	return nil
+}
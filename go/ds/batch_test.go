@@ -0,0 +1,110 @@
+package ds
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"cloud.google.com/go/datastore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.skia.org/infra/go/emulators/gcp_emulator"
+)
+
+func TestRunChunked_AggregatesErrorsByIndex(t *testing.T) {
+	length := 2*MAX_MODIFICATIONS + 10
+	chunkErr := errors.New("chunk failed")
+	entityErr := errors.New("entity failed")
+	errs := make(datastore.MultiError, length)
+	err := runChunked(context.Background(), length, errs, func(ctx context.Context, start, end int) error {
+		switch start {
+		case 0:
+			return nil
+		case MAX_MODIFICATIONS:
+			return chunkErr
+		default:
+			multiErr := make(datastore.MultiError, end-start)
+			multiErr[1] = entityErr
+			return multiErr
+		}
+	})
+	require.Error(t, err)
+
+	var multiErr datastore.MultiError
+	require.True(t, errors.As(err, &multiErr))
+	require.Len(t, multiErr, length)
+	for i, err := range multiErr {
+		switch {
+		case i >= MAX_MODIFICATIONS && i < 2*MAX_MODIFICATIONS:
+			assert.Equal(t, chunkErr, err, i)
+		case i == 2*MAX_MODIFICATIONS+1:
+			assert.Equal(t, entityErr, err, i)
+		default:
+			assert.NoError(t, err, i)
+		}
+	}
+}
+
+func TestRunChunked_NoErrors_ReturnsNil(t *testing.T) {
+	var mtx sync.Mutex
+	var calls [][2]int
+	errs := make(datastore.MultiError, MAX_MODIFICATIONS+1)
+	err := runChunked(context.Background(), MAX_MODIFICATIONS+1, errs, func(ctx context.Context, start, end int) error {
+		mtx.Lock()
+		defer mtx.Unlock()
+		calls = append(calls, [2]int{start, end})
+		return nil
+	})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, [][2]int{{0, MAX_MODIFICATIONS}, {MAX_MODIFICATIONS, MAX_MODIFICATIONS + 1}}, calls)
+
+	require.NoError(t, runChunked(context.Background(), 0, nil, func(ctx context.Context, start, end int) error {
+		return errors.New("should not be called")
+	}))
+}
+
+func TestPutMultiChunked_MismatchedLengths_ReturnsError(t *testing.T) {
+	_, err := PutMultiChunked(context.Background(), nil, []*datastore.Key{NewKey(TEST_KIND)}, []*testEntity{})
+	assert.Error(t, err)
+}
+
+func TestPutMultiChunked_GetMultiChunked_RoundTrip(t *testing.T) {
+	gcp_emulator.RequireDatastore(t)
+
+	require.NoError(t, InitForTesting("test-project", "test-namespace"))
+	client := DS
+	ctx := context.Background()
+	_, err := DeleteAll(ctx, client, TEST_KIND, true)
+	require.NoError(t, err)
+	defer func() {
+		_, err := DeleteAll(ctx, client, TEST_KIND, true)
+		require.NoError(t, err)
+	}()
+
+	n := MAX_MODIFICATIONS*2 + 1
+	keys := make([]*datastore.Key, n)
+	entities := make([]*testEntity, n)
+	for i := range keys {
+		keys[i] = &datastore.Key{Kind: string(TEST_KIND)}
+		entities[i] = &testEntity{Random: int64(i)}
+	}
+	putKeys, err := PutMultiChunked(ctx, client, keys, entities)
+	require.NoError(t, err)
+	require.Len(t, putKeys, n)
+	for _, key := range putKeys {
+		assert.Equal(t, "test-namespace", key.Namespace)
+		assert.NotZero(t, key.ID)
+	}
+
+	// Look up a missing entity along with the stored ones.
+	missing := datastore.NameKey(string(TEST_KIND), "missing", nil)
+	actual, err := GetMultiChunked[testEntity](ctx, client, append(putKeys, missing))
+	require.NoError(t, err)
+	require.Len(t, actual, n+1)
+	for i := 0; i < n; i++ {
+		require.NotNil(t, actual[i])
+		assert.Equal(t, int64(i), actual[i].Random)
+	}
+	assert.Nil(t, actual[n])
+}
@@ -0,0 +1,89 @@
+package ds
+
+import (
+	"context"
+
+	"cloud.google.com/go/datastore"
+	"go.skia.org/infra/go/skerr"
+	"google.golang.org/api/option"
+)
+
+// Client is a Cloud Datastore client which is bound to a project and
+// namespace. Unlike the package-level DS and Namespace, which are set by Init,
+// any number of Clients may be used at once, e.g. to copy entities between
+// namespaces.
+//
+// The embedded *datastore.Client does not apply the namespace on its own, so
+// keys and queries should be created via the methods of Client.
+type Client struct {
+	*datastore.Client
+	project   string
+	namespace string
+}
+
+// NewClient returns a Client for the given project and namespace.
+//
+// project - The project name, i.e. "google.com:skia-buildbots".
+// ns      - The datastore namespace to store data into.
+// opts    - Options to pass to the client.
+func NewClient(ctx context.Context, project, ns string, opts ...option.ClientOption) (*Client, error) {
+	if ns == "" {
+		return nil, skerr.Fmt("Datastore namespace cannot be empty.")
+	}
+	client, err := datastore.NewClient(ctx, project, opts...)
+	if err != nil {
+		return nil, skerr.Fmt("Failed to initialize Cloud Datastore: %s", err)
+	}
+	return WrapClient(client, project, ns), nil
+}
+
+// WrapClient returns a Client which uses the given *datastore.Client for the
+// given project and namespace.
+func WrapClient(client *datastore.Client, project, ns string) *Client {
+	return &Client{
+		Client:    client,
+		project:   project,
+		namespace: ns,
+	}
+}
+
+// Project returns the project of the Client.
+func (c *Client) Project() string {
+	return c.project
+}
+
+// Namespace returns the namespace of the Client.
+func (c *Client) Namespace() string {
+	return c.namespace
+}
+
+// NewKey creates a new indeterminate key of the given kind in the namespace of
+// the Client.
+func (c *Client) NewKey(kind Kind) *datastore.Key {
+	return newKey(c.namespace, kind)
+}
+
+// NewKeyWithParent creates a new indeterminate key of the given kind and parent
+// in the namespace of the Client.
+func (c *Client) NewKeyWithParent(kind Kind, parent *datastore.Key) *datastore.Key {
+	ret := c.NewKey(kind)
+	ret.Parent = parent
+	return ret
+}
+
+// NewQuery creates a new query of the given kind in the namespace of the
+// Client.
+func (c *Client) NewQuery(kind Kind) *datastore.Query {
+	return newQuery(c.namespace, kind)
+}
+
+// DeleteAll is like the package-level DeleteAll, in the namespace of the
+// Client.
+func (c *Client) DeleteAll(ctx context.Context, kind Kind, wait bool) (int, error) {
+	return deleteAll(ctx, c.Client, c.namespace, kind, wait)
+}
+
+// IterKeys is like the package-level IterKeys, in the namespace of the Client.
+func (c *Client) IterKeys(ctx context.Context, kind Kind, pageSize int) (<-chan *IterKeysItem, error) {
+	return iterKeys(ctx, c.Client, c.namespace, kind, pageSize)
+}
@@ -0,0 +1,61 @@
+package ds
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.skia.org/infra/go/emulators/gcp_emulator"
+)
+
+func TestClient_KeysAndQueriesUseClientNamespace(t *testing.T) {
+	Namespace = "global-namespace"
+	c := WrapClient(nil, "test-project", "client-namespace")
+	assert.Equal(t, "test-project", c.Project())
+	assert.Equal(t, "client-namespace", c.Namespace())
+
+	key := c.NewKey(TEST_KIND)
+	assert.Equal(t, string(TEST_KIND), key.Kind)
+	assert.Equal(t, "client-namespace", key.Namespace)
+	assert.True(t, key.Incomplete())
+
+	parent := c.NewKey(TEST_KIND)
+	parent.ID = 13
+	assert.Equal(t, parent, c.NewKeyWithParent(TEST_KIND, parent).Parent)
+
+	// The globals are not affected.
+	assert.Equal(t, "global-namespace", NewKey(TEST_KIND).Namespace)
+}
+
+func TestNewClient_EmptyNamespace_Error(t *testing.T) {
+	_, err := NewClient(context.Background(), "test-project", "")
+	require.Error(t, err)
+}
+
+func TestClient_DeleteAll_OnlyDeletesInClientNamespace(t *testing.T) {
+	gcp_emulator.RequireDatastore(t)
+
+	ctx := context.Background()
+	c1, err := NewClient(ctx, "test-project", "test-namespace-1")
+	require.NoError(t, err)
+	c2 := WrapClient(c1.Client, "test-project", "test-namespace-2")
+	for _, c := range []*Client{c1, c2} {
+		_, err := c.DeleteAll(ctx, TEST_KIND, true)
+		require.NoError(t, err)
+		_, err = c.Put(ctx, c.NewKey(TEST_KIND), &testEntity{Random: 1})
+		require.NoError(t, err)
+	}
+	defer func() {
+		_, err := c2.DeleteAll(ctx, TEST_KIND, true)
+		require.NoError(t, err)
+	}()
+
+	n, err := c1.DeleteAll(ctx, TEST_KIND, true)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+
+	count, err := c2.Count(ctx, c2.NewQuery(TEST_KIND).KeysOnly())
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+}
@@ -73,7 +73,20 @@ const (
 	SILENCE_ACTIVE_PARENT_AM  Kind = "SilenceActiveParentAm"
 	SILENCE_AM                Kind = "SilenceAm"
 	REMINDER_AM               Kind = "ReminderAm"
+	REMINDER_PREFERENCES_AM   Kind = "ReminderPreferencesAm"
+	ESCALATION_AM             Kind = "EscalationAm"
+	REMINDER_DELIVERY_AM      Kind = "ReminderDeliveryAm"
 	AUDITLOG_AM               Kind = "AuditLogAm"
+
+	// Gold
+	GOLDPUSHK_DEPLOYMENT Kind = "GoldpushkDeployment"
+
+	// Notifier
+	NOTIFIER_OUTBOX           Kind = "NotifierOutbox"
+	NOTIFIER_DELIVERY_FAILURE Kind = "NotifierDeliveryFailure"
+
+	// Migrations, see RunMigration.
+	MIGRATION_STATE Kind = "MigrationState"
 )
 
 // Namespaces that are used in production, and thus might be backed up.
@@ -93,6 +106,9 @@ const (
 
 	// AlertManager
 	ALERT_MANAGER_NS = "alert-manager"
+
+	// Gold
+	GOLDPUSHK_NS = "goldpushk"
 )
 
 var (
@@ -108,7 +124,8 @@ var (
 		ANDROID_COMPILE_NS:   {COMPILE_TASK, ANDROID_COMPILE_INSTANCES},
 		LEASING_SERVER_NS:    {TASK},
 		CT_NS:                {CAPTURE_SKPS_TASKS, CHROMIUM_ANALYSIS_TASKS, CHROMIUM_BUILD_TASKS, CHROMIUM_PERF_TASKS, LUA_SCRIPT_TASKS, METRICS_ANALYSIS_TASKS, PIXEL_DIFF_TASKS, RECREATE_PAGESETS_TASKS, RECREATE_WEBPAGE_ARCHIVES_TASKS, CLUSTER_TELEMETRY_IDS},
-		ALERT_MANAGER_NS:     {INCIDENT_AM, INCIDENT_ACTIVE_PARENT_AM, SILENCE_AM, SILENCE_ACTIVE_PARENT_AM, REMINDER_AM, AUDITLOG_AM},
+		ALERT_MANAGER_NS:     {INCIDENT_AM, INCIDENT_ACTIVE_PARENT_AM, SILENCE_AM, SILENCE_ACTIVE_PARENT_AM, REMINDER_AM, REMINDER_PREFERENCES_AM, ESCALATION_AM, REMINDER_DELIVERY_AM, AUDITLOG_AM},
+		GOLDPUSHK_NS:         {GOLDPUSHK_DEPLOYMENT},
 	}
 )
 
@@ -130,12 +147,12 @@ func InitWithOpt(project string, ns string, opts ...option.ClientOption) error {
 		return skerr.Fmt("Datastore namespace cannot be empty.")
 	}
 
-	Namespace = ns
-	var err error
-	DS, err = datastore.NewClient(context.Background(), project, opts...)
+	c, err := NewClient(context.Background(), project, ns, opts...)
 	if err != nil {
-		return skerr.Fmt("Failed to initialize Cloud Datastore: %s", err)
+		return err
 	}
+	DS = c.Client
+	Namespace = c.Namespace()
 	return nil
 }
 
@@ -173,7 +190,12 @@ func InitForTesting(project string, ns string, kinds ...Kind) error {
 //
 // Note: This is a very expensive operation if there are many entities of this
 // kind and should be run as an 'offline' task.
-func DeleteAll(client *datastore.Client, kind Kind, wait bool) (int, error) {
+func DeleteAll(ctx context.Context, client *datastore.Client, kind Kind, wait bool) (int, error) {
+	return deleteAll(ctx, client, Namespace, kind, wait)
+}
+
+// deleteAll implements DeleteAll for the given namespace.
+func deleteAll(ctx context.Context, client *datastore.Client, ns string, kind Kind, wait bool) (int, error) {
 	const (
 		// keyPageSize is the number of keys we retrieve at once
 		keyPageSize = 10000
@@ -182,30 +204,29 @@ func DeleteAll(client *datastore.Client, kind Kind, wait bool) (int, error) {
 		deletePageSize = 500
 	)
 
-	sliceIter := newKeySliceIterator(client, kind, keyPageSize)
-	slice, done, err := sliceIter.next()
-	ctx := context.TODO()
+	sliceIter := newKeySliceIterator(client, ns, kind, keyPageSize)
+	slice, done, err := sliceIter.next(ctx)
 	keySlices := [][]*datastore.Key{}
 
 	totalKeyCount := 0
 	for !done && (err == nil) {
 		keySlices = append(keySlices, slice)
 		totalKeyCount += len(slice)
-		slice, done, err = sliceIter.next()
+		slice, done, err = sliceIter.next(ctx)
 		sklog.Infof("Loaded %s %d keys %d", kind, len(slice), totalKeyCount)
 	}
 	if err != nil {
 		return 0, err
 	}
 
-	// Delete all slices in parallel.
-	var egroup errgroup.Group
+	// Delete all slices in parallel. The first error cancels the others.
+	egroup, egroupCtx := errgroup.WithContext(ctx)
 	for _, slice := range keySlices {
 		func(slice []*datastore.Key) {
 			egroup.Go(func() error {
 				for len(slice) > 0 {
 					targetSlice := slice[:util.MinInt(deletePageSize, len(slice))]
-					if err := client.DeleteMulti(ctx, targetSlice); err != nil {
+					if err := client.DeleteMulti(egroupCtx, targetSlice); err != nil {
 						return err
 					}
 					slice = slice[len(targetSlice):]
@@ -222,12 +243,16 @@ func DeleteAll(client *datastore.Client, kind Kind, wait bool) (int, error) {
 	if wait {
 		found := 1
 		for found > 0 {
-			if found, err = client.Count(context.TODO(), NewQuery(kind)); err != nil {
+			if found, err = client.Count(ctx, newQuery(ns, kind)); err != nil {
 				return 0, err
 			}
 			// Sleep proportional to the number of found keys, but no more than 10 seconds.
 			sleepTimeMs := util.MinInt64(int64(found)*10, 10000)
-			time.Sleep(time.Duration(sleepTimeMs) * time.Millisecond)
+			select {
+			case <-ctx.Done():
+				return 0, skerr.Wrapf(ctx.Err(), "waiting for %s entities to be deleted", kind)
+			case <-time.After(time.Duration(sleepTimeMs) * time.Millisecond):
+			}
 		}
 	}
 	return totalKeyCount, nil
@@ -235,10 +260,7 @@ func DeleteAll(client *datastore.Client, kind Kind, wait bool) (int, error) {
 
 // NewKey creates a new indeterminate key of the given kind.
 func NewKey(kind Kind) *datastore.Key {
-	return &datastore.Key{
-		Kind:      string(kind),
-		Namespace: Namespace,
-	}
+	return newKey(Namespace, kind)
 }
 
 func NewKeyWithParent(kind Kind, parent *datastore.Key) *datastore.Key {
@@ -249,7 +271,21 @@ func NewKeyWithParent(kind Kind, parent *datastore.Key) *datastore.Key {
 
 // NewQuery creates a new query of the given kind with the right namespace.
 func NewQuery(kind Kind) *datastore.Query {
-	return datastore.NewQuery(string(kind)).Namespace(Namespace)
+	return newQuery(Namespace, kind)
+}
+
+// newKey creates a new indeterminate key of the given kind in the given
+// namespace.
+func newKey(ns string, kind Kind) *datastore.Key {
+	return &datastore.Key{
+		Kind:      string(kind),
+		Namespace: ns,
+	}
+}
+
+// newQuery creates a new query of the given kind in the given namespace.
+func newQuery(ns string, kind Kind) *datastore.Query {
+	return datastore.NewQuery(string(kind)).Namespace(ns)
 }
 
 // IterKeysItem is the item returned by the IterKeys function via a channel.
@@ -259,9 +295,17 @@ type IterKeysItem struct {
 }
 
 // IterKeys iterates all keys of the specified kind in slices of pageSize length.
-func IterKeys(client *datastore.Client, kind Kind, pageSize int) (<-chan *IterKeysItem, error) {
-	sliceIter := newKeySliceIterator(client, kind, pageSize)
-	keySlice, done, err := sliceIter.next()
+// If retrieving a slice fails, the error is sent as the last item. The channel
+// is closed once all keys have been sent or the context is cancelled, in which
+// case the remaining keys are not retrieved.
+func IterKeys(ctx context.Context, client *datastore.Client, kind Kind, pageSize int) (<-chan *IterKeysItem, error) {
+	return iterKeys(ctx, client, Namespace, kind, pageSize)
+}
+
+// iterKeys implements IterKeys for the given namespace.
+func iterKeys(ctx context.Context, client *datastore.Client, ns string, kind Kind, pageSize int) (<-chan *IterKeysItem, error) {
+	sliceIter := newKeySliceIterator(client, ns, kind, pageSize)
+	keySlice, done, err := sliceIter.next(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -270,15 +314,17 @@ func IterKeys(client *datastore.Client, kind Kind, pageSize int) (<-chan *IterKe
 	go func() {
 		defer close(retCh)
 
-		keyCount := 0
-		for !done {
-			keyCount += len(keySlice)
-			retCh <- &IterKeysItem{
-				Keys: keySlice,
-				Err:  err,
+		for !done || err != nil {
+			select {
+			case retCh <- &IterKeysItem{Keys: keySlice, Err: err}:
+			case <-ctx.Done():
+				return
+			}
+			if err != nil {
+				return
 			}
 			// Get the next slice of keys.
-			keySlice, done, err = sliceIter.next()
+			keySlice, done, err = sliceIter.next(ctx)
 		}
 	}()
 	return retCh, nil
@@ -288,6 +334,7 @@ func IterKeys(client *datastore.Client, kind Kind, pageSize int) (<-chan *IterKe
 // in slices of fixed size.
 type keySliceIterator struct {
 	client    *datastore.Client
+	namespace string
 	kind      Kind
 	pageSize  int
 	orderedBy []string
@@ -295,12 +342,14 @@ type keySliceIterator struct {
 	done      bool
 }
 
-// newKeySliceIterator returns a new keySliceIterator instance for the given kind.
+// newKeySliceIterator returns a new keySliceIterator instance for the given
+// namespace and kind.
 // 'pageSize' defines the size of slices that are returned by the next method.
 // 'orderedBy' allows to sort the slices with the same operators as datastore.Query.
-func newKeySliceIterator(client *datastore.Client, kind Kind, pageSize int, orderedBy ...string) *keySliceIterator {
+func newKeySliceIterator(client *datastore.Client, ns string, kind Kind, pageSize int, orderedBy ...string) *keySliceIterator {
 	return &keySliceIterator{
 		client:    client,
+		namespace: ns,
 		kind:      kind,
 		pageSize:  pageSize,
 		orderedBy: orderedBy,
@@ -310,13 +359,16 @@ func newKeySliceIterator(client *datastore.Client, kind Kind, pageSize int, orde
 
 // next returns the next slice of keys of the iterator. If the returned bool is
 // true no more keys are available.
-func (k *keySliceIterator) next() ([]*datastore.Key, bool, error) {
+func (k *keySliceIterator) next(ctx context.Context) ([]*datastore.Key, bool, error) {
 	// Once we have reached the end, don't run the query again.
 	if k.done {
 		return []*datastore.Key{}, true, nil
 	}
+	if err := ctx.Err(); err != nil {
+		return nil, false, skerr.Wrapf(err, "Stopped retrieving keys")
+	}
 
-	query := NewQuery(k.kind).KeysOnly().Limit(k.pageSize)
+	query := newQuery(k.namespace, k.kind).KeysOnly().Limit(k.pageSize)
 	for _, ob := range k.orderedBy {
 		query = query.Order(ob)
 	}
@@ -329,7 +381,7 @@ func (k *keySliceIterator) next() ([]*datastore.Key, bool, error) {
 		query = query.Start(cursor)
 	}
 
-	it := k.client.Run(context.TODO(), query)
+	it := k.client.Run(ctx, query)
 	var err error
 	var key *datastore.Key
 	retKeys := make([]*datastore.Key, 0, k.pageSize)
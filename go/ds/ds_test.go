@@ -32,10 +32,11 @@ func TestDeleteAll(t *testing.T) {
 	// Ignore the cleanup-call returned by addRandEntities since we are
 	// calling DeleteAll in this function anyway.
 	_, _ = addRandEntities(t, client, nEntries, maxID)
-	_, err := DeleteAll(client, TEST_KIND, true)
+	ctx := context.Background()
+	_, err := DeleteAll(ctx, client, TEST_KIND, true)
 	require.NoError(t, err)
 
-	count, err := client.Count(context.TODO(), NewQuery(TEST_KIND))
+	count, err := client.Count(ctx, NewQuery(TEST_KIND))
 	require.NoError(t, err)
 	require.Equal(t, 0, count)
 }
@@ -51,9 +52,9 @@ func TestIterKeys(t *testing.T) {
 	defer cleanup()
 
 	// Iterate over the type and collect the instances
-	iterCh, err := IterKeys(client, TEST_KIND, 10)
+	ctx := context.Background()
+	iterCh, err := IterKeys(ctx, client, TEST_KIND, 10)
 	require.NoError(t, err)
-	ctx := context.TODO()
 	var found []*testEntity
 
 	for item := range iterCh {
@@ -66,18 +67,56 @@ func TestIterKeys(t *testing.T) {
 	require.Equal(t, exp, found)
 }
 
+func TestIterKeys_ContextCancelled_StopsIterating(t *testing.T) {
+	gcp_emulator.RequireDatastore(t)
+
+	require.NoError(t, InitForTesting("test-project", "test-namespace"))
+	client := DS
+	_, cleanup := addRandEntities(t, client, 50, 50)
+	defer cleanup()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	iterCh, err := IterKeys(ctx, client, TEST_KIND, 10)
+	require.NoError(t, err)
+	item := <-iterCh
+	require.NoError(t, item.Err)
+	require.Len(t, item.Keys, 10)
+	cancel()
+
+	// The channel is closed without retrieving all of the keys.
+	count := len(item.Keys)
+	for item := range iterCh {
+		count += len(item.Keys)
+	}
+	require.Less(t, count, 50)
+}
+
+func TestDeleteAll_ContextCancelled_ReturnsError(t *testing.T) {
+	gcp_emulator.RequireDatastore(t)
+
+	require.NoError(t, InitForTesting("test-project", "test-namespace"))
+	client := DS
+	_, cleanup := addRandEntities(t, client, 10, 10)
+	defer cleanup()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := DeleteAll(ctx, client, TEST_KIND, true)
+	require.ErrorIs(t, err, context.Canceled)
+}
+
 func addRandEntities(t *testing.T, client *datastore.Client, nEntries int, maxID int64) ([]*testEntity, func()) {
-	_, err := DeleteAll(client, TEST_KIND, true)
+	ctx := context.Background()
+	_, err := DeleteAll(ctx, client, TEST_KIND, true)
 	require.NoError(t, err)
 
 	cleanup := func() {
-		_, err := DeleteAll(client, TEST_KIND, true)
+		_, err := DeleteAll(ctx, client, TEST_KIND, true)
 		require.NoError(t, err)
 	}
 
 	// Create a test type and fill it with random values
 	exp := make([]*testEntity, 0, nEntries)
-	ctx := context.TODO()
 	for i := 0; i < nEntries; i++ {
 		newEntry := &testEntity{
 			Random:   rand.Int63(),
@@ -0,0 +1,161 @@
+package ds
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"cloud.google.com/go/datastore"
+	"go.skia.org/infra/go/metrics2"
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/go/sklog"
+	"go.skia.org/infra/go/util"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// defaultGCBatchSize is the default number of entities deleted at once by
+	// RunGC.
+	defaultGCBatchSize = MAX_MODIFICATIONS
+)
+
+// GCPolicy describes which entities of an ephemeral Kind are garbage.
+type GCPolicy struct {
+	// Kind is the Kind of the entities to garbage collect.
+	Kind Kind
+	// TimestampField is the name of an indexed time.Time property of the
+	// entities, e.g. "Created".
+	TimestampField string
+	// Retention is how long entities are kept after their timestamp.
+	Retention time.Duration
+}
+
+var (
+	gcPoliciesMutex sync.Mutex
+	gcPolicies      = map[Kind]*GCPolicy{}
+)
+
+// RegisterGC makes RunGC delete the entities of the given Kind whose
+// timestampField is older than retention. It panics if the policy is invalid or
+// the Kind is already registered, so it should be called from init functions.
+func RegisterGC(kind Kind, timestampField string, retention time.Duration) {
+	if kind == "" || timestampField == "" || retention <= 0 {
+		panic("GC policies require a Kind, a timestamp field and a positive retention.")
+	}
+	gcPoliciesMutex.Lock()
+	defer gcPoliciesMutex.Unlock()
+	if _, ok := gcPolicies[kind]; ok {
+		panic("GC policy for " + string(kind) + " is already registered.")
+	}
+	gcPolicies[kind] = &GCPolicy{
+		Kind:           kind,
+		TimestampField: timestampField,
+		Retention:      retention,
+	}
+}
+
+// getGCPolicies returns the registered GC policies, sorted by Kind.
+func getGCPolicies() []*GCPolicy {
+	gcPoliciesMutex.Lock()
+	defer gcPoliciesMutex.Unlock()
+	ret := make([]*GCPolicy, 0, len(gcPolicies))
+	for _, p := range gcPolicies {
+		ret = append(ret, p)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].Kind < ret[j].Kind
+	})
+	return ret
+}
+
+// GCOptions configures RunGC and StartGC.
+type GCOptions struct {
+	// BatchSize is the number of entities deleted at once. Defaults to
+	// MAX_MODIFICATIONS, which it must not exceed.
+	BatchSize int
+	// BatchesPerSecond limits the rate of deletions across all Kinds, to
+	// leave capacity for the applications using them. Unlimited if zero.
+	BatchesPerSecond float64
+}
+
+// RunGC deletes the expired entities of all the registered Kinds in Namespace
+// and returns the number of deleted entities per Kind. The number of deleted
+// entities is also reported via the datastore_gc_deleted metric.
+func RunGC(ctx context.Context, client *datastore.Client, opts GCOptions) (map[Kind]int, error) {
+	batchSize := opts.BatchSize
+	if batchSize == 0 {
+		batchSize = defaultGCBatchSize
+	}
+	if batchSize < 0 || batchSize > MAX_MODIFICATIONS {
+		return nil, skerr.Fmt("Batch size must be in the range [1, %d], got %d", MAX_MODIFICATIONS, batchSize)
+	}
+	limit := rate.Inf
+	if opts.BatchesPerSecond > 0 {
+		limit = rate.Limit(opts.BatchesPerSecond)
+	}
+	rl := rate.NewLimiter(limit, 1)
+
+	now := time.Now()
+	ret := map[Kind]int{}
+	for _, p := range getGCPolicies() {
+		n, err := collectGarbage(ctx, client, p, now, batchSize, rl)
+		ret[p.Kind] = n
+		if err != nil {
+			return ret, skerr.Wrapf(err, "Failed to garbage collect %s after %d entities", p.Kind, n)
+		}
+		if n > 0 {
+			sklog.Infof("Garbage collected %d %s entities.", n, p.Kind)
+		}
+	}
+	return ret, nil
+}
+
+// collectGarbage deletes the entities of the policy which expired before now,
+// in batches, and returns the number of deleted entities.
+func collectGarbage(ctx context.Context, client *datastore.Client, p *GCPolicy, now time.Time, batchSize int, rl *rate.Limiter) (int, error) {
+	deleted := metrics2.GetCounter("datastore_gc_deleted", map[string]string{
+		"kind": string(p.Kind),
+	})
+	query := NewQuery(p.Kind).FilterField(p.TimestampField, "<", now.Add(-p.Retention)).KeysOnly().Limit(batchSize)
+	total := 0
+	for {
+		if err := rl.Wait(ctx); err != nil {
+			return total, skerr.Wrap(err)
+		}
+		var keys []*datastore.Key
+		if err := retry(ctx, func() error {
+			var err error
+			keys, err = client.GetAll(ctx, query, nil)
+			return err
+		}); err != nil {
+			return total, skerr.Wrapf(err, "Failed to query expired entities")
+		}
+		if len(keys) == 0 {
+			return total, nil
+		}
+		if err := retry(ctx, func() error {
+			return client.DeleteMulti(ctx, keys)
+		}); err != nil {
+			return total, skerr.Wrapf(err, "Failed to delete %d expired entities", len(keys))
+		}
+		total += len(keys)
+		deleted.Inc(int64(len(keys)))
+		if len(keys) < batchSize {
+			return total, nil
+		}
+	}
+}
+
+// StartGC runs RunGC every interval until the context is cancelled. Errors are
+// logged, and the datastore_gc liveness is updated after every successful run.
+func StartGC(ctx context.Context, client *datastore.Client, interval time.Duration, opts GCOptions) {
+	liveness := metrics2.NewLiveness("datastore_gc")
+	go util.RepeatCtx(ctx, interval, func(ctx context.Context) {
+		if _, err := RunGC(ctx, client, opts); err != nil {
+			sklog.Errorf("Datastore GC failed: %s", err)
+			return
+		}
+		liveness.Reset()
+	})
+}
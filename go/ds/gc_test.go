@@ -0,0 +1,84 @@
+package ds
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.skia.org/infra/go/emulators/gcp_emulator"
+	"go.skia.org/infra/go/metrics2"
+)
+
+// gcTestEntity is an ephemeral entity of TEST_KIND.
+type gcTestEntity struct {
+	Created time.Time
+}
+
+// registerTestGC registers a GC policy for TEST_KIND for the duration of the
+// test.
+func registerTestGC(t *testing.T, retention time.Duration) {
+	RegisterGC(TEST_KIND, "Created", retention)
+	t.Cleanup(func() {
+		gcPoliciesMutex.Lock()
+		defer gcPoliciesMutex.Unlock()
+		delete(gcPolicies, TEST_KIND)
+	})
+}
+
+func TestRegisterGC_InvalidOrDuplicate_Panics(t *testing.T) {
+	assert.Panics(t, func() { RegisterGC(TEST_KIND, "", time.Hour) })
+	assert.Panics(t, func() { RegisterGC(TEST_KIND, "Created", 0) })
+
+	registerTestGC(t, time.Hour)
+	assert.Panics(t, func() { RegisterGC(TEST_KIND, "Created", time.Hour) })
+	require.Len(t, getGCPolicies(), 1)
+	assert.Equal(t, time.Hour, getGCPolicies()[0].Retention)
+}
+
+func TestRunGC_InvalidBatchSize_Error(t *testing.T) {
+	_, err := RunGC(context.Background(), nil, GCOptions{BatchSize: MAX_MODIFICATIONS + 1})
+	require.Error(t, err)
+}
+
+func TestRunGC_DeletesExpiredEntities(t *testing.T) {
+	gcp_emulator.RequireDatastore(t)
+
+	require.NoError(t, InitForTesting("test-project", "test-namespace"))
+	ctx := context.Background()
+	_, err := DeleteAll(ctx, DS, TEST_KIND, true)
+	require.NoError(t, err)
+	defer func() {
+		_, err := DeleteAll(ctx, DS, TEST_KIND, true)
+		require.NoError(t, err)
+	}()
+	registerTestGC(t, time.Hour)
+
+	now := time.Now()
+	const expired, live = 7, 3
+	for i := 0; i < expired+live; i++ {
+		created := now.Add(-2 * time.Hour)
+		if i >= expired {
+			created = now
+		}
+		_, err := DS.Put(ctx, NewKey(TEST_KIND), &gcTestEntity{Created: created})
+		require.NoError(t, err)
+	}
+	wait(t, DS, TEST_KIND, expired+live)
+
+	deletedBefore := metrics2.GetCounter("datastore_gc_deleted", map[string]string{"kind": string(TEST_KIND)}).Get()
+	// Use a small batch size to delete in multiple batches.
+	deleted, err := RunGC(ctx, DS, GCOptions{BatchSize: 3})
+	require.NoError(t, err)
+	assert.Equal(t, map[Kind]int{TEST_KIND: expired}, deleted)
+	assert.Equal(t, int64(expired), metrics2.GetCounter("datastore_gc_deleted", map[string]string{"kind": string(TEST_KIND)}).Get()-deletedBefore)
+
+	wait(t, DS, TEST_KIND, live)
+	var remaining []*gcTestEntity
+	_, err = DS.GetAll(ctx, NewQuery(TEST_KIND), &remaining)
+	require.NoError(t, err)
+	for _, e := range remaining {
+		assert.True(t, e.Created.After(now.Add(-time.Hour)))
+	}
+}
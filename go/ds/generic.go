@@ -0,0 +1,153 @@
+package ds
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"cloud.google.com/go/datastore"
+	"github.com/cenkalti/backoff"
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/go/sklog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	// retryInitialInterval and retryMaxElapsedTime configure the exponential
+	// backoff of Get, PutWithRetry and QueryAll. They are variables so that
+	// tests can shorten them.
+	retryInitialInterval = 100 * time.Millisecond
+	retryMaxElapsedTime  = 30 * time.Second
+
+	// transientCodes are the gRPC codes of errors which may succeed if retried.
+	transientCodes = []codes.Code{
+		codes.Aborted,
+		codes.DeadlineExceeded,
+		codes.Internal,
+		codes.ResourceExhausted,
+		codes.Unavailable,
+	}
+)
+
+// Get loads the entity with the given key into a new T. Keys without a
+// namespace are looked up in Namespace. If there is no such entity, Get
+// returns (nil, nil). Transient RPC errors are retried with backoff.
+func Get[T any](ctx context.Context, client *datastore.Client, key *datastore.Key) (*T, error) {
+	key = withNamespace(key)
+	var found bool
+	ret := new(T)
+	err := retry(ctx, func() error {
+		err := client.Get(ctx, key, ret)
+		if errors.Is(err, datastore.ErrNoSuchEntity) {
+			return nil
+		}
+		found = err == nil
+		return err
+	})
+	if err != nil {
+		return nil, skerr.Wrapf(err, "getting %s", key)
+	}
+	if !found {
+		return nil, nil
+	}
+	return ret, nil
+}
+
+// PutWithRetry stores the entity with the given key and returns the complete
+// key, which has an ID assigned if the given key was incomplete. Keys without a
+// namespace are stored in Namespace. Transient RPC errors are retried with
+// backoff, so entities are written at least once.
+func PutWithRetry[T any](ctx context.Context, client *datastore.Client, key *datastore.Key, entity *T) (*datastore.Key, error) {
+	key = withNamespace(key)
+	var ret *datastore.Key
+	err := retry(ctx, func() error {
+		var err error
+		ret, err = client.Put(ctx, key, entity)
+		return err
+	})
+	if err != nil {
+		return nil, skerr.Wrapf(err, "putting %s", key)
+	}
+	return ret, nil
+}
+
+// QueryAll runs the query in Namespace and returns all of the matching entities
+// along with their keys. Transient RPC errors are retried with backoff, in which
+// case the query is run again from the start.
+//
+// Unlike Get and PutWithRetry, which only fill in a missing namespace, QueryAll
+// always overrides any namespace set on the query, since datastore.Query does
+// not expose it. Use client.GetAll directly to query a different namespace.
+func QueryAll[T any](ctx context.Context, client *datastore.Client, q *datastore.Query) ([]*T, []*datastore.Key, error) {
+	q = q.Namespace(Namespace)
+	var entities []*T
+	var keys []*datastore.Key
+	err := retry(ctx, func() error {
+		entities = nil
+		var err error
+		keys, err = client.GetAll(ctx, q, &entities)
+		return err
+	})
+	if err != nil {
+		return nil, nil, skerr.Wrapf(err, "running query")
+	}
+	return entities, keys, nil
+}
+
+// withNamespace returns a copy of the key in which the key and its ancestors
+// are in Namespace if they have no namespace.
+func withNamespace(key *datastore.Key) *datastore.Key {
+	if key == nil {
+		return nil
+	}
+	ret := *key
+	if ret.Namespace == "" {
+		ret.Namespace = Namespace
+	}
+	ret.Parent = withNamespace(key.Parent)
+	return &ret
+}
+
+// isTransient returns true if the error is an RPC error which may succeed if
+// retried.
+func isTransient(err error) bool {
+	st, ok := status.FromError(skerr.Unwrap(err))
+	if !ok {
+		return false
+	}
+	for _, code := range transientCodes {
+		if st.Code() == code {
+			return true
+		}
+	}
+	return false
+}
+
+// retry calls fn until it succeeds or returns an error which isn't transient,
+// backing off exponentially in between, and returns the last error.
+func retry(ctx context.Context, fn func() error) error {
+	exp := &backoff.ExponentialBackOff{
+		InitialInterval:     retryInitialInterval,
+		RandomizationFactor: 0.5,
+		Multiplier:          2,
+		MaxInterval:         retryMaxElapsedTime / 4,
+		MaxElapsedTime:      retryMaxElapsedTime,
+		Clock:               backoff.SystemClock,
+	}
+	o := func() error {
+		// If the context is bad, retrying won't help, so just end.
+		if err := ctx.Err(); err != nil {
+			return backoff.Permanent(err)
+		}
+		err := fn()
+		if err != nil && !isTransient(err) {
+			return backoff.Permanent(err)
+		}
+		return err
+	}
+	notify := func(err error, wait time.Duration) {
+		sklog.Warningf("Transient datastore error, retrying in %s: %s", wait, err)
+	}
+	return backoff.RetryNotify(o, exp, notify)
+}
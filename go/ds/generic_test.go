@@ -0,0 +1,155 @@
+package ds
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/datastore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.skia.org/infra/go/emulators/gcp_emulator"
+	"go.skia.org/infra/go/skerr"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func setFastRetries(t *testing.T) {
+	initial, maxElapsed := retryInitialInterval, retryMaxElapsedTime
+	retryInitialInterval, retryMaxElapsedTime = time.Millisecond, time.Second
+	t.Cleanup(func() {
+		retryInitialInterval, retryMaxElapsedTime = initial, maxElapsed
+	})
+}
+
+func TestWithNamespace_SetsMissingNamespaces(t *testing.T) {
+	Namespace = "test-namespace"
+	parent := &datastore.Key{Kind: "Parent", Name: "p"}
+	key := &datastore.Key{Kind: "Child", ID: 1, Parent: parent}
+
+	actual := withNamespace(key)
+	assert.Equal(t, "test-namespace", actual.Namespace)
+	assert.Equal(t, "test-namespace", actual.Parent.Namespace)
+	// The given key is not modified.
+	assert.Empty(t, key.Namespace)
+	assert.Empty(t, parent.Namespace)
+
+	key.Namespace = "other-namespace"
+	assert.Equal(t, "other-namespace", withNamespace(key).Namespace)
+}
+
+func TestIsTransient(t *testing.T) {
+	assert.True(t, isTransient(status.Error(codes.Unavailable, "unavailable")))
+	assert.True(t, isTransient(skerr.Wrap(status.Error(codes.Aborted, "contention"))))
+	assert.False(t, isTransient(status.Error(codes.InvalidArgument, "bad request")))
+	assert.False(t, isTransient(datastore.ErrNoSuchEntity))
+	assert.False(t, isTransient(errors.New("some error")))
+}
+
+func TestRetry_TransientErrors_Retried(t *testing.T) {
+	setFastRetries(t)
+	calls := 0
+	err := retry(context.Background(), func() error {
+		calls++
+		if calls < 3 {
+			return status.Error(codes.Unavailable, "unavailable")
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 3, calls)
+}
+
+func TestRetry_PermanentError_NotRetried(t *testing.T) {
+	setFastRetries(t)
+	calls := 0
+	expected := status.Error(codes.InvalidArgument, "bad request")
+	err := retry(context.Background(), func() error {
+		calls++
+		return expected
+	})
+	assert.Equal(t, expected, err)
+	assert.Equal(t, 1, calls)
+}
+
+func TestRetry_ContextCancelled_StopsRetrying(t *testing.T) {
+	setFastRetries(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	err := retry(ctx, func() error {
+		calls++
+		cancel()
+		return status.Error(codes.Unavailable, "unavailable")
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 1, calls)
+}
+
+func TestGetPutQueryAll_RoundTrip(t *testing.T) {
+	gcp_emulator.RequireDatastore(t)
+
+	require.NoError(t, InitForTesting("test-project", "test-namespace"))
+	client := DS
+	ctx := context.Background()
+	_, err := DeleteAll(ctx, client, TEST_KIND, true)
+	require.NoError(t, err)
+	defer func() {
+		_, err := DeleteAll(ctx, client, TEST_KIND, true)
+		require.NoError(t, err)
+	}()
+
+	// The key has no namespace, PutWithRetry puts it in Namespace.
+	key, err := PutWithRetry(ctx, client, &datastore.Key{Kind: string(TEST_KIND)}, &testEntity{Random: 1, Sortable: 2})
+	require.NoError(t, err)
+	assert.Equal(t, "test-namespace", key.Namespace)
+	assert.NotZero(t, key.ID)
+
+	actual, err := Get[testEntity](ctx, client, key)
+	require.NoError(t, err)
+	require.NotNil(t, actual)
+	assert.Equal(t, int64(1), actual.Random)
+	assert.Equal(t, int64(2), actual.Sortable)
+
+	missing, err := Get[testEntity](ctx, client, datastore.IDKey(string(TEST_KIND), key.ID+1, nil))
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+
+	wait(t, client, TEST_KIND, 1)
+	entities, keys, err := QueryAll[testEntity](ctx, client, datastore.NewQuery(string(TEST_KIND)))
+	require.NoError(t, err)
+	require.Len(t, entities, 1)
+	assert.Equal(t, []*datastore.Key{key}, keys)
+	assert.Equal(t, key, entities[0].Key)
+}
+
+func TestQueryAll_QueryWithNamespace_NamespaceOverridden(t *testing.T) {
+	gcp_emulator.RequireDatastore(t)
+
+	require.NoError(t, InitForTesting("test-project", "test-namespace"))
+	client := DS
+	ctx := context.Background()
+	otherKey := &datastore.Key{Kind: string(TEST_KIND), Namespace: "other-namespace"}
+	cleanup := func() {
+		_, err := DeleteAll(ctx, client, TEST_KIND, true)
+		require.NoError(t, err)
+		keys, err := client.GetAll(ctx, datastore.NewQuery(string(TEST_KIND)).Namespace(otherKey.Namespace).KeysOnly(), nil)
+		require.NoError(t, err)
+		require.NoError(t, client.DeleteMulti(ctx, keys))
+	}
+	cleanup()
+	defer cleanup()
+
+	key, err := PutWithRetry(ctx, client, &datastore.Key{Kind: string(TEST_KIND)}, &testEntity{Random: 1})
+	require.NoError(t, err)
+	_, err = PutWithRetry(ctx, client, otherKey, &testEntity{Random: 2})
+	require.NoError(t, err)
+
+	// The query asks for "other-namespace", but QueryAll runs it in Namespace.
+	wait(t, client, TEST_KIND, 1)
+	entities, keys, err := QueryAll[testEntity](ctx, client, datastore.NewQuery(string(TEST_KIND)).Namespace(otherKey.Namespace))
+	require.NoError(t, err)
+	require.Len(t, entities, 1)
+	assert.Equal(t, int64(1), entities[0].Random)
+	assert.Equal(t, []*datastore.Key{key}, keys)
+}
@@ -0,0 +1,210 @@
+package ds
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/datastore"
+	"go.opencensus.io/trace"
+	"go.skia.org/infra/go/metrics2"
+)
+
+// kindUnknown is the Kind reported for operations whose Kind can't be
+// determined, e.g. queries which return no keys.
+const kindUnknown = "unknown"
+
+// InstrumentedClient wraps a *datastore.Client and records the latency, error
+// count and number of entities of the wrapped operations per Kind via metrics2,
+// along with an opencensus span per operation, so that we can see which
+// services use Datastore and how. Operations which aren't wrapped, e.g. Run,
+// are passed through to the embedded client without instrumentation.
+type InstrumentedClient struct {
+	*datastore.Client
+	metricTags map[string]string
+}
+
+// NewInstrumentedClient returns an InstrumentedClient which wraps the given
+// client. The service name is added as a tag to all of the metrics.
+func NewInstrumentedClient(client *datastore.Client, service string) *InstrumentedClient {
+	return &InstrumentedClient{
+		Client: client,
+		metricTags: map[string]string{
+			"service": service,
+		},
+	}
+}
+
+// instrumentedOp records a single operation.
+type instrumentedOp struct {
+	metricTags map[string]string
+	op         string
+	// kind may be updated before finish is called, e.g. once the keys
+	// returned by a query are known.
+	kind   string
+	caller string
+	span   *trace.Span
+	start  time.Time
+}
+
+// startOp starts recording an operation on the given Kind, and returns the
+// context of its span. The caller's caller is recorded as the origin of the
+// operation.
+func (c *InstrumentedClient) startOp(ctx context.Context, op, kind string) (context.Context, *instrumentedOp) {
+	ctx, span := trace.StartSpan(ctx, "datastore."+op)
+	return ctx, &instrumentedOp{
+		metricTags: c.metricTags,
+		op:         op,
+		kind:       kind,
+		caller:     callerFuncName(3),
+		span:       span,
+		start:      time.Now(),
+	}
+}
+
+// finish records the latency, number of entities and error of the operation,
+// and ends its span.
+func (o *instrumentedOp) finish(entities int, err error) {
+	tags := map[string]string{
+		"op":   o.op,
+		"kind": o.kind,
+	}
+	metrics2.GetFloat64SummaryMetric("datastore_ops_latency_ns", o.metricTags, tags).Observe(float64(time.Since(o.start)))
+	metrics2.GetCounter("datastore_ops_count", o.metricTags, tags, map[string]string{
+		"caller": o.caller,
+	}).Inc(1)
+	metrics2.GetCounter("datastore_entities_count", o.metricTags, tags).Inc(int64(entities))
+
+	o.span.AddAttributes(
+		trace.StringAttribute("kind", o.kind),
+		trace.StringAttribute("caller", o.caller),
+		trace.Int64Attribute("entities", int64(entities)),
+	)
+	if isError(err) {
+		metrics2.GetCounter("datastore_errors", o.metricTags, tags).Inc(1)
+		o.span.SetStatus(trace.Status{Code: trace.StatusCodeUnknown, Message: err.Error()})
+	}
+	o.span.End()
+}
+
+// isError returns true if the error is an actual failure, rather than missing
+// entities, which are reported via datastore.ErrNoSuchEntity.
+func isError(err error) bool {
+	if err == nil || errors.Is(err, datastore.ErrNoSuchEntity) {
+		return false
+	}
+	var multiErr datastore.MultiError
+	if errors.As(err, &multiErr) {
+		for _, err := range multiErr {
+			if isError(err) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// callerFuncName returns the name of the function skip frames up the stack,
+// without its package path, e.g. "ds.TestFoo" or "(*Store).Get".
+func callerFuncName(skip int) string {
+	pc, _, _, ok := runtime.Caller(skip)
+	if !ok {
+		return "unknown"
+	}
+	fn := runtime.FuncForPC(pc)
+	if fn == nil {
+		return "unknown"
+	}
+	name := fn.Name()
+	return name[strings.LastIndex(name, "/")+1:]
+}
+
+// keyKind returns the Kind of the key.
+func keyKind(key *datastore.Key) string {
+	if key == nil {
+		return kindUnknown
+	}
+	return key.Kind
+}
+
+// keysKind returns the Kind of the keys, which are assumed to be of the same
+// Kind.
+func keysKind(keys []*datastore.Key) string {
+	if len(keys) == 0 {
+		return kindUnknown
+	}
+	return keyKind(keys[0])
+}
+
+// Get wraps datastore.Client.Get.
+func (c *InstrumentedClient) Get(ctx context.Context, key *datastore.Key, dst interface{}) error {
+	ctx, op := c.startOp(ctx, "Get", keyKind(key))
+	err := c.Client.Get(ctx, key, dst)
+	op.finish(1, err)
+	return err
+}
+
+// GetMulti wraps datastore.Client.GetMulti.
+func (c *InstrumentedClient) GetMulti(ctx context.Context, keys []*datastore.Key, dst interface{}) error {
+	ctx, op := c.startOp(ctx, "GetMulti", keysKind(keys))
+	err := c.Client.GetMulti(ctx, keys, dst)
+	op.finish(len(keys), err)
+	return err
+}
+
+// Put wraps datastore.Client.Put.
+func (c *InstrumentedClient) Put(ctx context.Context, key *datastore.Key, src interface{}) (*datastore.Key, error) {
+	ctx, op := c.startOp(ctx, "Put", keyKind(key))
+	ret, err := c.Client.Put(ctx, key, src)
+	op.finish(1, err)
+	return ret, err
+}
+
+// PutMulti wraps datastore.Client.PutMulti.
+func (c *InstrumentedClient) PutMulti(ctx context.Context, keys []*datastore.Key, src interface{}) ([]*datastore.Key, error) {
+	ctx, op := c.startOp(ctx, "PutMulti", keysKind(keys))
+	ret, err := c.Client.PutMulti(ctx, keys, src)
+	op.finish(len(keys), err)
+	return ret, err
+}
+
+// Delete wraps datastore.Client.Delete.
+func (c *InstrumentedClient) Delete(ctx context.Context, key *datastore.Key) error {
+	ctx, op := c.startOp(ctx, "Delete", keyKind(key))
+	err := c.Client.Delete(ctx, key)
+	op.finish(1, err)
+	return err
+}
+
+// DeleteMulti wraps datastore.Client.DeleteMulti.
+func (c *InstrumentedClient) DeleteMulti(ctx context.Context, keys []*datastore.Key) error {
+	ctx, op := c.startOp(ctx, "DeleteMulti", keysKind(keys))
+	err := c.Client.DeleteMulti(ctx, keys)
+	op.finish(len(keys), err)
+	return err
+}
+
+// GetAll wraps datastore.Client.GetAll. The Kind of the query is taken from
+// the returned keys, and the query itself is attached to the span, so that
+// the shapes of expensive queries can be found.
+func (c *InstrumentedClient) GetAll(ctx context.Context, q *datastore.Query, dst interface{}) ([]*datastore.Key, error) {
+	ctx, op := c.startOp(ctx, "GetAll", kindUnknown)
+	op.span.AddAttributes(trace.StringAttribute("query", fmt.Sprintf("%+v", q)))
+	keys, err := c.Client.GetAll(ctx, q, dst)
+	op.kind = keysKind(keys)
+	op.finish(len(keys), err)
+	return keys, err
+}
+
+// Count wraps datastore.Client.Count. The query is attached to the span.
+func (c *InstrumentedClient) Count(ctx context.Context, q *datastore.Query) (int, error) {
+	ctx, op := c.startOp(ctx, "Count", kindUnknown)
+	op.span.AddAttributes(trace.StringAttribute("query", fmt.Sprintf("%+v", q)))
+	n, err := c.Client.Count(ctx, q)
+	op.finish(n, err)
+	return n, err
+}
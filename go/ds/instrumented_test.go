@@ -0,0 +1,87 @@
+package ds
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"cloud.google.com/go/datastore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/trace"
+	"go.skia.org/infra/go/emulators/gcp_emulator"
+	"go.skia.org/infra/go/metrics2"
+	"go.skia.org/infra/go/tracing/tracingtest"
+)
+
+func TestIsError(t *testing.T) {
+	assert.False(t, isError(nil))
+	assert.False(t, isError(datastore.ErrNoSuchEntity))
+	assert.False(t, isError(datastore.MultiError{nil, datastore.ErrNoSuchEntity}))
+	assert.True(t, isError(datastore.MultiError{datastore.ErrNoSuchEntity, errors.New("failed")}))
+	assert.True(t, isError(errors.New("failed")))
+}
+
+func TestKeysKind(t *testing.T) {
+	assert.Equal(t, kindUnknown, keysKind(nil))
+	assert.Equal(t, "Foo", keysKind([]*datastore.Key{datastore.IDKey("Foo", 1, nil)}))
+}
+
+func TestInstrumentedOp_RecordsMetricsAndSpan(t *testing.T) {
+	exporter := &tracingtest.Exporter{}
+	trace.RegisterExporter(exporter)
+	defer trace.UnregisterExporter(exporter)
+	trace.ApplyConfig(trace.Config{DefaultSampler: trace.AlwaysSample()})
+
+	c := NewInstrumentedClient(nil, "instrumented-op-test")
+	tags := map[string]string{"service": "instrumented-op-test", "op": "Get", "kind": "Foo"}
+
+	// Like the methods of InstrumentedClient, so that the test is the caller.
+	get := func() {
+		_, op := c.startOp(context.Background(), "Get", "Foo")
+		op.finish(3, errors.New("failed"))
+	}
+	get()
+
+	assert.Equal(t, int64(3), metrics2.GetCounter("datastore_entities_count", tags).Get())
+	assert.Equal(t, int64(1), metrics2.GetCounter("datastore_errors", tags).Get())
+	assert.Equal(t, int64(1), metrics2.GetCounter("datastore_ops_count", tags, map[string]string{
+		"caller": "ds.TestInstrumentedOp_RecordsMetricsAndSpan",
+	}).Get())
+
+	spans := exporter.SpanData()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "datastore.Get", spans[0].Name)
+	assert.Equal(t, "Foo", spans[0].Attributes["kind"])
+	assert.Equal(t, int64(3), spans[0].Attributes["entities"])
+	assert.Equal(t, "failed", spans[0].Status.Message)
+}
+
+func TestInstrumentedClient_PutGetAll(t *testing.T) {
+	gcp_emulator.RequireDatastore(t)
+
+	require.NoError(t, InitForTesting("test-project", "test-namespace"))
+	ctx := context.Background()
+	_, err := DeleteAll(ctx, DS, TEST_KIND, true)
+	require.NoError(t, err)
+	defer func() {
+		_, err := DeleteAll(ctx, DS, TEST_KIND, true)
+		require.NoError(t, err)
+	}()
+	c := NewInstrumentedClient(DS, "instrumented-client-test")
+	tags := map[string]string{"service": "instrumented-client-test", "kind": string(TEST_KIND)}
+
+	key, err := c.Put(ctx, NewKey(TEST_KIND), &testEntity{Random: 1})
+	require.NoError(t, err)
+	assert.NoError(t, c.Get(ctx, key, &testEntity{}))
+	wait(t, DS, TEST_KIND, 1)
+	keys, err := c.GetAll(ctx, NewQuery(TEST_KIND), &[]*testEntity{})
+	require.NoError(t, err)
+	assert.Len(t, keys, 1)
+
+	for _, op := range []string{"Put", "Get", "GetAll"} {
+		tags["op"] = op
+		assert.Equal(t, int64(1), metrics2.GetCounter("datastore_entities_count", tags).Get(), op)
+		assert.Zero(t, metrics2.GetCounter("datastore_errors", tags).Get(), op)
+	}
+}
@@ -0,0 +1,272 @@
+package ds
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"cloud.google.com/go/datastore"
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/go/sklog"
+	"golang.org/x/time/rate"
+	"google.golang.org/api/iterator"
+)
+
+// defaultMigrationBatchSize is the number of entities migrated at once if
+// MigrationOptions.BatchSize is zero.
+const defaultMigrationBatchSize = 100
+
+// Entity is an entity of any Kind, as seen by a TransformFunc.
+type Entity struct {
+	Key        *datastore.Key
+	Properties datastore.PropertyList
+}
+
+// Property returns the property with the given name, or nil if there is none.
+func (e *Entity) Property(name string) *datastore.Property {
+	for i := range e.Properties {
+		if e.Properties[i].Name == name {
+			return &e.Properties[i]
+		}
+	}
+	return nil
+}
+
+// SetProperty sets the value of the property with the given name, adding the
+// property if there is none.
+func (e *Entity) SetProperty(name string, value interface{}, noIndex bool) {
+	if p := e.Property(name); p != nil {
+		p.Value = value
+		p.NoIndex = noIndex
+		return
+	}
+	e.Properties = append(e.Properties, datastore.Property{Name: name, Value: value, NoIndex: noIndex})
+}
+
+// TransformFunc transforms an entity in place and returns true if it changed.
+// Changing the key of the entity re-keys it, i.e. the entity is stored under
+// the new key and the old one is deleted.
+//
+// A migration may be interrupted and resumed, and re-keyed entities may be
+// visited again, so TransformFunc must be idempotent.
+type TransformFunc func(ctx context.Context, e *Entity) (bool, error)
+
+// Migration transforms all the entities of a Kind.
+type Migration struct {
+	// Name uniquely identifies the migration, e.g. "2023-11-add-owner".
+	Name string
+	// Kind is the Kind of the entities to transform.
+	Kind Kind
+	// Transform is applied to every entity of Kind.
+	Transform TransformFunc
+}
+
+var (
+	migrationsMutex sync.Mutex
+	migrations      = map[string]*Migration{}
+)
+
+// RegisterMigration makes a migration available to RunMigration. It panics if
+// the migration is invalid or another migration has the same name, so it should
+// be called from init functions.
+func RegisterMigration(name string, kind Kind, transform TransformFunc) {
+	if name == "" || kind == "" || transform == nil {
+		panic("Migrations require a name, a Kind and a transform func.")
+	}
+	migrationsMutex.Lock()
+	defer migrationsMutex.Unlock()
+	if _, ok := migrations[name]; ok {
+		panic("Migration " + name + " is already registered.")
+	}
+	migrations[name] = &Migration{
+		Name:      name,
+		Kind:      kind,
+		Transform: transform,
+	}
+}
+
+// getMigration returns the registered migration with the given name.
+func getMigration(name string) (*Migration, error) {
+	migrationsMutex.Lock()
+	defer migrationsMutex.Unlock()
+	m, ok := migrations[name]
+	if !ok {
+		return nil, skerr.Fmt("No migration named %q is registered", name)
+	}
+	return m, nil
+}
+
+// MigrationState is the progress of a migration, which is stored in Datastore
+// under the name of the migration so that interrupted migrations can resume.
+type MigrationState struct {
+	Name string
+	Kind string
+	// Cursor is where the migration resumes from.
+	Cursor string `datastore:",noindex"`
+	// Processed is the number of entities transformed so far, and Changed the
+	// number of them that were written.
+	Processed int64
+	Changed   int64
+	Done      bool
+	Started   time.Time
+	Updated   time.Time
+}
+
+// MigrationOptions configures RunMigration.
+type MigrationOptions struct {
+	// DryRun transforms the entities without writing them or the progress of
+	// the migration, which allows to count the entities that would change.
+	DryRun bool
+	// BatchSize is the number of entities read and written at once. Defaults
+	// to 100 and must not exceed MAX_MODIFICATIONS.
+	BatchSize int
+	// BatchesPerSecond limits the rate of batches, to leave capacity for the
+	// applications using the Kind. Unlimited if zero.
+	BatchesPerSecond float64
+}
+
+// migrationStateKey returns the key of the MigrationState of a migration.
+func migrationStateKey(name string) *datastore.Key {
+	key := NewKey(MIGRATION_STATE)
+	key.Name = name
+	return key
+}
+
+// GetMigrationState returns the progress of the migration with the given
+// name, or nil if it has never been run.
+func GetMigrationState(ctx context.Context, client *datastore.Client, name string) (*MigrationState, error) {
+	return Get[MigrationState](ctx, client, migrationStateKey(name))
+}
+
+// RunMigration applies the registered migration with the given name to all of
+// the entities of its Kind in Namespace, in batches. Unless it is a dry run,
+// progress is stored after every batch, and running a migration again resumes
+// from where it stopped. Completed migrations aren't run again.
+func RunMigration(ctx context.Context, client *datastore.Client, name string, opts MigrationOptions) (*MigrationState, error) {
+	m, err := getMigration(name)
+	if err != nil {
+		return nil, err
+	}
+	batchSize := opts.BatchSize
+	if batchSize == 0 {
+		batchSize = defaultMigrationBatchSize
+	}
+	if batchSize < 0 || batchSize > MAX_MODIFICATIONS {
+		return nil, skerr.Fmt("Batch size must be in the range [1, %d], got %d", MAX_MODIFICATIONS, batchSize)
+	}
+	limit := rate.Inf
+	if opts.BatchesPerSecond > 0 {
+		limit = rate.Limit(opts.BatchesPerSecond)
+	}
+	rl := rate.NewLimiter(limit, 1)
+
+	var state *MigrationState
+	if !opts.DryRun {
+		if state, err = GetMigrationState(ctx, client, name); err != nil {
+			return nil, skerr.Wrapf(err, "Failed to load the state of migration %s", name)
+		}
+	}
+	if state == nil {
+		state = &MigrationState{
+			Name:    name,
+			Kind:    string(m.Kind),
+			Started: time.Now(),
+		}
+	}
+	if state.Done {
+		sklog.Infof("Migration %s is already done.", name)
+		return state, nil
+	}
+
+	for !state.Done {
+		if err := rl.Wait(ctx); err != nil {
+			return state, skerr.Wrapf(err, "Migration %s interrupted", name)
+		}
+		if err := migrateBatch(ctx, client, m, state, batchSize, opts.DryRun); err != nil {
+			return state, skerr.Wrapf(err, "Migration %s failed after %d entities", name, state.Processed)
+		}
+		state.Updated = time.Now()
+		if !opts.DryRun {
+			if _, err := PutWithRetry(ctx, client, migrationStateKey(name), state); err != nil {
+				return state, skerr.Wrapf(err, "Failed to store the state of migration %s", name)
+			}
+		}
+		sklog.Infof("Migration %s: processed %d entities, changed %d.", name, state.Processed, state.Changed)
+	}
+	return state, nil
+}
+
+// migrateBatch transforms the next batch of entities after state.Cursor, writes
+// the ones that changed unless dryRun is true, and advances the state.
+func migrateBatch(ctx context.Context, client *datastore.Client, m *Migration, state *MigrationState, batchSize int, dryRun bool) error {
+	query := NewQuery(m.Kind).Limit(batchSize)
+	if state.Cursor != "" {
+		cursor, err := datastore.DecodeCursor(state.Cursor)
+		if err != nil {
+			return skerr.Wrapf(err, "Bad cursor %s", state.Cursor)
+		}
+		query = query.Start(cursor)
+	}
+
+	var entities []*Entity
+	it := client.Run(ctx, query)
+	for {
+		var props datastore.PropertyList
+		key, err := it.Next(&props)
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return skerr.Wrapf(err, "Failed to read %s entities", m.Kind)
+		}
+		entities = append(entities, &Entity{Key: key, Properties: props})
+	}
+	cursor, err := it.Cursor()
+	if err != nil {
+		return skerr.Wrapf(err, "Failed to get the next cursor")
+	}
+
+	var putKeys, deleteKeys []*datastore.Key
+	var putProps []datastore.PropertyList
+	for _, e := range entities {
+		oldKey := e.Key
+		// Copy the key, so that changing it in place re-keys the entity.
+		newKey := *oldKey
+		e.Key = &newKey
+		changed, err := m.Transform(ctx, e)
+		if err != nil {
+			return skerr.Wrapf(err, "Failed to transform %s", oldKey)
+		}
+		if changed {
+			putKeys = append(putKeys, withNamespace(e.Key))
+			putProps = append(putProps, e.Properties)
+			if !e.Key.Equal(oldKey) {
+				deleteKeys = append(deleteKeys, oldKey)
+			}
+		}
+	}
+
+	if !dryRun && len(putKeys) > 0 {
+		// Write the new entities before deleting the old ones, so that an
+		// interrupted batch never loses entities.
+		if err := retry(ctx, func() error {
+			_, err := client.PutMulti(ctx, putKeys, putProps)
+			return err
+		}); err != nil {
+			return skerr.Wrapf(err, "Failed to write %d entities", len(putKeys))
+		}
+		if len(deleteKeys) > 0 {
+			if err := retry(ctx, func() error {
+				return client.DeleteMulti(ctx, deleteKeys)
+			}); err != nil {
+				return skerr.Wrapf(err, "Failed to delete %d re-keyed entities", len(deleteKeys))
+			}
+		}
+	}
+
+	state.Processed += int64(len(entities))
+	state.Changed += int64(len(putKeys))
+	state.Cursor = cursor.String()
+	state.Done = len(entities) < batchSize
+	return nil
+}
@@ -0,0 +1,189 @@
+package ds
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"cloud.google.com/go/datastore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.skia.org/infra/go/emulators/gcp_emulator"
+)
+
+// registerTestMigration registers a migration with a name unique to the test.
+func registerTestMigration(t *testing.T, transform TransformFunc) string {
+	name := "test-migration-" + t.Name()
+	RegisterMigration(name, TEST_KIND, transform)
+	t.Cleanup(func() {
+		migrationsMutex.Lock()
+		defer migrationsMutex.Unlock()
+		delete(migrations, name)
+	})
+	return name
+}
+
+// doubleSortable is a TransformFunc which doubles the Sortable property of
+// testEntity, and marks entities as migrated so that it is idempotent.
+func doubleSortable(ctx context.Context, e *Entity) (bool, error) {
+	if e.Property("Migrated") != nil {
+		return false, nil
+	}
+	p := e.Property("Sortable")
+	if p == nil {
+		return false, fmt.Errorf("entity %s has no Sortable property", e.Key)
+	}
+	p.Value = p.Value.(int64) * 2
+	e.SetProperty("Migrated", true, true)
+	return true, nil
+}
+
+// clearMigrationStates deletes the progress of previous test runs.
+func clearMigrationStates(t *testing.T, client *datastore.Client) {
+	_, err := DeleteAll(context.Background(), client, MIGRATION_STATE, true)
+	require.NoError(t, err)
+}
+
+type migratedTestEntity struct {
+	Key      *datastore.Key `datastore:"__key__"`
+	Random   int64
+	Sortable int64
+	Migrated bool
+}
+
+func TestRegisterMigration_Duplicate_Panics(t *testing.T) {
+	name := registerTestMigration(t, doubleSortable)
+	assert.Panics(t, func() {
+		RegisterMigration(name, TEST_KIND, doubleSortable)
+	})
+	assert.Panics(t, func() {
+		RegisterMigration("no-transform", TEST_KIND, nil)
+	})
+}
+
+func TestRunMigration_InvalidInputs_ReturnsError(t *testing.T) {
+	ctx := context.Background()
+	_, err := RunMigration(ctx, nil, "unknown-migration", MigrationOptions{})
+	assert.Error(t, err)
+
+	name := registerTestMigration(t, doubleSortable)
+	_, err = RunMigration(ctx, nil, name, MigrationOptions{BatchSize: MAX_MODIFICATIONS + 1})
+	assert.Error(t, err)
+}
+
+func TestEntity_SetProperty(t *testing.T) {
+	e := &Entity{Properties: datastore.PropertyList{{Name: "A", Value: int64(1)}}}
+	e.SetProperty("A", int64(2), false)
+	e.SetProperty("B", "b", true)
+	assert.Equal(t, datastore.PropertyList{
+		{Name: "A", Value: int64(2)},
+		{Name: "B", Value: "b", NoIndex: true},
+	}, e.Properties)
+	assert.Nil(t, e.Property("C"))
+}
+
+func TestRunMigration_DryRun_DoesNotWrite(t *testing.T) {
+	gcp_emulator.RequireDatastore(t)
+
+	require.NoError(t, InitForTesting("test-project", "test-namespace"))
+	client := DS
+	clearMigrationStates(t, client)
+	exp, cleanup := addRandEntities(t, client, 25, 100)
+	defer cleanup()
+	ctx := context.Background()
+	name := registerTestMigration(t, doubleSortable)
+
+	state, err := RunMigration(ctx, client, name, MigrationOptions{DryRun: true, BatchSize: 10})
+	require.NoError(t, err)
+	assert.True(t, state.Done)
+	assert.Equal(t, int64(25), state.Processed)
+	assert.Equal(t, int64(25), state.Changed)
+
+	stored, err := GetMigrationState(ctx, client, name)
+	require.NoError(t, err)
+	assert.Nil(t, stored)
+	actual, err := Get[testEntity](ctx, client, exp[0].Key)
+	require.NoError(t, err)
+	assert.Equal(t, exp[0], actual)
+}
+
+func TestRunMigration_TransformsAllEntitiesAndResumes(t *testing.T) {
+	gcp_emulator.RequireDatastore(t)
+
+	require.NoError(t, InitForTesting("test-project", "test-namespace"))
+	client := DS
+	clearMigrationStates(t, client)
+	exp, cleanup := addRandEntities(t, client, 25, 100)
+	defer cleanup()
+	ctx := context.Background()
+
+	// Fail part way through, then resume.
+	fail := true
+	calls := 0
+	name := registerTestMigration(t, func(ctx context.Context, e *Entity) (bool, error) {
+		calls++
+		if fail && calls > 15 {
+			return false, fmt.Errorf("transform failed")
+		}
+		return doubleSortable(ctx, e)
+	})
+	state, err := RunMigration(ctx, client, name, MigrationOptions{BatchSize: 10})
+	require.Error(t, err)
+	assert.Equal(t, int64(10), state.Processed)
+
+	fail = false
+	state, err = RunMigration(ctx, client, name, MigrationOptions{BatchSize: 10, BatchesPerSecond: 100})
+	require.NoError(t, err)
+	assert.True(t, state.Done)
+	assert.Equal(t, int64(25), state.Processed)
+	assert.Equal(t, int64(25), state.Changed)
+
+	stored, err := GetMigrationState(ctx, client, name)
+	require.NoError(t, err)
+	assert.Equal(t, state.Processed, stored.Processed)
+	assert.True(t, stored.Done)
+
+	for _, e := range exp {
+		actual, err := Get[migratedTestEntity](ctx, client, e.Key)
+		require.NoError(t, err)
+		require.NotNil(t, actual)
+		assert.Equal(t, e.Sortable*2, actual.Sortable)
+		assert.True(t, actual.Migrated)
+	}
+
+	// Completed migrations aren't run again.
+	calls = 0
+	_, err = RunMigration(ctx, client, name, MigrationOptions{BatchSize: 10})
+	require.NoError(t, err)
+	assert.Zero(t, calls)
+}
+
+func TestRunMigration_ChangedKey_ReKeysEntity(t *testing.T) {
+	gcp_emulator.RequireDatastore(t)
+
+	require.NoError(t, InitForTesting("test-project", "test-namespace"))
+	client := DS
+	clearMigrationStates(t, client)
+	exp, cleanup := addRandEntities(t, client, 1, 100)
+	defer cleanup()
+	ctx := context.Background()
+
+	name := registerTestMigration(t, func(ctx context.Context, e *Entity) (bool, error) {
+		if e.Key.Name != "" {
+			return false, nil
+		}
+		e.Key.Name = fmt.Sprintf("entity-%d", e.Key.ID)
+		e.Key.ID = 0
+		return true, nil
+	})
+	_, err := RunMigration(ctx, client, name, MigrationOptions{})
+	require.NoError(t, err)
+
+	old, err := Get[testEntity](ctx, client, exp[0].Key)
+	require.NoError(t, err)
+	assert.Nil(t, old)
+	actual, err := Get[testEntity](ctx, client, datastore.NameKey(string(TEST_KIND), fmt.Sprintf("entity-%d", exp[0].Key.ID), nil))
+	require.NoError(t, err)
+	require.NotNil(t, actual)
+	assert.Equal(t, exp[0].Random, actual.Random)
+}
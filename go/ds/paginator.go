@@ -0,0 +1,115 @@
+package ds
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+
+	"cloud.google.com/go/datastore"
+	"go.skia.org/infra/go/skerr"
+	"google.golang.org/api/iterator"
+)
+
+// MaxPageSize is the largest page size supported by Paginator.
+const MaxPageSize = 1000
+
+// ErrInvalidPageToken is returned by Paginator.Page for page tokens which it
+// did not return, e.g. so that HTTP handlers can respond with a 400.
+var ErrInvalidPageToken = errors.New("invalid page token")
+
+// Page is a page of query results.
+type Page[T any] struct {
+	// Items are the entities of the page, and Keys their keys.
+	Items []*T
+	Keys  []*datastore.Key
+	// NextToken is the token of the next page, or empty if this is the last
+	// page.
+	NextToken string
+}
+
+// Paginator splits the results of a query into pages, which are identified by
+// opaque tokens, e.g. for HTTP list endpoints.
+type Paginator[T any] struct {
+	client   *datastore.Client
+	query    *datastore.Query
+	pageSize int
+}
+
+// NewPaginator returns a Paginator which runs the query in Namespace and
+// returns pages of pageSize entities. The query must not have a limit, offset
+// or cursors, which Paginator sets.
+func NewPaginator[T any](client *datastore.Client, q *datastore.Query, pageSize int) (*Paginator[T], error) {
+	if pageSize < 1 || pageSize > MaxPageSize {
+		return nil, skerr.Fmt("page size must be in the range [1, %d], got %d", MaxPageSize, pageSize)
+	}
+	return &Paginator[T]{
+		client:   client,
+		query:    q.Namespace(Namespace),
+		pageSize: pageSize,
+	}, nil
+}
+
+// Page returns the page with the given token, or the first page if the token
+// is empty. It returns an error wrapping ErrInvalidPageToken if the token is
+// invalid.
+func (p *Paginator[T]) Page(ctx context.Context, token string) (*Page[T], error) {
+	q := p.query.Limit(p.pageSize + 1)
+	if token != "" {
+		cursor, err := DecodePageToken(token)
+		if err != nil {
+			return nil, err
+		}
+		q = q.Start(cursor)
+	}
+
+	page := &Page[T]{
+		Items: make([]*T, 0, p.pageSize),
+		Keys:  make([]*datastore.Key, 0, p.pageSize),
+	}
+	it := p.client.Run(ctx, q)
+	for len(page.Items) < p.pageSize {
+		item := new(T)
+		key, err := it.Next(item)
+		if err == iterator.Done {
+			return page, nil
+		}
+		if err != nil {
+			return nil, skerr.Wrapf(err, "reading page")
+		}
+		page.Items = append(page.Items, item)
+		page.Keys = append(page.Keys, key)
+	}
+
+	// The page is full, check whether there is another one.
+	cursor, err := it.Cursor()
+	if err != nil {
+		return nil, skerr.Wrapf(err, "getting the cursor of the next page")
+	}
+	if _, err := it.Next(new(T)); err == iterator.Done {
+		return page, nil
+	} else if err != nil {
+		return nil, skerr.Wrapf(err, "checking for a next page")
+	}
+	page.NextToken = EncodePageToken(cursor)
+	return page, nil
+}
+
+// EncodePageToken returns an opaque page token for the cursor, which is safe to
+// use in URLs.
+func EncodePageToken(cursor datastore.Cursor) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(cursor.String()))
+}
+
+// DecodePageToken returns the cursor of a page token returned by
+// EncodePageToken, or an error wrapping ErrInvalidPageToken.
+func DecodePageToken(token string) (datastore.Cursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return datastore.Cursor{}, skerr.Wrapf(ErrInvalidPageToken, "%q: %s", token, err)
+	}
+	cursor, err := datastore.DecodeCursor(string(b))
+	if err != nil {
+		return datastore.Cursor{}, skerr.Wrapf(ErrInvalidPageToken, "%q: %s", token, err)
+	}
+	return cursor, nil
+}
@@ -0,0 +1,83 @@
+package ds
+
+import (
+	"context"
+	"testing"
+
+	"cloud.google.com/go/datastore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.skia.org/infra/go/emulators/gcp_emulator"
+)
+
+func TestPageToken_RoundTrip(t *testing.T) {
+	cursor, err := datastore.DecodeCursor("CjgSMmoJdGVzdC1wcm9qciULEgxEU19URVNUX0tJTkQYgICAgICAgAoMogEOdGVzdC1uYW1lc3BhY2UYACAA")
+	require.NoError(t, err)
+
+	actual, err := DecodePageToken(EncodePageToken(cursor))
+	require.NoError(t, err)
+	assert.Equal(t, cursor.String(), actual.String())
+}
+
+func TestDecodePageToken_Invalid_ReturnsErrInvalidPageToken(t *testing.T) {
+	_, err := DecodePageToken("not a token!")
+	assert.ErrorIs(t, err, ErrInvalidPageToken)
+	_, err = DecodePageToken("bm90IGEgY3Vyc29y")
+	assert.ErrorIs(t, err, ErrInvalidPageToken)
+}
+
+func TestNewPaginator_InvalidPageSize_ReturnsError(t *testing.T) {
+	_, err := NewPaginator[testEntity](nil, NewQuery(TEST_KIND), 0)
+	assert.Error(t, err)
+	_, err = NewPaginator[testEntity](nil, NewQuery(TEST_KIND), MaxPageSize+1)
+	assert.Error(t, err)
+}
+
+func TestPaginator_Page_ReturnsAllEntitiesInPages(t *testing.T) {
+	gcp_emulator.RequireDatastore(t)
+
+	require.NoError(t, InitForTesting("test-project", "test-namespace"))
+	client := DS
+	exp, cleanup := addRandEntities(t, client, 25, 100)
+	defer cleanup()
+	ctx := context.Background()
+
+	p, err := NewPaginator[testEntity](client, NewQuery(TEST_KIND).Order("__key__"), 10)
+	require.NoError(t, err)
+
+	var actual []*testEntity
+	var pageSizes []int
+	token := ""
+	for {
+		page, err := p.Page(ctx, token)
+		require.NoError(t, err)
+		require.Len(t, page.Keys, len(page.Items))
+		actual = append(actual, page.Items...)
+		pageSizes = append(pageSizes, len(page.Items))
+		if page.NextToken == "" {
+			break
+		}
+		token = page.NextToken
+	}
+	assert.Equal(t, []int{10, 10, 5}, pageSizes)
+	assert.Equal(t, exp, actual)
+
+	_, err = p.Page(ctx, "invalid")
+	assert.ErrorIs(t, err, ErrInvalidPageToken)
+}
+
+func TestPaginator_Page_FullLastPage_HasNoNextToken(t *testing.T) {
+	gcp_emulator.RequireDatastore(t)
+
+	require.NoError(t, InitForTesting("test-project", "test-namespace"))
+	client := DS
+	_, cleanup := addRandEntities(t, client, 10, 100)
+	defer cleanup()
+
+	p, err := NewPaginator[testEntity](client, NewQuery(TEST_KIND), 10)
+	require.NoError(t, err)
+	page, err := p.Page(context.Background(), "")
+	require.NoError(t, err)
+	assert.Len(t, page.Items, 10)
+	assert.Empty(t, page.NextToken)
+}
@@ -6,9 +6,12 @@ import (
 	"math/rand"
 	"net"
 	"net/http"
+	"regexp"
+	"strings"
 	"testing"
 	"time"
 
+	"cloud.google.com/go/datastore"
 	"github.com/stretchr/testify/require"
 	"go.skia.org/infra/go/ds"
 	"go.skia.org/infra/go/emulators"
@@ -39,7 +42,68 @@ func cleanup(t require.TestingT, kinds ...ds.Kind) {
 // datastore to connect to the emulator and also clears out all instances of
 // the given 'kinds' from the datastore.
 func InitDatastore(t require.TestingT, kinds ...ds.Kind) util.CleanupFunc {
-	gcp_emulator.RequireDatastore(t.(*testing.T))
+	requireDatastoreEmulator(t.(*testing.T))
+
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	err := ds.InitForTesting("test-project", fmt.Sprintf("test-namespace-%d", r.Uint64()))
+	require.NoError(t, err)
+	cleanup(t, kinds...)
+	return func() {
+		cleanup(t, kinds...)
+	}
+}
+
+// InitDatastoreForTest connects ds.DS to the Datastore emulator, starting it if
+// it isn't running, and sets ds.Namespace to a namespace unique to the test, so
+// that tests never see each other's data. All of the entities in the namespace
+// are deleted when the test ends, whatever their Kind. It returns the client.
+func InitDatastoreForTest(t *testing.T) *datastore.Client {
+	requireDatastoreEmulator(t)
+
+	// Namespaces may only contain letters, digits, '.', '-' and '_'.
+	name := nonNamespaceChars.ReplaceAllString(t.Name(), "_")
+	if len(name) > 60 {
+		name = name[:60]
+	}
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	ns := fmt.Sprintf("test-%s-%d", name, r.Uint64())
+	require.NoError(t, ds.InitForTesting("test-project", ns))
+	client := ds.DS
+
+	t.Cleanup(func() {
+		deleteNamespace(t, client, ns)
+	})
+	return client
+}
+
+// nonNamespaceChars matches the characters which aren't allowed in namespaces.
+var nonNamespaceChars = regexp.MustCompile(`[^0-9A-Za-z._-]`)
+
+// deleteNamespace deletes all of the entities in the namespace.
+func deleteNamespace(t *testing.T, client *datastore.Client, ns string) {
+	ctx := context.Background()
+	// A kindless query returns the entities of all Kinds.
+	keys, err := client.GetAll(ctx, datastore.NewQuery("").Namespace(ns).KeysOnly(), nil)
+	require.NoError(t, err)
+	// Entities of internal Kinds, e.g. statistics, can't be deleted.
+	userKeys := make([]*datastore.Key, 0, len(keys))
+	for _, key := range keys {
+		if !strings.HasPrefix(key.Kind, "__") {
+			userKeys = append(userKeys, key)
+		}
+	}
+	if len(userKeys) == 0 {
+		return
+	}
+	require.NoError(t, util.ChunkIter(len(userKeys), ds.MAX_MODIFICATIONS, func(start, end int) error {
+		return client.DeleteMulti(ctx, userKeys[start:end])
+	}))
+}
+
+// requireDatastoreEmulator starts the Datastore emulator if it isn't running
+// and fails the test if it isn't accessible.
+func requireDatastoreEmulator(t *testing.T) {
+	gcp_emulator.RequireDatastore(t)
 	// Copied from net/http to create a fresh http client. In some tests the
 	// httpmock replaces the default http client and the healthcheck below fails.
 	var transport http.RoundTripper = &http.Transport{
@@ -60,12 +124,4 @@ func InitDatastore(t require.TestingT, kinds ...ds.Kind) util.CleanupFunc {
 	emulatorHost := emulators.GetEmulatorHostEnvVar(emulators.Datastore)
 	_, err := httpClient.Get("http://" + emulatorHost + "/")
 	require.NoError(t, err, fmt.Sprintf("Cloud emulator host %s appears to be down or not accessible.", emulatorHost))
-
-	r := rand.New(rand.NewSource(time.Now().UnixNano()))
-	err = ds.InitForTesting("test-project", fmt.Sprintf("test-namespace-%d", r.Uint64()))
-	require.NoError(t, err)
-	cleanup(t, kinds...)
-	return func() {
-		cleanup(t, kinds...)
-	}
 }
@@ -0,0 +1,46 @@
+package testutil
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"cloud.google.com/go/datastore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.skia.org/infra/go/ds"
+)
+
+type testEntity struct {
+	Value int64
+}
+
+func TestInitDatastoreForTest_UniqueNamespaceIsWipedAfterTest(t *testing.T) {
+	ctx := context.Background()
+	var client *datastore.Client
+	var ns string
+	require.True(t, t.Run("sub-test", func(t *testing.T) {
+		client = InitDatastoreForTest(t)
+		ns = ds.Namespace
+		assert.Contains(t, ns, "sub-test")
+
+		for _, kind := range []ds.Kind{"KindA", "KindB"} {
+			_, err := client.Put(ctx, ds.NewKey(kind), &testEntity{Value: 1})
+			require.NoError(t, err)
+		}
+		count, err := client.Count(ctx, datastore.NewQuery("").Namespace(ns).KeysOnly())
+		require.NoError(t, err)
+		assert.Equal(t, 2, count)
+	}))
+
+	// The cleanup of the sub-test deleted the entities of all Kinds.
+	keys, err := client.GetAll(ctx, datastore.NewQuery("").Namespace(ns).KeysOnly(), nil)
+	require.NoError(t, err)
+	for _, key := range keys {
+		assert.True(t, strings.HasPrefix(key.Kind, "__"), "entity of Kind %s was not deleted", key.Kind)
+	}
+
+	// Every test gets its own namespace.
+	InitDatastoreForTest(t)
+	assert.NotEqual(t, ns, ds.Namespace)
+}
@@ -0,0 +1,117 @@
+package ds
+
+import (
+	"context"
+	"errors"
+
+	"cloud.google.com/go/datastore"
+	"go.skia.org/infra/go/skerr"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// errContention replaces datastore.ErrConcurrentTransaction while retrying
+// transactions, so that it is considered transient.
+var errContention = status.Error(codes.Aborted, "too much contention on the transaction")
+
+// EntityGroup is a group of entities which share an ancestor key. Queries
+// within an entity group are strongly consistent, and transactions may span
+// all of its entities, at the cost of limiting the write rate of the group to
+// about one write per second.
+//
+// The ancestor entity itself doesn't need to exist; its Kind is typically a
+// fake Kind which only exists to group the entities, e.g.
+// KIND_AUTOROLL_MODE_ANCESTOR.
+type EntityGroup struct {
+	kind Kind
+	id   int64
+}
+
+// NewEntityGroup returns the entity group whose ancestor has the given Kind and
+// ID. Keys are created in Namespace at the time they are requested, so entity
+// groups may be created before Init is called.
+func NewEntityGroup(kind Kind, id int64) *EntityGroup {
+	return &EntityGroup{
+		kind: kind,
+		id:   id,
+	}
+}
+
+// Ancestor returns the key of the ancestor of the entity group.
+func (g *EntityGroup) Ancestor() *datastore.Key {
+	key := NewKey(g.kind)
+	key.ID = g.id
+	return key
+}
+
+// NewKey returns a new incomplete key of the given Kind in the entity group.
+func (g *EntityGroup) NewKey(kind Kind) *datastore.Key {
+	return NewKeyWithParent(kind, g.Ancestor())
+}
+
+// NameKey returns the key of the given Kind and name in the entity group.
+func (g *EntityGroup) NameKey(kind Kind, name string) *datastore.Key {
+	key := g.NewKey(kind)
+	key.Name = name
+	return key
+}
+
+// NewQuery returns a query of the given Kind restricted to the entity group,
+// which is strongly consistent.
+func (g *EntityGroup) NewQuery(kind Kind) *datastore.Query {
+	return NewQuery(kind).Ancestor(g.Ancestor())
+}
+
+// RunInTransactionWithRetry runs fn in a transaction. If the transaction fails
+// because of contention or a transient RPC error, it is retried with backoff,
+// for longer than datastore.Client.RunInTransaction retries on its own. fn may
+// be called multiple times, so it must not have side effects other than on the
+// transaction.
+func RunInTransactionWithRetry(ctx context.Context, client *datastore.Client, fn func(tx *datastore.Transaction) error, opts ...datastore.TransactionOption) error {
+	err := retry(ctx, func() error {
+		_, err := client.RunInTransaction(ctx, fn, opts...)
+		if errors.Is(err, datastore.ErrConcurrentTransaction) {
+			// Contention is transient, so make retry back off.
+			return errContention
+		}
+		return err
+	})
+	if errors.Is(err, errContention) {
+		return skerr.Wrap(datastore.ErrConcurrentTransaction)
+	}
+	return skerr.Wrap(err)
+}
+
+// ReadModifyWrite reads the entity with the given key into a new T in a
+// transaction, calls fn with it, and writes it back unless fn returns an error,
+// in which case the transaction is rolled back and the error returned. found is
+// false if the entity doesn't exist yet, in which case fn receives the zero T.
+// It returns the written entity. Keys without a namespace are in Namespace.
+//
+// Transactions are retried as by RunInTransactionWithRetry, so fn may be
+// called multiple times.
+func ReadModifyWrite[T any](ctx context.Context, client *datastore.Client, key *datastore.Key, fn func(e *T, found bool) error) (*T, error) {
+	key = withNamespace(key)
+	var ret *T
+	err := RunInTransactionWithRetry(ctx, client, func(tx *datastore.Transaction) error {
+		e := new(T)
+		found := true
+		if err := tx.Get(key, e); errors.Is(err, datastore.ErrNoSuchEntity) {
+			found = false
+		} else if err != nil {
+			return err
+		}
+		if err := fn(e, found); err != nil {
+			return err
+		}
+		if _, err := tx.Put(key, e); err != nil {
+			return err
+		}
+		ret = e
+		return nil
+	})
+	if err != nil {
+		return nil, skerr.Wrapf(err, "updating %s", key)
+	}
+	return ret, nil
+}
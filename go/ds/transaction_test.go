@@ -0,0 +1,99 @@
+package ds
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"cloud.google.com/go/datastore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.skia.org/infra/go/emulators/gcp_emulator"
+)
+
+func TestEntityGroup_Keys(t *testing.T) {
+	Namespace = "test-namespace"
+	g := NewEntityGroup(KIND_AUTOROLL_MODE_ANCESTOR, 13)
+
+	ancestor := g.Ancestor()
+	assert.Equal(t, string(KIND_AUTOROLL_MODE_ANCESTOR), ancestor.Kind)
+	assert.Equal(t, int64(13), ancestor.ID)
+	assert.Equal(t, "test-namespace", ancestor.Namespace)
+
+	key := g.NewKey(KIND_AUTOROLL_MODE)
+	assert.True(t, key.Incomplete())
+	assert.Equal(t, string(KIND_AUTOROLL_MODE), key.Kind)
+	assert.Equal(t, "test-namespace", key.Namespace)
+	assert.True(t, ancestor.Equal(key.Parent))
+
+	key = g.NameKey(KIND_AUTOROLL_MODE, "my-roller")
+	assert.Equal(t, "my-roller", key.Name)
+	assert.True(t, ancestor.Equal(key.Parent))
+}
+
+func TestReadModifyWrite(t *testing.T) {
+	gcp_emulator.RequireDatastore(t)
+
+	require.NoError(t, InitForTesting("test-project", "test-namespace"))
+	ctx := context.Background()
+	_, err := DeleteAll(ctx, DS, TEST_KIND, true)
+	require.NoError(t, err)
+	defer func() {
+		_, err := DeleteAll(ctx, DS, TEST_KIND, true)
+		require.NoError(t, err)
+	}()
+	g := NewEntityGroup(TEST_KIND, 13)
+	key := g.NameKey(TEST_KIND, "rmw")
+	increment := func(e *testEntity, found bool) error {
+		e.Random++
+		return nil
+	}
+
+	// The entity is created if it doesn't exist.
+	actual, err := ReadModifyWrite(ctx, DS, key, func(e *testEntity, found bool) error {
+		assert.False(t, found)
+		return increment(e, found)
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), actual.Random)
+
+	// And updated otherwise.
+	actual, err = ReadModifyWrite(ctx, DS, key, func(e *testEntity, found bool) error {
+		assert.True(t, found)
+		return increment(e, found)
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), actual.Random)
+
+	// Errors roll back the transaction.
+	failure := errors.New("failed")
+	_, err = ReadModifyWrite(ctx, DS, key, func(e *testEntity, found bool) error {
+		e.Random = 100
+		return failure
+	})
+	require.ErrorIs(t, err, failure)
+
+	// The entity group query is strongly consistent, so no need to wait.
+	var entities []*testEntity
+	_, err = DS.GetAll(ctx, g.NewQuery(TEST_KIND), &entities)
+	require.NoError(t, err)
+	require.Len(t, entities, 1)
+	assert.Equal(t, int64(2), entities[0].Random)
+}
+
+func TestRunInTransactionWithRetry_ReturnsError(t *testing.T) {
+	gcp_emulator.RequireDatastore(t)
+	setFastRetries(t)
+
+	require.NoError(t, InitForTesting("test-project", "test-namespace"))
+	ctx := context.Background()
+	failure := errors.New("failed")
+	calls := 0
+	err := RunInTransactionWithRetry(ctx, DS, func(tx *datastore.Transaction) error {
+		calls++
+		return failure
+	})
+	require.ErrorIs(t, err, failure)
+	// Permanent errors are not retried.
+	assert.Equal(t, 1, calls)
+}
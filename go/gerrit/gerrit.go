@@ -276,6 +276,7 @@ type ChangeInfo struct {
 	Subject         string              `json:"subject"`
 	Branch          string              `json:"branch"`
 	Committed       bool                `json:"committed"`
+	Hashtags        []string            `json:"hashtags"`
 	Messages        []ChangeInfoMessage `json:"messages"`
 	Reviewers       struct {
 		CC       []*Person `json:"CC"`
@@ -473,6 +474,7 @@ type GerritInterface interface {
 	SendToCQ(context.Context, *ChangeInfo, string) error
 	SendToDryRun(context.Context, *ChangeInfo, string) error
 	SetCommitMessage(context.Context, *ChangeInfo, string) error
+	SetHashtags(context.Context, *ChangeInfo, []string, []string) error
 	SetReadyForReview(context.Context, *ChangeInfo) error
 	SetReview(context.Context, *ChangeInfo, string, map[string]int, []string, NotifyOption, NotifyDetails, string, int, []*AttentionSetInput) error
 	SetTopic(context.Context, string, int64) error
@@ -1176,6 +1178,19 @@ func queryString(terms []*SearchTerm) string {
 	return strings.Join(q, " ")
 }
 
+// SetHashtags adds and removes hashtags on the given change. See details in
+// https://gerrit-review.googlesource.com/Documentation/rest-api-changes.html#set-hashtags
+func (g *Gerrit) SetHashtags(ctx context.Context, issue *ChangeInfo, add, remove []string) error {
+	postData := map[string]interface{}{}
+	if len(add) > 0 {
+		postData["add"] = add
+	}
+	if len(remove) > 0 {
+		postData["remove"] = remove
+	}
+	return g.postJson(ctx, fmt.Sprintf("/changes/%s/hashtags", FullChangeId(issue)), postData)
+}
+
 // SetTopic sets a topic on the Gerrit change with the provided id.
 func (g *Gerrit) SetTopic(ctx context.Context, topic string, changeNum int64) error {
 	// Respect the rate limit.
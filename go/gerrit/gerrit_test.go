@@ -829,3 +829,31 @@ func TestCreateCherryPickChange(t *testing.T) {
 	require.Equal(t, "myProject~release~I8473b95934b5732ac55d26311a706c9c2bde9941", ci.Id)
 	require.Equal(t, "I8473b95934b5732ac55d26311a706c9c2bde9941", ci.ChangeId)
 }
+
+func TestSetHashtags(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "POST", r.Method)
+		require.Equal(t, "/a/changes/myProject~4711/hashtags", r.URL.Path)
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		var data map[string][]string
+		require.NoError(t, json.Unmarshal(body, &data))
+		require.Equal(t, map[string][]string{
+			"add":    {"autoroll", "skia"},
+			"remove": {"old"},
+		}, data)
+		w.Header().Set("Content-Type", "application/json")
+		_, err = fmt.Fprintln(w, `)]}'
+["autoroll","skia"]`)
+		require.NoError(t, err)
+	}))
+	defer ts.Close()
+
+	api, err := NewGerritWithConfig(ConfigChromium, ts.URL, c)
+	require.NoError(t, err)
+	ci := &ChangeInfo{
+		Project: "myProject",
+		Issue:   4711,
+	}
+	require.NoError(t, api.SetHashtags(context.Background(), ci, []string{"autoroll", "skia"}, []string{"old"}))
+}
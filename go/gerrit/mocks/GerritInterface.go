@@ -862,6 +862,24 @@ func (_m *GerritInterface) SetCommitMessage(_a0 context.Context, _a1 *gerrit.Cha
 	return r0
 }
 
+// SetHashtags provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *GerritInterface) SetHashtags(_a0 context.Context, _a1 *gerrit.ChangeInfo, _a2 []string, _a3 []string) error {
+	ret := _m.Called(_a0, _a1, _a2, _a3)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetHashtags")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *gerrit.ChangeInfo, []string, []string) error); ok {
+		r0 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SetReadyForReview provides a mock function with given fields: _a0, _a1
 func (_m *GerritInterface) SetReadyForReview(_a0 context.Context, _a1 *gerrit.ChangeInfo) error {
 	ret := _m.Called(_a0, _a1)
@@ -48,6 +48,15 @@ timer, and call Reset() on the instance to reset it to zero.  Note that
 Liveness requires a name and not a measurement, because the measurement is
 always “liveness”, and the provided name is inserted as a tag.
 
+Call SetExpectedInterval() on a Liveness to report how often it is expected
+to be reset as a companion metric, eg. liveness_my_process_expected_s, with
+the same tags except for type="liveness_expected".  This allows a single alert
+rule for all such livenesses, eg.
+
+	{__name__=~"liveness_.+_s", type="liveness"} > ignoring(type) 2 * {__name__=~"liveness_.+_expected_s", type="liveness_expected"}
+
+Healthy() reports the same condition, eg. for health check endpoints.
+
 ### Timer
 
 Timer in metrics2 behaves similarly to the old metrics timer, except that you
@@ -60,11 +69,83 @@ since data points will not be evenly spaced and may not exist for a time
 period.  Timer requires a name and not a measurement, because the measurement
 is always “timer” and the provided name is inserted as a tag.
 
+### SlidingWindowTimer
+
+SlidingWindowTimer keeps the durations measured within a sliding window in
+memory and reports their 50th, 90th and 99th percentiles as gauges, for
+services where histograms are too heavy.  Call
+metrics2.NewSlidingWindowTimer(name, window, tags) once, then either call
+Start() and Stop() on the returned Timer or call Observe() with a duration.
+
 ### FuncTimer
 
 FuncTimer is a special Timer designed specifically for timing the duration of
 functions.  It does not accept any parameters because it automatically fills in
 the function name and package name in the tags.  Just do defer
-metrics2.FuncTimer().Stop() at the beginning of the function.
+metrics2.FuncTimer().Stop() at the beginning of the function.  In addition to
+the usual timer summary, FuncTimer reports the duration in seconds to the
+timer_func_timer_seconds histogram, which, unlike the summary, can be
+aggregated across instances, eg. with histogram_quantile().
+
+### Histograms and Summaries
+
+For other distributions, call metrics2.GetHistogram(measurement, buckets, tags)
+or metrics2.GetSummary(measurement, objectives, tags) and call Observe() on the
+returned instance for each data point.  Histograms count values within the
+given buckets and may be aggregated across instances; summaries calculate the
+given quantiles within each instance.  Pass nil to use
+DefaultHistogramBuckets or DefaultSummaryObjectives respectively.
+
+### Cardinality Guard
+
+Every distinct combination of tag values creates a new time series, so a typo
+in a tag key or an unbounded tag value can create a large number of series.
+Call metrics2.SetMetricSchema(measurement, schema) to validate the tag keys of
+a measurement and cap its number of series, and
+metrics2.SetDefaultMaxSeries(n) to cap the series of all other measurements.
+Rejected metrics may still be used but are not exported; each rejection is
+logged once and counted in metrics2_rejected_series.
+
+### Help Text and Units
+
+By default, the HELP text of a metric is its measurement name.  To describe a
+metric, create it using metrics2.WithOptions(metrics2.WithHelp(help),
+metrics2.WithUnit(unit)), which returns a Client with all of the usual methods,
+eg. metrics2.WithOptions(metrics2.WithUnit("bytes")).GetInt64Metric(name, tags).
+The description of a measurement is fixed when it is first created.
+
+### Snapshots
+
+Call Snapshot() on a Client, or metrics2.GetSnapshot(), to get the current
+value of every series, eg. to check metrics in tests.  Use Snapshot.Get() to
+look up a series by measurement and tags, and Snapshot.Diff() to find the
+series which changed between two snapshots.
+
+### Deleting Metrics
+
+Metrics of entities which no longer exist, eg. per-bot metrics, are reported
+until they are deleted.  Call metrics2.ListMetrics(sel) and
+metrics2.DeleteMetrics(sel) to list and delete the metrics selected by a
+MetricSelector, by measurement prefix, tags and last update time.  Call
+metrics2.StartTTL(ctx, client, sel, ttl) to automatically delete the selected
+metrics which have not been updated within the given duration.
+
+Pushing Metrics
+---------------
+Short-lived processes, eg. command line tools, exit before they can be scraped.
+Call metrics2.InitPush(cfg) instead of InitPrometheus to push metrics to a
+Prometheus Pushgateway or to export them to an OpenTelemetry collector using
+OTLP.  The metrics helpers are used as usual, and metrics are pushed when the
+process exits via cleanup.Cleanup(), eg. using common.Defer(), or whenever
+GetDefaultClient().Flush() is called.
+
+Worker Processes
+----------------
+Worker subprocesses forked by a service can't be scraped either.  Call
+metrics2.InitWorker(dir) in each worker and metrics2.AggregateWorkers(ctx, dir)
+in the parent before starting the workers.  Workers periodically write their
+counters and gauges to dir and the parent merges them into its own metrics:
+counters are summed across workers and gauges take the most recently written
+value.
 
 */
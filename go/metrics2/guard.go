@@ -0,0 +1,162 @@
+package metrics2
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"go.skia.org/infra/go/sklog"
+	"go.skia.org/infra/go/util"
+)
+
+const (
+	// MEASUREMENT_REJECTED_SERIES counts the metrics rejected by the
+	// cardinality guard, by measurement and reason.
+	MEASUREMENT_REJECTED_SERIES = "metrics2_rejected_series"
+
+	// Reasons for which the cardinality guard rejects a metric.
+	rejectInvalidTags   = "invalid_tags"
+	rejectTooManySeries = "too_many_series"
+)
+
+// MetricSchema describes the allowed tags of a measurement.
+type MetricSchema struct {
+	// TagKeys are the tag keys which every instance of the measurement must
+	// have, and no others. If empty, the tag keys are not validated.
+	TagKeys []string
+
+	// MaxSeries is the maximum number of distinct combinations of tag values
+	// of the measurement. If zero, the default set by SetDefaultMaxSeries
+	// applies.
+	MaxSeries int
+}
+
+// cardinalityGuard validates the tags of metrics against registered schemas
+// and caps the number of distinct series of each measurement. Metrics which
+// are rejected are not exported. The zero value allows all metrics.
+type cardinalityGuard struct {
+	mutex sync.Mutex
+
+	// defaultMaxSeries applies to measurements without a MaxSeries. Zero means
+	// unlimited.
+	defaultMaxSeries int
+
+	// schemas are keyed by clean measurement name.
+	schemas map[string]MetricSchema
+
+	// series are the series keys which have been allowed, keyed by clean
+	// measurement name. Only populated for measurements with a cap.
+	series map[string]map[string]bool
+
+	// logged keeps track of the rejections which have been logged, so that
+	// every measurement is logged at most once per reason.
+	logged map[string]bool
+
+	rejected *prometheus.CounterVec
+}
+
+// setSchema registers the schema of the given measurement.
+func (g *cardinalityGuard) setSchema(measurement string, schema MetricSchema) {
+	keys := make([]string, 0, len(schema.TagKeys))
+	for _, k := range schema.TagKeys {
+		keys = append(keys, clean(k))
+	}
+	sort.Strings(keys)
+	schema.TagKeys = keys
+
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	if g.schemas == nil {
+		g.schemas = map[string]MetricSchema{}
+	}
+	g.schemas[clean(measurement)] = schema
+}
+
+// setDefaultMaxSeries sets the cap for measurements without a MaxSeries.
+func (g *cardinalityGuard) setDefaultMaxSeries(maxSeries int) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	g.defaultMaxSeries = maxSeries
+}
+
+// allow returns true if the series with the given clean measurement, sorted
+// clean tag keys and series key, as returned by commonGet, may be created or
+// retrieved. Rejections are logged and counted.
+func (g *cardinalityGuard) allow(measurement string, keys []string, seriesKey string) bool {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	schema, hasSchema := g.schemas[measurement]
+	if hasSchema && len(schema.TagKeys) > 0 && !util.SSliceEqual(schema.TagKeys, keys) {
+		g.reject(measurement, rejectInvalidTags, fmt.Sprintf("got tag keys %v but expected %v", keys, schema.TagKeys))
+		return false
+	}
+
+	maxSeries := g.defaultMaxSeries
+	if hasSchema && schema.MaxSeries > 0 {
+		maxSeries = schema.MaxSeries
+	}
+	if maxSeries <= 0 {
+		return true
+	}
+	if g.series == nil {
+		g.series = map[string]map[string]bool{}
+	}
+	series, ok := g.series[measurement]
+	if !ok {
+		series = map[string]bool{}
+		g.series[measurement] = series
+	}
+	if series[seriesKey] {
+		return true
+	}
+	if len(series) >= maxSeries {
+		g.reject(measurement, rejectTooManySeries, fmt.Sprintf("already has the maximum of %d series", maxSeries))
+		return false
+	}
+	series[seriesKey] = true
+	return true
+}
+
+// forget removes the given series of the given clean measurement, eg. when
+// the metric is deleted, so that it no longer counts towards the cap.
+func (g *cardinalityGuard) forget(measurement, seriesKey string) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	delete(g.series[measurement], seriesKey)
+}
+
+// reject logs and counts the rejection of the given measurement. Assumes that
+// the caller holds the mutex.
+func (g *cardinalityGuard) reject(measurement, reason, msg string) {
+	logKey := measurement + " " + reason
+	if g.logged == nil {
+		g.logged = map[string]bool{}
+	}
+	if !g.logged[logKey] {
+		sklog.Errorf("Rejected metric %q: %s. Further rejections of this metric for this reason are only counted in %s.", measurement, msg, MEASUREMENT_REJECTED_SERIES)
+		g.logged[logKey] = true
+	}
+
+	if g.rejected == nil {
+		rejected := prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: MEASUREMENT_REJECTED_SERIES,
+				Help: MEASUREMENT_REJECTED_SERIES,
+			},
+			[]string{"measurement", "reason"},
+		)
+		if err := prometheus.Register(rejected); err != nil {
+			are, ok := err.(prometheus.AlreadyRegisteredError)
+			if !ok {
+				sklog.Errorf("Failed to register %q: %s", MEASUREMENT_REJECTED_SERIES, err)
+				return
+			}
+			rejected = are.ExistingCollector.(*prometheus.CounterVec)
+		}
+		g.rejected = rejected
+	}
+	g.rejected.WithLabelValues(measurement, reason).Inc()
+}
@@ -0,0 +1,64 @@
+package metrics2
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metrics_util "go.skia.org/infra/go/metrics2/testutils"
+)
+
+func TestCardinalityGuard_InvalidTags(t *testing.T) {
+	c := getPromClient()
+	c.SetMetricSchema("guarded.metric", MetricSchema{TagKeys: []string{"some_key"}})
+
+	labels := map[string]string{"some_key": "some-value"}
+	g := c.GetInt64Metric("guarded.metric", labels)
+	g.Update(1)
+	require.Equal(t, "1", metrics_util.GetRecordedMetric(t, "guarded_metric", labels))
+
+	// A typo in the tag key is rejected but the metric is still usable.
+	typo := map[string]string{"some_kye": "some-value"}
+	g = c.GetInt64Metric("guarded.metric", typo)
+	g.Update(2)
+	require.Equal(t, int64(2), g.Get())
+	require.NoError(t, g.Delete())
+	require.Equal(t, `Could not find anything for guarded_metric{some_kye="some-value"}`, metrics_util.GetRecordedMetric(t, "guarded_metric", typo))
+	require.Equal(t, "1", metrics_util.GetRecordedMetric(t, MEASUREMENT_REJECTED_SERIES, map[string]string{"measurement": "guarded_metric", "reason": rejectInvalidTags}))
+
+	// Metrics without a schema are not validated.
+	g = c.GetInt64Metric("unguarded", typo)
+	g.Update(3)
+	require.Equal(t, "3", metrics_util.GetRecordedMetric(t, "unguarded", typo))
+}
+
+func TestCardinalityGuard_MaxSeries(t *testing.T) {
+	c := getPromClient()
+	c.SetMetricSchema("capped", MetricSchema{MaxSeries: 2})
+
+	for _, v := range []string{"a", "b", "c"} {
+		c.GetCounter("capped", map[string]string{"key": v}).Inc(1)
+	}
+	require.Equal(t, "1", metrics_util.GetRecordedMetric(t, "capped", map[string]string{"key": "a"}))
+	require.Equal(t, "1", metrics_util.GetRecordedMetric(t, "capped", map[string]string{"key": "b"}))
+	require.Equal(t, `Could not find anything for capped{key="c"}`, metrics_util.GetRecordedMetric(t, "capped", map[string]string{"key": "c"}))
+
+	// Existing series may still be retrieved.
+	c.GetCounter("capped", map[string]string{"key": "a"}).Inc(1)
+	require.Equal(t, "2", metrics_util.GetRecordedMetric(t, "capped", map[string]string{"key": "a"}))
+	require.Equal(t, "1", metrics_util.GetRecordedMetric(t, MEASUREMENT_REJECTED_SERIES, map[string]string{"measurement": "capped", "reason": rejectTooManySeries}))
+
+	// The default applies to measurements without a MaxSeries.
+	c.SetDefaultMaxSeries(1)
+	c.GetHistogram("default_capped", nil, map[string]string{"key": "a"}).Observe(1)
+	c.GetHistogram("default_capped", nil, map[string]string{"key": "b"}).Observe(1)
+	c.GetSummary("default_capped_summary", nil, map[string]string{"key": "a"}).Observe(1)
+	c.GetSummary("default_capped_summary", nil, map[string]string{"key": "b"}).Observe(1)
+	require.Equal(t, "1", metrics_util.GetRecordedMetric(t, "default_capped_count", map[string]string{"key": "a"}))
+	require.Equal(t, `Could not find anything for default_capped_count{key="b"}`, metrics_util.GetRecordedMetric(t, "default_capped_count", map[string]string{"key": "b"}))
+	require.Equal(t, "1", metrics_util.GetRecordedMetric(t, "default_capped_summary_count", map[string]string{"key": "a"}))
+	require.Equal(t, `Could not find anything for default_capped_summary_count{key="b"}`, metrics_util.GetRecordedMetric(t, "default_capped_summary_count", map[string]string{"key": "b"}))
+
+	// The rejection counter is itself exempt from the guard.
+	require.Equal(t, "1", metrics_util.GetRecordedMetric(t, MEASUREMENT_REJECTED_SERIES, map[string]string{"measurement": "default_capped", "reason": rejectTooManySeries}))
+	require.Equal(t, "1", metrics_util.GetRecordedMetric(t, MEASUREMENT_REJECTED_SERIES, map[string]string{"measurement": "default_capped_summary", "reason": rejectTooManySeries}))
+}
@@ -0,0 +1,198 @@
+package metrics2
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/go/sklog"
+	"go.skia.org/infra/go/util"
+)
+
+const (
+	// MAX_TTL_CHECK_PERIOD is the maximum time between checks for metrics
+	// which have expired by StartTTL.
+	MAX_TTL_CHECK_PERIOD = time.Minute
+)
+
+// updateTime records when a metric was last updated.
+type updateTime struct {
+	nanos atomic.Int64
+}
+
+// touch records that the metric was updated now.
+func (u *updateTime) touch() {
+	u.nanos.Store(time.Now().UnixNano())
+}
+
+// get returns the time at which the metric was last updated.
+func (u *updateTime) get() time.Time {
+	return time.Unix(0, u.nanos.Load())
+}
+
+// MetricInfo describes a metric created by a Client.
+type MetricInfo struct {
+	Measurement string
+	Tags        map[string]string
+	// LastUpdated is the time at which the metric was last updated or
+	// observed, or created if it has not been updated since.
+	LastUpdated time.Time
+}
+
+// MetricSelector selects metrics created by a Client. The zero value selects
+// all metrics.
+type MetricSelector struct {
+	// Prefix, if not empty, selects metrics whose measurement starts with it,
+	// eg. "liveness_" or "autoroll_".
+	Prefix string
+
+	// Tags selects metrics which have all of the given tags, eg. the ID of an
+	// entity which no longer exists.
+	Tags map[string]string
+
+	// NotUpdatedSince, if not zero, selects metrics which have not been
+	// updated since the given time.
+	NotUpdatedSince time.Time
+}
+
+// matches returns true if the given metric is selected.
+func (s MetricSelector) matches(info MetricInfo) bool {
+	if s.Prefix != "" && !strings.HasPrefix(info.Measurement, clean(s.Prefix)) {
+		return false
+	}
+	for k, v := range s.Tags {
+		if actual, ok := info.Tags[clean(k)]; !ok || actual != v {
+			return false
+		}
+	}
+	if !s.NotUpdatedSince.IsZero() && !info.LastUpdated.Before(s.NotUpdatedSince) {
+		return false
+	}
+	return true
+}
+
+// managedMetric is a metric which can be listed and deleted.
+type managedMetric struct {
+	info   MetricInfo
+	delete func() error
+}
+
+// managedMetrics returns the metrics created by this client which may be
+// listed and deleted, sorted by SeriesKey.
+func (p *promClient) managedMetrics() []managedMetric {
+	ret := []managedMetric{}
+
+	p.int64Mutex.Lock()
+	for _, m := range p.int64Gauges {
+		ret = append(ret, managedMetric{
+			info:   MetricInfo{Measurement: m.measurement, Tags: m.tags, LastUpdated: m.updated.get()},
+			delete: m.delete,
+		})
+	}
+	p.int64Mutex.Unlock()
+
+	p.float64Mutex.Lock()
+	for _, m := range p.float64Gauges {
+		ret = append(ret, managedMetric{
+			info:   MetricInfo{Measurement: m.measurement, Tags: m.tags, LastUpdated: m.updated.get()},
+			delete: m.delete,
+		})
+	}
+	p.float64Mutex.Unlock()
+
+	p.float64SummaryMutex.Lock()
+	for _, m := range p.float64Summaries {
+		ret = append(ret, managedMetric{
+			info:   MetricInfo{Measurement: m.measurement, Tags: m.tags, LastUpdated: m.updated.get()},
+			delete: m.delete,
+		})
+	}
+	p.float64SummaryMutex.Unlock()
+
+	p.float64HistogramMutex.Lock()
+	for _, m := range p.float64Histograms {
+		ret = append(ret, managedMetric{
+			info:   MetricInfo{Measurement: m.measurement, Tags: m.tags, LastUpdated: m.updated.get()},
+			delete: m.delete,
+		})
+	}
+	p.float64HistogramMutex.Unlock()
+
+	sort.Slice(ret, func(i, j int) bool {
+		return SeriesKey(ret[i].info.Measurement, ret[i].info.Tags) < SeriesKey(ret[j].info.Measurement, ret[j].info.Tags)
+	})
+	return ret
+}
+
+// ListMetrics returns the metrics created by this client which are selected
+// by the given MetricSelector, sorted by SeriesKey.
+func (p *promClient) ListMetrics(sel MetricSelector) []MetricInfo {
+	ret := []MetricInfo{}
+	for _, m := range p.managedMetrics() {
+		if sel.matches(m.info) {
+			ret = append(ret, m.info)
+		}
+	}
+	return ret
+}
+
+// DeleteMetrics deletes the metrics created by this client which are selected
+// by the given MetricSelector and returns the number of deleted metrics.
+func (p *promClient) DeleteMetrics(sel MetricSelector) (int, error) {
+	deleted := 0
+	var errs []string
+	for _, m := range p.managedMetrics() {
+		if !sel.matches(m.info) {
+			continue
+		}
+		if err := m.delete(); err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+		deleted++
+	}
+	if len(errs) > 0 {
+		return deleted, skerr.Fmt("Failed to delete %d metrics: %s", len(errs), strings.Join(errs, "; "))
+	}
+	return deleted, nil
+}
+
+// StartTTL periodically deletes the metrics of the given Client which are
+// selected by the given MetricSelector and have not been updated within the
+// given TTL, until the given context is canceled. This prevents metrics of
+// entities which no longer exist, eg. per-bot metrics, from being reported
+// forever. A deleted metric is reported again if it is subsequently updated.
+// Note that Livenesses are updated periodically and therefore never expire,
+// and that Counters are only updated when they change.
+func StartTTL(ctx context.Context, c Client, sel MetricSelector, ttl time.Duration) {
+	period := ttl
+	if period > MAX_TTL_CHECK_PERIOD {
+		period = MAX_TTL_CHECK_PERIOD
+	}
+	go util.RepeatCtx(ctx, period, func(_ context.Context) {
+		expiredSel := sel
+		expiredSel.NotUpdatedSince = time.Now().Add(-ttl)
+		n, err := c.DeleteMetrics(expiredSel)
+		if err != nil {
+			sklog.Errorf("Failed to delete expired metrics: %s", err)
+		}
+		if n > 0 {
+			sklog.Infof("Deleted %d metrics which were not updated within %s.", n, ttl)
+		}
+	})
+}
+
+// ListMetrics returns the metrics of the default client which are selected by
+// the given MetricSelector.
+func ListMetrics(sel MetricSelector) []MetricInfo {
+	return defaultClient.ListMetrics(sel)
+}
+
+// DeleteMetrics deletes the metrics of the default client which are selected
+// by the given MetricSelector and returns the number of deleted metrics.
+func DeleteMetrics(sel MetricSelector) (int, error) {
+	return defaultClient.DeleteMetrics(sel)
+}
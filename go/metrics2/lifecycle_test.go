@@ -0,0 +1,160 @@
+package metrics2
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestListAndDeleteMetrics(t *testing.T) {
+	c := getPromClient()
+	c.GetInt64Metric("bot_busy", map[string]string{"bot": "a"}).Update(1)
+	c.GetCounter("bot_tasks", map[string]string{"bot": "a"}).Inc(2)
+	c.GetFloat64Metric("bot_load", map[string]string{"bot": "b"}).Update(0.5)
+	c.GetSummary("bot_task_duration", nil, map[string]string{"bot": "a"}).Observe(1)
+	c.GetHistogram("bot_queue_time", nil, map[string]string{"bot": "a"}).Observe(1)
+	c.GetInt64Metric("other").Update(1)
+
+	names := func(infos []MetricInfo) []string {
+		ret := []string{}
+		for _, info := range infos {
+			ret = append(ret, SeriesKey(info.Measurement, info.Tags))
+		}
+		return ret
+	}
+	require.Equal(t, []string{
+		`bot_busy{bot="a"}`,
+		`bot_load{bot="b"}`,
+		`bot_queue_time{bot="a"}`,
+		`bot_task_duration{bot="a"}`,
+		`bot_tasks{bot="a"}`,
+		`other`,
+	}, names(c.ListMetrics(MetricSelector{})))
+	require.Equal(t, []string{`bot_load{bot="b"}`}, names(c.ListMetrics(MetricSelector{Prefix: "bot_", Tags: map[string]string{"bot": "b"}})))
+
+	// Delete all metrics of bot "a".
+	n, err := c.DeleteMetrics(MetricSelector{Tags: map[string]string{"bot": "a"}})
+	require.NoError(t, err)
+	require.Equal(t, 4, n)
+	require.Equal(t, []string{`bot_load{bot="b"}`, `other`}, names(c.ListMetrics(MetricSelector{})))
+	snap, err := c.Snapshot()
+	require.NoError(t, err)
+	require.Equal(t, Snapshot{
+		SeriesKey("bot_load", map[string]string{"bot": "b"}): 0.5,
+		SeriesKey("other", nil):                              1,
+	}, snap)
+
+	// Deleted metrics may be created again.
+	c.GetHistogram("bot_queue_time", nil, map[string]string{"bot": "a"}).Observe(1)
+	require.Len(t, c.ListMetrics(MetricSelector{Prefix: "bot_queue_time"}), 1)
+}
+
+func TestDeleteMetrics_NotUpdatedSince(t *testing.T) {
+	c := getPromClient()
+	stale := c.GetInt64Metric("stale")
+	fresh := c.GetInt64Metric("fresh")
+	cutoff := time.Now()
+	time.Sleep(time.Millisecond)
+	fresh.Update(1)
+
+	require.Len(t, c.ListMetrics(MetricSelector{NotUpdatedSince: cutoff}), 1)
+	n, err := c.DeleteMetrics(MetricSelector{NotUpdatedSince: cutoff})
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+	infos := c.ListMetrics(MetricSelector{})
+	require.Len(t, infos, 1)
+	require.Equal(t, "fresh", infos[0].Measurement)
+
+	_, ok := mustSnapshot(t, c).Get("stale", nil)
+	require.False(t, ok)
+
+	// The stale metric is reported again once it is updated.
+	stale.Update(2)
+	v, ok := mustSnapshot(t, c).Get("stale", nil)
+	require.True(t, ok)
+	require.Equal(t, 2.0, v)
+	require.Len(t, c.ListMetrics(MetricSelector{}), 2)
+}
+
+func TestDeleteMetrics_UpdatedHandlesReappear(t *testing.T) {
+	c := getPromClient()
+	tags := map[string]string{"bot": "a"}
+	i64 := c.GetInt64Metric("reappear_int", tags)
+	i64.Update(1)
+	f64 := c.GetFloat64Metric("reappear_float", tags)
+	f64.Update(0.5)
+	counter := c.GetCounter("reappear_counter", tags)
+	counter.Inc(2)
+	summary := c.GetSummary("reappear_summary", nil, tags)
+	summary.Observe(1)
+	histogram := c.GetHistogram("reappear_histogram", nil, tags)
+	histogram.Observe(1)
+
+	n, err := c.DeleteMetrics(MetricSelector{Prefix: "reappear_"})
+	require.NoError(t, err)
+	require.Equal(t, 5, n)
+	require.Empty(t, c.ListMetrics(MetricSelector{Prefix: "reappear_"}))
+
+	i64.Update(3)
+	f64.Update(1.5)
+	counter.Inc(1)
+	summary.Observe(2)
+	histogram.Observe(2)
+	require.Len(t, c.ListMetrics(MetricSelector{Prefix: "reappear_"}), 5)
+	snap := mustSnapshot(t, c)
+	for measurement, expect := range map[string]float64{
+		"reappear_int":             3,
+		"reappear_float":           1.5,
+		"reappear_counter":         3,
+		"reappear_summary_count":   1,
+		"reappear_histogram_count": 1,
+	} {
+		v, ok := snap.Get(measurement, tags)
+		require.True(t, ok, measurement)
+		require.Equal(t, expect, v, measurement)
+	}
+
+	// A handle obtained after the deletion shares the series of the old
+	// handle.
+	require.NoError(t, i64.Delete())
+	c.GetInt64Metric("reappear_int", tags).Update(5)
+	i64.Update(4)
+	v, ok := mustSnapshot(t, c).Get("reappear_int", tags)
+	require.True(t, ok)
+	require.Equal(t, 4.0, v)
+	require.Len(t, c.ListMetrics(MetricSelector{Prefix: "reappear_int"}), 1)
+}
+
+func TestDeleteMetrics_FreesGuardedSeries(t *testing.T) {
+	c := getPromClient()
+	c.SetMetricSchema("capped", MetricSchema{MaxSeries: 1})
+	c.GetInt64Metric("capped", map[string]string{"key": "a"}).Update(1)
+	_, err := c.DeleteMetrics(MetricSelector{Prefix: "capped"})
+	require.NoError(t, err)
+	c.GetInt64Metric("capped", map[string]string{"key": "b"}).Update(1)
+	v, ok := mustSnapshot(t, c).Get("capped", map[string]string{"key": "b"})
+	require.True(t, ok)
+	require.Equal(t, 1.0, v)
+}
+
+func TestStartTTL(t *testing.T) {
+	c := getPromClient()
+	c.GetInt64Metric("expiring", map[string]string{"bot": "a"}).Update(1)
+	c.GetInt64Metric("kept").Update(1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	StartTTL(ctx, c, MetricSelector{Prefix: "expiring"}, time.Millisecond)
+	require.Eventually(t, func() bool {
+		return len(c.ListMetrics(MetricSelector{Prefix: "expiring"})) == 0
+	}, 5*time.Second, time.Millisecond)
+	require.Len(t, c.ListMetrics(MetricSelector{Prefix: "kept"}), 1)
+}
+
+func mustSnapshot(t *testing.T, c Client) Snapshot {
+	snap, err := c.Snapshot()
+	require.NoError(t, err)
+	return snap
+}
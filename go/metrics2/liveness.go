@@ -3,6 +3,7 @@ package metrics2
 import (
 	"context"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
@@ -13,6 +14,14 @@ import (
 const (
 	LIVENESS_REPORT_FREQUENCY = time.Minute
 	MEASUREMENT_LIVENESS      = "liveness"
+
+	// TYPE_LIVENESS_EXPECTED is the value of the "type" tag of the metric
+	// which reports the expected interval of a Liveness.
+	TYPE_LIVENESS_EXPECTED = "liveness_expected"
+
+	// LIVENESS_UNHEALTHY_FACTOR is the multiple of its expected interval after
+	// which a Liveness is no longer healthy.
+	LIVENESS_UNHEALTHY_FACTOR = 2
 )
 
 // liveness implements Liveness.
@@ -21,6 +30,15 @@ type liveness struct {
 	lastSuccessfulUpdate time.Time
 	m                    Int64Metric
 	mtx                  sync.Mutex
+
+	// c, measurement and tags are used to create expectedMetric.
+	c           Client
+	measurement string
+	tags        map[string]string
+
+	// expected is the expected interval between resets, or zero if unknown.
+	expected       time.Duration
+	expectedMetric Int64Metric
 }
 
 // newLiveness creates a new Liveness metric helper. The current value is
@@ -45,6 +63,9 @@ func newLiveness(c Client, name string, makeUnique bool, tagsList ...map[string]
 		lastSuccessfulUpdate: time.Now(),
 		m:                    c.GetInt64Metric(measurement, tags),
 		mtx:                  sync.Mutex{},
+		c:                    c,
+		measurement:          measurement,
+		tags:                 tags,
 	}
 	go util.RepeatCtx(ctx, LIVENESS_REPORT_FREQUENCY, func(_ context.Context) { l.update() })
 	return l
@@ -94,6 +115,29 @@ func (l *liveness) ManualReset(lastSuccessfulUpdate time.Time) {
 	l.updateLocked()
 }
 
+// SetExpectedInterval implements the Liveness interface.
+func (l *liveness) SetExpectedInterval(expected time.Duration) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	l.expected = expected
+	if l.expectedMetric == nil {
+		tags := util.CopyStringMap(l.tags)
+		tags["type"] = TYPE_LIVENESS_EXPECTED
+		l.expectedMetric = l.c.GetInt64Metric(strings.TrimSuffix(l.measurement, "_s")+"_expected_s", tags)
+	}
+	l.expectedMetric.Update(int64(expected.Seconds()))
+}
+
+// Healthy implements the Liveness interface.
+func (l *liveness) Healthy() bool {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	if l.expected <= 0 {
+		return true
+	}
+	return time.Since(l.lastSuccessfulUpdate) <= LIVENESS_UNHEALTHY_FACTOR*l.expected
+}
+
 // NewLiveness creates a new Liveness metric helper using the default client.
 // The current value is reported at the given frequency; if the report frequency
 // is zero, the value is only reported when it changes.
@@ -0,0 +1,45 @@
+package metrics2
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLiveness_ExpectedInterval(t *testing.T) {
+	c := getPromClient()
+	tags := map[string]string{"some_key": "some-value"}
+	l := c.NewLiveness("my_process", tags)
+	defer l.Close()
+
+	// Without an expected interval the liveness is always healthy and has no
+	// companion metric.
+	l.ManualReset(time.Now().Add(-time.Hour))
+	require.True(t, l.Healthy())
+	snap, err := c.Snapshot()
+	require.NoError(t, err)
+	require.Equal(t, Snapshot{
+		SeriesKey("liveness_my_process_s", map[string]string{"name": "my_process", "type": MEASUREMENT_LIVENESS, "some_key": "some-value"}): 3600,
+	}, snap)
+
+	l.SetExpectedInterval(20 * time.Minute)
+	require.False(t, l.Healthy())
+	l.ManualReset(time.Now().Add(-39 * time.Minute))
+	require.True(t, l.Healthy())
+	l.Reset()
+	require.True(t, l.Healthy())
+
+	snap, err = c.Snapshot()
+	require.NoError(t, err)
+	v, ok := snap.Get("liveness_my_process_expected_s", map[string]string{"name": "my_process", "type": TYPE_LIVENESS_EXPECTED, "some_key": "some-value"})
+	require.True(t, ok)
+	require.Equal(t, 1200.0, v)
+
+	// The expected interval may be changed.
+	l.SetExpectedInterval(time.Minute)
+	snap, err = c.Snapshot()
+	require.NoError(t, err)
+	v, _ = snap.Get("liveness_my_process_expected_s", map[string]string{"name": "my_process", "type": TYPE_LIVENESS_EXPECTED, "some_key": "some-value"})
+	require.Equal(t, 60.0, v)
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"go.skia.org/infra/go/sklog"
 )
@@ -41,6 +42,17 @@ type Liveness interface {
 	// Close stops the internal goroutine. Usually used for testing since most Liveness instances
 	// live for the duration of the process.
 	Close()
+
+	// SetExpectedInterval sets the expected interval between calls to Reset,
+	// which is reported as a companion metric with the same tags, except for
+	// a "type" tag of TYPE_LIVENESS_EXPECTED, so that alerts need not
+	// hard-code thresholds.
+	SetExpectedInterval(expected time.Duration)
+
+	// Healthy returns false if the time since the last successful update
+	// exceeds LIVENESS_UNHEALTHY_FACTOR times the expected interval. A
+	// Liveness without an expected interval is always healthy.
+	Healthy() bool
 }
 
 // BoolMetric is a metric which reports a Boolean value, represented on the backend as an int64.
@@ -85,6 +97,14 @@ type Float64SummaryMetric interface {
 	Observe(v float64)
 }
 
+// Float64HistogramMetric is a metric which reports the distribution of many
+// float64 values as counts of values within configurable buckets. Unlike
+// Float64SummaryMetric, histograms can be aggregated across instances.
+type Float64HistogramMetric interface {
+	// Observe adds a data point to the metric.
+	Observe(v float64)
+}
+
 // Counter is a struct used for tracking metrics which increment or decrement.
 type Counter interface {
 	// Dec decrements the counter by the given quantity.
@@ -127,18 +147,75 @@ type Client interface {
 	// GetFloat64SummaryMetric returns an Float64SummaryMetric instance.
 	GetFloat64SummaryMetric(measurement string, tags ...map[string]string) Float64SummaryMetric
 
+	// GetHistogram returns a Float64HistogramMetric instance with the given
+	// bucket upper bounds, which must be sorted in increasing order. If
+	// buckets is empty then DefaultHistogramBuckets are used. The buckets of a
+	// measurement are fixed by the first call for that measurement and set of
+	// tag keys.
+	GetHistogram(measurement string, buckets []float64, tags ...map[string]string) Float64HistogramMetric
+
+	// GetSummary returns a Float64SummaryMetric instance with the given
+	// objectives, which map quantiles to their allowed absolute error. If
+	// objectives is empty then DefaultSummaryObjectives are used. The
+	// objectives of a measurement are fixed by the first call for that
+	// measurement and set of tag keys.
+	GetSummary(measurement string, objectives map[float64]float64, tags ...map[string]string) Float64SummaryMetric
+
 	// NewLiveness creates a new Liveness metric helper.
 	NewLiveness(name string, tagsList ...map[string]string) Liveness
 
 	// NewTimer creates and returns a new started timer.
 	NewTimer(name string, tagsList ...map[string]string) Timer
 
+	// NewSlidingWindowTimer creates a new SlidingWindowTimer which reports the
+	// percentiles of the durations within the given window.
+	NewSlidingWindowTimer(name string, window time.Duration, tagsList ...map[string]string) SlidingWindowTimer
+
 	// Int64MetricExists returns true if the given Int64Metric already exists.
 	Int64MetricExists(measurement string, tags ...map[string]string) bool
+
+	// SetMetricSchema registers the allowed tags of the given measurement.
+	// Metrics whose tags do not match the schema, or which would exceed its
+	// maximum number of series, are logged, counted and not exported.
+	SetMetricSchema(measurement string, schema MetricSchema)
+
+	// ListMetrics returns the metrics created by this Client which are
+	// selected by the given MetricSelector, sorted by SeriesKey.
+	ListMetrics(sel MetricSelector) []MetricInfo
+
+	// DeleteMetrics deletes the metrics created by this Client which are
+	// selected by the given MetricSelector, eg. those of entities which no
+	// longer exist, and returns the number of deleted metrics. A deleted
+	// metric is reported again if it is subsequently updated.
+	DeleteMetrics(sel MetricSelector) (int, error)
+
+	// Snapshot returns the current value of every series, eg. so that tests
+	// can check the values of metrics.
+	Snapshot() (Snapshot, error)
+
+	// WithOptions returns a Client which creates metrics the same way as this
+	// Client, described by the given options, eg. WithHelp and WithUnit. The
+	// options of a measurement are fixed by the first call for that
+	// measurement and set of tag keys.
+	WithOptions(opts ...MetricOption) Client
+
+	// SetDefaultMaxSeries caps the number of series of every measurement
+	// without a MaxSeries in its schema. Metrics which would exceed the cap
+	// are logged, counted and not exported. Zero, the default, means
+	// unlimited.
+	SetDefaultMaxSeries(maxSeries int)
 }
 
 var (
 	defaultClient Client = NewPromClient()
+
+	// DefaultHistogramBuckets are the buckets used by GetHistogram if none are
+	// given. They are suited to latencies measured in seconds.
+	DefaultHistogramBuckets = prometheus.DefBuckets
+
+	// DefaultSummaryObjectives are the objectives used by GetSummary if none
+	// are given.
+	DefaultSummaryObjectives = map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.99: 0.001}
 )
 
 // GetDefaultClient returns the default Client.
@@ -15,7 +15,29 @@ func GetFloat64SummaryMetric(measurement string, tags ...map[string]string) Floa
 	return defaultClient.GetFloat64SummaryMetric(measurement, tags...)
 }
 
+// GetHistogram returns a Float64HistogramMetric instance using the default client.
+func GetHistogram(measurement string, buckets []float64, tags ...map[string]string) Float64HistogramMetric {
+	return defaultClient.GetHistogram(measurement, buckets, tags...)
+}
+
+// GetSummary returns a Float64SummaryMetric instance using the default client.
+func GetSummary(measurement string, objectives map[float64]float64, tags ...map[string]string) Float64SummaryMetric {
+	return defaultClient.GetSummary(measurement, objectives, tags...)
+}
+
 // GetBoolMetric returns a BoolMetric instance using the default client.
 func GetBoolMetric(measurement string, tags ...map[string]string) BoolMetric {
 	return defaultClient.GetBoolMetric(measurement, tags...)
 }
+
+// SetMetricSchema registers the allowed tags of the given measurement using the
+// default client.
+func SetMetricSchema(measurement string, schema MetricSchema) {
+	defaultClient.SetMetricSchema(measurement, schema)
+}
+
+// SetDefaultMaxSeries caps the number of series of each measurement using the
+// default client.
+func SetDefaultMaxSeries(maxSeries int) {
+	defaultClient.SetDefaultMaxSeries(maxSeries)
+}
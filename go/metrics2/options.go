@@ -0,0 +1,152 @@
+package metrics2
+
+import (
+	"fmt"
+	"time"
+)
+
+// metricOptions describes the metrics created by a Client returned by
+// WithOptions.
+type metricOptions struct {
+	help string
+	unit string
+}
+
+// helpText returns the HELP text of the given clean measurement.
+func (o metricOptions) helpText(measurement string) string {
+	help := o.help
+	if help == "" {
+		help = measurement
+	}
+	if o.unit != "" {
+		help = fmt.Sprintf("%s (unit: %s)", help, o.unit)
+	}
+	return help
+}
+
+// MetricOption is an option for the metrics created by a Client returned by
+// WithOptions.
+type MetricOption func(*metricOptions)
+
+// WithHelp sets the description of a metric, which is reported as its HELP
+// text. Defaults to the measurement name.
+func WithHelp(help string) MetricOption {
+	return func(o *metricOptions) {
+		o.help = help
+	}
+}
+
+// WithUnit sets the unit of the values of a metric, eg. "seconds" or "bytes",
+// which is included in its HELP text.
+func WithUnit(unit string) MetricOption {
+	return func(o *metricOptions) {
+		o.unit = unit
+	}
+}
+
+// describer is implemented by Clients which can describe the metrics they
+// create.
+type describer interface {
+	// describe sets the options of the given measurement. It has no effect if
+	// the measurement has already been registered with the given tag keys.
+	describe(measurement string, opts metricOptions)
+}
+
+// optionsClient is a Client which describes every metric it creates using the
+// given options before creating it using the wrapped Client.
+type optionsClient struct {
+	Client
+	d    describer
+	opts metricOptions
+}
+
+// newOptionsClient returns an optionsClient which wraps the given Client,
+// which is described using the given describer.
+func newOptionsClient(c Client, d describer, opts ...MetricOption) *optionsClient {
+	ret := &optionsClient{
+		Client: c,
+		d:      d,
+	}
+	for _, opt := range opts {
+		opt(&ret.opts)
+	}
+	return ret
+}
+
+func (c *optionsClient) GetCounter(name string, tagsList ...map[string]string) Counter {
+	c.d.describe(name, c.opts)
+	return c.Client.GetCounter(name, tagsList...)
+}
+
+func (c *optionsClient) GetFloat64Metric(measurement string, tags ...map[string]string) Float64Metric {
+	c.d.describe(measurement, c.opts)
+	return c.Client.GetFloat64Metric(measurement, tags...)
+}
+
+func (c *optionsClient) GetInt64Metric(measurement string, tags ...map[string]string) Int64Metric {
+	c.d.describe(measurement, c.opts)
+	return c.Client.GetInt64Metric(measurement, tags...)
+}
+
+func (c *optionsClient) GetBoolMetric(name string, tags ...map[string]string) BoolMetric {
+	c.d.describe(name, c.opts)
+	return c.Client.GetBoolMetric(name, tags...)
+}
+
+func (c *optionsClient) GetFloat64SummaryMetric(measurement string, tags ...map[string]string) Float64SummaryMetric {
+	c.d.describe(measurement, c.opts)
+	return c.Client.GetFloat64SummaryMetric(measurement, tags...)
+}
+
+func (c *optionsClient) GetHistogram(measurement string, buckets []float64, tags ...map[string]string) Float64HistogramMetric {
+	c.d.describe(measurement, c.opts)
+	return c.Client.GetHistogram(measurement, buckets, tags...)
+}
+
+func (c *optionsClient) GetSummary(measurement string, objectives map[float64]float64, tags ...map[string]string) Float64SummaryMetric {
+	c.d.describe(measurement, c.opts)
+	return c.Client.GetSummary(measurement, objectives, tags...)
+}
+
+// NewLiveness creates the liveness using this Client so that the underlying
+// metric is described.
+func (c *optionsClient) NewLiveness(name string, tagsList ...map[string]string) Liveness {
+	return newLiveness(c, name, true, tagsList...)
+}
+
+// NewTimer creates the timer using this Client so that the underlying metric
+// is described.
+func (c *optionsClient) NewTimer(name string, tagsList ...map[string]string) Timer {
+	return newTimer(c, name, true, tagsList...)
+}
+
+// NewSlidingWindowTimer creates the timer using this Client so that the
+// underlying metrics are described.
+func (c *optionsClient) NewSlidingWindowTimer(name string, window time.Duration, tagsList ...map[string]string) SlidingWindowTimer {
+	t := newSlidingWindowTimer(c, name, window, tagsList...)
+	t.start()
+	return t
+}
+
+func (c *optionsClient) WithOptions(opts ...MetricOption) Client {
+	ret := &optionsClient{
+		Client: c.Client,
+		d:      c.d,
+		opts:   c.opts,
+	}
+	for _, opt := range opts {
+		opt(&ret.opts)
+	}
+	return ret
+}
+
+// WithOptions returns a Client which uses the default client to create
+// metrics described by the given options, eg.
+//
+//	metrics2.WithOptions(metrics2.WithHelp("Time to sync."), metrics2.WithUnit("seconds")).GetFloat64Metric("sync_duration")
+func WithOptions(opts ...MetricOption) Client {
+	return defaultClient.WithOptions(opts...)
+}
+
+// Validate that the concrete structs faithfully implement their respective interfaces.
+var _ Client = (*optionsClient)(nil)
@@ -0,0 +1,68 @@
+package metrics2
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+)
+
+// getHelp returns the HELP text of the given metric.
+func getHelp(t *testing.T, name string) string {
+	mfs, err := prometheus.DefaultRegisterer.(*prometheus.Registry).Gather()
+	require.NoError(t, err)
+	for _, mf := range mfs {
+		if mf.GetName() == name {
+			return mf.GetHelp()
+		}
+	}
+	require.Fail(t, "No such metric", name)
+	return ""
+}
+
+func TestWithOptions(t *testing.T) {
+	c := getPromClient()
+
+	// Existing calls are described by the measurement name.
+	c.GetInt64Metric("undescribed").Update(1)
+	require.Equal(t, "undescribed", getHelp(t, "undescribed"))
+
+	c.WithOptions(WithHelp("Number of widgets.")).GetInt64Metric("widgets").Update(1)
+	require.Equal(t, "Number of widgets.", getHelp(t, "widgets"))
+
+	c.WithOptions(WithHelp("Size of the cache."), WithUnit("bytes")).GetFloat64Metric("cache.size").Update(1)
+	require.Equal(t, "Size of the cache. (unit: bytes)", getHelp(t, "cache_size"))
+
+	c.WithOptions(WithUnit("seconds")).GetHistogram("latency", nil).Observe(1)
+	require.Equal(t, "latency (unit: seconds)", getHelp(t, "latency"))
+
+	// Options may be combined across calls.
+	c.WithOptions(WithHelp("Queue length.")).WithOptions(WithUnit("tasks")).GetCounter("queue").Inc(1)
+	require.Equal(t, "Queue length. (unit: tasks)", getHelp(t, "queue"))
+
+	// Timers describe their underlying summary.
+	c.WithOptions(WithHelp("Time to sync.")).NewTimer("sync").Stop()
+	require.Equal(t, "Time to sync.", getHelp(t, "timer_sync_ns"))
+
+	// Liveness describes its underlying gauge.
+	l := c.WithOptions(WithHelp("Time since the last sync."), WithUnit("seconds")).NewLiveness("sync")
+	defer l.Close()
+	l.ManualReset(time.Now())
+	require.Equal(t, "Time since the last sync. (unit: seconds)", getHelp(t, "liveness_sync_s"))
+}
+
+func TestWithOptions_PushClient(t *testing.T) {
+	c := getPromClient()
+	pc, err := newPushClient(c, prometheus.DefaultRegisterer.(*prometheus.Registry), PushConfig{
+		Backend: PushGateway,
+		URL:     "http://example.com",
+		Job:     "my_job",
+	})
+	require.NoError(t, err)
+	described := pc.WithOptions(WithHelp("Pushed widgets."))
+	described.GetInt64Metric("pushed_widgets").Update(1)
+	require.Equal(t, "Pushed widgets.", getHelp(t, "pushed_widgets"))
+	// Flush still pushes using the pushClient.
+	require.Equal(t, Client(pc), described.(*optionsClient).Client)
+}
@@ -0,0 +1,271 @@
+package metrics2
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/go/util"
+)
+
+// The types below are the subset of the OTLP metrics protocol, in its JSON
+// encoding, which is needed to export the metrics of a prometheus.Gatherer.
+// See https://github.com/open-telemetry/opentelemetry-proto. As in the proto3
+// JSON mapping, 64 bit integers are encoded as strings.
+
+// otlpAggregationTemporalityCumulative is AGGREGATION_TEMPORALITY_CUMULATIVE.
+const otlpAggregationTemporalityCumulative = 2
+
+type otlpRequest struct {
+	ResourceMetrics []otlpResourceMetrics `json:"resourceMetrics"`
+}
+
+type otlpResourceMetrics struct {
+	Resource     otlpResource       `json:"resource"`
+	ScopeMetrics []otlpScopeMetrics `json:"scopeMetrics"`
+}
+
+type otlpResource struct {
+	Attributes []otlpKeyValue `json:"attributes"`
+}
+
+type otlpScopeMetrics struct {
+	Scope   otlpScope    `json:"scope"`
+	Metrics []otlpMetric `json:"metrics"`
+}
+
+type otlpScope struct {
+	Name string `json:"name"`
+}
+
+type otlpKeyValue struct {
+	Key   string       `json:"key"`
+	Value otlpAnyValue `json:"value"`
+}
+
+type otlpAnyValue struct {
+	StringValue string `json:"stringValue"`
+}
+
+type otlpMetric struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description,omitempty"`
+	Gauge       *otlpGauge     `json:"gauge,omitempty"`
+	Sum         *otlpSum       `json:"sum,omitempty"`
+	Summary     *otlpSummary   `json:"summary,omitempty"`
+	Histogram   *otlpHistogram `json:"histogram,omitempty"`
+}
+
+type otlpGauge struct {
+	DataPoints []otlpNumberDataPoint `json:"dataPoints"`
+}
+
+type otlpSum struct {
+	DataPoints             []otlpNumberDataPoint `json:"dataPoints"`
+	AggregationTemporality int                   `json:"aggregationTemporality"`
+	IsMonotonic            bool                  `json:"isMonotonic"`
+}
+
+type otlpNumberDataPoint struct {
+	Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+	StartTimeUnixNano string         `json:"startTimeUnixNano,omitempty"`
+	TimeUnixNano      string         `json:"timeUnixNano"`
+	AsDouble          float64        `json:"asDouble"`
+}
+
+type otlpSummary struct {
+	DataPoints []otlpSummaryDataPoint `json:"dataPoints"`
+}
+
+type otlpSummaryDataPoint struct {
+	Attributes        []otlpKeyValue      `json:"attributes,omitempty"`
+	StartTimeUnixNano string              `json:"startTimeUnixNano"`
+	TimeUnixNano      string              `json:"timeUnixNano"`
+	Count             string              `json:"count"`
+	Sum               float64             `json:"sum"`
+	QuantileValues    []otlpQuantileValue `json:"quantileValues,omitempty"`
+}
+
+type otlpQuantileValue struct {
+	Quantile float64 `json:"quantile"`
+	Value    float64 `json:"value"`
+}
+
+type otlpHistogram struct {
+	DataPoints             []otlpHistogramDataPoint `json:"dataPoints"`
+	AggregationTemporality int                      `json:"aggregationTemporality"`
+}
+
+type otlpHistogramDataPoint struct {
+	Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+	StartTimeUnixNano string         `json:"startTimeUnixNano"`
+	TimeUnixNano      string         `json:"timeUnixNano"`
+	Count             string         `json:"count"`
+	Sum               float64        `json:"sum"`
+	// BucketCounts are the number of values in each bucket, ie. unlike
+	// Prometheus buckets they are not cumulative, including a final bucket
+	// for values larger than the last of ExplicitBounds.
+	BucketCounts   []string  `json:"bucketCounts"`
+	ExplicitBounds []float64 `json:"explicitBounds"`
+}
+
+// otlpAttributes converts Prometheus labels to OTLP attributes.
+func otlpAttributes(labels []*dto.LabelPair) []otlpKeyValue {
+	ret := make([]otlpKeyValue, 0, len(labels))
+	for _, l := range labels {
+		ret = append(ret, otlpKeyValue{
+			Key:   l.GetName(),
+			Value: otlpAnyValue{StringValue: l.GetValue()},
+		})
+	}
+	return ret
+}
+
+// otlpTime encodes the given time as nanoseconds since the epoch.
+func otlpTime(t time.Time) string {
+	return strconv.FormatInt(t.UnixNano(), 10)
+}
+
+// toOTLPMetric converts the given Prometheus metric family to an OTLP metric.
+// Cumulative values are reported as starting at the given time.
+func toOTLPMetric(mf *dto.MetricFamily, start, now time.Time) otlpMetric {
+	ret := otlpMetric{
+		Name:        mf.GetName(),
+		Description: mf.GetHelp(),
+	}
+	startNano, nowNano := otlpTime(start), otlpTime(now)
+	switch mf.GetType() {
+	case dto.MetricType_COUNTER:
+		ret.Sum = &otlpSum{
+			AggregationTemporality: otlpAggregationTemporalityCumulative,
+			IsMonotonic:            true,
+		}
+		for _, m := range mf.GetMetric() {
+			ret.Sum.DataPoints = append(ret.Sum.DataPoints, otlpNumberDataPoint{
+				Attributes:        otlpAttributes(m.GetLabel()),
+				StartTimeUnixNano: startNano,
+				TimeUnixNano:      nowNano,
+				AsDouble:          m.GetCounter().GetValue(),
+			})
+		}
+	case dto.MetricType_SUMMARY:
+		ret.Summary = &otlpSummary{}
+		for _, m := range mf.GetMetric() {
+			dp := otlpSummaryDataPoint{
+				Attributes:        otlpAttributes(m.GetLabel()),
+				StartTimeUnixNano: startNano,
+				TimeUnixNano:      nowNano,
+				Count:             strconv.FormatUint(m.GetSummary().GetSampleCount(), 10),
+				Sum:               m.GetSummary().GetSampleSum(),
+			}
+			for _, q := range m.GetSummary().GetQuantile() {
+				// Quantiles without observations are NaN, which JSON can't
+				// encode.
+				if math.IsNaN(q.GetValue()) {
+					continue
+				}
+				dp.QuantileValues = append(dp.QuantileValues, otlpQuantileValue{
+					Quantile: q.GetQuantile(),
+					Value:    q.GetValue(),
+				})
+			}
+			ret.Summary.DataPoints = append(ret.Summary.DataPoints, dp)
+		}
+	case dto.MetricType_HISTOGRAM:
+		ret.Histogram = &otlpHistogram{
+			AggregationTemporality: otlpAggregationTemporalityCumulative,
+		}
+		for _, m := range mf.GetMetric() {
+			h := m.GetHistogram()
+			dp := otlpHistogramDataPoint{
+				Attributes:        otlpAttributes(m.GetLabel()),
+				StartTimeUnixNano: startNano,
+				TimeUnixNano:      nowNano,
+				Count:             strconv.FormatUint(h.GetSampleCount(), 10),
+				Sum:               h.GetSampleSum(),
+				BucketCounts:      []string{},
+				ExplicitBounds:    []float64{},
+			}
+			var prev uint64
+			for _, b := range h.GetBucket() {
+				if math.IsInf(b.GetUpperBound(), 1) {
+					continue
+				}
+				dp.ExplicitBounds = append(dp.ExplicitBounds, b.GetUpperBound())
+				dp.BucketCounts = append(dp.BucketCounts, strconv.FormatUint(b.GetCumulativeCount()-prev, 10))
+				prev = b.GetCumulativeCount()
+			}
+			dp.BucketCounts = append(dp.BucketCounts, strconv.FormatUint(h.GetSampleCount()-prev, 10))
+			ret.Histogram.DataPoints = append(ret.Histogram.DataPoints, dp)
+		}
+	default:
+		// Gauges and untyped metrics, which includes the Int64Metric and
+		// Counter implementations of promClient.
+		ret.Gauge = &otlpGauge{}
+		for _, m := range mf.GetMetric() {
+			v := m.GetGauge().GetValue()
+			if mf.GetType() == dto.MetricType_UNTYPED {
+				v = m.GetUntyped().GetValue()
+			}
+			ret.Gauge.DataPoints = append(ret.Gauge.DataPoints, otlpNumberDataPoint{
+				Attributes:   otlpAttributes(m.GetLabel()),
+				TimeUnixNano: nowNano,
+				AsDouble:     v,
+			})
+		}
+	}
+	return ret
+}
+
+// pushOTLP exports the metrics of the given Gatherer to the given OTLP/HTTP
+// metrics endpoint, identified by the given service name. Cumulative values
+// are reported as starting at the given time.
+func pushOTLP(client *http.Client, url, serviceName string, g prometheus.Gatherer, start, now time.Time) error {
+	mfs, err := g.Gather()
+	if err != nil {
+		return skerr.Wrapf(err, "gathering metrics")
+	}
+	metrics := make([]otlpMetric, 0, len(mfs))
+	for _, mf := range mfs {
+		metrics = append(metrics, toOTLPMetric(mf, start, now))
+	}
+	req := otlpRequest{
+		ResourceMetrics: []otlpResourceMetrics{
+			{
+				Resource: otlpResource{
+					Attributes: []otlpKeyValue{
+						{Key: "service.name", Value: otlpAnyValue{StringValue: serviceName}},
+					},
+				},
+				ScopeMetrics: []otlpScopeMetrics{
+					{
+						Scope:   otlpScope{Name: "go.skia.org/infra/go/metrics2"},
+						Metrics: metrics,
+					},
+				},
+			},
+		},
+	}
+	b, err := json.Marshal(req)
+	if err != nil {
+		return skerr.Wrapf(err, "encoding metrics")
+	}
+	resp, err := client.Post(url, "application/json", bytes.NewReader(b))
+	if err != nil {
+		return skerr.Wrapf(err, "exporting metrics to %s", url)
+	}
+	defer util.Close(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return skerr.Fmt("Exporting metrics to %s failed with status %d: %s", url, resp.StatusCode, string(body))
+	}
+	return nil
+}
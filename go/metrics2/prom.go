@@ -6,6 +6,8 @@ import (
 	"sort"
 	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 
@@ -38,6 +40,20 @@ type promInt64 struct {
 	i      int64
 	gauge  prometheus.Gauge
 	delete func() error
+
+	// deleted is true if the metric has been deleted, eg. by StartTTL. The
+	// next update calls restore to re-register the series, so that the
+	// metric is reported again.
+	deleted atomic.Bool
+	restore func() prometheus.Gauge
+
+	// measurement and tags identify the metric, eg. for ListMetrics and when
+	// it is written for aggregation by a parent process. counter is true if
+	// the metric backs a Counter.
+	measurement string
+	tags        map[string]string
+	counter     bool
+	updated     updateTime
 }
 
 func (m *promInt64) Get() int64 {
@@ -49,8 +65,12 @@ func (m *promInt64) Get() int64 {
 func (m *promInt64) Update(v int64) {
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
+	if m.deleted.Load() {
+		m.gauge = m.restore()
+	}
 	m.i = v
 	m.gauge.Set(float64(v))
+	m.updated.touch()
 }
 
 func (m *promInt64) Delete() error {
@@ -87,6 +107,16 @@ type promFloat64 struct {
 	i      float64
 	gauge  prometheus.Gauge
 	delete func() error
+
+	// deleted and restore are as for promInt64.
+	deleted atomic.Bool
+	restore func() prometheus.Gauge
+
+	// measurement and tags identify the metric, eg. for ListMetrics and when
+	// it is written for aggregation by a parent process.
+	measurement string
+	tags        map[string]string
+	updated     updateTime
 }
 
 func (m *promFloat64) Get() float64 {
@@ -98,21 +128,68 @@ func (m *promFloat64) Get() float64 {
 func (m *promFloat64) Update(v float64) {
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
+	if m.deleted.Load() {
+		m.gauge = m.restore()
+	}
 	m.i = v
 	m.gauge.Set(float64(v))
+	m.updated.touch()
 }
 
 func (m *promFloat64) Delete() error {
 	return m.delete()
 }
 
-// promFloat64Summary implements the Float64Metric interface.
+// promFloat64Summary implements the Float64SummaryMetric interface.
 type promFloat64Summary struct {
+	mutex    sync.Mutex
 	observer prometheus.Observer
+	delete   func() error
+
+	// deleted and restore are as for promInt64.
+	deleted atomic.Bool
+	restore func() prometheus.Observer
+
+	// measurement and tags identify the metric, eg. for ListMetrics.
+	measurement string
+	tags        map[string]string
+	updated     updateTime
 }
 
 func (m *promFloat64Summary) Observe(v float64) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if m.deleted.Load() {
+		m.observer = m.restore()
+	}
+	m.observer.Observe(v)
+	m.updated.touch()
+}
+
+// promFloat64Histogram implements the Float64HistogramMetric interface.
+type promFloat64Histogram struct {
+	mutex    sync.Mutex
+	observer prometheus.Observer
+	delete   func() error
+
+	// deleted and restore are as for promInt64.
+	deleted atomic.Bool
+	restore func() prometheus.Observer
+
+	// measurement and tags identify the metric, eg. for ListMetrics.
+	measurement string
+	tags        map[string]string
+	updated     updateTime
+}
+
+func (m *promFloat64Histogram) Observe(v float64) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if m.deleted.Load() {
+		m.observer = m.restore()
+	}
 	m.observer.Observe(v)
+	m.updated.touch()
 }
 
 // promCounter implements the Counter interface.
@@ -163,16 +240,29 @@ type promClient struct {
 	float64SummaryVecs  map[string]*prometheus.SummaryVec
 	float64Summaries    map[string]*promFloat64Summary
 	float64SummaryMutex sync.Mutex
+
+	float64HistogramVecs  map[string]*prometheus.HistogramVec
+	float64Histograms     map[string]*promFloat64Histogram
+	float64HistogramMutex sync.Mutex
+
+	guard cardinalityGuard
+
+	// options describe the metrics of each clean measurement.
+	options      map[string]metricOptions
+	optionsMutex sync.Mutex
 }
 
 func NewPromClient() *promClient {
 	return &promClient{
-		int64GaugeVecs:     map[string]*prometheus.GaugeVec{},
-		int64Gauges:        map[string]*promInt64{},
-		float64GaugeVecs:   map[string]*prometheus.GaugeVec{},
-		float64Gauges:      map[string]*promFloat64{},
-		float64SummaryVecs: map[string]*prometheus.SummaryVec{},
-		float64Summaries:   map[string]*promFloat64Summary{},
+		int64GaugeVecs:       map[string]*prometheus.GaugeVec{},
+		int64Gauges:          map[string]*promInt64{},
+		float64GaugeVecs:     map[string]*prometheus.GaugeVec{},
+		float64Gauges:        map[string]*promFloat64{},
+		float64SummaryVecs:   map[string]*prometheus.SummaryVec{},
+		float64Summaries:     map[string]*promFloat64Summary{},
+		float64HistogramVecs: map[string]*prometheus.HistogramVec{},
+		float64Histograms:    map[string]*promFloat64Histogram{},
+		options:              map[string]metricOptions{},
 	}
 }
 
@@ -218,6 +308,12 @@ func (p *promClient) commonGet(measurement string, tags ...map[string]string) (s
 
 func (p *promClient) GetInt64Metric(name string, tags ...map[string]string) Int64Metric {
 	measurement, cleanTags, keys, gaugeKey, gaugeVecKey := p.commonGet(name, tags...)
+	if !p.guard.allow(measurement, keys, gaugeKey) {
+		return &promInt64{
+			gauge:  newDetachedGauge(measurement),
+			delete: func() error { return nil },
+		}
+	}
 
 	p.int64Mutex.Lock()
 	defer p.int64Mutex.Unlock()
@@ -233,7 +329,7 @@ func (p *promClient) GetInt64Metric(name string, tags ...map[string]string) Int6
 		gaugeVec = prometheus.NewGaugeVec(
 			prometheus.GaugeOpts{
 				Name: measurement,
-				Help: measurement,
+				Help: p.helpText(measurement),
 			},
 			keys,
 		)
@@ -250,17 +346,40 @@ func (p *promClient) GetInt64Metric(name string, tags ...map[string]string) Int6
 		sklog.Fatalf("Failed to get gauge: %s", skerr.Wrap(err))
 	}
 	ret := &promInt64{
-		delete: func() error {
-			p.int64Mutex.Lock()
-			defer p.int64Mutex.Unlock()
-			if !gaugeVec.Delete(labels) {
-				return fmt.Errorf("Failed to delete metric %s-%#v.", measurement, labels)
-			}
-			delete(p.int64Gauges, gaugeKey)
-			return nil
-		},
-		gauge: gauge,
+		gauge:       gauge,
+		measurement: measurement,
+		tags:        cleanTags,
 	}
+	ret.delete = func() error {
+		p.int64Mutex.Lock()
+		defer p.int64Mutex.Unlock()
+		if !gaugeVec.Delete(labels) {
+			return fmt.Errorf("Failed to delete metric %s-%#v.", measurement, labels)
+		}
+		delete(p.int64Gauges, gaugeKey)
+		p.guard.forget(measurement, gaugeKey)
+		ret.deleted.Store(true)
+		return nil
+	}
+	ret.restore = func() prometheus.Gauge {
+		if !p.guard.allow(measurement, keys, gaugeKey) {
+			return newDetachedGauge(measurement)
+		}
+		p.int64Mutex.Lock()
+		defer p.int64Mutex.Unlock()
+		gauge, err := gaugeVec.GetMetricWith(labels)
+		if err != nil {
+			sklog.Fatalf("Failed to get gauge: %s", skerr.Wrap(err))
+		}
+		// The metric may have been created again in the meantime, in
+		// which case both share the same series.
+		if _, ok := p.int64Gauges[gaugeKey]; !ok {
+			p.int64Gauges[gaugeKey] = ret
+		}
+		ret.deleted.Store(false)
+		return gauge
+	}
+	ret.updated.touch()
 
 	p.int64Gauges[gaugeKey] = ret
 	return ret
@@ -275,13 +394,23 @@ func (p *promClient) GetBoolMetric(name string, tags ...map[string]string) BoolM
 
 func (p *promClient) GetCounter(name string, tags ...map[string]string) Counter {
 	i64 := p.GetInt64Metric(name, tags...)
+	pi := i64.(*promInt64)
+	pi.mutex.Lock()
+	pi.counter = true
+	pi.mutex.Unlock()
 	return &promCounter{
-		pi: (i64.(*promInt64)),
+		pi: pi,
 	}
 }
 
 func (p *promClient) GetFloat64Metric(name string, tags ...map[string]string) Float64Metric {
 	measurement, cleanTags, keys, gaugeKey, gaugeVecKey := p.commonGet(name, tags...)
+	if !p.guard.allow(measurement, keys, gaugeKey) {
+		return &promFloat64{
+			gauge:  newDetachedGauge(measurement),
+			delete: func() error { return nil },
+		}
+	}
 
 	p.float64Mutex.Lock()
 	defer p.float64Mutex.Unlock()
@@ -297,7 +426,7 @@ func (p *promClient) GetFloat64Metric(name string, tags ...map[string]string) Fl
 		gaugeVec = prometheus.NewGaugeVec(
 			prometheus.GaugeOpts{
 				Name: measurement,
-				Help: measurement,
+				Help: p.helpText(measurement),
 			},
 			keys,
 		)
@@ -314,23 +443,60 @@ func (p *promClient) GetFloat64Metric(name string, tags ...map[string]string) Fl
 		sklog.Fatalf("Failed to get gauge: %s", skerr.Wrap(err))
 	}
 	ret := &promFloat64{
-		delete: func() error {
-			p.float64Mutex.Lock()
-			defer p.float64Mutex.Unlock()
-			if !gaugeVec.Delete(labels) {
-				return fmt.Errorf("Failed to delete metric %s-%#v.", measurement, labels)
-			}
-			delete(p.float64Gauges, gaugeKey)
-			return nil
-		},
-		gauge: gauge,
+		gauge:       gauge,
+		measurement: measurement,
+		tags:        cleanTags,
+	}
+	ret.delete = func() error {
+		p.float64Mutex.Lock()
+		defer p.float64Mutex.Unlock()
+		if !gaugeVec.Delete(labels) {
+			return fmt.Errorf("Failed to delete metric %s-%#v.", measurement, labels)
+		}
+		delete(p.float64Gauges, gaugeKey)
+		p.guard.forget(measurement, gaugeKey)
+		ret.deleted.Store(true)
+		return nil
 	}
+	ret.restore = func() prometheus.Gauge {
+		if !p.guard.allow(measurement, keys, gaugeKey) {
+			return newDetachedGauge(measurement)
+		}
+		p.float64Mutex.Lock()
+		defer p.float64Mutex.Unlock()
+		gauge, err := gaugeVec.GetMetricWith(labels)
+		if err != nil {
+			sklog.Fatalf("Failed to get gauge: %s", skerr.Wrap(err))
+		}
+		if _, ok := p.float64Gauges[gaugeKey]; !ok {
+			p.float64Gauges[gaugeKey] = ret
+		}
+		ret.deleted.Store(false)
+		return gauge
+	}
+	ret.updated.touch()
 	p.float64Gauges[gaugeKey] = ret
 	return ret
 }
 
 func (p *promClient) GetFloat64SummaryMetric(name string, tags ...map[string]string) Float64SummaryMetric {
+	return p.GetSummary(name, nil, tags...)
+}
+
+func (p *promClient) GetSummary(name string, objectives map[float64]float64, tags ...map[string]string) Float64SummaryMetric {
+	if len(objectives) == 0 {
+		objectives = DefaultSummaryObjectives
+	}
 	measurement, cleanTags, keys, summaryKey, summaryVecKey := p.commonGet(name, tags...)
+	if !p.guard.allow(measurement, keys, summaryKey) {
+		return &promFloat64Summary{
+			observer: prometheus.NewSummary(prometheus.SummaryOpts{
+				Name:       measurement,
+				Help:       p.helpText(measurement),
+				Objectives: objectives,
+			}),
+		}
+	}
 
 	p.float64SummaryMutex.Lock()
 	defer p.float64SummaryMutex.Unlock()
@@ -346,8 +512,8 @@ func (p *promClient) GetFloat64SummaryMetric(name string, tags ...map[string]str
 		summaryVec = prometheus.NewSummaryVec(
 			prometheus.SummaryOpts{
 				Name:       measurement,
-				Help:       measurement,
-				Objectives: map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.99: 0.001},
+				Help:       p.helpText(measurement),
+				Objectives: objectives,
 			},
 			keys,
 		)
@@ -362,14 +528,184 @@ func (p *promClient) GetFloat64SummaryMetric(name string, tags ...map[string]str
 	if err != nil {
 		sklog.Fatalf("Failed to get observer: %s", skerr.Wrap(err))
 	}
+	labels := prometheus.Labels(cleanTags)
 	ret := &promFloat64Summary{
-		observer: observer,
+		observer:    observer,
+		measurement: measurement,
+		tags:        cleanTags,
+	}
+	ret.delete = func() error {
+		p.float64SummaryMutex.Lock()
+		defer p.float64SummaryMutex.Unlock()
+		if !summaryVec.Delete(labels) {
+			return fmt.Errorf("Failed to delete metric %s-%#v.", measurement, labels)
+		}
+		delete(p.float64Summaries, summaryKey)
+		p.guard.forget(measurement, summaryKey)
+		ret.deleted.Store(true)
+		return nil
 	}
+	ret.restore = func() prometheus.Observer {
+		if !p.guard.allow(measurement, keys, summaryKey) {
+			return prometheus.NewSummary(prometheus.SummaryOpts{
+				Name:       measurement,
+				Help:       p.helpText(measurement),
+				Objectives: objectives,
+			})
+		}
+		p.float64SummaryMutex.Lock()
+		defer p.float64SummaryMutex.Unlock()
+		observer, err := summaryVec.GetMetricWith(labels)
+		if err != nil {
+			sklog.Fatalf("Failed to get observer: %s", skerr.Wrap(err))
+		}
+		if _, ok := p.float64Summaries[summaryKey]; !ok {
+			p.float64Summaries[summaryKey] = ret
+		}
+		ret.deleted.Store(false)
+		return observer
+	}
+	ret.updated.touch()
 
 	p.float64Summaries[summaryKey] = ret
 	return ret
 }
 
+func (p *promClient) GetHistogram(name string, buckets []float64, tags ...map[string]string) Float64HistogramMetric {
+	if len(buckets) == 0 {
+		buckets = DefaultHistogramBuckets
+	}
+	measurement, cleanTags, keys, histogramKey, histogramVecKey := p.commonGet(name, tags...)
+	if !p.guard.allow(measurement, keys, histogramKey) {
+		return &promFloat64Histogram{
+			observer: prometheus.NewHistogram(prometheus.HistogramOpts{
+				Name:    measurement,
+				Help:    p.helpText(measurement),
+				Buckets: buckets,
+			}),
+		}
+	}
+
+	p.float64HistogramMutex.Lock()
+	defer p.float64HistogramMutex.Unlock()
+
+	if ret, ok := p.float64Histograms[histogramKey]; ok {
+		return ret
+	}
+
+	// Didn't find the metric, so we need to look for a HistogramVec to create it under.
+	histogramVec, ok := p.float64HistogramVecs[histogramVecKey]
+	if !ok {
+		// Register a new histogram vec.
+		histogramVec = prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Name:    measurement,
+				Help:    p.helpText(measurement),
+				Buckets: buckets,
+			},
+			keys,
+		)
+		err := prometheus.Register(histogramVec)
+		if err != nil {
+			sklog.Fatalf("Failed to register %q %v: %s", measurement, cleanTags, skerr.Wrap(err))
+		}
+		p.float64HistogramVecs[histogramVecKey] = histogramVec
+	}
+
+	observer, err := histogramVec.GetMetricWith(cleanTags)
+	if err != nil {
+		sklog.Fatalf("Failed to get observer: %s", skerr.Wrap(err))
+	}
+	labels := prometheus.Labels(cleanTags)
+	ret := &promFloat64Histogram{
+		observer:    observer,
+		measurement: measurement,
+		tags:        cleanTags,
+	}
+	ret.delete = func() error {
+		p.float64HistogramMutex.Lock()
+		defer p.float64HistogramMutex.Unlock()
+		if !histogramVec.Delete(labels) {
+			return fmt.Errorf("Failed to delete metric %s-%#v.", measurement, labels)
+		}
+		delete(p.float64Histograms, histogramKey)
+		p.guard.forget(measurement, histogramKey)
+		ret.deleted.Store(true)
+		return nil
+	}
+	ret.restore = func() prometheus.Observer {
+		if !p.guard.allow(measurement, keys, histogramKey) {
+			return prometheus.NewHistogram(prometheus.HistogramOpts{
+				Name:    measurement,
+				Help:    p.helpText(measurement),
+				Buckets: buckets,
+			})
+		}
+		p.float64HistogramMutex.Lock()
+		defer p.float64HistogramMutex.Unlock()
+		observer, err := histogramVec.GetMetricWith(labels)
+		if err != nil {
+			sklog.Fatalf("Failed to get observer: %s", skerr.Wrap(err))
+		}
+		if _, ok := p.float64Histograms[histogramKey]; !ok {
+			p.float64Histograms[histogramKey] = ret
+		}
+		ret.deleted.Store(false)
+		return observer
+	}
+	ret.updated.touch()
+
+	p.float64Histograms[histogramKey] = ret
+	return ret
+}
+
+// describe implements describer.
+func (c *promClient) describe(measurement string, opts metricOptions) {
+	c.optionsMutex.Lock()
+	defer c.optionsMutex.Unlock()
+	c.options[clean(measurement)] = opts
+}
+
+// helpText returns the HELP text of the given clean measurement.
+func (c *promClient) helpText(measurement string) string {
+	c.optionsMutex.Lock()
+	defer c.optionsMutex.Unlock()
+	return c.options[measurement].helpText(measurement)
+}
+
+// Snapshot returns the metrics of prometheus.DefaultRegisterer, where
+// promClient registers its metrics, which includes metrics registered by other
+// libraries.
+func (c *promClient) Snapshot() (Snapshot, error) {
+	g, ok := prometheus.DefaultRegisterer.(prometheus.Gatherer)
+	if !ok {
+		g = prometheus.DefaultGatherer
+	}
+	return snapshotGatherer(g)
+}
+
+func (c *promClient) WithOptions(opts ...MetricOption) Client {
+	return newOptionsClient(c, c, opts...)
+}
+
+// newDetachedGauge returns a gauge which is not registered and is therefore
+// not exported. It is returned in place of metrics rejected by the cardinality
+// guard so that callers can continue to use them.
+func newDetachedGauge(measurement string) prometheus.Gauge {
+	return prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: measurement,
+		Help: measurement,
+	})
+}
+
+func (c *promClient) SetMetricSchema(measurement string, schema MetricSchema) {
+	c.guard.setSchema(measurement, schema)
+}
+
+func (c *promClient) SetDefaultMaxSeries(maxSeries int) {
+	c.guard.setDefaultMaxSeries(maxSeries)
+}
+
 func (c *promClient) Flush() error {
 	// The Flush is a lie.
 	return nil
@@ -383,6 +719,12 @@ func (c *promClient) NewTimer(name string, tagsList ...map[string]string) Timer
 	return newTimer(c, name, true, tagsList...)
 }
 
+func (c *promClient) NewSlidingWindowTimer(name string, window time.Duration, tagsList ...map[string]string) SlidingWindowTimer {
+	t := newSlidingWindowTimer(c, name, window, tagsList...)
+	t.start()
+	return t
+}
+
 func (c *promClient) Int64MetricExists(name string, tags ...map[string]string) bool {
 	_, _, _, gaugeKey, _ := c.commonGet(name, tags...)
 
@@ -398,5 +740,6 @@ var _ Int64Metric = (*promInt64)(nil)
 var _ BoolMetric = (*promBool)(nil)
 var _ Float64Metric = (*promFloat64)(nil)
 var _ Float64SummaryMetric = (*promFloat64Summary)(nil)
+var _ Float64HistogramMetric = (*promFloat64Histogram)(nil)
 var _ Counter = (*promCounter)(nil)
 var _ Client = (*promClient)(nil)
@@ -170,3 +170,68 @@ func TestPanicOn(t *testing.T) {
 			require.Fail(t, "Should have panic'd by now.")
 	*/
 }
+
+func TestHistogram(t *testing.T) {
+	c := getPromClient()
+	labels := map[string]string{"some_key": "some-value"}
+	h := c.GetHistogram("a.h", []float64{1, 10}, labels)
+	require.NotNil(t, h)
+	require.NotNil(t, c.float64HistogramVecs["a_h [some_key]"])
+	require.NotNil(t, c.float64Histograms["a_h-some_key-some-value"])
+
+	h.Observe(0.5)
+	h.Observe(5)
+	h = c.GetHistogram("a.h", []float64{1, 10}, labels)
+	h.Observe(50)
+	require.Equal(t, "3", metrics_util.GetRecordedMetric(t, "a_h_count", labels))
+	require.Equal(t, "55.5", metrics_util.GetRecordedMetric(t, "a_h_sum", labels))
+
+	// Prometheus lists the "le" label after all others, so check the buckets
+	// of a histogram without tags.
+	h = c.GetHistogram("buckets", []float64{1, 10})
+	h.Observe(0.5)
+	h.Observe(5)
+	h.Observe(50)
+	require.Equal(t, "1", metrics_util.GetRecordedMetric(t, "buckets_bucket", map[string]string{"le": "1"}))
+	require.Equal(t, "2", metrics_util.GetRecordedMetric(t, "buckets_bucket", map[string]string{"le": "10"}))
+	require.Equal(t, "3", metrics_util.GetRecordedMetric(t, "buckets_bucket", map[string]string{"le": "+Inf"}))
+
+	h = c.GetHistogram("default_buckets", nil)
+	h.Observe(0.2)
+	require.Equal(t, "0", metrics_util.GetRecordedMetric(t, "default_buckets_bucket", map[string]string{"le": "0.1"}))
+	require.Equal(t, "1", metrics_util.GetRecordedMetric(t, "default_buckets_bucket", map[string]string{"le": "0.25"}))
+}
+
+func TestSummary(t *testing.T) {
+	c := getPromClient()
+	labels := map[string]string{"some_key": "some-value"}
+	s := c.GetSummary("a.s", map[float64]float64{0.75: 0.01}, labels)
+	require.NotNil(t, s)
+	require.NotNil(t, c.float64SummaryVecs["a_s [some_key]"])
+	s.Observe(1)
+	s.Observe(2)
+	require.Equal(t, "2", metrics_util.GetRecordedMetric(t, "a_s_count", labels))
+	require.Equal(t, "3", metrics_util.GetRecordedMetric(t, "a_s_sum", labels))
+
+	// Prometheus lists the "quantile" label after all others, so check the
+	// quantiles of summaries without tags.
+	s = c.GetSummary("objectives", map[float64]float64{0.75: 0.01})
+	for i := 1; i <= 4; i++ {
+		s.Observe(float64(i))
+	}
+	require.Equal(t, "3", metrics_util.GetRecordedMetric(t, "objectives", map[string]string{"quantile": "0.75"}))
+	require.Equal(t, `Could not find anything for objectives{quantile="0.5"}`, metrics_util.GetRecordedMetric(t, "objectives", map[string]string{"quantile": "0.5"}))
+
+	s = c.GetFloat64SummaryMetric("default_objectives")
+	s.Observe(1)
+	require.Equal(t, "1", metrics_util.GetRecordedMetric(t, "default_objectives", map[string]string{"quantile": "0.99"}))
+}
+
+func TestFuncTimer(t *testing.T) {
+	c := getPromClient()
+	labels := map[string]string{"package": "pkg", "func": "fn"}
+	timer := newFuncTimer(c, labels)
+	timer.Stop()
+	require.Equal(t, "1", metrics_util.GetRecordedMetric(t, MEASUREMENT_FUNC_TIMER_HISTOGRAM+"_count", labels))
+	require.Equal(t, "1", metrics_util.GetRecordedMetric(t, "timer_func_timer_ns_count", map[string]string{"func": "fn", "name": NAME_FUNC_TIMER, "package": "pkg", "type": MEASUREMENT_TIMER}))
+}
@@ -0,0 +1,126 @@
+package metrics2
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/push"
+
+	"go.skia.org/infra/go/cleanup"
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/go/sklog"
+)
+
+// Supported values for PushConfig.Backend.
+const (
+	// PushGateway pushes metrics to a Prometheus Pushgateway.
+	PushGateway = "pushgateway"
+
+	// OTLP exports metrics to an OpenTelemetry collector using OTLP over
+	// HTTP, encoded as JSON.
+	OTLP = "otlp"
+)
+
+// PushConfig configures how metrics are pushed by InitPush.
+type PushConfig struct {
+	// Backend is the backend to which metrics are pushed, either PushGateway
+	// or OTLP.
+	Backend string
+
+	// URL is the URL of the backend, eg. "http://pushgateway:9091" for
+	// PushGateway or "http://otel-collector:4318/v1/metrics" for OTLP.
+	URL string
+
+	// Job identifies the process which pushes the metrics. It is used as the
+	// job label by PushGateway and as the service.name resource attribute by
+	// OTLP.
+	Job string
+
+	// Client is the HTTP client used to push metrics. Defaults to
+	// http.DefaultClient if nil.
+	Client *http.Client
+}
+
+// pushClient is a Client for short-lived processes which can't be scraped.
+// Metrics are recorded exactly like promClient, and Flush pushes them to the
+// configured backend.
+type pushClient struct {
+	*promClient
+	gatherer prometheus.Gatherer
+	push     func(prometheus.Gatherer) error
+}
+
+// newPushClient returns a pushClient which records metrics using the given
+// promClient and pushes the metrics of the given Gatherer to the backend
+// described by cfg.
+func newPushClient(c *promClient, gatherer prometheus.Gatherer, cfg PushConfig) (*pushClient, error) {
+	if cfg.URL == "" {
+		return nil, skerr.Fmt("URL is required to push metrics.")
+	}
+	if cfg.Job == "" {
+		return nil, skerr.Fmt("Job is required to push metrics.")
+	}
+	httpClient := cfg.Client
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	ret := &pushClient{
+		promClient: c,
+		gatherer:   gatherer,
+	}
+	switch cfg.Backend {
+	case PushGateway:
+		ret.push = func(g prometheus.Gatherer) error {
+			return push.New(cfg.URL, cfg.Job).Gatherer(g).Client(httpClient).Push()
+		}
+	case OTLP:
+		start := time.Now()
+		ret.push = func(g prometheus.Gatherer) error {
+			return pushOTLP(httpClient, cfg.URL, cfg.Job, g, start, time.Now())
+		}
+	default:
+		return nil, skerr.Fmt("Unknown push backend %q.", cfg.Backend)
+	}
+	return ret, nil
+}
+
+// Flush pushes the current value of all metrics to the backend.
+func (c *pushClient) Flush() error {
+	if err := c.push(c.gatherer); err != nil {
+		return skerr.Wrapf(err, "pushing metrics")
+	}
+	return nil
+}
+
+// WithOptions wraps this Client, rather than the embedded promClient, so that
+// Flush pushes metrics.
+func (c *pushClient) WithOptions(opts ...MetricOption) Client {
+	return newOptionsClient(c, c.promClient, opts...)
+}
+
+// InitPush initializes metrics to be pushed to the backend described by cfg
+// instead of being scraped, for short-lived processes such as command line
+// tools. Metrics are pushed when Flush is called on the default client and when
+// the process exits via cleanup.Cleanup(), eg. using common.Defer(). The
+// metrics helpers, eg. GetCounter and NewTimer, are used as usual.
+func InitPush(cfg PushConfig) error {
+	pc, ok := defaultClient.(*promClient)
+	if !ok {
+		return skerr.Fmt("Metrics have already been initialized for pushing.")
+	}
+	c, err := newPushClient(pc, prometheus.DefaultGatherer, cfg)
+	if err != nil {
+		return err
+	}
+	defaultClient = c
+	cleanup.AtExit(func() {
+		if err := c.Flush(); err != nil {
+			sklog.Errorf("Failed to push metrics: %s", err)
+		}
+	})
+	return nil
+}
+
+// Validate that the concrete structs faithfully implement their respective interfaces.
+var _ Client = (*pushClient)(nil)
@@ -0,0 +1,153 @@
+package metrics2
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPushClient_InvalidConfig(t *testing.T) {
+	c := getPromClient()
+	_, err := newPushClient(c, prometheus.NewRegistry(), PushConfig{Backend: "bogus", URL: "http://example.com", Job: "job"})
+	require.Error(t, err)
+	_, err = newPushClient(c, prometheus.NewRegistry(), PushConfig{Backend: OTLP, Job: "job"})
+	require.Error(t, err)
+	_, err = newPushClient(c, prometheus.NewRegistry(), PushConfig{Backend: OTLP, URL: "http://example.com"})
+	require.Error(t, err)
+}
+
+func TestPushClient_PushGateway(t *testing.T) {
+	c := getPromClient()
+	var path, body string
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		b, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		body = string(b)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer s.Close()
+
+	pc, err := newPushClient(c, prometheus.DefaultRegisterer.(*prometheus.Registry), PushConfig{
+		Backend: PushGateway,
+		URL:     s.URL,
+		Job:     "my_job",
+	})
+	require.NoError(t, err)
+	pc.GetCounter("pushed_counter", map[string]string{"some_key": "some-value"}).Inc(3)
+	require.NoError(t, pc.Flush())
+	require.Equal(t, "/metrics/job/my_job", path)
+	// The body is in the Prometheus protobuf format.
+	require.True(t, strings.Contains(body, "pushed_counter"))
+	require.True(t, strings.Contains(body, "some-value"))
+}
+
+func TestPushClient_PushGatewayError(t *testing.T) {
+	c := getPromClient()
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "nope", http.StatusInternalServerError)
+	}))
+	defer s.Close()
+
+	pc, err := newPushClient(c, prometheus.DefaultRegisterer.(*prometheus.Registry), PushConfig{
+		Backend: PushGateway,
+		URL:     s.URL,
+		Job:     "my_job",
+	})
+	require.NoError(t, err)
+	pc.GetCounter("pushed_counter").Inc(1)
+	require.Error(t, pc.Flush())
+}
+
+func TestPushClient_OTLP(t *testing.T) {
+	c := getPromClient()
+	var req otlpRequest
+	var contentType string
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contentType = r.Header.Get("Content-Type")
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer s.Close()
+
+	pc, err := newPushClient(c, prometheus.DefaultRegisterer.(*prometheus.Registry), PushConfig{
+		Backend: OTLP,
+		URL:     s.URL + "/v1/metrics",
+		Job:     "my_job",
+	})
+	require.NoError(t, err)
+	labels := map[string]string{"some_key": "some-value"}
+	pc.GetInt64Metric("otlp_gauge", labels).Update(4)
+	h := pc.GetHistogram("otlp_histogram", []float64{1, 10})
+	h.Observe(0.5)
+	h.Observe(5)
+	h.Observe(50)
+	pc.GetSummary("otlp_summary", map[float64]float64{0.5: 0.05}).Observe(2)
+	require.NoError(t, pc.Flush())
+
+	require.Equal(t, "application/json", contentType)
+	require.Len(t, req.ResourceMetrics, 1)
+	require.Equal(t, []otlpKeyValue{{Key: "service.name", Value: otlpAnyValue{StringValue: "my_job"}}}, req.ResourceMetrics[0].Resource.Attributes)
+	require.Len(t, req.ResourceMetrics[0].ScopeMetrics, 1)
+	metrics := map[string]otlpMetric{}
+	for _, m := range req.ResourceMetrics[0].ScopeMetrics[0].Metrics {
+		metrics[m.Name] = m
+	}
+
+	gauge := metrics["otlp_gauge"].Gauge
+	require.NotNil(t, gauge)
+	require.Len(t, gauge.DataPoints, 1)
+	require.Equal(t, 4.0, gauge.DataPoints[0].AsDouble)
+	require.Equal(t, []otlpKeyValue{{Key: "some_key", Value: otlpAnyValue{StringValue: "some-value"}}}, gauge.DataPoints[0].Attributes)
+
+	hist := metrics["otlp_histogram"].Histogram
+	require.NotNil(t, hist)
+	require.Equal(t, otlpAggregationTemporalityCumulative, hist.AggregationTemporality)
+	require.Len(t, hist.DataPoints, 1)
+	require.Equal(t, "3", hist.DataPoints[0].Count)
+	require.Equal(t, 55.5, hist.DataPoints[0].Sum)
+	require.Equal(t, []float64{1, 10}, hist.DataPoints[0].ExplicitBounds)
+	require.Equal(t, []string{"1", "1", "1"}, hist.DataPoints[0].BucketCounts)
+
+	summary := metrics["otlp_summary"].Summary
+	require.NotNil(t, summary)
+	require.Len(t, summary.DataPoints, 1)
+	require.Equal(t, "1", summary.DataPoints[0].Count)
+	require.Equal(t, []otlpQuantileValue{{Quantile: 0.5, Value: 2}}, summary.DataPoints[0].QuantileValues)
+}
+
+func TestToOTLPMetric_Counter(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	counter := prometheus.NewCounter(prometheus.CounterOpts{Name: "real_counter", Help: "A counter."})
+	require.NoError(t, reg.Register(counter))
+	counter.Add(2)
+	mfs, err := reg.Gather()
+	require.NoError(t, err)
+	require.Len(t, mfs, 1)
+
+	start := time.Unix(100, 0)
+	now := time.Unix(200, 0)
+	m := toOTLPMetric(mfs[0], start, now)
+	require.Equal(t, "real_counter", m.Name)
+	require.Equal(t, "A counter.", m.Description)
+	require.Nil(t, m.Gauge)
+	require.Equal(t, &otlpSum{
+		DataPoints: []otlpNumberDataPoint{
+			{
+				Attributes:        []otlpKeyValue{},
+				StartTimeUnixNano: "100000000000",
+				TimeUnixNano:      "200000000000",
+				AsDouble:          2,
+			},
+		},
+		AggregationTemporality: otlpAggregationTemporalityCumulative,
+		IsMonotonic:            true,
+	}, m.Sum)
+}
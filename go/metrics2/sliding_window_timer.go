@@ -0,0 +1,204 @@
+package metrics2
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"go.skia.org/infra/go/util"
+)
+
+const (
+	// SLIDING_WINDOW_REPORT_FREQUENCY is how often a SlidingWindowTimer
+	// reports its percentiles.
+	SLIDING_WINDOW_REPORT_FREQUENCY = 15 * time.Second
+
+	// SLIDING_WINDOW_MAX_SAMPLES is the maximum number of durations kept by a
+	// SlidingWindowTimer. The oldest durations are dropped beyond this.
+	SLIDING_WINDOW_MAX_SAMPLES = 10000
+
+	// TYPE_SLIDING_WINDOW_TIMER is the value of the "type" tag of the metrics
+	// reported by a SlidingWindowTimer.
+	TYPE_SLIDING_WINDOW_TIMER = "sliding_window_timer"
+)
+
+var (
+	// slidingWindowPercentiles are the percentiles reported by a
+	// SlidingWindowTimer.
+	slidingWindowPercentiles = []float64{50, 90, 99}
+)
+
+// SlidingWindowTimer measures durations and reports percentiles of the
+// durations within a sliding window as gauges, for services where histograms
+// are too heavy. The percentiles are reported in nanoseconds to the
+// "timer_<name>_window_ns" measurement, with a "percentile" tag of "p50",
+// "p90" or "p99".
+type SlidingWindowTimer interface {
+	// Start returns a started Timer which records the elapsed time when Stop
+	// is called.
+	Start() Timer
+
+	// Observe records the given duration.
+	Observe(d time.Duration)
+
+	// Percentile returns the given percentile, between 0 and 100, of the
+	// durations within the window, or zero if there are none.
+	Percentile(p float64) time.Duration
+
+	// Close stops the internal goroutine.
+	Close()
+}
+
+// slidingWindowSample is a duration observed at a point in time.
+type slidingWindowSample struct {
+	ts  time.Time
+	dur time.Duration
+}
+
+// slidingWindowTimer implements SlidingWindowTimer.
+type slidingWindowTimer struct {
+	cancelFn func()
+	window   time.Duration
+	metrics  map[float64]Float64Metric
+	now      func() time.Time
+
+	mtx sync.Mutex
+	// samples are sorted by time.
+	samples []slidingWindowSample
+}
+
+// newSlidingWindowTimer creates a new SlidingWindowTimer which reports
+// percentiles over the given window using the given Client.
+func newSlidingWindowTimer(c Client, name string, window time.Duration, tagsList ...map[string]string) *slidingWindowTimer {
+	measurement := fmt.Sprintf("%s_%s_window_ns", MEASUREMENT_TIMER, name)
+	metrics := make(map[float64]Float64Metric, len(slidingWindowPercentiles))
+	for _, p := range slidingWindowPercentiles {
+		tags := util.AddParams(map[string]string{}, tagsList...)
+		tags["name"] = name
+		tags["type"] = TYPE_SLIDING_WINDOW_TIMER
+		tags["percentile"] = fmt.Sprintf("p%g", p)
+		metrics[p] = c.GetFloat64Metric(measurement, tags)
+	}
+	return &slidingWindowTimer{
+		window:  window,
+		metrics: metrics,
+		now:     time.Now,
+	}
+}
+
+// start begins reporting the percentiles periodically.
+func (t *slidingWindowTimer) start() {
+	ctx, cancelFn := context.WithCancel(context.Background())
+	t.cancelFn = cancelFn
+	go util.RepeatCtx(ctx, SLIDING_WINDOW_REPORT_FREQUENCY, func(_ context.Context) { t.report() })
+}
+
+// Start implements SlidingWindowTimer.
+func (t *slidingWindowTimer) Start() Timer {
+	return &slidingWindowTimerRun{
+		t:     t,
+		begin: time.Now(),
+	}
+}
+
+// Observe implements SlidingWindowTimer.
+func (t *slidingWindowTimer) Observe(d time.Duration) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	t.samples = append(t.samples, slidingWindowSample{ts: t.now(), dur: d})
+	if len(t.samples) > SLIDING_WINDOW_MAX_SAMPLES {
+		t.samples = t.samples[len(t.samples)-SLIDING_WINDOW_MAX_SAMPLES:]
+	}
+}
+
+// pruneLocked removes the samples which are outside of the window and returns
+// the sorted durations of the remaining samples. Assumes the caller holds a
+// lock.
+func (t *slidingWindowTimer) pruneLocked() []time.Duration {
+	cutoff := t.now().Add(-t.window)
+	i := sort.Search(len(t.samples), func(i int) bool {
+		return t.samples[i].ts.After(cutoff)
+	})
+	t.samples = t.samples[i:]
+	ret := make([]time.Duration, 0, len(t.samples))
+	for _, s := range t.samples {
+		ret = append(ret, s.dur)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i] < ret[j]
+	})
+	return ret
+}
+
+// percentile returns the given percentile of the given sorted durations, using
+// the nearest-rank method.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	if rank > len(sorted) {
+		rank = len(sorted)
+	}
+	return sorted[rank-1]
+}
+
+// Percentile implements SlidingWindowTimer.
+func (t *slidingWindowTimer) Percentile(p float64) time.Duration {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	return percentile(t.pruneLocked(), p)
+}
+
+// report updates the percentile metrics.
+func (t *slidingWindowTimer) report() {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	sorted := t.pruneLocked()
+	for p, m := range t.metrics {
+		m.Update(float64(percentile(sorted, p)))
+	}
+}
+
+// Close implements SlidingWindowTimer.
+func (t *slidingWindowTimer) Close() {
+	if t.cancelFn != nil {
+		t.cancelFn()
+	}
+}
+
+// slidingWindowTimerRun is a Timer which records its elapsed time in a
+// SlidingWindowTimer.
+type slidingWindowTimerRun struct {
+	t     *slidingWindowTimer
+	begin time.Time
+}
+
+// Start implements Timer.
+func (r *slidingWindowTimerRun) Start() {
+	r.begin = time.Now()
+}
+
+// Stop implements Timer.
+func (r *slidingWindowTimerRun) Stop() time.Duration {
+	dur := time.Since(r.begin)
+	r.t.Observe(dur)
+	return dur
+}
+
+// NewSlidingWindowTimer creates a new SlidingWindowTimer which reports the
+// percentiles of the durations within the given window using the default
+// client.
+func NewSlidingWindowTimer(name string, window time.Duration, tags ...map[string]string) SlidingWindowTimer {
+	return defaultClient.NewSlidingWindowTimer(name, window, tags...)
+}
+
+// Validate that the concrete structs faithfully implement their respective interfaces.
+var _ SlidingWindowTimer = (*slidingWindowTimer)(nil)
+var _ Timer = (*slidingWindowTimerRun)(nil)
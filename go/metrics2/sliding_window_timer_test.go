@@ -0,0 +1,78 @@
+package metrics2
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPercentile(t *testing.T) {
+	require.Equal(t, time.Duration(0), percentile(nil, 50))
+	sorted := []time.Duration{}
+	for i := 1; i <= 100; i++ {
+		sorted = append(sorted, time.Duration(i))
+	}
+	require.Equal(t, time.Duration(1), percentile(sorted, 0))
+	require.Equal(t, time.Duration(50), percentile(sorted, 50))
+	require.Equal(t, time.Duration(90), percentile(sorted, 90))
+	require.Equal(t, time.Duration(99), percentile(sorted, 99))
+	require.Equal(t, time.Duration(100), percentile(sorted, 100))
+	require.Equal(t, time.Duration(3), percentile([]time.Duration{1, 2, 3}, 99))
+}
+
+func TestSlidingWindowTimer(t *testing.T) {
+	c := getPromClient()
+	now := time.Unix(1000, 0)
+	swt := newSlidingWindowTimer(c, "sync", time.Minute, map[string]string{"some_key": "some-value"})
+	swt.now = func() time.Time { return now }
+
+	// Old durations which fall out of the window.
+	for i := 0; i < 10; i++ {
+		swt.Observe(time.Hour)
+	}
+	now = now.Add(2 * time.Minute)
+	for i := 1; i <= 100; i++ {
+		swt.Observe(time.Duration(i) * time.Millisecond)
+	}
+	require.Equal(t, 50*time.Millisecond, swt.Percentile(50))
+	require.Equal(t, 99*time.Millisecond, swt.Percentile(99))
+
+	swt.report()
+	snap, err := c.Snapshot()
+	require.NoError(t, err)
+	tags := func(p string) map[string]string {
+		return map[string]string{"name": "sync", "type": TYPE_SLIDING_WINDOW_TIMER, "some_key": "some-value", "percentile": p}
+	}
+	require.Equal(t, Snapshot{
+		SeriesKey("timer_sync_window_ns", tags("p50")): float64(50 * time.Millisecond),
+		SeriesKey("timer_sync_window_ns", tags("p90")): float64(90 * time.Millisecond),
+		SeriesKey("timer_sync_window_ns", tags("p99")): float64(99 * time.Millisecond),
+	}, snap)
+
+	// Once the window has passed, the percentiles are reported as zero.
+	now = now.Add(time.Minute)
+	swt.report()
+	snap, err = c.Snapshot()
+	require.NoError(t, err)
+	v, ok := snap.Get("timer_sync_window_ns", tags("p99"))
+	require.True(t, ok)
+	require.Equal(t, 0.0, v)
+}
+
+func TestSlidingWindowTimer_MaxSamples(t *testing.T) {
+	c := getPromClient()
+	swt := newSlidingWindowTimer(c, "busy", time.Hour)
+	for i := 0; i < SLIDING_WINDOW_MAX_SAMPLES+10; i++ {
+		swt.Observe(time.Second)
+	}
+	require.Len(t, swt.samples, SLIDING_WINDOW_MAX_SAMPLES)
+}
+
+func TestSlidingWindowTimer_Start(t *testing.T) {
+	c := getPromClient()
+	swt := c.NewSlidingWindowTimer("started", time.Hour)
+	defer swt.Close()
+	d := swt.Start().Stop()
+	require.Equal(t, d, swt.Percentile(50))
+}
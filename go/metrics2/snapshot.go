@@ -0,0 +1,138 @@
+package metrics2
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+
+	"go.skia.org/infra/go/skerr"
+)
+
+// Snapshot is the value of every series at a point in time, keyed by
+// SeriesKey. Summaries and histograms are represented by their "_count" and
+// "_sum" series along with a series per quantile, with a "quantile" tag, or
+// per bucket, with an "le" tag, respectively.
+type Snapshot map[string]float64
+
+// SeriesKey returns the key of the series with the given measurement and tags
+// in a Snapshot, eg. `my_metric{a="1",b="2"}`. Unlike the Prometheus exposition
+// format, tags are always sorted by key.
+func SeriesKey(measurement string, tags map[string]string) string {
+	if len(tags) == 0 {
+		return measurement
+	}
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	pairs := make([]string, 0, len(keys))
+	for _, k := range keys {
+		pairs = append(pairs, fmt.Sprintf("%s=%q", k, tags[k]))
+	}
+	return measurement + "{" + strings.Join(pairs, ",") + "}"
+}
+
+// Get returns the value of the series with the given measurement and tags, and
+// whether it exists. The measurement and tag keys are cleaned the same way as
+// when the metric was created.
+func (s Snapshot) Get(measurement string, tags map[string]string) (float64, bool) {
+	cleanTags := make(map[string]string, len(tags))
+	for k, v := range tags {
+		cleanTags[clean(k)] = v
+	}
+	v, ok := s[SeriesKey(clean(measurement), cleanTags)]
+	return v, ok
+}
+
+// Diff returns the series whose values differ between the given earlier
+// Snapshot and this one, mapped to the change in their values. Series which
+// only exist in this Snapshot are treated as changing from zero, and series
+// which no longer exist as changing to zero.
+func (s Snapshot) Diff(before Snapshot) Snapshot {
+	ret := Snapshot{}
+	for k, v := range s {
+		if b, ok := before[k]; !ok || b != v {
+			ret[k] = v - b
+		}
+	}
+	for k, b := range before {
+		if _, ok := s[k]; !ok {
+			ret[k] = -b
+		}
+	}
+	return ret
+}
+
+// labelsToTags converts the given Prometheus labels to tags, adding the given
+// extra tag if extraKey is not empty.
+func labelsToTags(labels []*dto.LabelPair, extraKey, extraValue string) map[string]string {
+	ret := make(map[string]string, len(labels)+1)
+	for _, l := range labels {
+		ret[l.GetName()] = l.GetValue()
+	}
+	if extraKey != "" {
+		ret[extraKey] = extraValue
+	}
+	return ret
+}
+
+// formatBound formats a quantile or bucket bound the way Prometheus does.
+func formatBound(f float64) string {
+	if math.IsInf(f, 1) {
+		return "+Inf"
+	}
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+// snapshotGatherer returns a Snapshot of the metrics of the given Gatherer.
+func snapshotGatherer(g prometheus.Gatherer) (Snapshot, error) {
+	mfs, err := g.Gather()
+	if err != nil {
+		return nil, skerr.Wrapf(err, "gathering metrics")
+	}
+	ret := Snapshot{}
+	for _, mf := range mfs {
+		name := mf.GetName()
+		for _, m := range mf.GetMetric() {
+			labels := m.GetLabel()
+			switch mf.GetType() {
+			case dto.MetricType_COUNTER:
+				ret[SeriesKey(name, labelsToTags(labels, "", ""))] = m.GetCounter().GetValue()
+			case dto.MetricType_GAUGE:
+				ret[SeriesKey(name, labelsToTags(labels, "", ""))] = m.GetGauge().GetValue()
+			case dto.MetricType_UNTYPED:
+				ret[SeriesKey(name, labelsToTags(labels, "", ""))] = m.GetUntyped().GetValue()
+			case dto.MetricType_SUMMARY:
+				ret[SeriesKey(name+"_count", labelsToTags(labels, "", ""))] = float64(m.GetSummary().GetSampleCount())
+				ret[SeriesKey(name+"_sum", labelsToTags(labels, "", ""))] = m.GetSummary().GetSampleSum()
+				for _, q := range m.GetSummary().GetQuantile() {
+					// Quantiles without observations are NaN, which never
+					// equals itself and would always appear in a Diff.
+					if math.IsNaN(q.GetValue()) {
+						continue
+					}
+					ret[SeriesKey(name, labelsToTags(labels, "quantile", formatBound(q.GetQuantile())))] = q.GetValue()
+				}
+			case dto.MetricType_HISTOGRAM:
+				ret[SeriesKey(name+"_count", labelsToTags(labels, "", ""))] = float64(m.GetHistogram().GetSampleCount())
+				ret[SeriesKey(name+"_sum", labelsToTags(labels, "", ""))] = m.GetHistogram().GetSampleSum()
+				for _, b := range m.GetHistogram().GetBucket() {
+					ret[SeriesKey(name+"_bucket", labelsToTags(labels, "le", formatBound(b.GetUpperBound())))] = float64(b.GetCumulativeCount())
+				}
+				ret[SeriesKey(name+"_bucket", labelsToTags(labels, "le", "+Inf"))] = float64(m.GetHistogram().GetSampleCount())
+			}
+		}
+	}
+	return ret, nil
+}
+
+// GetSnapshot returns a Snapshot of the metrics of the default client.
+func GetSnapshot() (Snapshot, error) {
+	return defaultClient.Snapshot()
+}
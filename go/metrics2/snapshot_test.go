@@ -0,0 +1,69 @@
+package metrics2
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSeriesKey(t *testing.T) {
+	require.Equal(t, "a", SeriesKey("a", nil))
+	require.Equal(t, `a{b="1",c="x\"y"}`, SeriesKey("a", map[string]string{"c": `x"y`, "b": "1"}))
+}
+
+func TestSnapshot(t *testing.T) {
+	c := getPromClient()
+	labels := map[string]string{"some_key": "some-value"}
+	c.GetInt64Metric("snap.int", labels).Update(3)
+	c.GetFloat64Metric("snap_float").Update(1.5)
+	c.GetCounter("snap_counter", labels).Inc(2)
+	c.GetBoolMetric("snap_bool").Update(true)
+	h := c.GetHistogram("snap_histogram", []float64{1, 10}, labels)
+	h.Observe(0.5)
+	h.Observe(50)
+	c.GetSummary("snap_summary", map[float64]float64{0.5: 0.05}).Observe(2)
+	c.GetSummary("snap_empty_summary", nil)
+
+	before, err := c.Snapshot()
+	require.NoError(t, err)
+
+	get := func(s Snapshot, measurement string, tags map[string]string) float64 {
+		v, ok := s.Get(measurement, tags)
+		require.True(t, ok, SeriesKey(measurement, tags))
+		return v
+	}
+	require.Equal(t, 3.0, get(before, "snap.int", labels))
+	require.Equal(t, 1.5, get(before, "snap_float", nil))
+	require.Equal(t, 2.0, get(before, "snap_counter", labels))
+	require.Equal(t, 1.0, get(before, "snap_bool", nil))
+	require.Equal(t, 2.0, get(before, "snap_histogram_count", labels))
+	require.Equal(t, 50.5, get(before, "snap_histogram_sum", labels))
+	require.Equal(t, 1.0, get(before, "snap_histogram_bucket", map[string]string{"some_key": "some-value", "le": "1"}))
+	require.Equal(t, 1.0, get(before, "snap_histogram_bucket", map[string]string{"some_key": "some-value", "le": "10"}))
+	require.Equal(t, 2.0, get(before, "snap_histogram_bucket", map[string]string{"some_key": "some-value", "le": "+Inf"}))
+	require.Equal(t, 1.0, get(before, "snap_summary_count", nil))
+	require.Equal(t, 2.0, get(before, "snap_summary", map[string]string{"quantile": "0.5"}))
+	require.Equal(t, 0.0, get(before, "snap_empty_summary_count", nil))
+	_, ok := before.Get("snap_empty_summary", map[string]string{"quantile": "0.99"})
+	require.False(t, ok)
+	_, ok = before.Get("snap.int", map[string]string{"some_key": "other-value"})
+	require.False(t, ok)
+
+	// Diff only includes the series which changed.
+	c.GetCounter("snap_counter", labels).Inc(1)
+	h.Observe(5)
+	c.GetInt64Metric("snap_new").Update(7)
+	require.NoError(t, c.GetInt64Metric("snap.int", labels).Delete())
+	after, err := c.Snapshot()
+	require.NoError(t, err)
+	diff := after.Diff(before)
+	require.Equal(t, Snapshot{
+		SeriesKey("snap_counter", labels):                                                             1,
+		SeriesKey("snap_histogram_count", labels):                                                     1,
+		SeriesKey("snap_histogram_sum", labels):                                                       5,
+		SeriesKey("snap_histogram_bucket", map[string]string{"some_key": "some-value", "le": "10"}):   1,
+		SeriesKey("snap_histogram_bucket", map[string]string{"some_key": "some-value", "le": "+Inf"}): 1,
+		SeriesKey("snap_new", nil):                                                                    7,
+		SeriesKey("snap_int", labels):                                                                 -3,
+	}, diff)
+}
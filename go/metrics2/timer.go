@@ -12,12 +12,18 @@ import (
 const (
 	MEASUREMENT_TIMER = "timer"
 	NAME_FUNC_TIMER   = "func_timer"
+
+	// MEASUREMENT_FUNC_TIMER_HISTOGRAM is the histogram, in seconds, to which
+	// FuncTimer reports in addition to its summary.
+	MEASUREMENT_FUNC_TIMER_HISTOGRAM = "timer_func_timer_seconds"
 )
 
 // timer implements Timer.
 type timer struct {
 	begin time.Time
 	m     Float64SummaryMetric
+	// h, if not nil, also receives the elapsed time in seconds.
+	h Float64HistogramMetric
 }
 
 // NewTimer creates and returns a new started timer.
@@ -51,9 +57,21 @@ func (t *timer) Stop() time.Duration {
 	dur := time.Now().Sub(t.begin)
 	v := float64(dur)
 	t.m.Observe(v)
+	if t.h != nil {
+		t.h.Observe(dur.Seconds())
+	}
 	return dur
 }
 
+// newFuncTimer creates and returns a new started timer for the function
+// identified by the given tags. In addition to the usual summary, it reports to
+// a histogram so that latencies can be aggregated across instances.
+func newFuncTimer(c Client, tags map[string]string) Timer {
+	ret := newTimer(c, NAME_FUNC_TIMER, true, tags).(*timer)
+	ret.h = c.GetHistogram(MEASUREMENT_FUNC_TIMER_HISTOGRAM, nil, tags)
+	return ret
+}
+
 // NewTimer creates and returns a new Timer using the default client.
 func NewTimer(name string, tags ...map[string]string) Timer {
 	return defaultClient.NewTimer(name, tags...)
@@ -79,7 +97,7 @@ func FuncTimer() Timer {
 		fn = split[len(split)-1]
 		pkg = strings.Join(split[:len(split)-1], ".")
 	}
-	return NewTimer(NAME_FUNC_TIMER, map[string]string{"package": pkg, "func": fn})
+	return newFuncTimer(defaultClient, map[string]string{"package": pkg, "func": fn})
 }
 
 // Verify that timer implements the Timer interface.
@@ -0,0 +1,241 @@
+package metrics2
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+
+	"go.skia.org/infra/go/cleanup"
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/go/sklog"
+	"go.skia.org/infra/go/util"
+)
+
+const (
+	// WORKER_WRITE_PERIOD is how often workers write their metrics.
+	WORKER_WRITE_PERIOD = 15 * time.Second
+
+	// WORKER_MERGE_PERIOD is how often the parent process merges the metrics
+	// of its workers.
+	WORKER_MERGE_PERIOD = 15 * time.Second
+
+	// Kinds of worker series, which determine how they are merged.
+	workerKindCounter = "counter"
+	workerKindInt64   = "int64"
+	workerKindFloat64 = "float64"
+
+	// workerFileExt is the extension of the files written by workers.
+	workerFileExt = ".json"
+)
+
+// workerSeries is a single series written by a worker.
+type workerSeries struct {
+	Measurement string            `json:"measurement"`
+	Tags        map[string]string `json:"tags"`
+	Kind        string            `json:"kind"`
+	Value       float64           `json:"value"`
+}
+
+// workerFile is the contents of the file written by a worker.
+type workerFile struct {
+	// Updated is the time at which the file was written, in nanoseconds since
+	// the epoch.
+	Updated int64          `json:"updated"`
+	Series  []workerSeries `json:"series"`
+}
+
+// workerSeriesList returns the current value of every Int64Metric,
+// Float64Metric, BoolMetric and Counter created by this client.
+func (p *promClient) workerSeriesList() []workerSeries {
+	ret := []workerSeries{}
+	p.int64Mutex.Lock()
+	for _, m := range p.int64Gauges {
+		m.mutex.Lock()
+		kind := workerKindInt64
+		if m.counter {
+			kind = workerKindCounter
+		}
+		ret = append(ret, workerSeries{
+			Measurement: m.measurement,
+			Tags:        m.tags,
+			Kind:        kind,
+			Value:       float64(m.i),
+		})
+		m.mutex.Unlock()
+	}
+	p.int64Mutex.Unlock()
+
+	p.float64Mutex.Lock()
+	for _, m := range p.float64Gauges {
+		ret = append(ret, workerSeries{
+			Measurement: m.measurement,
+			Tags:        m.tags,
+			Kind:        workerKindFloat64,
+			Value:       m.Get(),
+		})
+	}
+	p.float64Mutex.Unlock()
+	return ret
+}
+
+// writeWorkerFile writes the current value of the counters and gauges of this
+// client to the given file.
+func (p *promClient) writeWorkerFile(path string, now time.Time) error {
+	f := workerFile{
+		Updated: now.UnixNano(),
+		Series:  p.workerSeriesList(),
+	}
+	return util.WithWriteFile(path, func(w io.Writer) error {
+		return skerr.Wrap(json.NewEncoder(w).Encode(f))
+	})
+}
+
+// readWorkerFiles reads the files written by workers to the given directory,
+// sorted by the time at which they were written.
+func readWorkerFiles(dir string) ([]workerFile, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*"+workerFileExt))
+	if err != nil {
+		return nil, skerr.Wrap(err)
+	}
+	ret := make([]workerFile, 0, len(paths))
+	for _, path := range paths {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			// The worker may have been cleaned up since the Glob.
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, skerr.Wrapf(err, "reading worker metrics from %s", path)
+		}
+		var f workerFile
+		if err := json.Unmarshal(b, &f); err != nil {
+			return nil, skerr.Wrapf(err, "parsing worker metrics from %s", path)
+		}
+		ret = append(ret, f)
+	}
+	sort.SliceStable(ret, func(i, j int) bool {
+		return ret[i].Updated < ret[j].Updated
+	})
+	return ret, nil
+}
+
+// mergeWorkerSeries merges the series of the given worker files, which must be
+// sorted by the time at which they were written. Counters are summed across
+// workers, since each worker counts its own events, while gauges take the most
+// recently written value. Series which are written as different kinds by
+// different workers are logged and dropped.
+func mergeWorkerSeries(files []workerFile) []workerSeries {
+	merged := map[string]*workerSeries{}
+	conflicts := map[string]bool{}
+	for _, f := range files {
+		for _, s := range f.Series {
+			key := SeriesKey(s.Measurement, s.Tags)
+			if conflicts[key] {
+				continue
+			}
+			existing, ok := merged[key]
+			if !ok {
+				s := s
+				merged[key] = &s
+				continue
+			}
+			if existing.Kind != s.Kind {
+				sklog.Errorf("Worker metric %s is written as both %s and %s; dropping it.", key, existing.Kind, s.Kind)
+				conflicts[key] = true
+				delete(merged, key)
+				continue
+			}
+			if s.Kind == workerKindCounter {
+				existing.Value += s.Value
+			} else {
+				existing.Value = s.Value
+			}
+		}
+	}
+	ret := make([]workerSeries, 0, len(merged))
+	for _, s := range merged {
+		ret = append(ret, *s)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return SeriesKey(ret[i].Measurement, ret[i].Tags) < SeriesKey(ret[j].Measurement, ret[j].Tags)
+	})
+	return ret
+}
+
+// mergeWorkerFiles merges the metrics written by workers to the given
+// directory into this client.
+func (p *promClient) mergeWorkerFiles(dir string) error {
+	files, err := readWorkerFiles(dir)
+	if err != nil {
+		return err
+	}
+	for _, s := range mergeWorkerSeries(files) {
+		switch s.Kind {
+		case workerKindCounter, workerKindInt64:
+			p.GetInt64Metric(s.Measurement, s.Tags).Update(int64(s.Value))
+		case workerKindFloat64:
+			p.GetFloat64Metric(s.Measurement, s.Tags).Update(s.Value)
+		default:
+			sklog.Errorf("Unknown kind %q of worker metric %s.", s.Kind, SeriesKey(s.Measurement, s.Tags))
+		}
+	}
+	return nil
+}
+
+// InitWorker configures a worker subprocess, which can't be scraped, to write
+// the current value of its Counters, Int64Metrics, Float64Metrics and
+// BoolMetrics to the given directory periodically and when the process exits
+// via cleanup.Cleanup(), eg. using common.Defer(). The parent process merges
+// them into its own metrics using AggregateWorkers. Other types of metrics,
+// eg. timers, are not written.
+func InitWorker(dir string) error {
+	pc, ok := defaultClient.(*promClient)
+	if !ok {
+		return skerr.Fmt("Worker metrics require the default Prometheus client.")
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return skerr.Wrapf(err, "creating worker metrics directory %s", dir)
+	}
+	path := filepath.Join(dir, strconv.Itoa(os.Getpid())+workerFileExt)
+	write := func() {
+		if err := pc.writeWorkerFile(path, time.Now()); err != nil {
+			sklog.Errorf("Failed to write worker metrics: %s", err)
+		}
+	}
+	cleanup.Repeat(WORKER_WRITE_PERIOD, func(_ context.Context) { write() }, write)
+	return nil
+}
+
+// AggregateWorkers periodically merges the metrics written by worker
+// subprocesses using InitWorker to the given directory into the default client,
+// until the given context is canceled. Counters are summed across workers,
+// including workers which have exited, and gauges take the value most recently
+// written by any worker. The parent should not record the same series itself,
+// since they are overwritten. Files left by a previous run are removed, so
+// AggregateWorkers should be called before starting any workers.
+func AggregateWorkers(ctx context.Context, dir string) error {
+	pc, ok := defaultClient.(*promClient)
+	if !ok {
+		return skerr.Fmt("Worker metrics require the default Prometheus client.")
+	}
+	paths, err := filepath.Glob(filepath.Join(dir, "*"+workerFileExt))
+	if err != nil {
+		return skerr.Wrap(err)
+	}
+	for _, path := range paths {
+		if err := os.Remove(path); err != nil {
+			return skerr.Wrapf(err, "removing stale worker metrics")
+		}
+	}
+	go util.RepeatCtx(ctx, WORKER_MERGE_PERIOD, func(_ context.Context) {
+		if err := pc.mergeWorkerFiles(dir); err != nil {
+			sklog.Errorf("Failed to merge worker metrics: %s", err)
+		}
+	})
+	return nil
+}
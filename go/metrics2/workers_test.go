@@ -0,0 +1,79 @@
+package metrics2
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeWorkerFiles(t *testing.T) {
+	dir := t.TempDir()
+	labels := map[string]string{"some_key": "some-value"}
+	ts := time.Unix(1000, 0)
+
+	// Each worker process has its own client.
+	w1 := getPromClient()
+	w1.GetCounter("jobs", labels).Inc(3)
+	w1.GetInt64Metric("queue_length").Update(5)
+	w1.GetFloat64Metric("load").Update(0.5)
+	w1.GetInt64Metric("conflicting").Update(1)
+	require.NoError(t, w1.writeWorkerFile(filepath.Join(dir, "1.json"), ts))
+
+	w2 := getPromClient()
+	w2.GetCounter("jobs", labels).Inc(4)
+	w2.GetInt64Metric("queue_length").Update(2)
+	w2.GetBoolMetric("healthy").Update(true)
+	w2.GetCounter("conflicting").Inc(1)
+	require.NoError(t, w2.writeWorkerFile(filepath.Join(dir, "2.json"), ts.Add(time.Second)))
+
+	// Other files in the directory, eg. temporary files, are ignored.
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "3.json123"), []byte("garbage"), 0644))
+
+	parent := getPromClient()
+	require.NoError(t, parent.mergeWorkerFiles(dir))
+	snap, err := parent.Snapshot()
+	require.NoError(t, err)
+	require.Equal(t, Snapshot{
+		// Counters are summed.
+		SeriesKey("jobs", labels): 7,
+		// Gauges take the most recently written value.
+		SeriesKey("queue_length", nil): 2,
+		SeriesKey("load", nil):         0.5,
+		SeriesKey("healthy", nil):      1,
+	}, snap)
+
+	// Merging again doesn't double count.
+	require.NoError(t, parent.mergeWorkerFiles(dir))
+	require.Equal(t, int64(7), parent.GetCounter("jobs", labels).Get())
+}
+
+func TestMergeWorkerSeries_OrderedByUpdateTime(t *testing.T) {
+	older := workerFile{
+		Updated: 1,
+		Series: []workerSeries{
+			{Measurement: "g", Kind: workerKindFloat64, Value: 1},
+			{Measurement: "c", Kind: workerKindCounter, Value: 1},
+		},
+	}
+	newer := workerFile{
+		Updated: 2,
+		Series: []workerSeries{
+			{Measurement: "g", Kind: workerKindFloat64, Value: 2},
+			{Measurement: "c", Kind: workerKindCounter, Value: 2},
+		},
+	}
+	require.Equal(t, []workerSeries{
+		{Measurement: "c", Kind: workerKindCounter, Value: 3},
+		{Measurement: "g", Kind: workerKindFloat64, Value: 2},
+	}, mergeWorkerSeries([]workerFile{older, newer}))
+}
+
+func TestReadWorkerFiles_Invalid(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "1.json"), []byte("garbage"), 0644))
+	_, err := readWorkerFiles(dir)
+	require.Error(t, err)
+}
@@ -0,0 +1,323 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"go.skia.org/infra/email/go/emailclient"
+	"go.skia.org/infra/go/chatbot"
+	"go.skia.org/infra/go/now"
+	"go.skia.org/infra/go/sklog"
+	"go.skia.org/infra/go/util"
+)
+
+// EscalationStep sends a message via some Notifiers once it has been
+// unacknowledged for a while.
+type EscalationStep struct {
+	// After is how long after the message was first sent the step runs.
+	After     time.Duration
+	Notifiers []Notifier
+}
+
+// EscalationPolicy maps the severity of messages to the ordered steps which
+// escalate them, e.g. chat immediately, email after 15 minutes and a page
+// after an hour.
+type EscalationPolicy map[Severity][]*EscalationStep
+
+// EscalationStepConfig provides configuration for an EscalationStep.
+type EscalationStepConfig struct {
+	// After is a duration string, e.g. "15m". Zero if empty.
+	After string `json:"after,omitempty"`
+	// Notifiers used by the step. Their Filter and IncludeMsgTypes must not
+	// be set. Required.
+	Notifiers []*Config `json:"notifiers"`
+}
+
+// EscalationPolicyConfig provides configuration for an EscalationPolicy.
+type EscalationPolicyConfig struct {
+	// Steps maps severities, e.g. "error", to their steps, which must be
+	// ordered by After.
+	Steps map[string][]*EscalationStepConfig `json:"steps"`
+}
+
+// Validate the EscalationPolicyConfig.
+func (c *EscalationPolicyConfig) Validate() error {
+	if len(c.Steps) == 0 {
+		return errors.New("At least one severity is required.")
+	}
+	for sev, steps := range c.Steps {
+		if _, err := ParseSeverity(sev); err != nil {
+			return err
+		}
+		if len(steps) == 0 {
+			return fmt.Errorf("At least one step is required for severity %q.", sev)
+		}
+		var prev time.Duration
+		for _, step := range steps {
+			after, err := parseAfter(step.After)
+			if err != nil {
+				return err
+			}
+			if after < prev {
+				return fmt.Errorf("Steps for severity %q must be ordered by After.", sev)
+			}
+			prev = after
+			if len(step.Notifiers) == 0 {
+				return errors.New("At least one notifier is required for each step.")
+			}
+			for _, n := range step.Notifiers {
+				if n.Filter != "" || n.IncludeMsgTypes != nil {
+					return errors.New("Filter and IncludeMsgTypes may not be provided within escalation steps.")
+				}
+				if err := n.validateTransport(); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// parseAfter parses the After field of an EscalationStepConfig.
+func parseAfter(after string) (time.Duration, error) {
+	if after == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(after)
+	if err != nil {
+		return 0, fmt.Errorf("Invalid After %q: %s", after, err)
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("After must not be negative, got %q", after)
+	}
+	return d, nil
+}
+
+// Create an EscalationPolicy from the EscalationPolicyConfig.
+func (c *EscalationPolicyConfig) Create(ctx context.Context, client *http.Client, emailer emailclient.Client, chatBotConfigReader chatbot.ConfigReader) (EscalationPolicy, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	policy := EscalationPolicy{}
+	for sevName, stepConfigs := range c.Steps {
+		sev, err := ParseSeverity(sevName)
+		if err != nil {
+			return nil, err
+		}
+		for _, sc := range stepConfigs {
+			after, err := parseAfter(sc.After)
+			if err != nil {
+				return nil, err
+			}
+			step := &EscalationStep{
+				After: after,
+			}
+			for _, nc := range sc.Notifiers {
+				n, err := nc.createTransport(ctx, client, emailer, chatBotConfigReader)
+				if err != nil {
+					return nil, err
+				}
+				step.Notifiers = append(step.Notifiers, n)
+			}
+			policy[sev] = append(policy[sev], step)
+		}
+	}
+	return policy, nil
+}
+
+// Acknowledger reports whether an escalated message was acknowledged, e.g.
+// because someone looked into the failure it reports or it was resolved.
+type Acknowledger interface {
+	// IsAcknowledged returns true if the message with the given ID was
+	// acknowledged, in which case it is no longer escalated.
+	IsAcknowledged(ctx context.Context, id string) (bool, error)
+}
+
+// AcknowledgerFunc is an Acknowledger implemented by a func.
+type AcknowledgerFunc func(ctx context.Context, id string) (bool, error)
+
+// IsAcknowledged implements Acknowledger.
+func (f AcknowledgerFunc) IsAcknowledged(ctx context.Context, id string) (bool, error) {
+	return f(ctx, id)
+}
+
+// escalation is a message which is being escalated.
+type escalation struct {
+	thread string
+	msg    *Message
+	start  time.Time
+	steps  []*EscalationStep
+	// next is the index of the next step to run.
+	next int
+}
+
+// Escalator sends messages according to an EscalationPolicy, escalating them
+// until they are acknowledged. Escalations are only kept in memory, so they
+// are lost when the process restarts.
+type Escalator struct {
+	policy EscalationPolicy
+	acker  Acknowledger
+
+	// runMtx serializes running the steps of escalations.
+	runMtx sync.Mutex
+
+	mtx         sync.Mutex
+	escalations map[string]*escalation
+}
+
+// NewEscalator returns an Escalator which uses the given policy, and asks the
+// given Acknowledger whether to keep escalating messages.
+func NewEscalator(policy EscalationPolicy, acker Acknowledger) *Escalator {
+	for _, steps := range policy {
+		sort.SliceStable(steps, func(i, j int) bool {
+			return steps[i].After < steps[j].After
+		})
+	}
+	return &Escalator{
+		policy:      policy,
+		acker:       acker,
+		escalations: map[string]*escalation{},
+	}
+}
+
+// Escalate starts escalating the message with the given ID, which identifies
+// it to the Acknowledger, by running the steps of the policy for its severity
+// which are due immediately. Later steps are run by Tick. If the message has no
+// ThreadID, the ID is used, so that escalations are threaded. Escalating a
+// message whose ID is already being escalated does nothing.
+func (e *Escalator) Escalate(ctx context.Context, id, thread string, msg *Message) error {
+	steps := e.policy[msg.Severity]
+	if len(steps) == 0 {
+		sklog.Debugf("Not escalating %q; no escalation steps for severity %s.", id, msg.Severity)
+		return nil
+	}
+	if msg.ThreadID == "" {
+		msgCopy := *msg
+		msgCopy.ThreadID = id
+		msg = &msgCopy
+	}
+	e.mtx.Lock()
+	if _, ok := e.escalations[id]; ok {
+		e.mtx.Unlock()
+		return nil
+	}
+	esc := &escalation{
+		thread: thread,
+		msg:    msg,
+		start:  now.Now(ctx),
+		steps:  steps,
+	}
+	e.escalations[id] = esc
+	e.mtx.Unlock()
+
+	e.runMtx.Lock()
+	defer e.runMtx.Unlock()
+	return e.runDueSteps(ctx, id, esc)
+}
+
+// Resolve stops escalating the message with the given ID.
+func (e *Escalator) Resolve(id string) {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	delete(e.escalations, id)
+}
+
+// Pending returns the IDs of the messages which are being escalated.
+func (e *Escalator) Pending() []string {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	ret := make([]string, 0, len(e.escalations))
+	for id := range e.escalations {
+		ret = append(ret, id)
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+// isPending returns true if the message with the given ID is being escalated.
+func (e *Escalator) isPending(id string) bool {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	_, ok := e.escalations[id]
+	return ok
+}
+
+// Tick runs the steps which are due, unless their messages were acknowledged,
+// in which case they are no longer escalated.
+func (e *Escalator) Tick(ctx context.Context) error {
+	e.runMtx.Lock()
+	defer e.runMtx.Unlock()
+
+	e.mtx.Lock()
+	pending := make(map[string]*escalation, len(e.escalations))
+	for id, esc := range e.escalations {
+		pending[id] = esc
+	}
+	e.mtx.Unlock()
+
+	var errs []error
+	for id, esc := range pending {
+		if !e.isPending(id) {
+			// Resolved in the meantime.
+			continue
+		}
+		if ts := now.Now(ctx); ts.Before(esc.start.Add(esc.steps[esc.next].After)) {
+			continue
+		}
+		acked, err := e.acker.IsAcknowledged(ctx, id)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("Failed to check whether %q is acknowledged: %s", id, err))
+			continue
+		}
+		if acked {
+			sklog.Infof("%q was acknowledged; no longer escalating it.", id)
+			e.Resolve(id)
+			continue
+		}
+		if err := e.runDueSteps(ctx, id, esc); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("Failed to escalate %d messages: %v", len(errs), errs)
+	}
+	return nil
+}
+
+// runDueSteps runs the steps of the escalation which are due, and stops
+// escalating the message once all of its steps have run.
+func (e *Escalator) runDueSteps(ctx context.Context, id string, esc *escalation) error {
+	ts := now.Now(ctx)
+	var errs []error
+	for esc.next < len(esc.steps) && !ts.Before(esc.start.Add(esc.steps[esc.next].After)) {
+		step := esc.steps[esc.next]
+		esc.next++
+		sklog.Infof("Escalating %q (step %d of %d).", id, esc.next, len(esc.steps))
+		for _, n := range step.Notifiers {
+			if err := n.Send(ctx, esc.thread, esc.msg); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	if esc.next >= len(esc.steps) {
+		e.Resolve(id)
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("Failed to escalate %q: %v", id, errs)
+	}
+	return nil
+}
+
+// Start calls Tick every interval until the context is cancelled.
+func (e *Escalator) Start(ctx context.Context, interval time.Duration) {
+	go util.RepeatCtx(ctx, interval, func(ctx context.Context) {
+		if err := e.Tick(ctx); err != nil {
+			sklog.Error(err)
+		}
+	})
+}
@@ -0,0 +1,107 @@
+package notifier
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.skia.org/infra/email/go/emailclient"
+	"go.skia.org/infra/go/now"
+)
+
+func TestEscalationPolicyConfigValidate(t *testing.T) {
+	chat := &Config{Chat: &ChatNotifierConfig{RoomID: "my-room"}}
+	c := &EscalationPolicyConfig{}
+	require.EqualError(t, c.Validate(), "At least one severity is required.")
+
+	c.Steps = map[string][]*EscalationStepConfig{"bogus": {{Notifiers: []*Config{chat}}}}
+	require.EqualError(t, c.Validate(), "Unknown severity \"bogus\"")
+
+	c.Steps = map[string][]*EscalationStepConfig{"error": {{After: "1h", Notifiers: []*Config{chat}}, {After: "15m", Notifiers: []*Config{chat}}}}
+	require.EqualError(t, c.Validate(), "Steps for severity \"error\" must be ordered by After.")
+
+	c.Steps = map[string][]*EscalationStepConfig{"error": {{After: "soon", Notifiers: []*Config{chat}}}}
+	require.Error(t, c.Validate())
+
+	c.Steps = map[string][]*EscalationStepConfig{"error": {{Notifiers: []*Config{{Filter: "debug", Chat: chat.Chat}}}}}
+	require.EqualError(t, c.Validate(), "Filter and IncludeMsgTypes may not be provided within escalation steps.")
+
+	c.Steps = map[string][]*EscalationStepConfig{"error": {{Notifiers: []*Config{chat}}, {After: "15m", Notifiers: []*Config{chat}}}}
+	require.NoError(t, c.Validate())
+	policy, err := c.Create(context.Background(), nil, emailclient.New(), nil)
+	require.NoError(t, err)
+	require.Len(t, policy[SEVERITY_ERROR], 2)
+	require.Equal(t, 15*time.Minute, policy[SEVERITY_ERROR][1].After)
+}
+
+func TestEscalator(t *testing.T) {
+	ts := time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)
+	ctx := now.TimeTravelingContext(ts)
+	chat := &testNotifier{}
+	email := &testNotifier{}
+	page := &testNotifier{}
+	acked := map[string]bool{}
+	e := NewEscalator(EscalationPolicy{
+		SEVERITY_ERROR: {
+			{After: 0, Notifiers: []Notifier{chat}},
+			{After: 15 * time.Minute, Notifiers: []Notifier{email}},
+			{After: time.Hour, Notifiers: []Notifier{page}},
+		},
+	}, AcknowledgerFunc(func(_ context.Context, id string) (bool, error) {
+		return acked[id], nil
+	}))
+
+	msg := &Message{Subject: "Roll failed", Body: "body", Severity: SEVERITY_ERROR, Type: "failure"}
+	require.NoError(t, e.Escalate(ctx, "roll-1", "Roll failed", msg))
+	require.NoError(t, e.Escalate(ctx, "roll-2", "Roll failed", msg))
+	// Duplicates are ignored.
+	require.NoError(t, e.Escalate(ctx, "roll-1", "Roll failed", msg))
+	require.Len(t, chat.sent, 2)
+	require.Equal(t, "roll-1", chat.sent[0].msg.ThreadID)
+	require.Empty(t, email.sent)
+
+	ctx.SetTime(ts.Add(15 * time.Minute))
+	require.NoError(t, e.Tick(ctx))
+	require.Len(t, email.sent, 2)
+	require.Empty(t, page.sent)
+
+	// Acknowledged messages are no longer escalated.
+	acked["roll-1"] = true
+	ctx.SetTime(ts.Add(time.Hour))
+	require.NoError(t, e.Tick(ctx))
+	require.Len(t, page.sent, 1)
+	require.Equal(t, "roll-2", page.sent[0].msg.ThreadID)
+
+	// Both escalations are done.
+	require.Empty(t, e.Pending())
+}
+
+func TestEscalator_NoStepsForSeverity_NotSent(t *testing.T) {
+	chat := &testNotifier{}
+	e := NewEscalator(EscalationPolicy{
+		SEVERITY_ERROR: {{Notifiers: []Notifier{chat}}},
+	}, AcknowledgerFunc(func(_ context.Context, _ string) (bool, error) {
+		return false, nil
+	}))
+	require.NoError(t, e.Escalate(context.Background(), "id", "thread", &Message{Severity: SEVERITY_INFO}))
+	require.Empty(t, chat.sent)
+	require.Empty(t, e.Pending())
+}
+
+func TestEscalator_Resolve(t *testing.T) {
+	ts := time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)
+	ctx := now.TimeTravelingContext(ts)
+	email := &testNotifier{}
+	e := NewEscalator(EscalationPolicy{
+		SEVERITY_WARNING: {{After: time.Minute, Notifiers: []Notifier{email}}},
+	}, AcknowledgerFunc(func(_ context.Context, _ string) (bool, error) {
+		return false, nil
+	}))
+	require.NoError(t, e.Escalate(ctx, "id", "thread", &Message{Severity: SEVERITY_WARNING}))
+	require.Equal(t, []string{"id"}, e.Pending())
+	e.Resolve("id")
+	ctx.SetTime(ts.Add(time.Hour))
+	require.NoError(t, e.Tick(ctx))
+	require.Empty(t, email.sent)
+}
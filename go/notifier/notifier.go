@@ -2,6 +2,7 @@ package notifier
 
 import (
 	"context"
+	"crypto/sha256"
 	"errors"
 	"fmt"
 	"net/http"
@@ -46,6 +47,12 @@ type Config struct {
 
 	// If present, all messages inherit this subject line.
 	Subject string `json:"subject,omitempty"`
+
+	// If present, the subject and body of messages are rendered using these
+	// Go templates. See TemplateData for the available fields. This allows
+	// e.g. email to use HTML markup while chat uses short plain text.
+	SubjectTemplate string `json:"subjectTemplate,omitempty"`
+	BodyTemplate    string `json:"bodyTemplate,omitempty"`
 }
 
 // Validate the Config.
@@ -61,6 +68,18 @@ func (c *Config) Validate() error {
 			return err
 		}
 	}
+	return c.validateTransport()
+}
+
+// validateTransport validates the parts of the Config other than the filter,
+// i.e. the notification config, which are also used by RuleConfig.
+func (c *Config) validateTransport() error {
+	if _, err := parseTemplate("subject template", c.SubjectTemplate); err != nil {
+		return err
+	}
+	if _, err := parseTemplate("body template", c.BodyTemplate); err != nil {
+		return err
+	}
 	n := []util.Validator{}
 	if c.Email != nil {
 		n = append(n, c.Email)
@@ -89,7 +108,18 @@ func (c *Config) Create(ctx context.Context, client *http.Client, emailer emailc
 	if err != nil {
 		return nil, FILTER_SILENT, nil, "", err
 	}
+	n, err := c.createTransport(ctx, client, emailer, chatBotConfigReader)
+	if err != nil {
+		return nil, FILTER_SILENT, nil, "", err
+	}
+	return n, filter, c.IncludeMsgTypes, c.Subject, nil
+}
+
+// createTransport creates a Notifier from the notification config of the
+// Config, ignoring the filter.
+func (c *Config) createTransport(ctx context.Context, client *http.Client, emailer emailclient.Client, chatBotConfigReader chatbot.ConfigReader) (Notifier, error) {
 	var n Notifier
+	var err error
 	if c.Email != nil {
 		n, err = EmailNotifier(c.Email.Emails, emailer, "")
 	} else if c.Chat != nil {
@@ -99,12 +129,15 @@ func (c *Config) Create(ctx context.Context, client *http.Client, emailer emailc
 	} else if c.Monorail != nil {
 		n, err = MonorailNotifier(client, c.Monorail.Project, c.Monorail.Owner, c.Monorail.CC, c.Monorail.Components, c.Monorail.Labels)
 	} else {
-		return nil, FILTER_SILENT, nil, "", fmt.Errorf("No config specified!")
+		return nil, fmt.Errorf("No config specified!")
 	}
 	if err != nil {
-		return nil, FILTER_SILENT, nil, "", err
+		return nil, err
 	}
-	return n, filter, c.IncludeMsgTypes, c.Subject, nil
+	if c.SubjectTemplate != "" || c.BodyTemplate != "" {
+		return TemplatedNotifier(n, c.SubjectTemplate, c.BodyTemplate)
+	}
+	return n, nil
 }
 
 // Create a copy of this Config.
@@ -113,6 +146,8 @@ func (c *Config) Copy() *Config {
 		Filter:          c.Filter,
 		IncludeMsgTypes: util.CopyStringSlice(c.IncludeMsgTypes),
 		Subject:         c.Subject,
+		SubjectTemplate: c.SubjectTemplate,
+		BodyTemplate:    c.BodyTemplate,
 	}
 	if c.Email != nil {
 		configCopy.Email = &EmailNotifierConfig{
@@ -174,10 +209,21 @@ func (n *emailNotifier) Send(_ context.Context, subject string, msg *Message) er
 	body := strings.ReplaceAll(msg.Body, "\n", "<br/>")
 	recipients := append(util.CopyStringSlice(n.to), msg.ExtraRecipients...)
 	sklog.Infof("Sending email to %s: %s", strings.Join(recipients, ","), subject)
-	_, err := n.emailer.SendWithMarkup("", n.from, recipients, subject, body, n.markup, "")
+	_, err := n.emailer.SendWithMarkup("", n.from, recipients, subject, body, n.markup, EmailThreadingReference(msg.ThreadID))
 	return err
 }
 
+// EmailThreadingReference returns the message ID which emails about the given
+// thread refer to via their In-Reply-To and References headers, so that email
+// clients group them, or the empty string if threadID is empty. The message ID
+// is derived from the thread ID, so that no state needs to be kept.
+func EmailThreadingReference(threadID string) string {
+	if threadID == "" {
+		return ""
+	}
+	return fmt.Sprintf("<thread-%x@skia.org>", sha256.Sum256([]byte(threadID)))
+}
+
 // EmailNotifier returns a Notifier which sends email to interested parties.
 // Sends the same ViewAction markup with each message.
 func EmailNotifier(emails []string, emailer emailclient.Client, markup string) (Notifier, error) {
@@ -208,8 +254,13 @@ type chatNotifier struct {
 	roomId       string
 }
 
-// See documentation for Notifier interface.
+// See documentation for Notifier interface. Messages with a ThreadID are
+// posted in the chat thread of that ID, rather than the thread of their
+// subject.
 func (n *chatNotifier) Send(_ context.Context, thread string, msg *Message) error {
+	if msg.ThreadID != "" {
+		thread = msg.ThreadID
+	}
 	return chatbot.SendUsingConfig(msg.Body, n.roomId, thread, n.configReader)
 }
 
@@ -241,12 +292,16 @@ type pubSubNotifier struct {
 
 // See documentation for Notifier interface.
 func (n *pubSubNotifier) Send(ctx context.Context, subject string, msg *Message) error {
+	attrs := map[string]string{
+		"severity": msg.Severity.String(),
+		"subject":  subject,
+	}
+	if msg.ThreadID != "" {
+		attrs["thread_id"] = msg.ThreadID
+	}
 	res := n.topic.Publish(ctx, &pubsub.Message{
-		Attributes: map[string]string{
-			"severity": msg.Severity.String(),
-			"subject":  subject,
-		},
-		Data: []byte(msg.Body),
+		Attributes: attrs,
+		Data:       []byte(msg.Body),
 	})
 	_, err := res.Get(ctx)
 	return err
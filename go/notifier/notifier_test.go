@@ -1,9 +1,14 @@
 package notifier
 
 import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"go.skia.org/infra/email/go/emailclient"
 	"go.skia.org/infra/go/deepequal/assertdeep"
 )
 
@@ -100,6 +105,8 @@ func TestConfigCopy(t *testing.T) {
 		Filter:          "info",
 		IncludeMsgTypes: []string{"a", "b"},
 		Subject:         "blah blah",
+		SubjectTemplate: "{{.Subject}}",
+		BodyTemplate:    "{{.Body}}",
 		Chat: &ChatNotifierConfig{
 			RoomID: "my-room",
 		},
@@ -128,3 +135,33 @@ func TestConfigCopy(t *testing.T) {
 	assertdeep.Copy(t, c.Monorail, cpy.Monorail)
 	assertdeep.Copy(t, c.PubSub, cpy.PubSub)
 }
+
+func TestEmailThreadingReference(t *testing.T) {
+	require.Equal(t, "", EmailThreadingReference(""))
+	ref := EmailThreadingReference("my-thread")
+	require.Regexp(t, `^<thread-[0-9a-f]{64}@skia\.org>$`, ref)
+	require.Equal(t, ref, EmailThreadingReference("my-thread"))
+	require.NotEqual(t, ref, EmailThreadingReference("other-thread"))
+}
+
+func TestEmailNotifier_ThreadID_SetsReplyHeaders(t *testing.T) {
+	var sent []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		sent = append(sent, string(b))
+	}))
+	defer srv.Close()
+
+	n, err := EmailNotifier([]string{"me@google.com"}, emailclient.NewAt(srv.URL), "")
+	require.NoError(t, err)
+	ctx := context.Background()
+	require.NoError(t, n.Send(ctx, "Subject", &Message{Body: "No thread"}))
+	require.NoError(t, n.Send(ctx, "Subject", &Message{Body: "Threaded", ThreadID: "my-thread"}))
+
+	require.Len(t, sent, 2)
+	require.NotContains(t, sent[0], "In-Reply-To:")
+	ref := EmailThreadingReference("my-thread")
+	require.Contains(t, sent[1], "In-Reply-To: "+ref)
+	require.Contains(t, sent[1], "References: "+ref)
+}
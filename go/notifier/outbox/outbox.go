@@ -0,0 +1,292 @@
+// Package outbox provides a persistent outbox for notifications, where failed
+// sends are queued and retried with backoff, and delivery failures are
+// recorded for debugging missed alerts.
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"cloud.google.com/go/datastore"
+	"go.skia.org/infra/go/ds"
+	"go.skia.org/infra/go/notifier"
+	"go.skia.org/infra/go/now"
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/go/sklog"
+	"go.skia.org/infra/go/util"
+)
+
+const (
+	defaultMaxAttempts    = 10
+	defaultInitialBackoff = time.Minute
+	defaultMaxBackoff     = time.Hour
+
+	// failureRetention is how long delivery failures are kept.
+	failureRetention = 30 * 24 * time.Hour
+
+	// processBatchSize is the maximum number of queued messages retried by
+	// a single call to Process.
+	processBatchSize = 100
+)
+
+func init() {
+	ds.RegisterGC(ds.NOTIFIER_DELIVERY_FAILURE, "Timestamp", failureRetention)
+}
+
+// Options configures an Outbox.
+type Options struct {
+	// MaxAttempts is the number of attempts to send a message, including the
+	// initial one, after which it is dropped. Defaults to 10.
+	MaxAttempts int
+	// InitialBackoff is the delay before the first retry, which doubles with
+	// every attempt up to MaxBackoff. Defaults are one minute and one hour
+	// respectively.
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+// entry is a queued message, stored as a NOTIFIER_OUTBOX entity.
+type entry struct {
+	Notifier        string
+	Thread          string    `datastore:",noindex"`
+	Subject         string    `datastore:",noindex"`
+	Body            string    `datastore:",noindex"`
+	Severity        int       `datastore:",noindex"`
+	Type            string    `datastore:",noindex"`
+	ExtraRecipients []string  `datastore:",noindex"`
+	Data            string    `datastore:",noindex"` // JSON-encoded Message.Data.
+	ThreadID        string    `datastore:",noindex"`
+	Attempts        int       `datastore:",noindex"`
+	Created         time.Time `datastore:",noindex"`
+	NextAttempt     time.Time
+}
+
+// message returns the Message of the entry.
+func (e *entry) message() (*notifier.Message, error) {
+	msg := &notifier.Message{
+		Subject:         e.Subject,
+		Body:            e.Body,
+		Severity:        notifier.Severity(e.Severity),
+		Type:            e.Type,
+		ExtraRecipients: e.ExtraRecipients,
+		ThreadID:        e.ThreadID,
+	}
+	if e.Data != "" {
+		if err := json.Unmarshal([]byte(e.Data), &msg.Data); err != nil {
+			return nil, skerr.Wrapf(err, "decoding message data")
+		}
+	}
+	return msg, nil
+}
+
+// DeliveryFailure records a failed attempt to send a message, stored as a
+// NOTIFIER_DELIVERY_FAILURE entity. Delivery failures are garbage collected
+// after 30 days, see ds.RunGC.
+type DeliveryFailure struct {
+	// Notifier is the name of the notifier which failed to send the message.
+	Notifier string
+	Thread   string `datastore:",noindex"`
+	Type     string `datastore:",noindex"`
+	Error    string `datastore:",noindex"`
+	// Attempt is the number of the failed attempt, starting at 1.
+	Attempt int `datastore:",noindex"`
+	// GaveUp is true if the message was dropped after this attempt.
+	GaveUp    bool `datastore:",noindex"`
+	Timestamp time.Time
+}
+
+// Outbox queues the messages which named Notifiers failed to send, and retries
+// them with backoff via Process. Only one process should call Process for the
+// namespace of the Outbox at a time.
+type Outbox struct {
+	client *ds.Client
+	opts   Options
+
+	mtx       sync.Mutex
+	notifiers map[string]notifier.Notifier
+}
+
+// New returns an Outbox which stores messages in the namespace of the given
+// client.
+func New(client *ds.Client, opts Options) *Outbox {
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = defaultMaxAttempts
+	}
+	if opts.InitialBackoff <= 0 {
+		opts.InitialBackoff = defaultInitialBackoff
+	}
+	if opts.MaxBackoff <= 0 {
+		opts.MaxBackoff = defaultMaxBackoff
+	}
+	return &Outbox{
+		client:    client,
+		opts:      opts,
+		notifiers: map[string]notifier.Notifier{},
+	}
+}
+
+// Wrap returns a Notifier which sends messages via the given Notifier, and
+// queues them in the Outbox if that fails, in which case Send only returns an
+// error if the message couldn't be queued either. The name identifies the
+// Notifier in the Outbox, so it must be unique and stable across restarts.
+func (o *Outbox) Wrap(name string, n notifier.Notifier) (notifier.Notifier, error) {
+	o.mtx.Lock()
+	defer o.mtx.Unlock()
+	if _, ok := o.notifiers[name]; ok {
+		return nil, skerr.Fmt("Notifier %q is already registered", name)
+	}
+	o.notifiers[name] = n
+	return &outboxNotifier{
+		outbox: o,
+		name:   name,
+	}, nil
+}
+
+// getNotifier returns the Notifier with the given name, or nil.
+func (o *Outbox) getNotifier(name string) notifier.Notifier {
+	o.mtx.Lock()
+	defer o.mtx.Unlock()
+	return o.notifiers[name]
+}
+
+// backoff returns the delay after the given number of failed attempts.
+func (o *Outbox) backoff(attempts int) time.Duration {
+	d := o.opts.InitialBackoff
+	for i := 1; i < attempts && d < o.opts.MaxBackoff; i++ {
+		d *= 2
+	}
+	if d > o.opts.MaxBackoff {
+		d = o.opts.MaxBackoff
+	}
+	return d
+}
+
+// recordFailure stores a DeliveryFailure for the failed attempt to send the
+// entry. Errors are logged, since they must not prevent retries.
+func (o *Outbox) recordFailure(ctx context.Context, e *entry, sendErr error, gaveUp bool) {
+	f := &DeliveryFailure{
+		Notifier:  e.Notifier,
+		Thread:    e.Thread,
+		Type:      e.Type,
+		Error:     sendErr.Error(),
+		Attempt:   e.Attempts,
+		GaveUp:    gaveUp,
+		Timestamp: now.Now(ctx),
+	}
+	if _, err := o.client.Put(ctx, o.client.NewKey(ds.NOTIFIER_DELIVERY_FAILURE), f); err != nil {
+		sklog.Errorf("Failed to record delivery failure of %s notification to %q: %s", e.Type, e.Thread, err)
+	}
+}
+
+// enqueue stores the entry, which failed to be sent, unless it has run out of
+// attempts.
+func (o *Outbox) enqueue(ctx context.Context, key *datastore.Key, e *entry, sendErr error) error {
+	gaveUp := e.Attempts >= o.opts.MaxAttempts
+	o.recordFailure(ctx, e, sendErr, gaveUp)
+	if gaveUp {
+		sklog.Errorf("Giving up on %s notification to %q via %s after %d attempts: %s", e.Type, e.Thread, e.Notifier, e.Attempts, sendErr)
+		if key.Incomplete() {
+			return nil
+		}
+		return skerr.Wrap(o.client.Delete(ctx, key))
+	}
+	e.NextAttempt = now.Now(ctx).Add(o.backoff(e.Attempts))
+	sklog.Warningf("Failed to send %s notification to %q via %s (attempt %d); retrying at %s: %s", e.Type, e.Thread, e.Notifier, e.Attempts, e.NextAttempt, sendErr)
+	_, err := o.client.Put(ctx, key, e)
+	return skerr.Wrap(err)
+}
+
+// Process retries the queued messages which are due, and returns the number of
+// messages which were sent.
+func (o *Outbox) Process(ctx context.Context) (int, error) {
+	q := o.client.NewQuery(ds.NOTIFIER_OUTBOX).FilterField("NextAttempt", "<=", now.Now(ctx)).Order("NextAttempt").Limit(processBatchSize)
+	var entries []*entry
+	keys, err := o.client.GetAll(ctx, q, &entries)
+	if err != nil {
+		return 0, skerr.Wrapf(err, "Failed to query queued messages")
+	}
+	sent := 0
+	for i, e := range entries {
+		n := o.getNotifier(e.Notifier)
+		if n == nil {
+			sklog.Warningf("Not retrying %s notification to %q; no notifier named %q.", e.Type, e.Thread, e.Notifier)
+			continue
+		}
+		msg, err := e.message()
+		if err != nil {
+			return sent, err
+		}
+		e.Attempts++
+		if sendErr := n.Send(ctx, e.Thread, msg); sendErr != nil {
+			if err := o.enqueue(ctx, keys[i], e, sendErr); err != nil {
+				return sent, skerr.Wrapf(err, "Failed to requeue message")
+			}
+			continue
+		}
+		if err := o.client.Delete(ctx, keys[i]); err != nil {
+			return sent, skerr.Wrapf(err, "Failed to delete sent message")
+		}
+		sent++
+	}
+	return sent, nil
+}
+
+// Start calls Process every interval until the context is cancelled.
+func (o *Outbox) Start(ctx context.Context, interval time.Duration) {
+	go util.RepeatCtx(ctx, interval, func(ctx context.Context) {
+		if _, err := o.Process(ctx); err != nil {
+			sklog.Errorf("Failed to process notifier outbox: %s", err)
+		}
+	})
+}
+
+// RecentFailures returns the most recent delivery failures of the Notifier with
+// the given name, most recent first.
+func (o *Outbox) RecentFailures(ctx context.Context, name string, limit int) ([]*DeliveryFailure, error) {
+	q := o.client.NewQuery(ds.NOTIFIER_DELIVERY_FAILURE).FilterField("Notifier", "=", name).Order("-Timestamp").Limit(limit)
+	var failures []*DeliveryFailure
+	if _, err := o.client.GetAll(ctx, q, &failures); err != nil {
+		return nil, skerr.Wrapf(err, "Failed to query delivery failures")
+	}
+	return failures, nil
+}
+
+// outboxNotifier is a Notifier implementation which queues the messages that
+// another Notifier fails to send.
+type outboxNotifier struct {
+	outbox *Outbox
+	name   string
+}
+
+// See documentation for notifier.Notifier interface.
+func (n *outboxNotifier) Send(ctx context.Context, thread string, msg *notifier.Message) error {
+	sendErr := n.outbox.getNotifier(n.name).Send(ctx, thread, msg)
+	if sendErr == nil {
+		return nil
+	}
+	e := &entry{
+		Notifier:        n.name,
+		Thread:          thread,
+		Subject:         msg.Subject,
+		Body:            msg.Body,
+		Severity:        int(msg.Severity),
+		Type:            msg.Type,
+		ExtraRecipients: msg.ExtraRecipients,
+		ThreadID:        msg.ThreadID,
+		Attempts:        1,
+		Created:         now.Now(ctx),
+	}
+	if msg.Data != nil {
+		b, err := json.Marshal(msg.Data)
+		if err != nil {
+			return skerr.Wrapf(err, "failed to send (%s) and to encode message data", sendErr)
+		}
+		e.Data = string(b)
+	}
+	if err := n.outbox.enqueue(ctx, n.outbox.client.NewKey(ds.NOTIFIER_OUTBOX), e, sendErr); err != nil {
+		return skerr.Wrapf(err, "failed to send (%s) and to queue the message", sendErr)
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.skia.org/infra/go/ds"
+	"go.skia.org/infra/go/ds/testutil"
+	"go.skia.org/infra/go/notifier"
+	"go.skia.org/infra/go/notifier/testutils"
+	"go.skia.org/infra/go/now"
+)
+
+// flakyNotifier fails to send until it is fixed.
+type flakyNotifier struct {
+	broken bool
+	sent   []*notifier.Message
+}
+
+func (n *flakyNotifier) Send(_ context.Context, _ string, msg *notifier.Message) error {
+	if n.broken {
+		return errors.New("broken")
+	}
+	n.sent = append(n.sent, msg)
+	return nil
+}
+
+func TestBackoff(t *testing.T) {
+	o := New(nil, Options{
+		InitialBackoff: time.Minute,
+		MaxBackoff:     5 * time.Minute,
+	})
+	assert.Equal(t, time.Minute, o.backoff(1))
+	assert.Equal(t, 2*time.Minute, o.backoff(2))
+	assert.Equal(t, 4*time.Minute, o.backoff(3))
+	assert.Equal(t, 5*time.Minute, o.backoff(4))
+	assert.Equal(t, 5*time.Minute, o.backoff(100))
+}
+
+func TestWrap_DuplicateName_Error(t *testing.T) {
+	o := New(nil, Options{})
+	_, err := o.Wrap("chat", &flakyNotifier{})
+	require.NoError(t, err)
+	_, err = o.Wrap("chat", &flakyNotifier{})
+	require.Error(t, err)
+}
+
+func TestOutbox_QueuesAndRetriesFailedSends(t *testing.T) {
+	client := ds.WrapClient(testutil.InitDatastoreForTest(t), "test-project", ds.Namespace)
+	ts := time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)
+	ctx := now.TimeTravelingContext(ts)
+
+	o := New(client, Options{MaxAttempts: 3})
+	fn := &flakyNotifier{broken: true}
+	n, err := o.Wrap("chat", fn)
+	require.NoError(t, err)
+
+	msg := &notifier.Message{
+		Subject:  "Subject",
+		Body:     "Body",
+		Severity: notifier.SEVERITY_ERROR,
+		Type:     "my-type",
+		Data:     map[string]interface{}{"roller": "skia-autoroll"},
+	}
+	// The failure is queued rather than returned.
+	require.NoError(t, n.Send(ctx, "thread", msg))
+
+	// The message is not retried before the backoff has passed.
+	sent, err := o.Process(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, sent)
+
+	ctx.SetTime(ts.Add(time.Minute))
+	sent, err = o.Process(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, sent)
+
+	failures, err := o.RecentFailures(ctx, "chat", 10)
+	require.NoError(t, err)
+	require.Len(t, failures, 2)
+	assert.Equal(t, 2, failures[0].Attempt)
+	assert.Equal(t, "broken", failures[0].Error)
+	assert.False(t, failures[0].GaveUp)
+
+	fn.broken = false
+	ctx.SetTime(ts.Add(time.Hour))
+	sent, err = o.Process(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, sent)
+	require.Len(t, fn.sent, 1)
+	assert.Equal(t, msg, fn.sent[0])
+
+	// The message is no longer queued.
+	sent, err = o.Process(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, sent)
+}
+
+func TestOutbox_GivesUpAfterMaxAttempts(t *testing.T) {
+	client := ds.WrapClient(testutil.InitDatastoreForTest(t), "test-project", ds.Namespace)
+	ts := time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)
+	ctx := now.TimeTravelingContext(ts)
+
+	o := New(client, Options{MaxAttempts: 2})
+	fn := &testutils.FailingNotifier{}
+	n, err := o.Wrap("email", fn)
+	require.NoError(t, err)
+	require.NoError(t, n.Send(ctx, "thread", &notifier.Message{
+		Subject: "Subject",
+		Body:    "Body",
+		Type:    "my-type",
+	}))
+
+	ctx.SetTime(ts.Add(time.Hour))
+	_, err = o.Process(ctx)
+	require.NoError(t, err)
+
+	failures, err := o.RecentFailures(ctx, "email", 10)
+	require.NoError(t, err)
+	require.Len(t, failures, 2)
+	assert.True(t, failures[0].GaveUp)
+	assert.Equal(t, testutils.ErrSendFailed.Error(), failures[0].Error)
+	assert.Equal(t, 2, fn.Calls())
+	count, err := client.Count(ctx, client.NewQuery(ds.NOTIFIER_OUTBOX))
+	require.NoError(t, err)
+	assert.Zero(t, count)
+}
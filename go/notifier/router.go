@@ -28,6 +28,14 @@ type Message struct {
 	// ExtraRecipients who should also be sent this Message. Not supported for
 	// all types of notification.
 	ExtraRecipients []string
+	// Data is extra data which is available to the templates of Notifiers,
+	// see TemplateData. Optional.
+	Data map[string]interface{}
+	// ThreadID identifies the conversation which the Message belongs to, e.g.
+	// a roll or an alert, so that Messages with the same ThreadID are grouped
+	// together by recipients' clients. Not supported for all types of
+	// notification. Optional.
+	ThreadID string
 }
 
 // Validate the Message.
@@ -44,9 +52,11 @@ func (m *Message) Validate() error {
 	return nil
 }
 
-// filteredThreadedNotifier groups a Notifier with a Filter and an optional
-// static subject line for all messages to this Notifier.
+// filteredThreadedNotifier groups a Notifier with a Filter or Matcher and an
+// optional static subject line for all messages to this Notifier.
 type filteredThreadedNotifier struct {
+	// matcher, if set, is used instead of includeMsgTypes and filter.
+	matcher             *Matcher
 	includeMsgTypes     []string
 	notifier            Notifier
 	filter              Filter
@@ -76,7 +86,12 @@ func (r *Router) Send(ctx context.Context, msg *Message) error {
 				subject = n.singleThreadSubject
 			}
 			msgLog := fmt.Sprintf("(%s; %s): %s\n\n%s", msg.Severity.String(), msg.Type, subject, msg.Body)
-			if n.includeMsgTypes != nil {
+			if n.matcher != nil {
+				if !n.matcher.Matches(msg) {
+					sklog.Debugf("Not sending notification (no match for %q): %s", n.matcher.String(), msgLog)
+					return nil
+				}
+			} else if n.includeMsgTypes != nil {
 				if !util.In(msg.Type, n.includeMsgTypes) {
 					sklog.Debugf("Not sending notification (%s not in %v): %s", msg.Type, n.includeMsgTypes, msgLog)
 					return nil
@@ -0,0 +1,286 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+)
+
+// Matcher decides which messages are sent by a routing rule. Matchers are
+// parsed from expressions consisting of clauses separated by ";", all of which
+// must match. Supported clauses are:
+//
+//	severity<op><severity>  where <op> is one of =, !=, <, <=, >, >= and more
+//	                        severe messages are greater, e.g. "severity>=warning"
+//	                        matches errors and warnings.
+//	type=<glob>[,<glob>...] matches messages whose type matches any of the
+//	                        given path.Match patterns, e.g. "type=new *".
+//
+// The empty expression matches all messages. Example:
+//
+//	severity>=warning; type=new failure,last n failed
+type Matcher struct {
+	expr       string
+	severities map[Severity]bool
+	types      []string
+}
+
+// allSeverities are the valid Severities, from the least to the most severe.
+var allSeverities = []Severity{SEVERITY_DEBUG, SEVERITY_INFO, SEVERITY_WARNING, SEVERITY_ERROR}
+
+// seriousness returns a number which is larger for more severe messages.
+func seriousness(s Severity) int {
+	return -int(s)
+}
+
+// ParseSeverity parses the name of a Severity, e.g. "warning".
+func ParseSeverity(s string) (Severity, error) {
+	for _, sev := range allSeverities {
+		if sev.String() == s {
+			return sev, nil
+		}
+	}
+	return SEVERITY_DEBUG, fmt.Errorf("Unknown severity %q", s)
+}
+
+// ParseMatcher parses a Matcher expression.
+func ParseMatcher(expr string) (*Matcher, error) {
+	m := &Matcher{
+		expr: expr,
+	}
+	for _, clause := range strings.Split(expr, ";") {
+		clause = strings.TrimSpace(clause)
+		if clause == "" {
+			continue
+		}
+		if strings.HasPrefix(clause, "severity") {
+			if m.severities != nil {
+				return nil, fmt.Errorf("Multiple severity clauses in %q", expr)
+			}
+			severities, err := parseSeverityClause(strings.TrimSpace(strings.TrimPrefix(clause, "severity")))
+			if err != nil {
+				return nil, fmt.Errorf("Invalid clause %q: %s", clause, err)
+			}
+			m.severities = severities
+		} else if strings.HasPrefix(clause, "type=") {
+			if m.types != nil {
+				return nil, fmt.Errorf("Multiple type clauses in %q", expr)
+			}
+			for _, glob := range strings.Split(strings.TrimPrefix(clause, "type="), ",") {
+				glob = strings.TrimSpace(glob)
+				if _, err := path.Match(glob, ""); err != nil || glob == "" {
+					return nil, fmt.Errorf("Invalid clause %q: bad pattern %q", clause, glob)
+				}
+				m.types = append(m.types, glob)
+			}
+		} else {
+			return nil, fmt.Errorf("Unknown clause %q", clause)
+		}
+	}
+	return m, nil
+}
+
+// parseSeverityClause parses the part of a severity clause following
+// "severity" and returns the matching Severities.
+func parseSeverityClause(clause string) (map[Severity]bool, error) {
+	// Longer operators first, so that e.g. ">=" isn't parsed as ">".
+	for _, op := range []string{"!=", "<=", ">=", "=", "<", ">"} {
+		if !strings.HasPrefix(clause, op) {
+			continue
+		}
+		sev, err := ParseSeverity(strings.TrimSpace(strings.TrimPrefix(clause, op)))
+		if err != nil {
+			return nil, err
+		}
+		ret := map[Severity]bool{}
+		for _, s := range allSeverities {
+			diff := seriousness(s) - seriousness(sev)
+			match := false
+			switch op {
+			case "=":
+				match = diff == 0
+			case "!=":
+				match = diff != 0
+			case "<":
+				match = diff < 0
+			case "<=":
+				match = diff <= 0
+			case ">":
+				match = diff > 0
+			case ">=":
+				match = diff >= 0
+			}
+			if match {
+				ret[s] = true
+			}
+		}
+		return ret, nil
+	}
+	return nil, errors.New("expected one of =, !=, <, <=, >, >=")
+}
+
+// Matches returns true if the Message matches the Matcher.
+func (m *Matcher) Matches(msg *Message) bool {
+	if m.severities != nil && !m.severities[msg.Severity] {
+		return false
+	}
+	if m.types == nil {
+		return true
+	}
+	for _, glob := range m.types {
+		// The patterns were validated by ParseMatcher.
+		if ok, _ := path.Match(glob, msg.Type); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns the expression of the Matcher.
+func (m *Matcher) String() string {
+	return m.expr
+}
+
+// RuleConfig provides configuration for a routing rule, which sends the
+// messages matching an expression via any number of notifiers.
+type RuleConfig struct {
+	// Match is a Matcher expression, see ParseMatcher. Required, but may be
+	// empty to match all messages.
+	Match string `json:"match"`
+
+	// Notifiers which send the matching messages. Their Filter and
+	// IncludeMsgTypes must not be set. Required.
+	Notifiers []*Config `json:"notifiers"`
+
+	// Optional fields.
+
+	// If present, all messages inherit this subject line.
+	Subject string `json:"subject,omitempty"`
+}
+
+// Validate the RuleConfig.
+func (c *RuleConfig) Validate() error {
+	if _, err := ParseMatcher(c.Match); err != nil {
+		return err
+	}
+	if len(c.Notifiers) == 0 {
+		return errors.New("At least one notifier is required.")
+	}
+	for _, n := range c.Notifiers {
+		if n.Filter != "" || n.IncludeMsgTypes != nil {
+			return errors.New("Filter and IncludeMsgTypes may not be provided within rules; use Match instead.")
+		}
+		if err := n.validateTransport(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ConfigsToRules converts Configs into RuleConfigs, combining the Configs
+// which filter messages the same way into a single rule which fans out to all
+// of their notifiers. Configs which are silent are dropped. The Configs must be
+// valid.
+func ConfigsToRules(configs []*Config) ([]*RuleConfig, error) {
+	rules := []*RuleConfig{}
+	byMatch := map[string]*RuleConfig{}
+	for _, c := range configs {
+		var match string
+		if c.IncludeMsgTypes != nil {
+			globs := make([]string, 0, len(c.IncludeMsgTypes))
+			for _, t := range c.IncludeMsgTypes {
+				globs = append(globs, escapeGlob(t))
+			}
+			match = "type=" + strings.Join(globs, ",")
+		} else {
+			filter, err := ParseFilter(c.Filter)
+			if err != nil {
+				return nil, err
+			}
+			if filter == FILTER_SILENT {
+				continue
+			}
+			// Filters send the messages which are more severe than the
+			// Severity with the same value.
+			match = "severity>" + Severity(filter).String()
+			if filter == FILTER_DEBUG {
+				match = ""
+			}
+		}
+		transport := c.Copy()
+		transport.Filter = ""
+		transport.IncludeMsgTypes = nil
+		transport.Subject = ""
+		key := match + "\n" + c.Subject
+		rule, ok := byMatch[key]
+		if !ok {
+			rule = &RuleConfig{
+				Match:   match,
+				Subject: c.Subject,
+			}
+			byMatch[key] = rule
+			rules = append(rules, rule)
+		}
+		rule.Notifiers = append(rule.Notifiers, transport)
+	}
+	return rules, nil
+}
+
+// escapeGlob escapes the characters which are special in path.Match patterns.
+// Note that message types containing "," or ";" can't be matched exactly.
+func escapeGlob(s string) string {
+	var sb strings.Builder
+	for _, r := range s {
+		if strings.ContainsRune(`*?[]\`, r) {
+			sb.WriteRune('\\')
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+// AddRule adds Notifiers which send the Messages matching the given Matcher.
+// If singleThreadSubject is provided, that will be used as the subject for all
+// Messages, ignoring their Subject field.
+func (r *Router) AddRule(m *Matcher, singleThreadSubject string, notifiers ...Notifier) {
+	for _, n := range notifiers {
+		r.notifiers = append(r.notifiers, &filteredThreadedNotifier{
+			matcher:             m,
+			notifier:            n,
+			singleThreadSubject: singleThreadSubject,
+		})
+	}
+}
+
+// AddFromRuleConfig adds the rule specified by the given RuleConfig.
+func (r *Router) AddFromRuleConfig(ctx context.Context, c *RuleConfig) error {
+	if err := c.Validate(); err != nil {
+		return err
+	}
+	m, err := ParseMatcher(c.Match)
+	if err != nil {
+		return err
+	}
+	notifiers := make([]Notifier, 0, len(c.Notifiers))
+	for _, nc := range c.Notifiers {
+		n, err := nc.createTransport(ctx, r.client, r.emailer, r.configReader)
+		if err != nil {
+			return err
+		}
+		notifiers = append(notifiers, n)
+	}
+	r.AddRule(m, c.Subject, notifiers...)
+	return nil
+}
+
+// AddFromRuleConfigs adds all of the rules specified by the given RuleConfigs.
+func (r *Router) AddFromRuleConfigs(ctx context.Context, cfgs []*RuleConfig) error {
+	for _, c := range cfgs {
+		if err := r.AddFromRuleConfig(ctx, c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
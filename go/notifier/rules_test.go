@@ -0,0 +1,116 @@
+package notifier
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.skia.org/infra/email/go/emailclient"
+)
+
+func TestParseMatcher(t *testing.T) {
+	test := func(expr string, severity Severity, msgType string, expect bool) {
+		m, err := ParseMatcher(expr)
+		require.NoError(t, err)
+		require.Equal(t, expect, m.Matches(&Message{Severity: severity, Type: msgType}), "%q %s %q", expr, severity, msgType)
+	}
+	test("", SEVERITY_DEBUG, "any", true)
+	test("severity>=warning", SEVERITY_ERROR, "any", true)
+	test("severity>=warning", SEVERITY_WARNING, "any", true)
+	test("severity>=warning", SEVERITY_INFO, "any", false)
+	test("severity<warning", SEVERITY_INFO, "any", true)
+	test("severity<warning", SEVERITY_WARNING, "any", false)
+	test("severity=info", SEVERITY_INFO, "any", true)
+	test("severity!=info", SEVERITY_INFO, "any", false)
+	test("type=new *", SEVERITY_DEBUG, "new failure", true)
+	test("type=new *", SEVERITY_DEBUG, "mode change", false)
+	test("type=mode change, new *", SEVERITY_DEBUG, "mode change", true)
+	test("severity>info; type=new *", SEVERITY_ERROR, "new failure", true)
+	test("severity>info; type=new *", SEVERITY_INFO, "new failure", false)
+	test("severity>info; type=new *", SEVERITY_ERROR, "mode change", false)
+
+	for _, expr := range []string{"bogus", "severity~info", "severity>=bogus", "type=[", "type=", "severity>info; severity<error"} {
+		_, err := ParseMatcher(expr)
+		require.Error(t, err, expr)
+	}
+}
+
+func TestRuleConfigValidate(t *testing.T) {
+	c := &RuleConfig{Match: "severity>=warning"}
+	require.EqualError(t, c.Validate(), "At least one notifier is required.")
+
+	c.Notifiers = []*Config{{Filter: "debug", Chat: &ChatNotifierConfig{RoomID: "my-room"}}}
+	require.EqualError(t, c.Validate(), "Filter and IncludeMsgTypes may not be provided within rules; use Match instead.")
+
+	c.Notifiers = []*Config{{Chat: &ChatNotifierConfig{}}}
+	require.EqualError(t, c.Validate(), "RoomID is required.")
+
+	c.Notifiers = []*Config{{Chat: &ChatNotifierConfig{RoomID: "my-room"}}}
+	require.NoError(t, c.Validate())
+
+	c.Match = "bogus"
+	require.EqualError(t, c.Validate(), "Unknown clause \"bogus\"")
+}
+
+func TestRouter_AddRule_FansOut(t *testing.T) {
+	r := NewRouter(nil, emailclient.New(), nil)
+	m, err := ParseMatcher("severity>=warning; type=roll *")
+	require.NoError(t, err)
+	n1 := &testNotifier{}
+	n2 := &testNotifier{}
+	r.AddRule(m, "", n1, n2)
+
+	ctx := context.Background()
+	require.NoError(t, r.Send(ctx, &Message{
+		Subject:  "Roll failed",
+		Body:     "body",
+		Severity: SEVERITY_ERROR,
+		Type:     "roll failed",
+	}))
+	require.NoError(t, r.Send(ctx, &Message{
+		Subject:  "Roll succeeded",
+		Body:     "body",
+		Severity: SEVERITY_INFO,
+		Type:     "roll succeeded",
+	}))
+	for _, n := range []*testNotifier{n1, n2} {
+		require.Len(t, n.sent, 1)
+		require.Equal(t, "Roll failed", n.sent[0].subject)
+	}
+}
+
+func TestConfigsToRules(t *testing.T) {
+	chat := &ChatNotifierConfig{RoomID: "my-room"}
+	email := &EmailNotifierConfig{Emails: []string{"me@google.com"}}
+	rules, err := ConfigsToRules([]*Config{
+		{Filter: "warning", Chat: chat},
+		{Filter: "warning", Email: email},
+		{Filter: "silent", Email: email},
+		{Filter: "debug", Email: email, Subject: "Single thread"},
+		{IncludeMsgTypes: []string{"mode change", "new failure"}, Chat: chat},
+	})
+	require.NoError(t, err)
+	require.Len(t, rules, 3)
+
+	require.Equal(t, "severity>info", rules[0].Match)
+	require.Len(t, rules[0].Notifiers, 2)
+	require.Equal(t, chat, rules[0].Notifiers[0].Chat)
+	require.Equal(t, email, rules[0].Notifiers[1].Email)
+
+	require.Equal(t, "", rules[1].Match)
+	require.Equal(t, "Single thread", rules[1].Subject)
+	require.Len(t, rules[1].Notifiers, 1)
+
+	require.Equal(t, "type=mode change,new failure", rules[2].Match)
+
+	for _, rule := range rules {
+		require.NoError(t, rule.Validate())
+	}
+
+	// The rules filter messages like the configs did.
+	m, err := ParseMatcher(rules[0].Match)
+	require.NoError(t, err)
+	for _, sev := range allSeverities {
+		require.Equal(t, FILTER_WARNING.ShouldSend(sev), m.Matches(&Message{Severity: sev}), sev.String())
+	}
+}
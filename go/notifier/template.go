@@ -0,0 +1,95 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"text/template"
+)
+
+// TemplateData is the data available to the subject and body templates of a
+// Config. The templates are Go text/template templates, e.g.
+// "{{.Type}}: {{.Subject}}".
+type TemplateData struct {
+	// Subject is the subject of the Message, or the subject of the Config in
+	// single-thread mode.
+	Subject string
+	// Body is the original body of the Message.
+	Body     string
+	Severity Severity
+	Type     string
+	// Data is the extra data of the Message.
+	Data     map[string]interface{}
+	ThreadID string
+}
+
+// parseTemplate parses the given template, which is empty if not used.
+func parseTemplate(name, text string) (*template.Template, error) {
+	if text == "" {
+		return nil, nil
+	}
+	tmpl, err := template.New(name).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse %s: %s", name, err)
+	}
+	return tmpl, nil
+}
+
+// templatedNotifier is a Notifier implementation which renders the subject
+// and body of messages using templates before passing them on to another
+// Notifier.
+type templatedNotifier struct {
+	notifier Notifier
+	subject  *template.Template
+	body     *template.Template
+}
+
+// TemplatedNotifier returns a Notifier which renders the subject and body of
+// messages using the given templates before sending them via the given
+// Notifier. Either template may be empty, in which case the subject or body is
+// sent as-is.
+func TemplatedNotifier(n Notifier, subjectTemplate, bodyTemplate string) (Notifier, error) {
+	subject, err := parseTemplate("subject template", subjectTemplate)
+	if err != nil {
+		return nil, err
+	}
+	body, err := parseTemplate("body template", bodyTemplate)
+	if err != nil {
+		return nil, err
+	}
+	return &templatedNotifier{
+		notifier: n,
+		subject:  subject,
+		body:     body,
+	}, nil
+}
+
+// See documentation for Notifier interface.
+func (n *templatedNotifier) Send(ctx context.Context, subject string, msg *Message) error {
+	data := &TemplateData{
+		Subject:  subject,
+		Body:     msg.Body,
+		Severity: msg.Severity,
+		Type:     msg.Type,
+		Data:     msg.Data,
+		ThreadID: msg.ThreadID,
+	}
+	if n.subject != nil {
+		var buf bytes.Buffer
+		if err := n.subject.Execute(&buf, data); err != nil {
+			return fmt.Errorf("Failed to render subject: %s", err)
+		}
+		subject = buf.String()
+	}
+	if n.body != nil {
+		var buf bytes.Buffer
+		if err := n.body.Execute(&buf, data); err != nil {
+			return fmt.Errorf("Failed to render body: %s", err)
+		}
+		// Don't modify the Message, which is shared with other Notifiers.
+		msgCopy := *msg
+		msgCopy.Body = buf.String()
+		msg = &msgCopy
+	}
+	return n.notifier.Send(ctx, subject, msg)
+}
@@ -0,0 +1,73 @@
+package notifier
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.skia.org/infra/email/go/emailclient"
+)
+
+func TestTemplatedNotifier(t *testing.T) {
+	tn := &testNotifier{}
+	n, err := TemplatedNotifier(tn, "[{{.Severity}}] {{.Subject}}", "<b>{{.Type}}</b>: {{.Body}} ({{.Data.roller}})")
+	require.NoError(t, err)
+
+	msg := &Message{
+		Subject:  "Roll failed",
+		Body:     "The roll failed.",
+		Severity: SEVERITY_ERROR,
+		Type:     "roll-failed",
+		Data: map[string]interface{}{
+			"roller": "skia-autoroll",
+		},
+	}
+	require.NoError(t, n.Send(context.Background(), msg.Subject, msg))
+	require.Len(t, tn.sent, 1)
+	require.Equal(t, "[error] Roll failed", tn.sent[0].subject)
+	require.Equal(t, "<b>roll-failed</b>: The roll failed. (skia-autoroll)", tn.sent[0].msg.Body)
+	// The original Message is unchanged.
+	require.Equal(t, "The roll failed.", msg.Body)
+}
+
+func TestTemplatedNotifier_EmptyTemplates_SendsAsIs(t *testing.T) {
+	tn := &testNotifier{}
+	n, err := TemplatedNotifier(tn, "", "")
+	require.NoError(t, err)
+	msg := &Message{
+		Subject: "Subject",
+		Body:    "Body",
+		Type:    "type",
+	}
+	require.NoError(t, n.Send(context.Background(), "Thread", msg))
+	require.Len(t, tn.sent, 1)
+	require.Equal(t, "Thread", tn.sent[0].subject)
+	require.Equal(t, msg, tn.sent[0].msg)
+}
+
+func TestConfigValidate_InvalidTemplate(t *testing.T) {
+	c := Config{
+		Filter: "debug",
+		Chat: &ChatNotifierConfig{
+			RoomID: "my-room",
+		},
+		BodyTemplate: "{{.Body",
+	}
+	require.ErrorContains(t, c.Validate(), "Failed to parse body template")
+
+	c.BodyTemplate = "{{.Body}}"
+	require.NoError(t, c.Validate())
+}
+
+func TestRouter_AddFromConfig_Templates(t *testing.T) {
+	r := NewRouter(nil, emailclient.New(), nil)
+	require.NoError(t, r.AddFromConfig(context.Background(), &Config{
+		Filter: "debug",
+		Chat: &ChatNotifierConfig{
+			RoomID: "my-room",
+		},
+		SubjectTemplate: "{{.Type}}",
+	}))
+	require.Len(t, r.notifiers, 1)
+	require.IsType(t, &templatedNotifier{}, r.notifiers[0].notifier)
+}
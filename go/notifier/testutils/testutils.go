@@ -0,0 +1,139 @@
+// Package testutils provides fake Notifiers for testing code which sends
+// notifications.
+package testutils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/stretchr/testify/require"
+	"go.skia.org/infra/go/notifier"
+)
+
+// SentMessage is a Message sent to a thread.
+type SentMessage struct {
+	// Thread is the thread, i.e. the subject, passed to Send.
+	Thread  string
+	Message *notifier.Message
+}
+
+// RecordingNotifier is a notifier.Notifier which records the messages sent to
+// it. The zero value is ready to use, and it is safe for concurrent use, since
+// notifier.Router sends messages in goroutines.
+type RecordingNotifier struct {
+	mtx  sync.Mutex
+	sent []*SentMessage
+}
+
+// Send implements notifier.Notifier.
+func (n *RecordingNotifier) Send(_ context.Context, thread string, msg *notifier.Message) error {
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+	n.sent = append(n.sent, &SentMessage{
+		Thread:  thread,
+		Message: msg,
+	})
+	return nil
+}
+
+// Sent returns the messages sent so far, in the order they were sent.
+func (n *RecordingNotifier) Sent() []*SentMessage {
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+	return append([]*SentMessage{}, n.sent...)
+}
+
+// SentToThread returns the messages sent to the given thread so far, in the
+// order they were sent.
+func (n *RecordingNotifier) SentToThread(thread string) []*notifier.Message {
+	var ret []*notifier.Message
+	for _, s := range n.Sent() {
+		if s.Thread == thread {
+			ret = append(ret, s.Message)
+		}
+	}
+	return ret
+}
+
+// Reset forgets the messages sent so far.
+func (n *RecordingNotifier) Reset() {
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+	n.sent = nil
+}
+
+// AssertSent asserts that exactly one message of the given type and severity
+// was sent to the RecordingNotifier, and returns it.
+func AssertSent(t require.TestingT, n *RecordingNotifier, msgType string, severity notifier.Severity) *SentMessage {
+	var found []*SentMessage
+	for _, s := range n.Sent() {
+		if s.Message.Type == msgType && s.Message.Severity == severity {
+			found = append(found, s)
+		}
+	}
+	require.Len(t, found, 1, "Expected one %s message of type %q; sent: %s", severity, msgType, describe(n.Sent()))
+	return found[0]
+}
+
+// AssertNotSent asserts that no message of the given type was sent to the
+// RecordingNotifier.
+func AssertNotSent(t require.TestingT, n *RecordingNotifier, msgType string) {
+	for _, s := range n.Sent() {
+		require.NotEqual(t, msgType, s.Message.Type, "Unexpected message of type %q; sent: %s", msgType, describe(n.Sent()))
+	}
+}
+
+// AssertSentCount asserts that the given number of messages were sent to the
+// RecordingNotifier.
+func AssertSentCount(t require.TestingT, n *RecordingNotifier, count int) {
+	sent := n.Sent()
+	require.Len(t, sent, count, "Sent: %s", describe(sent))
+}
+
+// describe returns a short description of the messages for failed assertions.
+func describe(sent []*SentMessage) string {
+	ret := "["
+	for i, s := range sent {
+		if i > 0 {
+			ret += ", "
+		}
+		ret += fmt.Sprintf("(%s; %s): %s", s.Message.Severity, s.Message.Type, s.Thread)
+	}
+	return ret + "]"
+}
+
+// ErrSendFailed is the default error returned by FailingNotifier.
+var ErrSendFailed = errors.New("send failed")
+
+// FailingNotifier is a notifier.Notifier whose Send always fails, for testing
+// error paths. It returns Err, or ErrSendFailed if Err is nil.
+type FailingNotifier struct {
+	Err error
+
+	mtx   sync.Mutex
+	calls int
+}
+
+// Send implements notifier.Notifier.
+func (n *FailingNotifier) Send(_ context.Context, _ string, _ *notifier.Message) error {
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+	n.calls++
+	if n.Err != nil {
+		return n.Err
+	}
+	return ErrSendFailed
+}
+
+// Calls returns the number of times Send was called.
+func (n *FailingNotifier) Calls() int {
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+	return n.calls
+}
+
+// Assert that the fakes implement notifier.Notifier.
+var _ notifier.Notifier = &RecordingNotifier{}
+var _ notifier.Notifier = &FailingNotifier{}
@@ -0,0 +1,131 @@
+package notifier
+
+import (
+	"context"
+	"crypto/sha256"
+	"sync"
+	"time"
+
+	"go.skia.org/infra/go/metrics2"
+	"go.skia.org/infra/go/now"
+	"go.skia.org/infra/go/sklog"
+	"golang.org/x/time/rate"
+)
+
+// ThrottleOptions configures a ThrottledNotifier.
+type ThrottleOptions struct {
+	// DedupeWindow is the time during which identical messages, i.e. messages
+	// with the same type, thread, subject and body, are only sent once. Messages are
+	// not deduplicated if zero.
+	DedupeWindow time.Duration
+	// MaxSendsPerThread is the number of messages which may be sent to a
+	// single thread within ThrottlePeriod. Sends are not limited if zero.
+	MaxSendsPerThread int
+	// ThrottlePeriod is the period of MaxSendsPerThread.
+	ThrottlePeriod time.Duration
+}
+
+// dedupeKey identifies identical messages.
+type dedupeKey struct {
+	msgType  string
+	thread   string
+	subject  string
+	bodyHash [sha256.Size]byte
+}
+
+// throttledNotifier is a Notifier implementation which drops duplicate
+// messages and limits the rate of messages sent by another Notifier.
+type throttledNotifier struct {
+	notifier Notifier
+	opts     ThrottleOptions
+
+	sentCount         metrics2.Counter
+	deduplicatedCount metrics2.Counter
+	throttledCount    metrics2.Counter
+
+	mtx      sync.Mutex
+	lastSent map[dedupeKey]time.Time
+	limiters map[string]*rate.Limiter
+}
+
+// ThrottledNotifier returns a Notifier which wraps the given Notifier so that
+// noisy senders don't file duplicate bugs or spam chat rooms. Dropped messages
+// are logged but not reported as errors. The number of sent, deduplicated and
+// throttled messages are exposed as metrics, tagged with the given name.
+func ThrottledNotifier(n Notifier, name string, opts ThrottleOptions) Notifier {
+	tags := map[string]string{
+		"notifier": name,
+	}
+	return &throttledNotifier{
+		notifier:          n,
+		opts:              opts,
+		sentCount:         metrics2.GetCounter("notifier_sent", tags),
+		deduplicatedCount: metrics2.GetCounter("notifier_deduplicated", tags),
+		throttledCount:    metrics2.GetCounter("notifier_throttled", tags),
+		lastSent:          map[dedupeKey]time.Time{},
+		limiters:          map[string]*rate.Limiter{},
+	}
+}
+
+// See documentation for Notifier interface.
+func (n *throttledNotifier) Send(ctx context.Context, thread string, msg *Message) error {
+	key := dedupeKey{
+		msgType:  msg.Type,
+		thread:   thread,
+		subject:  msg.Subject,
+		bodyHash: sha256.Sum256([]byte(msg.Body)),
+	}
+	ts := now.Now(ctx)
+	if !n.allow(key, ts) {
+		return nil
+	}
+	if err := n.notifier.Send(ctx, thread, msg); err != nil {
+		// Allow the message to be sent again.
+		n.mtx.Lock()
+		delete(n.lastSent, key)
+		n.mtx.Unlock()
+		return err
+	}
+	n.sentCount.Inc(1)
+	return nil
+}
+
+// allow returns true if the message with the given key may be sent at the
+// given time, in which case it is recorded as sent.
+func (n *throttledNotifier) allow(key dedupeKey, ts time.Time) bool {
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+
+	if n.opts.DedupeWindow > 0 {
+		// Forget the messages which are outside of the window.
+		for k, sent := range n.lastSent {
+			if ts.Sub(sent) >= n.opts.DedupeWindow {
+				delete(n.lastSent, k)
+			}
+		}
+		if _, ok := n.lastSent[key]; ok {
+			sklog.Infof("Not sending duplicate %s notification to %q.", key.msgType, key.thread)
+			n.deduplicatedCount.Inc(1)
+			return false
+		}
+	}
+
+	if n.opts.MaxSendsPerThread > 0 && n.opts.ThrottlePeriod > 0 {
+		limiter, ok := n.limiters[key.thread]
+		if !ok {
+			limit := rate.Every(n.opts.ThrottlePeriod / time.Duration(n.opts.MaxSendsPerThread))
+			limiter = rate.NewLimiter(limit, n.opts.MaxSendsPerThread)
+			n.limiters[key.thread] = limiter
+		}
+		if !limiter.AllowN(ts, 1) {
+			sklog.Warningf("Not sending %s notification to %q; more than %d sent within %s.", key.msgType, key.thread, n.opts.MaxSendsPerThread, n.opts.ThrottlePeriod)
+			n.throttledCount.Inc(1)
+			return false
+		}
+	}
+
+	if n.opts.DedupeWindow > 0 {
+		n.lastSent[key] = ts
+	}
+	return true
+}
@@ -0,0 +1,96 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.skia.org/infra/go/metrics2"
+	"go.skia.org/infra/go/now"
+)
+
+type failingNotifier struct{}
+
+func (n *failingNotifier) Send(_ context.Context, _ string, _ *Message) error {
+	return errors.New("failed")
+}
+
+func throttleTestMessage(body string) *Message {
+	return &Message{
+		Subject:  "Subject",
+		Body:     body,
+		Severity: SEVERITY_WARNING,
+		Type:     "my-msg-type",
+	}
+}
+
+func TestThrottledNotifier_DedupesWithinWindow(t *testing.T) {
+	ts := time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)
+	ctx := now.TimeTravelingContext(ts)
+	tn := &testNotifier{}
+	n := ThrottledNotifier(tn, "dedupe-test", ThrottleOptions{
+		DedupeWindow: time.Hour,
+	})
+
+	require.NoError(t, n.Send(ctx, "thread", throttleTestMessage("body")))
+	require.NoError(t, n.Send(ctx, "thread", throttleTestMessage("body")))
+	// Different bodies, subjects and threads are not duplicates.
+	require.NoError(t, n.Send(ctx, "thread", throttleTestMessage("other body")))
+	require.NoError(t, n.Send(ctx, "other thread", throttleTestMessage("body")))
+	otherSubject := throttleTestMessage("body")
+	otherSubject.Subject = "Other subject"
+	require.NoError(t, n.Send(ctx, "thread", otherSubject))
+	require.Len(t, tn.sent, 4)
+
+	// The duplicate is sent again once the window has passed.
+	ctx.SetTime(ts.Add(time.Hour))
+	require.NoError(t, n.Send(ctx, "thread", throttleTestMessage("body")))
+	require.Len(t, tn.sent, 5)
+
+	tags := map[string]string{"notifier": "dedupe-test"}
+	require.Equal(t, int64(5), metrics2.GetCounter("notifier_sent", tags).Get())
+	require.Equal(t, int64(1), metrics2.GetCounter("notifier_deduplicated", tags).Get())
+	require.Equal(t, int64(0), metrics2.GetCounter("notifier_throttled", tags).Get())
+}
+
+func TestThrottledNotifier_LimitsSendsPerThread(t *testing.T) {
+	ts := time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)
+	ctx := now.TimeTravelingContext(ts)
+	tn := &testNotifier{}
+	n := ThrottledNotifier(tn, "throttle-test", ThrottleOptions{
+		MaxSendsPerThread: 2,
+		ThrottlePeriod:    time.Hour,
+	})
+
+	for _, body := range []string{"1", "2", "3"} {
+		require.NoError(t, n.Send(ctx, "thread", throttleTestMessage(body)))
+	}
+	require.Len(t, tn.sent, 2)
+	// Other threads have their own limit.
+	require.NoError(t, n.Send(ctx, "other thread", throttleTestMessage("1")))
+	require.Len(t, tn.sent, 3)
+
+	// Sends are allowed again as time passes.
+	ctx.SetTime(ts.Add(30 * time.Minute))
+	require.NoError(t, n.Send(ctx, "thread", throttleTestMessage("4")))
+	require.Len(t, tn.sent, 4)
+
+	tags := map[string]string{"notifier": "throttle-test"}
+	require.Equal(t, int64(4), metrics2.GetCounter("notifier_sent", tags).Get())
+	require.Equal(t, int64(1), metrics2.GetCounter("notifier_throttled", tags).Get())
+}
+
+func TestThrottledNotifier_FailedSendIsNotDeduped(t *testing.T) {
+	ctx := context.Background()
+	n := ThrottledNotifier(&failingNotifier{}, "failure-test", ThrottleOptions{
+		DedupeWindow: time.Hour,
+	})
+	require.Error(t, n.Send(ctx, "thread", throttleTestMessage("body")))
+	require.Error(t, n.Send(ctx, "thread", throttleTestMessage("body")))
+
+	tags := map[string]string{"notifier": "failure-test"}
+	require.Equal(t, int64(0), metrics2.GetCounter("notifier_sent", tags).Get())
+	require.Equal(t, int64(0), metrics2.GetCounter("notifier_deduplicated", tags).Get())
+}
@@ -32,7 +32,9 @@ type imgTest struct {
 	passFailStep                bool
 	patchsetID                  string
 	patchsetOrder               int
+	reportMatchMetrics          bool
 	tryJobID                    string
+	uploadDebugArtifacts        bool
 	uploadOnly                  bool
 	urlOverride                 string
 	workDir                     string
@@ -144,6 +146,8 @@ func (i *imgTest) addCommonFlags(cmd *cobra.Command, optional bool) {
 	cmd.Flags().StringVar(&i.workDir, fstrWorkDir, "", "Work directory for intermediate results")
 	cmd.Flags().BoolVar(&i.passFailStep, "passfail", false, "Whether the 'add' call returns a pass/fail for each test.")
 	cmd.Flags().BoolVar(&i.uploadOnly, "upload-only", false, "Skip reading expectations from the server. Incompatible with passfail=true.")
+	cmd.Flags().BoolVar(&i.reportMatchMetrics, "report-match-metrics", false, "Whether to attach the name and metrics of the non-exact image matching algorithm as optional keys to the results of images that match via said algorithm. Only used if passfail=true.")
+	cmd.Flags().BoolVar(&i.uploadDebugArtifacts, "upload-debug-artifacts", false, "Whether to upload to GCS the debug artifacts of any non-exact image matching algorithm for images that do not match. Only used if passfail=true.")
 
 	cmd.Flags().StringVar(&i.bucketOverride, "bucket", "", "GCS Bucket to write to. If empty the URL will be derived from the value of 'instance'")
 	cmd.Flags().StringVar(&i.changelistID, "changelist", "", "Changelist ID if this is run as a TryJob.")
@@ -255,13 +259,15 @@ func (i *imgTest) Init(ctx context.Context) {
 	}
 
 	config := goldclient.GoldClientConfig{
-		FailureFile:     i.failureFile,
-		InstanceID:      i.instanceID,
-		OverrideBucket:  i.bucketOverride,
-		OverrideGoldURL: i.urlOverride,
-		PassFailStep:    i.passFailStep,
-		UploadOnly:      i.uploadOnly,
-		WorkDir:         i.workDir,
+		FailureFile:          i.failureFile,
+		InstanceID:           i.instanceID,
+		OverrideBucket:       i.bucketOverride,
+		OverrideGoldURL:      i.urlOverride,
+		PassFailStep:         i.passFailStep,
+		ReportMatchMetrics:   i.reportMatchMetrics,
+		UploadDebugArtifacts: i.uploadDebugArtifacts,
+		UploadOnly:           i.uploadOnly,
+		WorkDir:              i.workDir,
 	}
 	goldClient, err := goldclient.NewCloudClient(config)
 	ifErrLogExit(ctx, err)
@@ -327,13 +333,15 @@ func (i *imgTest) Add(ctx context.Context) {
 		}
 
 		config := goldclient.GoldClientConfig{
-			FailureFile:     i.failureFile,
-			InstanceID:      i.instanceID,
-			OverrideBucket:  i.bucketOverride,
-			OverrideGoldURL: i.urlOverride,
-			PassFailStep:    i.passFailStep,
-			UploadOnly:      i.uploadOnly,
-			WorkDir:         i.workDir,
+			FailureFile:          i.failureFile,
+			InstanceID:           i.instanceID,
+			OverrideBucket:       i.bucketOverride,
+			OverrideGoldURL:      i.urlOverride,
+			PassFailStep:         i.passFailStep,
+			ReportMatchMetrics:   i.reportMatchMetrics,
+			UploadDebugArtifacts: i.uploadDebugArtifacts,
+			UploadOnly:           i.uploadOnly,
+			WorkDir:              i.workDir,
 		}
 		goldClient, err = goldclient.NewCloudClient(config)
 		ifErrLogExit(ctx, err)
@@ -14,17 +14,22 @@ import (
 	"go.skia.org/infra/go/skerr"
 	"go.skia.org/infra/go/util"
 	"go.skia.org/infra/gold-client/go/imgmatching"
+	"go.skia.org/infra/gold-client/go/imgmatching/cropped"
+	"go.skia.org/infra/gold-client/go/imgmatching/downscale"
 	"go.skia.org/infra/gold-client/go/imgmatching/exact"
 	"go.skia.org/infra/gold-client/go/imgmatching/fuzzy"
+	"go.skia.org/infra/gold-client/go/imgmatching/masked"
 	"go.skia.org/infra/gold-client/go/imgmatching/positive_if_only_image"
 	"go.skia.org/infra/gold-client/go/imgmatching/sample_area"
 	"go.skia.org/infra/gold-client/go/imgmatching/sobel"
+	"go.skia.org/infra/gold-client/go/imgmatching/ssim"
 )
 
 // matchEnv provides the environment for the match command.
 type matchEnv struct {
-	algorithmName string
-	parameters    []string
+	algorithmName     string
+	parameters        []string
+	debugArtifactsDir string
 }
 
 // getMatchCmd returns the definition of the match command.
@@ -48,6 +53,7 @@ algorithms and parameters.
 
 	cmd.Flags().StringVar(&env.algorithmName, "algorithm", "", "Image matching algorithm (e.g. exact, fuzzy, sobel).")
 	cmd.Flags().StringArrayVar(&env.parameters, "parameter", []string{}, "Any number of algorithm-specific parameters represented as name:value pairs (e.g. sobel_edge_threshold:10).")
+	cmd.Flags().StringVar(&env.debugArtifactsDir, "debug-artifacts-dir", "", "If set, any intermediate images and numeric metrics produced by the algorithm will be written to this directory.")
 	must(cmd.MarkFlagRequired("algorithm"))
 
 	return cmd
@@ -82,8 +88,28 @@ func (m *matchEnv) Match(ctx context.Context, leftFile, rightFile string) {
 		logInfo(ctx, "Images do not match.\n")
 	}
 
+	// Write out the algorithm's debug artifacts, if requested.
+	if m.debugArtifactsDir != "" {
+		err := writeDebugArtifacts(ctx, matcher, m.debugArtifactsDir)
+		ifErrLogExit(ctx, err)
+	}
+
+	// Print out debug information about any size tolerance and ignored regions, then unwrap the
+	// underlying algorithm.
+	if croppedMatcher, ok := matcher.(*cropped.Matcher); ok {
+		printOutCroppedDebugInfo(ctx, croppedMatcher)
+		matcher = croppedMatcher.Delegate
+	}
+	if maskedMatcher, ok := matcher.(*masked.Matcher); ok {
+		err := printOutMaskedDebugInfo(ctx, maskedMatcher)
+		ifErrLogExit(ctx, err)
+		matcher = maskedMatcher.Delegate
+	}
+
 	// Print out algorithm-specific debug information.
 	switch algorithmName {
+	case imgmatching.DownscaleFuzzyMatching:
+		printOutDownscaleDebugInfo(ctx, matcher.(*downscale.Matcher))
 	case imgmatching.ExactMatching:
 		printOutExactDebugInfo(ctx, matcher.(*exact.Matcher))
 	case imgmatching.FuzzyMatching:
@@ -95,6 +121,8 @@ func (m *matchEnv) Match(ctx context.Context, leftFile, rightFile string) {
 	case imgmatching.SobelFuzzyMatching:
 		err := printOutSobelDebugInfo(ctx, matcher.(*sobel.Matcher))
 		ifErrLogExit(ctx, err)
+	case imgmatching.SSIMMatching:
+		printOutSSIMDebugInfo(ctx, matcher.(*ssim.Matcher))
 	}
 
 	exitProcess(ctx, 0)
@@ -132,6 +160,24 @@ func makeOptionalKeys(algorithmName string, parameters []string) (map[string]str
 	return keys, nil
 }
 
+// writeDebugArtifacts writes the debug artifacts reported by the given matcher, if any, to the
+// given directory.
+func writeDebugArtifacts(ctx context.Context, matcher imgmatching.Matcher, dir string) error {
+	provider, ok := matcher.(imgmatching.DebugArtifactsProvider)
+	if !ok {
+		logInfof(ctx, "The image matching algorithm does not produce any debug artifacts.\n")
+		return nil
+	}
+	paths, err := provider.DebugArtifacts().WriteToDir(dir)
+	if err != nil {
+		return skerr.Wrapf(err, "writing debug artifacts to %s", dir)
+	}
+	for _, p := range paths {
+		printDebugInfoItem(ctx, "Debug artifact", p)
+	}
+	return nil
+}
+
 // printOutExactDebugInfo prints out stats debug info reported by the given exact.Matcher.
 func printOutExactDebugInfo(ctx context.Context, matcher *exact.Matcher) {
 	printDebugInfoItem(ctx, "Last different pixel found", matcher.LastDifferentPixelFound())
@@ -140,8 +186,15 @@ func printOutExactDebugInfo(ctx context.Context, matcher *exact.Matcher) {
 // printOutFuzzyDebugInfo prints out stats reported by the given fuzzy.Matcher.
 func printOutFuzzyDebugInfo(ctx context.Context, matcher *fuzzy.Matcher) {
 	printDebugInfoItem(ctx, "Number of different pixels", matcher.NumDifferentPixels())
-	printDebugInfoItem(ctx, "Maximum delta", matcher.MaxPixelDelta())
+	if matcher.PixelDeltaMetric == fuzzy.PixelDeltaMetricCIEDE2000 {
+		printDebugInfoItem(ctx, "Maximum CIEDE2000 delta", matcher.MaxCIEDE2000Delta())
+	} else {
+		printDebugInfoItem(ctx, "Maximum delta", matcher.MaxPixelDelta())
+	}
 	printDebugInfoItem(ctx, "Pixel comparison method", matcher.PixelComparisonMethod())
+	if matcher.AlphaMode != fuzzy.AlphaModeDefault {
+		printDebugInfoItem(ctx, "Alpha mode", matcher.AlphaMode)
+	}
 }
 
 // printOutPositiveIfOnlyImageDebugInfo prints out stats reported by the given
@@ -195,10 +248,58 @@ func printOutSobelDebugInfo(ctx context.Context, matcher *sobel.Matcher) error {
 		return skerr.Wrap(err)
 	}
 
+	if matcher.BorderMode != sobel.BorderModeZero {
+		printDebugInfoItem(ctx, "Border mode", matcher.BorderMode)
+	}
+	printOutFuzzyDebugInfo(ctx, &matcher.Matcher)
+	return nil
+}
+
+// printOutDownscaleDebugInfo prints out the size of the images compared by the given
+// downscale.Matcher, and the stats reported by the embedded fuzzy.Matcher.
+func printOutDownscaleDebugInfo(ctx context.Context, matcher *downscale.Matcher) {
+	if matcher.DownscaledExpectedImage() != nil {
+		printDebugInfoItem(ctx, "Downscaled image size", matcher.DownscaledExpectedImage().Bounds().Size())
+	}
 	printOutFuzzyDebugInfo(ctx, &matcher.Matcher)
+}
+
+// printOutCroppedDebugInfo prints out stats reported by the given cropped.Matcher.
+func printOutCroppedDebugInfo(ctx context.Context, matcher *cropped.Matcher) {
+	printDebugInfoItem(ctx, "Maximum size delta", matcher.MaxSizeDelta)
+	printDebugInfoItem(ctx, "Size delta", matcher.SizeDelta())
+}
+
+// printOutMaskedDebugInfo prints out stats reported by the given masked.Matcher, and writes the
+// mask of ignored pixels to a temporary directory.
+func printOutMaskedDebugInfo(ctx context.Context, matcher *masked.Matcher) error {
+	printDebugInfoItem(ctx, "Ignored regions", matcher.IgnoredRegions)
+	printDebugInfoItem(ctx, "Number of ignored pixels", matcher.NumIgnoredPixels())
+
+	// The mask is not computed if the images are of different sizes.
+	if matcher.CombinedMask() == nil {
+		return nil
+	}
+
+	tempDir, err := os.MkdirTemp("", "goldctl-*")
+	if err != nil {
+		return skerr.Wrap(err)
+	}
+	p := filepath.Join(tempDir, "ignored-pixels-mask.png")
+	if err := writePngToTmp(matcher.CombinedMask(), p); err != nil {
+		return skerr.Wrap(err)
+	}
+	printDebugInfoItem(ctx, "Mask of ignored pixels", p)
 	return nil
 }
 
+// printOutSSIMDebugInfo prints out stats reported by the given ssim.Matcher.
+func printOutSSIMDebugInfo(ctx context.Context, matcher *ssim.Matcher) {
+	printDebugInfoItem(ctx, "Mean SSIM", matcher.Score())
+	printDebugInfoItem(ctx, "Lowest window SSIM", matcher.LowestScore())
+	printDebugInfoItem(ctx, "Lowest SSIM window", matcher.LowestScoreWindow())
+}
+
 // writePngToTmp takes an image, saves it to disk as a PNG image with the given filename.
 func writePngToTmp(img image.Image, filename string) error {
 	err := util.WithWriteFile(filename, func(writer io.Writer) error {
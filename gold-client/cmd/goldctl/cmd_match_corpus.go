@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"sort"
+
+	"github.com/spf13/cobra"
+	"go.skia.org/infra/gold-client/go/imgmatching/corpus"
+)
+
+// matchCorpusEnv provides the environment for the match-corpus command.
+type matchCorpusEnv struct {
+	allResults bool
+}
+
+// getMatchCorpusCmd returns the definition of the match-corpus command.
+func getMatchCorpusCmd() *cobra.Command {
+	env := &matchCorpusEnv{}
+	cmd := &cobra.Command{
+		Use:   "match-corpus",
+		Short: "Runs image matching algorithms over a corpus of image pairs with expected outcomes",
+		Long: `
+Takes a directory containing a manifest.json file and runs every image matching algorithm defined
+in the manifest against every pair of images listed in it.
+
+Reports any pairs for which an algorithm's output differs from the expected output in the
+manifest, and exits with a non-zero exit code if there are any such regressions.
+
+This command is intended for validating changes to the image matching algorithms against a large
+corpus of real-world images.
+`,
+		Args: cobra.ExactArgs(1), // Takes exactly one corpus directory as a positional argument.
+		Run:  env.runMatchCorpusCmd,
+	}
+
+	cmd.Flags().BoolVar(&env.allResults, "all-results", false, "If set, the outcome of every algorithm on every pair will be printed out, not just regressions.")
+
+	return cmd
+}
+
+func (m *matchCorpusEnv) runMatchCorpusCmd(cmd *cobra.Command, args []string) {
+	ctx := cmd.Context()
+	m.MatchCorpus(ctx, args[0])
+}
+
+// MatchCorpus runs the image matching algorithms in the manifest of the given corpus directory.
+func (m *matchCorpusEnv) MatchCorpus(ctx context.Context, dir string) {
+	manifest, err := corpus.LoadManifest(dir)
+	ifErrLogExit(ctx, err)
+	report, err := corpus.Run(manifest, dir)
+	ifErrLogExit(ctx, err)
+
+	if m.allResults {
+		for _, r := range report.Results {
+			printCorpusResult(ctx, r)
+		}
+	}
+
+	regressions := report.Regressions()
+	for _, r := range regressions {
+		logInfof(ctx, "REGRESSION: pair %q, matcher %q: expected match=%t, got match=%t\n", r.Pair, r.Matcher, r.Expected, r.Actual)
+	}
+	logInfof(ctx, "Ran %d matchers over %d pairs: %d results, %d regressions.\n",
+		len(manifest.Matchers), len(manifest.Pairs), len(report.Results), len(regressions))
+
+	if len(regressions) > 0 {
+		exitProcess(ctx, 1)
+	}
+	exitProcess(ctx, 0)
+}
+
+// printCorpusResult prints out the outcome of a single matcher on a single pair of images.
+func printCorpusResult(ctx context.Context, r corpus.Result) {
+	status := "unchecked"
+	if r.Checked {
+		status = "ok"
+		if r.IsRegression() {
+			status = "REGRESSION"
+		}
+	}
+	logInfof(ctx, "%s / %s: match=%t (%s)\n", r.Pair, r.Matcher, r.Actual, status)
+	names := make([]string, 0, len(r.Metrics))
+	for name := range r.Metrics {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		printDebugInfoItem(ctx, name, r.Metrics[name])
+	}
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.skia.org/infra/go/testutils"
+	"go.skia.org/infra/gold-client/go/imgmatching/corpus"
+)
+
+// writeCorpusManifest writes a manifest referencing the a01 and a05 test images to a temporary
+// directory, with the given expected outcome of the exact matcher on the a01/a05 pair.
+func writeCorpusManifest(t *testing.T, expectA01A05Match bool) string {
+	td := testutils.TestDataDir(t)
+	a01 := filepath.Join(td, a01Digest+".png")
+	a05 := filepath.Join(td, a05Digest+".png")
+
+	dir := t.TempDir()
+	manifest := fmt.Sprintf(`{
+	  "matchers": {
+	    "exact": {"image_matching_algorithm": "exact"},
+	    "fuzzy": {"image_matching_algorithm": "fuzzy", "fuzzy_max_different_pixels": "2", "fuzzy_pixel_delta_threshold": "10"}
+	  },
+	  "pairs": [
+	    {"name": "a01-a01", "left": %q, "right": %q, "expected": {"exact": true, "fuzzy": true}},
+	    {"name": "a01-a05", "left": %q, "right": %q, "expected": {"exact": %t, "fuzzy": true}}
+	  ]
+	}`, a01, a01, a01, a05, expectA01A05Match)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, corpus.ManifestFileName), []byte(manifest), 0644))
+	return dir
+}
+
+func TestMatchCorpus_NoRegressions_ExitCodeZero(t *testing.T) {
+
+	ctx, output, exit := testContext(nil, nil, nil, nil)
+	env := matchCorpusEnv{}
+	runUntilExit(t, func() {
+		env.MatchCorpus(ctx, writeCorpusManifest(t, false))
+	})
+	logs := output.String()
+	exit.AssertWasCalledWithCode(t, 0, logs)
+
+	assert.Equal(t, "Ran 2 matchers over 2 pairs: 4 results, 0 regressions.\n", logs)
+}
+
+func TestMatchCorpus_Regression_ExitCodeOne(t *testing.T) {
+
+	ctx, output, exit := testContext(nil, nil, nil, nil)
+	env := matchCorpusEnv{allResults: true}
+	runUntilExit(t, func() {
+		env.MatchCorpus(ctx, writeCorpusManifest(t, true))
+	})
+	logs := output.String()
+	exit.AssertWasCalledWithCode(t, 1, logs)
+
+	assert.Contains(t, logs, "a01-a01 / exact: match=true (ok)\n")
+	assert.Contains(t, logs, "a01-a05 / exact: match=false (REGRESSION)\n")
+	assert.Contains(t, logs, "a01-a05 / fuzzy: match=true (ok)\n")
+	assert.Contains(t, logs, "num_different_pixels: 2\n")
+	assert.Contains(t, logs, `REGRESSION: pair "a01-a05", matcher "exact": expected match=true, got match=false`)
+	assert.Contains(t, logs, "Ran 2 matchers over 2 pairs: 4 results, 1 regressions.\n")
+}
+
+func TestMatchCorpus_MissingManifest_ExitCodeOne(t *testing.T) {
+
+	ctx, output, exit := testContext(nil, nil, nil, nil)
+	env := matchCorpusEnv{}
+	runUntilExit(t, func() {
+		env.MatchCorpus(ctx, t.TempDir())
+	})
+	logs := output.String()
+	exit.AssertWasCalledWithCode(t, 1, logs)
+
+	assert.Contains(t, logs, "reading manifest")
+}
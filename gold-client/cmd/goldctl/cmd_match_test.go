@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"go.skia.org/infra/go/testutils"
 	"go.skia.org/infra/gold-client/go/imgmatching"
+	"go.skia.org/infra/gold-client/go/imgmatching/debugartifacts"
 )
 
 func TestMatch_Fuzzy_NonZeroPixelDeltaThreshold_ImagesAreWithinTolerance_ExitCodeZero(t *testing.T) {
@@ -88,3 +89,127 @@ func TestMatch_Sobel_ImagesAreVeryDifferent_ExitCodeZero(t *testing.T) {
 	assert.Contains(t, logs, `Maximum delta: 1020`, logs)
 	assert.Contains(t, logs, `Pixel comparison method: pixel delta threshold`, logs)
 }
+
+func TestMatch_Downscale_IdenticalImages_ExitCodeZero(t *testing.T) {
+
+	td := testutils.TestDataDir(t)
+
+	ctx, output, exit := testContext(nil, nil, nil, nil)
+	env := matchEnv{
+		algorithmName: "downscale",
+		parameters: []string{
+			string(imgmatching.DownscaleFactor + ":2"),
+			string(imgmatching.MaxDifferentPixels + ":0"),
+			string(imgmatching.PixelDeltaThreshold + ":0"),
+		},
+	}
+	runUntilExit(t, func() {
+		env.Match(ctx, filepath.Join(td, a01Digest+".png"), filepath.Join(td, a01Digest+".png"))
+	})
+	logs := output.String()
+	exit.AssertWasCalledWithCode(t, 0, output.String())
+
+	assert.Contains(t, logs, `Images match.`, logs)
+	assert.Contains(t, logs, `Downscaled image size: (4,4)`, logs)
+	assert.Contains(t, logs, `Number of different pixels: 0`, logs)
+}
+
+func TestMatch_SSIM_IdenticalImages_ExitCodeZero(t *testing.T) {
+
+	td := testutils.TestDataDir(t)
+
+	ctx, output, exit := testContext(nil, nil, nil, nil)
+	env := matchEnv{
+		algorithmName: "ssim",
+		parameters: []string{
+			string(imgmatching.SSIMMinScore + ":0.99"),
+		},
+	}
+	runUntilExit(t, func() {
+		env.Match(ctx, filepath.Join(td, a01Digest+".png"), filepath.Join(td, a01Digest+".png"))
+	})
+	logs := output.String()
+	exit.AssertWasCalledWithCode(t, 0, output.String())
+
+	assert.Contains(t, logs, `Images match.`, logs)
+	assert.Contains(t, logs, `Mean SSIM: 1`, logs)
+	assert.Contains(t, logs, `Lowest window SSIM: 1`, logs)
+}
+
+func TestMatch_Fuzzy_IgnoredRegions_ReportsIgnoredPixels(t *testing.T) {
+
+	td := testutils.TestDataDir(t)
+
+	ctx, output, exit := testContext(nil, nil, nil, nil)
+	env := matchEnv{
+		algorithmName: "fuzzy",
+		parameters: []string{
+			string(imgmatching.MaxDifferentPixels + ":0"),
+			string(imgmatching.PixelDeltaThreshold + ":0"),
+			string(imgmatching.IgnoredRegions + ":0,0,2,3"),
+		},
+	}
+	runUntilExit(t, func() {
+		env.Match(ctx, filepath.Join(td, a01Digest+".png"), filepath.Join(td, a01Digest+".png"))
+	})
+	logs := output.String()
+	exit.AssertWasCalledWithCode(t, 0, output.String())
+
+	assert.Contains(t, logs, `Images match.`, logs)
+	assert.Contains(t, logs, `Ignored regions: [(0,0)-(2,3)]`, logs)
+	assert.Contains(t, logs, `Number of ignored pixels: 6`, logs)
+	assert.Contains(t, logs, `Mask of ignored pixels: `, logs)
+	assert.Contains(t, logs, `Number of different pixels: 0`, logs)
+}
+
+func TestMatch_Sobel_DebugArtifactsDir_WritesArtifacts(t *testing.T) {
+
+	td := testutils.TestDataDir(t)
+	outDir := filepath.Join(t.TempDir(), "artifacts")
+
+	ctx, output, exit := testContext(nil, nil, nil, nil)
+	env := matchEnv{
+		algorithmName: "sobel",
+		parameters: []string{
+			string(imgmatching.EdgeThreshold + ":10"),
+			string(imgmatching.MaxDifferentPixels + ":0"),
+			string(imgmatching.PixelDeltaThreshold + ":0"),
+		},
+		debugArtifactsDir: outDir,
+	}
+	runUntilExit(t, func() {
+		env.Match(ctx, filepath.Join(td, a01Digest+".png"), filepath.Join(td, a05Digest+".png"))
+	})
+	logs := output.String()
+	exit.AssertWasCalledWithCode(t, 0, output.String())
+
+	for _, name := range []string{"sobel-output.png", "expected-with-edges-removed.png", "actual-with-edges-removed.png", debugartifacts.MetricsFileName} {
+		assert.Contains(t, logs, "Debug artifact: "+filepath.Join(outDir, name), logs)
+		assert.FileExists(t, filepath.Join(outDir, name))
+	}
+}
+
+func TestMatch_Fuzzy_MaxSizeDelta_ReportsSizeDelta(t *testing.T) {
+
+	td := testutils.TestDataDir(t)
+
+	ctx, output, exit := testContext(nil, nil, nil, nil)
+	env := matchEnv{
+		algorithmName: "fuzzy",
+		parameters: []string{
+			string(imgmatching.MaxDifferentPixels + ":0"),
+			string(imgmatching.PixelDeltaThreshold + ":0"),
+			string(imgmatching.MaxSizeDelta + ":1"),
+		},
+	}
+	runUntilExit(t, func() {
+		env.Match(ctx, filepath.Join(td, a01Digest+".png"), filepath.Join(td, a01Digest+".png"))
+	})
+	logs := output.String()
+	exit.AssertWasCalledWithCode(t, 0, output.String())
+
+	assert.Contains(t, logs, `Images match.`, logs)
+	assert.Contains(t, logs, `Maximum size delta: 1`, logs)
+	assert.Contains(t, logs, `Size delta: 0`, logs)
+	assert.Contains(t, logs, `Number of different pixels: 0`, logs)
+}
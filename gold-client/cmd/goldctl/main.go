@@ -46,6 +46,7 @@ It can be used directly or in a scripted environment. `,
 	rootCmd.AddCommand(getDumpCmd())
 	rootCmd.AddCommand(getDiffCmd())
 	rootCmd.AddCommand(getMatchCmd())
+	rootCmd.AddCommand(getMatchCorpusCmd())
 	rootCmd.AddCommand(getWhoamiCmd())
 
 	ctx := executionContext(context.Background(), os.Stdout, os.Stderr, os.Exit)
@@ -15,6 +15,7 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
 
 	"golang.org/x/sync/errgroup"
@@ -43,6 +44,10 @@ const (
 	// digestsDirectory is the directory inside the work directory in which digests downloaded from
 	// GCS will be cached.
 	digestsDirectory = "digests"
+
+	// debugArtifactsDirectory is the directory inside the work directory in which the debug
+	// artifacts of non-exact image matching algorithms are written before being uploaded to GCS.
+	debugArtifactsDirectory = "debug-artifacts"
 )
 
 // GoldClient is the uniform interface to communicate with the Gold service.
@@ -167,6 +172,16 @@ type GoldClientConfig struct {
 	// UploadOnly is a mode where we don't check expectations against the server - i.e.
 	// we just operate in upload mode.
 	UploadOnly bool
+
+	// UploadDebugArtifacts indicates whether the debug artifacts (e.g. intermediate images) of
+	// non-exact image matching algorithms should be uploaded to GCS for any images that do not
+	// match their baseline, to assist in triaging them.
+	UploadDebugArtifacts bool
+
+	// ReportMatchMetrics indicates whether the name and metrics (e.g. number of different pixels) of
+	// the non-exact image matching algorithm should be attached to the results as optional keys for
+	// any images that match their baseline via said algorithm.
+	ReportMatchMetrics bool
 }
 
 // NewCloudClient returns an implementation of the GoldClient that relies on the Gold service.
@@ -258,6 +273,8 @@ func (c *CloudClient) SetSharedConfig(ctx context.Context, sharedConfig jsonio.G
 		existingConfig.OverrideGoldURL = c.resultState.GoldURL
 		existingConfig.PassFailStep = c.resultState.PerTestPassFail
 		existingConfig.UploadOnly = c.resultState.UploadOnly
+		existingConfig.UploadDebugArtifacts = c.resultState.UploadDebugArtifacts
+		existingConfig.ReportMatchMetrics = c.resultState.ReportMatchMetrics
 	}
 	c.resultState = newResultState(sharedConfig, &existingConfig)
 
@@ -346,17 +363,29 @@ func (c *CloudClient) addTest(ctx context.Context, name types.TestName, imgFileN
 	// If we do per test pass/fail then upload the result and compare it to the baseline.
 	ret := true
 	if c.resultState.PerTestPassFail {
+		// Match metrics are attached to the result, so if they are to be reported, the results can
+		// only be uploaded once the image has been matched against the baseline.
+		resultIdx := len(c.resultState.SharedConfig.Results) - 1
+		matchingDone := make(chan struct{})
 		egroup.Go(func() error {
+			if c.resultState.ReportMatchMetrics {
+				<-matchingDone
+			}
 			return c.uploadResultJSON(ctx)
 		})
 
 		egroup.Go(func() error {
-			match, algorithmName, err := c.matchImageAgainstBaseline(ctx, name, traceID, imgBytes, imgDigest, optionalKeys)
+			defer close(matchingDone)
+			match, algorithmName, metrics, err := c.matchImageAgainstBaseline(ctx, name, traceID, imgBytes, imgDigest, optionalKeys)
 			if err != nil {
 				return skerr.Wrapf(err, "matching image against baseline")
 			}
 			ret = match
 
+			if match && algorithmName != imgmatching.ExactMatching && c.resultState.ReportMatchMetrics {
+				addMatchMetricsToResult(&c.resultState.SharedConfig.Results[resultIdx], algorithmName, metrics)
+			}
+
 			// If the image is untriaged, but matches the latest positive digest in its baseline via the
 			// specified non-exact image matching algorithm, then triage the image as positive.
 			if match && algorithmName != imgmatching.ExactMatching {
@@ -472,7 +501,7 @@ func (c *CloudClient) Check(ctx context.Context, name types.TestName, imgFileNam
 	}
 
 	_, _, traceID := c.makeResultKeyAndTraceParamsAndID(name, keys)
-	match, _, err := c.matchImageAgainstBaseline(ctx, name, traceID, imgBytes, imgHash, optionalKeys)
+	match, _, _, err := c.matchImageAgainstBaseline(ctx, name, traceID, imgBytes, imgHash, optionalKeys)
 	return match, skerr.Wrap(err)
 }
 
@@ -489,16 +518,18 @@ func (c *CloudClient) Check(ctx context.Context, name types.TestName, imgFileNam
 // either positive or negative in the baseline, imgmatching.ExactMatching will be returned
 // regardless of whether a non-exact image matching algorithm was specified via the optionalKeys.
 //
+// Returns the metrics reported by the non-exact image matching algorithm, if any.
+//
 // A non-nil error is returned if there are any problems parsing or instantiating the specified
 // image matching algorithm, for example if there are any missing parameters.
-func (c *CloudClient) matchImageAgainstBaseline(ctx context.Context, testName types.TestName, traceId tiling.TraceIDV2, imageBytes []byte, imageHash types.Digest, optionalKeys map[string]string) (bool, imgmatching.AlgorithmName, error) {
+func (c *CloudClient) matchImageAgainstBaseline(ctx context.Context, testName types.TestName, traceId tiling.TraceIDV2, imageBytes []byte, imageHash types.Digest, optionalKeys map[string]string) (bool, imgmatching.AlgorithmName, map[string]float64, error) {
 	// First we check whether the digest is a known positive or negative, regardless of the specified
 	// image matching algorithm.
 	if c.resultState.Expectations[testName][imageHash] == expectations.Positive {
-		return true, imgmatching.ExactMatching, nil
+		return true, imgmatching.ExactMatching, nil, nil
 	}
 	if c.resultState.Expectations[testName][imageHash] == expectations.Negative {
-		return false, imgmatching.ExactMatching, nil
+		return false, imgmatching.ExactMatching, nil, nil
 	}
 
 	// Extract the specified image matching algorithm from the optionalKeys (defaulting to exact
@@ -506,31 +537,31 @@ func (c *CloudClient) matchImageAgainstBaseline(ctx context.Context, testName ty
 	// algorithm requires one (i.e. all but exact matching).
 	algorithmName, matcher, err := imgmatching.MakeMatcher(optionalKeys)
 	if err != nil {
-		return false, "", skerr.Wrapf(err, "parsing image matching algorithm from optional keys")
+		return false, "", nil, skerr.Wrapf(err, "parsing image matching algorithm from optional keys")
 	}
 
 	// Nothing else to do if performing exact matching: we've already checked whether the image is a
 	// known positive.
 	if algorithmName == imgmatching.ExactMatching {
-		return false, algorithmName, nil
+		return false, algorithmName, nil, nil
 	}
 
 	// This can happen if a user supplied just the hash and not the image itself.
 	if len(imageBytes) == 0 {
-		return false, "", skerr.Fmt("Must supply the image if using a non-exact matching algorithm")
+		return false, "", nil, skerr.Fmt("Must supply the image if using a non-exact matching algorithm")
 	}
 
 	// Decode test output PNG image.
 	img, err := png.Decode(bytes.NewReader(imageBytes))
 	if err != nil {
-		return false, "", skerr.Wrapf(err, "decoding PNG image")
+		return false, "", nil, skerr.Wrapf(err, "decoding PNG image")
 	}
 
 	// Fetch the most recent positive digest.
 	infof(ctx, "Fetching most recent positive digest for trace with ID %q.\n", traceId)
 	mostRecentPositiveDigest, err := c.MostRecentPositiveDigest(ctx, traceId)
 	if err != nil {
-		return false, "", skerr.Wrapf(err, "retrieving most recent positive image")
+		return false, "", nil, skerr.Wrapf(err, "retrieving most recent positive image")
 	}
 
 	// Download from GCS the image corresponding to the most recent positive digest.
@@ -540,13 +571,68 @@ func (c *CloudClient) matchImageAgainstBaseline(ctx context.Context, testName ty
 	} else {
 		mostRecentPositiveImage, _, err = c.getDigestFromCacheOrGCS(ctx, mostRecentPositiveDigest)
 		if err != nil {
-			return false, "", skerr.Wrapf(err, "downloading most recent positive image from GCS")
+			return false, "", nil, skerr.Wrapf(err, "downloading most recent positive image from GCS")
 		}
 	}
 
-	// Return algorithm's output.
 	infof(ctx, "Non-exact image comparison using algorithm %q against most recent positive digest %q.\n", algorithmName, mostRecentPositiveDigest)
-	return matcher.Match(mostRecentPositiveImage, img), algorithmName, nil
+	match := matcher.Match(mostRecentPositiveImage, img)
+
+	// Debug artifacts are a triaging aid, so failing to upload them does not fail the comparison.
+	if !match && c.resultState.UploadDebugArtifacts {
+		if err := c.uploadDebugArtifacts(ctx, matcher, imageHash); err != nil {
+			errorf(ctx, "Could not upload debug artifacts for digest %s: %s\n", imageHash, err)
+		}
+	}
+
+	var metrics map[string]float64
+	if provider, ok := matcher.(imgmatching.DebugArtifactsProvider); ok {
+		metrics = provider.DebugArtifacts().Metrics
+	}
+
+	// Return algorithm's output.
+	return match, algorithmName, metrics, nil
+}
+
+// addMatchMetricsToResult attaches the given image matching algorithm name and metrics to the
+// given result as optional keys.
+func addMatchMetricsToResult(result *jsonio.Result, algorithmName imgmatching.AlgorithmName, metrics map[string]float64) {
+	result.Options[imgmatching.MatchedAlgorithmNameOptKey] = string(algorithmName)
+	for name, value := range metrics {
+		result.Options[imgmatching.MatchMetricOptKeyPrefix+name] = strconv.FormatFloat(value, 'g', -1, 64)
+	}
+}
+
+// uploadDebugArtifacts uploads to GCS the debug artifacts reported by the given matcher, if any,
+// about its comparison of the image with the given digest.
+func (c *CloudClient) uploadDebugArtifacts(ctx context.Context, matcher imgmatching.Matcher, digest types.Digest) error {
+	provider, ok := matcher.(imgmatching.DebugArtifactsProvider)
+	if !ok {
+		return nil
+	}
+	bundle := provider.DebugArtifacts()
+	if bundle.IsEmpty() {
+		return nil
+	}
+
+	// Artifacts are written to disk first because some GCS uploaders can only upload files.
+	paths, err := bundle.WriteToDir(filepath.Join(c.workDir, debugArtifactsDirectory, string(digest)))
+	if err != nil {
+		return skerr.Wrap(err)
+	}
+	uploader := extractGCSUploader(ctx)
+	for _, p := range paths {
+		b, err := os.ReadFile(p)
+		if err != nil {
+			return skerr.Wrapf(err, "reading debug artifact %s", p)
+		}
+		dst := c.resultState.getGCSDebugArtifactPath(digest, filepath.Base(p))
+		if err := uploader.UploadBytes(ctx, b, p, dst); err != nil {
+			return skerr.Wrapf(err, "uploading debug artifact %s to %s", p, dst)
+		}
+	}
+	infof(ctx, "Uploaded debug artifacts for digest %s to %s\n", digest, c.resultState.getGCSDebugArtifactPath(digest, ""))
+	return nil
 }
 
 // Finalize implements the GoldClient interface.
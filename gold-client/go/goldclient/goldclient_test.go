@@ -12,6 +12,7 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -1047,6 +1048,93 @@ func TestReportPassFailPassWithFuzzyMatching(t *testing.T) {
 	assert.True(t, pass)
 }
 
+// TestReportPassFailPassWithFuzzyMatching_ReportMatchMetrics tests that the name and metrics of
+// the non-exact image matching algorithm are attached to the uploaded result when the image
+// matches its baseline and ReportMatchMetrics is set.
+func TestReportPassFailPassWithFuzzyMatching_ReportMatchMetrics(t *testing.T) {
+
+	wd := t.TempDir()
+
+	// The test name is defined in mockBaselineJSON. The image hashes below are not.
+	testName := types.TestName("ThisIsTheOnlyTest")
+
+	latestPositiveImageBytes := imageToPngBytes(t, text.MustToNRGBA(`! SKTEXTSIMPLE
+	2 2
+	0x00000000 0x00000000
+	0x00000000 0x00000000`))
+	const latestPositiveImageHash = types.Digest("22222222222222222222222222222222")
+
+	// New image differs from the latest positive image by one pixel.
+	newImageBytes := imageToPngBytes(t, text.MustToNRGBA(`! SKTEXTSIMPLE
+	2 2
+	0x01020304 0x00000000
+	0x00000000 0x00000000`))
+	const newImageHash = "11111111111111111111111111111111"
+
+	ctx, httpClient, uploader, downloader := makeMocks()
+	defer httpClient.AssertExpectations(t)
+	defer uploader.AssertExpectations(t)
+	defer downloader.AssertExpectations(t)
+
+	hashesResp := httpResponse(newImageHash, "200 OK", http.StatusOK)
+	httpClient.On("Get", "https://testing-gold.skia.org/json/v1/hashes").Return(hashesResp, nil)
+	exp := httpResponse(mockBaselineJSON, "200 OK", http.StatusOK)
+	httpClient.On("Get", "https://testing-gold.skia.org/json/v2/expectations?issue=867&crs=gerrit").Return(exp, nil)
+	groupingsResp := httpResponse(`{"grouping_param_keys_by_corpus": {"gtest-pixeltests": ["name", "source_type"]}}`, "200 OK", http.StatusOK)
+	httpClient.On("Get", "https://testing-gold.skia.org/json/v1/groupings").Return(groupingsResp, nil)
+	const latestPositiveDigestRpcUrl = "https://testing-gold.skia.org/json/v2/latestpositivedigest/84c1168e85de827b0b958c8994485e83"
+	const latestPositiveDigestResponse = `{"digest":"` + string(latestPositiveImageHash) + `"}`
+	httpClient.On("Get", latestPositiveDigestRpcUrl).Return(httpResponse(latestPositiveDigestResponse, "200 OK", http.StatusOK), nil)
+	downloader.On("DownloadImage", testutils.AnyContext, "https://testing-gold.skia.org", latestPositiveImageHash).Return(latestPositiveImageBytes, nil)
+	httpClient.On("Post", "https://testing-gold.skia.org/json/v2/triage", "application/json", mock.Anything).Return(httpResponse("", "200 OK", http.StatusOK), nil)
+
+	// The uploaded result should include the algorithm name and metrics as optional keys.
+	expectedJSONPath := "skia-gold-testing/trybot/dm-json-v1/2019/04/02/19/867__5309/117/dm-1554234843000000000.json"
+	checkResults := func(g jsonio.GoldResults) bool {
+		assert.Len(t, g.Results, 1)
+		assert.Equal(t, map[string]string{
+			"ext":                                        "png",
+			imgmatching.AlgorithmNameOptKey:              string(imgmatching.FuzzyMatching),
+			string(imgmatching.MaxDifferentPixels):       "1",
+			string(imgmatching.PixelDeltaThreshold):      "10",
+			"image_matching_matched_algorithm":           "fuzzy",
+			"image_matching_metric_num_different_pixels": "1",
+			"image_matching_metric_max_pixel_delta":      "10",
+		}, g.Results[0].Options)
+		return true
+	}
+	uploader.On("UploadJSON", testutils.AnyContext, mock.MatchedBy(checkResults), filepath.Join(wd, jsonTempFile), expectedJSONPath).Return(nil)
+
+	goldClient, err := NewCloudClient(GoldClientConfig{
+		InstanceID:         testInstanceID,
+		WorkDir:            wd,
+		PassFailStep:       true,
+		ReportMatchMetrics: true,
+	})
+	require.NoError(t, err)
+	config := makeTestSharedConfig()
+	config.Key[types.CorpusField] = "gtest-pixeltests"
+	require.NoError(t, goldClient.SetSharedConfig(ctx, config, false))
+
+	overrideLoadAndHashImage(goldClient, func(path string) ([]byte, types.Digest, error) {
+		return newImageBytes, newImageHash, nil
+	})
+
+	optionalKeys := map[string]string{
+		imgmatching.AlgorithmNameOptKey:         string(imgmatching.FuzzyMatching),
+		string(imgmatching.MaxDifferentPixels):  "1",
+		string(imgmatching.PixelDeltaThreshold): "10",
+	}
+
+	extraKeys := map[string]string{
+		"another_notch": "emeril",
+	}
+
+	pass, err := goldClient.Test(ctx, testName, testImgPath, "", extraKeys, optionalKeys)
+	require.NoError(t, err)
+	assert.True(t, pass)
+}
+
 // TestNegativePassFail ensures that a digest marked negative returns false in pass-fail mode.
 func TestNegativePassFail(t *testing.T) {
 
@@ -1585,7 +1673,7 @@ func TestCloudClient_MatchImageAgainstBaseline_NoAlgorithmSpecified_DefaultsToEx
 			}
 
 			// Parameters traceId and imageBytes are not used in exact matching.
-			got, algorithmName, err := goldClient.matchImageAgainstBaseline(ctx, testName, "" /* =traceId */, []byte{} /* =imageBytes */, digest, nil /* =optionalKeys */)
+			got, algorithmName, _, err := goldClient.matchImageAgainstBaseline(ctx, testName, "" /* =traceId */, []byte{} /* =imageBytes */, digest, nil /* =optionalKeys */)
 
 			assert.NoError(t, err)
 			assert.Equal(t, imgmatching.ExactMatching, algorithmName)
@@ -1621,7 +1709,7 @@ func TestCloudClient_MatchImageAgainstBaseline_ExactMatching_Success(t *testing.
 			}
 
 			// Parameters traceId and imageBytes are not used in exact matching.
-			got, algorithmName, err := goldClient.matchImageAgainstBaseline(ctx, testName, "" /* =traceId */, []byte{} /* =imageBytes */, digest, optionalKeys)
+			got, algorithmName, _, err := goldClient.matchImageAgainstBaseline(ctx, testName, "" /* =traceId */, []byte{} /* =imageBytes */, digest, optionalKeys)
 
 			assert.NoError(t, err)
 			assert.Equal(t, imgmatching.ExactMatching, algorithmName)
@@ -1655,7 +1743,7 @@ func TestCloudClient_MatchImageAgainstBaseline_FuzzyMatching_ImageAlreadyLabeled
 				},
 			}
 
-			got, algorithmName, err := goldClient.matchImageAgainstBaseline(ctx, testName, "" /* =traceId */, nil /* =imageBytes */, digest, optionalKeys)
+			got, algorithmName, _, err := goldClient.matchImageAgainstBaseline(ctx, testName, "" /* =traceId */, nil /* =imageBytes */, digest, optionalKeys)
 			assert.NoError(t, err)
 			assert.Equal(t, imgmatching.ExactMatching, algorithmName)
 			assert.Equal(t, want, got)
@@ -1694,7 +1782,7 @@ func TestCloudClient_MatchImageAgainstBaseline_FuzzyMatching_UntriagedImage_Succ
 				string(imgmatching.PixelDeltaThreshold): "10",
 			}
 
-			actual, algorithmName, err := goldClient.matchImageAgainstBaseline(ctx, testName, traceId, imageBytes, digest, optionalKeys)
+			actual, algorithmName, _, err := goldClient.matchImageAgainstBaseline(ctx, testName, traceId, imageBytes, digest, optionalKeys)
 			assert.NoError(t, err)
 			assert.Equal(t, imgmatching.FuzzyMatching, algorithmName)
 			assert.Equal(t, expected, actual)
@@ -1724,12 +1812,77 @@ func TestCloudClient_MatchImageAgainstBaseline_FuzzyMatching_UntriagedImage_Succ
 		false)
 }
 
+func TestCloudClient_MatchImageAgainstBaseline_UploadDebugArtifacts_UploadsArtifactsOnlyIfNoMatch(t *testing.T) {
+	const testName = types.TestName("my_test")
+	const traceId = tiling.TraceIDV2("1234567890abcdef1234567890abcdef")
+	const digest = types.Digest("11111111111111111111111111111111")
+
+	const latestPositiveDigestRpcUrl = "https://testing-gold.skia.org/json/v2/latestpositivedigest/1234567890abcdef1234567890abcdef"
+	const latestPositiveDigestResponse = `{"digest":"22222222222222222222222222222222"}`
+	const latestPositiveDigest = types.Digest("22222222222222222222222222222222")
+	latestPositiveImageBytes := imageToPngBytes(t, text.MustToNRGBA(`! SKTEXTSIMPLE
+	2 2
+	0x00000000 0x00000000
+	0x00000000 0x00000000`))
+
+	test := func(name string, imageBytes []byte, expected bool) {
+		t.Run(name, func(t *testing.T) {
+			wd := t.TempDir()
+			ctx, httpClient, gcsUploader, dlr := makeMocks()
+			defer httpClient.AssertExpectations(t)
+			defer gcsUploader.AssertExpectations(t)
+			defer dlr.AssertExpectations(t)
+			goldClient, err := NewCloudClient(GoldClientConfig{
+				WorkDir:              wd,
+				InstanceID:           testInstanceID,
+				UploadDebugArtifacts: true,
+			})
+			require.NoError(t, err)
+
+			httpClient.On("Get", latestPositiveDigestRpcUrl).Return(httpResponse(latestPositiveDigestResponse, "200 OK", http.StatusOK), nil)
+			dlr.On("DownloadImage", testutils.AnyContext, "https://testing-gold.skia.org", latestPositiveDigest).Return(latestPositiveImageBytes, nil)
+			if !expected {
+				localPath := filepath.Join(wd, debugArtifactsDirectory, string(digest), "metrics.json")
+				gcsUploader.On("UploadBytes", testutils.AnyContext, mock.MatchedBy(func(b []byte) bool {
+					return strings.Contains(string(b), `"num_different_pixels": 2`)
+				}), localPath, "gs://skia-gold-testing/dm-debug-artifacts-v1/11111111111111111111111111111111/metrics.json").Return(nil)
+			}
+
+			optionalKeys := map[string]string{
+				imgmatching.AlgorithmNameOptKey:         string(imgmatching.FuzzyMatching),
+				string(imgmatching.MaxDifferentPixels):  "1",
+				string(imgmatching.PixelDeltaThreshold): "10",
+			}
+
+			actual, algorithmName, _, err := goldClient.matchImageAgainstBaseline(ctx, testName, traceId, imageBytes, digest, optionalKeys)
+			assert.NoError(t, err)
+			assert.Equal(t, imgmatching.FuzzyMatching, algorithmName)
+			assert.Equal(t, expected, actual)
+		})
+	}
+
+	test(
+		"images match, no artifacts uploaded",
+		imageToPngBytes(t, text.MustToNRGBA(`! SKTEXTSIMPLE
+		2 2
+		0x00000505 0x00000000
+		0x00000000 0x00000000`)),
+		true)
+	test(
+		"images do not match, artifacts uploaded",
+		imageToPngBytes(t, text.MustToNRGBA(`! SKTEXTSIMPLE
+		2 2
+		0x00000505 0x00000001
+		0x00000000 0x00000000`)),
+		false)
+}
+
 func TestCloudClient_MatchImageAgainstBaseline_FuzzyMatching_InvalidParameters_ReturnsError(t *testing.T) {
 	test := func(name string, optionalKeys map[string]string, expectedError string) {
 		t.Run(name, func(t *testing.T) {
 			goldClient, ctx, _, _ := makeGoldClientForMatchImageAgainstBaselineTests(t)
 
-			_, _, err := goldClient.matchImageAgainstBaseline(ctx, "my_test", "" /* =traceId */, nil /* =imageBytes */, "11111111111111111111111111111111", optionalKeys)
+			_, _, _, err := goldClient.matchImageAgainstBaseline(ctx, "my_test", "" /* =traceId */, nil /* =imageBytes */, "11111111111111111111111111111111", optionalKeys)
 			assert.Error(t, err)
 			assert.Contains(t, err.Error(), expectedError)
 		})
@@ -1775,7 +1928,7 @@ func TestCloudClient_MatchImageAgainstBaseline_FuzzyMatching_NoRecentPositiveDig
 		string(imgmatching.PixelPerChannelDeltaThreshold): "0",
 	}
 
-	matched, algorithmName, err := goldClient.matchImageAgainstBaseline(ctx, testName, traceId, imageBytes, digest, optionalKeys)
+	matched, algorithmName, _, err := goldClient.matchImageAgainstBaseline(ctx, testName, traceId, imageBytes, digest, optionalKeys)
 	assert.NoError(t, err)
 	assert.False(t, matched)
 	assert.Equal(t, imgmatching.FuzzyMatching, algorithmName)
@@ -1807,7 +1960,7 @@ func TestCloudClient_MatchImageAgainstBaseline_PositiveIfOnlyImageMatching_Untri
 				imgmatching.AlgorithmNameOptKey: string(imgmatching.PositiveIfOnlyImageMatching),
 			}
 
-			actual, algorithmName, err := goldClient.matchImageAgainstBaseline(ctx, testName, traceId, imageBytes, digest, optionalKeys)
+			actual, algorithmName, _, err := goldClient.matchImageAgainstBaseline(ctx, testName, traceId, imageBytes, digest, optionalKeys)
 			assert.NoError(t, err)
 			assert.Equal(t, imgmatching.PositiveIfOnlyImageMatching, algorithmName)
 			assert.Equal(t, expected, actual)
@@ -1850,7 +2003,7 @@ func TestCloudClient_MatchImageAgainstBaseline_PositiveIfOnlyImageMatching_NoRec
 		imgmatching.AlgorithmNameOptKey: string(imgmatching.PositiveIfOnlyImageMatching),
 	}
 
-	matched, algorithmName, err := goldClient.matchImageAgainstBaseline(ctx, testName, traceId, imageBytes, digest, optionalKeys)
+	matched, algorithmName, _, err := goldClient.matchImageAgainstBaseline(ctx, testName, traceId, imageBytes, digest, optionalKeys)
 	assert.NoError(t, err)
 	assert.True(t, matched)
 	assert.Equal(t, imgmatching.PositiveIfOnlyImageMatching, algorithmName)
@@ -1877,7 +2030,7 @@ func TestCloudClient_MatchImageAgainstBaseline_SampleAreaMatching_ImageAlreadyLa
 				},
 			}
 
-			got, algorithmName, err := goldClient.matchImageAgainstBaseline(ctx, testName, "" /* =traceId */, nil /* =imageBytes */, digest, optionalKeys)
+			got, algorithmName, _, err := goldClient.matchImageAgainstBaseline(ctx, testName, "" /* =traceId */, nil /* =imageBytes */, digest, optionalKeys)
 			assert.NoError(t, err)
 			assert.Equal(t, imgmatching.ExactMatching, algorithmName)
 			assert.Equal(t, want, got)
@@ -1923,7 +2076,7 @@ func TestCloudClient_MatchImageAgainstBaseline_SampleAreaMatching_UntriagedImage
 				string(imgmatching.SampleAreaChannelDeltaThreshold): "0",
 			}
 
-			actual, algorithmName, err := goldClient.matchImageAgainstBaseline(ctx, testName, traceId, imageBytes, digest, optionalKeys)
+			actual, algorithmName, _, err := goldClient.matchImageAgainstBaseline(ctx, testName, traceId, imageBytes, digest, optionalKeys)
 			assert.NoError(t, err)
 			assert.Equal(t, imgmatching.SampleAreaMatching, algorithmName)
 			assert.Equal(t, expected, actual)
@@ -1983,7 +2136,7 @@ func TestCloudClient_MatchImageAgainstBaseline_SampleAreaMatching_InvalidParamet
 		t.Run(name, func(t *testing.T) {
 			goldClient, ctx, _, _ := makeGoldClientForMatchImageAgainstBaselineTests(t)
 
-			_, _, err := goldClient.matchImageAgainstBaseline(ctx, "my_test", "" /* =traceId */, nil /* =imageBytes */, "11111111111111111111111111111111", optionalKeys)
+			_, _, _, err := goldClient.matchImageAgainstBaseline(ctx, "my_test", "" /* =traceId */, nil /* =imageBytes */, "11111111111111111111111111111111", optionalKeys)
 			assert.Error(t, err)
 			assert.Contains(t, err.Error(), expectedError)
 		})
@@ -2029,7 +2182,7 @@ func TestCloudClient_MatchImageAgainstBaseline_SampleAreaMatching_NoRecentPositi
 		string(imgmatching.SampleAreaChannelDeltaThreshold): "0",
 	}
 
-	matched, algorithmName, err := goldClient.matchImageAgainstBaseline(ctx, testName, traceId, imageBytes, digest, optionalKeys)
+	matched, algorithmName, _, err := goldClient.matchImageAgainstBaseline(ctx, testName, traceId, imageBytes, digest, optionalKeys)
 	assert.NoError(t, err)
 	assert.False(t, matched)
 	assert.Equal(t, imgmatching.SampleAreaMatching, algorithmName)
@@ -2056,7 +2209,7 @@ func TestCloudClient_MatchImageAgainstBaseline_SobelFuzzyMatching_ImageAlreadyLa
 				},
 			}
 
-			got, algorithmName, err := goldClient.matchImageAgainstBaseline(ctx, testName, "" /* =traceId */, nil /* =imageBytes */, digest, optionalKeys)
+			got, algorithmName, _, err := goldClient.matchImageAgainstBaseline(ctx, testName, "" /* =traceId */, nil /* =imageBytes */, digest, optionalKeys)
 			assert.NoError(t, err)
 			assert.Equal(t, imgmatching.ExactMatching, algorithmName)
 			assert.Equal(t, want, got)
@@ -2112,7 +2265,7 @@ func TestCloudClient_MatchImageAgainstBaseline_SobelFuzzyMatching_UntriagedImage
 				string(imgmatching.EdgeThreshold):       edgeThreshold,
 			}
 
-			actual, algorithmName, err := goldClient.matchImageAgainstBaseline(ctx, testName, traceId, testImageBytes, digest, optionalKeys)
+			actual, algorithmName, _, err := goldClient.matchImageAgainstBaseline(ctx, testName, traceId, testImageBytes, digest, optionalKeys)
 			assert.NoError(t, err)
 			assert.Equal(t, imgmatching.SobelFuzzyMatching, algorithmName)
 			assert.Equal(t, expected, actual)
@@ -2128,7 +2281,7 @@ func TestCloudClient_MatchImageAgainstBaseline_SobelFuzzyMatching_InvalidParamet
 		t.Run(name, func(t *testing.T) {
 			goldClient, ctx, _, _ := makeGoldClientForMatchImageAgainstBaselineTests(t)
 
-			_, _, err := goldClient.matchImageAgainstBaseline(ctx, "my_test", "" /* =traceId */, nil /* =imageBytes */, "11111111111111111111111111111111", optionalKeys)
+			_, _, _, err := goldClient.matchImageAgainstBaseline(ctx, "my_test", "" /* =traceId */, nil /* =imageBytes */, "11111111111111111111111111111111", optionalKeys)
 			assert.Error(t, err)
 			assert.Contains(t, err.Error(), expectedError)
 		})
@@ -2192,7 +2345,7 @@ func TestCloudClient_MatchImageAgainstBaseline_SobelFuzzyMatching_NoRecentPositi
 		string(imgmatching.PixelDeltaThreshold): "10",
 	}
 
-	matched, algorithmName, err := goldClient.matchImageAgainstBaseline(ctx, testName, traceId, imageBytes, digest, optionalKeys)
+	matched, algorithmName, _, err := goldClient.matchImageAgainstBaseline(ctx, testName, traceId, imageBytes, digest, optionalKeys)
 	assert.NoError(t, err)
 	assert.False(t, matched)
 	assert.Equal(t, imgmatching.SobelFuzzyMatching, algorithmName)
@@ -2205,7 +2358,7 @@ func TestCloudClient_MatchImageAgainstBaseline_UnknownAlgorithm_ReturnsError(t *
 		imgmatching.AlgorithmNameOptKey: "unknown algorithm",
 	}
 
-	_, _, err := goldClient.matchImageAgainstBaseline(ctx, "" /* =testName */, "" /* =traceId */, nil /* =imageBytes */, "" /* =digest */, optionalKeys)
+	_, _, _, err := goldClient.matchImageAgainstBaseline(ctx, "" /* =testName */, "" /* =traceId */, nil /* =imageBytes */, "" /* =digest */, optionalKeys)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "unrecognized image matching algorithm")
 }
@@ -24,6 +24,10 @@ const (
 	// imagePrefix is the path prefix in the GCS bucket that holds images.
 	imagePrefix = "dm-images-v1"
 
+	// debugArtifactsPrefix is the path prefix in the GCS bucket that holds the debug artifacts of
+	// non-exact image matching algorithms, grouped by digest.
+	debugArtifactsPrefix = "dm-debug-artifacts-v1"
+
 	// goldHostTemplate constructs the URL of the Gold instance from the instance id
 	goldHostTemplate = "https://%s-gold.skia.org"
 
@@ -42,15 +46,17 @@ var md5Regexp = regexp.MustCompile(`^[a-f0-9]{32}$`)
 type resultState struct {
 	// SharedConfig is all the data that is common test to test, for example, the
 	// keys about this machine (e.g. GPU, OS).
-	SharedConfig    jsonio.GoldResults
-	PerTestPassFail bool
-	FailureFile     string
-	UploadOnly      bool
-	InstanceID      string
-	GoldURL         string
-	Bucket          string
-	KnownHashes     types.DigestSet
-	Expectations    expectations.Baseline
+	SharedConfig         jsonio.GoldResults
+	PerTestPassFail      bool
+	FailureFile          string
+	UploadOnly           bool
+	UploadDebugArtifacts bool
+	ReportMatchMetrics   bool
+	InstanceID           string
+	GoldURL              string
+	Bucket               string
+	KnownHashes          types.DigestSet
+	Expectations         expectations.Baseline
 }
 
 // newResultState creates a new instance of resultState
@@ -65,13 +71,15 @@ func newResultState(sharedConfig jsonio.GoldResults, config *GoldClientConfig) *
 	}
 
 	ret := &resultState{
-		SharedConfig:    sharedConfig,
-		PerTestPassFail: config.PassFailStep,
-		FailureFile:     config.FailureFile,
-		InstanceID:      config.InstanceID,
-		UploadOnly:      config.UploadOnly,
-		GoldURL:         goldURL,
-		Bucket:          bucket,
+		SharedConfig:         sharedConfig,
+		PerTestPassFail:      config.PassFailStep,
+		FailureFile:          config.FailureFile,
+		InstanceID:           config.InstanceID,
+		UploadOnly:           config.UploadOnly,
+		UploadDebugArtifacts: config.UploadDebugArtifacts,
+		ReportMatchMetrics:   config.ReportMatchMetrics,
+		GoldURL:              goldURL,
+		Bucket:               bucket,
 	}
 
 	return ret
@@ -202,6 +210,13 @@ func (r *resultState) getGCSImagePath(imgHash types.Digest) string {
 	return fmt.Sprintf("gs://%s/%s/%s.png", r.Bucket, imagePrefix, imgHash)
 }
 
+// getGCSDebugArtifactPath returns the path in GCS where the debug artifact with the given file name
+// about the image with the given hash should be stored. If fileName is empty, it returns the path
+// of the directory that holds all debug artifacts about said image.
+func (r *resultState) getGCSDebugArtifactPath(imgHash types.Digest, fileName string) string {
+	return fmt.Sprintf("gs://%s/%s/%s/%s", r.Bucket, debugArtifactsPrefix, imgHash, fileName)
+}
+
 // loadStateFromJSON loads a serialization of a resultState instance that was previously written
 // via the save method.
 func loadStateFromJSON(fileName string) (*resultState, error) {
@@ -3,16 +3,27 @@ package imgmatching
 // AlgorithmNameOptKey is the optional key used to indicate a non-exact matching algorithm.
 const AlgorithmNameOptKey = "image_matching_algorithm"
 
+// MatchedAlgorithmNameOptKey is the optional key that goldctl attaches to a result to indicate
+// the name of the non-exact image matching algorithm by which the image matched its baseline.
+const MatchedAlgorithmNameOptKey = "image_matching_matched_algorithm"
+
+// MatchMetricOptKeyPrefix is the prefix of the optional keys that goldctl attaches to a result
+// with the metrics (e.g. "num_different_pixels") reported by the non-exact image matching algorithm
+// by which the image matched its baseline.
+const MatchMetricOptKeyPrefix = "image_matching_metric_"
+
 // AlgorithmName is a non-exact image matching algorithm specified via the AlgorithmNameOptKey
 // optional key, e.g. "fuzzy".
 type AlgorithmName string
 
 const (
+	DownscaleFuzzyMatching      = AlgorithmName("downscale")
 	ExactMatching               = AlgorithmName("exact")
 	FuzzyMatching               = AlgorithmName("fuzzy")
 	PositiveIfOnlyImageMatching = AlgorithmName("positive_if_only_image")
 	SampleAreaMatching          = AlgorithmName("sample_area")
 	SobelFuzzyMatching          = AlgorithmName("sobel")
+	SSIMMatching                = AlgorithmName("ssim")
 )
 
 // AlgorithmParamOptKey is an optional key indicating a parameter for the specified non-exact image
@@ -36,10 +47,34 @@ const (
 	// parameter of algorithms FuzzyMatching and SobelFuzzyMatching.
 	IgnoredBorderThickness = AlgorithmParamOptKey("fuzzy_ignored_border_thickness")
 
+	// AlphaMode is the optional key used to specify the AlphaMode parameter of algorithms
+	// FuzzyMatching and SobelFuzzyMatching. Valid values are "ignore_transparent_pixels" and
+	// "premultiply". If not specified, all four channels are compared as they are.
+	AlphaMode = AlgorithmParamOptKey("fuzzy_alpha_mode")
+
+	// PixelDeltaMetric is the optional key used to specify the PixelDeltaMetric parameter of
+	// algorithms FuzzyMatching and SobelFuzzyMatching. Valid values are "sum", "max_channel" and
+	// "ciede2000". If not specified, the metric is inferred from which delta threshold is set.
+	PixelDeltaMetric = AlgorithmParamOptKey("fuzzy_pixel_delta_metric")
+
+	// CIEDE2000Threshold is the optional key used to specify the CIEDE2000Threshold parameter of
+	// algorithms FuzzyMatching and SobelFuzzyMatching. It is required if PixelDeltaMetric is
+	// "ciede2000", and cannot be used otherwise.
+	CIEDE2000Threshold = AlgorithmParamOptKey("fuzzy_ciede2000_threshold")
+
 	// EdgeThreshold is the optional key used to specify the EdgeThreshold parameter of the
 	// SobelFuzzyMatching algorithm.
 	EdgeThreshold = AlgorithmParamOptKey("sobel_edge_threshold")
 
+	// SobelBorderMode is the optional key used to specify the BorderMode parameter of the
+	// SobelFuzzyMatching algorithm. Valid values are "replicate" and "edge". If not specified,
+	// border pixels are never considered part of an edge.
+	SobelBorderMode = AlgorithmParamOptKey("sobel_border_mode")
+
+	// DownscaleFactor is the optional key used to specify the DownscaleFactor parameter of the
+	// DownscaleFuzzyMatching algorithm.
+	DownscaleFactor = AlgorithmParamOptKey("downscale_factor")
+
 	// SampleAreaWidth is the optional key used to specify the SampleAreaWidth
 	// parameter of the SampleAreaMatching algorithm.
 	SampleAreaWidth = AlgorithmParamOptKey("sample_area_width")
@@ -51,4 +86,29 @@ const (
 	// SampleAreaChannelDeltaThreshold is the optional key used to specify the
 	// SampleAreaChannelDeltaThreshold parameter of the SampleAreaMatching algorithm.
 	SampleAreaChannelDeltaThreshold = AlgorithmParamOptKey("sample_area_channel_delta_threshold")
+
+	// SSIMWindowSize is the optional key used to specify the WindowSize parameter of the
+	// SSIMMatching algorithm.
+	SSIMWindowSize = AlgorithmParamOptKey("ssim_window_size")
+
+	// SSIMMinScore is the optional key used to specify the MinScore parameter of the SSIMMatching
+	// algorithm.
+	SSIMMinScore = AlgorithmParamOptKey("ssim_min_score")
+
+	// IgnoredRegions is the optional key used to specify rectangular regions of the images to
+	// exclude from the comparison, as a semicolon-separated list of "x0,y0,x1,y1" rectangles (e.g.
+	// "0,0,100,20;0,580,800,600"), where (x0,y0) is inclusive and (x1,y1) is exclusive. It can be
+	// used with any algorithm except ExactMatching and PositiveIfOnlyImageMatching.
+	IgnoredRegions = AlgorithmParamOptKey("ignored_regions")
+
+	// IgnoredRegionsMaskFile is the optional key used to specify the path to a PNG mask image whose
+	// non-black pixels indicate the pixels to exclude from the comparison. It can be used with any
+	// algorithm except ExactMatching and PositiveIfOnlyImageMatching.
+	IgnoredRegionsMaskFile = AlgorithmParamOptKey("ignored_regions_mask_file")
+
+	// MaxSizeDelta is the optional key used to specify by how many pixels the widths and heights of
+	// the images may differ. Images within this tolerance are cropped to the size of the smaller
+	// image before being compared. It can be used with any algorithm except ExactMatching and
+	// PositiveIfOnlyImageMatching.
+	MaxSizeDelta = AlgorithmParamOptKey("max_size_delta")
 )
@@ -0,0 +1,195 @@
+// Package corpus runs image matching algorithms over a corpus of image pairs with known expected
+// outcomes, so that changes to the algorithms can be validated against real-world images.
+package corpus
+
+import (
+	"bytes"
+	"encoding/json"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/gold-client/go/imgmatching"
+)
+
+// ManifestFileName is the name of the file describing a corpus, located at the root of the corpus
+// directory.
+const ManifestFileName = "manifest.json"
+
+// Manifest describes a corpus of image pairs and the image matching algorithms to run over them.
+type Manifest struct {
+	// Matchers maps a matcher name (e.g. "fuzzy_strict") to the optional keys that
+	// imgmatching.MakeMatcher() takes to instantiate it, including the algorithm name.
+	Matchers map[string]map[string]string `json:"matchers"`
+
+	// Pairs are the image pairs in the corpus. Every matcher is run over every pair.
+	Pairs []Pair `json:"pairs"`
+}
+
+// Pair is a pair of images in the corpus.
+type Pair struct {
+	// Name identifies the pair in reports.
+	Name string `json:"name"`
+
+	// Left and Right are the paths to the expected and actual PNG images. Relative paths are
+	// resolved against the corpus directory.
+	Left  string `json:"left"`
+	Right string `json:"right"`
+
+	// Expected maps matcher names to whether the matcher is expected to consider both images
+	// equivalent. Matchers not listed here are still run, but their outcome is not checked.
+	Expected map[string]bool `json:"expected"`
+}
+
+// Result is the outcome of running a matcher over a pair of images.
+type Result struct {
+	Pair    string
+	Matcher string
+
+	// Checked is true if the manifest specifies an expected outcome for this pair and matcher.
+	Checked bool
+	// Expected is the expected outcome. It is only meaningful if Checked is true.
+	Expected bool
+	// Actual is whether the matcher considered both images equivalent.
+	Actual bool
+
+	// Metrics are the numeric debug artifacts reported by the matcher, if any.
+	Metrics map[string]float64
+}
+
+// IsRegression returns true if the matcher's outcome differs from the expected outcome.
+func (r Result) IsRegression() bool {
+	return r.Checked && r.Expected != r.Actual
+}
+
+// Report contains the results of running every matcher over every pair in a corpus. Results are
+// ordered by pair, in manifest order, and then by matcher name.
+type Report struct {
+	Results []Result
+}
+
+// Regressions returns the results whose outcome differs from the expected outcome.
+func (r *Report) Regressions() []Result {
+	var regressions []Result
+	for _, result := range r.Results {
+		if result.IsRegression() {
+			regressions = append(regressions, result)
+		}
+	}
+	return regressions
+}
+
+// LoadManifest reads and validates the manifest file in the given corpus directory.
+func LoadManifest(dir string) (*Manifest, error) {
+	p := filepath.Join(dir, ManifestFileName)
+	b, err := os.ReadFile(p)
+	if err != nil {
+		return nil, skerr.Wrapf(err, "reading manifest %s", p)
+	}
+	var m Manifest
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, skerr.Wrapf(err, "parsing manifest %s", p)
+	}
+	if err := m.Validate(); err != nil {
+		return nil, skerr.Wrapf(err, "invalid manifest %s", p)
+	}
+	return &m, nil
+}
+
+// Validate returns an error if the manifest is malformed, e.g. if a pair references an undefined
+// matcher or a matcher cannot be instantiated.
+func (m *Manifest) Validate() error {
+	if len(m.Matchers) == 0 {
+		return skerr.Fmt("at least one matcher must be defined")
+	}
+	for name, optionalKeys := range m.Matchers {
+		if _, _, err := imgmatching.MakeMatcher(optionalKeys); err != nil {
+			return skerr.Wrapf(err, "matcher %q", name)
+		}
+	}
+	seen := map[string]bool{}
+	for i, pair := range m.Pairs {
+		if pair.Name == "" {
+			return skerr.Fmt("pair #%d has no name", i)
+		}
+		if seen[pair.Name] {
+			return skerr.Fmt("duplicate pair name %q", pair.Name)
+		}
+		seen[pair.Name] = true
+		if pair.Left == "" || pair.Right == "" {
+			return skerr.Fmt("pair %q must specify both a left and a right image", pair.Name)
+		}
+		for matcher := range pair.Expected {
+			if _, ok := m.Matchers[matcher]; !ok {
+				return skerr.Fmt("pair %q references undefined matcher %q", pair.Name, matcher)
+			}
+		}
+	}
+	return nil
+}
+
+// Run runs every matcher in the manifest over every pair of images, resolving relative image paths
+// against the given corpus directory. Regressions are reported in the returned Report rather than
+// as errors; an error is only returned if the corpus cannot be processed, e.g. if an image is
+// missing.
+func Run(m *Manifest, dir string) (*Report, error) {
+	matcherNames := make([]string, 0, len(m.Matchers))
+	for name := range m.Matchers {
+		matcherNames = append(matcherNames, name)
+	}
+	sort.Strings(matcherNames)
+
+	report := &Report{}
+	for _, pair := range m.Pairs {
+		left, err := loadPNG(dir, pair.Left)
+		if err != nil {
+			return nil, skerr.Wrapf(err, "pair %q", pair.Name)
+		}
+		right, err := loadPNG(dir, pair.Right)
+		if err != nil {
+			return nil, skerr.Wrapf(err, "pair %q", pair.Name)
+		}
+
+		for _, name := range matcherNames {
+			// Matchers hold debug information about the last pair of images they compared, so we
+			// instantiate a fresh one for each pair.
+			_, matcher, err := imgmatching.MakeMatcher(m.Matchers[name])
+			if err != nil {
+				return nil, skerr.Wrapf(err, "matcher %q", name)
+			}
+
+			expected, checked := pair.Expected[name]
+			result := Result{
+				Pair:     pair.Name,
+				Matcher:  name,
+				Checked:  checked,
+				Expected: expected,
+				Actual:   matcher.Match(left, right),
+			}
+			if provider, ok := matcher.(imgmatching.DebugArtifactsProvider); ok {
+				result.Metrics = provider.DebugArtifacts().Metrics
+			}
+			report.Results = append(report.Results, result)
+		}
+	}
+	return report, nil
+}
+
+// loadPNG decodes the PNG image at the given path, which is resolved against dir if relative.
+func loadPNG(dir, path string) (image.Image, error) {
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, path)
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, skerr.Wrapf(err, "reading image %s", path)
+	}
+	img, err := png.Decode(bytes.NewReader(b))
+	if err != nil {
+		return nil, skerr.Wrapf(err, "decoding PNG image %s", path)
+	}
+	return img, nil
+}
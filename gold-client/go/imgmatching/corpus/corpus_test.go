@@ -0,0 +1,127 @@
+package corpus
+
+import (
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.skia.org/infra/golden/go/image/text"
+)
+
+const image1 = `! SKTEXTSIMPLE
+2 2
+0x000000ff 0x000000ff
+0x000000ff 0x000000ff`
+
+// image2 differs from image1 in one pixel by 0x10 per channel.
+const image2 = `! SKTEXTSIMPLE
+2 2
+0x000000ff 0x000000ff
+0x000000ff 0x101010ff`
+
+const manifest = `{
+  "matchers": {
+    "exact": {"image_matching_algorithm": "exact"},
+    "fuzzy": {
+      "image_matching_algorithm": "fuzzy",
+      "fuzzy_max_different_pixels": "1",
+      "fuzzy_pixel_per_channel_delta_threshold": "16"
+    }
+  },
+  "pairs": [
+    {"name": "identical", "left": "image1.png", "right": "image1.png", "expected": {"exact": true, "fuzzy": true}},
+    {"name": "one pixel off", "left": "image1.png", "right": "image2.png", "expected": {"exact": true}}
+  ]
+}`
+
+// writeCorpus writes the given manifest and the SKTEXT images above as PNG files to a temporary
+// directory, and returns its path.
+func writeCorpus(t *testing.T, manifest string) string {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ManifestFileName), []byte(manifest), 0644))
+	writePNG(t, filepath.Join(dir, "image1.png"), text.MustToNRGBA(image1))
+	writePNG(t, filepath.Join(dir, "image2.png"), text.MustToNRGBA(image2))
+	return dir
+}
+
+func writePNG(t *testing.T, path string, img image.Image) {
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	require.NoError(t, png.Encode(f, img))
+	require.NoError(t, f.Close())
+}
+
+func TestRun_ReportsResultsAndRegressions(t *testing.T) {
+	dir := writeCorpus(t, manifest)
+
+	m, err := LoadManifest(dir)
+	require.NoError(t, err)
+	report, err := Run(m, dir)
+	require.NoError(t, err)
+
+	assert.Equal(t, []Result{
+		{Pair: "identical", Matcher: "exact", Checked: true, Expected: true, Actual: true},
+		{Pair: "identical", Matcher: "fuzzy", Checked: true, Expected: true, Actual: true, Metrics: map[string]float64{
+			"num_different_pixels": 0,
+			"max_pixel_delta":      0,
+		}},
+		{Pair: "one pixel off", Matcher: "exact", Checked: true, Expected: true, Actual: false},
+		{Pair: "one pixel off", Matcher: "fuzzy", Checked: false, Expected: false, Actual: true, Metrics: map[string]float64{
+			"num_different_pixels": 1,
+			"max_pixel_delta":      16,
+		}},
+	}, report.Results)
+	assert.Equal(t, []Result{report.Results[2]}, report.Regressions())
+}
+
+func TestRun_MissingImage_ReturnsError(t *testing.T) {
+	dir := writeCorpus(t, `{
+	  "matchers": {"exact": {"image_matching_algorithm": "exact"}},
+	  "pairs": [{"name": "missing", "left": "image1.png", "right": "nope.png"}]
+	}`)
+
+	m, err := LoadManifest(dir)
+	require.NoError(t, err)
+	_, err = Run(m, dir)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `pair "missing"`)
+	assert.Contains(t, err.Error(), "nope.png")
+}
+
+func TestLoadManifest_Invalid_ReturnsError(t *testing.T) {
+	test := func(name, manifest, wantErr string) {
+		t.Run(name, func(t *testing.T) {
+			_, err := LoadManifest(writeCorpus(t, manifest))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), wantErr)
+		})
+	}
+
+	test("malformed JSON", `{`, "parsing manifest")
+	test("no matchers", `{"pairs": []}`, "at least one matcher must be defined")
+	test("invalid matcher", `{"matchers": {"bad": {"image_matching_algorithm": "fuzzy"}}}`,
+		`matcher "bad"`)
+	test("unnamed pair", `{
+	  "matchers": {"exact": {"image_matching_algorithm": "exact"}},
+	  "pairs": [{"left": "image1.png", "right": "image1.png"}]
+	}`, "pair #0 has no name")
+	test("duplicate pair", `{
+	  "matchers": {"exact": {"image_matching_algorithm": "exact"}},
+	  "pairs": [
+	    {"name": "a", "left": "image1.png", "right": "image1.png"},
+	    {"name": "a", "left": "image1.png", "right": "image1.png"}
+	  ]
+	}`, `duplicate pair name "a"`)
+	test("missing image", `{
+	  "matchers": {"exact": {"image_matching_algorithm": "exact"}},
+	  "pairs": [{"name": "a", "left": "image1.png"}]
+	}`, `pair "a" must specify both a left and a right image`)
+	test("undefined matcher", `{
+	  "matchers": {"exact": {"image_matching_algorithm": "exact"}},
+	  "pairs": [{"name": "a", "left": "image1.png", "right": "image1.png", "expected": {"fuzzy": true}}]
+	}`, `pair "a" references undefined matcher "fuzzy"`)
+}
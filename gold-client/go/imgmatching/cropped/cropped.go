@@ -0,0 +1,102 @@
+package cropped
+
+import (
+	"image"
+	"image/draw"
+
+	"go.skia.org/infra/go/util"
+	"go.skia.org/infra/gold-client/go/imgmatching/debugartifacts"
+)
+
+// DelegateMatcher is an exact copy of the imgmatching.Matcher interface for the sole purpose of
+// avoiding an import cycle between packages imgmatching and cropped.
+type DelegateMatcher interface {
+	Match(expected, actual image.Image) bool
+}
+
+// debugArtifactsProvider is an exact copy of the imgmatching.DebugArtifactsProvider interface for
+// the sole purpose of avoiding an import cycle between packages imgmatching and cropped.
+type debugArtifactsProvider interface {
+	DebugArtifacts() debugartifacts.Bundle
+}
+
+// Matcher is an image matching algorithm.
+//
+// It wraps another image matching algorithm (e.g. fuzzy.Matcher or masked.Matcher), and tolerates
+// small differences in the size of the images. This is useful for tests whose output size varies
+// slightly between runs, e.g. by one pixel due to DPI rounding.
+//
+// The algorithm performs the following steps:
+//  1. If the images are the same size, it passes them to the Delegate algorithm as they are and
+//     returns its return value.
+//  2. If their widths or heights differ by more than MaxSizeDelta pixels, it returns false.
+//  3. Otherwise, it aligns both images at their top-left corners and crops them to the width and
+//     height of the smaller of the two, i.e. it drops the extra rows and columns along the right
+//     and bottom edges of the larger image.
+//  4. It passes the two resulting images to the Delegate algorithm and returns its return value.
+type Matcher struct {
+	Delegate     DelegateMatcher
+	MaxSizeDelta int // Valid values are 0 or greater.
+
+	// Debug information about the last pair of matched images.
+	sizeDelta            int
+	croppedExpectedImage image.Image
+	croppedActualImage   image.Image
+}
+
+// Match implements the imgmatching.Matcher interface.
+func (m *Matcher) Match(expected, actual image.Image) bool {
+	m.sizeDelta = 0
+	m.croppedExpectedImage = nil
+	m.croppedActualImage = nil
+
+	// Expected image will be nil if no recent positive image is found. The delegate decides whether
+	// that is a match. The same goes for images of the same size, which need no cropping.
+	if expected == nil || expected.Bounds().Size() == actual.Bounds().Size() {
+		return m.Delegate.Match(expected, actual)
+	}
+
+	expectedSize, actualSize := expected.Bounds().Size(), actual.Bounds().Size()
+	m.sizeDelta = util.MaxInt(util.AbsInt(expectedSize.X-actualSize.X), util.AbsInt(expectedSize.Y-actualSize.Y))
+	if m.sizeDelta > m.MaxSizeDelta {
+		return false
+	}
+
+	size := image.Pt(util.MinInt(expectedSize.X, actualSize.X), util.MinInt(expectedSize.Y, actualSize.Y))
+	m.croppedExpectedImage = crop(expected, size)
+	m.croppedActualImage = crop(actual, size)
+	return m.Delegate.Match(m.croppedExpectedImage, m.croppedActualImage)
+}
+
+// crop returns a copy of the given image cropped to the given size, keeping its top-left corner.
+// The returned image has bounds starting at (0, 0).
+func crop(img image.Image, size image.Point) image.Image {
+	outputImg := image.NewNRGBA(image.Rectangle{Max: size})
+	draw.Draw(outputImg, outputImg.Bounds(), img, img.Bounds().Min, draw.Src)
+	return outputImg
+}
+
+// SizeDelta returns the largest difference between the widths or heights of the images from the
+// last Match method call.
+func (m *Matcher) SizeDelta() int { return m.sizeDelta }
+
+// CroppedExpectedImage returns the left image from the last Match method call after cropping. It
+// returns nil if no cropping took place.
+func (m *Matcher) CroppedExpectedImage() image.Image { return m.croppedExpectedImage }
+
+// CroppedActualImage returns the right image from the last Match method call after cropping. It
+// returns nil if no cropping took place.
+func (m *Matcher) CroppedActualImage() image.Image { return m.croppedActualImage }
+
+// DebugArtifacts implements the imgmatching.DebugArtifactsProvider interface. It includes the
+// artifacts reported by the Delegate, if any.
+func (m *Matcher) DebugArtifacts() debugartifacts.Bundle {
+	b := debugartifacts.New()
+	if delegate, ok := m.Delegate.(debugArtifactsProvider); ok {
+		b.Merge(delegate.DebugArtifacts())
+	}
+	b.Metrics["size_delta"] = float64(m.sizeDelta)
+	b.AddImage("expected-cropped", m.croppedExpectedImage)
+	b.AddImage("actual-cropped", m.croppedActualImage)
+	return b
+}
@@ -0,0 +1,103 @@
+package cropped
+
+import (
+	"image"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.skia.org/infra/gold-client/go/imgmatching/fuzzy"
+	"go.skia.org/infra/gold-client/go/imgmatching/masked"
+	"go.skia.org/infra/golden/go/image/text"
+)
+
+const (
+	// expectedImage is a 3x3 image.
+	expectedImage = `! SKTEXTSIMPLE
+3 3
+0x000000ff 0x111111ff 0x222222ff
+0x333333ff 0x444444ff 0x555555ff
+0x666666ff 0x777777ff 0x888888ff`
+
+	// actualImage is identical to expectedImage, except that it has an extra column and row.
+	actualImage = `! SKTEXTSIMPLE
+4 4
+0x000000ff 0x111111ff 0x222222ff 0xffffffff
+0x333333ff 0x444444ff 0x555555ff 0xffffffff
+0x666666ff 0x777777ff 0x888888ff 0xffffffff
+0xffffffff 0xffffffff 0xffffffff 0xffffffff`
+
+	// smallImage is a 2x2 image.
+	smallImage = `! SKTEXTSIMPLE
+2 2
+0x000000ff 0x111111ff
+0x333333ff 0x444444ff`
+)
+
+func TestMatcher_SameSize_DelegatesToUnderlyingMatcher(t *testing.T) {
+	m := Matcher{Delegate: &fuzzy.Matcher{}, MaxSizeDelta: 1}
+	assert.True(t, m.Match(text.MustToNRGBA(expectedImage), text.MustToNRGBA(expectedImage)))
+	assert.Equal(t, 0, m.SizeDelta())
+	assert.Nil(t, m.CroppedExpectedImage())
+	assert.Nil(t, m.CroppedActualImage())
+}
+
+func TestMatcher_SizeDeltaWithinTolerance_CropsImages(t *testing.T) {
+	m := Matcher{Delegate: &fuzzy.Matcher{}, MaxSizeDelta: 1}
+
+	assert.True(t, m.Match(text.MustToNRGBA(expectedImage), text.MustToNRGBA(actualImage)))
+	assert.Equal(t, 1, m.SizeDelta())
+	assert.Equal(t, text.MustToNRGBA(expectedImage), m.CroppedExpectedImage())
+	assert.Equal(t, text.MustToNRGBA(expectedImage), m.CroppedActualImage())
+
+	// The larger image can be on either side.
+	assert.True(t, m.Match(text.MustToNRGBA(actualImage), text.MustToNRGBA(expectedImage)))
+}
+
+func TestMatcher_SizeDeltaAboveTolerance_ReturnsFalse(t *testing.T) {
+	m := Matcher{Delegate: &fuzzy.Matcher{}, MaxSizeDelta: 1}
+	assert.False(t, m.Match(text.MustToNRGBA(smallImage), text.MustToNRGBA(actualImage)))
+	assert.Equal(t, 2, m.SizeDelta())
+	assert.Nil(t, m.CroppedExpectedImage())
+}
+
+func TestMatcher_ZeroTolerance_DifferentSizes_ReturnsFalse(t *testing.T) {
+	m := Matcher{Delegate: &fuzzy.Matcher{}}
+	assert.False(t, m.Match(text.MustToNRGBA(expectedImage), text.MustToNRGBA(actualImage)))
+}
+
+func TestMatcher_CroppedImagesDiffer_ReturnsDelegateResult(t *testing.T) {
+	m := Matcher{Delegate: &fuzzy.Matcher{}, MaxSizeDelta: 2}
+	assert.False(t, m.Match(text.MustToNRGBA(smallImage), text.MustToNRGBA(`! SKTEXTSIMPLE
+	3 2
+	0x000000ff 0x111111ff 0x222222ff
+	0x333333ff 0x555555ff 0x555555ff`)))
+	assert.Equal(t, 1, m.Delegate.(*fuzzy.Matcher).NumDifferentPixels())
+}
+
+func TestMatcher_NonZeroOrigin_CroppedImagesStartAtOrigin(t *testing.T) {
+	m := Matcher{Delegate: &fuzzy.Matcher{}, MaxSizeDelta: 1}
+	actual := text.MustToNRGBA(actualImage).SubImage(image.Rect(1, 1, 4, 4))
+
+	assert.False(t, m.Match(text.MustToNRGBA(smallImage), actual))
+	assert.Equal(t, image.Rect(0, 0, 2, 2), m.CroppedActualImage().Bounds())
+	assert.Equal(t, text.MustToNRGBA(`! SKTEXTSIMPLE
+	2 2
+	0x444444ff 0x555555ff
+	0x777777ff 0x888888ff`), m.CroppedActualImage())
+}
+
+func TestMatcher_DebugArtifacts_IncludesDelegateArtifacts(t *testing.T) {
+	m := Matcher{Delegate: &masked.Matcher{Delegate: &fuzzy.Matcher{}}, MaxSizeDelta: 1}
+	assert.True(t, m.Match(text.MustToNRGBA(expectedImage), text.MustToNRGBA(actualImage)))
+
+	b := m.DebugArtifacts()
+	assert.Equal(t, map[string]float64{
+		"num_different_pixels": 0,
+		"max_pixel_delta":      0,
+		"num_ignored_pixels":   0,
+		"size_delta":           1,
+	}, b.Metrics)
+	assert.Equal(t, m.CroppedExpectedImage(), b.Images["expected-cropped"])
+	assert.Equal(t, m.CroppedActualImage(), b.Images["actual-cropped"])
+	assert.Contains(t, b.Images, "ignored-pixels-mask")
+}
@@ -0,0 +1,98 @@
+// Package debugartifacts defines the debug artifacts that image matching algorithms report about
+// the last pair of images they compared, e.g. intermediate images and numeric metrics.
+package debugartifacts
+
+import (
+	"encoding/json"
+	"image"
+	"image/png"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/go/util"
+)
+
+// MetricsFileName is the name of the file where WriteToDir writes the metrics of a Bundle.
+const MetricsFileName = "metrics.json"
+
+// Bundle contains the debug artifacts produced by an image matching algorithm when comparing a
+// pair of images.
+type Bundle struct {
+	// Images maps artifact names (e.g. "sobel-output") to intermediate images. Names must be valid
+	// file names without an extension.
+	Images map[string]image.Image
+
+	// Metrics maps metric names (e.g. "num_different_pixels") to their values.
+	Metrics map[string]float64
+}
+
+// New returns an empty Bundle.
+func New() Bundle {
+	return Bundle{
+		Images:  map[string]image.Image{},
+		Metrics: map[string]float64{},
+	}
+}
+
+// AddImage adds the given image to the bundle, unless it is nil or empty. Note that a nil pointer
+// to a concrete image type (e.g. a nil *image.Gray) is not a nil image.Image.
+func (b Bundle) AddImage(name string, img image.Image) {
+	if img == nil || img.Bounds().Empty() {
+		return
+	}
+	b.Images[name] = img
+}
+
+// Merge adds all artifacts in other to this bundle, overwriting any artifacts with the same names.
+func (b Bundle) Merge(other Bundle) {
+	for name, img := range other.Images {
+		b.Images[name] = img
+	}
+	for name, value := range other.Metrics {
+		b.Metrics[name] = value
+	}
+}
+
+// IsEmpty returns true if the bundle contains no artifacts.
+func (b Bundle) IsEmpty() bool {
+	return len(b.Images) == 0 && len(b.Metrics) == 0
+}
+
+// WriteToDir writes each image in the bundle to the given directory as a PNG file named after the
+// image, and the metrics as a JSON file named MetricsFileName. The directory is created if it does
+// not exist. It returns the paths of the written files in lexicographical order.
+func (b Bundle) WriteToDir(dir string) ([]string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, skerr.Wrapf(err, "creating directory %s", dir)
+	}
+
+	var paths []string
+	for name, img := range b.Images {
+		p := filepath.Join(dir, name+".png")
+		err := util.WithWriteFile(p, func(w io.Writer) error {
+			return skerr.Wrap(png.Encode(w, img))
+		})
+		if err != nil {
+			return nil, skerr.Wrapf(err, "writing image %s", p)
+		}
+		paths = append(paths, p)
+	}
+
+	if len(b.Metrics) > 0 {
+		p := filepath.Join(dir, MetricsFileName)
+		jsonBytes, err := json.MarshalIndent(b.Metrics, "", "  ")
+		if err != nil {
+			return nil, skerr.Wrap(err)
+		}
+		if err := os.WriteFile(p, jsonBytes, 0644); err != nil {
+			return nil, skerr.Wrapf(err, "writing metrics %s", p)
+		}
+		paths = append(paths, p)
+	}
+
+	sort.Strings(paths)
+	return paths, nil
+}
@@ -0,0 +1,64 @@
+package debugartifacts
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBundle_AddImage_NilOrEmptyImage_Ignored(t *testing.T) {
+	b := New()
+	b.AddImage("nil", nil)
+	b.AddImage("empty", image.NewGray(image.Rectangle{}))
+	assert.True(t, b.IsEmpty())
+
+	b.AddImage("gray", image.NewGray(image.Rect(0, 0, 1, 1)))
+	assert.False(t, b.IsEmpty())
+	assert.Len(t, b.Images, 1)
+}
+
+func TestBundle_Merge_AddsAllArtifacts(t *testing.T) {
+	b := New()
+	b.Metrics["a"] = 1
+	b.Metrics["b"] = 2
+
+	other := New()
+	other.Metrics["b"] = 3
+	other.AddImage("img", image.NewGray(image.Rect(0, 0, 1, 1)))
+	b.Merge(other)
+
+	assert.Equal(t, map[string]float64{"a": 1, "b": 3}, b.Metrics)
+	assert.Contains(t, b.Images, "img")
+}
+
+func TestBundle_WriteToDir_WritesImagesAndMetrics(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "artifacts")
+	img := image.NewGray(image.Rect(0, 0, 2, 3))
+
+	b := New()
+	b.AddImage("sobel-output", img)
+	b.Metrics["num_different_pixels"] = 5
+
+	paths, err := b.WriteToDir(dir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(dir, MetricsFileName),
+		filepath.Join(dir, "sobel-output.png"),
+	}, paths)
+
+	pngBytes, err := os.ReadFile(filepath.Join(dir, "sobel-output.png"))
+	require.NoError(t, err)
+	decoded, err := png.Decode(bytes.NewReader(pngBytes))
+	require.NoError(t, err)
+	assert.Equal(t, img.Bounds(), decoded.Bounds())
+
+	jsonBytes, err := os.ReadFile(filepath.Join(dir, MetricsFileName))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"num_different_pixels": 5}`, string(jsonBytes))
+}
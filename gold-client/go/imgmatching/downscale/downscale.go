@@ -0,0 +1,141 @@
+package downscale
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+
+	"go.skia.org/infra/gold-client/go/imgmatching/debugartifacts"
+	"go.skia.org/infra/gold-client/go/imgmatching/fuzzy"
+	"go.skia.org/infra/gold-client/go/imgmatching/internal/rowbands"
+)
+
+// testMatcher is an exact copy of the imgmatching.Matcher interface for the sole purpose of
+// avoiding an import cycle between packages imgmatching and downscale.
+type testMatcher interface {
+	Match(expected, actual image.Image) bool
+}
+
+// Matcher is an image matching algorithm.
+//
+// It extends the fuzzy.Matcher algorithm by downsampling both images before comparing them, which
+// smooths out small differences such as subpixel anti-aliasing jitter along edges.
+//
+// The algorithm performs the following steps:
+//  1. It downsamples *both* images by DownscaleFactor using a box filter, i.e. each pixel in the
+//     downsampled images is the average of a DownscaleFactor x DownscaleFactor block of pixels in
+//     the original image. Blocks along the right and bottom edges are cropped to fit the image.
+//     Colors are averaged in premultiplied alpha so that transparent pixels do not contribute.
+//  2. It passes the two resulting images to the fuzzy.Matcher algorithm (using parameters
+//     MaxDifferentPixels, PixelDeltaThreshold, PixelPerChannelDeltaThreshold, etc.) and returns
+//     its return value. Note that MaxDifferentPixels and IgnoredBorderThickness refer to pixels
+//     in the downsampled images.
+type Matcher struct {
+	fuzzy.Matcher
+	DownscaleFactor int // Valid values are 1 or greater.
+
+	// If set, fuzzyMatcherForTesting will be used instead of the embedded fuzzy.Matcher.
+	fuzzyMatcherForTesting testMatcher
+
+	// Debug information about the last pair of matched images.
+	downscaledExpectedImage image.Image
+	downscaledActualImage   image.Image
+}
+
+// Match implements the imgmatching.Matcher interface.
+func (m *Matcher) Match(expected, actual image.Image) bool {
+	m.downscaledExpectedImage = nil
+	m.downscaledActualImage = nil
+
+	// Expected image will be nil if no recent positive image is found.
+	if expected == nil {
+		return false
+	}
+
+	// Images must be the same size.
+	if !expected.Bounds().Eq(actual.Bounds()) {
+		return false
+	}
+
+	// Note that the DownscaleFactor value range is enforced by the imgmatching.MakeMatcher() factory
+	// function.
+	m.downscaledExpectedImage = downscale(expected, m.DownscaleFactor)
+	m.downscaledActualImage = downscale(actual, m.DownscaleFactor)
+
+	// Determine whether to use the embedded fuzzy.Matcher or the fuzzyMatcherForTesting.
+	fuzzyMatcher := m.fuzzyMatcherForTesting
+	if fuzzyMatcher == nil {
+		fuzzyMatcher = &m.Matcher
+	}
+
+	// Delegate to the fuzzy matcher.
+	return fuzzyMatcher.Match(m.downscaledExpectedImage, m.downscaledActualImage)
+}
+
+// DownscaledExpectedImage returns the left image from the last Match method call after
+// downsampling.
+func (m *Matcher) DownscaledExpectedImage() image.Image { return m.downscaledExpectedImage }
+
+// DownscaledActualImage returns the right image from the last Match method call after
+// downsampling.
+func (m *Matcher) DownscaledActualImage() image.Image { return m.downscaledActualImage }
+
+// DebugArtifacts implements the imgmatching.DebugArtifactsProvider interface. It includes the
+// artifacts reported by the embedded fuzzy.Matcher.
+func (m *Matcher) DebugArtifacts() debugartifacts.Bundle {
+	b := m.Matcher.DebugArtifacts()
+	b.AddImage("expected-downscaled", m.downscaledExpectedImage)
+	b.AddImage("actual-downscaled", m.downscaledActualImage)
+	return b
+}
+
+// downscale returns a copy of the given image downsampled by the given factor using a box filter.
+// The returned image has bounds starting at (0, 0), and its width and height are those of the
+// input image divided by the given factor, rounded up.
+func downscale(img image.Image, factor int) *image.NRGBA {
+	// Convert to NRGBA so that we can access the pixel values directly.
+	bounds := img.Bounds()
+	src := image.NewNRGBA(bounds)
+	draw.Draw(src, bounds, img, bounds.Min, draw.Src)
+
+	outputBounds := image.Rect(0, 0, (bounds.Dx()+factor-1)/factor, (bounds.Dy()+factor-1)/factor)
+	output := image.NewNRGBA(outputBounds)
+
+	rowbands.ForEach(outputBounds, func(_ int, band image.Rectangle) {
+		for y := band.Min.Y; y < band.Max.Y; y++ {
+			for x := band.Min.X; x < band.Max.X; x++ {
+				block := image.Rect(x*factor, y*factor, (x+1)*factor, (y+1)*factor).Add(bounds.Min).Intersect(bounds)
+				output.SetNRGBA(x, y, averageColor(src, block))
+			}
+		}
+	})
+
+	return output
+}
+
+// averageColor returns the average color of the pixels in the given non-empty block of the given
+// image. Colors are weighted by their alpha channel, i.e. averaged in premultiplied alpha.
+func averageColor(img *image.NRGBA, block image.Rectangle) color.NRGBA {
+	var r, g, b, a int
+	for y := block.Min.Y; y < block.Max.Y; y++ {
+		for x := block.Min.X; x < block.Max.X; x++ {
+			p := img.NRGBAAt(x, y)
+			r += int(p.R) * int(p.A)
+			g += int(p.G) * int(p.A)
+			b += int(p.B) * int(p.A)
+			a += int(p.A)
+		}
+	}
+	if a == 0 {
+		return color.NRGBA{}
+	}
+
+	// Round to the nearest integer.
+	n := block.Dx() * block.Dy()
+	return color.NRGBA{
+		R: uint8((r + a/2) / a),
+		G: uint8((g + a/2) / a),
+		B: uint8((b + a/2) / a),
+		A: uint8((a + n/2) / n),
+	}
+}
@@ -0,0 +1,123 @@
+package downscale
+
+import (
+	"image"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.skia.org/infra/gold-client/go/imgmatching/fuzzy"
+	"go.skia.org/infra/gold-client/go/mocks"
+	"go.skia.org/infra/golden/go/image/text"
+)
+
+// image1 is a 4x4 image with an anti-aliased edge between its left and right halves.
+const image1 = `! SKTEXTSIMPLE
+4 4
+0x000000ff 0x404040ff 0xffffffff 0xffffffff
+0x000000ff 0x404040ff 0xffffffff 0xffffffff
+0x000000ff 0x000000ff 0xc0c0c0ff 0xffffffff
+0x000000ff 0x000000ff 0xc0c0c0ff 0xffffffff`
+
+// image2 is identical to image1, except that the anti-aliasing of the edge is shifted by a pixel.
+const image2 = `! SKTEXTSIMPLE
+4 4
+0x000000ff 0x000000ff 0xc0c0c0ff 0xffffffff
+0x000000ff 0x000000ff 0xc0c0c0ff 0xffffffff
+0x000000ff 0x404040ff 0xffffffff 0xffffffff
+0x000000ff 0x404040ff 0xffffffff 0xffffffff`
+
+func TestMatcher_Match_DownscaledImagesPassedToFuzzyMatcher(t *testing.T) {
+	fuzzyMatcher := &mocks.Matcher{}
+
+	// Return value does not matter, we're only testing that the right inputs are passed.
+	fuzzyMatcher.On("Match", text.MustToNRGBA(`! SKTEXTSIMPLE
+	2 2
+	0x202020ff 0xffffffff
+	0x000000ff 0xe0e0e0ff`), text.MustToNRGBA(`! SKTEXTSIMPLE
+	2 2
+	0x000000ff 0xe0e0e0ff
+	0x202020ff 0xffffffff`)).Return(true)
+
+	m := Matcher{DownscaleFactor: 2, fuzzyMatcherForTesting: fuzzyMatcher}
+	assert.True(t, m.Match(text.MustToNRGBA(image1), text.MustToNRGBA(image2)))
+	fuzzyMatcher.AssertExpectations(t)
+}
+
+func TestMatcher_Match_AntiAliasingJitter_Success(t *testing.T) {
+	// Without downscaling, the images differ in 8 pixels by up to 0xc0 per channel.
+	m := Matcher{
+		Matcher:         fuzzy.Matcher{MaxDifferentPixels: 4, PixelPerChannelDeltaThreshold: 0x20},
+		DownscaleFactor: 1,
+	}
+	assert.False(t, m.Match(text.MustToNRGBA(image1), text.MustToNRGBA(image2)))
+	assert.Equal(t, 8, m.NumDifferentPixels())
+
+	// After downscaling by 2, all four pixels differ by 0x20 per channel at most.
+	m.DownscaleFactor = 2
+	assert.True(t, m.Match(text.MustToNRGBA(image1), text.MustToNRGBA(image2)))
+	assert.Equal(t, 4, m.NumDifferentPixels())
+	assert.Equal(t, 0x20, m.MaxPixelDelta())
+
+	// Downscaling by 4 averages out the differences completely.
+	m.DownscaleFactor = 4
+	m.MaxDifferentPixels = 0
+	m.PixelPerChannelDeltaThreshold = 0
+	assert.True(t, m.Match(text.MustToNRGBA(image1), text.MustToNRGBA(image2)))
+}
+
+func TestMatcher_Match_NilExpectedImage_ReturnsFalse(t *testing.T) {
+	m := Matcher{DownscaleFactor: 2}
+	assert.False(t, m.Match(nil, text.MustToNRGBA(image1)))
+	assert.Nil(t, m.DownscaledExpectedImage())
+	assert.Nil(t, m.DownscaledActualImage())
+}
+
+func TestMatcher_Match_DifferentSizeImages_ReturnsFalse(t *testing.T) {
+	m := Matcher{DownscaleFactor: 2}
+	assert.False(t, m.Match(text.MustToNRGBA(image1), text.MustToNRGBA(`! SKTEXTSIMPLE
+	2 2
+	0x000000ff 0x000000ff
+	0x000000ff 0x000000ff`)))
+}
+
+func TestDownscale_PartialBlocksAndTransparentPixels_Success(t *testing.T) {
+	input := text.MustToNRGBA(`! SKTEXTSIMPLE
+	3 3
+	0xff000000 0xff0000ff 0x00ff00ff
+	0x00000000 0x00000000 0x00ff00ff
+	0x0000ffff 0x0000ff80 0xffffffff`)
+
+	// Transparent pixels do not contribute to the color of the downsampled pixels, only to their
+	// alpha. Blocks along the right and bottom edges are cropped to the image bounds.
+	assert.Equal(t, text.MustToNRGBA(`! SKTEXTSIMPLE
+	2 2
+	0xff000040 0x00ff00ff
+	0x0000ffc0 0xffffffff`), downscale(input, 2))
+}
+
+func TestDownscale_NonZeroOrigin_OutputStartsAtOrigin(t *testing.T) {
+	input := text.MustToNRGBA(image1).SubImage(image.Rect(2, 0, 4, 4))
+
+	output := downscale(input, 2)
+	assert.Equal(t, image.Rect(0, 0, 1, 2), output.Bounds())
+	assert.Equal(t, text.MustToNRGBA(`! SKTEXTSIMPLE
+	1 2
+	0xffffffff
+	0xe0e0e0ff`), output)
+}
+
+func TestMatcher_DebugArtifacts_ReportsDownscaledImages(t *testing.T) {
+	m := Matcher{
+		Matcher:         fuzzy.Matcher{MaxDifferentPixels: 4, PixelPerChannelDeltaThreshold: 0x20},
+		DownscaleFactor: 2,
+	}
+	assert.True(t, m.Match(text.MustToNRGBA(image1), text.MustToNRGBA(image2)))
+
+	b := m.DebugArtifacts()
+	assert.Equal(t, map[string]float64{
+		"num_different_pixels": 4,
+		"max_pixel_delta":      0x20,
+	}, b.Metrics)
+	assert.Equal(t, m.DownscaledExpectedImage(), b.Images["expected-downscaled"])
+	assert.Equal(t, m.DownscaledActualImage(), b.Images["actual-downscaled"])
+}
@@ -1,25 +1,77 @@
 package imgmatching
 
 import (
+	"bytes"
 	"fmt"
+	"image"
+	"image/png"
 	"math"
+	"os"
 	"strconv"
 	"strings"
 
 	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/gold-client/go/imgmatching/cropped"
+	"go.skia.org/infra/gold-client/go/imgmatching/downscale"
 	"go.skia.org/infra/gold-client/go/imgmatching/exact"
 	"go.skia.org/infra/gold-client/go/imgmatching/fuzzy"
+	"go.skia.org/infra/gold-client/go/imgmatching/masked"
 	"go.skia.org/infra/gold-client/go/imgmatching/positive_if_only_image"
 	"go.skia.org/infra/gold-client/go/imgmatching/sample_area"
 	"go.skia.org/infra/gold-client/go/imgmatching/sobel"
+	"go.skia.org/infra/gold-client/go/imgmatching/ssim"
 )
 
 // MakeMatcher takes a map of optional keys and returns the specified image matching algorithm
 // name, and the corresponding Matcher instance (or nil if none is specified).
 //
+// If the IgnoredRegions or IgnoredRegionsMaskFile optional keys are present, the returned Matcher
+// is a masked.Matcher that wraps the Matcher for the specified algorithm. If the MaxSizeDelta
+// optional key is present, the returned Matcher is a cropped.Matcher that wraps the former.
+//
 // It returns a non-nil error if the specified image matching algorithm is invalid, or if any
 // required parameters are not found, or if the parameter values are not valid.
 func MakeMatcher(optionalKeys map[string]string) (AlgorithmName, Matcher, error) {
+	algorithmName, matcher, err := makeUnmaskedMatcher(optionalKeys)
+	if err != nil {
+		return "", nil, skerr.Wrap(err)
+	}
+
+	_, hasIgnoredRegions := optionalKeys[string(IgnoredRegions)]
+	_, hasMaskFile := optionalKeys[string(IgnoredRegionsMaskFile)]
+	_, hasMaxSizeDelta := optionalKeys[string(MaxSizeDelta)]
+	if !hasIgnoredRegions && !hasMaskFile && !hasMaxSizeDelta {
+		return algorithmName, matcher, nil
+	}
+
+	// Exact matching is done by comparing digests, so there is no Matcher to wrap.
+	if algorithmName == ExactMatching || algorithmName == PositiveIfOnlyImageMatching {
+		if hasMaxSizeDelta {
+			return "", nil, skerr.Fmt("image matching algorithm %q does not support a size tolerance", algorithmName)
+		}
+		return "", nil, skerr.Fmt("image matching algorithm %q does not support ignored regions", algorithmName)
+	}
+
+	if hasIgnoredRegions || hasMaskFile {
+		matcher, err = makeMaskedMatcher(optionalKeys, matcher)
+		if err != nil {
+			return "", nil, skerr.Wrap(err)
+		}
+	}
+
+	if hasMaxSizeDelta {
+		matcher, err = makeCroppedMatcher(optionalKeys, matcher)
+		if err != nil {
+			return "", nil, skerr.Wrap(err)
+		}
+	}
+	return algorithmName, matcher, nil
+}
+
+// makeUnmaskedMatcher returns the specified image matching algorithm name and the corresponding
+// Matcher instance, ignoring the IgnoredRegions, IgnoredRegionsMaskFile and MaxSizeDelta optional
+// keys.
+func makeUnmaskedMatcher(optionalKeys map[string]string) (AlgorithmName, Matcher, error) {
 	algorithmNameStr, ok := optionalKeys[AlgorithmNameOptKey]
 	algorithmName := AlgorithmName(algorithmNameStr)
 
@@ -29,6 +81,13 @@ func MakeMatcher(optionalKeys map[string]string) (AlgorithmName, Matcher, error)
 	}
 
 	switch algorithmName {
+	case DownscaleFuzzyMatching:
+		matcher, err := makeDownscaleFuzzyMatcher(optionalKeys)
+		if err != nil {
+			return "", nil, skerr.Wrap(err)
+		}
+		return DownscaleFuzzyMatching, matcher, nil
+
 	case ExactMatching:
 		return ExactMatching, &exact.Matcher{}, nil
 
@@ -56,6 +115,13 @@ func MakeMatcher(optionalKeys map[string]string) (AlgorithmName, Matcher, error)
 		}
 		return SobelFuzzyMatching, matcher, nil
 
+	case SSIMMatching:
+		matcher, err := makeSSIMMatcher(optionalKeys)
+		if err != nil {
+			return "", nil, skerr.Wrap(err)
+		}
+		return SSIMMatching, matcher, nil
+
 	default:
 		return "", nil, skerr.Fmt("unrecognized image matching algorithm: %q", algorithmName)
 	}
@@ -95,11 +161,57 @@ func makeFuzzyMatcher(optionalKeys map[string]string) (*fuzzy.Matcher, error) {
 		return nil, skerr.Wrap(err)
 	}
 
+	alphaMode, err := getAndValidateEnumParameter(AlphaMode, []string{
+		string(fuzzy.AlphaModeIgnoreTransparentPixels),
+		string(fuzzy.AlphaModePremultiply),
+	}, optionalKeys)
+	if err != nil {
+		return nil, skerr.Wrap(err)
+	}
+
+	pixelDeltaMetric, err := getAndValidateEnumParameter(PixelDeltaMetric, []string{
+		string(fuzzy.PixelDeltaMetricSum),
+		string(fuzzy.PixelDeltaMetricMaxChannel),
+		string(fuzzy.PixelDeltaMetricCIEDE2000),
+	}, optionalKeys)
+	if err != nil {
+		return nil, skerr.Wrap(err)
+	}
+
+	// The maximum value corresponds to the CIEDE2000 difference between black and white.
+	isCIEDE2000 := fuzzy.PixelDeltaMetric(pixelDeltaMetric) == fuzzy.PixelDeltaMetricCIEDE2000
+	ciede2000Threshold, err := getAndValidateFloatParameter(CIEDE2000Threshold, 0, 100, isCIEDE2000 /* =required */, optionalKeys)
+	if err != nil {
+		return nil, skerr.Wrap(err)
+	}
+
+	// Ensure that the delta thresholds are consistent with the chosen metric.
+	switch fuzzy.PixelDeltaMetric(pixelDeltaMetric) {
+	case fuzzy.PixelDeltaMetricSum:
+		if pixelPerChannelDeltaThreshold > 0 {
+			return nil, skerr.Fmt("%s cannot be set when %s is %q", PixelPerChannelDeltaThreshold, PixelDeltaMetric, pixelDeltaMetric)
+		}
+	case fuzzy.PixelDeltaMetricMaxChannel:
+		if pixelDeltaThreshold > 0 {
+			return nil, skerr.Fmt("%s cannot be set when %s is %q", PixelDeltaThreshold, PixelDeltaMetric, pixelDeltaMetric)
+		}
+	case fuzzy.PixelDeltaMetricCIEDE2000:
+		if pixelDeltaThreshold > 0 || pixelPerChannelDeltaThreshold > 0 {
+			return nil, skerr.Fmt("%s and %s cannot be set when %s is %q", PixelDeltaThreshold, PixelPerChannelDeltaThreshold, PixelDeltaMetric, pixelDeltaMetric)
+		}
+	}
+	if _, ok := optionalKeys[string(CIEDE2000Threshold)]; ok && !isCIEDE2000 {
+		return nil, skerr.Fmt("%s can only be set when %s is %q", CIEDE2000Threshold, PixelDeltaMetric, fuzzy.PixelDeltaMetricCIEDE2000)
+	}
+
 	return &fuzzy.Matcher{
 		MaxDifferentPixels:            maxDifferentPixels,
 		PixelDeltaThreshold:           pixelDeltaThreshold,
 		PixelPerChannelDeltaThreshold: pixelPerChannelDeltaThreshold,
+		CIEDE2000Threshold:            ciede2000Threshold,
 		IgnoredBorderThickness:        ignoredBorderThickness,
+		AlphaMode:                     fuzzy.AlphaMode(alphaMode),
+		PixelDeltaMetric:              fuzzy.PixelDeltaMetric(pixelDeltaMetric),
 	}, nil
 }
 
@@ -157,12 +269,135 @@ func makeSobelFuzzyMatcher(optionalKeys map[string]string) (*sobel.Matcher, erro
 		return nil, skerr.Wrap(err)
 	}
 
+	borderMode, err := getAndValidateEnumParameter(SobelBorderMode, []string{
+		string(sobel.BorderModeReplicate),
+		string(sobel.BorderModeEdge),
+	}, optionalKeys)
+	if err != nil {
+		return nil, skerr.Wrap(err)
+	}
+
 	return &sobel.Matcher{
 		Matcher:       *fuzzyMatcher,
 		EdgeThreshold: edgeThreshold,
+		BorderMode:    sobel.BorderMode(borderMode),
+	}, nil
+}
+
+// makeDownscaleFuzzyMatcher returns a downscale.Matcher instance set up with the parameter values
+// in the given optional keys map.
+func makeDownscaleFuzzyMatcher(optionalKeys map[string]string) (*downscale.Matcher, error) {
+	// Instantiate the fuzzy.Matcher that will be embedded in the downscale.Matcher.
+	fuzzyMatcher, err := makeFuzzyMatcher(optionalKeys)
+	if err != nil {
+		return nil, skerr.Wrap(err)
+	}
+
+	downscaleFactor, err := getAndValidateIntParameter(DownscaleFactor, 1, math.MaxInt32, true /* =required */, optionalKeys)
+	if err != nil {
+		return nil, skerr.Wrap(err)
+	}
+
+	return &downscale.Matcher{
+		Matcher:         *fuzzyMatcher,
+		DownscaleFactor: downscaleFactor,
+	}, nil
+}
+
+// makeSSIMMatcher returns an ssim.Matcher instance set up with the parameter values in the given
+// optional keys map.
+func makeSSIMMatcher(optionalKeys map[string]string) (*ssim.Matcher, error) {
+	// The window size defaults to ssim.DefaultWindowSize if not specified.
+	windowSize, err := getAndValidateIntParameter(SSIMWindowSize, 1, math.MaxInt32, false /* =required */, optionalKeys)
+	if err != nil {
+		return nil, skerr.Wrap(err)
+	}
+	if _, ok := optionalKeys[string(SSIMWindowSize)]; !ok {
+		windowSize = ssim.DefaultWindowSize
+	}
+
+	// SSIM values range from -1 to 1, but a negative threshold would match anti-correlated images.
+	minScore, err := getAndValidateFloatParameter(SSIMMinScore, 0, 1, true /* =required */, optionalKeys)
+	if err != nil {
+		return nil, skerr.Wrap(err)
+	}
+
+	return &ssim.Matcher{
+		WindowSize: windowSize,
+		MinScore:   minScore,
+	}, nil
+}
+
+// makeMaskedMatcher returns a masked.Matcher instance that wraps the given Matcher, set up with the
+// ignored regions and mask image in the given optional keys map.
+func makeMaskedMatcher(optionalKeys map[string]string, delegate Matcher) (*masked.Matcher, error) {
+	matcher := &masked.Matcher{Delegate: delegate}
+
+	if regionsStr, ok := optionalKeys[string(IgnoredRegions)]; ok {
+		if strings.TrimSpace(regionsStr) == "" {
+			return nil, skerr.Fmt("image matching parameter %q cannot be empty", IgnoredRegions)
+		}
+		for _, regionStr := range strings.Split(regionsStr, ";") {
+			region, err := parseRectangle(regionStr)
+			if err != nil {
+				return nil, skerr.Wrapf(err, "parsing image matching parameter %q", IgnoredRegions)
+			}
+			matcher.IgnoredRegions = append(matcher.IgnoredRegions, region)
+		}
+	}
+
+	if path, ok := optionalKeys[string(IgnoredRegionsMaskFile)]; ok {
+		if strings.TrimSpace(path) == "" {
+			return nil, skerr.Fmt("image matching parameter %q cannot be empty", IgnoredRegionsMaskFile)
+		}
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return nil, skerr.Wrapf(err, "reading mask image for image matching parameter %q", IgnoredRegionsMaskFile)
+		}
+		mask, err := png.Decode(bytes.NewReader(b))
+		if err != nil {
+			return nil, skerr.Wrapf(err, "decoding mask image %s", path)
+		}
+		matcher.Mask = mask
+	}
+
+	return matcher, nil
+}
+
+// makeCroppedMatcher returns a cropped.Matcher instance that wraps the given Matcher, set up with
+// the size tolerance in the given optional keys map.
+func makeCroppedMatcher(optionalKeys map[string]string, delegate Matcher) (*cropped.Matcher, error) {
+	maxSizeDelta, err := getAndValidateIntParameter(MaxSizeDelta, 0, math.MaxInt32, true /* =required */, optionalKeys)
+	if err != nil {
+		return nil, skerr.Wrap(err)
+	}
+
+	return &cropped.Matcher{
+		Delegate:     delegate,
+		MaxSizeDelta: maxSizeDelta,
 	}, nil
 }
 
+// parseRectangle parses a rectangle of the form "x0,y0,x1,y1", where x0 < x1 and y0 < y1.
+func parseRectangle(s string) (image.Rectangle, error) {
+	parts := strings.Split(s, ",")
+	if len(parts) != 4 {
+		return image.Rectangle{}, skerr.Fmt("invalid rectangle %q; expected x0,y0,x1,y1", s)
+	}
+	var coords [4]int
+	for i, part := range parts {
+		v, err := strconv.ParseInt(strings.TrimSpace(part), 10, 32)
+		if err != nil {
+			return image.Rectangle{}, skerr.Fmt("invalid rectangle %q: %q", s, err.Error())
+		}
+		coords[i] = int(v)
+	}
+	if coords[0] >= coords[2] || coords[1] >= coords[3] {
+		return image.Rectangle{}, skerr.Fmt("invalid rectangle %q: x0 must be less than x1, and y0 must be less than y1", s)
+	}
+	return image.Rect(coords[0], coords[1], coords[2], coords[3]), nil
+}
+
 // getAndValidateIntParameter extracts and validates the given required integer parameter from the
 // given map of optional keys.
 //
@@ -222,3 +457,65 @@ func getAndValidateIntParameter(name AlgorithmParamOptKey, min, max int, require
 
 	return intVal, nil
 }
+
+// getAndValidateFloatParameter extracts and validates the given floating point parameter from the
+// given map of optional keys. The value must be between min and max inclusive.
+//
+// If required is false and the parameter is not present in the map of optional keys, a value of 0
+// will be returned.
+func getAndValidateFloatParameter(name AlgorithmParamOptKey, min, max float64, required bool, optionalKeys map[string]string) (float64, error) {
+	// Validate bounds.
+	if min >= max {
+		// This is almost surely a programming error.
+		panic(fmt.Sprintf("min must be strictly less than max, min was %f, max was %f", min, max))
+	}
+
+	// Validate presence.
+	stringVal, ok := optionalKeys[string(name)]
+	if !ok {
+		if required {
+			return 0, skerr.Fmt("required image matching parameter not found: %q", name)
+		}
+		return 0, nil
+	}
+
+	// Value cannot be empty.
+	if strings.TrimSpace(stringVal) == "" {
+		return 0, skerr.Fmt("image matching parameter %q cannot be empty", name)
+	}
+
+	floatVal, err := strconv.ParseFloat(stringVal, 64)
+	if err != nil {
+		return 0, skerr.Fmt("parsing float value for image matching parameter %q: %q", name, err.Error())
+	}
+
+	// Value must be between bounds. The negated comparison also rejects NaN.
+	if !(floatVal >= min && floatVal <= max) {
+		return 0, skerr.Fmt("image matching parameter %q must be between %g and %g, was: %s", name, min, max, stringVal)
+	}
+
+	return floatVal, nil
+}
+
+// getAndValidateEnumParameter extracts the given optional parameter from the given map of optional
+// keys, and validates that it is one of the given values.
+//
+// If the parameter is not present in the map of optional keys, an empty string will be returned.
+func getAndValidateEnumParameter(name AlgorithmParamOptKey, validValues []string, optionalKeys map[string]string) (string, error) {
+	stringVal, ok := optionalKeys[string(name)]
+	if !ok {
+		return "", nil
+	}
+
+	// Value cannot be empty.
+	if strings.TrimSpace(stringVal) == "" {
+		return "", skerr.Fmt("image matching parameter %q cannot be empty", name)
+	}
+
+	for _, validValue := range validValues {
+		if stringVal == validValue {
+			return stringVal, nil
+		}
+	}
+	return "", skerr.Fmt("image matching parameter %q must be one of %q, was: %q", name, validValues, stringVal)
+}
@@ -2,15 +2,24 @@ package imgmatching
 
 import (
 	"fmt"
+	"image"
+	"image/png"
 	"math"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.skia.org/infra/gold-client/go/imgmatching/cropped"
+	"go.skia.org/infra/gold-client/go/imgmatching/downscale"
 	"go.skia.org/infra/gold-client/go/imgmatching/exact"
 	"go.skia.org/infra/gold-client/go/imgmatching/fuzzy"
+	"go.skia.org/infra/gold-client/go/imgmatching/masked"
 	"go.skia.org/infra/gold-client/go/imgmatching/positive_if_only_image"
 	"go.skia.org/infra/gold-client/go/imgmatching/sample_area"
 	"go.skia.org/infra/gold-client/go/imgmatching/sobel"
+	"go.skia.org/infra/gold-client/go/imgmatching/ssim"
 )
 
 func TestMakeMatcher_UnknownAlgorithm_ReturnsError(t *testing.T) {
@@ -466,6 +475,119 @@ func TestMakeMatcher_FuzzyMatching(t *testing.T) {
 	}
 }
 
+func TestMakeMatcher_FuzzyMatching_AlphaMode(t *testing.T) {
+	test := func(name, alphaMode string, want fuzzy.AlphaMode, wantErr string) {
+		t.Run(name, func(t *testing.T) {
+			for _, algorithm := range []AlgorithmName{FuzzyMatching, SobelFuzzyMatching} {
+				optionalKeys := map[string]string{
+					AlgorithmNameOptKey:        string(algorithm),
+					string(MaxDifferentPixels): "0",
+					string(EdgeThreshold):      "0",
+				}
+				if alphaMode != missing {
+					optionalKeys[string(AlphaMode)] = alphaMode
+				}
+
+				_, matcher, err := MakeMatcher(optionalKeys)
+
+				if wantErr != "" {
+					assert.Error(t, err)
+					assert.Contains(t, err.Error(), wantErr)
+					continue
+				}
+				assert.NoError(t, err)
+				if algorithm == FuzzyMatching {
+					assert.Equal(t, want, matcher.(*fuzzy.Matcher).AlphaMode)
+				} else {
+					assert.Equal(t, want, matcher.(*sobel.Matcher).AlphaMode)
+				}
+			}
+		})
+	}
+
+	test("missing, defaults to comparing all channels", missing, fuzzy.AlphaModeDefault, "")
+	test("ignore transparent pixels", "ignore_transparent_pixels", fuzzy.AlphaModeIgnoreTransparentPixels, "")
+	test("premultiply", "premultiply", fuzzy.AlphaModePremultiply, "")
+	test("empty, returns error", "", "",
+		`image matching parameter "fuzzy_alpha_mode" cannot be empty`)
+	test("unknown value, returns error", "ignore_alpha", "",
+		`image matching parameter "fuzzy_alpha_mode" must be one of ["ignore_transparent_pixels" "premultiply"], was: "ignore_alpha"`)
+}
+
+func TestMakeMatcher_FuzzyMatching_PixelDeltaMetric(t *testing.T) {
+	test := func(name string, extraKeys map[string]string, wantMetric fuzzy.PixelDeltaMetric, wantThreshold float64, wantErr string) {
+		t.Run(name, func(t *testing.T) {
+			for _, algorithm := range []AlgorithmName{FuzzyMatching, SobelFuzzyMatching} {
+				optionalKeys := map[string]string{
+					AlgorithmNameOptKey:        string(algorithm),
+					string(MaxDifferentPixels): "0",
+					string(EdgeThreshold):      "0",
+				}
+				for k, v := range extraKeys {
+					optionalKeys[k] = v
+				}
+
+				_, matcher, err := MakeMatcher(optionalKeys)
+
+				if wantErr != "" {
+					assert.Error(t, err)
+					assert.Contains(t, err.Error(), wantErr)
+					continue
+				}
+				assert.NoError(t, err)
+				var fuzzyMatcher *fuzzy.Matcher
+				if algorithm == FuzzyMatching {
+					fuzzyMatcher = matcher.(*fuzzy.Matcher)
+				} else {
+					fuzzyMatcher = &matcher.(*sobel.Matcher).Matcher
+				}
+				assert.Equal(t, wantMetric, fuzzyMatcher.PixelDeltaMetric)
+				assert.Equal(t, wantThreshold, fuzzyMatcher.CIEDE2000Threshold)
+			}
+		})
+	}
+
+	test("missing, defaults to legacy behavior", nil, fuzzy.PixelDeltaMetricDefault, 0, "")
+	test("sum", map[string]string{
+		string(PixelDeltaMetric):    "sum",
+		string(PixelDeltaThreshold): "10",
+	}, fuzzy.PixelDeltaMetricSum, 0, "")
+	test("max channel", map[string]string{
+		string(PixelDeltaMetric):              "max_channel",
+		string(PixelPerChannelDeltaThreshold): "10",
+	}, fuzzy.PixelDeltaMetricMaxChannel, 0, "")
+	test("ciede2000", map[string]string{
+		string(PixelDeltaMetric):   "ciede2000",
+		string(CIEDE2000Threshold): "2.3",
+	}, fuzzy.PixelDeltaMetricCIEDE2000, 2.3, "")
+	test("unknown metric, returns error", map[string]string{
+		string(PixelDeltaMetric): "euclidean",
+	}, "", 0, `image matching parameter "fuzzy_pixel_delta_metric" must be one of ["sum" "max_channel" "ciede2000"], was: "euclidean"`)
+	test("sum with per-channel threshold, returns error", map[string]string{
+		string(PixelDeltaMetric):              "sum",
+		string(PixelPerChannelDeltaThreshold): "10",
+	}, "", 0, `fuzzy_pixel_per_channel_delta_threshold cannot be set when fuzzy_pixel_delta_metric is "sum"`)
+	test("max channel with sum threshold, returns error", map[string]string{
+		string(PixelDeltaMetric):    "max_channel",
+		string(PixelDeltaThreshold): "10",
+	}, "", 0, `fuzzy_pixel_delta_threshold cannot be set when fuzzy_pixel_delta_metric is "max_channel"`)
+	test("ciede2000 without threshold, returns error", map[string]string{
+		string(PixelDeltaMetric): "ciede2000",
+	}, "", 0, `required image matching parameter not found: "fuzzy_ciede2000_threshold"`)
+	test("ciede2000 with integer threshold, returns error", map[string]string{
+		string(PixelDeltaMetric):    "ciede2000",
+		string(CIEDE2000Threshold):  "2.3",
+		string(PixelDeltaThreshold): "10",
+	}, "", 0, `fuzzy_pixel_delta_threshold and fuzzy_pixel_per_channel_delta_threshold cannot be set when fuzzy_pixel_delta_metric is "ciede2000"`)
+	test("ciede2000 threshold out of range, returns error", map[string]string{
+		string(PixelDeltaMetric):   "ciede2000",
+		string(CIEDE2000Threshold): "101",
+	}, "", 0, `fuzzy_ciede2000_threshold`)
+	test("ciede2000 threshold without ciede2000 metric, returns error", map[string]string{
+		string(CIEDE2000Threshold): "2.3",
+	}, "", 0, `fuzzy_ciede2000_threshold can only be set when fuzzy_pixel_delta_metric is "ciede2000"`)
+}
+
 func TestMakeMatcher_PositiveIfOnlyImageMatching(t *testing.T) {
 	algorithmName, matcher, err := MakeMatcher(map[string]string{
 		AlgorithmNameOptKey: string(PositiveIfOnlyImageMatching),
@@ -676,6 +798,39 @@ func TestMakeMatcher_SobelFuzzyMatching(t *testing.T) {
 	}
 }
 
+func TestMakeMatcher_SobelFuzzyMatching_BorderMode(t *testing.T) {
+	test := func(name, borderMode string, want sobel.BorderMode, wantErr string) {
+		t.Run(name, func(t *testing.T) {
+			optionalKeys := map[string]string{
+				AlgorithmNameOptKey:        string(SobelFuzzyMatching),
+				string(MaxDifferentPixels): "0",
+				string(EdgeThreshold):      "0",
+			}
+			if borderMode != missing {
+				optionalKeys[string(SobelBorderMode)] = borderMode
+			}
+
+			_, matcher, err := MakeMatcher(optionalKeys)
+
+			if wantErr != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, want, matcher.(*sobel.Matcher).BorderMode)
+		})
+	}
+
+	test("missing, defaults to zero", missing, sobel.BorderModeZero, "")
+	test("replicate", "replicate", sobel.BorderModeReplicate, "")
+	test("edge", "edge", sobel.BorderModeEdge, "")
+	test("empty, returns error", "", "",
+		`image matching parameter "sobel_border_mode" cannot be empty`)
+	test("unknown value, returns error", "wrap", "",
+		`image matching parameter "sobel_border_mode" must be one of ["replicate" "edge"], was: "wrap"`)
+}
+
 func TestMakeMatcher_SampleAreaMatching_Error(t *testing.T) {
 	type sampleAreaErrorTestCase struct {
 		name                            string
@@ -981,3 +1136,245 @@ func TestMakeMatcher_SampleAreaMatching_Success(t *testing.T) {
 		})
 	}
 }
+
+func TestMakeMatcher_SSIMMatching_Success(t *testing.T) {
+	test := func(name string, optionalKeys map[string]string, want ssim.Matcher) {
+		t.Run(name, func(t *testing.T) {
+			optionalKeys[AlgorithmNameOptKey] = string(SSIMMatching)
+
+			algorithmName, matcher, err := MakeMatcher(optionalKeys)
+
+			assert.NoError(t, err)
+			assert.Equal(t, SSIMMatching, algorithmName)
+			assert.Equal(t, &want, matcher)
+		})
+	}
+
+	test("window size: missing, uses default", map[string]string{
+		string(SSIMMinScore): "0.95",
+	}, ssim.Matcher{WindowSize: ssim.DefaultWindowSize, MinScore: 0.95})
+	test("window size: value = lower limit", map[string]string{
+		string(SSIMWindowSize): "1",
+		string(SSIMMinScore):   "0.95",
+	}, ssim.Matcher{WindowSize: 1, MinScore: 0.95})
+	test("min score: value = lower limit", map[string]string{
+		string(SSIMWindowSize): "11",
+		string(SSIMMinScore):   "0",
+	}, ssim.Matcher{WindowSize: 11, MinScore: 0})
+	test("min score: value = upper limit", map[string]string{
+		string(SSIMWindowSize): "11",
+		string(SSIMMinScore):   "1",
+	}, ssim.Matcher{WindowSize: 11, MinScore: 1})
+}
+
+func TestMakeMatcher_SSIMMatching_Error(t *testing.T) {
+	test := func(name string, optionalKeys map[string]string, wantErr string) {
+		t.Run(name, func(t *testing.T) {
+			optionalKeys[AlgorithmNameOptKey] = string(SSIMMatching)
+
+			_, _, err := MakeMatcher(optionalKeys)
+
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), wantErr)
+		})
+	}
+
+	test("min score: missing", map[string]string{},
+		`required image matching parameter not found: "ssim_min_score"`)
+	test("min score: empty", map[string]string{string(SSIMMinScore): ""},
+		`image matching parameter "ssim_min_score" cannot be empty`)
+	test("min score: not a number", map[string]string{string(SSIMMinScore): "high"},
+		"invalid syntax")
+	test("min score: NaN", map[string]string{string(SSIMMinScore): "NaN"},
+		`image matching parameter "ssim_min_score" must be between 0 and 1, was: NaN`)
+	test("min score: value > upper limit", map[string]string{string(SSIMMinScore): "1.5"},
+		`image matching parameter "ssim_min_score" must be between 0 and 1, was: 1.5`)
+	test("min score: value < lower limit", map[string]string{string(SSIMMinScore): "-0.1"},
+		`image matching parameter "ssim_min_score" must be between 0 and 1, was: -0.1`)
+	test("window size: value < lower limit", map[string]string{string(SSIMWindowSize): "0", string(SSIMMinScore): "0.9"},
+		`image matching parameter "ssim_window_size" must be at least 1, was: 0`)
+}
+
+func TestMakeMatcher_DownscaleFuzzyMatching_Success(t *testing.T) {
+	test := func(name string, optionalKeys map[string]string, want downscale.Matcher) {
+		t.Run(name, func(t *testing.T) {
+			optionalKeys[AlgorithmNameOptKey] = string(DownscaleFuzzyMatching)
+
+			algorithmName, matcher, err := MakeMatcher(optionalKeys)
+
+			assert.NoError(t, err)
+			assert.Equal(t, DownscaleFuzzyMatching, algorithmName)
+			assert.Equal(t, &want, matcher)
+		})
+	}
+
+	test("downscale factor: value = lower limit", map[string]string{
+		string(DownscaleFactor):    "1",
+		string(MaxDifferentPixels): "10",
+	}, downscale.Matcher{Matcher: fuzzy.Matcher{MaxDifferentPixels: 10}, DownscaleFactor: 1})
+	test("fuzzy parameters are passed to the embedded fuzzy.Matcher", map[string]string{
+		string(DownscaleFactor):               "4",
+		string(MaxDifferentPixels):            "10",
+		string(PixelPerChannelDeltaThreshold): "20",
+		string(IgnoredBorderThickness):        "1",
+	}, downscale.Matcher{
+		Matcher: fuzzy.Matcher{
+			MaxDifferentPixels:            10,
+			PixelPerChannelDeltaThreshold: 20,
+			IgnoredBorderThickness:        1,
+		},
+		DownscaleFactor: 4,
+	})
+}
+
+func TestMakeMatcher_DownscaleFuzzyMatching_Error(t *testing.T) {
+	test := func(name string, optionalKeys map[string]string, wantErr string) {
+		t.Run(name, func(t *testing.T) {
+			optionalKeys[AlgorithmNameOptKey] = string(DownscaleFuzzyMatching)
+
+			_, _, err := MakeMatcher(optionalKeys)
+
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), wantErr)
+		})
+	}
+
+	test("downscale factor: missing", map[string]string{string(MaxDifferentPixels): "10"},
+		`required image matching parameter not found: "downscale_factor"`)
+	test("downscale factor: not an integer", map[string]string{string(DownscaleFactor): "1.5", string(MaxDifferentPixels): "10"},
+		"invalid syntax")
+	test("downscale factor: value < lower limit", map[string]string{string(DownscaleFactor): "0", string(MaxDifferentPixels): "10"},
+		`image matching parameter "downscale_factor" must be at least 1, was: 0`)
+	test("fuzzy parameters: missing", map[string]string{string(DownscaleFactor): "2"},
+		`required image matching parameter not found: "fuzzy_max_different_pixels"`)
+}
+
+func TestMakeMatcher_IgnoredRegions_Success(t *testing.T) {
+	maskFile := filepath.Join(t.TempDir(), "mask.png")
+	mask := image.NewGray(image.Rect(0, 0, 4, 4))
+	f, err := os.Create(maskFile)
+	require.NoError(t, err)
+	require.NoError(t, png.Encode(f, mask))
+	require.NoError(t, f.Close())
+
+	algorithmName, matcher, err := MakeMatcher(map[string]string{
+		AlgorithmNameOptKey:            string(FuzzyMatching),
+		string(MaxDifferentPixels):     "0",
+		string(PixelDeltaThreshold):    "0",
+		string(IgnoredRegions):         "0,0,10,20; 5,5,6,6",
+		string(IgnoredRegionsMaskFile): maskFile,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, FuzzyMatching, algorithmName)
+	maskedMatcher, ok := matcher.(*masked.Matcher)
+	require.True(t, ok)
+	assert.Equal(t, &fuzzy.Matcher{}, maskedMatcher.Delegate)
+	assert.Equal(t, []image.Rectangle{image.Rect(0, 0, 10, 20), image.Rect(5, 5, 6, 6)}, maskedMatcher.IgnoredRegions)
+	assert.Equal(t, mask.Bounds(), maskedMatcher.Mask.Bounds())
+}
+
+func TestMakeMatcher_IgnoredRegions_Error(t *testing.T) {
+	test := func(name string, optionalKeys map[string]string, wantErr string) {
+		t.Run(name, func(t *testing.T) {
+			_, _, err := MakeMatcher(optionalKeys)
+
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), wantErr)
+		})
+	}
+
+	fuzzyKeys := func(key AlgorithmParamOptKey, value string) map[string]string {
+		return map[string]string{
+			AlgorithmNameOptKey:         string(FuzzyMatching),
+			string(MaxDifferentPixels):  "0",
+			string(PixelDeltaThreshold): "0",
+			string(key):                 value,
+		}
+	}
+
+	test("exact matching", map[string]string{string(IgnoredRegions): "0,0,1,1"},
+		`image matching algorithm "exact" does not support ignored regions`)
+	test("positive if only image", map[string]string{
+		AlgorithmNameOptKey:    string(PositiveIfOnlyImageMatching),
+		string(IgnoredRegions): "0,0,1,1",
+	}, `image matching algorithm "positive_if_only_image" does not support ignored regions`)
+	test("regions: empty", fuzzyKeys(IgnoredRegions, ""),
+		`image matching parameter "ignored_regions" cannot be empty`)
+	test("regions: too few coordinates", fuzzyKeys(IgnoredRegions, "0,0,1"),
+		`invalid rectangle "0,0,1"; expected x0,y0,x1,y1`)
+	test("regions: not a number", fuzzyKeys(IgnoredRegions, "0,0,1,foo"),
+		"invalid syntax")
+	test("regions: empty rectangle", fuzzyKeys(IgnoredRegions, "0,0,1,1;5,5,5,6"),
+		`invalid rectangle "5,5,5,6": x0 must be less than x1, and y0 must be less than y1`)
+	test("mask file: empty", fuzzyKeys(IgnoredRegionsMaskFile, ""),
+		`image matching parameter "ignored_regions_mask_file" cannot be empty`)
+	test("mask file: not found", fuzzyKeys(IgnoredRegionsMaskFile, filepath.Join(t.TempDir(), "nonexistent.png")),
+		"reading mask image")
+}
+
+func TestMakeMatcher_MaxSizeDelta_Success(t *testing.T) {
+	algorithmName, matcher, err := MakeMatcher(map[string]string{
+		AlgorithmNameOptKey:         string(FuzzyMatching),
+		string(MaxDifferentPixels):  "0",
+		string(PixelDeltaThreshold): "0",
+		string(MaxSizeDelta):        "1",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, FuzzyMatching, algorithmName)
+	assert.Equal(t, &cropped.Matcher{Delegate: &fuzzy.Matcher{}, MaxSizeDelta: 1}, matcher)
+}
+
+func TestMakeMatcher_MaxSizeDeltaAndIgnoredRegions_CroppedMatcherWrapsMaskedMatcher(t *testing.T) {
+	algorithmName, matcher, err := MakeMatcher(map[string]string{
+		AlgorithmNameOptKey:         string(FuzzyMatching),
+		string(MaxDifferentPixels):  "0",
+		string(PixelDeltaThreshold): "0",
+		string(IgnoredRegions):      "0,0,1,1",
+		string(MaxSizeDelta):        "2",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, FuzzyMatching, algorithmName)
+	assert.Equal(t, &cropped.Matcher{
+		Delegate: &masked.Matcher{
+			Delegate:       &fuzzy.Matcher{},
+			IgnoredRegions: []image.Rectangle{image.Rect(0, 0, 1, 1)},
+		},
+		MaxSizeDelta: 2,
+	}, matcher)
+}
+
+func TestMakeMatcher_MaxSizeDelta_Error(t *testing.T) {
+	test := func(name string, optionalKeys map[string]string, wantErr string) {
+		t.Run(name, func(t *testing.T) {
+			_, _, err := MakeMatcher(optionalKeys)
+
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), wantErr)
+		})
+	}
+
+	fuzzyKeys := func(value string) map[string]string {
+		return map[string]string{
+			AlgorithmNameOptKey:         string(FuzzyMatching),
+			string(MaxDifferentPixels):  "0",
+			string(PixelDeltaThreshold): "0",
+			string(MaxSizeDelta):        value,
+		}
+	}
+
+	test("exact matching", map[string]string{string(MaxSizeDelta): "1"},
+		`image matching algorithm "exact" does not support a size tolerance`)
+	test("positive if only image", map[string]string{
+		AlgorithmNameOptKey:  string(PositiveIfOnlyImageMatching),
+		string(MaxSizeDelta): "1",
+	}, `image matching algorithm "positive_if_only_image" does not support a size tolerance`)
+	test("empty", fuzzyKeys(""),
+		`image matching parameter "max_size_delta" cannot be empty`)
+	test("not an integer", fuzzyKeys("1.5"),
+		"invalid syntax")
+	test("negative", fuzzyKeys("-1"),
+		`image matching parameter "max_size_delta" must be at least 0, was: -1`)
+}
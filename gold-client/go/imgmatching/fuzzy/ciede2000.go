@@ -0,0 +1,130 @@
+package fuzzy
+
+import (
+	"image/color"
+	"math"
+)
+
+// srgbToLinear maps 8-bit sRGB channel values to linear RGB values between 0 and 1.
+var srgbToLinear = func() [256]float64 {
+	var table [256]float64
+	for i := range table {
+		c := float64(i) / 255
+		if c <= 0.04045 {
+			table[i] = c / 12.92
+		} else {
+			table[i] = math.Pow((c+0.055)/1.055, 2.4)
+		}
+	}
+	return table
+}()
+
+// lab is a color in the CIELAB color space.
+type lab struct {
+	l, a, b float64
+}
+
+// toLab converts the R, G and B channels of the given color, interpreted as sRGB, into the CIELAB
+// color space using the D65 reference white. The alpha channel is ignored.
+func toLab(c color.NRGBA) lab {
+	r, g, b := srgbToLinear[c.R], srgbToLinear[c.G], srgbToLinear[c.B]
+
+	// Linear sRGB to CIEXYZ, normalized by the D65 reference white.
+	x := (0.4124564*r + 0.3575761*g + 0.1804375*b) / 0.95047
+	y := 0.2126729*r + 0.7151522*g + 0.0721750*b
+	z := (0.0193339*r + 0.1191920*g + 0.9503041*b) / 1.08883
+
+	f := func(t float64) float64 {
+		const delta = 6.0 / 29.0
+		if t > delta*delta*delta {
+			return math.Cbrt(t)
+		}
+		return t/(3*delta*delta) + 4.0/29.0
+	}
+	fx, fy, fz := f(x), f(y), f(z)
+	return lab{l: 116*fy - 16, a: 500 * (fx - fy), b: 200 * (fy - fz)}
+}
+
+// ciede2000 returns the CIEDE2000 color difference[1] between the two given colors, with the
+// parametric weighting factors kL, kC and kH set to 1.
+//
+// [1] G. Sharma, W. Wu, E. N. Dalal, "The CIEDE2000 color-difference formula: Implementation
+// notes, supplementary test data, and mathematical observations", Color Research & Application,
+// 30(1), 2005.
+func ciede2000(lab1, lab2 lab) float64 {
+	const pow25To7 = 6103515625.0 // 25^7
+
+	c1 := math.Hypot(lab1.a, lab1.b)
+	c2 := math.Hypot(lab2.a, lab2.b)
+	cBar7 := math.Pow((c1+c2)/2, 7)
+	g := 0.5 * (1 - math.Sqrt(cBar7/(cBar7+pow25To7)))
+
+	a1Prime := (1 + g) * lab1.a
+	a2Prime := (1 + g) * lab2.a
+	c1Prime := math.Hypot(a1Prime, lab1.b)
+	c2Prime := math.Hypot(a2Prime, lab2.b)
+	h1Prime := hueAngle(a1Prime, lab1.b)
+	h2Prime := hueAngle(a2Prime, lab2.b)
+
+	deltaLPrime := lab2.l - lab1.l
+	deltaCPrime := c2Prime - c1Prime
+	var deltahPrime float64
+	if c1Prime*c2Prime != 0 {
+		deltahPrime = h2Prime - h1Prime
+		if deltahPrime > 180 {
+			deltahPrime -= 360
+		} else if deltahPrime < -180 {
+			deltahPrime += 360
+		}
+	}
+	deltaHPrime := 2 * math.Sqrt(c1Prime*c2Prime) * math.Sin(degreesToRadians(deltahPrime/2))
+
+	lBarPrime := (lab1.l + lab2.l) / 2
+	cBarPrime := (c1Prime + c2Prime) / 2
+	hBarPrime := h1Prime + h2Prime
+	if c1Prime*c2Prime != 0 {
+		if math.Abs(h1Prime-h2Prime) <= 180 {
+			hBarPrime /= 2
+		} else if hBarPrime < 360 {
+			hBarPrime = (hBarPrime + 360) / 2
+		} else {
+			hBarPrime = (hBarPrime - 360) / 2
+		}
+	}
+
+	t := 1 -
+		0.17*math.Cos(degreesToRadians(hBarPrime-30)) +
+		0.24*math.Cos(degreesToRadians(2*hBarPrime)) +
+		0.32*math.Cos(degreesToRadians(3*hBarPrime+6)) -
+		0.20*math.Cos(degreesToRadians(4*hBarPrime-63))
+	deltaTheta := 30 * math.Exp(-math.Pow((hBarPrime-275)/25, 2))
+	cBarPrime7 := math.Pow(cBarPrime, 7)
+	rC := 2 * math.Sqrt(cBarPrime7/(cBarPrime7+pow25To7))
+	lBarMinus50Squared := (lBarPrime - 50) * (lBarPrime - 50)
+	sL := 1 + 0.015*lBarMinus50Squared/math.Sqrt(20+lBarMinus50Squared)
+	sC := 1 + 0.045*cBarPrime
+	sH := 1 + 0.015*cBarPrime*t
+	rT := -math.Sin(degreesToRadians(2*deltaTheta)) * rC
+
+	lTerm := deltaLPrime / sL
+	cTerm := deltaCPrime / sC
+	hTerm := deltaHPrime / sH
+	return math.Sqrt(lTerm*lTerm + cTerm*cTerm + hTerm*hTerm + rT*cTerm*hTerm)
+}
+
+// hueAngle returns the hue angle in degrees, between 0 and 360, of the given a and b coordinates.
+func hueAngle(a, b float64) float64 {
+	if a == 0 && b == 0 {
+		return 0
+	}
+	h := math.Atan2(b, a) * 180 / math.Pi
+	if h < 0 {
+		h += 360
+	}
+	return h
+}
+
+// degreesToRadians converts the given angle in degrees to radians.
+func degreesToRadians(degrees float64) float64 {
+	return degrees * math.Pi / 180
+}
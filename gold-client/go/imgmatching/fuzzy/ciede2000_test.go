@@ -0,0 +1,47 @@
+package fuzzy
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCIEDE2000_SharmaTestData_Success(t *testing.T) {
+	// Test data from Table 1 in G. Sharma, W. Wu, E. N. Dalal, "The CIEDE2000 color-difference
+	// formula: Implementation notes, supplementary test data, and mathematical observations".
+	test := func(lab1, lab2 lab, expected float64) {
+		assert.InDelta(t, expected, ciede2000(lab1, lab2), 0.0001, "%v vs. %v", lab1, lab2)
+		assert.InDelta(t, expected, ciede2000(lab2, lab1), 0.0001, "%v vs. %v", lab2, lab1)
+	}
+
+	test(lab{50, 2.6772, -79.7751}, lab{50, 0, -82.7485}, 2.0425)
+	test(lab{50, -1.3802, -84.2814}, lab{50, 0, -82.7485}, 1.0000)
+	test(lab{50, 0, 0}, lab{50, -1, 2}, 2.3669)
+	test(lab{50, -1, 2}, lab{50, 0, 0}, 2.3669)
+	test(lab{50, 2.49, -0.001}, lab{50, -2.49, 0.0011}, 7.2195)
+	test(lab{50, 2.5, 0}, lab{73, 25, -18}, 27.1492)
+	test(lab{50, 2.5, 0}, lab{50, 3.2592, 0.335}, 1.0000)
+	test(lab{60.2574, -34.0099, 36.2677}, lab{60.4626, -34.1751, 39.4387}, 1.2644)
+	test(lab{22.7233, 20.0904, -46.694}, lab{23.0331, 14.973, -42.5619}, 2.0373)
+	test(lab{90.9257, -0.5406, -0.9208}, lab{88.6381, -0.8985, -0.7239}, 1.5381)
+	test(lab{2.0776, 0.0795, -1.135}, lab{0.9033, -0.0636, -0.5514}, 0.9082)
+}
+
+func TestToLab_ReferenceColors_Success(t *testing.T) {
+	test := func(c color.NRGBA, expected lab) {
+		actual := toLab(c)
+		assert.InDelta(t, expected.l, actual.l, 0.01, "L for %v", c)
+		assert.InDelta(t, expected.a, actual.a, 0.01, "a for %v", c)
+		assert.InDelta(t, expected.b, actual.b, 0.01, "b for %v", c)
+	}
+
+	test(color.NRGBA{R: 0x00, G: 0x00, B: 0x00, A: 0xFF}, lab{0, 0, 0})
+	test(color.NRGBA{R: 0xFF, G: 0xFF, B: 0xFF, A: 0xFF}, lab{100, 0, 0})
+	test(color.NRGBA{R: 0xFF, G: 0x00, B: 0x00, A: 0xFF}, lab{53.24, 80.09, 67.20})
+	test(color.NRGBA{R: 0x00, G: 0xFF, B: 0x00, A: 0xFF}, lab{87.73, -86.18, 83.18})
+	test(color.NRGBA{R: 0x00, G: 0x00, B: 0xFF, A: 0xFF}, lab{32.30, 79.19, -107.86})
+
+	// Alpha is ignored.
+	test(color.NRGBA{R: 0xFF, G: 0xFF, B: 0xFF, A: 0x00}, lab{100, 0, 0})
+}
@@ -2,8 +2,57 @@ package fuzzy
 
 import (
 	"image"
+	"image/color"
 	"image/draw"
-	"math"
+
+	"go.skia.org/infra/gold-client/go/imgmatching/debugartifacts"
+	"go.skia.org/infra/gold-client/go/imgmatching/internal/rowbands"
+)
+
+// AlphaMode determines how the alpha channel is taken into account when comparing two pixels.
+type AlphaMode string
+
+const (
+	// AlphaModeDefault compares the R, G, B and A channels of both pixels as they are, i.e. without
+	// premultiplying the color channels by alpha.
+	AlphaModeDefault = AlphaMode("")
+
+	// AlphaModeIgnoreTransparentPixels is like AlphaModeDefault, except that two fully transparent
+	// pixels (i.e. A = 0) are considered identical regardless of their R, G and B channels.
+	AlphaModeIgnoreTransparentPixels = AlphaMode("ignore_transparent_pixels")
+
+	// AlphaModePremultiply premultiplies the R, G and B channels of both pixels by their alpha before
+	// comparing them, so that color differences are weighted by the opacity of the pixels.
+	AlphaModePremultiply = AlphaMode("premultiply")
+)
+
+// PixelDeltaMetric determines how the difference between two pixels is measured.
+type PixelDeltaMetric string
+
+const (
+	// PixelDeltaMetricDefault uses PixelDeltaMetricMaxChannel if PixelPerChannelDeltaThreshold > 0,
+	// or PixelDeltaMetricSum otherwise.
+	PixelDeltaMetricDefault = PixelDeltaMetric("")
+
+	// PixelDeltaMetricSum measures the difference between two pixels as dR + dG + dB + dA, where
+	// d{R,G,B,A} are the per-channel deltas, and compares it against PixelDeltaThreshold.
+	PixelDeltaMetricSum = PixelDeltaMetric("sum")
+
+	// PixelDeltaMetricMaxChannel measures the difference between two pixels as
+	// max(dR, dG, dB, dA), where d{R,G,B,A} are the per-channel deltas, and compares it against
+	// PixelPerChannelDeltaThreshold.
+	PixelDeltaMetricMaxChannel = PixelDeltaMetric("max_channel")
+
+	// PixelDeltaMetricCIEDE2000 measures the difference between two pixels as the CIEDE2000 color
+	// difference[1] of their R, G and B channels in the CIELAB color space, which approximates
+	// perceived differences much better than per-channel deltas, and compares it against
+	// CIEDE2000Threshold. As a rule of thumb, differences below 1 are not perceptible by humans.
+	//
+	// The alpha channel is not taken into account by this metric; consider using
+	// AlphaModePremultiply to weight color differences by the opacity of the pixels.
+	//
+	// [1] https://en.wikipedia.org/wiki/Color_difference#CIEDE2000
+	PixelDeltaMetricCIEDE2000 = PixelDeltaMetric("ciede2000")
 )
 
 // Matcher is an image matching algorithm.
@@ -16,8 +65,12 @@ import (
 //     dR + dG + dB + dA > PixelDeltaThreshold, where d{R,G,B,A} are the per-channel deltas.
 //   - Else: There are no pixels such that max(dR, dG, dB, dA) > PixelPerChannelDeltaThreshold,
 //     where d{R,G,B,A} are the per-channel deltas.
+//   - The two conditions above can be replaced with a different way of measuring pixel
+//     differences by setting PixelDeltaMetric (see the PixelDeltaMetric constants).
 //   - If IgnoredBorderThickness > 0, then the first/last IgnoredBorderThickness rows/columns will
 //     be ignored when performing the above pixel-wise comparisons.
+//   - Pixels are compared according to AlphaMode (see the AlphaMode constants), which defaults to
+//     comparing all four channels as they are.
 //
 // Note that if MaxDifferentPixels = 0 this algorithm will perform an exact image comparison. If
 // that is intentional, consider using exact matching instead (e.g. by not specifying the
@@ -29,15 +82,21 @@ import (
 // 0 <= dR + dG + dB + dA <= 255*4 = 1020).
 //
 // Valid PixelPerChannelDelta values are 0 to 255 inclusive.
+//
+// Valid CIEDE2000Threshold values are 0 to 100 inclusive.
 type Matcher struct {
 	MaxDifferentPixels            int
 	PixelDeltaThreshold           int
 	PixelPerChannelDeltaThreshold int
+	CIEDE2000Threshold            float64
 	IgnoredBorderThickness        int
+	AlphaMode                     AlphaMode
+	PixelDeltaMetric              PixelDeltaMetric
 
 	// Debug information about the last pair of matched images.
 	actualNumDifferentPixels int
 	actualMaxPixelDelta      int
+	actualMaxCIEDE2000Delta  float64
 }
 
 // Match implements the imagmatching.Matcher interface.
@@ -52,12 +111,8 @@ func (m *Matcher) Match(expected, actual image.Image) bool {
 		return false
 	}
 
-	// Determine which delta threshold we will be using. We assume that at most one of
-	// PixelDeltaThreshold and PixelPerChannelDeltaThreshold will be set.
-	usePerChannelThreshold := false
-	if m.PixelPerChannelDeltaThreshold > 0 {
-		usePerChannelThreshold = true
-	}
+	// Determine which pixel delta metric we will be using.
+	metric := m.pixelDeltaMetric()
 
 	// Convert both images to NRGBA.
 	bounds := expected.Bounds()
@@ -69,32 +124,26 @@ func (m *Matcher) Match(expected, actual image.Image) bool {
 	// Reset counters.
 	m.actualNumDifferentPixels = 0
 	m.actualMaxPixelDelta = 0
+	m.actualMaxCIEDE2000Delta = 0
 
-	// Iterate over all pixels, with the exception of the ignored border pixels.
+	// Iterate over all pixels, with the exception of the ignored border pixels. Rows are split into
+	// bands which are compared concurrently, and the per-band results are combined afterwards.
 	b := m.IgnoredBorderThickness
-	for x := (bounds.Min.X + b); x < (bounds.Max.X - b); x++ {
-		for y := (bounds.Min.Y + b); y < (bounds.Max.Y - b); y++ {
-			p1 := expectedNRGBA.NRGBAAt(x, y)
-			p2 := actualNRGBA.NRGBAAt(x, y)
-
-			// Track number of different pixels.
-			if p1 != p2 {
-				m.actualNumDifferentPixels++
-			}
-
-			// Track maximum pixel-wise difference.
-			var pixelDelta int
-			if usePerChannelThreshold {
-				pixelDelta = absDiff(p1.R, p2.R)
-				pixelDelta = int(math.Max(float64(pixelDelta), float64(absDiff(p1.G, p2.G))))
-				pixelDelta = int(math.Max(float64(pixelDelta), float64(absDiff(p1.B, p2.B))))
-				pixelDelta = int(math.Max(float64(pixelDelta), float64(absDiff(p1.A, p2.A))))
-			} else {
-				pixelDelta = absDiff(p1.R, p2.R) + absDiff(p1.G, p2.G) + absDiff(p1.B, p2.B) + absDiff(p1.A, p2.A)
-			}
-			if pixelDelta > m.actualMaxPixelDelta {
-				m.actualMaxPixelDelta = pixelDelta
-			}
+	comparedArea := image.Rectangle{
+		Min: image.Pt(bounds.Min.X+b, bounds.Min.Y+b),
+		Max: image.Pt(bounds.Max.X-b, bounds.Max.Y-b),
+	}
+	bandResults := make([]bandResult, rowbands.NumBands(comparedArea))
+	rowbands.ForEach(comparedArea, func(i int, band image.Rectangle) {
+		bandResults[i] = compareBand(expectedNRGBA, actualNRGBA, band, metric, m.AlphaMode)
+	})
+	for _, r := range bandResults {
+		m.actualNumDifferentPixels += r.numDifferentPixels
+		if r.maxPixelDelta > m.actualMaxPixelDelta {
+			m.actualMaxPixelDelta = r.maxPixelDelta
+		}
+		if r.maxCIEDE2000Delta > m.actualMaxCIEDE2000Delta {
+			m.actualMaxCIEDE2000Delta = r.maxCIEDE2000Delta
 		}
 	}
 
@@ -104,17 +153,27 @@ func (m *Matcher) Match(expected, actual image.Image) bool {
 	}
 
 	// Pixel-wise differences must be below the given threshold.
-	if usePerChannelThreshold {
-		if m.actualMaxPixelDelta > m.PixelPerChannelDeltaThreshold {
-			return false
-		}
-	} else {
-		if m.actualMaxPixelDelta > m.PixelDeltaThreshold {
-			return false
-		}
+	switch metric {
+	case PixelDeltaMetricCIEDE2000:
+		return m.actualMaxCIEDE2000Delta <= m.CIEDE2000Threshold
+	case PixelDeltaMetricMaxChannel:
+		return m.actualMaxPixelDelta <= m.PixelPerChannelDeltaThreshold
+	default:
+		return m.actualMaxPixelDelta <= m.PixelDeltaThreshold
 	}
+}
 
-	return true
+// pixelDeltaMetric returns the pixel delta metric to use, resolving PixelDeltaMetricDefault.
+func (m *Matcher) pixelDeltaMetric() PixelDeltaMetric {
+	if m.PixelDeltaMetric != PixelDeltaMetricDefault {
+		return m.PixelDeltaMetric
+	}
+	// We assume that at most one of PixelDeltaThreshold and PixelPerChannelDeltaThreshold will be
+	// set.
+	if m.PixelPerChannelDeltaThreshold > 0 {
+		return PixelDeltaMetricMaxChannel
+	}
+	return PixelDeltaMetricSum
 }
 
 // NumDifferentPixels returns the number of different pixels between the last two matched images.
@@ -123,15 +182,108 @@ func (m *Matcher) NumDifferentPixels() int { return m.actualNumDifferentPixels }
 // MaxPixelDelta returns the maximum per-channel delta sum between the last two matched images.
 func (m *Matcher) MaxPixelDelta() int { return m.actualMaxPixelDelta }
 
+// MaxCIEDE2000Delta returns the maximum CIEDE2000 color difference between the last two matched
+// images. It is only computed if PixelDeltaMetric is PixelDeltaMetricCIEDE2000.
+func (m *Matcher) MaxCIEDE2000Delta() float64 { return m.actualMaxCIEDE2000Delta }
+
+// DebugArtifacts implements the imgmatching.DebugArtifactsProvider interface.
+func (m *Matcher) DebugArtifacts() debugartifacts.Bundle {
+	b := debugartifacts.New()
+	b.Metrics["num_different_pixels"] = float64(m.actualNumDifferentPixels)
+	if m.PixelDeltaMetric == PixelDeltaMetricCIEDE2000 {
+		b.Metrics["max_ciede2000_delta"] = m.actualMaxCIEDE2000Delta
+	} else {
+		b.Metrics["max_pixel_delta"] = float64(m.actualMaxPixelDelta)
+	}
+	return b
+}
+
 // PixelComparisonMethod returns whether pixel comparison is being done using
 // the sum of per-channel differences or the max per-channel difference.
 func (m *Matcher) PixelComparisonMethod() string {
+	switch m.PixelDeltaMetric {
+	case PixelDeltaMetricSum:
+		return "pixel delta threshold"
+	case PixelDeltaMetricMaxChannel:
+		return "pixel per-channel delta threshold"
+	case PixelDeltaMetricCIEDE2000:
+		return "CIEDE2000 color difference threshold"
+	}
 	if m.PixelDeltaThreshold > 0 {
 		return "pixel delta threshold"
 	}
 	return "pixel per-channel delta threshold"
 }
 
+// bandResult holds the result of comparing a band of rows of two images.
+type bandResult struct {
+	numDifferentPixels int
+	maxPixelDelta      int
+	maxCIEDE2000Delta  float64
+}
+
+// compareBand compares the pixels of the two given images within the given band.
+func compareBand(expected, actual *image.NRGBA, band image.Rectangle, metric PixelDeltaMetric, alphaMode AlphaMode) bandResult {
+	var r bandResult
+	for y := band.Min.Y; y < band.Max.Y; y++ {
+		for x := band.Min.X; x < band.Max.X; x++ {
+			p1 := expected.NRGBAAt(x, y)
+			p2 := actual.NRGBAAt(x, y)
+
+			switch alphaMode {
+			case AlphaModeIgnoreTransparentPixels:
+				if p1.A == 0 && p2.A == 0 {
+					continue
+				}
+			case AlphaModePremultiply:
+				p1 = premultiply(p1)
+				p2 = premultiply(p2)
+			}
+
+			// Track number of different pixels.
+			if p1 != p2 {
+				r.numDifferentPixels++
+			}
+
+			// Track maximum pixel-wise difference.
+			switch metric {
+			case PixelDeltaMetricCIEDE2000:
+				// Converting to CIELAB is expensive, so we skip pixels with identical colors.
+				if p1.R != p2.R || p1.G != p2.G || p1.B != p2.B {
+					if delta := ciede2000(toLab(p1), toLab(p2)); delta > r.maxCIEDE2000Delta {
+						r.maxCIEDE2000Delta = delta
+					}
+				}
+			case PixelDeltaMetricMaxChannel:
+				r.maxPixelDelta = maxInt(r.maxPixelDelta, absDiff(p1.R, p2.R), absDiff(p1.G, p2.G), absDiff(p1.B, p2.B), absDiff(p1.A, p2.A))
+			default:
+				r.maxPixelDelta = maxInt(r.maxPixelDelta, absDiff(p1.R, p2.R)+absDiff(p1.G, p2.G)+absDiff(p1.B, p2.B)+absDiff(p1.A, p2.A))
+			}
+		}
+	}
+	return r
+}
+
+// premultiply returns the given pixel with its R, G and B channels multiplied by its alpha, rounded
+// to the nearest integer.
+func premultiply(p color.NRGBA) color.NRGBA {
+	mul := func(c uint8) uint8 {
+		return uint8((uint32(c)*uint32(p.A) + 127) / 255)
+	}
+	return color.NRGBA{R: mul(p.R), G: mul(p.G), B: mul(p.B), A: p.A}
+}
+
+// maxInt returns the largest of the given values.
+func maxInt(first int, rest ...int) int {
+	m := first
+	for _, v := range rest {
+		if v > m {
+			m = v
+		}
+	}
+	return m
+}
+
 // absDiff takes two uint8 values m and n, computes |m - n|, and converts the result into an int
 // suitable for addition without the risk of overflowing.
 func absDiff(m, n uint8) int {
@@ -2,6 +2,7 @@ package fuzzy
 
 import (
 	"image"
+	"image/color"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -1173,3 +1174,130 @@ func TestMatcher_NonZeroIgnoredBorderThickness_Success(t *testing.T) {
 		}
 	})
 }
+
+func TestMatcher_AlphaMode_Success(t *testing.T) {
+
+	// The top row differs only in the color of fully transparent pixels, and the bottom row differs
+	// in the color of nearly transparent pixels.
+	image1 := text.MustToNRGBA(`! SKTEXTSIMPLE
+	2 2
+	0xFF000000 0x00000000
+	0xFF000002 0x00000002`)
+	image2 := text.MustToNRGBA(`! SKTEXTSIMPLE
+	2 2
+	0x00FF0000 0x00000000
+	0x00000002 0x00000002`)
+
+	test := func(name string, alphaMode AlphaMode, expectedToMatch bool, expectedNumDifferentPixels, expectedMaxPixelDelta int) {
+		runTestCases(t, []testCase{
+			{
+				name:                       name,
+				image1:                     image1,
+				image2:                     image2,
+				expectedToMatch:            expectedToMatch,
+				expectedNumDifferentPixels: expectedNumDifferentPixels,
+				expectedMaxPixelDelta:      expectedMaxPixelDelta,
+			},
+		}, func() Matcher {
+			return Matcher{
+				MaxDifferentPixels:  1,
+				PixelDeltaThreshold: 10,
+				AlphaMode:           alphaMode,
+			}
+		})
+	}
+
+	test("default, all channels compared", AlphaModeDefault, false, 2, 510)
+	test("ignore transparent pixels, only nearly transparent pixel differs", AlphaModeIgnoreTransparentPixels, false, 1, 255)
+	test("premultiply, nearly transparent pixel differs slightly", AlphaModePremultiply, true, 1, 2)
+}
+
+func TestMatcher_PixelDeltaMetric_Success(t *testing.T) {
+	image1 := text.MustToNRGBA(`! SKTEXTSIMPLE
+	2 1
+	0x0000FFFF 0x808080FF`)
+	// Both images differ from image1 by 8 in a single channel of a single pixel, but the difference
+	// is much less perceptible in the saturated blue pixel than in the gray one.
+	blueDiffers := text.MustToNRGBA(`! SKTEXTSIMPLE
+	2 1
+	0x0000F7FF 0x808080FF`)
+	grayDiffers := text.MustToNRGBA(`! SKTEXTSIMPLE
+	2 1
+	0x0000FFFF 0x808880FF`)
+
+	test := func(name string, metric PixelDeltaMetric, image2 image.Image, expectedToMatch bool, expectedMaxPixelDelta int) {
+		runTestCases(t, []testCase{
+			{
+				name:                       name,
+				image1:                     image1,
+				image2:                     image2,
+				expectedToMatch:            expectedToMatch,
+				expectedNumDifferentPixels: 1,
+				expectedMaxPixelDelta:      expectedMaxPixelDelta,
+			},
+		}, func() Matcher {
+			return Matcher{
+				MaxDifferentPixels:            1,
+				PixelDeltaThreshold:           8,
+				PixelPerChannelDeltaThreshold: 8,
+				CIEDE2000Threshold:            2,
+				PixelDeltaMetric:              metric,
+			}
+		})
+	}
+
+	test("sum, blue pixel differs", PixelDeltaMetricSum, blueDiffers, true, 8)
+	test("max channel, gray pixel differs", PixelDeltaMetricMaxChannel, grayDiffers, true, 8)
+	test("ciede2000, blue pixel differs", PixelDeltaMetricCIEDE2000, blueDiffers, true, 0)
+	test("ciede2000, gray pixel differs", PixelDeltaMetricCIEDE2000, grayDiffers, false, 0)
+
+	m := Matcher{MaxDifferentPixels: 1, CIEDE2000Threshold: 2, PixelDeltaMetric: PixelDeltaMetricCIEDE2000}
+	assert.True(t, m.Match(image1, blueDiffers))
+	assert.InDelta(t, 1.02, m.MaxCIEDE2000Delta(), 0.01)
+	assert.False(t, m.Match(image1, grayDiffers))
+	assert.InDelta(t, 6.80, m.MaxCIEDE2000Delta(), 0.01)
+	assert.Equal(t, map[string]float64{
+		"num_different_pixels": 1,
+		"max_ciede2000_delta":  m.MaxCIEDE2000Delta(),
+	}, m.DebugArtifacts().Metrics)
+	assert.Equal(t, "CIEDE2000 color difference threshold", m.PixelComparisonMethod())
+}
+
+// BenchmarkMatcher_Match_4KImages compares two 3840x2160 images, which are processed concurrently
+// in bands of rows. Run it with e.g. -cpu=1,2,4,8 to compare against single-threaded performance.
+func BenchmarkMatcher_Match_4KImages(b *testing.B) {
+	bounds := image.Rect(0, 0, 3840, 2160)
+	expected := image.NewNRGBA(bounds)
+	actual := image.NewNRGBA(bounds)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			c := color.NRGBA{R: uint8(x), G: uint8(y), B: uint8(x + y), A: 0xFF}
+			expected.SetNRGBA(x, y, c)
+			if (x+y)%100 == 0 {
+				c.R++
+			}
+			actual.SetNRGBA(x, y, c)
+		}
+	}
+
+	matcher := Matcher{MaxDifferentPixels: bounds.Dx() * bounds.Dy(), PixelDeltaThreshold: 4}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		matcher.Match(expected, actual)
+	}
+}
+
+func TestMatcher_DebugArtifacts_ReportsMetrics(t *testing.T) {
+	m := Matcher{MaxDifferentPixels: 1, PixelDeltaThreshold: 10}
+	assert.False(t, m.Match(text.MustToNRGBA(`! SKTEXTSIMPLE
+	2 1
+	0x000000ff 0x000000ff`), text.MustToNRGBA(`! SKTEXTSIMPLE
+	2 1
+	0x000000ff 0x102030ff`)))
+
+	assert.Equal(t, map[string]float64{
+		"num_different_pixels": 1,
+		"max_pixel_delta":      0x10 + 0x20 + 0x30,
+	}, m.DebugArtifacts().Metrics)
+	assert.Empty(t, m.DebugArtifacts().Images)
+}
@@ -0,0 +1,60 @@
+// Package rowbands splits per-pixel image computations across goroutines.
+package rowbands
+
+import (
+	"image"
+	"runtime"
+	"sync"
+)
+
+// minRowsPerBand is the minimum number of rows processed by each goroutine. Smaller images are
+// processed with fewer goroutines, or on the calling goroutine, because the cost of spawning
+// goroutines would outweigh the benefits.
+const minRowsPerBand = 64
+
+// ForEach splits the given rectangle into horizontal bands of consecutive rows, and calls fn once
+// for each band, concurrently. It returns after all calls to fn have returned.
+//
+// The number of bands is at most runtime.GOMAXPROCS(0). The bands are disjoint, so fn can safely
+// write to the rows of an image within the band it is given. Bands are numbered from 0 in
+// top-to-bottom order, which callers can use to store per-band results in a slice of length
+// NumBands(r). ForEach does not call fn if r is empty.
+func ForEach(r image.Rectangle, fn func(i int, band image.Rectangle)) {
+	numBands := NumBands(r)
+	if numBands == 0 {
+		return
+	}
+	if numBands == 1 {
+		fn(0, r)
+		return
+	}
+
+	var wg sync.WaitGroup
+	height := r.Dy()
+	for i := 0; i < numBands; i++ {
+		band := r
+		band.Min.Y = r.Min.Y + i*height/numBands
+		band.Max.Y = r.Min.Y + (i+1)*height/numBands
+		wg.Add(1)
+		go func(i int, band image.Rectangle) {
+			defer wg.Done()
+			fn(i, band)
+		}(i, band)
+	}
+	wg.Wait()
+}
+
+// NumBands returns the number of bands into which ForEach splits the given rectangle.
+func NumBands(r image.Rectangle) int {
+	if r.Empty() {
+		return 0
+	}
+	numBands := r.Dy() / minRowsPerBand
+	if maxBands := runtime.GOMAXPROCS(0); numBands > maxBands {
+		numBands = maxBands
+	}
+	if numBands < 1 {
+		numBands = 1
+	}
+	return numBands
+}
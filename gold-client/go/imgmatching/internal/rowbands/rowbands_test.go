@@ -0,0 +1,60 @@
+package rowbands
+
+import (
+	"image"
+	"runtime"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestForEach_EmptyRectangle_DoesNotCallFn(t *testing.T) {
+	called := false
+	ForEach(image.Rect(0, 0, 10, 0), func(int, image.Rectangle) { called = true })
+	assert.False(t, called)
+	assert.Equal(t, 0, NumBands(image.Rect(0, 0, 10, 0)))
+}
+
+func TestForEach_SmallRectangle_SingleBand(t *testing.T) {
+	r := image.Rect(5, 10, 15, 10+minRowsPerBand-1)
+	var bands []image.Rectangle
+	ForEach(r, func(i int, band image.Rectangle) {
+		assert.Equal(t, 0, i)
+		bands = append(bands, band)
+	})
+	assert.Equal(t, []image.Rectangle{r}, bands)
+}
+
+func TestForEach_LargeRectangle_BandsCoverRectangleWithoutOverlap(t *testing.T) {
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(4))
+
+	r := image.Rect(3, 7, 20, 7+10*minRowsPerBand+5)
+	numBands := NumBands(r)
+	assert.Equal(t, 4, numBands)
+
+	var mutex sync.Mutex
+	bands := make([]image.Rectangle, numBands)
+	rowCounts := map[int]int{}
+	ForEach(r, func(i int, band image.Rectangle) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		bands[i] = band
+		for y := band.Min.Y; y < band.Max.Y; y++ {
+			rowCounts[y]++
+		}
+	})
+
+	for i, band := range bands {
+		assert.Equal(t, r.Min.X, band.Min.X)
+		assert.Equal(t, r.Max.X, band.Max.X)
+		assert.False(t, band.Empty())
+		if i > 0 {
+			assert.Equal(t, bands[i-1].Max.Y, band.Min.Y)
+		}
+	}
+	assert.Len(t, rowCounts, r.Dy())
+	for y, count := range rowCounts {
+		assert.Equal(t, 1, count, "row %d", y)
+	}
+}
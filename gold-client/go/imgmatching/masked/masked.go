@@ -0,0 +1,140 @@
+package masked
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+
+	"go.skia.org/infra/gold-client/go/imgmatching/debugartifacts"
+)
+
+// DelegateMatcher is an exact copy of the imgmatching.Matcher interface for the sole purpose of
+// avoiding an import cycle between packages imgmatching and masked.
+type DelegateMatcher interface {
+	Match(expected, actual image.Image) bool
+}
+
+// debugArtifactsProvider is an exact copy of the imgmatching.DebugArtifactsProvider interface for
+// the sole purpose of avoiding an import cycle between packages imgmatching and masked.
+type debugArtifactsProvider interface {
+	DebugArtifacts() debugartifacts.Bundle
+}
+
+// Matcher is an image matching algorithm.
+//
+// It wraps another image matching algorithm (e.g. fuzzy.Matcher or sobel.Matcher), and excludes
+// any pixels in known regions of the images from the comparison. This is useful for tests whose
+// output differs between runs only in a known region, e.g. a timestamp or a scrollbar.
+//
+// The algorithm performs the following steps:
+//  1. It determines the ignored pixels, namely those inside any of the IgnoredRegions, and those
+//     at which Mask (if not nil) is not black. Pixels outside the bounds of Mask are not ignored.
+//  2. It replaces the ignored pixels with transparent black pixels on *both* images, so that they
+//     are identical in both.
+//  3. It passes the two resulting images to the Delegate algorithm and returns its return value.
+type Matcher struct {
+	Delegate       DelegateMatcher
+	IgnoredRegions []image.Rectangle
+	Mask           image.Image
+
+	// Debug information about the last pair of matched images.
+	numIgnoredPixels             int
+	expectedImageWithMaskApplied image.Image
+	actualImageWithMaskApplied   image.Image
+	combinedMask                 *image.Gray
+}
+
+// Match implements the imgmatching.Matcher interface.
+func (m *Matcher) Match(expected, actual image.Image) bool {
+	m.numIgnoredPixels = 0
+	m.expectedImageWithMaskApplied = nil
+	m.actualImageWithMaskApplied = nil
+	m.combinedMask = nil
+
+	// Expected image will be nil if no recent positive image is found. The delegate decides whether
+	// that is a match. The same goes for images of different sizes.
+	if expected == nil || !expected.Bounds().Eq(actual.Bounds()) {
+		return m.Delegate.Match(expected, actual)
+	}
+
+	m.combinedMask = m.makeCombinedMask(expected.Bounds())
+	m.expectedImageWithMaskApplied = applyMask(expected, m.combinedMask)
+	m.actualImageWithMaskApplied = applyMask(actual, m.combinedMask)
+	return m.Delegate.Match(m.expectedImageWithMaskApplied, m.actualImageWithMaskApplied)
+}
+
+// makeCombinedMask returns a mask with the given bounds where ignored pixels are white and all
+// other pixels are black.
+func (m *Matcher) makeCombinedMask(bounds image.Rectangle) *image.Gray {
+	mask := image.NewGray(bounds)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			p := image.Pt(x, y)
+			ignored := false
+			for _, r := range m.IgnoredRegions {
+				if p.In(r) {
+					ignored = true
+					break
+				}
+			}
+			if !ignored && m.Mask != nil && p.In(m.Mask.Bounds()) {
+				r, g, b, _ := m.Mask.At(x, y).RGBA()
+				ignored = r != 0 || g != 0 || b != 0
+			}
+			if ignored {
+				mask.SetGray(x, y, color.Gray{Y: 0xFF})
+				m.numIgnoredPixels++
+			}
+		}
+	}
+	return mask
+}
+
+// applyMask returns a copy of the input image in which all pixels that are white in the given mask
+// are replaced with transparent black pixels.
+func applyMask(img image.Image, mask *image.Gray) image.Image {
+	outputImg := image.NewNRGBA(img.Bounds())
+	draw.Draw(outputImg, img.Bounds(), img, img.Bounds().Min, draw.Src)
+	for y := img.Bounds().Min.Y; y < img.Bounds().Max.Y; y++ {
+		for x := img.Bounds().Min.X; x < img.Bounds().Max.X; x++ {
+			if mask.GrayAt(x, y).Y != 0 {
+				outputImg.SetNRGBA(x, y, color.NRGBA{})
+			}
+		}
+	}
+	return outputImg
+}
+
+// NumIgnoredPixels returns the number of pixels excluded from the last Match method call.
+func (m *Matcher) NumIgnoredPixels() int { return m.numIgnoredPixels }
+
+// CombinedMask returns a grayscale image from the last Match method call where the ignored pixels
+// are white, and all other pixels are black. It returns nil if no mask was applied.
+func (m *Matcher) CombinedMask() image.Image {
+	if m.combinedMask == nil {
+		return nil
+	}
+	return m.combinedMask
+}
+
+// ExpectedImageWithMaskApplied returns the left image from the last Match method call with the
+// ignored pixels removed.
+func (m *Matcher) ExpectedImageWithMaskApplied() image.Image { return m.expectedImageWithMaskApplied }
+
+// ActualImageWithMaskApplied returns the right image from the last Match method call with the
+// ignored pixels removed.
+func (m *Matcher) ActualImageWithMaskApplied() image.Image { return m.actualImageWithMaskApplied }
+
+// DebugArtifacts implements the imgmatching.DebugArtifactsProvider interface. It includes the
+// artifacts reported by the Delegate, if any.
+func (m *Matcher) DebugArtifacts() debugartifacts.Bundle {
+	b := debugartifacts.New()
+	if delegate, ok := m.Delegate.(debugArtifactsProvider); ok {
+		b.Merge(delegate.DebugArtifacts())
+	}
+	b.Metrics["num_ignored_pixels"] = float64(m.numIgnoredPixels)
+	b.AddImage("ignored-pixels-mask", m.CombinedMask())
+	b.AddImage("expected-with-mask-applied", m.expectedImageWithMaskApplied)
+	b.AddImage("actual-with-mask-applied", m.actualImageWithMaskApplied)
+	return b
+}
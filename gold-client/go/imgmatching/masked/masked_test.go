@@ -0,0 +1,119 @@
+package masked
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.skia.org/infra/gold-client/go/imgmatching/fuzzy"
+	"go.skia.org/infra/golden/go/image/text"
+)
+
+const (
+	// expectedImage is a 4x4 image.
+	expectedImage = `! SKTEXTSIMPLE
+4 4
+0x000000ff 0x000000ff 0x000000ff 0x000000ff
+0x000000ff 0x000000ff 0x000000ff 0x000000ff
+0x000000ff 0x000000ff 0x000000ff 0x000000ff
+0x000000ff 0x000000ff 0x000000ff 0x000000ff`
+
+	// actualImage differs from expectedImage in the top-right and bottom-left corners.
+	actualImage = `! SKTEXTSIMPLE
+4 4
+0x000000ff 0x000000ff 0xffffffff 0xffffffff
+0x000000ff 0x000000ff 0xffffffff 0xffffffff
+0x000000ff 0x000000ff 0x000000ff 0x000000ff
+0xffffffff 0x000000ff 0x000000ff 0x000000ff`
+)
+
+func TestMatcher_NoIgnoredRegions_DelegatesToUnderlyingMatcher(t *testing.T) {
+	m := Matcher{Delegate: &fuzzy.Matcher{}}
+	assert.False(t, m.Match(text.MustToNRGBA(expectedImage), text.MustToNRGBA(actualImage)))
+	assert.Equal(t, 0, m.NumIgnoredPixels())
+	assert.Equal(t, 5, m.Delegate.(*fuzzy.Matcher).NumDifferentPixels())
+}
+
+func TestMatcher_IgnoredRegions_ExcludesPixelsInRegions(t *testing.T) {
+	m := Matcher{
+		Delegate: &fuzzy.Matcher{},
+		IgnoredRegions: []image.Rectangle{
+			image.Rect(2, 0, 4, 2),
+			image.Rect(0, 3, 1, 4),
+		},
+	}
+	assert.True(t, m.Match(text.MustToNRGBA(expectedImage), text.MustToNRGBA(actualImage)))
+	assert.Equal(t, 5, m.NumIgnoredPixels())
+
+	// Ignored pixels are transparent black in both images.
+	assert.Equal(t, color.NRGBA{}, m.ExpectedImageWithMaskApplied().At(3, 0))
+	assert.Equal(t, color.NRGBA{}, m.ActualImageWithMaskApplied().At(3, 0))
+	assert.Equal(t, color.NRGBA{A: 0xff}, m.ActualImageWithMaskApplied().At(1, 1))
+	assert.Equal(t, color.Gray{Y: 0xff}, m.CombinedMask().At(0, 3))
+	assert.Equal(t, color.Gray{}, m.CombinedMask().At(1, 3))
+}
+
+func TestMatcher_IgnoredRegionsPartiallyCoverDifferences_ReturnsFalse(t *testing.T) {
+	m := Matcher{
+		Delegate:       &fuzzy.Matcher{},
+		IgnoredRegions: []image.Rectangle{image.Rect(2, 0, 4, 2)},
+	}
+	assert.False(t, m.Match(text.MustToNRGBA(expectedImage), text.MustToNRGBA(actualImage)))
+	assert.Equal(t, 4, m.NumIgnoredPixels())
+	assert.Equal(t, 1, m.Delegate.(*fuzzy.Matcher).NumDifferentPixels())
+}
+
+func TestMatcher_MaskImage_ExcludesNonBlackPixels(t *testing.T) {
+	// The mask is smaller than the images; pixels outside of it are not ignored.
+	mask := text.MustToNRGBA(`! SKTEXTSIMPLE
+4 3
+0x000000ff 0x000000ff 0xff0000ff 0xffffffff
+0x000000ff 0x000000ff 0x00ff00ff 0x0000ffff
+0x000000ff 0x000000ff 0x000000ff 0x000000ff`)
+
+	m := Matcher{Delegate: &fuzzy.Matcher{MaxDifferentPixels: 1, PixelDeltaThreshold: 1020}, Mask: mask}
+	assert.True(t, m.Match(text.MustToNRGBA(expectedImage), text.MustToNRGBA(actualImage)))
+	assert.Equal(t, 4, m.NumIgnoredPixels())
+	assert.Equal(t, 1, m.Delegate.(*fuzzy.Matcher).NumDifferentPixels())
+}
+
+func TestMatcher_DifferentSizeImages_DelegatesWithoutMask(t *testing.T) {
+	m := Matcher{
+		Delegate:       &fuzzy.Matcher{},
+		IgnoredRegions: []image.Rectangle{image.Rect(0, 0, 4, 4)},
+	}
+	assert.False(t, m.Match(text.MustToNRGBA(expectedImage), text.MustToNRGBA(`! SKTEXTSIMPLE
+1 1
+0x000000ff`)))
+	assert.Equal(t, 0, m.NumIgnoredPixels())
+	require.Nil(t, m.CombinedMask())
+}
+
+func TestMatcher_NilExpectedImage_DelegatesWithoutMask(t *testing.T) {
+	m := Matcher{
+		Delegate:       &fuzzy.Matcher{},
+		IgnoredRegions: []image.Rectangle{image.Rect(0, 0, 4, 4)},
+	}
+	assert.False(t, m.Match(nil, text.MustToNRGBA(actualImage)))
+	assert.Nil(t, m.CombinedMask())
+}
+
+func TestMatcher_DebugArtifacts_IncludesMaskAndDelegateArtifacts(t *testing.T) {
+	m := Matcher{
+		Delegate:       &fuzzy.Matcher{},
+		IgnoredRegions: []image.Rectangle{image.Rect(2, 0, 4, 2)},
+	}
+	m.Match(text.MustToNRGBA(expectedImage), text.MustToNRGBA(actualImage))
+
+	artifacts := m.DebugArtifacts()
+	assert.Equal(t, map[string]float64{
+		"num_ignored_pixels":   4,
+		"num_different_pixels": 1,
+		"max_pixel_delta":      765,
+	}, artifacts.Metrics)
+	assert.Equal(t, m.CombinedMask(), artifacts.Images["ignored-pixels-mask"])
+	assert.Equal(t, m.ExpectedImageWithMaskApplied(), artifacts.Images["expected-with-mask-applied"])
+	assert.Equal(t, m.ActualImageWithMaskApplied(), artifacts.Images["actual-with-mask-applied"])
+}
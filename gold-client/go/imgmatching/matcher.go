@@ -3,11 +3,16 @@ package imgmatching
 import (
 	"image"
 
+	"go.skia.org/infra/gold-client/go/imgmatching/cropped"
+	"go.skia.org/infra/gold-client/go/imgmatching/debugartifacts"
+	"go.skia.org/infra/gold-client/go/imgmatching/downscale"
 	"go.skia.org/infra/gold-client/go/imgmatching/exact"
 	"go.skia.org/infra/gold-client/go/imgmatching/fuzzy"
+	"go.skia.org/infra/gold-client/go/imgmatching/masked"
 	"go.skia.org/infra/gold-client/go/imgmatching/positive_if_only_image"
 	"go.skia.org/infra/gold-client/go/imgmatching/sample_area"
 	"go.skia.org/infra/gold-client/go/imgmatching/sobel"
+	"go.skia.org/infra/gold-client/go/imgmatching/ssim"
 )
 
 // Matcher represents a generic image matching algorithm.
@@ -16,10 +21,30 @@ type Matcher interface {
 	Match(expected, actual image.Image) bool
 }
 
+// DebugArtifactsProvider is implemented by Matchers that can report debug artifacts, such as
+// intermediate images and numeric metrics, about the last pair of images they matched.
+type DebugArtifactsProvider interface {
+	// DebugArtifacts returns the debug artifacts about the last pair of matched images.
+	DebugArtifacts() debugartifacts.Bundle
+}
+
 // Make sure the matchers implement the imgmatching.Matcher interface.
 // Note: this is done here instead of in their respective packages to prevent import cycles.
+var _ Matcher = (*cropped.Matcher)(nil)
+var _ Matcher = (*downscale.Matcher)(nil)
 var _ Matcher = (*exact.Matcher)(nil)
 var _ Matcher = (*fuzzy.Matcher)(nil)
+var _ Matcher = (*masked.Matcher)(nil)
 var _ Matcher = (*positive_if_only_image.Matcher)(nil)
 var _ Matcher = (*sample_area.Matcher)(nil)
 var _ Matcher = (*sobel.Matcher)(nil)
+var _ Matcher = (*ssim.Matcher)(nil)
+
+// Make sure the non-exact matchers implement the imgmatching.DebugArtifactsProvider interface.
+var _ DebugArtifactsProvider = (*cropped.Matcher)(nil)
+var _ DebugArtifactsProvider = (*downscale.Matcher)(nil)
+var _ DebugArtifactsProvider = (*fuzzy.Matcher)(nil)
+var _ DebugArtifactsProvider = (*masked.Matcher)(nil)
+var _ DebugArtifactsProvider = (*sample_area.Matcher)(nil)
+var _ DebugArtifactsProvider = (*sobel.Matcher)(nil)
+var _ DebugArtifactsProvider = (*ssim.Matcher)(nil)
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"image"
 	"image/draw"
+
+	"go.skia.org/infra/gold-client/go/imgmatching/debugartifacts"
 )
 
 // Matcher is a non-exact image matching algorithm.
@@ -123,6 +125,13 @@ func (m *Matcher) FailedSampleArea() image.Rectangle { return m.failedSampleArea
 // sample area.
 func (m *Matcher) NumDifferentPixels() int { return m.numDifferentPixels }
 
+// DebugArtifacts implements the imgmatching.DebugArtifactsProvider interface.
+func (m *Matcher) DebugArtifacts() debugartifacts.Bundle {
+	b := debugartifacts.New()
+	b.Metrics["num_different_pixels"] = float64(m.numDifferentPixels)
+	return b
+}
+
 // SampleAreaWidthTooSmall returns whether the comparison failed because the
 // provided sample area is too small to be used.
 func (m *Matcher) SampleAreaWidthTooSmall() bool { return m.sampleAreaWidthTooSmall }
@@ -6,7 +6,27 @@ import (
 	"image/draw"
 	"math"
 
+	"go.skia.org/infra/gold-client/go/imgmatching/debugartifacts"
 	"go.skia.org/infra/gold-client/go/imgmatching/fuzzy"
+	"go.skia.org/infra/gold-client/go/imgmatching/internal/rowbands"
+)
+
+// BorderMode determines how the Sobel operator is computed for pixels at the borders of the image,
+// which lack some of the 8 neighboring pixels needed to apply the convolutions.
+type BorderMode string
+
+const (
+	// BorderModeZero leaves border pixels black in the Sobel operator output, meaning that they are
+	// never considered part of an edge.
+	BorderModeZero = BorderMode("")
+
+	// BorderModeReplicate pads the image by replicating its border pixels, so that the Sobel
+	// operator can be computed for border pixels like for any other pixel.
+	BorderModeReplicate = BorderMode("replicate")
+
+	// BorderModeEdge treats all border pixels as edges, i.e. they are always above EdgeThreshold and
+	// therefore excluded from comparison.
+	BorderModeEdge = BorderMode("edge")
 )
 
 // testMatcher is an exact copy of the imgmatching.Matcher interface for the sole purpose of
@@ -24,7 +44,8 @@ type testMatcher interface {
 //  1. It applies the Sobel operator to the expected image, producing a 0 to 255 value per pixel
 //     indicating how likely it is to be part of an edge.
 //  2. It zeroes-out any (x,y) coordinates on *both* images where the aforementioned value exceeds
-//     EdgeThreshold. Note that this assumes both images are of equal size.
+//     EdgeThreshold. Note that this assumes both images are of equal size. Border pixels are
+//     handled according to BorderMode (see the BorderMode constants).
 //  3. It passes the two resulting images to the fuzzy.Matcher algorithm (using parameters
 //     MaxDifferentPixels, PixelDeltaThreshold, PixelPerChannelDeltaThreshold and
 //     IgnoredBorderThickness) and returns its return value.
@@ -33,6 +54,7 @@ type testMatcher interface {
 type Matcher struct {
 	fuzzy.Matcher
 	EdgeThreshold int // Valid values are 0 to 255 inclusive.
+	BorderMode    BorderMode
 
 	// If set, fuzzyMatcherForTesting will be used instead of the embedded fuzzy.Matcher.
 	fuzzyMatcherForTesting testMatcher
@@ -56,7 +78,7 @@ func (m *Matcher) Match(expected, actual image.Image) bool {
 	}
 
 	// Extract edges from the expected image.
-	m.sobelOutput = sobel(imageToGray(expected))
+	m.sobelOutput = sobel(imageToGray(expected), m.BorderMode)
 
 	// Zero-out edges on *both* the expected and actual images, using the edges from the former in
 	// both cases.
@@ -88,16 +110,29 @@ func (m *Matcher) ExpectedImageWithEdgesRemoved() image.Image { return m.expecte
 // removed.
 func (m *Matcher) ActualImageWithEdgesRemoved() image.Image { return m.actualImageWithEdgesRemoved }
 
+// DebugArtifacts implements the imgmatching.DebugArtifactsProvider interface. It includes the
+// artifacts reported by the embedded fuzzy.Matcher.
+func (m *Matcher) DebugArtifacts() debugartifacts.Bundle {
+	b := m.Matcher.DebugArtifacts()
+	if m.sobelOutput != nil {
+		b.AddImage("sobel-output", m.sobelOutput)
+	}
+	b.AddImage("expected-with-edges-removed", m.expectedImageWithEdgesRemoved)
+	b.AddImage("actual-with-edges-removed", m.actualImageWithEdgesRemoved)
+	return b
+}
+
 // sobel returns a grayscale image with the result of applying the Sobel operator[1] to each pixel
 // in the input image.
 //
-// The returned image has the same size as the input image. Border pixels will be black, because
-// computing the Sobel operator requires all 8 neighboring pixels (as a consequence, all pixels
-// will be black for input images smaller than 3x3). The value of the Sobel operator is clipped at
-// 255 before being converted into an 8-bit grayscale pixel.
+// The returned image has the same size as the input image. Computing the Sobel operator requires
+// all 8 neighboring pixels, so border pixels are handled according to the given BorderMode. With
+// BorderModeZero, border pixels will be black (as a consequence, all pixels will be black for input
+// images smaller than 3x3). The value of the Sobel operator is clipped at 255 before being
+// converted into an 8-bit grayscale pixel.
 //
 // [1] https://en.wikipedia.org/wiki/Sobel_operator
-func sobel(img *image.Gray) *image.Gray {
+func sobel(img *image.Gray, borderMode BorderMode) *image.Gray {
 	kernelX := [3][3]int{
 		{1, 0, -1},
 		{2, 0, -2},
@@ -112,23 +147,44 @@ func sobel(img *image.Gray) *image.Gray {
 
 	outputImg := image.NewGray(img.Bounds())
 
-	// Iterate over all pixels except those at the borders of the image, because we need all 8
-	// neighboring pixels to be able to apply the convolutions. Border pixels will remain black.
-	for y := img.Bounds().Min.Y + 1; y < img.Bounds().Max.Y-1; y++ {
-		for x := img.Bounds().Min.X + 1; x < img.Bounds().Max.X-1; x++ {
-			// Apply convolutions.
-			convolutionX := applyConvolution(img, kernelX, x, y)
-			convolutionY := applyConvolution(img, kernelY, x, y)
-
-			// Compute the Sobel operator as the norm of the convolution vector.
-			sobelOperator := math.Sqrt(float64(convolutionX*convolutionX + convolutionY*convolutionY))
-
-			// Clip sobelOperator and set output pixel (x,y).
-			clippedSobelOperator := uint8(sobelOperator)
-			if sobelOperator > float64(math.MaxUint8) {
-				clippedSobelOperator = math.MaxUint8
+	// Unless the image is replicate-padded, iterate over all pixels except those at the borders of
+	// the image, because we need all 8 neighboring pixels to be able to apply the convolutions.
+	// Border pixels will remain black, or be set to white below if they are to be treated as edges.
+	//
+	// Rows are split into bands which are processed concurrently. This is safe because each pixel
+	// of the output image is written exactly once, and the input image is only read.
+	area := image.Rectangle{Min: img.Bounds().Min.Add(image.Pt(1, 1)), Max: img.Bounds().Max.Sub(image.Pt(1, 1))}
+	if borderMode == BorderModeReplicate {
+		area = img.Bounds()
+	}
+	rowbands.ForEach(area, func(_ int, band image.Rectangle) {
+		for y := band.Min.Y; y < band.Max.Y; y++ {
+			for x := band.Min.X; x < band.Max.X; x++ {
+				// Apply convolutions.
+				convolutionX := applyConvolution(img, kernelX, x, y)
+				convolutionY := applyConvolution(img, kernelY, x, y)
+
+				// Compute the Sobel operator as the norm of the convolution vector.
+				sobelOperator := math.Sqrt(float64(convolutionX*convolutionX + convolutionY*convolutionY))
+
+				// Clip sobelOperator and set output pixel (x,y).
+				clippedSobelOperator := uint8(sobelOperator)
+				if sobelOperator > float64(math.MaxUint8) {
+					clippedSobelOperator = math.MaxUint8
+				}
+				outputImg.SetGray(x, y, color.Gray{Y: clippedSobelOperator})
+			}
+		}
+	})
+
+	if borderMode == BorderModeEdge {
+		b := img.Bounds()
+		for y := b.Min.Y; y < b.Max.Y; y++ {
+			for x := b.Min.X; x < b.Max.X; x++ {
+				if x == b.Min.X || x == b.Max.X-1 || y == b.Min.Y || y == b.Max.Y-1 {
+					outputImg.SetGray(x, y, color.Gray{Y: math.MaxUint8})
+				}
 			}
-			outputImg.SetGray(x, y, color.Gray{Y: clippedSobelOperator})
 		}
 	}
 
@@ -136,20 +192,33 @@ func sobel(img *image.Gray) *image.Gray {
 }
 
 // applyConvolution returns the result of applying the given convolution kernel to the pixel at
-// (x, y) in the input grayscale image.
+// (x, y) in the input grayscale image. Neighboring pixels outside of the image bounds take the
+// value of the nearest pixel within the bounds, i.e. the image is replicate-padded.
 func applyConvolution(img *image.Gray, kernel [3][3]int, x, y int) int {
 	convolution := 0
+	b := img.Bounds()
 
 	// Iterate over all coordinates of the 3x3 convolution kernel matrix.
 	for j := 0; j <= 2; j++ {
 		for i := 0; i <= 2; i++ {
-			convolution += int(img.GrayAt(x+i-1, y+j-1).Y) * kernel[j][i]
+			convolution += int(img.GrayAt(clamp(x+i-1, b.Min.X, b.Max.X-1), clamp(y+j-1, b.Min.Y, b.Max.Y-1)).Y) * kernel[j][i]
 		}
 	}
 
 	return convolution
 }
 
+// clamp returns v limited to the [lo, hi] range.
+func clamp(v, lo, hi int) int {
+	if v < lo {
+		return lo
+	}
+	if v > hi {
+		return hi
+	}
+	return v
+}
+
 // zeroOutEdges returns a copy of the input image in which all pixels above the edge threshold are
 // replaced with black pixels. Input and edges images must have the same bounds.
 func zeroOutEdges(img image.Image, edges *image.Gray, edgeThreshold uint8) image.Image {
@@ -161,19 +230,21 @@ func zeroOutEdges(img image.Image, edges *image.Gray, edgeThreshold uint8) image
 
 	outputImg := image.NewNRGBA(img.Bounds())
 
-	// Iterate over all pixels.
-	for y := img.Bounds().Min.Y; y < img.Bounds().Max.Y; y++ {
-		for x := img.Bounds().Min.X; x < img.Bounds().Max.X; x++ {
-			pixel := img.At(x, y)
+	// Iterate over all pixels, split into bands of rows which are processed concurrently.
+	rowbands.ForEach(img.Bounds(), func(_ int, band image.Rectangle) {
+		for y := band.Min.Y; y < band.Max.Y; y++ {
+			for x := band.Min.X; x < band.Max.X; x++ {
+				pixel := img.At(x, y)
 
-			// Zero out pixel if it's above the edge threshold.
-			if edges.GrayAt(x, y).Y > edgeThreshold {
-				pixel = &color.NRGBA{R: 0, G: 0, B: 0, A: 255}
-			}
+				// Zero out pixel if it's above the edge threshold.
+				if edges.GrayAt(x, y).Y > edgeThreshold {
+					pixel = &color.NRGBA{R: 0, G: 0, B: 0, A: 255}
+				}
 
-			outputImg.Set(x, y, pixel)
+				outputImg.Set(x, y, pixel)
+			}
 		}
-	}
+	})
 
 	return outputImg
 }
@@ -153,6 +153,15 @@ func TestMatcher_Match_Success(t *testing.T) {
 			assertImagesEqualWithMessage(t, tc.expectedImage2WithEdgesRemoved, matcher.ActualImageWithEdgesRemoved(), "image2 with edges removed")
 			assert.Equal(t, tc.expectedNumDifferentPixels, matcher.Matcher.NumDifferentPixels())
 			assert.Equal(t, tc.expectedMaxPixelDelta, matcher.Matcher.MaxPixelDelta())
+
+			artifacts := matcher.DebugArtifacts()
+			assertImagesEqualWithMessage(t, tc.expectedSobelOutput, artifacts.Images["sobel-output"], "sobel output artifact")
+			assertImagesEqualWithMessage(t, tc.expectedImage1WithEdgesRemoved, artifacts.Images["expected-with-edges-removed"], "image1 with edges removed artifact")
+			assertImagesEqualWithMessage(t, tc.expectedImage2WithEdgesRemoved, artifacts.Images["actual-with-edges-removed"], "image2 with edges removed artifact")
+			assert.Equal(t, map[string]float64{
+				"num_different_pixels": float64(tc.expectedNumDifferentPixels),
+				"max_pixel_delta":      float64(tc.expectedMaxPixelDelta),
+			}, artifacts.Metrics)
 		})
 	}
 }
@@ -190,12 +199,13 @@ func TestMatcher_Match_DifferentSizeImages_ReturnsFalse(t *testing.T) {
 
 	assert.False(t, matcher.Match(smallImage, largeImage))
 	assert.False(t, matcher.Match(largeImage, smallImage))
+	assert.Empty(t, matcher.DebugArtifacts().Images)
 }
 
 // TestSobel_Success tests the sobel() function using the canonical image1 and image1Sobel images
 // used throughout this file.
 func TestSobel_Success(t *testing.T) {
-	assertImagesEqual(t, text.MustToGray(image1Sobel), sobel(text.MustToGray(image1)))
+	assertImagesEqual(t, text.MustToGray(image1Sobel), sobel(text.MustToGray(image1), BorderModeZero))
 }
 
 // TestSobel_SmallImages_Success tests various edge cases involving small images.
@@ -358,9 +368,64 @@ func TestSobel_SmallImages_Success(t *testing.T) {
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			assertImagesEqual(t, tc.expectedOutput, sobel(tc.input))
+			assertImagesEqual(t, tc.expectedOutput, sobel(tc.input, BorderModeZero))
+		})
+	}
+}
+
+func TestSobel_BorderModes_Success(t *testing.T) {
+
+	input := text.MustToGray(`! SKTEXTSIMPLE
+	3 3
+	0x00 0xFF 0xFF
+	0x00 0xFF 0xFF
+	0x00 0xFF 0xFF`)
+
+	test := func(name string, borderMode BorderMode, expectedOutput string) {
+		t.Run(name, func(t *testing.T) {
+			assertImagesEqual(t, text.MustToGray(expectedOutput), sobel(input, borderMode))
 		})
 	}
+
+	test("zero, border pixels are black", BorderModeZero, `! SKTEXTSIMPLE
+	3 3
+	0x00 0x00 0x00
+	0x00 0xFF 0x00
+	0x00 0x00 0x00`)
+	test("replicate, gradients computed for border pixels", BorderModeReplicate, `! SKTEXTSIMPLE
+	3 3
+	0xFF 0xFF 0x00
+	0xFF 0xFF 0x00
+	0xFF 0xFF 0x00`)
+	test("edge, border pixels are white", BorderModeEdge, `! SKTEXTSIMPLE
+	3 3
+	0xFF 0xFF 0xFF
+	0xFF 0xFF 0xFF
+	0xFF 0xFF 0xFF`)
+}
+
+func TestMatcher_Match_BorderModeEdge_BorderPixelsIgnored(t *testing.T) {
+
+	// The images differ only in their border pixels.
+	image1 := text.MustToNRGBA(`! SKTEXTSIMPLE
+	3 3
+	0x00 0x00 0x00
+	0x00 0x00 0x00
+	0x00 0x00 0x00`)
+	image2 := text.MustToNRGBA(`! SKTEXTSIMPLE
+	3 3
+	0xFF 0x00 0x00
+	0x00 0x00 0x00
+	0x00 0x00 0xFF`)
+
+	matcher := Matcher{
+		Matcher:       fuzzy.Matcher{MaxDifferentPixels: 0},
+		EdgeThreshold: 0xFE,
+	}
+	assert.False(t, matcher.Match(image1, image2))
+
+	matcher.BorderMode = BorderModeEdge
+	assert.True(t, matcher.Match(image1, image2))
 }
 
 func TestSobel_EdgesAtVariousAngles_Success(t *testing.T) {
@@ -471,7 +536,7 @@ func TestSobel_EdgesAtVariousAngles_Success(t *testing.T) {
 
 	test := func(name string, input, expectedOutput *image.Gray) {
 		t.Run(name, func(t *testing.T) {
-			assertImagesEqual(t, expectedOutput, sobel(input))
+			assertImagesEqual(t, expectedOutput, sobel(input, BorderModeZero))
 		})
 	}
 
@@ -492,7 +557,7 @@ func TestSobel_GoldenImage_Success(t *testing.T) {
 
 	input := readPngAsGray(t, "test/input.png")
 	expectedOutput := readPngAsGray(t, "test/sobel-expected-output.png")
-	assert.Equal(t, expectedOutput, sobel(input))
+	assert.Equal(t, expectedOutput, sobel(input, BorderModeZero))
 }
 
 // TestZeroOutEdges_Success tests function zeroOutEdges() against image1 and image2 using the edges
@@ -583,6 +648,42 @@ func TestZeroOutEdges_GoldenImage_Success(t *testing.T) {
 	assert.Equal(t, expectedOutput, zeroOutEdges(input, edges, 0x55))
 }
 
+// The benchmarks below process the golden image concurrently in bands of rows. Run them with
+// e.g. -cpu=1,2,4,8 to compare against single-threaded performance.
+
+func BenchmarkSobel_GoldenImage(b *testing.B) {
+	input := readPngAsGray(b, "test/input.png")
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		sobel(input, BorderModeZero)
+	}
+}
+
+func BenchmarkZeroOutEdges_GoldenImage(b *testing.B) {
+	input := readPng(b, "test/input.png")
+	edges := readPngAsGray(b, "test/sobel-expected-output.png")
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		zeroOutEdges(input, edges, 0x55)
+	}
+}
+
+func BenchmarkMatcher_Match_GoldenImage(b *testing.B) {
+	expected := readPng(b, "test/input.png")
+	actual := readPng(b, "test/zero-out-edges-expected-output.png")
+	matcher := Matcher{
+		Matcher: fuzzy.Matcher{
+			MaxDifferentPixels:  expected.Bounds().Dx() * expected.Bounds().Dy(),
+			PixelDeltaThreshold: 1020,
+		},
+		EdgeThreshold: 0x55,
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		matcher.Match(expected, actual)
+	}
+}
+
 func TestZeroOutEdges_InputAndEdgesImagesHaveDifferentBounds_Panics(t *testing.T) {
 
 	assert.Panics(t, func() {
@@ -752,12 +853,12 @@ func imageToText(t *testing.T, img image.Image) string {
 
 // readPngAsGray reads a PNG image from the file system, converts it to grayscale and returns it as
 // an *image.Gray.
-func readPngAsGray(t *testing.T, filename string) *image.Gray {
+func readPngAsGray(t testing.TB, filename string) *image.Gray {
 	return imageToGray(readPng(t, filename))
 }
 
 // readPng reads a PNG image from the file system and returns it as an *image.NRGBA.
-func readPng(t *testing.T, filename string) *image.NRGBA {
+func readPng(t testing.TB, filename string) *image.NRGBA {
 	// Read image.
 	imgBytes, err := os.ReadFile(filename)
 	require.NoError(t, err)
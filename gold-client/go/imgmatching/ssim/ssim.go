@@ -0,0 +1,194 @@
+package ssim
+
+import (
+	"image"
+	"image/draw"
+	"math"
+
+	"go.skia.org/infra/gold-client/go/imgmatching/debugartifacts"
+)
+
+const (
+	// DefaultWindowSize is the window size used if WindowSize is not set.
+	DefaultWindowSize = 8
+
+	// Stabilization constants from the SSIM paper, for 8-bit channels: c1 = (0.01*255)^2 and
+	// c2 = (0.03*255)^2.
+	c1 = 6.5025
+	c2 = 58.5225
+)
+
+// Matcher is an image matching algorithm.
+//
+// It computes the structural similarity index measure (SSIM)[1] of the luminance of both images,
+// which unlike pixel-count based algorithms such as fuzzy.Matcher captures perceptual differences
+// in structure, contrast and brightness.
+//
+// The algorithm performs the following steps:
+//  1. It converts both images to grayscale.
+//  2. It computes the SSIM of every WindowSize x WindowSize window of pixels (sliding one pixel at
+//     a time), using the stabilization constants from the original paper for 8-bit channels. If
+//     either image dimension is smaller than WindowSize, the window is shrunk to fit the image.
+//  3. It considers the images to be equal if they are of equal size, and if the mean SSIM across
+//     all windows is at least MinScore.
+//
+// SSIM values range from -1 to 1, where 1 means the images are identical. Valid MinScore values
+// are 0 to 1 inclusive.
+//
+// [1] https://en.wikipedia.org/wiki/Structural_similarity
+type Matcher struct {
+	WindowSize int // Valid values are 1 or greater. Defaults to DefaultWindowSize if 0.
+	MinScore   float64
+
+	// Debug information about the last pair of matched images.
+	score             float64
+	lowestScore       float64
+	lowestScoreWindow image.Rectangle
+}
+
+// Match implements the imgmatching.Matcher interface.
+func (m *Matcher) Match(expected, actual image.Image) bool {
+	m.score = 0
+	m.lowestScore = 0
+	m.lowestScoreWindow = image.Rectangle{}
+
+	// Expected image will be nil if no recent positive image is found.
+	if expected == nil {
+		return false
+	}
+
+	// Images must be the same size.
+	if !expected.Bounds().Eq(actual.Bounds()) {
+		return false
+	}
+
+	bounds := expected.Bounds()
+	if bounds.Empty() {
+		m.score = 1
+		m.lowestScore = 1
+		return true
+	}
+
+	windowSize := m.WindowSize
+	if windowSize == 0 {
+		windowSize = DefaultWindowSize
+	}
+	if windowSize > bounds.Dx() {
+		windowSize = bounds.Dx()
+	}
+	if windowSize > bounds.Dy() {
+		windowSize = bounds.Dy()
+	}
+
+	expectedSums := newSummedAreaTables(imageToGray(expected), nil)
+	actualSums := newSummedAreaTables(imageToGray(actual), expectedSums.gray)
+
+	n := float64(windowSize * windowSize)
+	scoreSum := 0.0
+	numWindows := 0
+	m.lowestScore = math.Inf(1)
+	for y := 0; y <= bounds.Dy()-windowSize; y++ {
+		for x := 0; x <= bounds.Dx()-windowSize; x++ {
+			sumX := expectedSums.sum.window(x, y, windowSize)
+			sumY := actualSums.sum.window(x, y, windowSize)
+			sumXX := expectedSums.sumSquares.window(x, y, windowSize)
+			sumYY := actualSums.sumSquares.window(x, y, windowSize)
+			sumXY := actualSums.sumProducts.window(x, y, windowSize)
+
+			meanX := sumX / n
+			meanY := sumY / n
+			varianceX := sumXX/n - meanX*meanX
+			varianceY := sumYY/n - meanY*meanY
+			covariance := sumXY/n - meanX*meanY
+
+			score := ((2*meanX*meanY + c1) * (2*covariance + c2)) /
+				((meanX*meanX + meanY*meanY + c1) * (varianceX + varianceY + c2))
+			scoreSum += score
+			numWindows++
+
+			if score < m.lowestScore {
+				m.lowestScore = score
+				m.lowestScoreWindow = image.Rect(x, y, x+windowSize, y+windowSize).Add(bounds.Min)
+			}
+		}
+	}
+
+	m.score = scoreSum / float64(numWindows)
+	return m.score >= m.MinScore
+}
+
+// Score returns the mean SSIM across all windows of the last pair of matched images.
+func (m *Matcher) Score() float64 { return m.score }
+
+// LowestScore returns the lowest SSIM of any window of the last pair of matched images.
+func (m *Matcher) LowestScore() float64 { return m.lowestScore }
+
+// LowestScoreWindow returns the window with the lowest SSIM in the last pair of matched images.
+func (m *Matcher) LowestScoreWindow() image.Rectangle { return m.lowestScoreWindow }
+
+// DebugArtifacts implements the imgmatching.DebugArtifactsProvider interface.
+func (m *Matcher) DebugArtifacts() debugartifacts.Bundle {
+	b := debugartifacts.New()
+	b.Metrics["mean_ssim"] = m.score
+	b.Metrics["lowest_window_ssim"] = m.lowestScore
+	return b
+}
+
+// summedAreaTable holds, for each (x, y), the sum of some per-pixel value over all pixels above
+// and to the left of (x, y), exclusive. It has one more row and column than the image.
+type summedAreaTable struct {
+	stride int
+	values []float64
+}
+
+// window returns the sum of the per-pixel values in the size x size window whose top-left corner
+// is (x, y), relative to the image bounds.
+func (t summedAreaTable) window(x, y, size int) float64 {
+	at := func(x, y int) float64 { return t.values[y*t.stride+x] }
+	return at(x+size, y+size) - at(x, y+size) - at(x+size, y) + at(x, y)
+}
+
+// summedAreaTables holds the summed area tables needed to compute the SSIM of any window.
+type summedAreaTables struct {
+	gray        *image.Gray
+	sum         summedAreaTable
+	sumSquares  summedAreaTable
+	sumProducts summedAreaTable // Products with the pixels of another image, if given.
+}
+
+// newSummedAreaTables computes the summed area tables of the pixel values, their squares, and
+// their products with the pixels of the other image, if not nil.
+func newSummedAreaTables(img, other *image.Gray) summedAreaTables {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	stride := width + 1
+	newTable := func() summedAreaTable {
+		return summedAreaTable{stride: stride, values: make([]float64, stride*(height+1))}
+	}
+
+	tables := summedAreaTables{gray: img, sum: newTable(), sumSquares: newTable()}
+	if other != nil {
+		tables.sumProducts = newTable()
+	}
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			v := float64(img.GrayAt(bounds.Min.X+x, bounds.Min.Y+y).Y)
+			i := (y+1)*stride + x + 1
+			up, left, upLeft := i-stride, i-1, i-stride-1
+			tables.sum.values[i] = v + tables.sum.values[up] + tables.sum.values[left] - tables.sum.values[upLeft]
+			tables.sumSquares.values[i] = v*v + tables.sumSquares.values[up] + tables.sumSquares.values[left] - tables.sumSquares.values[upLeft]
+			if other != nil {
+				w := float64(other.GrayAt(bounds.Min.X+x, bounds.Min.Y+y).Y)
+				tables.sumProducts.values[i] = v*w + tables.sumProducts.values[up] + tables.sumProducts.values[left] - tables.sumProducts.values[upLeft]
+			}
+		}
+	}
+	return tables
+}
+
+// imageToGray converts the given image to grayscale.
+func imageToGray(img image.Image) *image.Gray {
+	grayImg := image.NewGray(img.Bounds())
+	draw.Draw(grayImg, img.Bounds(), img, img.Bounds().Min, draw.Src)
+	return grayImg
+}
@@ -0,0 +1,85 @@
+package ssim
+
+import (
+	"image"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.skia.org/infra/golden/go/image/text"
+)
+
+// checkerboard is a 4x4 image with a 2x2 checkerboard pattern.
+const checkerboard = `! SKTEXTSIMPLE
+4 4
+0x000000ff 0x000000ff 0xffffffff 0xffffffff
+0x000000ff 0x000000ff 0xffffffff 0xffffffff
+0xffffffff 0xffffffff 0x000000ff 0x000000ff
+0xffffffff 0xffffffff 0x000000ff 0x000000ff`
+
+func TestMatcher_DifferentSizeImages_ReturnsFalse(t *testing.T) {
+	m := Matcher{MinScore: 0}
+	assert.False(t, m.Match(text.MustToNRGBA(checkerboard), text.MustToNRGBA(`! SKTEXTSIMPLE
+	1 1
+	0x000000ff`)))
+}
+
+func TestMatcher_NilExpectedImage_ReturnsFalse(t *testing.T) {
+	m := Matcher{MinScore: 0}
+	assert.False(t, m.Match(nil, text.MustToNRGBA(checkerboard)))
+}
+
+func TestMatcher_IdenticalImages_ScoreIsOne(t *testing.T) {
+	m := Matcher{WindowSize: 2, MinScore: 1}
+	assert.True(t, m.Match(text.MustToNRGBA(checkerboard), text.MustToNRGBA(checkerboard)))
+	assert.Equal(t, 1.0, m.Score())
+	assert.Equal(t, 1.0, m.LowestScore())
+}
+
+func TestMatcher_SlightlyDifferentImages_MatchesAboveThreshold(t *testing.T) {
+	slightlyDifferent := text.MustToNRGBA(`! SKTEXTSIMPLE
+	4 4
+	0x000000ff 0x000000ff 0xffffffff 0xffffffff
+	0x000000ff 0x040404ff 0xffffffff 0xffffffff
+	0xffffffff 0xffffffff 0x000000ff 0x000000ff
+	0xffffffff 0xffffffff 0x000000ff 0x000000ff`)
+
+	m := Matcher{WindowSize: 2, MinScore: 0.95}
+	assert.True(t, m.Match(text.MustToNRGBA(checkerboard), slightlyDifferent))
+	assert.Less(t, m.Score(), 1.0)
+	assert.Greater(t, m.Score(), 0.95)
+
+	// The lowest-scoring window contains the different pixel.
+	assert.True(t, image.Pt(1, 1).In(m.LowestScoreWindow()))
+
+	m = Matcher{WindowSize: 2, MinScore: 1}
+	assert.False(t, m.Match(text.MustToNRGBA(checkerboard), slightlyDifferent))
+}
+
+func TestMatcher_StructurallyDifferentImages_ReturnsFalse(t *testing.T) {
+	inverted := text.MustToNRGBA(`! SKTEXTSIMPLE
+	4 4
+	0xffffffff 0xffffffff 0x000000ff 0x000000ff
+	0xffffffff 0xffffffff 0x000000ff 0x000000ff
+	0x000000ff 0x000000ff 0xffffffff 0xffffffff
+	0x000000ff 0x000000ff 0xffffffff 0xffffffff`)
+
+	m := Matcher{WindowSize: 3, MinScore: 0.5}
+	assert.False(t, m.Match(text.MustToNRGBA(checkerboard), inverted))
+	assert.Less(t, m.Score(), 0.0)
+	assert.Equal(t, image.Rect(0, 0, 3, 3).Size(), m.LowestScoreWindow().Size())
+}
+
+func TestMatcher_WindowLargerThanImage_UsesWholeImage(t *testing.T) {
+	m := Matcher{MinScore: 1}
+	assert.True(t, m.Match(text.MustToNRGBA(checkerboard), text.MustToNRGBA(checkerboard)))
+	assert.Equal(t, image.Rect(0, 0, 4, 4), m.LowestScoreWindow())
+}
+
+func TestMatcher_DebugArtifacts_ReportsScores(t *testing.T) {
+	m := Matcher{WindowSize: 2, MinScore: 1}
+	assert.True(t, m.Match(text.MustToNRGBA(checkerboard), text.MustToNRGBA(checkerboard)))
+	assert.Equal(t, map[string]float64{
+		"mean_ssim":          1,
+		"lowest_window_ssim": 1,
+	}, m.DebugArtifacts().Metrics)
+}
@@ -235,6 +235,10 @@ func (n nopLiveness) Reset() {}
 
 func (n nopLiveness) Close() {}
 
+func (n nopLiveness) SetExpectedInterval(_ time.Duration) {}
+
+func (n nopLiveness) Healthy() bool { return true }
+
 // Ensure that nopLiveness implements the Liveness interface.
 var _ metrics2.Liveness = (*nopLiveness)(nil)
 
@@ -0,0 +1,134 @@
+package goldpushk
+
+import (
+	"context"
+	"fmt"
+	"os/user"
+	"sort"
+	"time"
+
+	"go.skia.org/infra/go/ds"
+	"go.skia.org/infra/go/now"
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/go/sklog"
+)
+
+// DeploymentRecord is the audit log entry recorded for each deployment.
+type DeploymentRecord struct {
+	// User is the user who ran goldpushk.
+	User string `datastore:"user"`
+
+	// DeployableUnits are the canonical names of the deployed DeployableUnits, including canaries,
+	// e.g. "gold-chrome-frontend".
+	DeployableUnits []string `datastore:"deployable_units"`
+
+	// Instances and Clusters are the Gold instances and Kubernetes clusters involved in the
+	// deployment, e.g. "chrome" and "skia-public".
+	Instances []string `datastore:"instances"`
+	Clusters  []string `datastore:"clusters"`
+
+	// SkiaInfraCommit is the commit of the skia-infra checkout from which the configuration files were
+	// generated.
+	SkiaInfraCommit string `datastore:"skia_infra_commit"`
+
+	// K8sConfigCommit is the commit of the k8s-config repository that was deployed. If any clusters
+	// check in their configuration files to other repositories, the commit of each repository is
+	// listed, prefixed by its URL.
+	K8sConfigCommit string `datastore:"k8s_config_commit"`
+
+	StartedAt  time.Time `datastore:"started_at"`
+	FinishedAt time.Time `datastore:"finished_at"`
+
+	// Succeeded is false if the deployment failed, in which case Error holds the reason.
+	Succeeded bool   `datastore:"succeeded"`
+	Error     string `datastore:"error,noindex"`
+}
+
+// auditLog durably stores DeploymentRecords.
+type auditLog interface {
+	put(ctx context.Context, record *DeploymentRecord) error
+}
+
+// datastoreAuditLog is an auditLog backed by Cloud Datastore.
+type datastoreAuditLog struct{}
+
+// put implements the auditLog interface.
+func (datastoreAuditLog) put(ctx context.Context, record *DeploymentRecord) error {
+	if _, err := ds.DS.Put(ctx, ds.NewKey(ds.GOLDPUSHK_DEPLOYMENT), record); err != nil {
+		return skerr.Wrap(err)
+	}
+	return nil
+}
+
+// SetAuditLog makes goldpushk record every deployment, successful or not, in the Cloud Datastore
+// of the given project, under the ds.GOLDPUSHK_NS namespace.
+func (g *Goldpushk) SetAuditLog(project string) error {
+	if err := ds.Init(project, ds.GOLDPUSHK_NS); err != nil {
+		return skerr.Wrap(err)
+	}
+	g.auditLog = datastoreAuditLog{}
+	return nil
+}
+
+// recordDeployment stores a DeploymentRecord with the outcome of the deployment in the audit log. A
+// nil runErr means the deployment succeeded. Failing to record the deployment is logged but does
+// not fail the deployment.
+func (g *Goldpushk) recordDeployment(ctx context.Context, runErr error) {
+	if g.auditLog == nil || g.dryRun {
+		return
+	}
+	record := g.makeDeploymentRecord(ctx, runErr)
+	if err := g.auditLog.put(ctx, record); err != nil {
+		sklog.Errorf("Failed to record deployment in the audit log: %s", err)
+		fmt.Printf("Warning: failed to record deployment in the audit log: %s\n", err)
+	}
+}
+
+// makeDeploymentRecord returns the DeploymentRecord for a deployment with the given outcome.
+func (g *Goldpushk) makeDeploymentRecord(ctx context.Context, runErr error) *DeploymentRecord {
+	var units []DeployableUnit
+	units = append(units, g.canariedDeployableUnits...)
+	units = append(units, g.deployableUnits...)
+
+	instances := map[string]bool{}
+	clusters := map[string]bool{}
+	for _, unit := range units {
+		instances[string(unit.Instance)] = true
+		clusters[getCluster(unit).name] = true
+	}
+
+	record := &DeploymentRecord{
+		User:            getUsername(),
+		DeployableUnits: canonicalNames(units),
+		Instances:       sortedKeys(instances),
+		Clusters:        sortedKeys(clusters),
+		SkiaInfraCommit: g.getSkiaInfraCommit(ctx),
+		K8sConfigCommit: g.getK8sConfigCommit(),
+		StartedAt:       g.runStartedAt,
+		FinishedAt:      now.Now(ctx),
+		Succeeded:       runErr == nil,
+	}
+	if runErr != nil {
+		record.Error = runErr.Error()
+	}
+	return record
+}
+
+// getUsername returns the name of the user running goldpushk, or "unknown" if it cannot be
+// determined.
+func getUsername() string {
+	if u, err := user.Current(); err == nil {
+		return u.Username
+	}
+	return "unknown"
+}
+
+// sortedKeys returns the keys of the given set in ascending order.
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
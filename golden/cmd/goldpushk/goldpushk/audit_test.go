@@ -0,0 +1,124 @@
+package goldpushk
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.skia.org/infra/go/exec"
+	"go.skia.org/infra/go/now"
+)
+
+// fakeAuditLog records the DeploymentRecords stored in it.
+type fakeAuditLog struct {
+	records []*DeploymentRecord
+}
+
+func (f *fakeAuditLog) put(_ context.Context, record *DeploymentRecord) error {
+	f.records = append(f.records, record)
+	return nil
+}
+
+var (
+	fakeRunStartedAt  = time.Date(2021, time.May, 4, 10, 0, 0, 0, time.UTC)
+	fakeRunFinishedAt = time.Date(2021, time.May, 4, 10, 25, 0, 0, time.UTC)
+)
+
+func TestGoldpushk_RecordDeployment_Success_RecordsDeployment(t *testing.T) {
+	corpUnit := getUnit(t, SkiaInfra, Frontend)
+	corpUnit.cluster = clusterSkiaCorp
+
+	fake := &fakeAuditLog{}
+	g := &Goldpushk{
+		canariedDeployableUnits: []DeployableUnit{getUnit(t, Skia, DiffCalculator)},
+		deployableUnits:         []DeployableUnit{getUnit(t, Chrome, Frontend), corpUnit},
+		goldSrcDir:              "/infra/golden",
+		k8sConfigRepoUrl:        "https://k8s-config.com",
+		k8sConfigCommits:        map[string]string{"https://k8s-config.com": "abcd1234"},
+		auditLog:                fake,
+		runStartedAt:            fakeRunStartedAt,
+	}
+
+	commandCollector := exec.CommandCollector{}
+	commandCollector.SetDelegateRun(func(_ context.Context, cmd *exec.Command) error {
+		_, err := cmd.CombinedOutput.Write([]byte("0123456789abcdef\n"))
+		return err
+	})
+	ctx := exec.NewContext(context.Background(), commandCollector.Run)
+	ctx = context.WithValue(ctx, now.ContextKey, fakeRunFinishedAt)
+
+	g.recordDeployment(ctx, nil)
+
+	require.Len(t, fake.records, 1)
+	record := fake.records[0]
+	assert.NotEmpty(t, record.User)
+	assert.Equal(t, []string{"gold-skia-diffcalculator", "gold-chrome-frontend", "gold-skia-infra-frontend"}, record.DeployableUnits)
+	assert.Equal(t, []string{"chrome", "skia", "skia-infra"}, record.Instances)
+	assert.Equal(t, []string{"skia-corp", "skia-public"}, record.Clusters)
+	assert.Equal(t, "0123456789abcdef", record.SkiaInfraCommit)
+	assert.Equal(t, "abcd1234", record.K8sConfigCommit)
+	assert.Equal(t, fakeRunStartedAt, record.StartedAt)
+	assert.Equal(t, fakeRunFinishedAt, record.FinishedAt)
+	assert.True(t, record.Succeeded)
+	assert.Empty(t, record.Error)
+}
+
+func TestGoldpushk_RecordDeployment_Failure_RecordsError(t *testing.T) {
+	fake := &fakeAuditLog{}
+	g := &Goldpushk{
+		deployableUnits:          []DeployableUnit{getUnit(t, Skia, Frontend)},
+		k8sConfigRepoUrl:         "https://k8s-config.com",
+		k8sConfigBaselineCommits: map[string]string{"https://k8s-config.com": "beef"},
+		auditLog:                 fake,
+	}
+
+	commandCollector := exec.CommandCollector{}
+	ctx := exec.NewContext(context.Background(), commandCollector.Run)
+
+	g.recordDeployment(ctx, errors.New("kubectl exploded"))
+
+	require.Len(t, fake.records, 1)
+	record := fake.records[0]
+	assert.Equal(t, "unknown", record.SkiaInfraCommit)
+	assert.Equal(t, "beef (no changes)", record.K8sConfigCommit)
+	assert.False(t, record.Succeeded)
+	assert.Equal(t, "kubectl exploded", record.Error)
+}
+
+func TestGoldpushk_RecordDeployment_ClusterWithOwnConfigRepo_RecordsCommitOfEachRepo(t *testing.T) {
+	europeUnit := getUnit(t, Chrome, Frontend)
+	europeUnit.cluster = cluster{name: "skia-europe", projectID: "skia-public", zone: "europe-west1-b", configRepo: "https://k8s-config-europe.com", configDir: "skia-europe"}
+
+	fake := &fakeAuditLog{}
+	g := &Goldpushk{
+		deployableUnits:          []DeployableUnit{getUnit(t, Skia, Frontend), europeUnit},
+		k8sConfigRepoUrl:         "https://k8s-config.com",
+		k8sConfigBaselineCommits: map[string]string{"https://k8s-config.com": "beef", "https://k8s-config-europe.com": "cafe"},
+		k8sConfigCommits:         map[string]string{"https://k8s-config-europe.com": "abcd1234"},
+		auditLog:                 fake,
+	}
+
+	commandCollector := exec.CommandCollector{}
+	ctx := exec.NewContext(context.Background(), commandCollector.Run)
+
+	g.recordDeployment(ctx, nil)
+
+	require.Len(t, fake.records, 1)
+	assert.Equal(t, "https://k8s-config.com: beef (no changes), https://k8s-config-europe.com: abcd1234", fake.records[0].K8sConfigCommit)
+}
+
+func TestGoldpushk_RecordDeployment_DryRun_DoesNotRecord(t *testing.T) {
+	fake := &fakeAuditLog{}
+	g := &Goldpushk{
+		deployableUnits: []DeployableUnit{getUnit(t, Skia, Frontend)},
+		dryRun:          true,
+		auditLog:        fake,
+	}
+
+	g.recordDeployment(context.Background(), nil)
+	assert.Empty(t, fake.records)
+}
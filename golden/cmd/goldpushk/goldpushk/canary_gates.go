@@ -0,0 +1,268 @@
+package goldpushk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+
+	"github.com/flynn/json5"
+	"go.skia.org/infra/go/httputils"
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/go/util"
+)
+
+// CanaryGate is a condition on a Prometheus metric that all canaried DeployableUnits must satisfy
+// before the remaining DeployableUnits are pushed.
+type CanaryGate struct {
+	// Name is a human-readable name for the gate, e.g. "error rate".
+	Name string `json:"name"`
+
+	// Query is a PromQL expression, written as a text/template which will be executed once per
+	// canaried DeployableUnit. Available template fields are .App (e.g. "gold-skia-frontend"),
+	// .Instance (e.g. "skia") and .Service (e.g. "frontend").
+	Query string `json:"query"`
+
+	// Min and Max are the inclusive bounds that every sample returned by the query must fall within.
+	// A nil bound is not checked.
+	Min *float64 `json:"min,omitempty"`
+	Max *float64 `json:"max,omitempty"`
+
+	// AllowNoData determines whether the gate passes if the query returns no samples (e.g. because
+	// the canaried service has not received any traffic yet).
+	AllowNoData bool `json:"allow_no_data"`
+}
+
+// DefaultCanaryGates returns the CanaryGates used if none are provided explicitly. They check that
+// all pods are up, and that the rate of HTTP 5xx responses and the 99th percentile of the request
+// latency stay within reasonable bounds.
+func DefaultCanaryGates() []CanaryGate {
+	one, maxErrorRate, maxLatencyMs := 1.0, 0.05, 5000.0
+	return []CanaryGate{
+		{
+			Name:  "liveness",
+			Query: `min(up{app="{{.App}}"})`,
+			Min:   &one,
+		},
+		{
+			Name:        "error rate",
+			Query:       `sum(rate(http_request_metrics{app="{{.App}}",status=~"5.."}[5m])) / sum(rate(http_request_metrics{app="{{.App}}"}[5m]))`,
+			Max:         &maxErrorRate,
+			AllowNoData: true,
+		},
+		{
+			Name:        "p99 latency (ms)",
+			Query:       `max(http_request_latency_ms{app="{{.App}}",quantile="0.99"})`,
+			Max:         &maxLatencyMs,
+			AllowNoData: true,
+		},
+	}
+}
+
+// LoadCanaryGates reads a JSON5 file containing a list of CanaryGates.
+func LoadCanaryGates(path string) ([]CanaryGate, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, skerr.Wrapf(err, "reading %s", path)
+	}
+	var gates []CanaryGate
+	if err := json5.Unmarshal(b, &gates); err != nil {
+		return nil, skerr.Wrapf(err, "parsing %s", path)
+	}
+	for _, gate := range gates {
+		if gate.Name == "" || gate.Query == "" {
+			return nil, skerr.Fmt("invalid canary gate %+v: name and query are required", gate)
+		}
+		if gate.Min == nil && gate.Max == nil {
+			return nil, skerr.Fmt("invalid canary gate %q: at least one of min or max is required", gate.Name)
+		}
+		if _, err := template.New(gate.Name).Parse(gate.Query); err != nil {
+			return nil, skerr.Wrapf(err, "invalid query for canary gate %q", gate.Name)
+		}
+	}
+	return gates, nil
+}
+
+// SetCanaryGates enables checking the given CanaryGates against the Prometheus server at the given
+// URL (e.g. "http://prometheus:9090") before promoting the canaries.
+func (g *Goldpushk) SetCanaryGates(prometheusURL string, gates []CanaryGate) {
+	g.prometheusURL = prometheusURL
+	g.canaryGates = gates
+	g.httpClient = httputils.NewTimeoutClient()
+}
+
+// canaryGateResult is the result of evaluating a CanaryGate for a single DeployableUnit.
+type canaryGateResult struct {
+	unit   DeployableUnit
+	gate   CanaryGate
+	values []float64
+	passed bool
+}
+
+// checkCanaryGates evaluates the CanaryGates for all canaried DeployableUnits and prints out the
+// results. If any gate fails, the user is asked whether to promote the canaries anyway. It returns
+// false if the remaining DeployableUnits should not be pushed.
+func (g *Goldpushk) checkCanaryGates(ctx context.Context) (bool, error) {
+	if len(g.canariedDeployableUnits) == 0 || len(g.canaryGates) == 0 {
+		return true, nil
+	}
+
+	if g.dryRun {
+		fmt.Println("\nSkipping canary gates (dry run).")
+		return true, nil
+	}
+
+	fmt.Printf("\nChecking canary gates against %s.\n", g.prometheusURL)
+	var results []canaryGateResult
+	for _, unit := range g.canariedDeployableUnits {
+		for _, gate := range g.canaryGates {
+			result, err := g.evaluateCanaryGate(ctx, unit, gate)
+			if err != nil {
+				return false, skerr.Wrap(err)
+			}
+			results = append(results, result)
+		}
+	}
+
+	// Print out a status table.
+	allPassed := true
+	w := tabwriter.NewWriter(os.Stdout, 10, 0, 2, ' ', 0)
+	if _, err := fmt.Fprintln(w, "\nPASSED\tGATE\tVALUES\tNAME"); err != nil {
+		return false, skerr.Wrap(err)
+	}
+	for _, result := range results {
+		passed := "Yes"
+		if !result.passed {
+			passed = "No"
+			allPassed = false
+		}
+		values := "<no data>"
+		if len(result.values) > 0 {
+			var valueStrs []string
+			for _, v := range result.values {
+				valueStrs = append(valueStrs, strconv.FormatFloat(v, 'g', 4, 64))
+			}
+			values = strings.Join(valueStrs, ",")
+		}
+		if _, err := fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", passed, result.gate.Name, values, result.unit.CanonicalName()); err != nil {
+			return false, skerr.Wrap(err)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return false, skerr.Wrap(err)
+	}
+
+	if allPassed {
+		return true, nil
+	}
+
+	// Give the user the option to override the failed gates.
+	ok, err := g.prompt(ctx, "\nSome canary gates failed. Push the remaining services anyway?")
+	if err != nil {
+		return false, skerr.Wrap(err)
+	}
+	if !ok {
+		fmt.Println("Aborting.")
+		return false, nil
+	}
+	return true, nil
+}
+
+// evaluateCanaryGate runs the query of the given CanaryGate for the given DeployableUnit and checks
+// the returned samples against the gate's bounds.
+func (g *Goldpushk) evaluateCanaryGate(ctx context.Context, unit DeployableUnit, gate CanaryGate) (canaryGateResult, error) {
+	t, err := template.New(gate.Name).Parse(gate.Query)
+	if err != nil {
+		return canaryGateResult{}, skerr.Wrapf(err, "parsing query for canary gate %q", gate.Name)
+	}
+	var query bytes.Buffer
+	if err := t.Execute(&query, map[string]string{
+		"App":      unit.CanonicalName(),
+		"Instance": string(unit.Instance),
+		"Service":  string(unit.Service),
+	}); err != nil {
+		return canaryGateResult{}, skerr.Wrapf(err, "executing query template for canary gate %q", gate.Name)
+	}
+
+	values, err := g.queryPrometheus(ctx, query.String())
+	if err != nil {
+		return canaryGateResult{}, skerr.Wrapf(err, "evaluating canary gate %q for %s", gate.Name, unit.CanonicalName())
+	}
+
+	result := canaryGateResult{
+		unit:   unit,
+		gate:   gate,
+		values: values,
+		passed: len(values) > 0 || gate.AllowNoData,
+	}
+	for _, v := range values {
+		if (gate.Min != nil && v < *gate.Min) || (gate.Max != nil && v > *gate.Max) {
+			result.passed = false
+		}
+	}
+	return result, nil
+}
+
+// prometheusQueryResponse is the subset of the response of Prometheus' /api/v1/query endpoint that
+// goldpushk cares about. See https://prometheus.io/docs/prometheus/latest/querying/api/.
+type prometheusQueryResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+	Data   struct {
+		ResultType string `json:"resultType"`
+		Result     []struct {
+			// Value is a [timestamp, "value"] pair.
+			Value []interface{} `json:"value"`
+		} `json:"result"`
+	} `json:"data"`
+}
+
+// queryPrometheus runs an instant query against the Prometheus server and returns the values of all
+// the samples in the resulting vector.
+func (g *Goldpushk) queryPrometheus(ctx context.Context, query string) ([]float64, error) {
+	u := strings.TrimSuffix(g.prometheusURL, "/") + "/api/v1/query?" + url.Values{"query": {query}}.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, skerr.Wrap(err)
+	}
+	resp, err := g.httpClient.Do(req)
+	if err != nil {
+		return nil, skerr.Wrapf(err, "querying Prometheus")
+	}
+	defer util.Close(resp.Body)
+
+	var queryResponse prometheusQueryResponse
+	if err := json.NewDecoder(resp.Body).Decode(&queryResponse); err != nil {
+		return nil, skerr.Wrapf(err, "decoding Prometheus response (HTTP %d)", resp.StatusCode)
+	}
+	if queryResponse.Status != "success" {
+		return nil, skerr.Fmt("Prometheus query %q failed: %s", query, queryResponse.Error)
+	}
+	if queryResponse.Data.ResultType != "vector" {
+		return nil, skerr.Fmt("Prometheus query %q returned a %s; expected a vector", query, queryResponse.Data.ResultType)
+	}
+
+	var values []float64
+	for _, sample := range queryResponse.Data.Result {
+		if len(sample.Value) != 2 {
+			return nil, skerr.Fmt("unexpected sample value %v", sample.Value)
+		}
+		valueStr, ok := sample.Value[1].(string)
+		if !ok {
+			return nil, skerr.Fmt("unexpected sample value %v", sample.Value)
+		}
+		v, err := strconv.ParseFloat(valueStr, 64)
+		if err != nil {
+			return nil, skerr.Wrapf(err, "parsing sample value %q", valueStr)
+		}
+		values = append(values, v)
+	}
+	return values, nil
+}
@@ -0,0 +1,170 @@
+package goldpushk
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGoldpushk_EvaluateCanaryGate_WithinBounds_Passes(t *testing.T) {
+	g, queries := newGoldpushkWithFakePrometheus(t, map[string]string{
+		`min(up{app="gold-skia-frontend"})`: `[{"metric": {}, "value": [1600000000, "1"]}]`,
+	})
+
+	unit := getUnit(t, Skia, Frontend)
+	result, err := g.evaluateCanaryGate(context.Background(), unit, DefaultCanaryGates()[0])
+	require.NoError(t, err)
+	assert.True(t, result.passed)
+	assert.Equal(t, []float64{1}, result.values)
+	assert.Equal(t, []string{`min(up{app="gold-skia-frontend"})`}, *queries)
+}
+
+func TestGoldpushk_EvaluateCanaryGate_OutOfBounds_Fails(t *testing.T) {
+	g, _ := newGoldpushkWithFakePrometheus(t, map[string]string{
+		`max(http_request_latency_ms{app="gold-skia-frontend",quantile="0.99"})`: `[{"metric": {"pod": "a"}, "value": [1600000000, "120"]}, {"metric": {"pod": "b"}, "value": [1600000000, "9000"]}]`,
+	})
+
+	unit := getUnit(t, Skia, Frontend)
+	result, err := g.evaluateCanaryGate(context.Background(), unit, DefaultCanaryGates()[2])
+	require.NoError(t, err)
+	assert.False(t, result.passed)
+	assert.Equal(t, []float64{120, 9000}, result.values)
+}
+
+func TestGoldpushk_EvaluateCanaryGate_NoData_PassesOnlyIfAllowed(t *testing.T) {
+	g, _ := newGoldpushkWithFakePrometheus(t, map[string]string{})
+
+	maxValue := 1.0
+	gate := CanaryGate{Name: "gate", Query: `foo{app="{{.App}}"}`, Max: &maxValue}
+	unit := getUnit(t, Skia, Frontend)
+
+	result, err := g.evaluateCanaryGate(context.Background(), unit, gate)
+	require.NoError(t, err)
+	assert.False(t, result.passed)
+
+	gate.AllowNoData = true
+	result, err = g.evaluateCanaryGate(context.Background(), unit, gate)
+	require.NoError(t, err)
+	assert.True(t, result.passed)
+}
+
+func TestGoldpushk_EvaluateCanaryGate_TemplateFields_Expanded(t *testing.T) {
+	g, queries := newGoldpushkWithFakePrometheus(t, map[string]string{})
+
+	minValue := 0.0
+	gate := CanaryGate{Name: "gate", Query: `foo{app="{{.App}}",instance="{{.Instance}}",service="{{.Service}}"}`, Min: &minValue, AllowNoData: true}
+	_, err := g.evaluateCanaryGate(context.Background(), getUnit(t, Chrome, Ingestion), gate)
+	require.NoError(t, err)
+	assert.Equal(t, []string{`foo{app="gold-chrome-ingestion",instance="chrome",service="ingestion"}`}, *queries)
+}
+
+func TestGoldpushk_CheckCanaryGates_GateFailsAndUserAborts_ReturnsFalse(t *testing.T) {
+	g, _ := newGoldpushkWithFakePrometheus(t, map[string]string{
+		`min(up{app="gold-skia-frontend"})`: `[{"metric": {}, "value": [1600000000, "0"]}]`,
+	})
+	g.canariedDeployableUnits = []DeployableUnit{getUnit(t, Skia, Frontend)}
+	g.canaryGates = DefaultCanaryGates()[:1]
+
+	fakeStdout, restoreStdout := hideStdout(t)
+	defer restoreStdout()
+	restoreStdin := fakeStdin(t, "n\n")
+	defer restoreStdin()
+
+	ok, err := g.checkCanaryGates(context.Background())
+	require.NoError(t, err)
+	assert.False(t, ok)
+	assert.Contains(t, readFakeStdout(t, fakeStdout), "Some canary gates failed.")
+}
+
+func TestGoldpushk_CheckCanaryGates_GateFailsAndUserOverrides_ReturnsTrue(t *testing.T) {
+	g, _ := newGoldpushkWithFakePrometheus(t, map[string]string{
+		`min(up{app="gold-skia-frontend"})`: `[{"metric": {}, "value": [1600000000, "0"]}]`,
+	})
+	g.canariedDeployableUnits = []DeployableUnit{getUnit(t, Skia, Frontend)}
+	g.canaryGates = DefaultCanaryGates()[:1]
+
+	_, restoreStdout := hideStdout(t)
+	defer restoreStdout()
+	restoreStdin := fakeStdin(t, "y\n")
+	defer restoreStdin()
+
+	ok, err := g.checkCanaryGates(context.Background())
+	require.NoError(t, err)
+	assert.True(t, ok)
+}
+
+func TestGoldpushk_CheckCanaryGates_AllGatesPass_ReturnsTrue(t *testing.T) {
+	g, _ := newGoldpushkWithFakePrometheus(t, map[string]string{
+		`min(up{app="gold-skia-frontend"})`: `[{"metric": {}, "value": [1600000000, "1"]}]`,
+	})
+	g.canariedDeployableUnits = []DeployableUnit{getUnit(t, Skia, Frontend)}
+	g.canaryGates = DefaultCanaryGates()
+
+	_, restoreStdout := hideStdout(t)
+	defer restoreStdout()
+
+	ok, err := g.checkCanaryGates(context.Background())
+	require.NoError(t, err)
+	assert.True(t, ok)
+}
+
+func TestLoadCanaryGates_Success(t *testing.T) {
+	path := writeConfigFile(t, "gates.json5", `[
+  {
+    name: "error rate",
+    query: "sum(rate(errors{app=\"{{.App}}\"}[5m]))",
+    max: 0.5,
+  },
+]`)
+
+	gates, err := LoadCanaryGates(path)
+	require.NoError(t, err)
+	require.Len(t, gates, 1)
+	assert.Equal(t, "error rate", gates[0].Name)
+	assert.Nil(t, gates[0].Min)
+	assert.Equal(t, 0.5, *gates[0].Max)
+}
+
+func TestLoadCanaryGates_NoBounds_Error(t *testing.T) {
+	path := writeConfigFile(t, "gates.json5", `[{name: "error rate", query: "errors"}]`)
+
+	_, err := LoadCanaryGates(path)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "at least one of min or max is required")
+}
+
+// newGoldpushkWithFakePrometheus returns a Goldpushk instance that talks to a fake Prometheus
+// server, which responds to the given queries with the given result vectors. Queries not in the
+// map return an empty vector. It also returns a pointer to the list of queries received.
+func newGoldpushkWithFakePrometheus(t *testing.T, results map[string]string) (*Goldpushk, *[]string) {
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/v1/query", r.URL.Path)
+		query := r.URL.Query().Get("query")
+		queries = append(queries, query)
+		result, ok := results[query]
+		if !ok {
+			result = "[]"
+		}
+		_, err := fmt.Fprintf(w, `{"status": "success", "data": {"resultType": "vector", "result": %s}}`, result)
+		require.NoError(t, err)
+	}))
+	t.Cleanup(server.Close)
+
+	g := &Goldpushk{}
+	g.SetCanaryGates(server.URL, nil)
+	return g, &queries
+}
+
+// getUnit returns the production DeployableUnit with the given instance and service.
+func getUnit(t *testing.T, instance Instance, service Service) DeployableUnit {
+	s := ProductionDeployableUnits()
+	unit, ok := s.Get(makeID(instance, service))
+	require.True(t, ok)
+	return unit
+}
@@ -0,0 +1,185 @@
+package goldpushk
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/flynn/json5"
+	"go.skia.org/infra/go/skerr"
+	"sigs.k8s.io/yaml"
+)
+
+// validNameRegexp matches valid Gold instance and service names. Since these names are used to
+// build Kubernetes object names (e.g. "gold-skia-diffcalculator"), they must be valid DNS labels.
+var validNameRegexp = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+
+// DeployableUnitSetConfig is the on-disk representation of a DeployableUnitSet. It can be written
+// as either JSON5 or YAML. Example (JSON5):
+//
+//	{
+//	  clusters: [
+//	    {
+//	      name: "skia-europe",
+//	      project: "skia-public",
+//	      zone: "europe-west1-b",
+//	      config_repo: "https://skia.googlesource.com/k8s-config-europe",
+//	      config_dir: "skia-europe",
+//	    },
+//	  ],
+//	  instances: [
+//	    {
+//	      name: "skia",
+//	      cluster: "skia-public",
+//	      services: ["diffcalculator", "frontend", "ingestion"],
+//	    },
+//	  ],
+//	}
+type DeployableUnitSetConfig struct {
+	// Clusters lists any Kubernetes clusters in addition to the built-in "skia-public" and
+	// "skia-corp" clusters.
+	Clusters []ClusterConfig `json:"clusters"`
+
+	Instances []InstanceConfig `json:"instances"`
+}
+
+// ClusterConfig describes a Kubernetes cluster on which Gold instances can be deployed.
+type ClusterConfig struct {
+	// Name is the name of the GKE cluster, e.g. "skia-public".
+	Name string `json:"name"`
+
+	// Project is the ID of the GCP project that owns the cluster, e.g. "skia-public".
+	Project string `json:"project"`
+
+	// Zone is the zone of the GKE cluster. Defaults to "us-central1-a".
+	Zone string `json:"zone"`
+
+	// ConfigRepo is the URL of the Git repository where configuration files for this cluster are
+	// checked in. Defaults to the k8s-config repository. Each repository is checked out, committed to
+	// and rolled back independently.
+	ConfigRepo string `json:"config_repo"`
+
+	// ConfigDir is the subdirectory of the above repository where configuration files for this
+	// cluster are checked in. Defaults to the name of the cluster.
+	ConfigDir string `json:"config_dir"`
+}
+
+// InstanceConfig describes a Gold instance and the services that should be deployed for it.
+type InstanceConfig struct {
+	// Name is the name of the Gold instance, e.g. "skia".
+	Name Instance `json:"name"`
+
+	// Cluster is the name of the Kubernetes cluster where all services for this instance will be
+	// deployed, e.g. "skia-public".
+	Cluster string `json:"cluster"`
+
+	// Services is the list of services to deploy for this instance, e.g. "frontend".
+	Services []Service `json:"services"`
+}
+
+// LoadDeployableUnitSet reads a DeployableUnitSetConfig from the given JSON5 or YAML file (the
+// format is inferred from the file extension), validates it and returns the corresponding
+// DeployableUnitSet.
+func LoadDeployableUnitSet(path string) (DeployableUnitSet, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return DeployableUnitSet{}, skerr.Wrapf(err, "reading %s", path)
+	}
+
+	var config DeployableUnitSetConfig
+	switch filepath.Ext(path) {
+	case ".yaml", ".yml":
+		if err := yaml.Unmarshal(b, &config); err != nil {
+			return DeployableUnitSet{}, skerr.Wrapf(err, "parsing %s as YAML", path)
+		}
+	case ".json", ".json5":
+		if err := json5.Unmarshal(b, &config); err != nil {
+			return DeployableUnitSet{}, skerr.Wrapf(err, "parsing %s as JSON5", path)
+		}
+	default:
+		return DeployableUnitSet{}, skerr.Fmt("unsupported file extension %q; must be one of .json, .json5, .yaml or .yml", filepath.Ext(path))
+	}
+
+	s, err := config.DeployableUnitSet()
+	if err != nil {
+		return DeployableUnitSet{}, skerr.Wrapf(err, "invalid config file %s", path)
+	}
+	return s, nil
+}
+
+// DeployableUnitSet validates the DeployableUnitSetConfig and returns the corresponding
+// DeployableUnitSet.
+func (c DeployableUnitSetConfig) DeployableUnitSet() (DeployableUnitSet, error) {
+	if len(c.Instances) == 0 {
+		return DeployableUnitSet{}, skerr.Fmt("no instances specified")
+	}
+
+	clusters := map[string]cluster{}
+	for _, builtInCluster := range builtInClusters {
+		clusters[builtInCluster.name] = builtInCluster
+	}
+	for _, clusterConfig := range c.Clusters {
+		if !validNameRegexp.MatchString(clusterConfig.Name) {
+			return DeployableUnitSet{}, skerr.Fmt("invalid cluster name %q", clusterConfig.Name)
+		}
+		if _, ok := clusters[clusterConfig.Name]; ok {
+			return DeployableUnitSet{}, skerr.Fmt("duplicate cluster %q", clusterConfig.Name)
+		}
+		if clusterConfig.Project == "" {
+			return DeployableUnitSet{}, skerr.Fmt("cluster %q: no project specified", clusterConfig.Name)
+		}
+		cluster := cluster{
+			name:       clusterConfig.Name,
+			projectID:  clusterConfig.Project,
+			zone:       clusterConfig.Zone,
+			configRepo: clusterConfig.ConfigRepo,
+			configDir:  clusterConfig.ConfigDir,
+		}
+		if cluster.zone == "" {
+			cluster.zone = defaultClusterZone
+		}
+		if cluster.configDir == "" {
+			cluster.configDir = cluster.name
+		}
+		clusters[cluster.name] = cluster
+	}
+
+	s := DeployableUnitSet{}
+	seenInstances := map[Instance]bool{}
+	for _, instanceConfig := range c.Instances {
+		instance := instanceConfig.Name
+		if !validNameRegexp.MatchString(string(instance)) {
+			return DeployableUnitSet{}, skerr.Fmt("invalid instance name %q", instance)
+		}
+		if seenInstances[instance] {
+			return DeployableUnitSet{}, skerr.Fmt("duplicate instance %q", instance)
+		}
+		seenInstances[instance] = true
+		s.knownInstances = append(s.knownInstances, instance)
+
+		cluster, ok := clusters[instanceConfig.Cluster]
+		if !ok {
+			return DeployableUnitSet{}, skerr.Fmt("instance %q: unknown cluster %q", instance, instanceConfig.Cluster)
+		}
+		options := DeploymentOptions{cluster: cluster}
+
+		if len(instanceConfig.Services) == 0 {
+			return DeployableUnitSet{}, skerr.Fmt("instance %q: no services specified", instance)
+		}
+		seenServices := map[Service]bool{}
+		for _, service := range instanceConfig.Services {
+			if !validNameRegexp.MatchString(string(service)) {
+				return DeployableUnitSet{}, skerr.Fmt("instance %q: invalid service name %q", instance, service)
+			}
+			if seenServices[service] {
+				return DeployableUnitSet{}, skerr.Fmt("instance %q: duplicate service %q", instance, service)
+			}
+			seenServices[service] = true
+			if !s.IsKnownService(service) {
+				s.knownServices = append(s.knownServices, service)
+			}
+			s.addWithOptions(instance, service, options)
+		}
+	}
+	return s, nil
+}
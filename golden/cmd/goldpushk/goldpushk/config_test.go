@@ -0,0 +1,147 @@
+package goldpushk
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadDeployableUnitSet_JSON5_Success(t *testing.T) {
+	path := writeConfigFile(t, "units.json5", `{
+  // Comments are allowed.
+  instances: [
+    {
+      name: "skia",
+      cluster: "skia-public",
+      services: ["diffcalculator", "frontend"],
+    },
+    {
+      name: "chrome",
+      cluster: "skia-corp",
+      services: ["frontend", "ingestion"],
+    },
+  ],
+}`)
+
+	s, err := LoadDeployableUnitSet(path)
+	require.NoError(t, err)
+	assertLoadedDeployableUnitSet(t, s)
+}
+
+func TestLoadDeployableUnitSet_YAML_Success(t *testing.T) {
+	path := writeConfigFile(t, "units.yaml", `
+instances:
+  - name: skia
+    cluster: skia-public
+    services: [diffcalculator, frontend]
+  - name: chrome
+    cluster: skia-corp
+    services: [frontend, ingestion]
+`)
+
+	s, err := LoadDeployableUnitSet(path)
+	require.NoError(t, err)
+	assertLoadedDeployableUnitSet(t, s)
+}
+
+func TestLoadDeployableUnitSet_UnsupportedExtension_Error(t *testing.T) {
+	path := writeConfigFile(t, "units.txt", `{}`)
+
+	_, err := LoadDeployableUnitSet(path)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "unsupported file extension")
+}
+
+func TestDeployableUnitSetConfig_DeployableUnitSet_InvalidConfigs_Error(t *testing.T) {
+	test := func(name string, config DeployableUnitSetConfig, expectedErr string) {
+		t.Run(name, func(t *testing.T) {
+			_, err := config.DeployableUnitSet()
+			require.Error(t, err)
+			require.Contains(t, err.Error(), expectedErr)
+		})
+	}
+
+	test("no instances", DeployableUnitSetConfig{}, "no instances specified")
+	test("invalid instance name", DeployableUnitSetConfig{
+		Instances: []InstanceConfig{{Name: "Skia_Public", Cluster: "skia-public", Services: []Service{Frontend}}},
+	}, `invalid instance name "Skia_Public"`)
+	test("duplicate instance", DeployableUnitSetConfig{
+		Instances: []InstanceConfig{
+			{Name: Skia, Cluster: "skia-public", Services: []Service{Frontend}},
+			{Name: Skia, Cluster: "skia-public", Services: []Service{Ingestion}},
+		},
+	}, `duplicate instance "skia"`)
+	test("unknown cluster", DeployableUnitSetConfig{
+		Instances: []InstanceConfig{{Name: Skia, Cluster: "skia-private", Services: []Service{Frontend}}},
+	}, `unknown cluster "skia-private"`)
+	test("invalid cluster name", DeployableUnitSetConfig{
+		Clusters:  []ClusterConfig{{Name: "Skia Europe", Project: "skia-public"}},
+		Instances: []InstanceConfig{{Name: Skia, Cluster: "skia-public", Services: []Service{Frontend}}},
+	}, `invalid cluster name "Skia Europe"`)
+	test("duplicate cluster", DeployableUnitSetConfig{
+		Clusters:  []ClusterConfig{{Name: "skia-corp", Project: "skia-corp"}},
+		Instances: []InstanceConfig{{Name: Skia, Cluster: "skia-public", Services: []Service{Frontend}}},
+	}, `duplicate cluster "skia-corp"`)
+	test("cluster without project", DeployableUnitSetConfig{
+		Clusters:  []ClusterConfig{{Name: "skia-europe"}},
+		Instances: []InstanceConfig{{Name: Skia, Cluster: "skia-europe", Services: []Service{Frontend}}},
+	}, `cluster "skia-europe": no project specified`)
+	test("no services", DeployableUnitSetConfig{
+		Instances: []InstanceConfig{{Name: Skia, Cluster: "skia-public"}},
+	}, `instance "skia": no services specified`)
+	test("invalid service name", DeployableUnitSetConfig{
+		Instances: []InstanceConfig{{Name: Skia, Cluster: "skia-public", Services: []Service{"front end"}}},
+	}, `invalid service name "front end"`)
+	test("duplicate service", DeployableUnitSetConfig{
+		Instances: []InstanceConfig{{Name: Skia, Cluster: "skia-public", Services: []Service{Frontend, Frontend}}},
+	}, `duplicate service "frontend"`)
+}
+
+func TestDeployableUnitSetConfig_DeployableUnitSet_AdditionalClusters_Success(t *testing.T) {
+	config := DeployableUnitSetConfig{
+		Clusters: []ClusterConfig{
+			{Name: "skia-europe", Project: "skia-public", Zone: "europe-west1-b", ConfigRepo: "https://k8s-config-europe.com", ConfigDir: "skia-europe-config"},
+			{Name: "skia-asia", Project: "skia-public"},
+		},
+		Instances: []InstanceConfig{
+			{Name: Skia, Cluster: "skia-europe", Services: []Service{Frontend}},
+			{Name: Chrome, Cluster: "skia-asia", Services: []Service{Frontend}},
+		},
+	}
+
+	s, err := config.DeployableUnitSet()
+	require.NoError(t, err)
+
+	unit, ok := s.Get(makeID(Skia, Frontend))
+	require.True(t, ok)
+	require.Equal(t, cluster{name: "skia-europe", projectID: "skia-public", zone: "europe-west1-b", configRepo: "https://k8s-config-europe.com", configDir: "skia-europe-config"}, unit.cluster)
+	unit, ok = s.Get(makeID(Chrome, Frontend))
+	require.True(t, ok)
+	require.Equal(t, cluster{name: "skia-asia", projectID: "skia-public", zone: "us-central1-a", configDir: "skia-asia"}, unit.cluster)
+}
+
+// assertLoadedDeployableUnitSet asserts that the given DeployableUnitSet matches the one described
+// by the config files used in the tests above.
+func assertLoadedDeployableUnitSet(t *testing.T, s DeployableUnitSet) {
+	require.Equal(t, []Instance{Skia, Chrome}, s.KnownInstances())
+	require.Equal(t, []Service{DiffCalculator, Frontend, Ingestion}, s.KnownServices())
+
+	unit, ok := s.Get(makeID(Skia, DiffCalculator))
+	require.True(t, ok)
+	require.Equal(t, clusterSkiaPublic, unit.cluster)
+	unit, ok = s.Get(makeID(Chrome, Ingestion))
+	require.True(t, ok)
+	require.Equal(t, clusterSkiaCorp, unit.cluster)
+	_, ok = s.Get(makeID(Chrome, DiffCalculator))
+	require.False(t, ok)
+}
+
+// writeConfigFile writes the given contents to a file with the given name inside a temporary
+// directory, and returns the path to said file.
+func writeConfigFile(t *testing.T, name, contents string) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(contents), 0644))
+	return path
+}
@@ -0,0 +1,141 @@
+package goldpushk
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"sigs.k8s.io/yaml"
+
+	"go.skia.org/infra/go/exec"
+	"go.skia.org/infra/go/skerr"
+)
+
+// configChecksumAnnotation is the annotation holding the checksum of the instance-specific
+// configuration files. It is set on the ConfigMaps pushed by goldpushk, and on the pod templates of
+// the generated deployment files via the CONFIG_CHECKSUM template variable. Because the pod
+// templates only change when the checksum changes, pods are restarted only when the content of the
+// ConfigMap actually changed.
+const configChecksumAnnotation = "gold.skia.org/config-checksum"
+
+// configMapManifest is a Kubernetes ConfigMap, as expected by "kubectl apply".
+type configMapManifest struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Metadata   struct {
+		Name        string            `json:"name"`
+		Annotations map[string]string `json:"annotations"`
+	} `json:"metadata"`
+	Data map[string]string `json:"data"`
+}
+
+// readConfigMapData reads the file(s) at the given path with the same semantics as
+// "kubectl create configmap --from-file <path>": if path is a file, its basename is used as the key;
+// if it is a directory, each regular file directly inside it becomes a key, and anything else is
+// ignored.
+func readConfigMapData(path string) (map[string]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, skerr.Wrap(err)
+	}
+
+	var files []string
+	if info.IsDir() {
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return nil, skerr.Wrapf(err, "listing %s", path)
+		}
+		for _, entry := range entries {
+			if entry.Type().IsRegular() {
+				files = append(files, filepath.Join(path, entry.Name()))
+			}
+		}
+	} else {
+		files = []string{path}
+	}
+
+	data := map[string]string{}
+	for _, file := range files {
+		b, err := os.ReadFile(file)
+		if err != nil {
+			return nil, skerr.Wrapf(err, "reading %s", file)
+		}
+		data[filepath.Base(file)] = string(b)
+	}
+	return data, nil
+}
+
+// configMapChecksum returns a checksum of the given ConfigMap data which only depends on its keys
+// and values.
+func configMapChecksum(data map[string]string) string {
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	h := sha256.New()
+	for _, key := range keys {
+		// Keys and values are NUL-terminated so that different splits of the same bytes between keys
+		// and values produce different checksums.
+		_, _ = h.Write([]byte(key))
+		_, _ = h.Write([]byte{0})
+		_, _ = h.Write([]byte(data[key]))
+		_, _ = h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// getInstanceConfigChecksum returns the checksum of the instance-specific configuration files for
+// the given instance, i.e. the value of the configChecksumAnnotation of its ConfigMap.
+func (g *Goldpushk) getInstanceConfigChecksum(instance Instance) (string, error) {
+	data, err := readConfigMapData(g.getInstanceSpecificConfigDir(instance))
+	if err != nil {
+		return "", skerr.Wrap(err)
+	}
+	return configMapChecksum(data), nil
+}
+
+// applyConfigMap creates or updates a ConfigMap with the given name from the file(s) at the given
+// path. This is equivalent to "kubectl create configmap <name> --from-file <path> --dry-run=client
+// -o yaml | kubectl apply -f -", i.e. the ConfigMap is updated in place and is never missing from
+// the cluster.
+func (g *Goldpushk) applyConfigMap(ctx context.Context, path, configMapName string) error {
+	b, err := makeConfigMapManifest(path, configMapName)
+	if err != nil {
+		return skerr.Wrap(err)
+	}
+
+	cmd := makeExecCommand("kubectl", []string{"apply", "-f", "-"}, g.verbose)
+	cmd.Stdin = bytes.NewReader(b)
+	if err := exec.Run(ctx, cmd); err != nil {
+		return skerr.Wrapf(err, "failed to run %s to apply ConfigMap %s", cmdToDebugStr(cmd), configMapName)
+	}
+	return nil
+}
+
+// makeConfigMapManifest returns the YAML manifest of a ConfigMap with the given name and the
+// file(s) at the given path as its data.
+func makeConfigMapManifest(path, configMapName string) ([]byte, error) {
+	data, err := readConfigMapData(path)
+	if err != nil {
+		return nil, skerr.Wrap(err)
+	}
+
+	manifest := configMapManifest{
+		APIVersion: "v1",
+		Kind:       "ConfigMap",
+		Data:       data,
+	}
+	manifest.Metadata.Name = configMapName
+	manifest.Metadata.Annotations = map[string]string{configChecksumAnnotation: configMapChecksum(data)}
+	b, err := yaml.Marshal(manifest)
+	if err != nil {
+		return nil, skerr.Wrap(err)
+	}
+	return b, nil
+}
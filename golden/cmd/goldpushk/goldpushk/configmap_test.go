@@ -0,0 +1,72 @@
+package goldpushk
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.skia.org/infra/go/exec"
+)
+
+func TestReadConfigMapData_Directory_ReadsRegularFilesOnly(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.json5"), []byte("alpha"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "b.json5"), []byte("beta"), 0644))
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "subdir"), 0755))
+
+	data, err := readConfigMapData(dir)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"a.json5": "alpha", "b.json5": "beta"}, data)
+}
+
+func TestReadConfigMapData_File_UsesBasenameAsKey(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "a.json5")
+	require.NoError(t, os.WriteFile(path, []byte("alpha"), 0644))
+
+	data, err := readConfigMapData(path)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"a.json5": "alpha"}, data)
+}
+
+func TestConfigMapChecksum_DependsOnlyOnContents(t *testing.T) {
+	checksum := configMapChecksum(map[string]string{"a": "alpha", "b": "beta"})
+	assert.Len(t, checksum, 64)
+	assert.Equal(t, checksum, configMapChecksum(map[string]string{"b": "beta", "a": "alpha"}))
+	assert.NotEqual(t, checksum, configMapChecksum(map[string]string{"a": "alpha", "b": "gamma"}))
+	assert.NotEqual(t, configMapChecksum(map[string]string{"ab": "c"}), configMapChecksum(map[string]string{"a": "bc"}))
+}
+
+func TestGoldpushk_ApplyConfigMap_AppliesManifestFromStdin(t *testing.T) {
+	goldSrcDir := createFakeGoldSrcDir(t, Skia)
+	g := &Goldpushk{goldSrcDir: goldSrcDir}
+
+	// Set up mocks.
+	var manifest string
+	commandCollector := exec.CommandCollector{}
+	commandCollector.SetDelegateRun(func(_ context.Context, cmd *exec.Command) error {
+		b, err := io.ReadAll(cmd.Stdin)
+		require.NoError(t, err)
+		manifest = string(b)
+		return nil
+	})
+	ctx := exec.NewContext(context.Background(), commandCollector.Run)
+
+	// Call code under test.
+	require.NoError(t, g.pushConfigurationJSON(ctx, Skia))
+
+	assertCommandsMatch(t, &commandCollector, []string{"kubectl apply -f -"})
+	assert.Equal(t, `apiVersion: v1
+data:
+  skia.json5: '{instance: "skia"}'
+kind: ConfigMap
+metadata:
+  annotations:
+    gold.skia.org/config-checksum: `+fakeConfigChecksum(Skia)+`
+  name: gold-skia-config
+`, manifest)
+}
@@ -5,7 +5,9 @@
 // all the services goldpushk is able to manage.
 //
 // Function ProductionDeployableUnits is the source of truth of goldpushk, and should be updated to
-// reflect any relevant changes in configuration.
+// reflect any relevant changes in configuration. Alternatively, function LoadDeployableUnitSet
+// reads a DeployableUnitSet from a JSON5 or YAML file, which allows managing new Gold instances
+// without recompiling goldpushk.
 //
 // For testing, function TestingDeployableUnits should be used instead, which only contains
 // information about testing services that can be deployed the public or corp clusters without
@@ -14,13 +16,14 @@ package goldpushk
 
 import (
 	"context"
-	"errors"
 	"fmt"
+	"net/http"
 	"os"
-	osexec "os/exec"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
+	"sync"
 	"text/tabwriter"
 	"time"
 
@@ -30,6 +33,8 @@ import (
 	"go.skia.org/infra/go/now"
 	"go.skia.org/infra/go/skerr"
 	"go.skia.org/infra/go/sklog"
+	"go.skia.org/infra/go/util"
+	"golang.org/x/sync/errgroup"
 )
 
 const (
@@ -48,9 +53,8 @@ const (
 	// status of the affected pods.
 	delayBetweenPushAndMonitoring = 10 * time.Second
 
-	// Kubernetes does not like colons in its strings, so we can't use time.RFC3999 (or any of the
-	// provided formats) as is. This replaces the colons with underscores.
-	rfc3999KubernetesSafe = "2006-01-02T15_04_05Z07_00"
+	// Zone of the GKE clusters, unless otherwise specified.
+	defaultClusterZone = "us-central1-a"
 )
 
 // cluster represents a Kubernetes cluster on which to deploy DeployableUnits, and contains all the
@@ -58,11 +62,23 @@ const (
 type cluster struct {
 	name      string // e.g. "skia-corp".
 	projectID string // e.g. "google.com:skia-corp".
+	zone      string // e.g. "us-central1-a".
+
+	// URL of the Git repository where the configuration files for this cluster are checked in. If
+	// empty, defaults to the k8s-config repository.
+	configRepo string
+
+	// Subdirectory of the above repository where the configuration files for this cluster are
+	// checked in, e.g. "skia-corp".
+	configDir string
 }
 
 var (
-	clusterSkiaPublic = cluster{name: "skia-public", projectID: "skia-public"}
-	clusterSkiaCorp   = cluster{name: "skia-corp", projectID: "google.com:skia-corp"}
+	clusterSkiaPublic = cluster{name: "skia-public", projectID: "skia-public", zone: defaultClusterZone, configDir: "skia-public"}
+	clusterSkiaCorp   = cluster{name: "skia-corp", projectID: "google.com:skia-corp", zone: defaultClusterZone, configDir: "skia-corp"}
+
+	// builtInClusters are the clusters that goldpushk knows about without any configuration.
+	builtInClusters = []cluster{clusterSkiaPublic, clusterSkiaCorp}
 )
 
 // Goldpushk contains information about the deployment steps to be carried out.
@@ -76,16 +92,72 @@ type Goldpushk struct {
 	minUptimeSeconds           int
 	uptimePollFrequencySeconds int
 
+	// If true, the monitoring step determines readiness from the rollout status of Deployments and
+	// StatefulSets rather than from container start timestamps.
+	useRolloutStatus bool
+
+	// Time at which the rollout of each DeployableUnit was first observed as complete. Used to
+	// compute uptimes for StatefulSets when useRolloutStatus is true.
+	rolloutCompleteSince map[DeployableUnitID]time.Time
+
+	// How long to watch the pushed DeployableUnits for crash loops after the monitoring step. Zero
+	// disables this step.
+	soakPeriod time.Duration
+
+	// Prometheus server and conditions to check before promoting the canaries. No conditions are
+	// checked if canaryGates is empty.
+	prometheusURL string
+	canaryGates   []CanaryGate
+	httpClient    *http.Client
+
+	// Maximum number of DeployableUnits to push concurrently to a given cluster. Values smaller
+	// than 2 mean that DeployableUnits will be pushed sequentially.
+	parallelism int
+
+	// Record of this run's progress. Nil if journaling is disabled.
+	journal *journal
+
 	// Other constructor parameters.
 	k8sConfigRepoUrl string
 	verbose          bool
 
-	// Checked out Git repository with k8s config files.
-	k8sConfigCheckout *git.TempCheckout
+	// Checked out Git repositories with k8s config files, keyed by repository URL. This includes the
+	// k8s-config repository, and any other repositories used by the clusters of the DeployableUnits.
+	k8sConfigCheckouts map[string]*git.TempCheckout
+
+	// Commits at which the above repositories were checked out, i.e. before any configuration files
+	// were regenerated, or those recorded in the journal if resuming a run that committed them. Keyed
+	// by repository URL. Used to roll back crash-looping DeployableUnits.
+	k8sConfigBaselineCommits map[string]string
+
+	// Commits pushed to the above repositories with the regenerated configuration files, if any.
+	// Keyed by repository URL.
+	k8sConfigCommits map[string]string
+
+	// Sends a notification when the deployment finishes. Nil if notifications are disabled.
+	notifier deploymentNotifier
+
+	// Records every deployment. Nil if the audit log is disabled.
+	auditLog auditLog
+
+	// Time at which the deployment started, i.e. after the user confirmed it.
+	runStartedAt time.Time
+
+	// Lock that prevents concurrent deployments. Nil if locking is disabled.
+	lockStore      lockStore
+	lockTTL        time.Duration
+	forceBreakLock bool
 
 	// The Kubernetes cluster that the kubectl command is currently configured to use.
 	currentCluster cluster
 
+	// Container images to deploy instead of those in the templates.
+	imagePins  map[DeployableUnitID]string
+	sameAsProd bool
+
+	// Deployments and StatefulSets in each cluster, as read by --same-as-prod.
+	prodWorkloads map[cluster][]k8sWorkload
+
 	// Miscellaneous.
 	unitTest bool // Disables confirmation prompt from unit tests.
 
@@ -107,59 +179,109 @@ func New(deployableUnits []DeployableUnit, canariedDeployableUnits []DeployableU
 	}
 }
 
+// SetParallelism sets the maximum number of DeployableUnits that will be pushed concurrently to a
+// given cluster. By default, DeployableUnits are pushed sequentially.
+func (g *Goldpushk) SetParallelism(parallelism int) {
+	g.parallelism = parallelism
+}
+
 // Run carries out the deployment steps.
 func (g *Goldpushk) Run(ctx context.Context) error {
 	// Print out list of targeted deployable units, and ask for confirmation.
-	if ok, err := g.printOutInputsAndAskConfirmation(); err != nil {
-		return skerr.Wrap(err)
-	} else if !ok {
-		return nil
-	}
-
-	// Check out k8s-config.
-	if err := g.checkOutK8sConfigRepo(ctx); err != nil {
-		return skerr.Wrap(err)
-	}
-	defer g.k8sConfigCheckout.Delete()
-
-	// Regenerate config files.
-	if err := g.regenerateConfigFiles(ctx); err != nil {
-		return skerr.Wrap(err)
-	}
-
-	// Commit config files, giving the user the option to abort.
-	if ok, err := g.commitConfigFiles(ctx); err != nil {
+	if ok, err := g.printOutInputsAndAskConfirmation(ctx); err != nil {
 		return skerr.Wrap(err)
 	} else if !ok {
 		return nil
 	}
+	g.runStartedAt = now.Now(ctx)
 
-	// Deploy canaries.
-	if err := g.pushCanaries(ctx); err != nil {
-		return skerr.Wrap(err)
-	}
-
-	// Monitor canaries.
-	if err := g.monitorCanaries(ctx); err != nil {
+	// Make sure nobody else is deploying at the same time.
+	releaseLock, err := g.acquireLock(ctx)
+	if err != nil {
 		return skerr.Wrap(err)
 	}
+	defer releaseLock()
 
-	// Deploy remaining DeployableUnits.
-	if err := g.pushServices(ctx); err != nil {
+	// Check out k8s-config, and any other repositories with configuration files.
+	defer g.deleteK8sConfigCheckouts()
+	if err := g.checkOutK8sConfigRepos(ctx); err != nil {
 		return skerr.Wrap(err)
 	}
 
-	// Monitor remaining DeployableUnits.
-	if err := g.monitorServices(ctx); err != nil {
-		return skerr.Wrap(err)
+	// Run the remaining steps in order, skipping any steps completed by a previous run if resuming
+	// from a journal. Each step returns false if the user chose to abort.
+	steps := []struct {
+		name string
+		fn   func(context.Context) (bool, error)
+		// onResume, if not nil, runs instead of fn if the step was completed by a previous run.
+		onResume func(context.Context) error
+	}{
+		// Regenerate and commit config files, giving the user the option to abort. When resuming, the
+		// commit made by the previous run might never have landed (e.g. --no-commit, --dryrun or a
+		// pending submit), so the config files are regenerated without committing them again, rather
+		// than assuming that the fresh checkout contains them.
+		{name: stepCommitConfigFiles, fn: g.regenerateAndCommitConfigFiles, onResume: g.regenerateConfigFiles},
+		// Validate the generated files against the clusters before applying any of them.
+		{name: stepValidateConfigFiles, fn: alwaysProceed(g.validateConfigFiles)},
+		// Deploy canaries.
+		{name: stepPushCanaries, fn: alwaysProceed(g.pushCanaries)},
+		// Monitor canaries.
+		{name: stepMonitorCanaries, fn: alwaysProceed(g.monitorCanaries)},
+		// Watch canaries for crash loops, rolling them back if necessary.
+		{name: stepSoakCanaries, fn: alwaysProceed(g.soakAndRollBackCanaries)},
+		// Check the canary gates, giving the user the option to abort if any of them fail.
+		{name: stepCheckCanaryGates, fn: g.checkCanaryGates},
+		// Deploy remaining DeployableUnits.
+		{name: stepPushServices, fn: alwaysProceed(g.pushServices)},
+		// Monitor remaining DeployableUnits.
+		{name: stepMonitorServices, fn: alwaysProceed(g.monitorServices)},
+		// Watch remaining DeployableUnits for crash loops, rolling them back if necessary.
+		{name: stepSoakServices, fn: alwaysProceed(g.soakAndRollBackServices)},
+	}
+	for _, step := range steps {
+		if g.journal.isStepCompleted(step.name) {
+			if step.onResume == nil {
+				fmt.Printf("\nSkipping step %s (completed by a previous run).\n", step.name)
+				continue
+			}
+			fmt.Printf("\nResuming step %s (completed by a previous run).\n", step.name)
+			if err := step.onResume(ctx); err != nil {
+				if journalErr := g.journal.failStep(ctx, step.name, err); journalErr != nil {
+					sklog.Errorf("Failed to update journal: %s", journalErr)
+				}
+				g.notifyDeploymentFinished(ctx, err)
+				g.recordDeployment(ctx, err)
+				return skerr.Wrap(err)
+			}
+			continue
+		}
+		ok, err := step.fn(ctx)
+		if err != nil {
+			if journalErr := g.journal.failStep(ctx, step.name, err); journalErr != nil {
+				sklog.Errorf("Failed to update journal: %s", journalErr)
+			}
+			g.notifyDeploymentFinished(ctx, err)
+			g.recordDeployment(ctx, err)
+			return skerr.Wrap(err)
+		}
+		if !ok {
+			return nil
+		}
+		if err := g.journal.completeStep(ctx, step.name); err != nil {
+			return skerr.Wrap(err)
+		}
 	}
+	g.notifyDeploymentFinished(ctx, nil)
+	g.recordDeployment(ctx, nil)
 
 	// Give the user a chance to examine the generated files before exiting and cleaning up the Git
 	// repository.
 	if g.dryRun {
-		fmt.Println("\nDry-run finished. Any generated files can be found in the k8s-config Git repository checkout below:")
-		fmt.Printf("  %s\n", g.k8sConfigCheckout.GitDir)
-		fmt.Println("Press enter to delete the checkout above and exit.")
+		fmt.Println("\nDry-run finished. Any generated files can be found in the Git repository checkouts below:")
+		for _, repoUrl := range g.getK8sConfigRepoUrls() {
+			fmt.Printf("  %s\n", g.k8sConfigCheckouts[repoUrl].GitDir)
+		}
+		fmt.Println("Press enter to delete the checkouts above and exit.")
 		if _, err := fmt.Scanln(); err != nil {
 			return skerr.Wrap(err)
 		}
@@ -168,9 +290,20 @@ func (g *Goldpushk) Run(ctx context.Context) error {
 	return nil
 }
 
+// alwaysProceed adapts a deployment step that cannot be aborted by the user to the signature of
+// the steps in Goldpushk.Run().
+func alwaysProceed(fn func(context.Context) error) func(context.Context) (bool, error) {
+	return func(ctx context.Context) (bool, error) {
+		if err := fn(ctx); err != nil {
+			return false, skerr.Wrap(err)
+		}
+		return true, nil
+	}
+}
+
 // printOutInputsAndAskConfirmation prints out a summary of the actions to be
 // taken, then asks the user for confirmation.
-func (g *Goldpushk) printOutInputsAndAskConfirmation() (bool, error) {
+func (g *Goldpushk) printOutInputsAndAskConfirmation(ctx context.Context) (bool, error) {
 	// Skip if running from an unit test.
 	if g.unitTest {
 		return true, nil
@@ -188,9 +321,21 @@ func (g *Goldpushk) printOutInputsAndAskConfirmation() (bool, error) {
 	for _, d := range g.deployableUnits {
 		fmt.Printf("  %s\n", d.CanonicalName())
 	}
+	if len(g.imagePins) != 0 {
+		fmt.Println("\nThe following container images are pinned:")
+		var pinned []string
+		for id, image := range g.imagePins {
+			pinned = append(pinned, fmt.Sprintf("  %s: %s", id.CanonicalName(), image))
+		}
+		sort.Strings(pinned)
+		fmt.Println(strings.Join(pinned, "\n"))
+	}
+	if g.sameAsProd {
+		fmt.Println("\nAll other container images will be the same as in production.")
+	}
 
 	// Ask for confirmation, ending execution by default.
-	ok, err := prompt("\nProceed?")
+	ok, err := g.prompt(ctx, "\nProceed?")
 	if err != nil {
 		return false, skerr.Wrap(err)
 	}
@@ -202,31 +347,110 @@ func (g *Goldpushk) printOutInputsAndAskConfirmation() (bool, error) {
 	return true, nil
 }
 
-// prompt prints out a question to stdout and scans a y/n answer from stdin.
-func prompt(question string) (bool, error) {
+// prompt prints out a question to stdout and scans a y/n answer from stdin. The answer is recorded
+// in the journal, if any.
+func (g *Goldpushk) prompt(ctx context.Context, question string) (bool, error) {
 	fmt.Printf("%s (y/N): ", question)
 	var input string
 	if _, err := fmt.Scanln(&input); err != nil {
 		return false, skerr.Wrapf(err, "unable to read from standard input")
 	}
-	if input != "y" {
-		return false, nil
+	ok := input == "y"
+	if err := g.journal.confirmation(ctx, strings.TrimSpace(question), ok); err != nil {
+		return false, skerr.Wrap(err)
 	}
-	return true, nil
+	return ok, nil
 }
 
-// checkOutK8sConfigRepo checks out the k8s-config Git repository.
-func (g *Goldpushk) checkOutK8sConfigRepo(ctx context.Context) error {
+// checkOutK8sConfigRepos checks out the k8s-config Git repository, and any other repositories where
+// the configuration files of the DeployableUnits are checked in. The checked out commit of each
+// repository becomes its baseline for any rollbacks, unless a baseline was restored from the journal
+// of a previous run.
+func (g *Goldpushk) checkOutK8sConfigRepos(ctx context.Context) error {
 	fmt.Println()
-	var err error
-	g.k8sConfigCheckout, err = git.NewTempCheckout(ctx, g.k8sConfigRepoUrl)
-	if err != nil {
-		return skerr.Wrapf(err, "failed to check out %s", g.k8sConfigRepoUrl)
+	if g.k8sConfigCheckouts == nil {
+		g.k8sConfigCheckouts = map[string]*git.TempCheckout{}
+	}
+	if g.k8sConfigBaselineCommits == nil {
+		g.k8sConfigBaselineCommits = map[string]string{}
+	}
+	for _, repoUrl := range g.getK8sConfigRepoUrls() {
+		checkout, err := git.NewTempCheckout(ctx, repoUrl)
+		if err != nil {
+			return skerr.Wrapf(err, "failed to check out %s", repoUrl)
+		}
+		g.k8sConfigCheckouts[repoUrl] = checkout
+		if g.k8sConfigBaselineCommits[repoUrl] == "" {
+			g.k8sConfigBaselineCommits[repoUrl], err = checkout.FullHash(ctx, "HEAD")
+			if err != nil {
+				return skerr.Wrap(err)
+			}
+		}
+		fmt.Printf("Cloned Git repository %s at %s.\n", repoUrl, string(checkout.GitDir))
 	}
-	fmt.Printf("Cloned Git repository %s at %s.\n", g.k8sConfigRepoUrl, string(g.k8sConfigCheckout.GitDir))
 	return nil
 }
 
+// deleteK8sConfigCheckouts deletes the checkouts created by checkOutK8sConfigRepos.
+func (g *Goldpushk) deleteK8sConfigCheckouts() {
+	for _, checkout := range g.k8sConfigCheckouts {
+		checkout.Delete()
+	}
+}
+
+// getK8sConfigRepoUrls returns the URLs of the Git repositories where the configuration files of
+// the DeployableUnits are checked in. The k8s-config repository always comes first, followed by any
+// other repositories in the order in which their clusters first appear.
+func (g *Goldpushk) getK8sConfigRepoUrls() []string {
+	repoUrls := []string{g.k8sConfigRepoUrl}
+	for _, units := range [][]DeployableUnit{g.deployableUnits, g.canariedDeployableUnits} {
+		for _, unit := range units {
+			if repoUrl := g.getK8sConfigRepoUrl(getCluster(unit)); !util.In(repoUrl, repoUrls) {
+				repoUrls = append(repoUrls, repoUrl)
+			}
+		}
+	}
+	return repoUrls
+}
+
+// getK8sConfigRepoUrl returns the URL of the Git repository where the configuration files for the
+// given cluster are checked in.
+func (g *Goldpushk) getK8sConfigRepoUrl(c cluster) string {
+	if c.configRepo != "" {
+		return c.configRepo
+	}
+	return g.k8sConfigRepoUrl
+}
+
+// getK8sConfigCheckout returns the checkout of the Git repository where the configuration files for
+// the given cluster are checked in.
+func (g *Goldpushk) getK8sConfigCheckout(c cluster) *git.TempCheckout {
+	return g.k8sConfigCheckouts[g.getK8sConfigRepoUrl(c)]
+}
+
+// describeK8sConfigRepo returns a description of the Git repository with the given URL suitable
+// for messages to the user.
+func (g *Goldpushk) describeK8sConfigRepo(repoUrl string) string {
+	if repoUrl == g.k8sConfigRepoUrl {
+		return "the k8s-config Git repository"
+	}
+	return fmt.Sprintf("Git repository %s", repoUrl)
+}
+
+// regenerateAndCommitConfigFiles regenerates the config files and commits them to the k8s-config
+// repository (and any other repositories with configuration files). It returns false if the user
+// chose to abort.
+func (g *Goldpushk) regenerateAndCommitConfigFiles(ctx context.Context) (bool, error) {
+	if err := g.regenerateConfigFiles(ctx); err != nil {
+		return false, skerr.Wrap(err)
+	}
+	ok, err := g.commitConfigFiles(ctx)
+	if err != nil {
+		return false, skerr.Wrap(err)
+	}
+	return ok, nil
+}
+
 // regenerateConfigFiles regenerates the .yaml and .json5 files for each
 // instance/service pair that will be deployed. Any generated files will be
 // checked into the corresponding Git repository with configuration files.
@@ -307,13 +531,35 @@ func (g *Goldpushk) getDeploymentFilePath(unit DeployableUnit) string {
 
 // getGitRepoSubdirPath returns the path to the subdirectory inside the k8s-config
 // repository checkout in which the config files for the given DeployableUnit
-// should be checked in  (e.g. /path/to/k8s-config/skia-public-config).
+// should be checked in  (e.g. /path/to/k8s-config/skia-public-config). If the
+// cluster of the DeployableUnit has its own repository, its checkout is used
+// instead.
 func (g *Goldpushk) getGitRepoSubdirPath(unit DeployableUnit) string {
-	subdir := clusterSkiaPublic.name
-	if unit.internal {
-		subdir = clusterSkiaCorp.name
+	c := getCluster(unit)
+	return filepath.Join(string(g.getK8sConfigCheckout(c).GitDir), c.configDir)
+}
+
+// getCluster returns the Kubernetes cluster where the given DeployableUnit should be deployed.
+func getCluster(unit DeployableUnit) cluster {
+	if unit.cluster == (cluster{}) {
+		return clusterSkiaPublic
 	}
-	return filepath.Join(string(g.k8sConfigCheckout.GitDir), subdir)
+	return unit.cluster
+}
+
+// groupByCluster groups the given DeployableUnits by the cluster where they should be deployed.
+// Clusters are returned in the order in which they first appear in the given slice.
+func groupByCluster(units []DeployableUnit) ([]cluster, map[cluster][]DeployableUnit) {
+	var clusters []cluster
+	unitsByCluster := map[cluster][]DeployableUnit{}
+	for _, unit := range units {
+		c := getCluster(unit)
+		if _, ok := unitsByCluster[c]; !ok {
+			clusters = append(clusters, c)
+		}
+		unitsByCluster[c] = append(unitsByCluster[c], unit)
+	}
+	return clusters, unitsByCluster
 }
 
 // expandTemplate executes the kube-conf-gen command with arguments sufficient to produce the
@@ -329,10 +575,25 @@ func (g *Goldpushk) expandTemplate(ctx context.Context, unit DeployableUnit, tem
 	serviceJSON5 := fmt.Sprintf("%s-%s.json5", unit.Instance, unit.Service)
 	serviceJSON5 = filepath.Join(g.getInstanceSpecificConfigDir(unit.Instance), serviceJSON5)
 
-	err := g.execCmd(ctx, "kube-conf-gen", []string{
+	configChecksum, err := g.getInstanceConfigChecksum(unit.Instance)
+	if err != nil {
+		return skerr.Wrap(err)
+	}
+
+	imageVars, err := g.getImageTemplateVars(ctx, unit)
+	if err != nil {
+		return skerr.Wrap(err)
+	}
+
+	args := []string{
 		// Notes on the kube-conf-gen arguments used:
 		//   - Flag "-extra INSTANCE_ID:<instanceStr>" binds template variable
 		//     INSTANCE_ID to instanceStr.
+		//   - Flag "-extra CONFIG_CHECKSUM:<configChecksum>" binds the checksum
+		//     of the instance's ConfigMap, which the templates use to restart
+		//     pods only when the ConfigMap's contents change.
+		//   - Flags "-extra <IMAGE_VAR>:<image>" (if any) override the container
+		//     images set in the templates.
 		//   - Flag "-strict" will make kube-conf-gen fail in the presence of
 		//     unsupported types, missing data, etc.
 		//   - Flag "-parse_conf=false" prevents the values read from the JSON5
@@ -342,12 +603,15 @@ func (g *Goldpushk) expandTemplate(ctx context.Context, unit DeployableUnit, tem
 		"-c", instanceJSON5,
 		"-c", serviceJSON5,
 		"-extra", "INSTANCE_ID:" + instanceStr,
-		"-extra", "NOW:" + now.Now(ctx).Format(rfc3999KubernetesSafe),
+		"-extra", "CONFIG_CHECKSUM:" + configChecksum,
+	}
+	args = append(args, imageTemplateVarsToExtraArgs(imageVars)...)
+	args = append(args,
 		"-t", templatePath,
 		"-parse_conf=false", "-strict",
 		"-o", outputPath,
-	})
-	if err != nil {
+	)
+	if err := g.execCmd(ctx, "kube-conf-gen", args); err != nil {
 		return skerr.Wrap(err)
 	}
 	sklog.Infof("Generated %s", outputPath)
@@ -355,11 +619,20 @@ func (g *Goldpushk) expandTemplate(ctx context.Context, unit DeployableUnit, tem
 }
 
 // commitConfigFiles prints out a summary of the changes to be committed to
-// k8s-config, asks for confirmation and pushes those changes.
+// k8s-config (and any other repositories with configuration files), asks for
+// confirmation and pushes those changes.
 func (g *Goldpushk) commitConfigFiles(ctx context.Context) (bool, error) {
-	// Print out summary of changes (git status -s).
-	if err := g.printOutGitStatus(ctx); err != nil {
-		return false, skerr.Wrap(err)
+	repoUrls := g.getK8sConfigRepoUrls()
+	for _, repoUrl := range repoUrls {
+		// Print out summary of changes (git status -s).
+		if err := g.printOutGitStatus(ctx, repoUrl); err != nil {
+			return false, skerr.Wrap(err)
+		}
+
+		// Print out the changes themselves (git diff).
+		if err := g.printOutGitDiff(ctx, repoUrl); err != nil {
+			return false, skerr.Wrap(err)
+		}
 	}
 
 	// Skip if --no-commit or --dryrun.
@@ -373,7 +646,7 @@ func (g *Goldpushk) commitConfigFiles(ctx context.Context) (bool, error) {
 	}
 
 	// Ask for confirmation.
-	ok, err := prompt("\nCommit and push the above changes? Answering no will abort execution.")
+	ok, err := g.prompt(ctx, "\nCommit and push the above changes? Answering no will abort execution.")
 	if err != nil {
 		return false, skerr.Wrap(err)
 	}
@@ -382,41 +655,85 @@ func (g *Goldpushk) commitConfigFiles(ctx context.Context) (bool, error) {
 	}
 	fmt.Println()
 
-	// Skip if the k8s-config checkout has no changes (i.e. if "git status -s" prints out nothing).
-	stdout, err := g.k8sConfigCheckout.Git(ctx, "status", "-s")
+	for _, repoUrl := range repoUrls {
+		if err := g.pushConfigFiles(ctx, repoUrl); err != nil {
+			return false, skerr.Wrap(err)
+		}
+	}
+	return true, nil
+}
+
+// pushConfigFiles commits and pushes any changes in the checkout of the Git repository with the
+// given URL, and records the pushed commit.
+func (g *Goldpushk) pushConfigFiles(ctx context.Context, repoUrl string) error {
+	checkout := g.k8sConfigCheckouts[repoUrl]
+
+	// Skip if the checkout has no changes (i.e. if "git status -s" prints out nothing).
+	stdout, err := checkout.Git(ctx, "status", "-s")
 	if err != nil {
-		return false, skerr.Wrap(err)
+		return skerr.Wrap(err)
 	}
 	if len(stdout) == 0 {
-		return true, nil
+		return nil
 	}
 
 	// Add, commit and push changes.
-	fmt.Printf("Pushing changes to the k8s-config Git repository.\n")
-	if _, err := g.k8sConfigCheckout.Git(ctx, "add", "."); err != nil {
-		return false, skerr.Wrap(err)
+	fmt.Printf("Pushing changes to %s.\n", g.describeK8sConfigRepo(repoUrl))
+	if _, err := checkout.Git(ctx, "add", "."); err != nil {
+		return skerr.Wrap(err)
 	}
 	message := "Push\n\n" + rubberstamper.RandomChangeID(ctx)
-	if _, err := g.k8sConfigCheckout.Git(ctx, "commit", "-m", message); err != nil {
-		return false, skerr.Wrap(err)
+	if _, err := checkout.Git(ctx, "commit", "-m", message); err != nil {
+		return skerr.Wrap(err)
 	}
-	if _, err := g.k8sConfigCheckout.Git(ctx, "push", git.DefaultRemote, rubberstamper.PushRequestAutoSubmit); err != nil {
-		return false, skerr.Wrap(err)
+	if _, err := checkout.Git(ctx, "push", git.DefaultRemote, rubberstamper.PushRequestAutoSubmit); err != nil {
+		return skerr.Wrap(err)
 	}
-
-	return true, nil
+	hash, err := checkout.FullHash(ctx, "HEAD")
+	if err != nil {
+		return skerr.Wrap(err)
+	}
+	if g.k8sConfigCommits == nil {
+		g.k8sConfigCommits = map[string]string{}
+	}
+	g.k8sConfigCommits[repoUrl] = hash
+	if err := g.journal.k8sConfigCommitted(ctx, repoUrl, g.k8sConfigBaselineCommits[repoUrl], hash); err != nil {
+		return skerr.Wrap(err)
+	}
+	return nil
 }
 
-// printOutGitStatus runs "git status -s" on the k8s-config checkout and prints its output to stdout.
-func (g *Goldpushk) printOutGitStatus(ctx context.Context) error {
-	stdout, err := g.k8sConfigCheckout.Git(ctx, "status", "-s")
+// printOutGitStatus runs "git status -s" on the checkout of the Git repository with the given URL
+// and prints its output to stdout.
+func (g *Goldpushk) printOutGitStatus(ctx context.Context, repoUrl string) error {
+	stdout, err := g.k8sConfigCheckouts[repoUrl].Git(ctx, "status", "-s")
 	if err != nil {
 		return skerr.Wrap(err)
 	}
 	if len(stdout) == 0 {
-		fmt.Printf("\nNo changes to be pushed to the k8s-config Git repository.\n")
+		fmt.Printf("\nNo changes to be pushed to %s.\n", g.describeK8sConfigRepo(repoUrl))
 	} else {
-		fmt.Printf("\nChanges to be pushed to the k8s-config Git repository:\n")
+		fmt.Printf("\nChanges to be pushed to %s:\n", g.describeK8sConfigRepo(repoUrl))
+		fmt.Print(stdout)
+	}
+	return nil
+}
+
+// printOutGitDiff prints out a unified diff between the files in the checkout of the Git repository
+// with the given URL as of the last commit and the regenerated files, including any newly created
+// files.
+func (g *Goldpushk) printOutGitDiff(ctx context.Context, repoUrl string) error {
+	checkout := g.k8sConfigCheckouts[repoUrl]
+	// Mark any untracked files as intent-to-add so that they show up in the diff as new files.
+	if _, err := checkout.Git(ctx, "add", "--intent-to-add", "."); err != nil {
+		return skerr.Wrap(err)
+	}
+	stdout, err := checkout.Git(ctx, "diff", "--no-color", "--no-ext-diff")
+	if err != nil {
+		return skerr.Wrap(err)
+	}
+	if len(stdout) != 0 {
+		fmt.Printf("\nDiff of the changes to be pushed to %s:\n", g.describeK8sConfigRepo(repoUrl))
 		fmt.Print(stdout)
 	}
 	return nil
@@ -440,7 +757,7 @@ func (g *Goldpushk) monitorCanaries(ctx context.Context) error {
 	if len(g.canariedDeployableUnits) == 0 {
 		return nil
 	}
-	if err := g.monitor(ctx, g.canariedDeployableUnits, g.getUptimes, time.Sleep); err != nil {
+	if err := g.monitor(ctx, g.canariedDeployableUnits, g.getUptimesFn(), time.Sleep); err != nil {
 		return skerr.Wrap(err)
 	}
 	return nil
@@ -463,7 +780,7 @@ func (g *Goldpushk) pushServices(ctx context.Context) error {
 // monitorServices monitors the non-canaried DeployableUnits after they have been pushed to
 // production.
 func (g *Goldpushk) monitorServices(ctx context.Context) error {
-	if err := g.monitor(ctx, g.deployableUnits, g.getUptimes, time.Sleep); err != nil {
+	if err := g.monitor(ctx, g.deployableUnits, g.getUptimesFn(), time.Sleep); err != nil {
 		return skerr.Wrap(err)
 	}
 	return nil
@@ -477,27 +794,42 @@ func (g *Goldpushk) pushDeployableUnits(ctx context.Context, units []DeployableU
 		return nil
 	}
 
+	if g.parallelism > 1 {
+		return g.pushDeployableUnitsInParallel(ctx, units)
+	}
+
 	// We want to make sure we push configs for an instance only once on a given deploy command.
 	instanceSpecificConfigMapsPushed := map[Instance]bool{}
-	for _, unit := range units {
+	for _, unit := range g.skipPushedUnits(units) {
 		if err := g.pushSingleDeployableUnit(ctx, unit, instanceSpecificConfigMapsPushed); err != nil {
 			return skerr.Wrap(err)
 		}
+		if err := g.journal.unitPushed(ctx, unit); err != nil {
+			return skerr.Wrap(err)
+		}
 	}
 	return nil
 }
 
+// skipPushedUnits returns the given DeployableUnits minus any that the journal lists as already
+// pushed by a previous run.
+func (g *Goldpushk) skipPushedUnits(units []DeployableUnit) []DeployableUnit {
+	var unitsToPush []DeployableUnit
+	for _, unit := range units {
+		if g.journal.isUnitPushed(unit) {
+			fmt.Printf("%s: skipping (pushed by a previous run).\n", unit.CanonicalName())
+			continue
+		}
+		unitsToPush = append(unitsToPush, unit)
+	}
+	return unitsToPush
+}
+
 // pushSingleDeployableUnit pushes the given DeployableUnit to the corresponding cluster by running
 // "kubectl apply -f path/to/config.yaml".
 func (g *Goldpushk) pushSingleDeployableUnit(ctx context.Context, unit DeployableUnit, instanceSpecificConfigMapsPushed map[Instance]bool) error {
-	// Get the cluster corresponding to the given DeployableUnit.
-	cluster := clusterSkiaPublic
-	if unit.internal {
-		cluster = clusterSkiaCorp
-	}
-
-	// Switch clusters.
-	if err := g.switchClusters(ctx, cluster); err != nil {
+	// Switch to the cluster corresponding to the given DeployableUnit.
+	if err := g.switchClusters(ctx, getCluster(unit)); err != nil {
 		return skerr.Wrap(err)
 	}
 
@@ -517,47 +849,90 @@ func (g *Goldpushk) pushSingleDeployableUnit(ctx context.Context, unit Deployabl
 	return nil
 }
 
+// pushDeployableUnitsInParallel groups the given DeployableUnits by cluster and pushes them one
+// cluster at a time. Within each cluster, the instance-specific ConfigMaps are pushed first, and
+// then up to g.parallelism "kubectl apply" commands are run concurrently.
+//
+// Pushing a DeployableUnit does not stop if another one fails. Instead, the errors for all failed
+// DeployableUnits are aggregated and returned as a single error.
+func (g *Goldpushk) pushDeployableUnitsInParallel(ctx context.Context, units []DeployableUnit) error {
+	var failures []string
+	clusters, unitsByCluster := groupByCluster(g.skipPushedUnits(units))
+	for _, cluster := range clusters {
+		unitsInCluster := unitsByCluster[cluster]
+
+		// kubectl can only be configured to operate on one cluster at a time, so we switch clusters
+		// before spawning any goroutines.
+		if err := g.switchClusters(ctx, cluster); err != nil {
+			return skerr.Wrap(err)
+		}
+
+		// Push the instance-specific ConfigMaps before any DeployableUnits that might depend on them.
+		instanceSpecificConfigMapsPushed := map[Instance]bool{}
+		for _, unit := range unitsInCluster {
+			if !instanceSpecificConfigMapsPushed[unit.Instance] {
+				instanceSpecificConfigMapsPushed[unit.Instance] = true
+				if err := g.pushConfigurationJSON(ctx, unit.Instance); err != nil {
+					return skerr.Wrap(err)
+				}
+			}
+		}
+
+		// Apply the DeployableUnits concurrently.
+		var mutex sync.Mutex
+		var eg errgroup.Group
+		eg.SetLimit(g.parallelism)
+		for _, unit := range unitsInCluster {
+			unit := unit
+			eg.Go(func() error {
+				path := g.getDeploymentFilePath(unit)
+				fmt.Printf("%s: applying %s.\n", unit.CanonicalName(), path)
+				err := g.execCmd(ctx, "kubectl", []string{"apply", "-f", path})
+				if err == nil {
+					err = g.journal.unitPushed(ctx, unit)
+				}
+				if err != nil {
+					mutex.Lock()
+					defer mutex.Unlock()
+					failures = append(failures, fmt.Sprintf("%s: %s", unit.CanonicalName(), err))
+				}
+				// Errors are aggregated above so that one failure does not prevent other units from
+				// being pushed.
+				return nil
+			})
+		}
+		_ = eg.Wait()
+	}
+
+	if len(failures) > 0 {
+		sort.Strings(failures)
+		return skerr.Fmt("failed to push %d DeployableUnit(s):\n%s", len(failures), strings.Join(failures, "\n"))
+	}
+	return nil
+}
+
 // pushConfigurationJSON pushes all the configuration files for a given instance. This includes
 // all JSON5 files for all services. This is done because all services overlap with some common
 // configuration.
 func (g *Goldpushk) pushConfigurationJSON(ctx context.Context, instance Instance) error {
-	configMapName := fmt.Sprintf("gold-%s-config", instance)
 	instanceConfigDirectory := g.getInstanceSpecificConfigDir(instance)
-	if err := g.pushConfigMap(ctx, instanceConfigDirectory, configMapName); err != nil {
+	if err := g.applyConfigMap(ctx, instanceConfigDirectory, getInstanceConfigMapName(instance)); err != nil {
 		return skerr.Wrapf(err, "pushing the configuration files at %s", instanceConfigDirectory)
 	}
 	return nil
 }
 
-// pushConfigMap pushes the file(s) at a given path as a config map with the given name. It deletes
-// any pre-existing map before, so as to overwrite it. The path for config maps may be to a single
-// file or an entire directory.
-func (g *Goldpushk) pushConfigMap(ctx context.Context, path, configMapName string) error {
-	// Delete existing ConfigMap.
-	if err := g.execCmd(ctx, "kubectl", []string{"delete", "configmap", configMapName}); err != nil {
-		// Command "kubectl delete configmap" returns exit code 1 when the ConfigMap does not exist on
-		// the cluster.
-		var exitError *osexec.ExitError
-		if errors.As(skerr.Unwrap(err), &exitError) && exitError.ExitCode() == 1 {
-			sklog.Infof("Did not delete ConfigMap %s as it does not exist on the cluster.", configMapName)
-		} else {
-			return skerr.Wrap(err)
-		}
-	}
-
-	// Create new ConfigMap.
-	if err := g.execCmd(ctx, "kubectl", []string{"create", "configmap", configMapName, "--from-file", path}); err != nil {
-		return skerr.Wrap(err)
-	}
-
-	return nil
+// getInstanceConfigMapName returns the name of the ConfigMap with the configuration files for the
+// given instance.
+func getInstanceConfigMapName(instance Instance) string {
+	return fmt.Sprintf("gold-%s-config", instance)
 }
 
 // switchClusters runs the "gcloud" command necessary to switch kubectl to the given cluster.
 func (g *Goldpushk) switchClusters(ctx context.Context, cluster cluster) error {
 	if g.currentCluster != cluster {
 		sklog.Infof("Switching to cluster %s\n", cluster.name)
-		if err := g.execCmd(ctx, "gcloud", []string{"container", "clusters", "get-credentials", cluster.name, "--zone", "us-central1-a", "--project", cluster.projectID}); err != nil {
+		if err := g.execCmd(ctx, "gcloud", []string{"container", "clusters", "get-credentials", cluster.name, "--zone", cluster.zone, "--project", cluster.projectID}); err != nil {
 			return skerr.Wrap(err)
 		}
 		g.currentCluster = cluster
@@ -680,36 +1055,17 @@ func (g *Goldpushk) monitor(ctx context.Context, units []DeployableUnit, getUpti
 }
 
 // getUptimes groups the given DeployableUnits by cluster, calls getUptimesSingleCluster once per
-// cluster, and returns the union of the uptimes returned by both calls to getUptimesSingleCluster.
+// cluster, and returns the union of the uptimes returned by all calls to getUptimesSingleCluster.
 func (g *Goldpushk) getUptimes(ctx context.Context, units []DeployableUnit) (map[DeployableUnitID]time.Duration, error) {
 	// Group units by cluster.
-	var publicUnits []DeployableUnit
-	var corpUnits []DeployableUnit
-	for _, unit := range units {
-		if unit.internal {
-			corpUnits = append(corpUnits, unit)
-		} else {
-			publicUnits = append(publicUnits, unit)
-		}
-	}
+	clusters, unitsByCluster := groupByCluster(units)
 
-	// This will hold the uptimes from both clusters.
+	// This will hold the uptimes from all clusters.
 	allUptimes := make(map[DeployableUnitID]time.Duration)
 
 	// Once per cluster.
-	for i := 0; i < 2; i++ {
-		// Select the right cluster and DeployableUnits.
-		cluster := clusterSkiaPublic
-		units := publicUnits
-		if i == 1 {
-			cluster = clusterSkiaCorp
-			units = corpUnits
-		}
-
-		// Skip if no units.
-		if len(units) == 0 {
-			continue
-		}
+	for _, cluster := range clusters {
+		units := unitsByCluster[cluster]
 
 		// Switch to the current cluster.
 		if err := g.switchClusters(ctx, cluster); err != nil {
@@ -2,9 +2,11 @@ package goldpushk
 
 import (
 	"context"
+	"errors"
 	"io"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -66,22 +68,22 @@ func TestGoldpushk_CheckOutK8sConfigGitRepository_Success(t *testing.T) {
 	defer restoreStdout()
 
 	// Check out the fake k8s-config repository created earlier by running "git clone file://...".
-	err := g.checkOutK8sConfigRepo(ctx)
+	err := g.checkOutK8sConfigRepos(ctx)
 
 	// Assert that no errors occurred and that we have a git.TempCheckout for the cloned repo.
 	require.NoError(t, err)
-	require.NotNil(t, g.k8sConfigCheckout)
+	require.NotNil(t, g.getK8sConfigCheckout(clusterSkiaPublic))
 
 	// Clean up the checkout after the test finishes.
-	defer g.k8sConfigCheckout.Delete()
+	defer g.deleteK8sConfigCheckouts()
 
 	// Assert that the local path to the checkout is not the same as the local path to the fake
 	// k8s-config repo created earlier. This is just a basic correctness check to ensure that we're
 	// actually dealing with a clone of the original repo, as opposed to the original repo itself.
-	require.NotEqual(t, g.k8sConfigCheckout.GitDir, fakeK8sConfig.Dir())
+	require.NotEqual(t, g.getK8sConfigCheckout(clusterSkiaPublic).GitDir, fakeK8sConfig.Dir())
 
 	// Read README.md from the checkout.
-	k8sConfigReadmeMdBytes, err := os.ReadFile(filepath.Join(string(g.k8sConfigCheckout.GitDir), "README.md"))
+	k8sConfigReadmeMdBytes, err := os.ReadFile(filepath.Join(string(g.getK8sConfigCheckout(clusterSkiaPublic).GitDir), "README.md"))
 	require.NoError(t, err)
 
 	// Assert that file README.md has the expected contents.
@@ -99,7 +101,7 @@ func TestGoldpushk_GetDeploymentFilePath_Success(t *testing.T) {
 	s := ProductionDeployableUnits()
 	publicUnit, _ := s.Get(makeID(Skia, DiffCalculator))
 
-	require.Equal(t, filepath.Join(g.k8sConfigCheckout.Dir(), "skia-public", "gold-skia-diffcalculator.yaml"), g.getDeploymentFilePath(publicUnit))
+	require.Equal(t, filepath.Join(g.getK8sConfigCheckout(clusterSkiaPublic).Dir(), "skia-public", "gold-skia-diffcalculator.yaml"), g.getDeploymentFilePath(publicUnit))
 }
 
 func TestGoldpushk_RegenerateConfigFiles_Success(t *testing.T) {
@@ -117,7 +119,7 @@ func TestGoldpushk_RegenerateConfigFiles_Success(t *testing.T) {
 	g := Goldpushk{
 		deployableUnits:         deployableUnits,
 		canariedDeployableUnits: canariedDeployableUnits,
-		goldSrcDir:              "/path/to/buildbot/golden",
+		goldSrcDir:              createFakeGoldSrcDir(t, Skia, SkiaPublic),
 
 		// Fake out the copy
 		disableCopyingConfigsToCheckout: true,
@@ -137,39 +139,39 @@ func TestGoldpushk_RegenerateConfigFiles_Success(t *testing.T) {
 	expectedCommands := []string{
 		// Skia DiffCalculator
 		"kube-conf-gen " +
-			"-c /path/to/buildbot/golden/k8s-config-templates/gold-common.json5 " +
-			"-c /path/to/buildbot/golden/k8s-instances/skia/skia.json5 " +
-			"-c /path/to/buildbot/golden/k8s-instances/skia/skia-diffcalculator.json5 " +
+			"-c " + g.goldSrcDir + "/k8s-config-templates/gold-common.json5 " +
+			"-c " + g.goldSrcDir + "/k8s-instances/skia/skia.json5 " +
+			"-c " + g.goldSrcDir + "/k8s-instances/skia/skia-diffcalculator.json5 " +
 			"-extra INSTANCE_ID:skia " +
-			"-extra NOW:2020-07-06T05_04_03Z_00 " +
-			"-t /path/to/buildbot/golden/k8s-config-templates/gold-diffcalculator-template.yaml " +
+			"-extra CONFIG_CHECKSUM:" + fakeConfigChecksum(Skia) + " " +
+			"-t " + g.goldSrcDir + "/k8s-config-templates/gold-diffcalculator-template.yaml " +
 			"-parse_conf=false " +
 			"-strict " +
-			"-o " + g.k8sConfigCheckout.Dir() + "/skia-public/gold-skia-diffcalculator.yaml",
+			"-o " + g.getK8sConfigCheckout(clusterSkiaPublic).Dir() + "/skia-public/gold-skia-diffcalculator.yaml",
 
 		// SkiaPublic Frontend
 		"kube-conf-gen " +
-			"-c /path/to/buildbot/golden/k8s-config-templates/gold-common.json5 " +
-			"-c /path/to/buildbot/golden/k8s-instances/skia-public/skia-public.json5 " +
-			"-c /path/to/buildbot/golden/k8s-instances/skia-public/skia-public-frontend.json5 " +
+			"-c " + g.goldSrcDir + "/k8s-config-templates/gold-common.json5 " +
+			"-c " + g.goldSrcDir + "/k8s-instances/skia-public/skia-public.json5 " +
+			"-c " + g.goldSrcDir + "/k8s-instances/skia-public/skia-public-frontend.json5 " +
 			"-extra INSTANCE_ID:skia-public " +
-			"-extra NOW:2020-07-06T05_04_03Z_00 " +
-			"-t /path/to/buildbot/golden/k8s-config-templates/gold-frontend-template.yaml " +
+			"-extra CONFIG_CHECKSUM:" + fakeConfigChecksum(SkiaPublic) + " " +
+			"-t " + g.goldSrcDir + "/k8s-config-templates/gold-frontend-template.yaml " +
 			"-parse_conf=false " +
 			"-strict " +
-			"-o " + g.k8sConfigCheckout.Dir() + "/skia-public/gold-skia-public-frontend.yaml",
+			"-o " + g.getK8sConfigCheckout(clusterSkiaPublic).Dir() + "/skia-public/gold-skia-public-frontend.yaml",
 
 		// Skia IngestionBT
 		"kube-conf-gen " +
-			"-c /path/to/buildbot/golden/k8s-config-templates/gold-common.json5 " +
-			"-c /path/to/buildbot/golden/k8s-instances/skia/skia.json5 " +
-			"-c /path/to/buildbot/golden/k8s-instances/skia/skia-ingestion.json5 " +
+			"-c " + g.goldSrcDir + "/k8s-config-templates/gold-common.json5 " +
+			"-c " + g.goldSrcDir + "/k8s-instances/skia/skia.json5 " +
+			"-c " + g.goldSrcDir + "/k8s-instances/skia/skia-ingestion.json5 " +
 			"-extra INSTANCE_ID:skia " +
-			"-extra NOW:2020-07-06T05_04_03Z_00 " +
-			"-t /path/to/buildbot/golden/k8s-config-templates/gold-ingestion-template.yaml " +
+			"-extra CONFIG_CHECKSUM:" + fakeConfigChecksum(Skia) + " " +
+			"-t " + g.goldSrcDir + "/k8s-config-templates/gold-ingestion-template.yaml " +
 			"-parse_conf=false " +
 			"-strict " +
-			"-o " + g.k8sConfigCheckout.Dir() + "/skia-public/gold-skia-ingestion.yaml",
+			"-o " + g.getK8sConfigCheckout(clusterSkiaPublic).Dir() + "/skia-public/gold-skia-ingestion.yaml",
 	}
 
 	assertCommandsMatch(t, &commandCollector, expectedCommands)
@@ -196,12 +198,12 @@ func TestGoldpushk_CommitConfigFiles_Success(t *testing.T) {
 	defer restoreStdout()
 
 	// Check out the fake k8s-config repository created earlier by running "git clone file://...".
-	err := g.checkOutK8sConfigRepo(ctx)
+	err := g.checkOutK8sConfigRepos(ctx)
 	require.NoError(t, err)
-	defer g.k8sConfigCheckout.Delete()
+	defer g.deleteK8sConfigCheckouts()
 
 	// Add changes to the k8s-config repository checkout.
-	writeFileIntoRepo(t, g.k8sConfigCheckout, "foo.yaml", "I'm a change in k8s-config.")
+	writeFileIntoRepo(t, g.getK8sConfigCheckout(clusterSkiaPublic), "foo.yaml", "I'm a change in k8s-config.")
 
 	// Pretend that the user confirms the commit step.
 	cleanup := fakeStdin(t, "y\n")
@@ -236,12 +238,12 @@ func TestGoldpushk_CommitConfigFiles_UserAborts_DoesNotCommit(t *testing.T) {
 	defer restoreStdout()
 
 	// Check out the fake k8s-config repository created earlier by running "git clone file://...".
-	err := g.checkOutK8sConfigRepo(ctx)
+	err := g.checkOutK8sConfigRepos(ctx)
 	require.NoError(t, err)
-	defer g.k8sConfigCheckout.Delete()
+	defer g.deleteK8sConfigCheckouts()
 
 	// Add changes to the k8s-config repository checkout.
-	writeFileIntoRepo(t, g.k8sConfigCheckout, "foo.yaml", "I'm a change in k8s-config.")
+	writeFileIntoRepo(t, g.getK8sConfigCheckout(clusterSkiaPublic), "foo.yaml", "I'm a change in k8s-config.")
 
 	// Pretend that the user aborts the commit step.
 	restoreStdin := fakeStdin(t, "n\n")
@@ -280,12 +282,12 @@ func TestGoldpushk_CommitConfigFiles_FlagNoCommitSet_DoesNotCommit(t *testing.T)
 	defer restoreStdout()
 
 	// Check out the fake k8s-config repository created earlier by running "git clone file://...".
-	err := g.checkOutK8sConfigRepo(ctx)
+	err := g.checkOutK8sConfigRepos(ctx)
 	require.NoError(t, err)
-	defer g.k8sConfigCheckout.Delete()
+	defer g.deleteK8sConfigCheckouts()
 
 	// Add changes to the k8s-config repository checkout.
-	writeFileIntoRepo(t, g.k8sConfigCheckout, "foo.yaml", "I'm a change in k8s-config.")
+	writeFileIntoRepo(t, g.getK8sConfigCheckout(clusterSkiaPublic), "foo.yaml", "I'm a change in k8s-config.")
 
 	// Call the function under test, which should not commit nor push any changes.
 	ok, err := g.commitConfigFiles(ctx)
@@ -318,12 +320,12 @@ func TestGoldpushk_CommitConfigFiles_FlagDryRunSet_DoesNotCommit(t *testing.T) {
 	defer restoreStdout()
 
 	// Check out the fake k8s-config repository created earlier by running "git clone file://...".
-	err := g.checkOutK8sConfigRepo(ctx)
+	err := g.checkOutK8sConfigRepos(ctx)
 	require.NoError(t, err)
-	defer g.k8sConfigCheckout.Delete()
+	defer g.deleteK8sConfigCheckouts()
 
 	// Add changes to the k8s-config repository checkout.
-	writeFileIntoRepo(t, g.k8sConfigCheckout, "foo.yaml", "I'm a change in k8s-config.")
+	writeFileIntoRepo(t, g.getK8sConfigCheckout(clusterSkiaPublic), "foo.yaml", "I'm a change in k8s-config.")
 
 	// Call the function under test, which should not commit nor push any changes.
 	ok, err := g.commitConfigFiles(ctx)
@@ -334,6 +336,111 @@ func TestGoldpushk_CommitConfigFiles_FlagDryRunSet_DoesNotCommit(t *testing.T) {
 	assertNumCommits(t, ctx, fakeK8sConfig, 1)
 }
 
+func TestGoldpushk_CommitConfigFiles_FlagDryRunSet_PrintsDiff(t *testing.T) {
+	unittest.LinuxOnlyTest(t)
+
+	ctx := cipd_git.UseGitFinder(context.Background())
+
+	// Create a fake k8s-config repository (i.e. "git init" a temp directory).
+	fakeK8sConfig := createFakeK8sConfigRepo(t, ctx)
+	defer fakeK8sConfig.Cleanup()
+
+	// Create the goldpushk instance under test. We pass it the file://... URL to the Git repository
+	// created earlier.
+	g := Goldpushk{
+		k8sConfigRepoUrl: fakeK8sConfig.RepoUrl(),
+		dryRun:           true,
+	}
+
+	// Capture and hide goldpushk output to stdout.
+	fakeStdout, restoreStdout := hideStdout(t)
+	defer restoreStdout()
+
+	// Check out the fake k8s-config repository created earlier by running "git clone file://...".
+	err := g.checkOutK8sConfigRepos(ctx)
+	require.NoError(t, err)
+	defer g.deleteK8sConfigCheckouts()
+
+	// Modify an existing file and add a new one.
+	writeFileIntoRepo(t, g.getK8sConfigCheckout(clusterSkiaPublic), "README.md", "This is the modified repo k8s-config!")
+	writeFileIntoRepo(t, g.getK8sConfigCheckout(clusterSkiaPublic), "foo.yaml", "I'm a change in k8s-config.")
+
+	// Call the function under test.
+	ok, err := g.commitConfigFiles(ctx)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	// Assert that the diff was printed out.
+	stdout := readFakeStdout(t, fakeStdout)
+	assert.Contains(t, stdout, "Diff of the changes to be pushed to the k8s-config Git repository:")
+	assert.Contains(t, stdout, "-This is repo k8s-config!")
+	assert.Contains(t, stdout, "+This is the modified repo k8s-config!")
+	assert.Contains(t, stdout, "+++ b/foo.yaml")
+	assert.Contains(t, stdout, "+I'm a change in k8s-config.")
+
+	// Assert that no changes were pushed to the fake k8s-config repository.
+	assertNumCommits(t, ctx, fakeK8sConfig, 1)
+}
+
+func TestGoldpushk_CommitConfigFiles_ClusterWithOwnConfigRepo_CommitsToEachRepo(t *testing.T) {
+	unittest.LinuxOnlyTest(t)
+
+	ctx := cipd_git.UseGitFinder(context.Background())
+
+	// Create a fake k8s-config repository, and a fake repository for a cluster with its own
+	// repository.
+	fakeK8sConfig := createFakeK8sConfigRepo(t, ctx)
+	defer fakeK8sConfig.Cleanup()
+	fakeEuropeK8sConfig := createFakeK8sConfigRepo(t, ctx)
+	defer fakeEuropeK8sConfig.Cleanup()
+	europe := cluster{name: "skia-europe", projectID: "skia-public", zone: "europe-west1-b", configRepo: fakeEuropeK8sConfig.RepoUrl(), configDir: "skia-europe"}
+	europeUnit := getUnit(t, Chrome, Frontend)
+	europeUnit.cluster = europe
+
+	// Create the goldpushk instance under test.
+	g := Goldpushk{
+		deployableUnits:  []DeployableUnit{getUnit(t, Skia, Frontend), europeUnit},
+		k8sConfigRepoUrl: fakeK8sConfig.RepoUrl(),
+	}
+	require.NoError(t, g.SetJournal(filepath.Join(t.TempDir(), "journal.json"), false /* =resume */))
+
+	// Hide goldpushk output to stdout.
+	_, restoreStdout := hideStdout(t)
+	defer restoreStdout()
+
+	// Check out both repositories.
+	require.NoError(t, g.checkOutK8sConfigRepos(ctx))
+	defer g.deleteK8sConfigCheckouts()
+	require.Len(t, g.k8sConfigCheckouts, 2)
+	require.NotEqual(t, g.getK8sConfigCheckout(clusterSkiaPublic).GitDir, g.getK8sConfigCheckout(europe).GitDir)
+	require.Len(t, g.k8sConfigBaselineCommits, 2)
+
+	// Add changes to both checkouts.
+	writeFileIntoRepo(t, g.getK8sConfigCheckout(clusterSkiaPublic), "foo.yaml", "I'm a change in k8s-config.")
+	writeFileIntoRepo(t, g.getK8sConfigCheckout(europe), "bar.yaml", "I'm a change in k8s-config-europe.")
+
+	// Pretend that the user confirms the commit step.
+	cleanup := fakeStdin(t, "y\n")
+	defer cleanup()
+
+	// Call the function under test, which will commit and push the changes to each repository.
+	ok, err := g.commitConfigFiles(ctx)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	// Assert that a commit was pushed to each repository, and that the commit and baseline of each
+	// repository were recorded in the journal.
+	for _, repoUrl := range []string{fakeK8sConfig.RepoUrl(), fakeEuropeK8sConfig.RepoUrl()} {
+		require.NotEmpty(t, g.k8sConfigCommits[repoUrl])
+		assert.NotEqual(t, g.k8sConfigBaselineCommits[repoUrl], g.k8sConfigCommits[repoUrl])
+		assert.Equal(t, g.k8sConfigCommits[repoUrl], g.journal.K8sConfigCommits[repoUrl])
+		assert.Equal(t, g.k8sConfigBaselineCommits[repoUrl], g.journal.K8sConfigBaselineCommits[repoUrl])
+	}
+	europeFiles, err := g.getK8sConfigCheckout(europe).Git(ctx, "show", "--name-only", "--format=", g.k8sConfigCommits[fakeEuropeK8sConfig.RepoUrl()])
+	require.NoError(t, err)
+	assert.Equal(t, "bar.yaml", strings.TrimSpace(europeFiles))
+}
+
 func TestGoldpushk_SwitchClusters_Success(t *testing.T) {
 	unittest.LinuxOnlyTest(t)
 
@@ -378,7 +485,7 @@ func TestGoldpushk_PushCanaries_Success(t *testing.T) {
 	// Create the goldpushk instance under test.
 	g := &Goldpushk{
 		canariedDeployableUnits: units,
-		goldSrcDir:              "/infra/golden",
+		goldSrcDir:              createFakeGoldSrcDir(t, Skia),
 	}
 	addFakeK8sConfigRepoCheckout(g)
 
@@ -397,8 +504,7 @@ func TestGoldpushk_PushCanaries_Success(t *testing.T) {
 	// Assert that the correct kubectl and gcloud commands were executed.
 	expectedCommands := []string{
 		"gcloud container clusters get-credentials skia-public --zone us-central1-a --project skia-public",
-		"kubectl delete configmap gold-skia-config",
-		"kubectl create configmap gold-skia-config --from-file /infra/golden/k8s-instances/skia",
+		"kubectl apply -f -",
 		"kubectl apply -f /path/to/k8s-config/skia-public/gold-skia-diffcalculator.yaml",
 		"kubectl apply -f /path/to/k8s-config/skia-public/gold-skia-ingestion.yaml",
 	}
@@ -456,7 +562,7 @@ func TestGoldpushk_PushServices_Success(t *testing.T) {
 	// Create the goldpushk instance under test.
 	g := &Goldpushk{
 		deployableUnits: units,
-		goldSrcDir:      "/infra/golden",
+		goldSrcDir:      createFakeGoldSrcDir(t, Skia),
 	}
 	addFakeK8sConfigRepoCheckout(g)
 
@@ -475,8 +581,7 @@ func TestGoldpushk_PushServices_Success(t *testing.T) {
 	// Assert that the correct kubectl and gcloud commands were executed.
 	expectedCommands := []string{
 		"gcloud container clusters get-credentials skia-public --zone us-central1-a --project skia-public",
-		"kubectl delete configmap gold-skia-config",
-		"kubectl create configmap gold-skia-config --from-file /infra/golden/k8s-instances/skia",
+		"kubectl apply -f -",
 		"kubectl apply -f /path/to/k8s-config/skia-public/gold-skia-diffcalculator.yaml",
 		"kubectl apply -f /path/to/k8s-config/skia-public/gold-skia-ingestion.yaml",
 	}
@@ -530,6 +635,109 @@ Skipping push step (dry run).
 	require.Equal(t, expectedStdout, readFakeStdout(t, fakeStdout))
 }
 
+func TestGoldpushk_PushServices_Parallel_Success(t *testing.T) {
+	unittest.LinuxOnlyTest(t)
+
+	// Gather the DeployableUnits to deploy.
+	s := ProductionDeployableUnits()
+	var units []DeployableUnit
+	units = appendUnit(t, units, s, Chrome, DiffCalculator)
+	units = appendUnit(t, units, s, Skia, DiffCalculator)
+	units = appendUnit(t, units, s, Skia, Ingestion)
+	units = appendUnit(t, units, s, SkiaPublic, Frontend)
+
+	// Create the goldpushk instance under test.
+	g := &Goldpushk{
+		deployableUnits: units,
+		goldSrcDir:      createFakeGoldSrcDir(t, Chrome, Skia, SkiaPublic),
+		parallelism:     3,
+	}
+	addFakeK8sConfigRepoCheckout(g)
+
+	// Hide goldpushk output to stdout.
+	_, restoreStdout := hideStdout(t)
+	defer restoreStdout()
+
+	// Set up mocks.
+	commandCollector := exec.CommandCollector{}
+	commandCollectorCtx := exec.NewContext(context.Background(), commandCollector.Run)
+
+	// Call code under test.
+	err := g.pushServices(commandCollectorCtx)
+	require.NoError(t, err)
+
+	// The cluster switch and ConfigMap commands are executed sequentially, followed by the
+	// "kubectl apply" commands, which can run in any order.
+	var actual []string
+	for _, cmd := range commandCollector.Commands() {
+		actual = append(actual, exec.DebugString(cmd))
+	}
+	require.Len(t, actual, 8)
+	assert.Equal(t, []string{
+		"gcloud container clusters get-credentials skia-public --zone us-central1-a --project skia-public",
+		"kubectl apply -f -",
+		"kubectl apply -f -",
+		"kubectl apply -f -",
+	}, actual[:4])
+	assert.ElementsMatch(t, []string{
+		"kubectl apply -f /path/to/k8s-config/skia-public/gold-chrome-diffcalculator.yaml",
+		"kubectl apply -f /path/to/k8s-config/skia-public/gold-skia-diffcalculator.yaml",
+		"kubectl apply -f /path/to/k8s-config/skia-public/gold-skia-ingestion.yaml",
+		"kubectl apply -f /path/to/k8s-config/skia-public/gold-skia-public-frontend.yaml",
+	}, actual[4:])
+}
+
+func TestGoldpushk_PushServices_ParallelSomeUnitsFail_AllUnitsPushedAndErrorsAggregated(t *testing.T) {
+	unittest.LinuxOnlyTest(t)
+
+	// Gather the DeployableUnits to deploy.
+	s := ProductionDeployableUnits()
+	var units []DeployableUnit
+	units = appendUnit(t, units, s, Skia, DiffCalculator)
+	units = appendUnit(t, units, s, Skia, Frontend)
+	units = appendUnit(t, units, s, Skia, Ingestion)
+
+	// Create the goldpushk instance under test.
+	g := &Goldpushk{
+		deployableUnits: units,
+		goldSrcDir:      createFakeGoldSrcDir(t, Skia),
+		parallelism:     2,
+	}
+	addFakeK8sConfigRepoCheckout(g)
+
+	// Hide goldpushk output to stdout.
+	_, restoreStdout := hideStdout(t)
+	defer restoreStdout()
+
+	// Set up mocks. Applying the diffcalculator and ingestion units will fail.
+	commandCollector := exec.CommandCollector{}
+	commandCollector.SetDelegateRun(func(_ context.Context, cmd *exec.Command) error {
+		debugStr := exec.DebugString(cmd)
+		if strings.HasSuffix(debugStr, "gold-skia-diffcalculator.yaml") || strings.HasSuffix(debugStr, "gold-skia-ingestion.yaml") {
+			return errors.New("kubectl error")
+		}
+		return nil
+	})
+	commandCollectorCtx := exec.NewContext(context.Background(), commandCollector.Run)
+
+	// Call code under test.
+	err := g.pushServices(commandCollectorCtx)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to push 2 DeployableUnit(s)")
+	assert.Contains(t, err.Error(), "gold-skia-diffcalculator: ")
+	assert.Contains(t, err.Error(), "gold-skia-ingestion: ")
+	assert.NotContains(t, err.Error(), "gold-skia-frontend: ")
+
+	// All three units should have been applied regardless of the failures.
+	applies := 0
+	for _, cmd := range commandCollector.Commands() {
+		if cmd.Name == "kubectl" && cmd.Args[0] == "apply" && strings.HasSuffix(cmd.Args[2], ".yaml") {
+			applies++
+		}
+	}
+	assert.Equal(t, 3, applies)
+}
+
 func TestGoldpushk_GetUptimesSingleCluster_Success(t *testing.T) {
 	unittest.LinuxOnlyTest(t)
 
@@ -815,7 +1023,27 @@ func addFakeK8sConfigRepoCheckout(g *Goldpushk) {
 			GitDir: "/path/to/k8s-config",
 		},
 	}
-	g.k8sConfigCheckout = fakeK8sConfigCheckout
+	g.k8sConfigCheckouts = map[string]*git.TempCheckout{g.k8sConfigRepoUrl: fakeK8sConfigCheckout}
+}
+
+// createFakeGoldSrcDir creates a temporary golden directory with an instance-specific configuration
+// file for each of the given instances, and returns its path.
+func createFakeGoldSrcDir(t *testing.T, instances ...Instance) string {
+	goldSrcDir := t.TempDir()
+	for _, instance := range instances {
+		dir := filepath.Join(goldSrcDir, k8sInstancesDir, string(instance))
+		require.NoError(t, os.MkdirAll(dir, 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, string(instance)+".json5"), []byte("{instance: \""+string(instance)+"\"}"), 0644))
+	}
+	return goldSrcDir
+}
+
+// fakeConfigChecksum returns the checksum of the instance-specific configuration files created by
+// createFakeGoldSrcDir for the given instance.
+func fakeConfigChecksum(instance Instance) string {
+	return configMapChecksum(map[string]string{
+		string(instance) + ".json5": "{instance: \"" + string(instance) + "\"}",
+	})
 }
 
 // writeFileIntoRepo creates a file with the given name and contents into a *git.TempCheckout.
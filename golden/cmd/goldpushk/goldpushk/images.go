@@ -0,0 +1,179 @@
+package goldpushk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/flynn/json5"
+
+	"go.skia.org/infra/go/skerr"
+)
+
+// serviceImageTemplateVars maps each service to the template variable holding the container image
+// of its main container. The main container is named after the DeployableUnit's canonical name.
+var serviceImageTemplateVars = map[Service]string{
+	BaselineServer:  "BASELINE_SERVER_IMAGE",
+	DiffCalculator:  "DIFFCALCULATOR_IMAGE",
+	Frontend:        "FRONTEND_IMAGE",
+	GitilesFollower: "GITILESFOLLOWER_IMAGE",
+	Ingestion:       "INGESTION_IMAGE",
+	PeriodicTasks:   "PERIODICTASKS_IMAGE",
+}
+
+// sidecarImageTemplateVars maps the names of sidecar containers to the template variables holding
+// their container images.
+var sidecarImageTemplateVars = map[string]string{
+	"auth-proxy": "AUTH_PROXY_IMAGE",
+}
+
+// ParseImagePins parses a list of image pins of the form "instance:service=image", where image is
+// either a full container image (e.g. "gcr.io/skia-public/gold-frontend:2021-01-01T00_00_00Z-...")
+// or just a tag, in which case the image repository in gold-common.json5 is used.
+func ParseImagePins(deployableUnitSet DeployableUnitSet, pins []string) (map[DeployableUnitID]string, error) {
+	imagePins := map[DeployableUnitID]string{}
+	for _, pin := range pins {
+		unitStr, image, ok := strings.Cut(pin, "=")
+		instanceStr, serviceStr, ok2 := strings.Cut(unitStr, ":")
+		if !ok || !ok2 || image == "" {
+			return nil, skerr.Fmt("invalid image pin %q; expected instance:service=image", pin)
+		}
+		id := DeployableUnitID{Instance: Instance(instanceStr), Service: Service(serviceStr)}
+		if _, ok := deployableUnitSet.Get(id); !ok {
+			return nil, skerr.Fmt("invalid image pin %q: unknown service %s", pin, id.CanonicalName())
+		}
+		if _, ok := serviceImageTemplateVars[id.Service]; !ok {
+			return nil, skerr.Fmt("invalid image pin %q: service %s does not support image pinning", pin, id.Service)
+		}
+		if _, ok := imagePins[id]; ok {
+			return nil, skerr.Fmt("service %s is pinned more than once", id.CanonicalName())
+		}
+		imagePins[id] = image
+	}
+	return imagePins, nil
+}
+
+// SetImagePins pins the container images of the given DeployableUnits, overriding the images set
+// in the templates.
+func (g *Goldpushk) SetImagePins(imagePins map[DeployableUnitID]string) {
+	g.imagePins = imagePins
+}
+
+// SetSameAsProd makes goldpushk regenerate the deployment files with the container images currently
+// deployed to production, so that configuration-only changes do not update any images. Pinned
+// images take precedence.
+func (g *Goldpushk) SetSameAsProd(sameAsProd bool) {
+	g.sameAsProd = sameAsProd
+}
+
+// getImageTemplateVars returns the template variables that override the container images of the
+// given DeployableUnit, if any.
+func (g *Goldpushk) getImageTemplateVars(ctx context.Context, unit DeployableUnit) (map[string]string, error) {
+	vars := map[string]string{}
+
+	if g.sameAsProd {
+		prodImages, err := g.getProdImages(ctx, unit)
+		if err != nil {
+			return nil, skerr.Wrap(err)
+		}
+		for container, image := range prodImages {
+			if container == unit.CanonicalName() {
+				if v, ok := serviceImageTemplateVars[unit.Service]; ok {
+					vars[v] = image
+				}
+			} else if v, ok := sidecarImageTemplateVars[container]; ok {
+				vars[v] = image
+			}
+		}
+	}
+
+	if pin, ok := g.imagePins[unit.DeployableUnitID]; ok {
+		v := serviceImageTemplateVars[unit.Service]
+		image, err := g.resolveImagePin(v, pin)
+		if err != nil {
+			return nil, skerr.Wrap(err)
+		}
+		vars[v] = image
+	}
+
+	return vars, nil
+}
+
+// resolveImagePin returns the full container image for the given pin. If the pin is just a tag, it
+// is applied to the image repository of the given template variable in gold-common.json5.
+func (g *Goldpushk) resolveImagePin(templateVar, pin string) (string, error) {
+	if strings.Contains(pin, "/") {
+		return pin, nil
+	}
+
+	path := filepath.Join(g.goldSrcDir, k8sConfigTemplatesDir, "gold-common.json5")
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return "", skerr.Wrapf(err, "reading %s", path)
+	}
+	var common map[string]interface{}
+	if err := json5.Unmarshal(b, &common); err != nil {
+		return "", skerr.Wrapf(err, "parsing %s", path)
+	}
+	defaultImage, ok := common[templateVar].(string)
+	if !ok {
+		return "", skerr.Fmt("%s does not define %s", path, templateVar)
+	}
+	repository, _, _ := strings.Cut(defaultImage, ":")
+	return repository + ":" + pin, nil
+}
+
+// getProdImages returns the container images of the given DeployableUnit currently deployed to its
+// cluster, keyed by container name.
+func (g *Goldpushk) getProdImages(ctx context.Context, unit DeployableUnit) (map[string]string, error) {
+	c := getCluster(unit)
+	if g.prodWorkloads == nil {
+		g.prodWorkloads = map[cluster][]k8sWorkload{}
+	}
+	workloads, ok := g.prodWorkloads[c]
+	if !ok {
+		if err := g.switchClusters(ctx, c); err != nil {
+			return nil, skerr.Wrap(err)
+		}
+		stdout, err := g.execCmdAndReturnStdout(ctx, "kubectl", []string{"get", "deployments,statefulsets", "-o", "json"})
+		if err != nil {
+			return nil, skerr.Wrap(err)
+		}
+		var list k8sWorkloadList
+		if err := json.Unmarshal([]byte(stdout), &list); err != nil {
+			return nil, skerr.Wrapf(err, "parsing kubectl output")
+		}
+		workloads = list.Items
+		g.prodWorkloads[c] = workloads
+	}
+
+	for _, w := range workloads {
+		if w.Metadata.Name == unit.CanonicalName() || w.Metadata.Labels["app"] == unit.CanonicalName() {
+			images := map[string]string{}
+			for _, container := range w.Spec.Template.Spec.Containers {
+				images[container.Name] = container.Image
+			}
+			return images, nil
+		}
+	}
+	return nil, skerr.Fmt("%s is not deployed to cluster %s; cannot use the images currently in production", unit.CanonicalName(), c.name)
+}
+
+// imageTemplateVarsToExtraArgs converts the given template variables into kube-conf-gen "-extra"
+// arguments, sorted by variable name.
+func imageTemplateVarsToExtraArgs(vars map[string]string) []string {
+	var keys []string
+	for k := range vars {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var args []string
+	for _, k := range keys {
+		args = append(args, "-extra", fmt.Sprintf("%s:%s", k, vars[k]))
+	}
+	return args
+}
@@ -0,0 +1,131 @@
+package goldpushk
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.skia.org/infra/go/exec"
+)
+
+func TestParseImagePins_Success(t *testing.T) {
+	pins, err := ParseImagePins(ProductionDeployableUnits(), []string{
+		"skia:frontend=gcr.io/skia-public/gold-frontend:v2",
+		"chrome:ingestion=v3",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[DeployableUnitID]string{
+		makeID(Skia, Frontend):    "gcr.io/skia-public/gold-frontend:v2",
+		makeID(Chrome, Ingestion): "v3",
+	}, pins)
+}
+
+func TestParseImagePins_InvalidPins_Error(t *testing.T) {
+	test := func(name, pin, expectedErr string) {
+		t.Run(name, func(t *testing.T) {
+			_, err := ParseImagePins(ProductionDeployableUnits(), []string{pin})
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), expectedErr)
+		})
+	}
+
+	test("missing image", "skia:frontend", "expected instance:service=image")
+	test("missing service", "skia=v2", "expected instance:service=image")
+	test("unknown service", "skia:foo=v2", "unknown service gold-skia-foo")
+}
+
+func TestGoldpushk_GetImageTemplateVars_TagPin_UsesRepositoryFromGoldCommon(t *testing.T) {
+	goldSrcDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(goldSrcDir, k8sConfigTemplatesDir), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(goldSrcDir, k8sConfigTemplatesDir, "gold-common.json5"), []byte(`{
+    "FRONTEND_IMAGE": "gcr.io/skia-public/gold-frontend:v1",
+}`), 0644))
+
+	g := &Goldpushk{
+		goldSrcDir: goldSrcDir,
+		imagePins:  map[DeployableUnitID]string{makeID(Skia, Frontend): "v2"},
+	}
+
+	vars, err := g.getImageTemplateVars(context.Background(), getUnit(t, Skia, Frontend))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"FRONTEND_IMAGE": "gcr.io/skia-public/gold-frontend:v2"}, vars)
+
+	vars, err = g.getImageTemplateVars(context.Background(), getUnit(t, Skia, Ingestion))
+	require.NoError(t, err)
+	assert.Empty(t, vars)
+}
+
+const kubectlGetWorkloadsWithImagesOutput = `{
+  "items": [
+    {
+      "kind": "Deployment",
+      "metadata": {"name": "gold-skia-frontend", "labels": {"app": "gold-skia-frontend"}},
+      "spec": {"template": {"spec": {"containers": [
+        {"name": "gold-skia-frontend", "image": "gcr.io/skia-public/gold-frontend:prod"},
+        {"name": "auth-proxy", "image": "gcr.io/skia-public/auth-proxy:prod"}
+      ]}}}
+    },
+    {
+      "kind": "StatefulSet",
+      "metadata": {"name": "gold-skia-diffcalculator", "labels": {"app": "gold-skia-diffcalculator"}},
+      "spec": {"template": {"spec": {"containers": [
+        {"name": "gold-skia-diffcalculator", "image": "gcr.io/skia-public/gold-diffcalculator:prod"}
+      ]}}}
+    }
+  ]
+}`
+
+func TestGoldpushk_GetImageTemplateVars_SameAsProd_UsesDeployedImages(t *testing.T) {
+	g := &Goldpushk{
+		sameAsProd: true,
+		imagePins:  map[DeployableUnitID]string{makeID(Skia, DiffCalculator): "gcr.io/skia-public/gold-diffcalculator:pinned"},
+	}
+
+	commandCollector := exec.CommandCollector{}
+	commandCollector.SetDelegateRun(func(_ context.Context, cmd *exec.Command) error {
+		if cmd.Name == "kubectl" {
+			_, err := cmd.CombinedOutput.Write([]byte(kubectlGetWorkloadsWithImagesOutput))
+			require.NoError(t, err)
+		}
+		return nil
+	})
+	ctx := exec.NewContext(context.Background(), commandCollector.Run)
+
+	vars, err := g.getImageTemplateVars(ctx, getUnit(t, Skia, Frontend))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"FRONTEND_IMAGE":   "gcr.io/skia-public/gold-frontend:prod",
+		"AUTH_PROXY_IMAGE": "gcr.io/skia-public/auth-proxy:prod",
+	}, vars)
+
+	// Pinned images take precedence.
+	vars, err = g.getImageTemplateVars(ctx, getUnit(t, Skia, DiffCalculator))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"DIFFCALCULATOR_IMAGE": "gcr.io/skia-public/gold-diffcalculator:pinned"}, vars)
+
+	// Units that are not deployed cannot use the images in production.
+	_, err = g.getImageTemplateVars(ctx, getUnit(t, Skia, Ingestion))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "gold-skia-ingestion is not deployed to cluster skia-public")
+
+	// The workloads are only read once per cluster.
+	assertCommandsMatch(t, &commandCollector, []string{
+		"gcloud container clusters get-credentials skia-public --zone us-central1-a --project skia-public",
+		"kubectl get deployments,statefulsets -o json",
+	})
+}
+
+func TestImageTemplateVarsToExtraArgs_SortedByName(t *testing.T) {
+	assert.Equal(t, []string{
+		"-extra", "AUTH_PROXY_IMAGE:a:1",
+		"-extra", "FRONTEND_IMAGE:f:2",
+	}, imageTemplateVarsToExtraArgs(map[string]string{
+		"FRONTEND_IMAGE":   "f:2",
+		"AUTH_PROXY_IMAGE": "a:1",
+	}))
+	assert.Empty(t, imageTemplateVarsToExtraArgs(nil))
+}
@@ -0,0 +1,196 @@
+package goldpushk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"go.skia.org/infra/go/now"
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/go/util"
+)
+
+// Names of the steps recorded in the journal. Each step can be skipped when resuming a run if it
+// was completed by the previous run.
+const (
+	stepCommitConfigFiles   = "commit_config_files"
+	stepValidateConfigFiles = "validate_config_files"
+	stepPushCanaries        = "push_canaries"
+	stepMonitorCanaries     = "monitor_canaries"
+	stepSoakCanaries        = "soak_canaries"
+	stepCheckCanaryGates    = "check_canary_gates"
+	stepPushServices        = "push_services"
+	stepMonitorServices     = "monitor_services"
+	stepSoakServices        = "soak_services"
+)
+
+// journalEvent is an entry in the journal's structured execution log.
+type journalEvent struct {
+	Time    time.Time `json:"time"`
+	Step    string    `json:"step,omitempty"`
+	Unit    string    `json:"unit,omitempty"`
+	Message string    `json:"message"`
+}
+
+// journal is a structured record of a goldpushk run. It is written to disk as JSON after every
+// change, so that a run that fails or is interrupted halfway can be resumed by a subsequent run
+// which skips any steps already completed.
+//
+// All methods are safe to call on a nil *journal, in which case they do nothing.
+type journal struct {
+	DeployableUnits         []string `json:"deployable_units"`
+	CanariedDeployableUnits []string `json:"canaried_deployable_units"`
+	// Commits pushed to each repository with configuration files, and the commits they were based
+	// on, keyed by repository URL.
+	K8sConfigBaselineCommits map[string]string `json:"k8s_config_baseline_commits,omitempty"`
+	K8sConfigCommits         map[string]string `json:"k8s_config_commits,omitempty"`
+	CompletedSteps           []string          `json:"completed_steps"`
+	PushedUnits              []string          `json:"pushed_units"`
+	Confirmations            []string          `json:"confirmations"`
+	Events                   []journalEvent    `json:"events"`
+
+	path  string
+	mutex sync.Mutex
+}
+
+// SetJournal makes goldpushk record its progress in a JSON file at the given path. If resume is
+// true, the journal is read from the given path, and any steps it lists as completed will be
+// skipped. Resuming fails if the journal was written by a run that targeted a different set of
+// DeployableUnits.
+//
+// Note that if the previous run committed the regenerated config files, the resumed run regenerates
+// them in its own checkouts without committing them again, since the previous commits might not
+// have landed. The commits recorded by the previous run for each repository with configuration files
+// are restored, so that any rollbacks re-apply the deployment files from before the previous run,
+// rather than those at the HEAD of the resumed run's checkouts, which might already include the
+// previous run's commits.
+func (g *Goldpushk) SetJournal(path string, resume bool) error {
+	units, canariedUnits := canonicalNames(g.deployableUnits), canonicalNames(g.canariedDeployableUnits)
+	if !resume {
+		g.journal = &journal{
+			DeployableUnits:         units,
+			CanariedDeployableUnits: canariedUnits,
+			path:                    path,
+		}
+		return nil
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return skerr.Wrapf(err, "reading journal %s", path)
+	}
+	j := &journal{path: path}
+	if err := json.Unmarshal(b, j); err != nil {
+		return skerr.Wrapf(err, "parsing journal %s", path)
+	}
+	if !util.SSliceEqual(units, j.DeployableUnits) || !util.SSliceEqual(canariedUnits, j.CanariedDeployableUnits) {
+		return skerr.Fmt("journal %s was written by a run with different services and/or canaries; cannot resume", path)
+	}
+	g.journal = j
+	g.k8sConfigBaselineCommits = util.CopyStringMap(j.K8sConfigBaselineCommits)
+	g.k8sConfigCommits = util.CopyStringMap(j.K8sConfigCommits)
+	return nil
+}
+
+// isStepCompleted returns true if the journal lists the given step as completed.
+func (j *journal) isStepCompleted(step string) bool {
+	if j == nil {
+		return false
+	}
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+	return util.In(step, j.CompletedSteps)
+}
+
+// isUnitPushed returns true if the journal lists the given DeployableUnit as pushed.
+func (j *journal) isUnitPushed(unit DeployableUnit) bool {
+	if j == nil {
+		return false
+	}
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+	return util.In(unit.CanonicalName(), j.PushedUnits)
+}
+
+// completeStep records the given step as completed.
+func (j *journal) completeStep(ctx context.Context, step string) error {
+	return j.update(ctx, journalEvent{Step: step, Message: "step completed"}, func() {
+		j.CompletedSteps = append(j.CompletedSteps, step)
+	})
+}
+
+// failStep records an error that occurred during the given step.
+func (j *journal) failStep(ctx context.Context, step string, stepErr error) error {
+	return j.update(ctx, journalEvent{Step: step, Message: fmt.Sprintf("step failed: %s", stepErr)}, nil)
+}
+
+// unitPushed records the given DeployableUnit as pushed.
+func (j *journal) unitPushed(ctx context.Context, unit DeployableUnit) error {
+	return j.update(ctx, journalEvent{Unit: unit.CanonicalName(), Message: "pushed"}, func() {
+		j.PushedUnits = append(j.PushedUnits, unit.CanonicalName())
+	})
+}
+
+// confirmation records the answer given by the user to a confirmation prompt.
+func (j *journal) confirmation(ctx context.Context, question string, answer bool) error {
+	entry := fmt.Sprintf("%s %t", question, answer)
+	return j.update(ctx, journalEvent{Message: "confirmation: " + entry}, func() {
+		j.Confirmations = append(j.Confirmations, entry)
+	})
+}
+
+// k8sConfigCommitted records the hash of the commit pushed to the repository with configuration
+// files with the given URL, and the hash of the commit it was based on.
+func (j *journal) k8sConfigCommitted(ctx context.Context, repoUrl, baselineHash, hash string) error {
+	return j.update(ctx, journalEvent{Message: fmt.Sprintf("committed to %s: %s (baseline: %s)", repoUrl, hash, baselineHash)}, func() {
+		if j.K8sConfigBaselineCommits == nil {
+			j.K8sConfigBaselineCommits = map[string]string{}
+		}
+		if j.K8sConfigCommits == nil {
+			j.K8sConfigCommits = map[string]string{}
+		}
+		j.K8sConfigBaselineCommits[repoUrl] = baselineHash
+		j.K8sConfigCommits[repoUrl] = hash
+	})
+}
+
+// update applies the given mutation (if any), appends the given event to the execution log, and
+// writes the journal to disk.
+func (j *journal) update(ctx context.Context, event journalEvent, mutate func()) error {
+	if j == nil {
+		return nil
+	}
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	if mutate != nil {
+		mutate()
+	}
+	event.Time = now.Now(ctx)
+	j.Events = append(j.Events, event)
+
+	b, err := json.MarshalIndent(j, "", "  ")
+	if err != nil {
+		return skerr.Wrap(err)
+	}
+	if err := util.WithWriteFile(j.path, func(w io.Writer) error {
+		_, err := w.Write(b)
+		return err
+	}); err != nil {
+		return skerr.Wrapf(err, "writing journal %s", j.path)
+	}
+	return nil
+}
+
+// canonicalNames returns the canonical names of the given DeployableUnits.
+func canonicalNames(units []DeployableUnit) []string {
+	names := []string{}
+	for _, unit := range units {
+		names = append(names, unit.CanonicalName())
+	}
+	return names
+}
@@ -0,0 +1,269 @@
+package goldpushk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cipd_git "go.skia.org/infra/bazel/external/cipd/git"
+	"go.skia.org/infra/go/exec"
+	"go.skia.org/infra/go/now"
+	"go.skia.org/infra/go/testutils/unittest"
+)
+
+func TestGoldpushk_SetJournal_NewJournal_RecordsEventsToDisk(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal.json")
+	g := &Goldpushk{
+		deployableUnits:         []DeployableUnit{getUnit(t, Skia, Frontend)},
+		canariedDeployableUnits: []DeployableUnit{getUnit(t, Skia, DiffCalculator)},
+	}
+	require.NoError(t, g.SetJournal(path, false /* =resume */))
+
+	fakeNow := time.Date(2021, time.March, 4, 5, 6, 7, 0, time.UTC)
+	ctx := context.WithValue(context.Background(), now.ContextKey, fakeNow)
+	require.NoError(t, g.journal.confirmation(ctx, "Proceed?", true))
+	require.NoError(t, g.journal.completeStep(ctx, stepCommitConfigFiles))
+	require.NoError(t, g.journal.k8sConfigCommitted(ctx, "https://k8s-config.com", "0123abcd", "abcd1234"))
+	require.NoError(t, g.journal.unitPushed(ctx, getUnit(t, Skia, DiffCalculator)))
+	require.NoError(t, g.journal.failStep(ctx, stepMonitorCanaries, errors.New("oops")))
+
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var actual journal
+	require.NoError(t, json.Unmarshal(b, &actual))
+	assert.Equal(t, []string{"gold-skia-frontend"}, actual.DeployableUnits)
+	assert.Equal(t, []string{"gold-skia-diffcalculator"}, actual.CanariedDeployableUnits)
+	assert.Equal(t, map[string]string{"https://k8s-config.com": "0123abcd"}, actual.K8sConfigBaselineCommits)
+	assert.Equal(t, map[string]string{"https://k8s-config.com": "abcd1234"}, actual.K8sConfigCommits)
+	assert.Equal(t, []string{stepCommitConfigFiles}, actual.CompletedSteps)
+	assert.Equal(t, []string{"gold-skia-diffcalculator"}, actual.PushedUnits)
+	assert.Equal(t, []string{"Proceed? true"}, actual.Confirmations)
+	assert.Equal(t, []journalEvent{
+		{Time: fakeNow, Message: "confirmation: Proceed? true"},
+		{Time: fakeNow, Step: stepCommitConfigFiles, Message: "step completed"},
+		{Time: fakeNow, Message: "committed to https://k8s-config.com: abcd1234 (baseline: 0123abcd)"},
+		{Time: fakeNow, Unit: "gold-skia-diffcalculator", Message: "pushed"},
+		{Time: fakeNow, Step: stepMonitorCanaries, Message: "step failed: oops"},
+	}, actual.Events)
+}
+
+func TestGoldpushk_SetJournal_Resume_LoadsCompletedStepsAndPushedUnits(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal.json")
+	units := []DeployableUnit{getUnit(t, Skia, Frontend), getUnit(t, Skia, Ingestion)}
+
+	// Write a journal from a previous run.
+	previous := &Goldpushk{deployableUnits: units}
+	require.NoError(t, previous.SetJournal(path, false /* =resume */))
+	ctx := context.Background()
+	require.NoError(t, previous.journal.completeStep(ctx, stepCommitConfigFiles))
+	require.NoError(t, previous.journal.k8sConfigCommitted(ctx, "https://k8s-config.com", "0123abcd", "abcd1234"))
+	require.NoError(t, previous.journal.unitPushed(ctx, units[0]))
+
+	// Resume.
+	g := &Goldpushk{deployableUnits: units}
+	require.NoError(t, g.SetJournal(path, true /* =resume */))
+	assert.Equal(t, map[string]string{"https://k8s-config.com": "0123abcd"}, g.k8sConfigBaselineCommits)
+	assert.Equal(t, map[string]string{"https://k8s-config.com": "abcd1234"}, g.k8sConfigCommits)
+	assert.True(t, g.journal.isStepCompleted(stepCommitConfigFiles))
+	assert.False(t, g.journal.isStepCompleted(stepPushServices))
+	assert.True(t, g.journal.isUnitPushed(units[0]))
+	assert.False(t, g.journal.isUnitPushed(units[1]))
+}
+
+func TestGoldpushk_SetJournal_ResumeWithDifferentUnits_Error(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal.json")
+
+	previous := &Goldpushk{deployableUnits: []DeployableUnit{getUnit(t, Skia, Frontend)}}
+	require.NoError(t, previous.SetJournal(path, false /* =resume */))
+	require.NoError(t, previous.journal.completeStep(context.Background(), stepCommitConfigFiles))
+
+	g := &Goldpushk{deployableUnits: []DeployableUnit{getUnit(t, Chrome, Frontend)}}
+	err := g.SetJournal(path, true /* =resume */)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot resume")
+}
+
+func TestGoldpushk_PushServices_ResumedRun_SkipsPushedUnits(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal.json")
+	units := []DeployableUnit{getUnit(t, Skia, DiffCalculator), getUnit(t, Skia, Ingestion)}
+
+	// Write a journal from a previous run which pushed the first unit.
+	previous := &Goldpushk{deployableUnits: units}
+	require.NoError(t, previous.SetJournal(path, false /* =resume */))
+	require.NoError(t, previous.journal.unitPushed(context.Background(), units[0]))
+
+	// Create the goldpushk instance under test.
+	g := &Goldpushk{
+		deployableUnits: units,
+		goldSrcDir:      createFakeGoldSrcDir(t, Skia),
+	}
+	addFakeK8sConfigRepoCheckout(g)
+	require.NoError(t, g.SetJournal(path, true /* =resume */))
+
+	// Hide goldpushk output to stdout.
+	_, restoreStdout := hideStdout(t)
+	defer restoreStdout()
+
+	// Set up mocks.
+	commandCollector := exec.CommandCollector{}
+	commandCollectorCtx := exec.NewContext(context.Background(), commandCollector.Run)
+
+	// Call code under test.
+	require.NoError(t, g.pushServices(commandCollectorCtx))
+
+	// Assert that only the second unit was pushed.
+	expectedCommands := []string{
+		"gcloud container clusters get-credentials skia-public --zone us-central1-a --project skia-public",
+		"kubectl apply -f -",
+		"kubectl apply -f /path/to/k8s-config/skia-public/gold-skia-ingestion.yaml",
+	}
+	assertCommandsMatch(t, &commandCollector, expectedCommands)
+	assert.True(t, g.journal.isUnitPushed(units[1]))
+}
+
+func TestGoldpushk_Run_ResumedAfterCommittingConfigFiles_RegeneratesConfigFilesWithoutCommitting(t *testing.T) {
+	unittest.LinuxOnlyTest(t)
+
+	ctx := cipd_git.UseGitFinder(context.Background())
+
+	// Create a fake k8s-config repository (i.e. "git init" a temp directory).
+	fakeK8sConfig := createFakeK8sConfigRepo(t, ctx)
+	defer fakeK8sConfig.Cleanup()
+	assertNumCommits(t, ctx, fakeK8sConfig, 1)
+
+	// Write a journal from a previous run which completed every step.
+	path := filepath.Join(t.TempDir(), "journal.json")
+	units := []DeployableUnit{getUnit(t, Skia, DiffCalculator)}
+	previous := &Goldpushk{deployableUnits: units}
+	require.NoError(t, previous.SetJournal(path, false /* =resume */))
+	for _, step := range []string{stepCommitConfigFiles, stepValidateConfigFiles, stepPushCanaries, stepMonitorCanaries, stepSoakCanaries, stepCheckCanaryGates, stepPushServices, stepMonitorServices, stepSoakServices} {
+		require.NoError(t, previous.journal.completeStep(ctx, step))
+	}
+
+	// Create the goldpushk instance under test.
+	g := &Goldpushk{
+		deployableUnits:  units,
+		goldSrcDir:       createFakeGoldSrcDir(t, Skia),
+		k8sConfigRepoUrl: fakeK8sConfig.RepoUrl(),
+		unitTest:         true,
+
+		// Fake out the copy
+		disableCopyingConfigsToCheckout: true,
+	}
+	require.NoError(t, g.SetJournal(path, true /* =resume */))
+
+	// Hide goldpushk output to stdout.
+	_, restoreStdout := hideStdout(t)
+	defer restoreStdout()
+
+	// Set up mocks. Git commands are run for real so that the fake k8s-config repository is cloned.
+	commandCollector := exec.CommandCollector{}
+	commandCollector.SetDelegateRun(func(ctx context.Context, cmd *exec.Command) error {
+		if filepath.Base(cmd.Name) == "git" {
+			return exec.DefaultRun(ctx, cmd)
+		}
+		return nil
+	})
+	commandCollectorCtx := exec.NewContext(ctx, commandCollector.Run)
+
+	// Call code under test.
+	require.NoError(t, g.Run(commandCollectorCtx))
+
+	// Assert that the config files were regenerated.
+	var kubeConfGenCommands []string
+	for _, cmd := range commandCollector.Commands() {
+		if cmd.Name == "kube-conf-gen" {
+			kubeConfGenCommands = append(kubeConfGenCommands, exec.DebugString(cmd))
+		}
+	}
+	require.Len(t, kubeConfGenCommands, 1)
+	assert.True(t, strings.HasSuffix(kubeConfGenCommands[0], "/skia-public/gold-skia-diffcalculator.yaml"))
+
+	// Assert that nothing was committed.
+	assertNumCommits(t, ctx, fakeK8sConfig, 1)
+}
+
+func TestGoldpushk_Run_ResumedAfterCommittingConfigFiles_SoakFails_RollsBackToPreviousBaseline(t *testing.T) {
+	unittest.LinuxOnlyTest(t)
+
+	ctx := cipd_git.UseGitFinder(context.Background())
+
+	// Create a fake k8s-config repository with the deployment file as it was before the previous run.
+	fakeK8sConfig := createFakeK8sConfigRepo(t, ctx)
+	defer fakeK8sConfig.Cleanup()
+	fakeK8sConfig.Add(ctx, "skia-public/gold-skia-diffcalculator.yaml", "previous deployment")
+	baselineCommit := fakeK8sConfig.Commit(ctx)
+
+	// Simulate the commit pushed by the previous run landing, so that it is at the HEAD of the
+	// repository checked out by the resumed run.
+	fakeK8sConfig.Add(ctx, "skia-public/gold-skia-diffcalculator.yaml", "new deployment")
+	pushedCommit := fakeK8sConfig.Commit(ctx)
+
+	// Write a journal from a previous run which committed the config files and pushed the services,
+	// but was interrupted before the soak step.
+	path := filepath.Join(t.TempDir(), "journal.json")
+	units := []DeployableUnit{getUnit(t, Skia, DiffCalculator)}
+	previous := &Goldpushk{deployableUnits: units}
+	require.NoError(t, previous.SetJournal(path, false /* =resume */))
+	require.NoError(t, previous.journal.k8sConfigCommitted(ctx, fakeK8sConfig.RepoUrl(), baselineCommit, pushedCommit))
+	for _, step := range []string{stepCommitConfigFiles, stepValidateConfigFiles, stepPushCanaries, stepMonitorCanaries, stepSoakCanaries, stepCheckCanaryGates, stepPushServices, stepMonitorServices} {
+		require.NoError(t, previous.journal.completeStep(ctx, step))
+	}
+
+	// Create the goldpushk instance under test.
+	g := &Goldpushk{
+		deployableUnits:            units,
+		goldSrcDir:                 createFakeGoldSrcDir(t, Skia),
+		k8sConfigRepoUrl:           fakeK8sConfig.RepoUrl(),
+		soakPeriod:                 time.Minute,
+		uptimePollFrequencySeconds: 1,
+		unitTest:                   true,
+
+		// Fake out the copy
+		disableCopyingConfigsToCheckout: true,
+	}
+	require.NoError(t, g.SetJournal(path, true /* =resume */))
+
+	// Hide goldpushk output to stdout.
+	_, restoreStdout := hideStdout(t)
+	defer restoreStdout()
+
+	// Set up mocks. Git commands are run for real so that the fake k8s-config repository is cloned,
+	// the pods of the DeployableUnit are reported as crash-looping, and the contents of any applied
+	// deployment files are captured.
+	var appliedContents []string
+	commandCollector := exec.CommandCollector{}
+	commandCollector.SetDelegateRun(func(ctx context.Context, cmd *exec.Command) error {
+		if filepath.Base(cmd.Name) == "git" {
+			return exec.DefaultRun(ctx, cmd)
+		}
+		if cmd.Name == "kubectl" && cmd.Args[0] == "get" {
+			_, err := cmd.CombinedOutput.Write([]byte("app:gold-skia-diffcalculator  podName:gold-skia-diffcalculator-0  restarts:3  waiting:CrashLoopBackOff\n"))
+			return err
+		}
+		if cmd.Name == "kubectl" && cmd.Args[0] == "apply" {
+			b, err := os.ReadFile(cmd.Args[2])
+			require.NoError(t, err)
+			appliedContents = append(appliedContents, string(b))
+		}
+		return nil
+	})
+	commandCollectorCtx := exec.NewContext(ctx, commandCollector.Run)
+
+	// Call code under test.
+	err := g.Run(commandCollectorCtx)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "rolled back crash-looping services: gold-skia-diffcalculator")
+
+	// Assert that the deployment file from before the previous run was re-applied.
+	assert.Equal(t, baselineCommit, g.k8sConfigBaselineCommits[fakeK8sConfig.RepoUrl()])
+	assert.Equal(t, []string{"previous deployment"}, appliedContents)
+}
@@ -0,0 +1,233 @@
+package goldpushk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"google.golang.org/api/googleapi"
+
+	"go.skia.org/infra/go/now"
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/go/sklog"
+	"go.skia.org/infra/go/util"
+)
+
+// errLockExists is returned by lockStore.create if the lock is already held.
+var errLockExists = errors.New("lock already exists")
+
+// lockInfo is the content of the lock, which identifies its owner.
+type lockInfo struct {
+	Owner           string    `json:"owner"`
+	AcquiredAt      time.Time `json:"acquired_at"`
+	ExpiresAt       time.Time `json:"expires_at"`
+	DeployableUnits []string  `json:"deployable_units"`
+}
+
+// String returns a human-readable description of the lock.
+func (l lockInfo) String() string {
+	return fmt.Sprintf("held by %s since %s (expires at %s) to deploy %s", l.Owner, l.AcquiredAt.Format(time.RFC3339), l.ExpiresAt.Format(time.RFC3339), strings.Join(l.DeployableUnits, ", "))
+}
+
+// lockStore stores the lock that prevents concurrent goldpushk runs.
+type lockStore interface {
+	// create atomically creates the lock with the given contents. It returns errLockExists if the
+	// lock already exists.
+	create(ctx context.Context, contents []byte) error
+
+	// read returns the contents of the lock and a generation number identifying this particular
+	// version of the lock. It returns storage.ErrObjectNotExist if the lock does not exist.
+	read(ctx context.Context) ([]byte, int64, error)
+
+	// delete deletes the lock, but only if its generation matches the given one. Deleting a lock that
+	// does not exist is not an error.
+	delete(ctx context.Context, generation int64) error
+
+	// String returns a human-readable location of the lock.
+	String() string
+}
+
+// gcsLockStore is a lockStore backed by a GCS object.
+type gcsLockStore struct {
+	client *storage.Client
+	bucket string
+	path   string
+}
+
+// create implements the lockStore interface.
+func (s *gcsLockStore) create(ctx context.Context, contents []byte) error {
+	w := s.object().If(storage.Conditions{DoesNotExist: true}).NewWriter(ctx)
+	w.ContentType = "application/json"
+	if _, err := w.Write(contents); err != nil {
+		_ = w.Close()
+		return skerr.Wrap(err)
+	}
+	if err := w.Close(); err != nil {
+		if isPreconditionFailed(err) {
+			return errLockExists
+		}
+		return skerr.Wrap(err)
+	}
+	return nil
+}
+
+// read implements the lockStore interface.
+func (s *gcsLockStore) read(ctx context.Context) ([]byte, int64, error) {
+	r, err := s.object().NewReader(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer util.Close(r)
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, 0, skerr.Wrap(err)
+	}
+	return b, r.Attrs.Generation, nil
+}
+
+// delete implements the lockStore interface.
+func (s *gcsLockStore) delete(ctx context.Context, generation int64) error {
+	err := s.object().If(storage.Conditions{GenerationMatch: generation}).Delete(ctx)
+	if err == storage.ErrObjectNotExist {
+		return nil
+	}
+	return skerr.Wrap(err)
+}
+
+// String implements the lockStore interface.
+func (s *gcsLockStore) String() string {
+	return fmt.Sprintf("gs://%s/%s", s.bucket, s.path)
+}
+
+func (s *gcsLockStore) object() *storage.ObjectHandle {
+	return s.client.Bucket(s.bucket).Object(s.path)
+}
+
+// isPreconditionFailed returns true if the given error was caused by a failed GCS precondition.
+func isPreconditionFailed(err error) bool {
+	var apiErr *googleapi.Error
+	return errors.As(err, &apiErr) && apiErr.Code == http.StatusPreconditionFailed
+}
+
+// SetLock makes goldpushk acquire a lock stored as a GCS object at the given gs:// URL before
+// deploying, so that two goldpushk runs cannot deploy at the same time. The lock expires after the
+// given TTL in case a run dies without releasing it. If forceBreakLock is true, any existing lock is
+// broken, whether or not it has expired.
+func (g *Goldpushk) SetLock(ctx context.Context, gcsURL string, ttl time.Duration, forceBreakLock bool) error {
+	bucket, path, ok := strings.Cut(strings.TrimPrefix(gcsURL, "gs://"), "/")
+	if !strings.HasPrefix(gcsURL, "gs://") || !ok || bucket == "" || path == "" {
+		return skerr.Fmt("invalid lock location %q; expected gs://bucket/path", gcsURL)
+	}
+	client, err := storage.NewClient(ctx)
+	if err != nil {
+		return skerr.Wrap(err)
+	}
+	g.lockStore = &gcsLockStore{client: client, bucket: bucket, path: path}
+	g.lockTTL = ttl
+	g.forceBreakLock = forceBreakLock
+	return nil
+}
+
+// acquireLock acquires the lock, if one is configured. It fails if the lock is held by someone else
+// and has not expired, unless g.forceBreakLock is true. It returns a function that releases the
+// lock.
+func (g *Goldpushk) acquireLock(ctx context.Context) (release func(), err error) {
+	if g.lockStore == nil || g.dryRun {
+		return func() {}, nil
+	}
+
+	info := lockInfo{
+		Owner:           getLockOwner(),
+		AcquiredAt:      now.Now(ctx),
+		ExpiresAt:       now.Now(ctx).Add(g.lockTTL),
+		DeployableUnits: canonicalNames(append(append([]DeployableUnit{}, g.canariedDeployableUnits...), g.deployableUnits...)),
+	}
+	contents, err := json.Marshal(info)
+	if err != nil {
+		return nil, skerr.Wrap(err)
+	}
+
+	// Try to create the lock. If it exists, we break it if it expired (or we were told to) and try
+	// once more, so that a concurrent run that also broke it cannot both succeed.
+	for attempt := 0; attempt < 2; attempt++ {
+		err := g.lockStore.create(ctx, contents)
+		if err == nil {
+			fmt.Printf("\nAcquired lock %s.\n", g.lockStore)
+			return func() { g.releaseLock(ctx, contents) }, nil
+		}
+		if err != errLockExists {
+			return nil, skerr.Wrapf(err, "acquiring lock %s", g.lockStore)
+		}
+		if err := g.maybeBreakLock(ctx); err != nil {
+			return nil, skerr.Wrap(err)
+		}
+	}
+	return nil, skerr.Fmt("could not acquire lock %s; another goldpushk run might have just acquired it", g.lockStore)
+}
+
+// maybeBreakLock deletes the existing lock if it has expired or if g.forceBreakLock is true, and
+// returns an error otherwise.
+func (g *Goldpushk) maybeBreakLock(ctx context.Context) error {
+	b, generation, err := g.lockStore.read(ctx)
+	if err == storage.ErrObjectNotExist {
+		// Released in the meantime.
+		return nil
+	} else if err != nil {
+		return skerr.Wrapf(err, "reading lock %s", g.lockStore)
+	}
+	var existing lockInfo
+	if err := json.Unmarshal(b, &existing); err != nil {
+		// A corrupt lock is treated as expired.
+		sklog.Warningf("Could not parse lock %s: %s", g.lockStore, err)
+	}
+
+	expired := now.Now(ctx).After(existing.ExpiresAt)
+	if !expired && !g.forceBreakLock {
+		return skerr.Fmt("another goldpushk run holds lock %s: %s. Use --force-break-lock if you are sure that run is no longer active", g.lockStore, existing)
+	}
+	if expired {
+		fmt.Printf("\nBreaking expired lock %s: %s.\n", g.lockStore, existing)
+	} else {
+		fmt.Printf("\nForcibly breaking lock %s: %s.\n", g.lockStore, existing)
+	}
+	if err := g.lockStore.delete(ctx, generation); err != nil && !isPreconditionFailed(err) {
+		return skerr.Wrapf(err, "breaking lock %s", g.lockStore)
+	}
+	return nil
+}
+
+// releaseLock deletes the lock if it still has the given contents, i.e. if it has not been broken
+// and re-acquired by another run. Errors are logged, since the lock will eventually expire anyway.
+func (g *Goldpushk) releaseLock(ctx context.Context, contents []byte) {
+	b, generation, err := g.lockStore.read(ctx)
+	if err == storage.ErrObjectNotExist {
+		fmt.Printf("Warning: lock %s was broken by someone else before this run released it.\n", g.lockStore)
+		return
+	} else if err != nil {
+		sklog.Errorf("Failed to read lock %s: %s", g.lockStore, err)
+		return
+	}
+	if string(b) != string(contents) {
+		fmt.Printf("Warning: lock %s was broken and re-acquired by someone else before this run released it.\n", g.lockStore)
+		return
+	}
+	if err := g.lockStore.delete(ctx, generation); err != nil {
+		sklog.Errorf("Failed to release lock %s: %s", g.lockStore, err)
+	}
+}
+
+// getLockOwner returns a string identifying the user and host running goldpushk.
+func getLockOwner() string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
+	}
+	return fmt.Sprintf("%s@%s (pid %d)", getUsername(), hostname, os.Getpid())
+}
@@ -0,0 +1,149 @@
+package goldpushk
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.skia.org/infra/go/now"
+)
+
+// fakeLockStore is an in-memory lockStore.
+type fakeLockStore struct {
+	contents   []byte
+	generation int64
+}
+
+func (s *fakeLockStore) create(_ context.Context, contents []byte) error {
+	if s.contents != nil {
+		return errLockExists
+	}
+	s.contents = contents
+	s.generation++
+	return nil
+}
+
+func (s *fakeLockStore) read(_ context.Context) ([]byte, int64, error) {
+	if s.contents == nil {
+		return nil, 0, storage.ErrObjectNotExist
+	}
+	return s.contents, s.generation, nil
+}
+
+func (s *fakeLockStore) delete(_ context.Context, generation int64) error {
+	if s.contents != nil && generation == s.generation {
+		s.contents = nil
+	}
+	return nil
+}
+
+func (s *fakeLockStore) String() string {
+	return "fake-lock"
+}
+
+// setExistingLock stores a lock held by someone else which expires at the given time.
+func (s *fakeLockStore) setExistingLock(t *testing.T, expiresAt time.Time) {
+	b, err := json.Marshal(lockInfo{Owner: "alice@desktop", ExpiresAt: expiresAt, DeployableUnits: []string{"gold-chrome-frontend"}})
+	require.NoError(t, err)
+	s.contents = b
+	s.generation++
+}
+
+var fakeLockNow = time.Date(2021, time.May, 6, 7, 8, 9, 0, time.UTC)
+
+func newGoldpushkWithFakeLock(t *testing.T) (*Goldpushk, *fakeLockStore, context.Context) {
+	store := &fakeLockStore{}
+	g := &Goldpushk{
+		deployableUnits: []DeployableUnit{getUnit(t, Skia, Frontend)},
+		lockStore:       store,
+		lockTTL:         time.Hour,
+	}
+	ctx := context.WithValue(context.Background(), now.ContextKey, fakeLockNow)
+	return g, store, ctx
+}
+
+func TestGoldpushk_AcquireLock_NoExistingLock_AcquiresAndReleases(t *testing.T) {
+	g, store, ctx := newGoldpushkWithFakeLock(t)
+	_, restoreStdout := hideStdout(t)
+	defer restoreStdout()
+
+	release, err := g.acquireLock(ctx)
+	require.NoError(t, err)
+
+	var info lockInfo
+	require.NoError(t, json.Unmarshal(store.contents, &info))
+	assert.Equal(t, fakeLockNow, info.AcquiredAt)
+	assert.Equal(t, fakeLockNow.Add(time.Hour), info.ExpiresAt)
+	assert.Equal(t, []string{"gold-skia-frontend"}, info.DeployableUnits)
+	assert.NotEmpty(t, info.Owner)
+
+	release()
+	assert.Nil(t, store.contents)
+}
+
+func TestGoldpushk_AcquireLock_HeldByAnotherRun_Error(t *testing.T) {
+	g, store, ctx := newGoldpushkWithFakeLock(t)
+	store.setExistingLock(t, fakeLockNow.Add(time.Minute))
+
+	_, err := g.acquireLock(ctx)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "another goldpushk run holds lock fake-lock: held by alice@desktop")
+	assert.Contains(t, err.Error(), "--force-break-lock")
+}
+
+func TestGoldpushk_AcquireLock_ExpiredLock_BreaksLock(t *testing.T) {
+	g, store, ctx := newGoldpushkWithFakeLock(t)
+	store.setExistingLock(t, fakeLockNow.Add(-time.Minute))
+	fakeStdout, restoreStdout := hideStdout(t)
+	defer restoreStdout()
+
+	_, err := g.acquireLock(ctx)
+	require.NoError(t, err)
+	assert.Contains(t, readFakeStdout(t, fakeStdout), "Breaking expired lock fake-lock")
+
+	var info lockInfo
+	require.NoError(t, json.Unmarshal(store.contents, &info))
+	assert.Equal(t, []string{"gold-skia-frontend"}, info.DeployableUnits)
+}
+
+func TestGoldpushk_AcquireLock_ForceBreakLock_BreaksLock(t *testing.T) {
+	g, store, ctx := newGoldpushkWithFakeLock(t)
+	g.forceBreakLock = true
+	store.setExistingLock(t, fakeLockNow.Add(time.Minute))
+	fakeStdout, restoreStdout := hideStdout(t)
+	defer restoreStdout()
+
+	_, err := g.acquireLock(ctx)
+	require.NoError(t, err)
+	assert.Contains(t, readFakeStdout(t, fakeStdout), "Forcibly breaking lock fake-lock")
+}
+
+func TestGoldpushk_ReleaseLock_LockReacquiredBySomeoneElse_DoesNotDelete(t *testing.T) {
+	g, store, ctx := newGoldpushkWithFakeLock(t)
+	_, restoreStdout := hideStdout(t)
+	defer restoreStdout()
+
+	release, err := g.acquireLock(ctx)
+	require.NoError(t, err)
+
+	// Someone else breaks our lock and acquires it.
+	store.setExistingLock(t, fakeLockNow.Add(time.Hour))
+	othersLock := store.contents
+
+	release()
+	assert.Equal(t, othersLock, store.contents)
+}
+
+func TestGoldpushk_AcquireLock_DryRun_DoesNotLock(t *testing.T) {
+	g, store, ctx := newGoldpushkWithFakeLock(t)
+	g.dryRun = true
+
+	_, err := g.acquireLock(ctx)
+	require.NoError(t, err)
+	assert.Nil(t, store.contents)
+}
@@ -0,0 +1,187 @@
+package goldpushk
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/flynn/json5"
+
+	"go.skia.org/infra/email/go/emailclient"
+	"go.skia.org/infra/go/chatbot"
+	"go.skia.org/infra/go/httputils"
+	"go.skia.org/infra/go/notifier"
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/go/sklog"
+)
+
+// Types of the messages sent when a deployment finishes. They can be used with the
+// IncludeMsgTypes field of notifier.Config to only receive some of them.
+const (
+	msgTypeDeploymentSucceeded = "deployment_succeeded"
+	msgTypeDeploymentFailed    = "deployment_failed"
+)
+
+// NotificationsConfig configures the notifications sent when a deployment finishes.
+type NotificationsConfig struct {
+	// EmailServiceURL is the address of the email service used by email notifiers. Defaults to
+	// emailclient.DefaultEmailServiceURL.
+	EmailServiceURL string `json:"email_service_url,omitempty"`
+
+	// ChatWebhooksFile is the path to a file with the chat bot webhooks used by chat notifiers, in the
+	// format expected by chatbot.SendUsingConfig.
+	ChatWebhooksFile string `json:"chat_webhooks_file,omitempty"`
+
+	// Notifiers is the list of chat rooms, email addresses, Pub/Sub topics, etc. to notify.
+	Notifiers []*notifier.Config `json:"notifiers"`
+}
+
+// LoadNotificationsConfig reads a NotificationsConfig from a JSON5 file.
+func LoadNotificationsConfig(path string) (*NotificationsConfig, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, skerr.Wrapf(err, "reading %s", path)
+	}
+	var cfg NotificationsConfig
+	if err := json5.Unmarshal(b, &cfg); err != nil {
+		return nil, skerr.Wrapf(err, "parsing %s", path)
+	}
+	if len(cfg.Notifiers) == 0 {
+		return nil, skerr.Fmt("%s does not specify any notifiers", path)
+	}
+	for i, n := range cfg.Notifiers {
+		if err := n.Validate(); err != nil {
+			return nil, skerr.Wrapf(err, "invalid notifier #%d in %s", i, path)
+		}
+	}
+	return &cfg, nil
+}
+
+// deploymentNotifier sends notifications. It is implemented by *notifier.Router.
+type deploymentNotifier interface {
+	Send(ctx context.Context, msg *notifier.Message) error
+}
+
+// SetNotifications makes goldpushk send a notification through the notifiers in the given
+// NotificationsConfig when a deployment succeeds or fails.
+func (g *Goldpushk) SetNotifications(ctx context.Context, cfg *NotificationsConfig) error {
+	emailServiceURL := cfg.EmailServiceURL
+	if emailServiceURL == "" {
+		emailServiceURL = emailclient.DefaultEmailServiceURL
+	}
+	var chatBotConfigReader chatbot.ConfigReader
+	if cfg.ChatWebhooksFile != "" {
+		chatBotConfigReader = func() string {
+			b, err := os.ReadFile(cfg.ChatWebhooksFile)
+			if err != nil {
+				sklog.Errorf("Failed to read chat config: %s", err)
+				return ""
+			}
+			return string(b)
+		}
+	}
+
+	router := notifier.NewRouter(httputils.NewTimeoutClient(), emailclient.NewAt(emailServiceURL), chatBotConfigReader)
+	if err := router.AddFromConfigs(ctx, cfg.Notifiers); err != nil {
+		return skerr.Wrap(err)
+	}
+	g.notifier = router
+	return nil
+}
+
+// notifyDeploymentFinished sends a notification with the outcome of the deployment. A nil runErr
+// means the deployment succeeded. Failing to send the notification is logged but does not fail the
+// deployment.
+func (g *Goldpushk) notifyDeploymentFinished(ctx context.Context, runErr error) {
+	if g.notifier == nil || g.dryRun {
+		return
+	}
+	msg := g.makeDeploymentMessage(ctx, runErr)
+	if err := g.notifier.Send(ctx, msg); err != nil {
+		sklog.Errorf("Failed to send deployment notification: %s", err)
+		fmt.Printf("Warning: failed to send deployment notification: %s\n", err)
+	}
+}
+
+// makeDeploymentMessage returns the notification for a deployment with the given outcome. It lists
+// the deployed DeployableUnits, the commits of the repositories involved and the invoking user.
+func (g *Goldpushk) makeDeploymentMessage(ctx context.Context, runErr error) *notifier.Message {
+	var units []DeployableUnit
+	units = append(units, g.canariedDeployableUnits...)
+	units = append(units, g.deployableUnits...)
+	names := canonicalNames(units)
+
+	username := getUsername()
+
+	var body strings.Builder
+	if runErr == nil {
+		_, _ = fmt.Fprintf(&body, "%s deployed the following services:\n", username)
+	} else {
+		_, _ = fmt.Fprintf(&body, "%s failed to deploy the following services:\n", username)
+	}
+	for _, name := range names {
+		_, _ = fmt.Fprintf(&body, "  %s\n", name)
+	}
+	_, _ = fmt.Fprintf(&body, "\nskia-infra commit: %s\n", g.getSkiaInfraCommit(ctx))
+	_, _ = fmt.Fprintf(&body, "k8s-config commit: %s\n", g.getK8sConfigCommit())
+	if runErr != nil {
+		_, _ = fmt.Fprintf(&body, "\nError: %s\n", runErr)
+	}
+
+	msg := &notifier.Message{
+		Body: body.String(),
+	}
+	if runErr == nil {
+		msg.Subject = fmt.Sprintf("goldpushk: deployed %s", strings.Join(names, ", "))
+		msg.Severity = notifier.SEVERITY_INFO
+		msg.Type = msgTypeDeploymentSucceeded
+	} else {
+		msg.Subject = fmt.Sprintf("goldpushk: failed to deploy %s", strings.Join(names, ", "))
+		msg.Severity = notifier.SEVERITY_ERROR
+		msg.Type = msgTypeDeploymentFailed
+	}
+	return msg
+}
+
+// getSkiaInfraCommit returns the commit of the skia-infra checkout from which goldpushk generated
+// the config files, or "unknown" if it cannot be determined.
+func (g *Goldpushk) getSkiaInfraCommit(ctx context.Context) string {
+	stdout, err := g.execCmdAndReturnStdout(ctx, "git", []string{"-C", g.goldSrcDir, "rev-parse", "HEAD"})
+	if err != nil || strings.TrimSpace(stdout) == "" {
+		sklog.Warningf("Could not determine the skia-infra commit: %v", err)
+		return "unknown"
+	}
+	return strings.TrimSpace(stdout)
+}
+
+// getK8sConfigCommit returns the commit pushed to the k8s-config repository by this run (or by the
+// run it resumed), or the commit at which k8s-config was checked out if nothing was pushed. If any
+// clusters check in their configuration files to other repositories, the commit of each repository
+// is returned, prefixed by its URL.
+func (g *Goldpushk) getK8sConfigCommit() string {
+	repoUrls := g.getK8sConfigRepoUrls()
+	if len(repoUrls) == 1 {
+		return g.getK8sConfigRepoCommit(repoUrls[0])
+	}
+	var commits []string
+	for _, repoUrl := range repoUrls {
+		commits = append(commits, fmt.Sprintf("%s: %s", repoUrl, g.getK8sConfigRepoCommit(repoUrl)))
+	}
+	return strings.Join(commits, ", ")
+}
+
+// getK8sConfigRepoCommit returns the commit pushed to the repository with the given URL by this run
+// (or by the run it resumed), or the commit at which it was checked out if nothing was pushed.
+func (g *Goldpushk) getK8sConfigRepoCommit(repoUrl string) string {
+	if commit := g.k8sConfigCommits[repoUrl]; commit != "" {
+		return commit
+	}
+	if g.journal != nil && g.journal.K8sConfigCommits[repoUrl] != "" {
+		return g.journal.K8sConfigCommits[repoUrl]
+	}
+	if baseline := g.k8sConfigBaselineCommits[repoUrl]; baseline != "" {
+		return baseline + " (no changes)"
+	}
+	return "unknown"
+}
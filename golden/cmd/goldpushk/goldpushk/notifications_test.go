@@ -0,0 +1,116 @@
+package goldpushk
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.skia.org/infra/go/exec"
+	"go.skia.org/infra/go/notifier"
+)
+
+// fakeNotifier records the messages sent through it.
+type fakeNotifier struct {
+	messages []*notifier.Message
+}
+
+func (f *fakeNotifier) Send(_ context.Context, msg *notifier.Message) error {
+	f.messages = append(f.messages, msg)
+	return nil
+}
+
+func TestGoldpushk_NotifyDeploymentFinished_Success_SendsInfoMessage(t *testing.T) {
+	fake := &fakeNotifier{}
+	g := &Goldpushk{
+		canariedDeployableUnits: []DeployableUnit{getUnit(t, Skia, DiffCalculator)},
+		deployableUnits:         []DeployableUnit{getUnit(t, Skia, Frontend)},
+		goldSrcDir:              "/infra/golden",
+		k8sConfigRepoUrl:        "https://k8s-config.com",
+		k8sConfigCommits:        map[string]string{"https://k8s-config.com": "abcd1234"},
+		notifier:                fake,
+	}
+
+	commandCollector := exec.CommandCollector{}
+	commandCollector.SetDelegateRun(func(_ context.Context, cmd *exec.Command) error {
+		_, err := cmd.CombinedOutput.Write([]byte("0123456789abcdef\n"))
+		return err
+	})
+	ctx := exec.NewContext(context.Background(), commandCollector.Run)
+
+	g.notifyDeploymentFinished(ctx, nil)
+
+	assertCommandsMatch(t, &commandCollector, []string{"git -C /infra/golden rev-parse HEAD"})
+	require.Len(t, fake.messages, 1)
+	msg := fake.messages[0]
+	assert.Equal(t, "goldpushk: deployed gold-skia-diffcalculator, gold-skia-frontend", msg.Subject)
+	assert.Equal(t, notifier.SEVERITY_INFO, msg.Severity)
+	assert.Equal(t, msgTypeDeploymentSucceeded, msg.Type)
+	assert.Contains(t, msg.Body, "deployed the following services:\n  gold-skia-diffcalculator\n  gold-skia-frontend\n")
+	assert.Contains(t, msg.Body, "skia-infra commit: 0123456789abcdef\n")
+	assert.Contains(t, msg.Body, "k8s-config commit: abcd1234\n")
+	assert.NoError(t, msg.Validate())
+}
+
+func TestGoldpushk_NotifyDeploymentFinished_Failure_SendsErrorMessage(t *testing.T) {
+	fake := &fakeNotifier{}
+	g := &Goldpushk{
+		deployableUnits:          []DeployableUnit{getUnit(t, Skia, Frontend)},
+		k8sConfigRepoUrl:         "https://k8s-config.com",
+		k8sConfigBaselineCommits: map[string]string{"https://k8s-config.com": "beef"},
+		notifier:                 fake,
+	}
+
+	commandCollector := exec.CommandCollector{}
+	ctx := exec.NewContext(context.Background(), commandCollector.Run)
+
+	g.notifyDeploymentFinished(ctx, errors.New("kubectl exploded"))
+
+	require.Len(t, fake.messages, 1)
+	msg := fake.messages[0]
+	assert.Equal(t, "goldpushk: failed to deploy gold-skia-frontend", msg.Subject)
+	assert.Equal(t, notifier.SEVERITY_ERROR, msg.Severity)
+	assert.Equal(t, msgTypeDeploymentFailed, msg.Type)
+	assert.Contains(t, msg.Body, "skia-infra commit: unknown\n")
+	assert.Contains(t, msg.Body, "k8s-config commit: beef (no changes)\n")
+	assert.Contains(t, msg.Body, "Error: kubectl exploded\n")
+}
+
+func TestGoldpushk_NotifyDeploymentFinished_DryRun_DoesNotNotify(t *testing.T) {
+	fake := &fakeNotifier{}
+	g := &Goldpushk{
+		deployableUnits: []DeployableUnit{getUnit(t, Skia, Frontend)},
+		dryRun:          true,
+		notifier:        fake,
+	}
+
+	g.notifyDeploymentFinished(context.Background(), nil)
+	assert.Empty(t, fake.messages)
+}
+
+func TestLoadNotificationsConfig_Success(t *testing.T) {
+	path := writeConfigFile(t, "notifications.json5", `{
+  chat_webhooks_file: "/etc/goldpushk/webhooks",
+  notifiers: [
+    {filter: "info", chat: {room: "gold-deployments"}},
+    {includeMsgTypes: ["deployment_failed"], email: {emails: ["gold-team@example.com"]}},
+  ],
+}`)
+
+	cfg, err := LoadNotificationsConfig(path)
+	require.NoError(t, err)
+	assert.Equal(t, "/etc/goldpushk/webhooks", cfg.ChatWebhooksFile)
+	require.Len(t, cfg.Notifiers, 2)
+	assert.Equal(t, "gold-deployments", cfg.Notifiers[0].Chat.RoomID)
+	assert.Equal(t, []string{"gold-team@example.com"}, cfg.Notifiers[1].Email.Emails)
+}
+
+func TestLoadNotificationsConfig_InvalidNotifier_Error(t *testing.T) {
+	path := writeConfigFile(t, "notifications.json5", `{notifiers: [{chat: {room: "gold-deployments"}}]}`)
+
+	_, err := LoadNotificationsConfig(path)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid notifier #0")
+}
@@ -0,0 +1,296 @@
+package goldpushk
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/go/sklog"
+	"go.skia.org/infra/go/util"
+)
+
+const (
+	// Number of container restarts observed during the soak period after which a DeployableUnit is
+	// considered to be crash-looping, even if Kubernetes has not yet put any of its containers in
+	// the CrashLoopBackOff state.
+	crashLoopRestartThreshold = 2
+
+	// Reason reported by Kubernetes for containers that keep crashing after being restarted.
+	crashLoopBackOffReason = "CrashLoopBackOff"
+)
+
+// podHealth summarizes the health of all the pods corresponding to a DeployableUnit.
+type podHealth struct {
+	// Sum of the restart counts of all the containers across all the pods of a DeployableUnit.
+	restarts int
+
+	// True if any container of any of the pods of a DeployableUnit is in the CrashLoopBackOff state.
+	crashLooping bool
+}
+
+// podHealthFn has the same signature as method Goldpushk.getPodHealth(). To facilitate testing,
+// method Goldpushk.soak() takes a podHealthFn instance as a parameter instead of calling
+// Goldpushk.getPodHealth() directly.
+type podHealthFn func(context.Context, []DeployableUnit) (map[DeployableUnitID]podHealth, error)
+
+// SetSoakPeriod sets the amount of time during which the pushed DeployableUnits will be watched for
+// crash loops after they pass the monitoring step. Any crash-looping DeployableUnits will be rolled
+// back to the deployment file found in the k8s-config repository before goldpushk was run. A zero
+// duration (the default) disables this step.
+func (g *Goldpushk) SetSoakPeriod(soakPeriod time.Duration) {
+	g.soakPeriod = soakPeriod
+}
+
+// soakAndRollBackCanaries watches the canaried DeployableUnits for crash loops, and rolls them back
+// if necessary.
+func (g *Goldpushk) soakAndRollBackCanaries(ctx context.Context) error {
+	if len(g.canariedDeployableUnits) == 0 {
+		return nil
+	}
+	return skerr.Wrap(g.soakAndRollBack(ctx, g.canariedDeployableUnits))
+}
+
+// soakAndRollBackServices watches the non-canaried DeployableUnits for crash loops, and rolls them
+// back if necessary.
+func (g *Goldpushk) soakAndRollBackServices(ctx context.Context) error {
+	return skerr.Wrap(g.soakAndRollBack(ctx, g.deployableUnits))
+}
+
+// soakAndRollBack watches the given DeployableUnits for crash loops during the soak period. If any
+// of them crash-loop, it rolls them back and returns an error, which prevents goldpushk from
+// carrying out any subsequent deployment steps.
+func (g *Goldpushk) soakAndRollBack(ctx context.Context, units []DeployableUnit) error {
+	unhealthyUnits, err := g.soak(ctx, units, g.getPodHealth, time.Sleep)
+	if err != nil {
+		return skerr.Wrap(err)
+	}
+	if len(unhealthyUnits) == 0 {
+		return nil
+	}
+
+	var names []string
+	for _, unit := range unhealthyUnits {
+		names = append(names, unit.CanonicalName())
+	}
+	fmt.Printf("\nThe following services are crash-looping and will be rolled back:\n")
+	for _, name := range names {
+		fmt.Printf("  %s\n", name)
+	}
+	if err := g.rollBack(ctx, unhealthyUnits); err != nil {
+		return skerr.Wrapf(err, "failed to roll back crash-looping services")
+	}
+	return skerr.Fmt("rolled back crash-looping services: %s", strings.Join(names, ", "))
+}
+
+// soak polls the health of the pods of the given DeployableUnits for the duration of the soak
+// period, and returns the DeployableUnits that were found to be crash-looping, if any.
+//
+// A DeployableUnit is considered to be crash-looping if any of its containers are in the
+// CrashLoopBackOff state, or if its containers were restarted crashLoopRestartThreshold or more
+// times since the soak period started.
+func (g *Goldpushk) soak(ctx context.Context, units []DeployableUnit, getPodHealth podHealthFn, sleep sleepFn) ([]DeployableUnit, error) {
+	if g.soakPeriod <= 0 {
+		return nil, nil
+	}
+
+	if g.dryRun {
+		fmt.Println("\nSkipping soak step (dry run).")
+		return nil, nil
+	}
+
+	pollFrequency := time.Duration(g.uptimePollFrequencySeconds) * time.Second
+	fmt.Printf("\nWatching services for crash loops for %s (polling every %s).\n", g.soakPeriod, pollFrequency)
+
+	initialHealth, err := getPodHealth(ctx, units)
+	if err != nil {
+		return nil, skerr.Wrap(err)
+	}
+
+	health := initialHealth
+	for elapsed := time.Duration(0); ; elapsed += pollFrequency {
+		var unhealthyUnits []DeployableUnit
+		for _, unit := range units {
+			h := health[unit.DeployableUnitID]
+			if h.crashLooping || h.restarts-initialHealth[unit.DeployableUnitID].restarts >= crashLoopRestartThreshold {
+				unhealthyUnits = append(unhealthyUnits, unit)
+			}
+		}
+		if len(unhealthyUnits) > 0 {
+			return unhealthyUnits, nil
+		}
+
+		if elapsed >= g.soakPeriod {
+			fmt.Println("No crash loops detected.")
+			return nil, nil
+		}
+
+		sleep(pollFrequency)
+		if health, err = getPodHealth(ctx, units); err != nil {
+			return nil, skerr.Wrap(err)
+		}
+	}
+}
+
+// getPodHealth groups the given DeployableUnits by cluster, calls getPodHealthSingleCluster once
+// per cluster, and returns the union of the results.
+func (g *Goldpushk) getPodHealth(ctx context.Context, units []DeployableUnit) (map[DeployableUnitID]podHealth, error) {
+	allHealth := map[DeployableUnitID]podHealth{}
+	clusters, unitsByCluster := groupByCluster(units)
+	for _, cluster := range clusters {
+		unitsInCluster := unitsByCluster[cluster]
+		if err := g.switchClusters(ctx, cluster); err != nil {
+			return nil, skerr.Wrap(err)
+		}
+		health, err := g.getPodHealthSingleCluster(ctx, unitsInCluster)
+		if err != nil {
+			return nil, skerr.Wrap(err)
+		}
+		for unitID, h := range health {
+			allHealth[unitID] = h
+		}
+	}
+	return allHealth, nil
+}
+
+// getPodHealthSingleCluster returns the podHealth of each of the given DeployableUnits, which are
+// assumed to belong to the cluster that kubectl is currently configured to use. DeployableUnits
+// without any pods on the cluster will not have an entry in the returned map.
+func (g *Goldpushk) getPodHealthSingleCluster(ctx context.Context, units []DeployableUnit) (map[DeployableUnitID]podHealth, error) {
+	// JSONPath expression to be passed to kubectl. Below is a sample fragment of what the output
+	// looks like:
+	//
+	//   app:gold-skia-diffcalculator  podName:gold-skia-diffcalculator-0  restarts:0  waiting:
+	//   app:gold-skia-frontend  podName:gold-skia-frontend-67c547667d-cwt42  restarts:5 0  waiting:CrashLoopBackOff
+	//
+	// Fields restarts and waiting contain one space-separated value per container in the pod.
+	jsonPathExpr := `
+{range .items[*]}
+{'app:'}
+{.metadata.labels.app}
+{'  podName:'}
+{.metadata.name}
+{'  restarts:'}
+{.status.containerStatuses[*].restartCount}
+{'  waiting:'}
+{.status.containerStatuses[*].state.waiting.reason}
+{'\n'}
+{end}`
+	jsonPathExpr = strings.ReplaceAll(jsonPathExpr, "\n", "")
+
+	stdout, err := g.execCmdAndReturnStdout(ctx, "kubectl", []string{"get", "pods", "-o", fmt.Sprintf("jsonpath=%s", jsonPathExpr)})
+	if err != nil {
+		return nil, skerr.Wrap(err)
+	}
+
+	unitsByName := map[string]DeployableUnitID{}
+	for _, unit := range units {
+		unitsByName[unit.CanonicalName()] = unit.DeployableUnitID
+	}
+
+	re := regexp.MustCompile(`app:(?P<app>\S*)\s+podName:(?P<podName>\S+)\s+restarts:(?P<restarts>[\d ]*?)\s*waiting:(?P<waiting>.*)$`)
+	health := map[DeployableUnitID]podHealth{}
+	for _, line := range strings.Split(stdout, "\n") {
+		matches := re.FindStringSubmatch(line)
+		if len(matches) < 5 {
+			continue
+		}
+
+		// Skip pods that do not belong to any of the given DeployableUnits.
+		unitID, ok := unitsByName[matches[1]]
+		if !ok {
+			continue
+		}
+
+		h := health[unitID]
+		for _, restartsStr := range strings.Fields(matches[3]) {
+			restarts, err := strconv.Atoi(restartsStr)
+			if err != nil {
+				return nil, skerr.Wrapf(err, "parsing restart count in line %q", line)
+			}
+			h.restarts += restarts
+		}
+		for _, reason := range strings.Fields(matches[4]) {
+			if reason == crashLoopBackOffReason {
+				h.crashLooping = true
+			}
+		}
+		health[unitID] = h
+	}
+	return health, nil
+}
+
+// rollBack re-applies the deployment files of the given DeployableUnits as they were in the
+// k8s-config repository (or the repository of their cluster, if any) before goldpushk regenerated
+// them.
+//
+// Note that instance-specific ConfigMaps are not rolled back. Because the pod templates are
+// annotated with the checksum of the ConfigMap that was pushed (see configChecksumAnnotation), the
+// rolled back pods will still use the new configuration files, so a warning asking the user to
+// restore the affected ConfigMaps manually is printed.
+func (g *Goldpushk) rollBack(ctx context.Context, units []DeployableUnit) error {
+	// Check the baselines of all affected repositories before rolling back any DeployableUnits.
+	for _, unit := range units {
+		repoUrl := g.getK8sConfigRepoUrl(getCluster(unit))
+		baseline := g.k8sConfigBaselineCommits[repoUrl]
+		if baseline == "" {
+			return skerr.Fmt("unknown k8s-config baseline commit for %s", repoUrl)
+		}
+		if baseline == g.k8sConfigCommits[repoUrl] {
+			return skerr.Fmt("k8s-config baseline commit %s of %s is the commit pushed by this deployment; refusing to roll back to the same deployment files", baseline, repoUrl)
+		}
+	}
+
+	dir, err := os.MkdirTemp("", "goldpushk-rollback")
+	if err != nil {
+		return skerr.Wrap(err)
+	}
+	defer func() {
+		if err := os.RemoveAll(dir); err != nil {
+			sklog.Warningf("Failed to delete %s: %s", dir, err)
+		}
+	}()
+
+	for _, unit := range units {
+		// Retrieve the previous version of the deployment file from the k8s-config repository.
+		c := getCluster(unit)
+		baseline := g.k8sConfigBaselineCommits[g.getK8sConfigRepoUrl(c)]
+		repoPath := path.Join(c.configDir, unit.CanonicalName()+".yaml")
+		contents, err := g.getK8sConfigCheckout(c).Git(ctx, "show", baseline+":"+repoPath)
+		if err != nil {
+			return skerr.Wrapf(err, "retrieving previous version of %s; %s must be rolled back manually", repoPath, unit.CanonicalName())
+		}
+		previousDeploymentFile := filepath.Join(dir, unit.CanonicalName()+".yaml")
+		if err := os.WriteFile(previousDeploymentFile, []byte(contents), 0644); err != nil {
+			return skerr.Wrap(err)
+		}
+
+		if err := g.switchClusters(ctx, c); err != nil {
+			return skerr.Wrap(err)
+		}
+		fmt.Printf("%s: rolling back to %s at %s.\n", unit.CanonicalName(), repoPath, baseline)
+		if err := g.execCmd(ctx, "kubectl", []string{"apply", "-f", previousDeploymentFile}); err != nil {
+			return skerr.Wrap(err)
+		}
+	}
+
+	var configMaps []string
+	for _, unit := range units {
+		configMap := fmt.Sprintf("%s (cluster %s)", getInstanceConfigMapName(unit.Instance), getCluster(unit).name)
+		if !util.In(configMap, configMaps) {
+			configMaps = append(configMaps, configMap)
+		}
+	}
+	fmt.Println("\nWARNING: The following ConfigMaps were not rolled back. The rolled back services will keep using")
+	fmt.Println("the new configuration files unless these ConfigMaps are restored manually:")
+	for _, configMap := range configMaps {
+		fmt.Printf("  %s\n", configMap)
+	}
+	return nil
+}
@@ -0,0 +1,315 @@
+package goldpushk
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cipd_git "go.skia.org/infra/bazel/external/cipd/git"
+	"go.skia.org/infra/go/exec"
+	"go.skia.org/infra/go/testutils/unittest"
+)
+
+func TestGoldpushk_Soak_NoCrashLoops_ReturnsNoUnits(t *testing.T) {
+	s := ProductionDeployableUnits()
+	var units []DeployableUnit
+	units = appendUnit(t, units, s, Skia, DiffCalculator)
+	units = appendUnit(t, units, s, Skia, Frontend)
+
+	g := &Goldpushk{
+		soakPeriod:                 10 * time.Second,
+		uptimePollFrequencySeconds: 5,
+	}
+
+	_, restoreStdout := hideStdout(t)
+	defer restoreStdout()
+
+	// A single restart of a container does not count as a crash loop.
+	mockHealth := []map[DeployableUnitID]podHealth{
+		{makeID(Skia, DiffCalculator): {restarts: 3}, makeID(Skia, Frontend): {}},
+		{makeID(Skia, DiffCalculator): {restarts: 3}, makeID(Skia, Frontend): {restarts: 1}},
+		{makeID(Skia, DiffCalculator): {restarts: 4}, makeID(Skia, Frontend): {restarts: 1}},
+	}
+	getPodHealth, sleep, numSleeps := mockPodHealthAndSleep(t, mockHealth)
+
+	unhealthyUnits, err := g.soak(context.Background(), units, getPodHealth, sleep)
+	require.NoError(t, err)
+	assert.Empty(t, unhealthyUnits)
+	assert.Equal(t, 2, *numSleeps)
+}
+
+func TestGoldpushk_Soak_CrashLoopBackOff_ReturnsCrashLoopingUnits(t *testing.T) {
+	s := ProductionDeployableUnits()
+	var units []DeployableUnit
+	units = appendUnit(t, units, s, Skia, DiffCalculator)
+	units = appendUnit(t, units, s, Skia, Frontend)
+
+	g := &Goldpushk{
+		soakPeriod:                 time.Minute,
+		uptimePollFrequencySeconds: 5,
+	}
+
+	_, restoreStdout := hideStdout(t)
+	defer restoreStdout()
+
+	mockHealth := []map[DeployableUnitID]podHealth{
+		{makeID(Skia, DiffCalculator): {}, makeID(Skia, Frontend): {}},
+		{makeID(Skia, DiffCalculator): {}, makeID(Skia, Frontend): {restarts: 1, crashLooping: true}},
+	}
+	getPodHealth, sleep, numSleeps := mockPodHealthAndSleep(t, mockHealth)
+
+	unhealthyUnits, err := g.soak(context.Background(), units, getPodHealth, sleep)
+	require.NoError(t, err)
+	assert.Equal(t, []DeployableUnit{units[1]}, unhealthyUnits)
+	assert.Equal(t, 1, *numSleeps)
+}
+
+func TestGoldpushk_Soak_RestartsAboveThreshold_ReturnsCrashLoopingUnits(t *testing.T) {
+	s := ProductionDeployableUnits()
+	var units []DeployableUnit
+	units = appendUnit(t, units, s, Skia, DiffCalculator)
+	units = appendUnit(t, units, s, Skia, Frontend)
+
+	g := &Goldpushk{
+		soakPeriod:                 time.Minute,
+		uptimePollFrequencySeconds: 5,
+	}
+
+	_, restoreStdout := hideStdout(t)
+	defer restoreStdout()
+
+	mockHealth := []map[DeployableUnitID]podHealth{
+		{makeID(Skia, DiffCalculator): {restarts: 7}, makeID(Skia, Frontend): {}},
+		{makeID(Skia, DiffCalculator): {restarts: 8}, makeID(Skia, Frontend): {}},
+		{makeID(Skia, DiffCalculator): {restarts: 9}, makeID(Skia, Frontend): {}},
+	}
+	getPodHealth, sleep, numSleeps := mockPodHealthAndSleep(t, mockHealth)
+
+	unhealthyUnits, err := g.soak(context.Background(), units, getPodHealth, sleep)
+	require.NoError(t, err)
+	assert.Equal(t, []DeployableUnit{units[0]}, unhealthyUnits)
+	assert.Equal(t, 2, *numSleeps)
+}
+
+func TestGoldpushk_Soak_FlagDryRunSet_DoesNotSoak(t *testing.T) {
+	s := ProductionDeployableUnits()
+	var units []DeployableUnit
+	units = appendUnit(t, units, s, Skia, DiffCalculator)
+
+	g := &Goldpushk{
+		soakPeriod:                 time.Minute,
+		uptimePollFrequencySeconds: 5,
+		dryRun:                     true,
+	}
+
+	fakeStdout, restoreStdout := hideStdout(t)
+	defer restoreStdout()
+
+	getPodHealth := func(context.Context, []DeployableUnit) (map[DeployableUnitID]podHealth, error) {
+		require.Fail(t, "getPodHealth should not be called")
+		return nil, nil
+	}
+	sleep := func(time.Duration) {
+		require.Fail(t, "sleep should not be called")
+	}
+
+	unhealthyUnits, err := g.soak(context.Background(), units, getPodHealth, sleep)
+	require.NoError(t, err)
+	assert.Empty(t, unhealthyUnits)
+	assert.Equal(t, "\nSkipping soak step (dry run).\n", readFakeStdout(t, fakeStdout))
+}
+
+func TestGoldpushk_GetPodHealthSingleCluster_Success(t *testing.T) {
+	unittest.LinuxOnlyTest(t)
+
+	s := ProductionDeployableUnits()
+	var units []DeployableUnit
+	units = appendUnit(t, units, s, Skia, DiffCalculator)
+	units = appendUnit(t, units, s, Skia, Frontend)
+	units = appendUnit(t, units, s, Skia, Ingestion)
+	units = appendUnit(t, units, s, Chrome, Frontend)
+
+	g := &Goldpushk{}
+
+	const kubectlOutput = `app:gold-skia-diffcalculator  podName:gold-skia-diffcalculator-0  restarts:0  waiting:
+app:gold-skia-frontend  podName:gold-skia-frontend-67c547667d-cwt42  restarts:2 1  waiting:
+app:gold-skia-frontend  podName:gold-skia-frontend-67c547667d-hr86n  restarts:5 0  waiting:CrashLoopBackOff
+app:gold-skia-ingestion  podName:gold-skia-ingestion-f8b66844f-4969w  restarts:1  waiting:ContainerCreating
+app:gold-flutter-frontend  podName:gold-flutter-frontend-5dfd8b65cb-l4lt5  restarts:9  waiting:CrashLoopBackOff
+`
+	commandCollector := exec.CommandCollector{}
+	commandCollector.SetDelegateRun(func(ctx context.Context, cmd *exec.Command) error {
+		_, err := cmd.CombinedOutput.Write([]byte(kubectlOutput))
+		require.NoError(t, err)
+		return nil
+	})
+	commandCollectorCtx := exec.NewContext(context.Background(), commandCollector.Run)
+
+	health, err := g.getPodHealthSingleCluster(commandCollectorCtx, units)
+	require.NoError(t, err)
+	assert.Equal(t, map[DeployableUnitID]podHealth{
+		makeID(Skia, DiffCalculator): {restarts: 0},
+		makeID(Skia, Frontend):       {restarts: 8, crashLooping: true},
+		makeID(Skia, Ingestion):      {restarts: 1},
+	}, health)
+}
+
+func TestGoldpushk_RollBack_AppliesPreviousDeploymentFiles(t *testing.T) {
+	unittest.LinuxOnlyTest(t)
+
+	ctx := cipd_git.UseGitFinder(context.Background())
+
+	// Create a fake k8s-config repository with a deployment file.
+	fakeK8sConfig := createFakeK8sConfigRepo(t, ctx)
+	defer fakeK8sConfig.Cleanup()
+	fakeK8sConfig.Add(ctx, "skia-public/gold-skia-frontend.yaml", "previous deployment")
+	fakeK8sConfig.Commit(ctx)
+
+	g := &Goldpushk{
+		k8sConfigRepoUrl: fakeK8sConfig.RepoUrl(),
+	}
+
+	fakeStdout, restoreStdout := hideStdout(t)
+	defer restoreStdout()
+
+	// Check out the repository and regenerate the deployment file.
+	require.NoError(t, g.checkOutK8sConfigRepos(ctx))
+	defer g.deleteK8sConfigCheckouts()
+	writeFileIntoRepo(t, g.getK8sConfigCheckout(clusterSkiaPublic), "skia-public/gold-skia-frontend.yaml", "new deployment")
+
+	// Intercept gcloud and kubectl commands, and capture the contents of the applied file.
+	var appliedContents string
+	commandCollector := exec.CommandCollector{}
+	commandCollector.SetDelegateRun(func(ctx context.Context, cmd *exec.Command) error {
+		if cmd.Name == "kubectl" {
+			b, err := os.ReadFile(cmd.Args[2])
+			require.NoError(t, err)
+			appliedContents = string(b)
+			return nil
+		}
+		if cmd.Name == "gcloud" {
+			return nil
+		}
+		return exec.DefaultRun(ctx, cmd)
+	})
+	commandCollectorCtx := exec.NewContext(ctx, commandCollector.Run)
+
+	s := ProductionDeployableUnits()
+	unit, ok := s.Get(makeID(Skia, Frontend))
+	require.True(t, ok)
+	require.NoError(t, g.rollBack(commandCollectorCtx, []DeployableUnit{unit}))
+
+	assert.Equal(t, "previous deployment", appliedContents)
+	var kubectlCommands []string
+	for _, cmd := range commandCollector.Commands() {
+		if cmd.Name == "kubectl" {
+			kubectlCommands = append(kubectlCommands, exec.DebugString(cmd))
+			assert.Equal(t, "gold-skia-frontend.yaml", filepath.Base(cmd.Args[2]))
+		}
+	}
+	assert.Len(t, kubectlCommands, 1)
+	assert.Contains(t, readFakeStdout(t, fakeStdout), "ConfigMaps were not rolled back")
+	assert.Contains(t, readFakeStdout(t, fakeStdout), "gold-skia-config (cluster skia-public)")
+}
+
+func TestGoldpushk_RollBack_ClusterWithOwnConfigRepo_AppliesDeploymentFilesFromEachRepo(t *testing.T) {
+	unittest.LinuxOnlyTest(t)
+
+	ctx := cipd_git.UseGitFinder(context.Background())
+
+	// Create a fake k8s-config repository, and a fake repository for a cluster with its own
+	// repository, each with a deployment file.
+	fakeK8sConfig := createFakeK8sConfigRepo(t, ctx)
+	defer fakeK8sConfig.Cleanup()
+	fakeK8sConfig.Add(ctx, "skia-public/gold-skia-frontend.yaml", "previous public deployment")
+	fakeK8sConfig.Commit(ctx)
+	fakeEuropeK8sConfig := createFakeK8sConfigRepo(t, ctx)
+	defer fakeEuropeK8sConfig.Cleanup()
+	fakeEuropeK8sConfig.Add(ctx, "skia-europe/gold-chrome-frontend.yaml", "previous europe deployment")
+	fakeEuropeK8sConfig.Commit(ctx)
+
+	europeUnit := getUnit(t, Chrome, Frontend)
+	europeUnit.cluster = cluster{name: "skia-europe", projectID: "skia-public", zone: "europe-west1-b", configRepo: fakeEuropeK8sConfig.RepoUrl(), configDir: "skia-europe"}
+	units := []DeployableUnit{getUnit(t, Skia, Frontend), europeUnit}
+	g := &Goldpushk{
+		deployableUnits:  units,
+		k8sConfigRepoUrl: fakeK8sConfig.RepoUrl(),
+	}
+
+	_, restoreStdout := hideStdout(t)
+	defer restoreStdout()
+
+	// Check out the repositories and regenerate the deployment files.
+	require.NoError(t, g.checkOutK8sConfigRepos(ctx))
+	defer g.deleteK8sConfigCheckouts()
+	writeFileIntoRepo(t, g.getK8sConfigCheckout(clusterSkiaPublic), "skia-public/gold-skia-frontend.yaml", "new public deployment")
+	writeFileIntoRepo(t, g.getK8sConfigCheckout(europeUnit.cluster), "skia-europe/gold-chrome-frontend.yaml", "new europe deployment")
+
+	// Intercept gcloud and kubectl commands, and capture the contents of the applied files.
+	var appliedContents []string
+	commandCollector := exec.CommandCollector{}
+	commandCollector.SetDelegateRun(func(ctx context.Context, cmd *exec.Command) error {
+		if cmd.Name == "kubectl" {
+			b, err := os.ReadFile(cmd.Args[2])
+			require.NoError(t, err)
+			appliedContents = append(appliedContents, string(b))
+			return nil
+		}
+		if cmd.Name == "gcloud" {
+			return nil
+		}
+		return exec.DefaultRun(ctx, cmd)
+	})
+	commandCollectorCtx := exec.NewContext(ctx, commandCollector.Run)
+
+	require.NoError(t, g.rollBack(commandCollectorCtx, units))
+
+	assert.Equal(t, []string{"previous public deployment", "previous europe deployment"}, appliedContents)
+}
+
+func TestGoldpushk_RollBack_BaselineIsPushedCommit_Error(t *testing.T) {
+	g := &Goldpushk{
+		k8sConfigBaselineCommits: map[string]string{"": "abcd1234"},
+		k8sConfigCommits:         map[string]string{"": "abcd1234"},
+	}
+	addFakeK8sConfigRepoCheckout(g)
+
+	s := ProductionDeployableUnits()
+	unit, ok := s.Get(makeID(Skia, Frontend))
+	require.True(t, ok)
+	err := g.rollBack(context.Background(), []DeployableUnit{unit})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "refusing to roll back to the same deployment files")
+}
+
+func TestGoldpushk_RollBack_UnknownBaselineCommit_Error(t *testing.T) {
+	g := &Goldpushk{}
+	addFakeK8sConfigRepoCheckout(g)
+
+	s := ProductionDeployableUnits()
+	unit, ok := s.Get(makeID(Skia, Frontend))
+	require.True(t, ok)
+	err := g.rollBack(context.Background(), []DeployableUnit{unit})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown k8s-config baseline commit")
+}
+
+// mockPodHealthAndSleep returns a podHealthFn that returns the given podHealth maps in order,
+// advancing to the next one each time the returned sleepFn is called. It also returns a pointer to
+// the number of times the sleepFn was called.
+func mockPodHealthAndSleep(t *testing.T, mockHealth []map[DeployableUnitID]podHealth) (podHealthFn, sleepFn, *int) {
+	numSleeps := 0
+	getPodHealth := func(context.Context, []DeployableUnit) (map[DeployableUnitID]podHealth, error) {
+		require.Less(t, numSleeps, len(mockHealth))
+		return mockHealth[numSleeps], nil
+	}
+	sleep := func(time.Duration) {
+		numSleeps++
+	}
+	return getPodHealth, sleep, &numSleeps
+}
@@ -0,0 +1,211 @@
+package goldpushk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"go.skia.org/infra/go/now"
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/go/sklog"
+)
+
+// k8sWorkloadList is the subset of the output of "kubectl get deployments,statefulsets -o json"
+// that goldpushk needs in order to determine whether a rollout is complete, and which container
+// images are deployed.
+type k8sWorkloadList struct {
+	Items []k8sWorkload `json:"items"`
+}
+
+// k8sWorkload represents a Kubernetes Deployment or StatefulSet.
+type k8sWorkload struct {
+	Kind     string `json:"kind"`
+	Metadata struct {
+		Name       string            `json:"name"`
+		Generation int64             `json:"generation"`
+		Labels     map[string]string `json:"labels"`
+	} `json:"metadata"`
+	Spec struct {
+		Replicas *int32 `json:"replicas"`
+		Template struct {
+			Spec struct {
+				Containers []struct {
+					Name  string `json:"name"`
+					Image string `json:"image"`
+				} `json:"containers"`
+			} `json:"spec"`
+		} `json:"template"`
+	} `json:"spec"`
+	Status struct {
+		ObservedGeneration int64                  `json:"observedGeneration"`
+		Replicas           int32                  `json:"replicas"`
+		UpdatedReplicas    int32                  `json:"updatedReplicas"`
+		ReadyReplicas      int32                  `json:"readyReplicas"`
+		AvailableReplicas  int32                  `json:"availableReplicas"`
+		CurrentRevision    string                 `json:"currentRevision"`
+		UpdateRevision     string                 `json:"updateRevision"`
+		Conditions         []k8sWorkloadCondition `json:"conditions"`
+	} `json:"status"`
+}
+
+// k8sWorkloadCondition is a condition of a Kubernetes Deployment, e.g. "Progressing".
+type k8sWorkloadCondition struct {
+	Type           string    `json:"type"`
+	Status         string    `json:"status"`
+	Reason         string    `json:"reason"`
+	LastUpdateTime time.Time `json:"lastUpdateTime"`
+}
+
+// rolloutStatus is the status of the rollout of a DeployableUnit.
+type rolloutStatus struct {
+	// complete is true if all replicas have been updated and are available.
+	complete bool
+
+	// message explains why the rollout is not yet complete, e.g. "1 of 3 updated replicas are
+	// available".
+	message string
+
+	// completedAt is the time at which the rollout completed, if known. It can only be determined for
+	// Deployments.
+	completedAt time.Time
+}
+
+// SetUseRolloutStatus makes the monitoring step determine whether DeployableUnits are ready based
+// on the rollout status of their Deployments and StatefulSets (similarly to "kubectl rollout
+// status"), as opposed to the start timestamps of their pods.
+func (g *Goldpushk) SetUseRolloutStatus(useRolloutStatus bool) {
+	g.useRolloutStatus = useRolloutStatus
+}
+
+// getUptimesFn returns the uptimesFn used by the monitoring step.
+func (g *Goldpushk) getUptimesFn() uptimesFn {
+	if g.useRolloutStatus {
+		return g.getRolloutUptimes
+	}
+	return g.getUptimes
+}
+
+// getRolloutUptimes has the same signature as getUptimes, but it considers a DeployableUnit to be
+// up only if its rollout is complete, and it reports the time since its rollout completed.
+//
+// For StatefulSets, which do not report when their rollout completed, the uptime is measured from
+// the first call to this method that observed the rollout as complete.
+func (g *Goldpushk) getRolloutUptimes(ctx context.Context, units []DeployableUnit) (map[DeployableUnitID]time.Duration, error) {
+	if g.rolloutCompleteSince == nil {
+		g.rolloutCompleteSince = map[DeployableUnitID]time.Time{}
+	}
+
+	uptimes := map[DeployableUnitID]time.Duration{}
+	clusters, unitsByCluster := groupByCluster(units)
+	for _, cluster := range clusters {
+		if err := g.switchClusters(ctx, cluster); err != nil {
+			return nil, skerr.Wrap(err)
+		}
+		statuses, err := g.getRolloutStatusesSingleCluster(ctx, unitsByCluster[cluster])
+		if err != nil {
+			return nil, skerr.Wrap(err)
+		}
+		for _, unit := range unitsByCluster[cluster] {
+			status, ok := statuses[unit.DeployableUnitID]
+			if !ok || !status.complete {
+				delete(g.rolloutCompleteSince, unit.DeployableUnitID)
+				if ok {
+					sklog.Infof("Rollout of %s not complete: %s", unit.CanonicalName(), status.message)
+				}
+				continue
+			}
+			since := status.completedAt
+			if since.IsZero() {
+				if _, ok := g.rolloutCompleteSince[unit.DeployableUnitID]; !ok {
+					g.rolloutCompleteSince[unit.DeployableUnitID] = now.Now(ctx)
+				}
+				since = g.rolloutCompleteSince[unit.DeployableUnitID]
+			}
+			uptimes[unit.DeployableUnitID] = now.Now(ctx).Sub(since)
+		}
+	}
+	return uptimes, nil
+}
+
+// getRolloutStatusesSingleCluster returns the rolloutStatus of the Deployment or StatefulSet of each
+// of the given DeployableUnits, which are assumed to belong to the cluster that kubectl is currently
+// configured to use. DeployableUnits without a Deployment or StatefulSet on the cluster will not
+// have an entry in the returned map.
+func (g *Goldpushk) getRolloutStatusesSingleCluster(ctx context.Context, units []DeployableUnit) (map[DeployableUnitID]rolloutStatus, error) {
+	stdout, err := g.execCmdAndReturnStdout(ctx, "kubectl", []string{"get", "deployments,statefulsets", "-o", "json"})
+	if err != nil {
+		return nil, skerr.Wrap(err)
+	}
+	var workloads k8sWorkloadList
+	if err := json.Unmarshal([]byte(stdout), &workloads); err != nil {
+		return nil, skerr.Wrapf(err, "parsing kubectl output")
+	}
+
+	statuses := map[DeployableUnitID]rolloutStatus{}
+	for _, workload := range workloads.Items {
+		for _, unit := range units {
+			if workload.Metadata.Name == unit.CanonicalName() || workload.Metadata.Labels["app"] == unit.CanonicalName() {
+				statuses[unit.DeployableUnitID] = workload.rolloutStatus()
+			}
+		}
+	}
+	return statuses, nil
+}
+
+// rolloutStatus determines whether the rollout of the workload is complete. It follows the same
+// logic as "kubectl rollout status".
+func (w *k8sWorkload) rolloutStatus() rolloutStatus {
+	var replicas int32 = 1
+	if w.Spec.Replicas != nil {
+		replicas = *w.Spec.Replicas
+	}
+	notComplete := func(format string, args ...interface{}) rolloutStatus {
+		return rolloutStatus{message: fmt.Sprintf(format, args...)}
+	}
+
+	if w.Metadata.Generation > w.Status.ObservedGeneration {
+		return notComplete("waiting for %s spec update to be observed", w.Kind)
+	}
+
+	switch w.Kind {
+	case "Deployment":
+		var completedAt time.Time
+		for _, c := range w.Status.Conditions {
+			if c.Type != "Progressing" {
+				continue
+			}
+			if c.Reason == "ProgressDeadlineExceeded" {
+				return notComplete("rollout exceeded its progress deadline")
+			}
+			if c.Reason == "NewReplicaSetAvailable" {
+				completedAt = c.LastUpdateTime
+			}
+		}
+		if w.Status.UpdatedReplicas < replicas {
+			return notComplete("%d out of %d new replicas have been updated", w.Status.UpdatedReplicas, replicas)
+		}
+		if w.Status.Replicas > w.Status.UpdatedReplicas {
+			return notComplete("%d old replicas are pending termination", w.Status.Replicas-w.Status.UpdatedReplicas)
+		}
+		if w.Status.AvailableReplicas < w.Status.UpdatedReplicas {
+			return notComplete("%d of %d updated replicas are available", w.Status.AvailableReplicas, w.Status.UpdatedReplicas)
+		}
+		return rolloutStatus{complete: true, completedAt: completedAt}
+
+	case "StatefulSet":
+		if w.Status.ObservedGeneration == 0 {
+			return notComplete("waiting for StatefulSet spec update to be observed")
+		}
+		if w.Status.ReadyReplicas < replicas {
+			return notComplete("%d of %d pods are ready", w.Status.ReadyReplicas, replicas)
+		}
+		if w.Status.UpdateRevision != w.Status.CurrentRevision {
+			return notComplete("waiting for rolling update to finish: %d out of %d pods have been updated", w.Status.UpdatedReplicas, replicas)
+		}
+		return rolloutStatus{complete: true}
+
+	default:
+		return notComplete("unsupported kind %q", w.Kind)
+	}
+}
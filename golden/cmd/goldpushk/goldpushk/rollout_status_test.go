@@ -0,0 +1,157 @@
+package goldpushk
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.skia.org/infra/go/exec"
+	"go.skia.org/infra/go/now"
+)
+
+const kubectlGetWorkloadsOutput = `{
+  "items": [
+    {
+      "kind": "Deployment",
+      "metadata": {"name": "gold-chrome-frontend", "generation": 4, "labels": {"app": "gold-chrome-frontend"}},
+      "spec": {"replicas": 2},
+      "status": {
+        "observedGeneration": 4,
+        "replicas": 2,
+        "updatedReplicas": 2,
+        "readyReplicas": 2,
+        "availableReplicas": 2,
+        "conditions": [
+          {"type": "Available", "status": "True", "reason": "MinimumReplicasAvailable", "lastUpdateTime": "2019-10-01T17:00:00Z"},
+          {"type": "Progressing", "status": "True", "reason": "NewReplicaSetAvailable", "lastUpdateTime": "2019-10-01T17:28:00Z"}
+        ]
+      }
+    },
+    {
+      "kind": "Deployment",
+      "metadata": {"name": "gold-chrome-ingestion", "generation": 7, "labels": {"app": "gold-chrome-ingestion"}},
+      "spec": {"replicas": 3},
+      "status": {
+        "observedGeneration": 7,
+        "replicas": 3,
+        "updatedReplicas": 3,
+        "readyReplicas": 2,
+        "availableReplicas": 2,
+        "conditions": [
+          {"type": "Progressing", "status": "True", "reason": "ReplicaSetUpdated", "lastUpdateTime": "2019-10-01T17:29:00Z"}
+        ]
+      }
+    },
+    {
+      "kind": "StatefulSet",
+      "metadata": {"name": "gold-chrome-diffcalculator", "generation": 2, "labels": {"app": "gold-chrome-diffcalculator"}},
+      "spec": {"replicas": 1},
+      "status": {
+        "observedGeneration": 2,
+        "replicas": 1,
+        "updatedReplicas": 1,
+        "readyReplicas": 1,
+        "currentRevision": "gold-chrome-diffcalculator-abc",
+        "updateRevision": "gold-chrome-diffcalculator-abc"
+      }
+    }
+  ]
+}`
+
+func TestGoldpushk_GetRolloutUptimes_Success(t *testing.T) {
+	units := []DeployableUnit{
+		getUnit(t, Chrome, Frontend),
+		getUnit(t, Chrome, Ingestion),
+		getUnit(t, Chrome, DiffCalculator),
+		getUnit(t, Chrome, BaselineServer),
+	}
+	g := &Goldpushk{}
+
+	// Set up mocks.
+	commandCollector := exec.CommandCollector{}
+	commandCollector.SetDelegateRun(func(ctx context.Context, cmd *exec.Command) error {
+		if cmd.Name == "kubectl" {
+			_, err := cmd.CombinedOutput.Write([]byte(kubectlGetWorkloadsOutput))
+			require.NoError(t, err)
+		}
+		return nil
+	})
+	ctx := exec.NewContext(context.Background(), commandCollector.Run)
+	fakeNow := time.Date(2019, 10, 01, 17, 30, 0, 0, time.UTC)
+	ctx = context.WithValue(ctx, now.ContextKey, fakeNow)
+
+	// Call code under test.
+	uptimes, err := g.getRolloutUptimes(ctx, units)
+	require.NoError(t, err)
+
+	expectedCommands := []string{
+		"gcloud container clusters get-credentials skia-public --zone us-central1-a --project skia-public",
+		"kubectl get deployments,statefulsets -o json",
+	}
+	assertCommandsMatch(t, &commandCollector, expectedCommands)
+
+	// The Deployment's uptime is measured from the completion of its rollout.
+	assert.Len(t, uptimes, 2)
+	assert.Equal(t, 2*time.Minute, uptimes[makeID(Chrome, Frontend)]) // 17:30:00 - 17:28:00
+
+	// The StatefulSet's rollout was first observed as complete just now.
+	assert.Equal(t, time.Duration(0), uptimes[makeID(Chrome, DiffCalculator)])
+
+	// One of its replicas is not yet available.
+	assert.NotContains(t, uptimes, makeID(Chrome, Ingestion))
+
+	// It does not even show up in the kubectl output.
+	assert.NotContains(t, uptimes, makeID(Chrome, BaselineServer))
+
+	// Poll again a minute later.
+	ctx = context.WithValue(ctx, now.ContextKey, fakeNow.Add(time.Minute))
+	uptimes, err = g.getRolloutUptimes(ctx, units)
+	require.NoError(t, err)
+	assert.Equal(t, 3*time.Minute, uptimes[makeID(Chrome, Frontend)])
+	assert.Equal(t, time.Minute, uptimes[makeID(Chrome, DiffCalculator)])
+}
+
+func TestK8sWorkload_RolloutStatus_NotComplete(t *testing.T) {
+	replicas := int32(3)
+	test := func(name, expectedMessage string, mutate func(w *k8sWorkload)) {
+		t.Run(name, func(t *testing.T) {
+			w := k8sWorkload{Kind: "Deployment"}
+			w.Metadata.Generation = 2
+			w.Spec.Replicas = &replicas
+			w.Status.ObservedGeneration = 2
+			w.Status.Replicas = 3
+			w.Status.UpdatedReplicas = 3
+			w.Status.AvailableReplicas = 3
+			mutate(&w)
+
+			status := w.rolloutStatus()
+			assert.False(t, status.complete)
+			assert.Equal(t, expectedMessage, status.message)
+		})
+	}
+
+	test("spec update not observed", "waiting for Deployment spec update to be observed", func(w *k8sWorkload) {
+		w.Metadata.Generation = 3
+	})
+	test("replicas not updated", "1 out of 3 new replicas have been updated", func(w *k8sWorkload) {
+		w.Status.UpdatedReplicas = 1
+	})
+	test("old replicas pending termination", "1 old replicas are pending termination", func(w *k8sWorkload) {
+		w.Status.Replicas = 4
+	})
+	test("replicas not available", "2 of 3 updated replicas are available", func(w *k8sWorkload) {
+		w.Status.AvailableReplicas = 2
+	})
+	test("progress deadline exceeded", "rollout exceeded its progress deadline", func(w *k8sWorkload) {
+		w.Status.Conditions = []k8sWorkloadCondition{{Type: "Progressing", Status: "False", Reason: "ProgressDeadlineExceeded"}}
+	})
+	test("statefulset partitioned rollout", "waiting for rolling update to finish: 3 out of 3 pods have been updated", func(w *k8sWorkload) {
+		w.Kind = "StatefulSet"
+		w.Status.ReadyReplicas = 3
+		w.Status.CurrentRevision = "a"
+		w.Status.UpdateRevision = "b"
+	})
+}
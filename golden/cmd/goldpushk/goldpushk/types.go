@@ -29,7 +29,7 @@ func (d DeployableUnitID) CanonicalName() string {
 // DeploymentOptions contains any additional information required to deploy a
 // DeployableUnit to Kubernetes.
 type DeploymentOptions struct {
-	internal bool // If true, deploy to the "skia-corp" cluster, otherwise deploy to "skia-public".
+	cluster cluster // Cluster where to deploy the DeployableUnit. If empty, defaults to "skia-public".
 }
 
 // DeployableUnit represents a Gold instance/service pair that can be deployed
@@ -51,7 +51,7 @@ func TestDeployableUnitSetAdd(t *testing.T) {
 func TestDeployableUnitSetAddWithOptions(t *testing.T) {
 
 	s := DeployableUnitSet{}
-	s.addWithOptions(Chrome, DiffCalculator, DeploymentOptions{internal: true})
+	s.addWithOptions(Chrome, DiffCalculator, DeploymentOptions{cluster: clusterSkiaCorp})
 
 	expected := DeployableUnitSet{
 		deployableUnits: []DeployableUnit{
@@ -61,7 +61,7 @@ func TestDeployableUnitSetAddWithOptions(t *testing.T) {
 					Service:  DiffCalculator,
 				},
 				DeploymentOptions: DeploymentOptions{
-					internal: true,
+					cluster: clusterSkiaCorp,
 				},
 			},
 		},
@@ -74,7 +74,7 @@ func TestDeployableUnitSetOverwriteElements(t *testing.T) {
 	s := DeployableUnitSet{}
 
 	// Add element with addWithOptions().
-	s.addWithOptions(Chrome, DiffCalculator, DeploymentOptions{internal: true})
+	s.addWithOptions(Chrome, DiffCalculator, DeploymentOptions{cluster: clusterSkiaCorp})
 	expected := DeployableUnitSet{
 		deployableUnits: []DeployableUnit{
 			{
@@ -83,7 +83,7 @@ func TestDeployableUnitSetOverwriteElements(t *testing.T) {
 					Service:  DiffCalculator,
 				},
 				DeploymentOptions: DeploymentOptions{
-					internal: true,
+					cluster: clusterSkiaCorp,
 				},
 			},
 		},
@@ -91,7 +91,7 @@ func TestDeployableUnitSetOverwriteElements(t *testing.T) {
 	require.Equal(t, expected, s)
 
 	// Overwrite with addWithOptions().
-	s.addWithOptions(Chrome, DiffCalculator, DeploymentOptions{internal: false})
+	s.addWithOptions(Chrome, DiffCalculator, DeploymentOptions{cluster: clusterSkiaPublic})
 	expected = DeployableUnitSet{
 		deployableUnits: []DeployableUnit{
 			{
@@ -100,7 +100,7 @@ func TestDeployableUnitSetOverwriteElements(t *testing.T) {
 					Service:  DiffCalculator,
 				},
 				DeploymentOptions: DeploymentOptions{
-					internal: false,
+					cluster: clusterSkiaPublic,
 				},
 			},
 		},
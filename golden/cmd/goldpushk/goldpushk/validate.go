@@ -0,0 +1,86 @@
+package goldpushk
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"go.skia.org/infra/go/exec"
+	"go.skia.org/infra/go/skerr"
+)
+
+// validateConfigFiles validates the generated ConfigMaps and deployment files of all DeployableUnits
+// against their clusters by running "kubectl apply --dry-run=server", which catches broken
+// templates (e.g. invalid fields, malformed YAML, failed admission checks) before anything is
+// applied. It does not stop at the first invalid file; instead, all failures are reported as a
+// single error.
+//
+// Validation does not modify the clusters, so it is also performed in dry-run mode.
+func (g *Goldpushk) validateConfigFiles(ctx context.Context) error {
+	fmt.Println("\nValidating generated configuration files.")
+
+	var units []DeployableUnit
+	units = append(units, g.canariedDeployableUnits...)
+	units = append(units, g.deployableUnits...)
+
+	var failures []string
+	clusters, unitsByCluster := groupByCluster(units)
+	for _, cluster := range clusters {
+		if err := g.switchClusters(ctx, cluster); err != nil {
+			return skerr.Wrap(err)
+		}
+
+		instanceConfigMapsValidated := map[Instance]bool{}
+		for _, unit := range unitsByCluster[cluster] {
+			if !instanceConfigMapsValidated[unit.Instance] {
+				instanceConfigMapsValidated[unit.Instance] = true
+				configMapName := getInstanceConfigMapName(unit.Instance)
+				if err := g.validateConfigMap(ctx, unit.Instance); err != nil {
+					failures = append(failures, fmt.Sprintf("%s (cluster %s): %s", configMapName, cluster.name, err))
+				}
+			}
+
+			path := g.getDeploymentFilePath(unit)
+			if err := g.validateManifest(ctx, []string{"-f", path}, nil); err != nil {
+				failures = append(failures, fmt.Sprintf("%s (cluster %s): %s", path, cluster.name, err))
+			}
+		}
+	}
+
+	if len(failures) > 0 {
+		sort.Strings(failures)
+		return skerr.Fmt("%d generated configuration file(s) failed validation:\n%s", len(failures), strings.Join(failures, "\n"))
+	}
+	fmt.Println("All generated configuration files are valid.")
+	return nil
+}
+
+// validateConfigMap validates the ConfigMap with the configuration files of the given instance.
+func (g *Goldpushk) validateConfigMap(ctx context.Context, instance Instance) error {
+	b, err := makeConfigMapManifest(g.getInstanceSpecificConfigDir(instance), getInstanceConfigMapName(instance))
+	if err != nil {
+		return skerr.Wrap(err)
+	}
+	return g.validateManifest(ctx, []string{"-f", "-"}, bytes.NewReader(b))
+}
+
+// validateManifest runs "kubectl apply --dry-run=server" with the given file arguments and stdin.
+// The returned error includes the output of kubectl, which explains why validation failed.
+func (g *Goldpushk) validateManifest(ctx context.Context, fileArgs []string, stdin io.Reader) error {
+	var output bytes.Buffer
+	cmd := makeExecCommand("kubectl", append([]string{"apply", "--dry-run=server"}, fileArgs...), g.verbose)
+	cmd.Stdin = stdin
+	cmd.LogStdout = false
+	cmd.LogStderr = false
+	cmd.CombinedOutput = &output
+	if err := exec.Run(ctx, cmd); err != nil {
+		if msg := strings.TrimSpace(output.String()); msg != "" {
+			return skerr.Fmt("%s", msg)
+		}
+		return skerr.Wrapf(err, "failed to run %s", cmdToDebugStr(cmd))
+	}
+	return nil
+}
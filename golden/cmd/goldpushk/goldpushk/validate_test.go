@@ -0,0 +1,79 @@
+package goldpushk
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.skia.org/infra/go/exec"
+	"go.skia.org/infra/go/testutils/unittest"
+)
+
+func TestGoldpushk_ValidateConfigFiles_Success(t *testing.T) {
+	unittest.LinuxOnlyTest(t)
+
+	corpUnit := getUnit(t, Chrome, Frontend)
+	corpUnit.cluster = clusterSkiaCorp
+
+	g := &Goldpushk{
+		canariedDeployableUnits: []DeployableUnit{getUnit(t, Skia, DiffCalculator)},
+		deployableUnits:         []DeployableUnit{getUnit(t, Skia, Frontend), corpUnit},
+		goldSrcDir:              createFakeGoldSrcDir(t, Skia, Chrome),
+	}
+	addFakeK8sConfigRepoCheckout(g)
+	_, restoreStdout := hideStdout(t)
+	defer restoreStdout()
+
+	commandCollector := exec.CommandCollector{}
+	ctx := exec.NewContext(context.Background(), commandCollector.Run)
+
+	require.NoError(t, g.validateConfigFiles(ctx))
+
+	assertCommandsMatch(t, &commandCollector, []string{
+		"gcloud container clusters get-credentials skia-public --zone us-central1-a --project skia-public",
+		"kubectl apply --dry-run=server -f -",
+		"kubectl apply --dry-run=server -f /path/to/k8s-config/skia-public/gold-skia-diffcalculator.yaml",
+		"kubectl apply --dry-run=server -f /path/to/k8s-config/skia-public/gold-skia-frontend.yaml",
+		"gcloud container clusters get-credentials skia-corp --zone us-central1-a --project google.com:skia-corp",
+		"kubectl apply --dry-run=server -f -",
+		"kubectl apply --dry-run=server -f /path/to/k8s-config/skia-corp/gold-chrome-frontend.yaml",
+	})
+}
+
+func TestGoldpushk_ValidateConfigFiles_InvalidFiles_ReportsAllFailures(t *testing.T) {
+	unittest.LinuxOnlyTest(t)
+
+	g := &Goldpushk{
+		canariedDeployableUnits: []DeployableUnit{getUnit(t, Skia, DiffCalculator)},
+		deployableUnits:         []DeployableUnit{getUnit(t, Skia, Frontend), getUnit(t, Skia, Ingestion)},
+		goldSrcDir:              createFakeGoldSrcDir(t, Skia),
+	}
+	addFakeK8sConfigRepoCheckout(g)
+	_, restoreStdout := hideStdout(t)
+	defer restoreStdout()
+
+	commandCollector := exec.CommandCollector{}
+	commandCollector.SetDelegateRun(func(_ context.Context, cmd *exec.Command) error {
+		lastArg := cmd.Args[len(cmd.Args)-1]
+		if strings.HasSuffix(lastArg, "gold-skia-diffcalculator.yaml") || strings.HasSuffix(lastArg, "gold-skia-ingestion.yaml") {
+			_, err := cmd.CombinedOutput.Write([]byte(`error: error validating "` + lastArg + `": unknown field "replica"` + "\n"))
+			require.NoError(t, err)
+			return errors.New("exit status 1")
+		}
+		return nil
+	})
+	ctx := exec.NewContext(context.Background(), commandCollector.Run)
+
+	err := g.validateConfigFiles(ctx)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "2 generated configuration file(s) failed validation")
+	assert.Contains(t, err.Error(), `/path/to/k8s-config/skia-public/gold-skia-diffcalculator.yaml (cluster skia-public): error: error validating "/path/to/k8s-config/skia-public/gold-skia-diffcalculator.yaml": unknown field "replica"`)
+	assert.Contains(t, err.Error(), `/path/to/k8s-config/skia-public/gold-skia-ingestion.yaml (cluster skia-public): error: error validating "/path/to/k8s-config/skia-public/gold-skia-ingestion.yaml": unknown field "replica"`)
+
+	// All files are validated even though some of them are invalid.
+	assert.Len(t, commandCollector.Commands(), 5)
+}
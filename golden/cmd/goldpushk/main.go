@@ -21,8 +21,14 @@
 //   Deployment of all instances of a given service, designating one of them as the canary:
 //     $ goldpushk --service diffcalculator --instance all --canary skia:diffcalculator
 //
+//   Interactive selection of the services to deploy among those of instances matching a glob:
+//     $ goldpushk --interactive --instance-glob "chrome*"
+//
 //   Print out all Gold instances and services goldpushk is able to manage:
 //     $ goldpushk --list
+//
+//   Use a JSON5 or YAML file instead of the built-in set of Gold instances and services:
+//     $ goldpushk --units-config /path/to/units.json5 --service all --instance my-new-instance
 
 package main
 
@@ -34,6 +40,7 @@ import (
 	"sort"
 	"strings"
 	"text/tabwriter"
+	"time"
 
 	"github.com/spf13/cobra"
 	"go.skia.org/infra/go/skerr"
@@ -64,10 +71,28 @@ var (
 
 	// Optional flags.
 	flagList                       bool
+	flagInteractive                bool
+	flagInstanceGlob               string
+	flagServiceGlob                string
+	flagUnitsConfig                string
 	flagDryRun                     bool
 	flagNoCommit                   bool
 	flagMinUptimeSeconds           int
 	flagUptimePollFrequencySeconds int
+	flagRolloutStatus              bool
+	flagParallelism                int
+	flagSoakSeconds                int
+	flagPrometheusURL              string
+	flagCanaryGates                string
+	flagJournal                    string
+	flagNotifications              string
+	flagAuditProject               string
+	flagImagePins                  []string
+	flagSameAsProd                 bool
+	flagLock                       string
+	flagLockTTLMinutes             int
+	flagForceBreakLock             bool
+	flagResume                     bool
 
 	// Flags for debugging.
 	flagLogToStdErr bool
@@ -93,13 +118,31 @@ func main() {
 
 	rootCmd.Flags().SortFlags = false
 	rootCmd.Flags().BoolVar(&flagList, "list", false, "List known Gold instances and services (tip: try combining this flag with --testing).")
+	rootCmd.Flags().StringVar(&flagUnitsConfig, "units-config", "", "Path to a JSON5 or YAML file describing the Gold instances and services to manage. If not set, the built-in set of production instances and services is used.")
 	rootCmd.Flags().StringSliceVarP(&flagInstances, "instances", "i", []string{}, "[REQUIRED] Comma-delimited list of Gold instances to target (e.g. \"skia,flutter\"), or \""+all+"\" to target all instances.")
 	rootCmd.Flags().StringSliceVarP(&flagServices, "services", "s", []string{}, "[REQUIRED] Comma-delimited list of services to target (e.g. \"frontend,diffcalculator\"), or \""+all+"\" to target all services.")
+	rootCmd.Flags().BoolVar(&flagInteractive, "interactive", false, "Select the services to deploy and canary interactively instead of with --instances, --services and --canaries. The equivalent non-interactive command is printed out for reproducibility.")
+	rootCmd.Flags().StringVar(&flagInstanceGlob, "instance-glob", "*", "With --interactive, only list instances matching this glob (e.g. \"chrome*\").")
+	rootCmd.Flags().StringVar(&flagServiceGlob, "service-glob", "*", "With --interactive, only list services matching this glob (e.g. \"*server\").")
 	rootCmd.Flags().StringSliceVarP(&flagCanaries, "canaries", "c", []string{}, "Comma-delimited subset of Gold services to use as canaries, written as instance:service pairs (e.g. \"skia:diffcalculator,flutter:frontend\")")
+	rootCmd.Flags().StringSliceVar(&flagImagePins, "image", []string{}, "Comma-delimited list of container images to deploy instead of those in the templates, written as instance:service=image pairs (e.g. \"skia:frontend=gcr.io/skia-public/gold-frontend:<tag>\"). The image may be just a tag, in which case the repository in gold-common.json5 is used.")
+	rootCmd.Flags().BoolVar(&flagSameAsProd, "same-as-prod", false, "Regenerate the deployment files with the container images currently deployed to production, so that configuration-only changes do not update any images. Images given with --image take precedence.")
 	rootCmd.Flags().BoolVar(&flagDryRun, "dryrun", false, "Do everything except applying the new configuration to Kubernetes and committing changes to Git.")
 	rootCmd.Flags().BoolVar(&flagNoCommit, "no-commit", false, "Do not commit configuration changes to the k8s-config repository.")
 	rootCmd.Flags().IntVar(&flagMinUptimeSeconds, "min-uptime", 30, "Minimum uptime in seconds required for all services before exiting the monitoring step.")
 	rootCmd.Flags().IntVar(&flagUptimePollFrequencySeconds, "poll-freq", 3, "How often to poll Kubernetes for service uptimes, in seconds.")
+	rootCmd.Flags().BoolVar(&flagRolloutStatus, "rollout-status", false, "Determine whether services are up from the rollout status of their Deployments and StatefulSets (as in \"kubectl rollout status\") instead of from container start times. Recommended for services with multi-container pods.")
+	rootCmd.Flags().IntVar(&flagSoakSeconds, "soak", 0, "After the monitoring step, watch services for crash loops for this many seconds, and roll back any crash-looping services to their previous deployment files. Set to 0 to disable.")
+	rootCmd.Flags().StringVar(&flagPrometheusURL, "prometheus-url", "", "URL of a Prometheus server (e.g. \"http://localhost:9090\"). If set, canaries must pass a set of metric-based gates before the remaining services are pushed.")
+	rootCmd.Flags().StringVar(&flagCanaryGates, "canary-gates", "", "Path to a JSON5 file with the canary gates to check if --prometheus-url is set. If not set, a default set of liveness, error rate and latency gates is used.")
+	rootCmd.Flags().StringVar(&flagJournal, "journal", "", "Path to a JSON file where goldpushk will record the progress of this run (steps completed, services pushed, commits created, confirmations given).")
+	rootCmd.Flags().BoolVar(&flagResume, "resume", false, "Resume an interrupted run from the file given with --journal, skipping any steps that it completed.")
+	rootCmd.Flags().StringVar(&flagNotifications, "notifications", "", "Path to a JSON5 file configuring notifications (chat rooms, emails, Pub/Sub topics) to send when the deployment finishes. See goldpushk.NotificationsConfig.")
+	rootCmd.Flags().StringVar(&flagAuditProject, "audit-project", "", "GCP project whose Cloud Datastore records every deployment (user, services, clusters, commits, timestamps and outcome) in the \"goldpushk\" namespace. Deployments are not recorded if not set.")
+	rootCmd.Flags().StringVar(&flagLock, "lock", "", "GCS location of a lock file (e.g. \"gs://bucket/goldpushk.lock\") held for the duration of the run, which prevents concurrent deployments. Locking is disabled if not set.")
+	rootCmd.Flags().IntVar(&flagLockTTLMinutes, "lock-ttl", 120, "Minutes after which a lock held by a run that did not release it is considered expired and may be broken.")
+	rootCmd.Flags().BoolVar(&flagForceBreakLock, "force-break-lock", false, "Break the lock given with --lock even if it is held by another run and has not expired.")
+	rootCmd.Flags().IntVar(&flagParallelism, "parallelism", 1, "Maximum number of services to push concurrently to each cluster. Services are pushed sequentially if set to 1.")
 	rootCmd.Flags().BoolVar(&flagLogToStdErr, "logtostderr", false, "Log debug information to stderr. No logs will be produced if this flag is not set.")
 	rootCmd.Flags().BoolVar(&flagVerbose, "verbose", false, "Verbose logs. This will log the commands executed and their command-line parameters.")
 
@@ -113,6 +156,14 @@ func main() {
 func run(cmd *cobra.Command) {
 	// Get set of deployable units. Used as the source of truth across goldpushk.
 	deployableUnitSet := goldpushk.ProductionDeployableUnits()
+	if flagUnitsConfig != "" {
+		var err error
+		deployableUnitSet, err = goldpushk.LoadDeployableUnitSet(flagUnitsConfig)
+		if err != nil {
+			fmt.Printf("Error: %s.\n", err)
+			os.Exit(1)
+		}
+	}
 
 	// If --list is passed, print known services and exit. This takes into account flag --testing.
 	if flagList {
@@ -122,6 +173,25 @@ func run(cmd *cobra.Command) {
 		return
 	}
 
+	// If --interactive is passed, let the user select the services to deploy, and proceed as if the
+	// equivalent --instances, --services and --canaries flags were given.
+	if flagInteractive {
+		if len(flagInstances) > 0 || len(flagServices) > 0 || len(flagCanaries) > 0 {
+			fmt.Println("Error: flag --interactive cannot be combined with flags --instances, --services or --canaries.")
+			os.Exit(1)
+		}
+		selection, err := selectDeployableUnitsInteractively(os.Stdin, os.Stdout, deployableUnitSet, flagInstanceGlob, flagServiceGlob)
+		if err != nil {
+			fmt.Printf("Error: %s.\n", err)
+			os.Exit(1)
+		}
+		if selection == nil {
+			return
+		}
+		fmt.Printf("\nEquivalent non-interactive command:\n  %s\n\n", equivalentCommand(cmd, selection))
+		flagInstances, flagServices, flagCanaries = selection.instances, selection.services, selection.canaries
+	}
+
 	// If --list was not provided, validate presence of flags --services and --instances.
 	if len(flagInstances) == 0 {
 		fmt.Println("Error: flag \"instances\" is required.")
@@ -154,9 +224,70 @@ func run(cmd *cobra.Command) {
 
 	// Build goldpushk instance.
 	gpk := goldpushk.New(deployableUnits, canariedDeployableUnits, skiaInfraRoot, flagDryRun, flagNoCommit, flagMinUptimeSeconds, flagUptimePollFrequencySeconds, k8sConfigRepoUrl, flagVerbose)
+	gpk.SetParallelism(flagParallelism)
+	imagePins, err := goldpushk.ParseImagePins(deployableUnitSet, flagImagePins)
+	if err != nil {
+		fmt.Printf("Error: %s.\n", err)
+		os.Exit(1)
+	}
+	gpk.SetImagePins(imagePins)
+	gpk.SetSameAsProd(flagSameAsProd)
+	gpk.SetUseRolloutStatus(flagRolloutStatus)
+	gpk.SetSoakPeriod(time.Duration(flagSoakSeconds) * time.Second)
+	if flagPrometheusURL != "" {
+		canaryGates := goldpushk.DefaultCanaryGates()
+		if flagCanaryGates != "" {
+			canaryGates, err = goldpushk.LoadCanaryGates(flagCanaryGates)
+			if err != nil {
+				fmt.Printf("Error: %s.\n", err)
+				os.Exit(1)
+			}
+		}
+		gpk.SetCanaryGates(flagPrometheusURL, canaryGates)
+	}
+
+	if flagJournal != "" {
+		if err := gpk.SetJournal(flagJournal, flagResume); err != nil {
+			fmt.Printf("Error: %s.\n", err)
+			os.Exit(1)
+		}
+	} else if flagResume {
+		fmt.Println("Error: flag --resume requires flag --journal.")
+		os.Exit(1)
+	}
+
+	ctx := context.Background()
+	if flagNotifications != "" {
+		notificationsConfig, err := goldpushk.LoadNotificationsConfig(flagNotifications)
+		if err != nil {
+			fmt.Printf("Error: %s.\n", err)
+			os.Exit(1)
+		}
+		if err := gpk.SetNotifications(ctx, notificationsConfig); err != nil {
+			fmt.Printf("Error: %s.\n", err)
+			os.Exit(1)
+		}
+	}
+
+	if flagAuditProject != "" {
+		if err := gpk.SetAuditLog(flagAuditProject); err != nil {
+			fmt.Printf("Error: %s.\n", err)
+			os.Exit(1)
+		}
+	}
+
+	if flagLock != "" {
+		if err := gpk.SetLock(ctx, flagLock, time.Duration(flagLockTTLMinutes)*time.Minute, flagForceBreakLock); err != nil {
+			fmt.Printf("Error: %s.\n", err)
+			os.Exit(1)
+		}
+	} else if flagForceBreakLock {
+		fmt.Println("Error: flag --force-break-lock requires flag --lock.")
+		os.Exit(1)
+	}
 
 	// Run goldpushk.
-	if err = gpk.Run(context.Background()); err != nil {
+	if err = gpk.Run(ctx); err != nil {
 		fmt.Printf("Error: %s.\n", err)
 		os.Exit(1)
 	}
@@ -168,6 +299,9 @@ func listKnownServices(deployableUnitSet goldpushk.DeployableUnitSet) error {
 	if flagTesting {
 		mode = "testing"
 	}
+	if flagUnitsConfig != "" {
+		mode = flagUnitsConfig
+	}
 	fmt.Printf("Known Gold instances and services (%s):\n", mode)
 
 	// Print out table header.
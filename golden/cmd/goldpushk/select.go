@@ -0,0 +1,270 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/golden/cmd/goldpushk/goldpushk"
+)
+
+// selectionHelp explains the commands accepted by the interactive selection mode.
+const selectionHelp = `Commands:
+  <numbers>    Toggle the given services, e.g. "1 3 5-7".
+  c <numbers>  Toggle canarying for the given services. Canaried services are also selected.
+  a            Select all services, or deselect them all if they are all selected.
+  d            Done; deploy the selected services.
+  q            Quit without deploying anything.`
+
+// unitSelection is the outcome of the interactive selection mode, expressed as the values of the
+// --instances, --services and --canaries flags.
+type unitSelection struct {
+	instances []string
+	services  []string
+	canaries  []string
+}
+
+// selectableUnit is a DeployableUnit listed by the interactive selection mode.
+type selectableUnit struct {
+	id       goldpushk.DeployableUnitID
+	selected bool
+	canary   bool
+}
+
+// selectDeployableUnitsInteractively lists the DeployableUnits in the given set whose instance and
+// service names match the given globs (see path.Match), and lets the user choose which ones to
+// deploy and which ones to canary by reading commands from r. It returns nil if the user quits
+// without selecting anything.
+//
+// Because flags --instances and --services target every combination of the given instances and
+// services, the user is asked to adjust any selection that cannot be expressed with those flags.
+func selectDeployableUnitsInteractively(r io.Reader, w io.Writer, deployableUnitSet goldpushk.DeployableUnitSet, instanceGlob, serviceGlob string) (*unitSelection, error) {
+	var units []*selectableUnit
+	for _, instance := range deployableUnitSet.KnownInstances() {
+		if ok, err := path.Match(instanceGlob, string(instance)); err != nil {
+			return nil, skerr.Wrapf(err, "invalid instance glob %q", instanceGlob)
+		} else if !ok {
+			continue
+		}
+		for _, service := range deployableUnitSet.KnownServices() {
+			if ok, err := path.Match(serviceGlob, string(service)); err != nil {
+				return nil, skerr.Wrapf(err, "invalid service glob %q", serviceGlob)
+			} else if !ok {
+				continue
+			}
+			id := goldpushk.DeployableUnitID{Instance: instance, Service: service}
+			if _, ok := deployableUnitSet.Get(id); ok {
+				units = append(units, &selectableUnit{id: id})
+			}
+		}
+	}
+	if len(units) == 0 {
+		return nil, skerr.Fmt("no known Gold services match instance glob %q and service glob %q", instanceGlob, serviceGlob)
+	}
+
+	_, _ = fmt.Fprintln(w, selectionHelp)
+	for {
+		printSelectableUnits(w, units)
+		_, _ = fmt.Fprint(w, "> ")
+		line, err := readLine(r)
+		if err != nil {
+			return nil, skerr.Wrapf(err, "unable to read from standard input")
+		}
+		line = strings.TrimSpace(line)
+
+		switch {
+		case line == "q":
+			return nil, nil
+		case line == "d":
+			selection, err := makeUnitSelection(deployableUnitSet, units)
+			if err != nil {
+				_, _ = fmt.Fprintf(w, "Error: %s.\n", err)
+				continue
+			}
+			return selection, nil
+		case line == "a":
+			allSelected := true
+			for _, unit := range units {
+				allSelected = allSelected && unit.selected
+			}
+			for _, unit := range units {
+				unit.selected = !allSelected
+				unit.canary = unit.canary && unit.selected
+			}
+		case strings.HasPrefix(line, "c "):
+			indices, err := parseSelectionIndices(strings.TrimPrefix(line, "c "), len(units))
+			if err != nil {
+				_, _ = fmt.Fprintf(w, "Error: %s.\n", err)
+				continue
+			}
+			for _, i := range indices {
+				units[i].canary = !units[i].canary
+				units[i].selected = units[i].selected || units[i].canary
+			}
+		default:
+			indices, err := parseSelectionIndices(line, len(units))
+			if err != nil {
+				_, _ = fmt.Fprintf(w, "Error: %s.\n", err)
+				continue
+			}
+			for _, i := range indices {
+				units[i].selected = !units[i].selected
+				units[i].canary = units[i].canary && units[i].selected
+			}
+		}
+	}
+}
+
+// printSelectableUnits prints out the given units, one per line, with a checkbox indicating whether
+// they are selected.
+func printSelectableUnits(w io.Writer, units []*selectableUnit) {
+	_, _ = fmt.Fprintln(w)
+	for i, unit := range units {
+		checkbox := "[ ]"
+		if unit.selected {
+			checkbox = "[x]"
+		}
+		suffix := ""
+		if unit.canary {
+			suffix = " (canary)"
+		}
+		_, _ = fmt.Fprintf(w, "%3d %s %s%s\n", i+1, checkbox, unit.id.CanonicalName(), suffix)
+	}
+}
+
+// parseSelectionIndices parses a whitespace-separated list of 1-based numbers and ranges (e.g.
+// "1 3 5-7") and returns the corresponding 0-based indices.
+func parseSelectionIndices(s string, numUnits int) ([]int, error) {
+	var indices []int
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		return nil, skerr.Fmt("no services given")
+	}
+	for _, field := range fields {
+		startStr, endStr, isRange := strings.Cut(field, "-")
+		if !isRange {
+			endStr = startStr
+		}
+		start, err := strconv.Atoi(startStr)
+		if err != nil {
+			return nil, skerr.Fmt("invalid service number %q", field)
+		}
+		end, err := strconv.Atoi(endStr)
+		if err != nil {
+			return nil, skerr.Fmt("invalid service number %q", field)
+		}
+		if start < 1 || end > numUnits || start > end {
+			return nil, skerr.Fmt("service number %q out of range 1-%d", field, numUnits)
+		}
+		for i := start; i <= end; i++ {
+			indices = append(indices, i-1)
+		}
+	}
+	return indices, nil
+}
+
+// makeUnitSelection returns the values of the --instances, --services and --canaries flags that
+// target exactly the selected units. It returns an error if there are no such values.
+func makeUnitSelection(deployableUnitSet goldpushk.DeployableUnitSet, units []*selectableUnit) (*unitSelection, error) {
+	instances := map[string]bool{}
+	services := map[string]bool{}
+	selected := map[goldpushk.DeployableUnitID]bool{}
+	selection := &unitSelection{}
+	for _, unit := range units {
+		if !unit.selected {
+			continue
+		}
+		instances[string(unit.id.Instance)] = true
+		services[string(unit.id.Service)] = true
+		selected[unit.id] = true
+		if unit.canary {
+			selection.canaries = append(selection.canaries, fmt.Sprintf("%s:%s", unit.id.Instance, unit.id.Service))
+		}
+	}
+	if len(selected) == 0 {
+		return nil, skerr.Fmt("no services selected")
+	}
+	if len(selection.canaries) == len(selected) {
+		return nil, skerr.Fmt("all selected services are marked for canarying")
+	}
+	selection.instances = sortedSetKeys(instances)
+	selection.services = sortedSetKeys(services)
+
+	// Flags --instances and --services would also target any unselected combinations.
+	var unselected []string
+	for _, instance := range selection.instances {
+		for _, service := range selection.services {
+			id := goldpushk.DeployableUnitID{Instance: goldpushk.Instance(instance), Service: goldpushk.Service(service)}
+			if _, ok := deployableUnitSet.Get(id); ok && !selected[id] {
+				unselected = append(unselected, id.CanonicalName())
+			}
+		}
+	}
+	if len(unselected) > 0 {
+		return nil, skerr.Fmt("the selection cannot be deployed with a single goldpushk command because it would also deploy %s; please select those services as well, or deploy the selected services in several runs", strings.Join(unselected, ", "))
+	}
+	return selection, nil
+}
+
+// equivalentCommand returns a goldpushk command that deploys the given selection
+// non-interactively, with the same flags as the given command other than those of the interactive
+// selection mode.
+func equivalentCommand(cmd *cobra.Command, selection *unitSelection) string {
+	args := []string{"goldpushk"}
+	cmd.Flags().Visit(func(f *pflag.Flag) {
+		switch f.Name {
+		case "interactive", "instance-glob", "service-glob", "instances", "services", "canaries":
+			return
+		}
+		if f.Value.Type() == "bool" && f.Value.String() == "true" {
+			args = append(args, "--"+f.Name)
+		} else if sv, ok := f.Value.(pflag.SliceValue); ok {
+			args = append(args, fmt.Sprintf("--%s=%s", f.Name, strings.Join(sv.GetSlice(), ",")))
+		} else {
+			args = append(args, fmt.Sprintf("--%s=%s", f.Name, f.Value.String()))
+		}
+	})
+	args = append(args, "--instances="+strings.Join(selection.instances, ","), "--services="+strings.Join(selection.services, ","))
+	if len(selection.canaries) > 0 {
+		args = append(args, "--canaries="+strings.Join(selection.canaries, ","))
+	}
+	return strings.Join(args, " ")
+}
+
+// readLine reads a single line from r, without the trailing newline. It reads one byte at a time
+// so as to not consume any input past the end of the line, which is read later by goldpushk's
+// confirmation prompts.
+func readLine(r io.Reader) (string, error) {
+	var line []byte
+	b := make([]byte, 1)
+	for {
+		n, err := r.Read(b)
+		if n == 1 {
+			if b[0] == '\n' {
+				return string(line), nil
+			}
+			line = append(line, b[0])
+		}
+		if err == io.EOF && len(line) > 0 {
+			return string(line), nil
+		} else if err != nil {
+			return "", err
+		}
+	}
+}
+
+// sortedSetKeys returns the keys of the given set in ascending order.
+func sortedSetKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
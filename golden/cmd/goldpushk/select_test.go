@@ -0,0 +1,101 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.skia.org/infra/golden/cmd/goldpushk/goldpushk"
+)
+
+func TestSelectDeployableUnitsInteractively_SelectAndCanary_Success(t *testing.T) {
+	// Lists the flutter and flutter-engine baselineserver and frontend services, in this order.
+	input := strings.NewReader("1-4\nc 2\nd\n")
+	var output bytes.Buffer
+
+	selection, err := selectDeployableUnitsInteractively(input, &output, goldpushk.ProductionDeployableUnits(), "flutter*", "[bf]*")
+	require.NoError(t, err)
+	assert.Equal(t, &unitSelection{
+		instances: []string{"flutter", "flutter-engine"},
+		services:  []string{"baselineserver", "frontend"},
+		canaries:  []string{"flutter:frontend"},
+	}, selection)
+
+	assert.Contains(t, output.String(), "  1 [ ] gold-flutter-baselineserver\n  2 [ ] gold-flutter-frontend\n  3 [ ] gold-flutter-engine-baselineserver\n  4 [ ] gold-flutter-engine-frontend\n")
+	assert.Contains(t, output.String(), "  1 [x] gold-flutter-baselineserver\n  2 [x] gold-flutter-frontend (canary)\n")
+}
+
+func TestSelectDeployableUnitsInteractively_InvalidInputAndSelection_AsksAgain(t *testing.T) {
+	input := strings.NewReader("7\nfoo\nd\n1 2\nd\n")
+	var output bytes.Buffer
+
+	selection, err := selectDeployableUnitsInteractively(input, &output, goldpushk.ProductionDeployableUnits(), "chrome*", "frontend")
+	require.NoError(t, err)
+	assert.Equal(t, &unitSelection{
+		instances: []string{"chrome", "chrome-public"},
+		services:  []string{"frontend"},
+	}, selection)
+
+	assert.Contains(t, output.String(), `Error: service number "7" out of range 1-2.`)
+	assert.Contains(t, output.String(), `Error: invalid service number "foo".`)
+	assert.Contains(t, output.String(), "Error: no services selected.")
+}
+
+func TestSelectDeployableUnitsInteractively_Quit_ReturnsNil(t *testing.T) {
+	selection, err := selectDeployableUnitsInteractively(strings.NewReader("a\nq\n"), &bytes.Buffer{}, goldpushk.ProductionDeployableUnits(), "*", "*")
+	require.NoError(t, err)
+	assert.Nil(t, selection)
+}
+
+func TestSelectDeployableUnitsInteractively_NoMatches_Error(t *testing.T) {
+	_, err := selectDeployableUnitsInteractively(strings.NewReader(""), &bytes.Buffer{}, goldpushk.ProductionDeployableUnits(), "foo*", "*")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `no known Gold services match instance glob "foo*" and service glob "*"`)
+}
+
+func TestMakeUnitSelection_NotExpressibleWithFlags_Error(t *testing.T) {
+	s := goldpushk.ProductionDeployableUnits()
+	units := []*selectableUnit{
+		{id: makeID(goldpushk.Chrome, goldpushk.Frontend), selected: true},
+		{id: makeID(goldpushk.Skia, goldpushk.Ingestion), selected: true},
+	}
+
+	_, err := makeUnitSelection(s, units)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "would also deploy gold-chrome-ingestion, gold-skia-frontend")
+}
+
+func TestMakeUnitSelection_AllCanaries_Error(t *testing.T) {
+	units := []*selectableUnit{
+		{id: makeID(goldpushk.Chrome, goldpushk.Frontend), selected: true, canary: true},
+	}
+
+	_, err := makeUnitSelection(goldpushk.ProductionDeployableUnits(), units)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "all selected services are marked for canarying")
+}
+
+func TestEquivalentCommand_IncludesOtherFlags(t *testing.T) {
+	var dryRun bool
+	var soak int
+	var images, instances []string
+	var interactive bool
+	cmd := &cobra.Command{}
+	cmd.Flags().BoolVar(&dryRun, "dryrun", false, "")
+	cmd.Flags().IntVar(&soak, "soak", 0, "")
+	cmd.Flags().StringSliceVar(&images, "image", nil, "")
+	cmd.Flags().StringSliceVar(&instances, "instances", nil, "")
+	cmd.Flags().BoolVar(&interactive, "interactive", false, "")
+	require.NoError(t, cmd.Flags().Parse([]string{"--interactive", "--dryrun", "--soak", "60", "--image", "skia:frontend=v2,chrome:frontend=v3"}))
+
+	assert.Equal(t,
+		"goldpushk --dryrun --image=skia:frontend=v2,chrome:frontend=v3 --soak=60 --instances=chrome,skia --services=frontend --canaries=skia:frontend",
+		equivalentCommand(cmd, &unitSelection{
+			instances: []string{"chrome", "skia"},
+			services:  []string{"frontend"},
+			canaries:  []string{"skia:frontend"},
+		}))
+}
@@ -277,7 +277,7 @@ def nodejs_binary(
         visibility = visibility,
     )
 
-def sk_element_puppeteer_test(name, src, sk_demo_page_server, deps = []):
+def sk_element_puppeteer_test(name, src, sk_demo_page_server, deps = [], data = []):
     """Defines a Puppeteer test for the demo page served by an sk_demo_page_server.
 
     Puppeteer tests should save any screenshots inside the $TEST_UNDECLARED_OUTPUTS_DIR directory.
@@ -312,6 +312,7 @@ def sk_element_puppeteer_test(name, src, sk_demo_page_server, deps = []):
       src: A single TypeScript source file.
       sk_demo_page_server: Label for the sk_demo_page_server target.
       deps: Any ts_library dependencies.
+      data: Any static assets needed by the test at runtime, such as JSON or PNG fixtures.
     """
 
     if not src.endswith("_puppeteer_test.ts"):
@@ -341,6 +342,7 @@ def sk_element_puppeteer_test(name, src, sk_demo_page_server, deps = []):
             src_lib = name + "_ts_lib",
             tags = ["manual"],  # Exclude it from wildcards, e.g. "bazel test //...".
             deps = deps,
+            data = data,
             wait_for_debugger = debug,
             env = {"PUPPETEER_TEST_SHOW_BROWSER": "true"} if headful else {},
             _internal_skip_naming_convention_enforcement = True,
@@ -8,6 +8,7 @@ def karma_test(
         name,
         src,
         deps = [],
+        data = [],
         karma_config_file = "//infra-sk/karma_test:karma_config",
         static_karma_files = []):
     """Runs TypeScript unit tests in a browser with Karma, using Mocha as the test runner.
@@ -28,6 +29,8 @@ def karma_test(
       name: The name of the target.
       src: A single TypeScript source file.
       deps: Any ts_library dependencies.
+      data: Any static assets imported by src, such as JSON or PNG fixtures. These are made
+         available to the TypeScript compiler, the JS bundler and the test at runtime.
       karma_config_file: A string that refers to the karma.conf.js file which should be used to run
          the test. If omitted, a sensible default is used.
       static_karma_files: A list of labels for additional files that should be made available to
@@ -47,11 +50,13 @@ def karma_test(
         name = name + "_lib",
         srcs = [src],
         deps = deps,
+        data = data,
     )
 
     esbuild_dev_bundle(
         name = name + "_bundle",
         entry_point = src,
+        srcs = data,
         deps = [name + "_lib"],
         output = name + "_bundle.js",
     )
@@ -83,7 +88,7 @@ def karma_test(
             "//:node_modules/karma-chai-dom",
             "//:node_modules/karma-spec-reporter",
             "//:node_modules/mocha",
-        ] + browser + static_karma_files,
+        ] + browser + static_karma_files + data,
         no_copy_to_bin = browser,
         args = [
             "start",
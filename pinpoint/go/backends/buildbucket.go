@@ -95,6 +95,9 @@ type BuildbucketClient interface {
 	// GetBuildStatus returns the build status given the ID.
 	GetBuildStatus(ctx context.Context, buildID int64) (bpb.Status, error)
 
+	// GetBuildDetails returns the status, summary and steps of the build given the ID.
+	GetBuildDetails(ctx context.Context, buildID int64) (*bpb.Build, error)
+
 	// GetCASReference returns a CAS reference to the output artifacts of a successful build.
 	GetCASReference(ctx context.Context, buildID int64, target string) (*swarmingpb.SwarmingRpcsCASReference, error)
 
@@ -289,6 +292,28 @@ func (b *buildbucketClient) GetBuildStatus(ctx context.Context, buildID int64) (
 	return build.Status, nil
 }
 
+// createBuildDetailsRequest creates a GetBuildRequest that focuses on why a build ended.
+func (b *buildbucketClient) createBuildDetailsRequest(buildID int64) *bpb.GetBuildRequest {
+	return &bpb.GetBuildRequest{
+		Id: buildID,
+		Mask: &bpb.BuildMask{
+			Fields: &fieldmaskpb.FieldMask{
+				Paths: []string{"id", "status", "status_details", "summary_markdown", "steps"},
+			},
+		},
+	}
+}
+
+// GetBuildDetails fetches the status, summary and steps of a given build.
+func (b *buildbucketClient) GetBuildDetails(ctx context.Context, buildID int64) (*bpb.Build, error) {
+	req := b.createBuildDetailsRequest(buildID)
+	build, err := b.client.GetBuild(ctx, req)
+	if err != nil {
+		return nil, skerr.Wrapf(err, "Failed to fetch details of build %d", buildID)
+	}
+	return build, nil
+}
+
 // createCASReferenceRequest creates a GetBuildRequest that focuses on just the output properties.
 func (b *buildbucketClient) createCASReferenceRequest(buildID int64) *bpb.GetBuildRequest {
 	return &bpb.GetBuildRequest{
@@ -382,6 +382,52 @@ func TestGetBuildStatus(t *testing.T) {
 	})
 }
 
+func TestGetBuildDetails(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	buildID := int64(12345)
+
+	Convey(`OK`, t, func() {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+
+		mbc := bpb.NewMockBuildsClient(ctl)
+		c := NewBuildbucketClient(mbc)
+
+		req := c.createBuildDetailsRequest(buildID)
+		resp := &bpb.Build{
+			Id:              buildID,
+			Status:          bpb.Status_FAILURE,
+			SummaryMarkdown: "Step compile failed",
+			Steps: []*bpb.Step{
+				{Name: "compile", Status: bpb.Status_FAILURE},
+			},
+		}
+		mbc.EXPECT().GetBuild(ctx, req).Return(resp, nil)
+
+		build, err := c.GetBuildDetails(ctx, buildID)
+		So(err, ShouldBeNil)
+		So(build, ShouldResembleProto, resp)
+	})
+
+	Convey(`Err`, t, func() {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+
+		mbc := bpb.NewMockBuildsClient(ctl)
+		c := NewBuildbucketClient(mbc)
+
+		req := c.createBuildDetailsRequest(buildID)
+		mbc.EXPECT().GetBuild(ctx, req).Return(nil, appstatus.BadRequest(errors.New("random error")))
+
+		build, err := c.GetBuildDetails(ctx, buildID)
+		So(err, ShouldErrLike, "random error")
+		So(build, ShouldBeNil)
+	})
+}
+
 func createCASResponse(buildID int64, status bpb.Status, target, hash string) *bpb.Build {
 	return &bpb.Build{
 		Id:     buildID,
@@ -65,6 +65,36 @@ func (_m *BuildbucketClient) GetBuildFromWaterfall(ctx context.Context, builderN
 	return r0, r1
 }
 
+// GetBuildDetails provides a mock function with given fields: ctx, buildID
+func (_m *BuildbucketClient) GetBuildDetails(ctx context.Context, buildID int64) (*buildbucketpb.Build, error) {
+	ret := _m.Called(ctx, buildID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBuildDetails")
+	}
+
+	var r0 *buildbucketpb.Build
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (*buildbucketpb.Build, error)); ok {
+		return rf(ctx, buildID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *buildbucketpb.Build); ok {
+		r0 = rf(ctx, buildID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*buildbucketpb.Build)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, buildID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetBuildStatus provides a mock function with given fields: ctx, buildID
 func (_m *BuildbucketClient) GetBuildStatus(ctx context.Context, buildID int64) (buildbucketpb.Status, error) {
 	ret := _m.Called(ctx, buildID)
@@ -2,12 +2,14 @@
 // commit and a device target.
 //
 // build_chrome also supports gerrit patches and non-chromium
-// commits.
+// commits. Targets other than Chrome, such as V8 or ANGLE, are
+// built by specifying their builder in BuildParams.
 package build_chrome
 
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -23,15 +25,59 @@ import (
 	swarmingV1 "go.chromium.org/luci/common/api/swarming/swarming/v1"
 )
 
-// BuildChromeClient is a buildbucket client to build Chrome.
-type BuildChromeClient interface {
+// BuildParams defines the parameters of a build.
+type BuildParams struct {
+	// PinpointJobID is the Job ID to associate with the build.
+	PinpointJobID string
+	// Commit is the chromium commit hash.
+	Commit string
+	// Device is the name of the device, e.g. "linux-perf". It is used to
+	// look up the builder when Builder is empty.
+	Device string
+	// Builder is the name of the Buildbucket builder, e.g. "Linux Builder Perf".
+	// If set, it takes precedence over the builder configured for Device, so
+	// that targets such as V8 or ANGLE can be built on their own builders.
+	Builder string
+	// Deps is a map of url to git hash that's used to override dependencies
+	// defined in DEPS files, e.g. to build V8 at a specific commit.
+	Deps map[string]interface{}
+	// Patches are the Gerrit patches included in the build.
+	Patches []*buildbucketpb.GerritChange
+}
+
+// builder returns the Buildbucket builder to use for the build.
+func (p BuildParams) builder() (string, error) {
+	if p.Builder != "" {
+		return p.Builder, nil
+	}
+	cfg, err := bot_configs.GetBotConfig(p.Device, false)
+	if err != nil {
+		return "", err
+	}
+	return cfg.Builder, nil
+}
+
+// BuildClient is a buildbucket client to build Chrome and other targets.
+type BuildClient interface {
 	// SearchOrBuild starts a new Build if it doesn't exist, or it will fetch
 	// the existing one that matches the build parameters.
-	SearchOrBuild(ctx context.Context, pinpointJobID, commit, device string, deps map[string]interface{}, patches []*buildbucketpb.GerritChange) (int64, error)
+	SearchOrBuild(ctx context.Context, params BuildParams) (int64, error)
 
 	// GetStatus returns the Build status.
 	GetStatus(context.Context, int64) (buildbucketpb.Status, error)
 
+	// WatchBuild returns a channel that delivers the status transitions of
+	// the build, starting with its current status. The channel is closed once
+	// the build has ended or the context is cancelled. Transitions are
+	// delivered from Buildbucket pub/sub notifications if a BuildWatcher has
+	// been provided, and by polling otherwise.
+	WatchBuild(ctx context.Context, buildID int64) (<-chan buildbucketpb.Status, error)
+
+	// GetFailureDetails explains why the build failed, so that callers can
+	// decide whether to retry it or consider the commit unbuildable. It
+	// returns an error if the build hasn't failed.
+	GetFailureDetails(ctx context.Context, buildID int64) (*FailureDetails, error)
+
 	// RetrieveCAS retrieves CAS from the build.
 	RetrieveCAS(context.Context, int64, string) (*swarmingV1.SwarmingRpcsCASReference, error)
 
@@ -39,6 +85,11 @@ type BuildChromeClient interface {
 	CancelBuild(context.Context, int64, string) error
 }
 
+// BuildChromeClient is the former name of BuildClient.
+//
+// Deprecated: use BuildClient instead.
+type BuildChromeClient = BuildClient
+
 // These constants define default fields used in a buildbucket
 // ScheduleBuildRequest for Pinpoint Chrome builds
 const (
@@ -49,9 +100,16 @@ const (
 	ScheduleReqStage   string = "staging"
 )
 
-// buildChromeImpl implements BuildChromeClient to build Chrome.
+// buildChromeImpl implements BuildClient.
 type buildChromeImpl struct {
 	backends.BuildbucketClient
+
+	// watcher delivers status notifications to WatchBuild. If nil,
+	// WatchBuild polls.
+	watcher *BuildWatcher
+
+	// watchPollInterval overrides defaultWatchPollInterval if non-zero.
+	watchPollInterval time.Duration
 }
 
 // New returns buildChromeImpl.
@@ -72,6 +130,13 @@ func New(ctx context.Context) (*buildChromeImpl, error) {
 	}, nil
 }
 
+// WithWatcher makes WatchBuild use the notifications received by the given
+// BuildWatcher. A nil BuildWatcher makes WatchBuild poll.
+func (bci *buildChromeImpl) WithWatcher(w *BuildWatcher) *buildChromeImpl {
+	bci.watcher = w
+	return bci
+}
+
 // searchBuild looks for an existing buildbucket build using the
 // builder and the commit and returns the build ID and status of the build
 // TODO(b/315215756): add support for non-chromium commits. A non-chromium build, such
@@ -116,14 +181,14 @@ func (bci *buildChromeImpl) searchBuild(ctx context.Context, builder, commit str
 	return 0, nil
 }
 
-// SearchOrBuild implements BuildChromeClient interface
-func (bci *buildChromeImpl) SearchOrBuild(ctx context.Context, pinpointJobID, commit, device string, deps map[string]interface{}, patches []*buildbucketpb.GerritChange) (int64, error) {
-	builder, err := bot_configs.GetBotConfig(device, false)
+// SearchOrBuild implements BuildClient interface
+func (bci *buildChromeImpl) SearchOrBuild(ctx context.Context, params BuildParams) (int64, error) {
+	builder, err := params.builder()
 	if err != nil {
 		return 0, err
 	}
 
-	buildId, err := bci.searchBuild(ctx, builder.Builder, commit, deps, patches)
+	buildId, err := bci.searchBuild(ctx, builder, params.Commit, params.Deps, params.Patches)
 	// We can ignore the error here since we only need to know if there is an existing build.
 	if err == nil && buildId != 0 {
 		return buildId, nil
@@ -131,7 +196,7 @@ func (bci *buildChromeImpl) SearchOrBuild(ctx context.Context, pinpointJobID, co
 
 	// if the ongoing build failed or the build was not found, start new build
 	requestID := uuid.New().String()
-	build, err := bci.StartChromeBuild(ctx, pinpointJobID, requestID, builder.Builder, commit, deps, patches)
+	build, err := bci.StartChromeBuild(ctx, params.PinpointJobID, requestID, builder, params.Commit, params.Deps, params.Patches)
 	if err != nil {
 		return 0, skerr.Wrapf(err, "Failed to start a build")
 	}
@@ -139,7 +204,7 @@ func (bci *buildChromeImpl) SearchOrBuild(ctx context.Context, pinpointJobID, co
 	return build.Id, nil
 }
 
-// RetrieveCAS implements BuildChromeClient interface
+// RetrieveCAS implements BuildClient interface
 func (bci *buildChromeImpl) RetrieveCAS(ctx context.Context, buildID int64, target string) (*swarmingV1.SwarmingRpcsCASReference, error) {
 	ref, err := bci.GetCASReference(ctx, buildID, target)
 	if err != nil {
@@ -148,12 +213,12 @@ func (bci *buildChromeImpl) RetrieveCAS(ctx context.Context, buildID int64, targ
 	return ref, nil
 }
 
-// CancelBuild implements BuildChromeClient interface
+// CancelBuild implements BuildClient interface
 func (bci *buildChromeImpl) CancelBuild(ctx context.Context, buildID int64, summary string) error {
 	return bci.CancelBuild(ctx, buildID, summary)
 }
 
-// GetStatus implements BuildChromeClient interface
+// GetStatus implements BuildClient interface
 // TODO(b/315215756): switch from polling to pub sub
 func (bci *buildChromeImpl) GetStatus(ctx context.Context, buildID int64) (buildbucketpb.Status, error) {
 	return bci.GetBuildStatus(ctx, buildID)
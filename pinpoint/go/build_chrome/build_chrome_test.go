@@ -164,7 +164,7 @@ func TestBuildNonExistentDevice(t *testing.T) {
 		BuildbucketClient: mb,
 	}
 
-	id, err := bc.SearchOrBuild(ctx, "fake-jID", "fake-commit", "non-existent device", nil, nil)
+	id, err := bc.SearchOrBuild(ctx, BuildParams{PinpointJobID: "fake-jID", Commit: "fake-commit", Device: "non-existent device"})
 	assert.ErrorContains(t, err, "was not found")
 	assert.Zero(t, id)
 }
@@ -191,7 +191,37 @@ func TestBuildFound(t *testing.T) {
 
 	mb.On("GetSingleBuild", testutils.AnyContext, "Linux Builder Perf", backends.DefaultBucket, "fake-commit", mock.Anything, patches).Return(mockResp, nil)
 
-	id, err := bc.SearchOrBuild(ctx, "fake-jID", fakeCommit, device, map[string]interface{}{}, patches)
+	id, err := bc.SearchOrBuild(ctx, BuildParams{PinpointJobID: "fake-jID", Commit: fakeCommit, Device: device, Deps: map[string]interface{}{}, Patches: patches})
+	assert.NoError(t, err)
+	assert.Equal(t, expected, id)
+}
+
+func TestBuildFound_BuilderOverridesDevice(t *testing.T) {
+	expected := int64(1)
+	mockResp := &buildbucketpb.Build{
+		Id:      expected,
+		Status:  buildbucketpb.Status_SUCCESS,
+		EndTime: timestamppb.Now(),
+	}
+
+	ctx := context.Background()
+	mb := &mocks.BuildbucketClient{}
+	bc := buildChromeImpl{
+		BuildbucketClient: mb,
+	}
+	builder := "V8 Linux64 Builder"
+	deps := map[string]interface{}{"https://chromium.googlesource.com/v8/v8": "fake-v8-commit"}
+	var patches []*buildbucketpb.GerritChange = nil
+
+	mb.On("GetSingleBuild", testutils.AnyContext, builder, backends.DefaultBucket, "fake-commit", deps, patches).Return(mockResp, nil)
+
+	id, err := bc.SearchOrBuild(ctx, BuildParams{
+		PinpointJobID: "fake-jID",
+		Commit:        "fake-commit",
+		Device:        "non-existent device",
+		Builder:       builder,
+		Deps:          deps,
+	})
 	assert.NoError(t, err)
 	assert.Equal(t, expected, id)
 }
@@ -238,7 +268,7 @@ func TestNewBuild(t *testing.T) {
 				mb.On("StartChromeBuild", testutils.AnyContext, mock.Anything, mock.Anything, builder, commit, mock.Anything, patches).Return(test.mockResp, nil)
 			}
 
-			id, err := bc.SearchOrBuild(ctx, "fake-jID", commit, device, map[string]interface{}{}, patches)
+			id, err := bc.SearchOrBuild(ctx, BuildParams{PinpointJobID: "fake-jID", Commit: commit, Device: device, Deps: map[string]interface{}{}, Patches: patches})
 			if test.expectedError {
 				assert.Error(t, err)
 			} else {
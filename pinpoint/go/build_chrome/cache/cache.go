@@ -0,0 +1,162 @@
+// Package cache stores the results of Pinpoint builds, so that repeated
+// bisections of the same range reuse identical builds instead of
+// rebuilding them.
+package cache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	fs "cloud.google.com/go/firestore"
+	buildbucketpb "go.chromium.org/luci/buildbucket/proto"
+	swarmingV1 "go.chromium.org/luci/common/api/swarming/swarming/v1"
+
+	"go.skia.org/infra/go/firestore"
+	"go.skia.org/infra/go/skerr"
+)
+
+const (
+	// For accessing Firestore.
+	defaultAttempts  = 3
+	getSingleTimeout = 10 * time.Second
+	putSingleTimeout = 10 * time.Second
+
+	// buildsCol is the name of the collection of cached builds.
+	buildsCol = "Builds"
+)
+
+// Cache maps build configurations to the results of their builds.
+type Cache interface {
+	// Get returns the ID of the build with the given key and the CAS reference
+	// of its outputs for the given isolate target. If no such build has been
+	// cached, it returns (0, nil, nil).
+	Get(ctx context.Context, key, target string) (int64, *swarmingV1.SwarmingRpcsCASReference, error)
+
+	// Put records the ID of the build with the given key and the CAS reference
+	// of its outputs for the given isolate target. CAS references of other
+	// targets of the same build are kept.
+	Put(ctx context.Context, key, target string, buildID int64, cas *swarmingV1.SwarmingRpcsCASReference) error
+}
+
+// Key returns a canonical hash of a build configuration, which is suitable as
+// a key for Cache. The order of deps and patches does not affect the key.
+func Key(commit, device, builder string, deps map[string]interface{}, patches []*buildbucketpb.GerritChange) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "commit=%s\ndevice=%s\nbuilder=%s\n", commit, device, builder)
+
+	repos := make([]string, 0, len(deps))
+	for repo := range deps {
+		repos = append(repos, repo)
+	}
+	sort.Strings(repos)
+	for _, repo := range repos {
+		fmt.Fprintf(&b, "dep=%s@%v\n", repo, deps[repo])
+	}
+
+	changes := make([]string, 0, len(patches))
+	for _, p := range patches {
+		changes = append(changes, fmt.Sprintf("patch=%s/%s/%d/%d\n", p.GetHost(), p.GetProject(), p.GetChange(), p.GetPatchset()))
+	}
+	sort.Strings(changes)
+	for _, c := range changes {
+		b.WriteString(c)
+	}
+
+	sum := sha256.Sum256([]byte(b.String()))
+	return hex.EncodeToString(sum[:])
+}
+
+// casReference is the CAS reference of build outputs stored in Firestore.
+type casReference struct {
+	CasInstance string `firestore:"cas_instance"`
+	Hash        string `firestore:"hash"`
+	SizeBytes   int64  `firestore:"size_bytes"`
+}
+
+// buildData is the type that is stored in Firestore for each build.
+type buildData struct {
+	Created time.Time `firestore:"created"`
+	BuildID int64     `firestore:"build_id"`
+	// CAS maps isolate targets to the CAS reference of their outputs.
+	CAS map[string]casReference `firestore:"cas"`
+}
+
+// FirestoreCache implements Cache using Cloud Firestore for storage.
+type FirestoreCache struct {
+	client *firestore.Client
+}
+
+// New returns an instance of FirestoreCache.
+func New(ctx context.Context, ts oauth2.TokenSource, fsNamespace, fsProjectID string) (*FirestoreCache, error) {
+	fsClient, err := firestore.NewClient(ctx, fsProjectID, "pinpoint", fsNamespace, ts)
+	if err != nil {
+		return nil, skerr.Wrapf(err, "could not init firestore")
+	}
+	return &FirestoreCache{
+		client: fsClient,
+	}, nil
+}
+
+// Get implements Cache.
+func (f *FirestoreCache) Get(ctx context.Context, key, target string) (int64, *swarmingV1.SwarmingRpcsCASReference, error) {
+	docRef := f.client.Collection(buildsCol).Doc(key)
+	doc, err := f.client.Get(ctx, docRef, defaultAttempts, getSingleTimeout)
+	if st, ok := status.FromError(err); ok && st.Code() == codes.NotFound {
+		return 0, nil, nil
+	}
+	if err != nil {
+		return 0, nil, skerr.Wrapf(err, "could not get build %s from cache", key)
+	}
+	data := buildData{}
+	if err := doc.DataTo(&data); err != nil {
+		return 0, nil, skerr.Wrapf(err, "could not decode build %s", key)
+	}
+	ref, ok := data.CAS[target]
+	if !ok {
+		return 0, nil, nil
+	}
+	return data.BuildID, &swarmingV1.SwarmingRpcsCASReference{
+		CasInstance: ref.CasInstance,
+		Digest: &swarmingV1.SwarmingRpcsDigest{
+			Hash:      ref.Hash,
+			SizeBytes: ref.SizeBytes,
+		},
+	}, nil
+}
+
+// Put implements Cache.
+func (f *FirestoreCache) Put(ctx context.Context, key, target string, buildID int64, cas *swarmingV1.SwarmingRpcsCASReference) error {
+	if cas == nil || cas.Digest == nil {
+		return skerr.Fmt("build %d has no CAS reference for %s", buildID, target)
+	}
+	// Merge the CAS reference into the existing document, so that the
+	// references of other targets of the same build are kept.
+	data := map[string]interface{}{
+		"created":  time.Now(),
+		"build_id": buildID,
+		"cas": map[string]interface{}{
+			target: map[string]interface{}{
+				"cas_instance": cas.CasInstance,
+				"hash":         cas.Digest.Hash,
+				"size_bytes":   cas.Digest.SizeBytes,
+			},
+		},
+	}
+	docRef := f.client.Collection(buildsCol).Doc(key)
+	if _, err := f.client.Set(ctx, docRef, data, defaultAttempts, putSingleTimeout, fs.MergeAll); err != nil {
+		return skerr.Wrapf(err, "could not put build %d in cache", buildID)
+	}
+	return nil
+}
+
+// Confirm FirestoreCache implements Cache.
+var _ Cache = (*FirestoreCache)(nil)
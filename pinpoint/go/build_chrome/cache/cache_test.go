@@ -0,0 +1,121 @@
+package cache
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	buildbucketpb "go.chromium.org/luci/buildbucket/proto"
+	swarmingV1 "go.chromium.org/luci/common/api/swarming/swarming/v1"
+
+	"go.skia.org/infra/go/firestore/testutils"
+)
+
+const (
+	testCommit  = "fake-commit"
+	testDevice  = "linux-perf"
+	testBuilder = "Linux Builder Perf"
+	testTarget  = "performance_test_suite"
+)
+
+func TestKey_DifferentOrder_SameKey(t *testing.T) {
+	deps := map[string]interface{}{
+		"https://chromium.googlesource.com/v8/v8":   "v8-commit",
+		"https://skia.googlesource.com/skia":        "skia-commit",
+		"https://chromium.googlesource.com/angle/a": "angle-commit",
+	}
+	patches := []*buildbucketpb.GerritChange{
+		{Host: "chromium-review.googlesource.com", Project: "chromium/src", Change: 1, Patchset: 2},
+		{Host: "chromium-review.googlesource.com", Project: "v8/v8", Change: 3, Patchset: 4},
+	}
+	reversed := []*buildbucketpb.GerritChange{patches[1], patches[0]}
+
+	assert.Equal(t,
+		Key(testCommit, testDevice, "", deps, patches),
+		Key(testCommit, testDevice, "", deps, reversed))
+}
+
+func TestKey_DifferentConfigurations_DifferentKeys(t *testing.T) {
+	deps := map[string]interface{}{"https://chromium.googlesource.com/v8/v8": "v8-commit"}
+	patches := []*buildbucketpb.GerritChange{
+		{Host: "chromium-review.googlesource.com", Project: "chromium/src", Change: 1, Patchset: 2},
+	}
+
+	keys := map[string]bool{}
+	for _, key := range []string{
+		Key(testCommit, testDevice, "", nil, nil),
+		Key("other-commit", testDevice, "", nil, nil),
+		Key(testCommit, "mac-m1_mini_2020-perf", "", nil, nil),
+		Key(testCommit, testDevice, testBuilder, nil, nil),
+		Key(testCommit, testDevice, "", deps, nil),
+		Key(testCommit, testDevice, "", nil, patches),
+	} {
+		assert.False(t, keys[key], "duplicate key %s", key)
+		keys[key] = true
+	}
+}
+
+func newCacheForTesting(ctx context.Context, t *testing.T) *FirestoreCache {
+	c, cleanup := testutils.NewClientForTesting(ctx, t)
+	t.Cleanup(cleanup)
+	return &FirestoreCache{
+		client: c,
+	}
+}
+
+func TestGet_EmptyCache_ReturnsNothing(t *testing.T) {
+	ctx := context.Background()
+	c := newCacheForTesting(ctx, t)
+
+	buildID, cas, err := c.Get(ctx, Key(testCommit, testDevice, "", nil, nil), testTarget)
+	require.NoError(t, err)
+	assert.Zero(t, buildID)
+	assert.Nil(t, cas)
+}
+
+func TestPut_MultipleTargets_KeepsAllTargets(t *testing.T) {
+	ctx := context.Background()
+	c := newCacheForTesting(ctx, t)
+	key := Key(testCommit, testDevice, "", nil, nil)
+	cas := &swarmingV1.SwarmingRpcsCASReference{
+		CasInstance: "fake-instance",
+		Digest: &swarmingV1.SwarmingRpcsDigest{
+			Hash:      "hash",
+			SizeBytes: 123,
+		},
+	}
+	otherCAS := &swarmingV1.SwarmingRpcsCASReference{
+		CasInstance: "fake-instance",
+		Digest: &swarmingV1.SwarmingRpcsDigest{
+			Hash:      "other-hash",
+			SizeBytes: 456,
+		},
+	}
+
+	require.NoError(t, c.Put(ctx, key, testTarget, 1, cas))
+	require.NoError(t, c.Put(ctx, key, "other_target", 1, otherCAS))
+
+	buildID, actual, err := c.Get(ctx, key, testTarget)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), buildID)
+	assert.Equal(t, cas, actual)
+
+	buildID, actual, err = c.Get(ctx, key, "other_target")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), buildID)
+	assert.Equal(t, otherCAS, actual)
+
+	// Other targets of the same build have not been cached.
+	buildID, actual, err = c.Get(ctx, key, "missing_target")
+	require.NoError(t, err)
+	assert.Zero(t, buildID)
+	assert.Nil(t, actual)
+}
+
+func TestPut_NoCASReference_ReturnsError(t *testing.T) {
+	ctx := context.Background()
+	c := newCacheForTesting(ctx, t)
+
+	assert.Error(t, c.Put(ctx, Key(testCommit, testDevice, "", nil, nil), testTarget, 1, nil))
+}
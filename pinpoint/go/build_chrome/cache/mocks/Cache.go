@@ -0,0 +1,85 @@
+// Code generated by mockery v0.0.0-dev. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	swarming "go.chromium.org/luci/common/api/swarming/swarming/v1"
+)
+
+// Cache is an autogenerated mock type for the Cache type
+type Cache struct {
+	mock.Mock
+}
+
+// Get provides a mock function with given fields: ctx, key, target
+func (_m *Cache) Get(ctx context.Context, key string, target string) (int64, *swarming.SwarmingRpcsCASReference, error) {
+	ret := _m.Called(ctx, key, target)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 int64
+	var r1 *swarming.SwarmingRpcsCASReference
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (int64, *swarming.SwarmingRpcsCASReference, error)); ok {
+		return rf(ctx, key, target)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) int64); ok {
+		r0 = rf(ctx, key, target)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) *swarming.SwarmingRpcsCASReference); ok {
+		r1 = rf(ctx, key, target)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*swarming.SwarmingRpcsCASReference)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, string) error); ok {
+		r2 = rf(ctx, key, target)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Put provides a mock function with given fields: ctx, key, target, buildID, cas
+func (_m *Cache) Put(ctx context.Context, key string, target string, buildID int64, cas *swarming.SwarmingRpcsCASReference) error {
+	ret := _m.Called(ctx, key, target, buildID, cas)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Put")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int64, *swarming.SwarmingRpcsCASReference) error); ok {
+		r0 = rf(ctx, key, target, buildID, cas)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewCache creates a new instance of Cache. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewCache(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Cache {
+	mock := &Cache{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
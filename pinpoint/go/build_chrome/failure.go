@@ -0,0 +1,131 @@
+package build_chrome
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"go.skia.org/infra/go/skerr"
+
+	buildbucketpb "go.chromium.org/luci/buildbucket/proto"
+)
+
+// FailureType classifies why a build failed.
+type FailureType string
+
+const (
+	// FailureUnknown is a failure that couldn't be classified.
+	FailureUnknown FailureType = "unknown"
+	// FailurePatchApply means that a Gerrit patch could not be applied.
+	FailurePatchApply FailureType = "patch_apply"
+	// FailureCompile means that the code at the commit does not compile.
+	FailureCompile FailureType = "compile"
+	// FailureInfra means that the build failed for reasons unrelated to the
+	// code being built, e.g. a bot died or timed out.
+	FailureInfra FailureType = "infra"
+	// FailureCanceled means that the build was canceled.
+	FailureCanceled FailureType = "canceled"
+)
+
+// maxCompileErrors is the maximum number of compile error lines kept in
+// FailureDetails.
+const maxCompileErrors = 10
+
+// compileErrorRegex matches compiler and linker error lines, e.g.
+// "../../v8/src/api.cc:12:3: error: use of undeclared identifier 'foo'".
+var compileErrorRegex = regexp.MustCompile(`(?m)^.*\berror\b.*$`)
+
+// FailureDetails explains why a build failed.
+type FailureDetails struct {
+	// Status is the terminal status of the build.
+	Status buildbucketpb.Status
+	// Type classifies the failure.
+	Type FailureType
+	// FailingStep is the name of the first step that failed, if any.
+	FailingStep string
+	// SummaryMarkdown is the summary of the build, or of the failing step
+	// if the build has none.
+	SummaryMarkdown string
+	// CompileErrors are excerpts of the compile errors, if any.
+	CompileErrors []string
+}
+
+// Retriable returns true if building the same commit again may succeed, in
+// which case the commit shouldn't be considered unbuildable.
+func (fd *FailureDetails) Retriable() bool {
+	return fd.Type == FailureInfra || fd.Type == FailureCanceled
+}
+
+// GetFailureDetails implements BuildClient interface
+func (bci *buildChromeImpl) GetFailureDetails(ctx context.Context, buildID int64) (*FailureDetails, error) {
+	build, err := bci.GetBuildDetails(ctx, buildID)
+	if err != nil {
+		return nil, skerr.Wrapf(err, "Could not get the details of build %d", buildID)
+	}
+	if !hasEnded(build.GetStatus()) || build.GetStatus() == buildbucketpb.Status_SUCCESS {
+		return nil, skerr.Fmt("Build %d has not failed, its status is %s", buildID, build.GetStatus())
+	}
+	return classifyFailure(build), nil
+}
+
+// classifyFailure classifies the failure of a build from its status and steps.
+func classifyFailure(build *buildbucketpb.Build) *FailureDetails {
+	fd := &FailureDetails{
+		Status:          build.GetStatus(),
+		Type:            FailureUnknown,
+		SummaryMarkdown: build.GetSummaryMarkdown(),
+	}
+
+	var failingStep *buildbucketpb.Step
+	for _, step := range build.GetSteps() {
+		if step.GetStatus() == buildbucketpb.Status_FAILURE || step.GetStatus() == buildbucketpb.Status_INFRA_FAILURE {
+			failingStep = step
+			break
+		}
+	}
+	if failingStep != nil {
+		fd.FailingStep = failingStep.GetName()
+		if fd.SummaryMarkdown == "" {
+			fd.SummaryMarkdown = failingStep.GetSummaryMarkdown()
+		}
+	}
+
+	switch {
+	case build.GetStatus() == buildbucketpb.Status_CANCELED:
+		fd.Type = FailureCanceled
+	case build.GetStatus() == buildbucketpb.Status_INFRA_FAILURE,
+		build.GetStatusDetails().GetTimeout() != nil,
+		build.GetStatusDetails().GetResourceExhaustion() != nil,
+		failingStep.GetStatus() == buildbucketpb.Status_INFRA_FAILURE:
+		fd.Type = FailureInfra
+	case failingStep == nil:
+		// Without a failing step, there's nothing to blame the code for.
+		fd.Type = FailureUnknown
+	case isPatchStep(failingStep):
+		fd.Type = FailurePatchApply
+	case strings.HasPrefix(failingStep.GetName(), "compile"):
+		fd.Type = FailureCompile
+		fd.CompileErrors = compileErrors(failingStep.GetSummaryMarkdown())
+	}
+	return fd
+}
+
+// isPatchStep returns true if the step failed to check out or patch the code.
+//
+// Chromium recipes apply Gerrit patches in the bot_update step, which reports
+// "Patch failure" in its summary if they don't apply.
+func isPatchStep(step *buildbucketpb.Step) bool {
+	name := step.GetName()
+	return strings.Contains(name, "apply patch") ||
+		(strings.HasPrefix(name, "bot_update") && strings.Contains(step.GetSummaryMarkdown(), "Patch failure"))
+}
+
+// compileErrors returns at most maxCompileErrors error lines from the
+// summary of a compile step.
+func compileErrors(summary string) []string {
+	var errs []string
+	for _, line := range compileErrorRegex.FindAllString(summary, maxCompileErrors) {
+		errs = append(errs, strings.TrimSpace(line))
+	}
+	return errs
+}
@@ -0,0 +1,172 @@
+package build_chrome
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	buildbucketpb "go.chromium.org/luci/buildbucket/proto"
+
+	"go.skia.org/infra/go/testutils"
+	"go.skia.org/infra/pinpoint/go/backends/mocks"
+)
+
+const compileSummary = `FAILED: obj/v8/v8_base/api.o
+../../v8/src/api/api.cc:12:3: error: use of undeclared identifier 'foo'
+../../v8/src/api/api.cc:20:1: error: expected ';' after expression
+2 errors generated.`
+
+func TestClassifyFailure(t *testing.T) {
+	for i, test := range []struct {
+		name              string
+		build             *buildbucketpb.Build
+		expectedType      FailureType
+		expectedStep      string
+		expectedRetriable bool
+	}{
+		{
+			name: "patch does not apply",
+			build: &buildbucketpb.Build{
+				Status: buildbucketpb.Status_FAILURE,
+				Steps: []*buildbucketpb.Step{
+					{Name: "bot_update", Status: buildbucketpb.Status_FAILURE, SummaryMarkdown: "Patch failure: See attached log."},
+					{Name: "compile", Status: buildbucketpb.Status_CANCELED},
+				},
+			},
+			expectedType: FailurePatchApply,
+			expectedStep: "bot_update",
+		},
+		{
+			name: "compile error",
+			build: &buildbucketpb.Build{
+				Status: buildbucketpb.Status_FAILURE,
+				Steps: []*buildbucketpb.Step{
+					{Name: "bot_update", Status: buildbucketpb.Status_SUCCESS},
+					{Name: "compile", Status: buildbucketpb.Status_FAILURE, SummaryMarkdown: compileSummary},
+				},
+			},
+			expectedType: FailureCompile,
+			expectedStep: "compile",
+		},
+		{
+			name: "infra failure",
+			build: &buildbucketpb.Build{
+				Status: buildbucketpb.Status_INFRA_FAILURE,
+				Steps: []*buildbucketpb.Step{
+					{Name: "bot_update", Status: buildbucketpb.Status_INFRA_FAILURE},
+				},
+			},
+			expectedType:      FailureInfra,
+			expectedStep:      "bot_update",
+			expectedRetriable: true,
+		},
+		{
+			name: "timeout",
+			build: &buildbucketpb.Build{
+				Status: buildbucketpb.Status_FAILURE,
+				StatusDetails: &buildbucketpb.StatusDetails{
+					Timeout: &buildbucketpb.StatusDetails_Timeout{},
+				},
+				Steps: []*buildbucketpb.Step{
+					{Name: "compile", Status: buildbucketpb.Status_FAILURE},
+				},
+			},
+			expectedType:      FailureInfra,
+			expectedStep:      "compile",
+			expectedRetriable: true,
+		},
+		{
+			name: "canceled",
+			build: &buildbucketpb.Build{
+				Status: buildbucketpb.Status_CANCELED,
+			},
+			expectedType:      FailureCanceled,
+			expectedRetriable: true,
+		},
+		{
+			name: "unknown step",
+			build: &buildbucketpb.Build{
+				Status: buildbucketpb.Status_FAILURE,
+				Steps: []*buildbucketpb.Step{
+					{Name: "upload", Status: buildbucketpb.Status_FAILURE},
+				},
+			},
+			expectedType: FailureUnknown,
+			expectedStep: "upload",
+		},
+	} {
+		t.Run(fmt.Sprintf("[%d] %s", i, test.name), func(t *testing.T) {
+			fd := classifyFailure(test.build)
+			assert.Equal(t, test.build.Status, fd.Status)
+			assert.Equal(t, test.expectedType, fd.Type)
+			assert.Equal(t, test.expectedStep, fd.FailingStep)
+			assert.Equal(t, test.expectedRetriable, fd.Retriable())
+		})
+	}
+}
+
+func TestClassifyFailure_CompileError_ExtractsErrors(t *testing.T) {
+	fd := classifyFailure(&buildbucketpb.Build{
+		Status: buildbucketpb.Status_FAILURE,
+		Steps: []*buildbucketpb.Step{
+			{Name: "compile (with patch)", Status: buildbucketpb.Status_FAILURE, SummaryMarkdown: compileSummary},
+		},
+	})
+	assert.Equal(t, FailureCompile, fd.Type)
+	assert.Equal(t, compileSummary, fd.SummaryMarkdown)
+	assert.Equal(t, []string{
+		"../../v8/src/api/api.cc:12:3: error: use of undeclared identifier 'foo'",
+		"../../v8/src/api/api.cc:20:1: error: expected ';' after expression",
+	}, fd.CompileErrors)
+}
+
+func TestGetFailureDetails(t *testing.T) {
+	ctx := context.Background()
+	buildID := int64(1)
+
+	for i, test := range []struct {
+		name          string
+		mockResp      *buildbucketpb.Build
+		mockErr       error
+		expectedError bool
+	}{
+		{
+			name:          "buildbucket error",
+			mockErr:       fmt.Errorf("some error"),
+			expectedError: true,
+		},
+		{
+			name:          "build succeeded",
+			mockResp:      &buildbucketpb.Build{Id: buildID, Status: buildbucketpb.Status_SUCCESS},
+			expectedError: true,
+		},
+		{
+			name:          "build ongoing",
+			mockResp:      &buildbucketpb.Build{Id: buildID, Status: buildbucketpb.Status_STARTED},
+			expectedError: true,
+		},
+		{
+			name:     "build failed",
+			mockResp: &buildbucketpb.Build{Id: buildID, Status: buildbucketpb.Status_INFRA_FAILURE},
+		},
+	} {
+		t.Run(fmt.Sprintf("[%d] %s", i, test.name), func(t *testing.T) {
+			mb := &mocks.BuildbucketClient{}
+			bc := &buildChromeImpl{
+				BuildbucketClient: mb,
+			}
+			mb.On("GetBuildDetails", testutils.AnyContext, buildID).Return(test.mockResp, test.mockErr)
+
+			fd, err := bc.GetFailureDetails(ctx, buildID)
+			if test.expectedError {
+				assert.Error(t, err)
+				assert.Nil(t, fd)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, FailureInfra, fd.Type)
+			}
+		})
+	}
+}
@@ -0,0 +1,218 @@
+package build_chrome
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"cloud.google.com/go/pubsub"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/go/sklog"
+
+	buildbucketpb "go.chromium.org/luci/buildbucket/proto"
+)
+
+// defaultWatchPollInterval is how often WatchBuild polls Buildbucket when
+// pub/sub notifications are unavailable.
+const defaultWatchPollInterval = 5 * time.Second
+
+// Receiver receives pub/sub messages, e.g. *pubsub.Subscription.
+type Receiver interface {
+	Receive(ctx context.Context, f func(context.Context, *pubsub.Message)) error
+}
+
+// BuildWatcher receives Buildbucket pub/sub notifications and dispatches the
+// status transitions of builds to the callers of WatchBuild.
+//
+// The subscription should be attached to a topic that Buildbucket publishes
+// builds to, either the builds_v2 topic of the project or the topic of a
+// NotificationConfig. A single BuildWatcher should be shared by all the
+// BuildClients of a process.
+type BuildWatcher struct {
+	receiver Receiver
+
+	mutex    sync.Mutex
+	running  bool
+	watchers map[int64]map[chan buildbucketpb.Status]bool
+}
+
+// NewBuildWatcher returns a BuildWatcher which receives notifications from
+// the given subscription once started.
+func NewBuildWatcher(receiver Receiver) *BuildWatcher {
+	return &BuildWatcher{
+		receiver: receiver,
+		watchers: map[int64]map[chan buildbucketpb.Status]bool{},
+	}
+}
+
+// Start receives notifications in the background until the context is
+// cancelled or the subscription fails. Watchers then fall back to polling.
+func (w *BuildWatcher) Start(ctx context.Context) {
+	w.mutex.Lock()
+	w.running = true
+	w.mutex.Unlock()
+
+	go func() {
+		if err := w.receiver.Receive(ctx, w.handle); err != nil {
+			sklog.Errorf("Stopped receiving Buildbucket notifications, falling back to polling: %s", err)
+		}
+		w.stop()
+	}()
+}
+
+// stop closes the channels of all the watchers.
+func (w *BuildWatcher) stop() {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	w.running = false
+	for buildID, chans := range w.watchers {
+		for ch := range chans {
+			close(ch)
+		}
+		delete(w.watchers, buildID)
+	}
+}
+
+// watch returns a channel of the statuses of the given build, and a function
+// to stop watching it. The channel is closed if notifications stop, and only
+// holds the latest status, so that slow readers never block notifications.
+func (w *BuildWatcher) watch(buildID int64) (<-chan buildbucketpb.Status, func()) {
+	ch := make(chan buildbucketpb.Status, 1)
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	if !w.running {
+		close(ch)
+		return ch, func() {}
+	}
+	if w.watchers[buildID] == nil {
+		w.watchers[buildID] = map[chan buildbucketpb.Status]bool{}
+	}
+	w.watchers[buildID][ch] = true
+
+	return ch, func() {
+		w.mutex.Lock()
+		defer w.mutex.Unlock()
+		delete(w.watchers[buildID], ch)
+		if len(w.watchers[buildID]) == 0 {
+			delete(w.watchers, buildID)
+		}
+	}
+}
+
+// handle dispatches a pub/sub notification to the watchers of its build.
+func (w *BuildWatcher) handle(ctx context.Context, msg *pubsub.Message) {
+	// Notifications are only hints, so there is no point in redelivering them.
+	msg.Ack()
+
+	build, err := decodeBuild(msg.Data)
+	if err != nil {
+		sklog.Warningf("Ignoring Buildbucket notification %s: %s", msg.ID, err)
+		return
+	}
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	for ch := range w.watchers[build.GetId()] {
+		// Replace any status that hasn't been read yet.
+		select {
+		case <-ch:
+		default:
+		}
+		ch <- build.GetStatus()
+	}
+}
+
+// decodeBuild decodes the build from a Buildbucket notification, which is
+// either a PubSubCallBack or a BuildsV2PubSub encoded in JSON.
+func decodeBuild(data []byte) (*buildbucketpb.Build, error) {
+	opts := protojson.UnmarshalOptions{DiscardUnknown: true}
+	callback := &buildbucketpb.PubSubCallBack{}
+	if err := opts.Unmarshal(data, callback); err == nil && callback.GetBuildPubsub().GetBuild() != nil {
+		return callback.GetBuildPubsub().GetBuild(), nil
+	}
+	msg := &buildbucketpb.BuildsV2PubSub{}
+	if err := opts.Unmarshal(data, msg); err != nil {
+		return nil, skerr.Wrapf(err, "could not decode notification")
+	}
+	if msg.GetBuild() == nil {
+		return nil, skerr.Fmt("notification has no build")
+	}
+	return msg.GetBuild(), nil
+}
+
+// hasEnded returns true if the status is final.
+func hasEnded(status buildbucketpb.Status) bool {
+	return status&buildbucketpb.Status_ENDED_MASK != 0
+}
+
+// WatchBuild implements BuildClient interface
+func (bci *buildChromeImpl) WatchBuild(ctx context.Context, buildID int64) (<-chan buildbucketpb.Status, error) {
+	// Start watching before getting the current status, so that no
+	// transition is missed in between.
+	var notifications <-chan buildbucketpb.Status
+	unwatch := func() {}
+	if bci.watcher != nil {
+		notifications, unwatch = bci.watcher.watch(buildID)
+	}
+
+	status, err := bci.GetBuildStatus(ctx, buildID)
+	if err != nil {
+		unwatch()
+		return nil, skerr.Wrapf(err, "Could not get the status of build %d", buildID)
+	}
+
+	pollInterval := bci.watchPollInterval
+	if pollInterval == 0 {
+		pollInterval = defaultWatchPollInterval
+	}
+
+	statuses := make(chan buildbucketpb.Status)
+	go func() {
+		defer close(statuses)
+		defer unwatch()
+
+		ticker := time.NewTicker(pollInterval)
+		defer ticker.Stop()
+
+		last := buildbucketpb.Status_STATUS_UNSPECIFIED
+		for {
+			if status != last {
+				select {
+				case statuses <- status:
+				case <-ctx.Done():
+					return
+				}
+				last = status
+			}
+			if hasEnded(status) {
+				return
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case s, ok := <-notifications:
+				if !ok {
+					// Notifications stopped, poll from now on.
+					notifications = nil
+					continue
+				}
+				status = s
+			case <-ticker.C:
+				if notifications != nil {
+					continue
+				}
+				s, err := bci.GetBuildStatus(ctx, buildID)
+				if err != nil {
+					sklog.Warningf("Failed to poll the status of build %d: %s", buildID, err)
+					continue
+				}
+				status = s
+			}
+		}
+	}()
+	return statuses, nil
+}
@@ -0,0 +1,175 @@
+package build_chrome
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/pubsub"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	buildbucketpb "go.chromium.org/luci/buildbucket/proto"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"go.skia.org/infra/go/testutils"
+	"go.skia.org/infra/pinpoint/go/backends/mocks"
+)
+
+// fakeReceiver delivers the messages sent on its channel until it is closed.
+type fakeReceiver struct {
+	messages chan *pubsub.Message
+}
+
+func (r *fakeReceiver) Receive(ctx context.Context, f func(context.Context, *pubsub.Message)) error {
+	for msg := range r.messages {
+		f(ctx, msg)
+	}
+	return fmt.Errorf("subscription closed")
+}
+
+func notification(t *testing.T, buildID int64, status buildbucketpb.Status) *pubsub.Message {
+	data, err := protojson.Marshal(&buildbucketpb.BuildsV2PubSub{
+		Build: &buildbucketpb.Build{
+			Id:     buildID,
+			Status: status,
+		},
+	})
+	require.NoError(t, err)
+	return &pubsub.Message{Data: data}
+}
+
+func collect(t *testing.T, statuses <-chan buildbucketpb.Status) []buildbucketpb.Status {
+	var actual []buildbucketpb.Status
+	for {
+		select {
+		case s, ok := <-statuses:
+			if !ok {
+				return actual
+			}
+			actual = append(actual, s)
+		case <-time.After(5 * time.Second):
+			require.FailNow(t, "timed out waiting for build statuses", "received %v", actual)
+		}
+	}
+}
+
+func TestWatchBuild_NoWatcher_PollsUntilEnded(t *testing.T) {
+	ctx := context.Background()
+	buildID := int64(1)
+	mb := &mocks.BuildbucketClient{}
+	bc := &buildChromeImpl{
+		BuildbucketClient: mb,
+		watchPollInterval: time.Millisecond,
+	}
+
+	mb.On("GetBuildStatus", testutils.AnyContext, buildID).Return(buildbucketpb.Status_SCHEDULED, nil).Once()
+	mb.On("GetBuildStatus", testutils.AnyContext, buildID).Return(buildbucketpb.Status_STARTED, nil).Twice()
+	mb.On("GetBuildStatus", testutils.AnyContext, buildID).Return(buildbucketpb.Status_STATUS_UNSPECIFIED, fmt.Errorf("transient error")).Once()
+	mb.On("GetBuildStatus", testutils.AnyContext, buildID).Return(buildbucketpb.Status_SUCCESS, nil).Once()
+
+	statuses, err := bc.WatchBuild(ctx, buildID)
+	require.NoError(t, err)
+	assert.Equal(t, []buildbucketpb.Status{
+		buildbucketpb.Status_SCHEDULED,
+		buildbucketpb.Status_STARTED,
+		buildbucketpb.Status_SUCCESS,
+	}, collect(t, statuses))
+	mb.AssertExpectations(t)
+}
+
+func TestWatchBuild_GetBuildStatusFails_ReturnsError(t *testing.T) {
+	ctx := context.Background()
+	buildID := int64(1)
+	mb := &mocks.BuildbucketClient{}
+	bc := &buildChromeImpl{
+		BuildbucketClient: mb,
+	}
+
+	mb.On("GetBuildStatus", testutils.AnyContext, buildID).Return(buildbucketpb.Status_STATUS_UNSPECIFIED, fmt.Errorf("some error"))
+
+	statuses, err := bc.WatchBuild(ctx, buildID)
+	assert.Error(t, err)
+	assert.Nil(t, statuses)
+}
+
+func TestWatchBuild_WithWatcher_DeliversNotifications(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	buildID := int64(1)
+	mb := &mocks.BuildbucketClient{}
+	receiver := &fakeReceiver{messages: make(chan *pubsub.Message)}
+	defer close(receiver.messages)
+	w := NewBuildWatcher(receiver)
+	w.Start(ctx)
+	bc := (&buildChromeImpl{
+		BuildbucketClient: mb,
+		// Polling would time out the test.
+		watchPollInterval: time.Hour,
+	}).WithWatcher(w)
+
+	mb.On("GetBuildStatus", testutils.AnyContext, buildID).Return(buildbucketpb.Status_SCHEDULED, nil).Once()
+
+	statuses, err := bc.WatchBuild(ctx, buildID)
+	require.NoError(t, err)
+	assert.Equal(t, buildbucketpb.Status_SCHEDULED, <-statuses)
+
+	// Notifications of other builds are ignored.
+	receiver.messages <- notification(t, buildID+1, buildbucketpb.Status_FAILURE)
+	receiver.messages <- notification(t, buildID, buildbucketpb.Status_STARTED)
+	assert.Equal(t, buildbucketpb.Status_STARTED, <-statuses)
+	receiver.messages <- &pubsub.Message{Data: []byte("not a build")}
+	receiver.messages <- notification(t, buildID, buildbucketpb.Status_SUCCESS)
+	assert.Equal(t, []buildbucketpb.Status{buildbucketpb.Status_SUCCESS}, collect(t, statuses))
+	mb.AssertExpectations(t)
+}
+
+func TestWatchBuild_NotificationsStop_FallsBackToPolling(t *testing.T) {
+	ctx := context.Background()
+	buildID := int64(1)
+	mb := &mocks.BuildbucketClient{}
+	receiver := &fakeReceiver{messages: make(chan *pubsub.Message)}
+	w := NewBuildWatcher(receiver)
+	w.Start(ctx)
+	bc := (&buildChromeImpl{
+		BuildbucketClient: mb,
+		watchPollInterval: time.Millisecond,
+	}).WithWatcher(w)
+
+	mb.On("GetBuildStatus", testutils.AnyContext, buildID).Return(buildbucketpb.Status_STARTED, nil).Once()
+	mb.On("GetBuildStatus", testutils.AnyContext, buildID).Return(buildbucketpb.Status_FAILURE, nil).Once()
+
+	statuses, err := bc.WatchBuild(ctx, buildID)
+	require.NoError(t, err)
+	assert.Equal(t, buildbucketpb.Status_STARTED, <-statuses)
+
+	close(receiver.messages)
+	assert.Equal(t, []buildbucketpb.Status{buildbucketpb.Status_FAILURE}, collect(t, statuses))
+	mb.AssertExpectations(t)
+}
+
+func TestDecodeBuild_SupportsBothNotificationFormats(t *testing.T) {
+	build := &buildbucketpb.Build{
+		Id:     1,
+		Status: buildbucketpb.Status_SUCCESS,
+	}
+
+	data, err := protojson.Marshal(&buildbucketpb.PubSubCallBack{
+		BuildPubsub: &buildbucketpb.BuildsV2PubSub{Build: build},
+		UserData:    []byte("user data"),
+	})
+	require.NoError(t, err)
+	actual, err := decodeBuild(data)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), actual.GetId())
+	assert.Equal(t, buildbucketpb.Status_SUCCESS, actual.GetStatus())
+
+	data, err = protojson.Marshal(&buildbucketpb.BuildsV2PubSub{Build: build})
+	require.NoError(t, err)
+	actual, err = decodeBuild(data)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), actual.GetId())
+
+	_, err = decodeBuild([]byte("{}"))
+	assert.Error(t, err)
+}
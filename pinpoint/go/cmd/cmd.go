@@ -7,6 +7,7 @@ import (
 	"github.com/davecgh/go-spew/spew"
 	"go.skia.org/infra/go/skerr"
 	"go.skia.org/infra/go/sklog"
+	"go.skia.org/infra/pinpoint/go/compare"
 	"go.skia.org/infra/pinpoint/go/pinpoint"
 	"go.skia.org/infra/pinpoint/go/read_values"
 )
@@ -28,18 +29,24 @@ var (
 )
 
 type cliCmd struct {
-	jobID      string
-	mode       string
-	sampleSize int
-	startHash  string
-	endHash    string
-	device     string
-	target     string
-	benchmark  string
-	story      string
-	chart      string
-	agg        string
-	mag        float64
+	jobID       string
+	mode        string
+	sampleSize  int
+	startHash   string
+	endHash     string
+	device      string
+	target      string
+	benchmark   string
+	story       string
+	chart       string
+	agg         string
+	mag         float64
+	low         float64
+	comparisons int
+	exact       bool
+
+	checkpointDir string
+	resume        bool
 }
 
 func (cli *cliCmd) RegisterFlags() {
@@ -55,6 +62,11 @@ func (cli *cliCmd) RegisterFlags() {
 	flag.StringVar(&cli.chart, "chart", chartDefault, "chart/measurement/sub-story to read")
 	flag.StringVar(&cli.agg, "dataAgg", aggDefault, "method to aggregate benchmark measurements by. Options are sum, mean, min, max, count, and std.")
 	flag.Float64Var(&cli.mag, "magnitude", magDefault, "Raw magnitude expected")
+	flag.Float64Var(&cli.low, "low-threshold", 0, "significance level to compare commits with. Defaults to 0.01 if zero.")
+	flag.IntVar(&cli.comparisons, "comparisons", 0, "number of comparisons made, e.g. metrics compared. If greater than 1, the significance level is Bonferroni corrected.")
+	flag.BoolVar(&cli.exact, "exact-p-values", false, "compute exact p-values for small samples without ties.")
+	flag.StringVar(&cli.checkpointDir, "checkpoint-dir", "", "directory to checkpoint the job to after every iteration. Disabled if empty.")
+	flag.BoolVar(&cli.resume, "resume", false, "resume the job given by -id from its checkpoint in -checkpoint-dir.")
 }
 
 func (c *cliCmd) Run() (*pinpoint.PinpointRunResponse, error) {
@@ -63,6 +75,15 @@ func (c *cliCmd) Run() (*pinpoint.PinpointRunResponse, error) {
 	if err != nil {
 		return nil, err
 	}
+	if c.checkpointDir != "" {
+		pp = pp.WithCheckpointStore(pinpoint.NewFileCheckpointStore(c.checkpointDir))
+	}
+	if c.resume {
+		if c.jobID == "" || c.checkpointDir == "" {
+			return nil, skerr.Fmt("-resume requires -id and -checkpoint-dir")
+		}
+		return pp.Resume(ctx, c.jobID)
+	}
 	agg, err := c.getAggregationMethod()
 	if err != nil {
 		return nil, err
@@ -76,6 +97,11 @@ func (c *cliCmd) Run() (*pinpoint.PinpointRunResponse, error) {
 		EndCommit:         c.endHash,
 		Magnitude:         c.mag,
 		AggregationMethod: agg,
+		Thresholds: compare.Thresholds{
+			LowThreshold: c.low,
+			Comparisons:  c.comparisons,
+			ExactPValues: c.exact,
+		},
 	}
 
 	return pp.Run(ctx, req, c.jobID)
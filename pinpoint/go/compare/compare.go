@@ -31,6 +31,10 @@
 package compare
 
 import (
+	"fmt"
+	"math"
+	"sort"
+
 	"go.skia.org/infra/go/skerr"
 	"go.skia.org/infra/pinpoint/go/compare/thresholds"
 )
@@ -49,8 +53,49 @@ const (
 	// Different means that the samples are unlikely to come
 	// from the same distribution. Reject the null hypothesis.
 	Different
+
+	// NeedMoreData is an alias of Unknown.
+	NeedMoreData = Unknown
 )
 
+// Mode selects the statistical analysis used to compare two samples.
+type Mode int
+
+const (
+	// AutoMode selects FunctionalMode if all of the values are 0 or 1, i.e.
+	// they represent failures and successes, and PerformanceMode otherwise.
+	AutoMode Mode = iota
+	// FunctionalMode compares failure rates, using the functional thresholds.
+	FunctionalMode
+	// PerformanceMode compares measurements, using the performance thresholds.
+	PerformanceMode
+)
+
+// defaultFunctionalMagnitude is the difference in failure rate used by Compare
+// in functional mode if CompareOptions.Magnitude is zero.
+const defaultFunctionalMagnitude = 0.5
+
+// defaultPerformanceMagnitude is the absolute difference used by Compare in
+// performance mode if CompareOptions.Magnitude is zero.
+const defaultPerformanceMagnitude = 1.0
+
+// CompareOptions configures Compare.
+type CompareOptions struct {
+	// Mode selects the statistical analysis. Defaults to AutoMode.
+	Mode Mode
+	// Magnitude is the expected size of the difference between the samples. In
+	// functional mode, it is the difference in failure rate, between 0 and 1,
+	// and defaults to 0.5. In performance mode, it is the absolute difference
+	// in the measured values, which Compare normalizes by the interquartile
+	// range of both samples, and defaults to 1.0.
+	Magnitude float64
+	// Thresholds configures the significance level.
+	Thresholds Thresholds
+	// EffectSize, if set, makes Compare estimate the effect size of the
+	// difference between the samples, see EstimateEffectSize.
+	EffectSize *EffectSizeOptions
+}
+
 type VerdictEnum interface {
 	Verdict() verdict
 }
@@ -77,6 +122,208 @@ type CompareResults struct {
 	// 	HighThreshold is the `alpha` where if the p-value is lower means we need
 	// 											more information to make a definitive judgement.
 	HighThreshold float64
+	// Decision explains how the verdict was reached.
+	Decision ThresholdDecision
+	// EffectSize is the estimated size of the difference between the samples.
+	// It is only set by Compare if requested in CompareOptions and both
+	// samples have values.
+	EffectSize *EffectSize
+}
+
+// ThresholdDecision contains the p-value and thresholds behind a verdict, along
+// with where the high threshold came from, so that callers can tell how close
+// the p-value was to changing the verdict.
+type ThresholdDecision struct {
+	// PValue is the consolidated p-value for the statistical tests used.
+	PValue float64
+	// LowThreshold is the threshold below which the samples are Different.
+	LowThreshold float64
+	// HighThreshold is the threshold above which the samples are the Same.
+	HighThreshold float64
+	// NormalizedMagnitude is the normalized magnitude requested by the caller.
+	NormalizedMagnitude float64
+	// MagnitudeIndex is the index of the row of the table of high thresholds at
+	// or immediately below TableMagnitude, which is the normalized magnitude used
+	// to interpolate the high threshold.
+	MagnitudeIndex int
+	TableMagnitude float64
+	// SampleSize is the sample size requested by the caller, and TableSampleSize
+	// is the sample size used to interpolate the high threshold.
+	SampleSize      int
+	TableSampleSize float64
+	// MagnitudeClamped is true if NormalizedMagnitude was outside of the range
+	// covered by the table of high thresholds.
+	MagnitudeClamped bool
+	// SampleSizeClamped is true if SampleSize was larger than the largest sample
+	// size in the table of high thresholds for TableMagnitude.
+	SampleSizeClamped bool
+}
+
+// LowThresholdDistance returns how far the p-value is above the low threshold.
+// A distance less than or equal to zero means the samples are Different.
+func (d ThresholdDecision) LowThresholdDistance() float64 {
+	return d.PValue - d.LowThreshold
+}
+
+// HighThresholdDistance returns how far the p-value is above the high threshold.
+// A distance greater than zero means the samples are the Same, unless the p-value
+// is also below the low threshold.
+func (d ThresholdDecision) HighThresholdDistance() float64 {
+	return d.PValue - d.HighThreshold
+}
+
+// String returns a human readable summary of the decision, suitable for logs.
+func (d ThresholdDecision) String() string {
+	clamped := ""
+	if d.MagnitudeClamped {
+		clamped += " (magnitude clamped)"
+	}
+	if d.SampleSizeClamped {
+		clamped += " (sample size clamped)"
+	}
+	return fmt.Sprintf("p-value %.4f, low threshold %.4f (distance %+.4f), high threshold %.4f (distance %+.4f) from magnitude %.2f [index %d] and sample size %g%s",
+		d.PValue, d.LowThreshold, d.LowThresholdDistance(), d.HighThreshold, d.HighThresholdDistance(),
+		d.TableMagnitude, d.MagnitudeIndex, d.TableSampleSize, clamped)
+}
+
+// Thresholds configures the significance level used to compare samples, so that
+// it can be set per job.
+//
+// The zero value uses the default low threshold, thresholds.LowThreshold.
+type Thresholds struct {
+	// LowThreshold is the significance level. If the p-value is less than or
+	// equal to it, the samples are Different. Defaults to thresholds.LowThreshold
+	// if zero.
+	LowThreshold float64
+	// Comparisons is the number of comparisons made in the job, e.g. the number
+	// of metrics compared. If greater than 1, the low threshold is Bonferroni
+	// corrected, i.e. divided by the number of comparisons.
+	Comparisons int
+	// ExactPValues computes exact p-values for small samples without ties via
+	// MannWhitneyUExact and KolmogorovSmirnovExact, instead of the asymptotic
+	// approximations that the default tables of high thresholds were generated
+	// with.
+	ExactPValues bool
+}
+
+// DefaultThresholds returns the thresholds used by ComparePerformance and
+// CompareFunctional.
+func DefaultThresholds() Thresholds {
+	return Thresholds{LowThreshold: thresholds.LowThreshold}
+}
+
+// Validate returns an error if the thresholds are invalid.
+func (t Thresholds) Validate() error {
+	if t.LowThreshold < 0 || t.LowThreshold >= 1 {
+		return skerr.Fmt("low threshold must be in the range [0, 1), got %v", t.LowThreshold)
+	}
+	if t.Comparisons < 0 {
+		return skerr.Fmt("number of comparisons must not be negative, got %d", t.Comparisons)
+	}
+	return nil
+}
+
+// EffectiveLowThreshold returns the low threshold used to compare samples,
+// after applying the default value and the Bonferroni correction.
+func (t Thresholds) EffectiveLowThreshold() float64 {
+	low := t.LowThreshold
+	if low == 0 {
+		low = thresholds.LowThreshold
+	}
+	if t.Comparisons > 1 {
+		low /= float64(t.Comparisons)
+	}
+	return low
+}
+
+// newThresholdDecision returns a ThresholdDecision for the given low threshold
+// and high threshold lookup, without a p-value.
+func newThresholdDecision(lowThreshold, normalizedMagnitude float64, attemptCount int, lookup thresholds.HighThresholdLookup) ThresholdDecision {
+	return ThresholdDecision{
+		LowThreshold:        lowThreshold,
+		HighThreshold:       lookup.HighThreshold,
+		NormalizedMagnitude: normalizedMagnitude,
+		MagnitudeIndex:      lookup.MagnitudeIndex,
+		TableMagnitude:      lookup.Magnitude,
+		SampleSize:          attemptCount,
+		TableSampleSize:     lookup.SampleSize,
+		MagnitudeClamped:    lookup.MagnitudeClamped,
+		SampleSizeClamped:   lookup.SampleSizeClamped,
+	}
+}
+
+// Compare determines if valuesA and valuesB are statistically different,
+// statistically same or if more data is needed, without requiring the caller to
+// know whether the values are failures and successes or measurements (see Mode).
+//
+// The attempt count is the average size of valuesA and valuesB.
+func Compare(valuesA []float64, valuesB []float64, opts CompareOptions) (*CompareResults, error) {
+	mode := opts.Mode
+	if mode == AutoMode {
+		mode = detectMode(valuesA, valuesB)
+	}
+	attemptCount := (len(valuesA) + len(valuesB)) / 2
+
+	var result *CompareResults
+	var err error
+	switch mode {
+	case FunctionalMode:
+		magnitude := opts.Magnitude
+		if magnitude == 0 {
+			magnitude = defaultFunctionalMagnitude
+		}
+		result, err = opts.Thresholds.CompareFunctional(valuesA, valuesB, attemptCount, math.Abs(magnitude))
+	case PerformanceMode:
+		magnitude := opts.Magnitude
+		if magnitude == 0 {
+			magnitude = defaultPerformanceMagnitude
+		}
+		result, err = opts.Thresholds.ComparePerformance(valuesA, valuesB, attemptCount, normalizeMagnitude(valuesA, valuesB, magnitude))
+	default:
+		return nil, skerr.Fmt("unknown comparison mode %d", opts.Mode)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.EffectSize != nil && len(valuesA) > 0 && len(valuesB) > 0 {
+		result.EffectSize, err = EstimateEffectSize(valuesA, valuesB, *opts.EffectSize)
+		if err != nil {
+			return nil, skerr.Wrapf(err, "Failed to estimate the effect size")
+		}
+	}
+	return result, nil
+}
+
+// detectMode returns FunctionalMode if all of the values are 0 or 1, and
+// PerformanceMode otherwise.
+func detectMode(valuesA []float64, valuesB []float64) Mode {
+	for _, values := range [][]float64{valuesA, valuesB} {
+		for _, v := range values {
+			if v != 0 && v != 1 {
+				return PerformanceMode
+			}
+		}
+	}
+	return FunctionalMode
+}
+
+// normalizeMagnitude divides the absolute magnitude by the interquartile range
+// of all of the values. If the interquartile range is zero, the magnitude is
+// infinite, and the largest normalized magnitude in the thresholds tables is used.
+func normalizeMagnitude(valuesA []float64, valuesB []float64, magnitude float64) float64 {
+	all := make([]float64, 0, len(valuesA)+len(valuesB))
+	all = append(all, valuesA...)
+	all = append(all, valuesB...)
+	if len(all) == 0 {
+		return 0
+	}
+	sort.Float64s(all)
+	iqr := all[len(all)*3/4] - all[len(all)/4]
+	if iqr == 0 {
+		return math.Inf(1)
+	}
+	return math.Abs(magnitude / iqr)
 }
 
 // CompareFunctional determines if valuesA and valuesB are statistically different,
@@ -89,12 +336,7 @@ type CompareResults struct {
 // samples between valuesA and valuesB.
 func CompareFunctional(valuesA []float64, valuesB []float64, attemptCount int,
 	normalizedMagnitude float64) (*CompareResults, error) {
-	LowThreshold := thresholds.LowThreshold
-	HighThreshold, err := thresholds.HighThresholdFunctional(normalizedMagnitude, attemptCount)
-	if err != nil {
-		return nil, skerr.Wrapf(err, "Could not get functional high threshold")
-	}
-	return compare(valuesA, valuesB, LowThreshold, HighThreshold)
+	return DefaultThresholds().CompareFunctional(valuesA, valuesB, attemptCount, normalizedMagnitude)
 }
 
 // ComparePerformance determines if valuesA and valuesB are statistically different,
@@ -108,34 +350,56 @@ func CompareFunctional(valuesA []float64, valuesB []float64, attemptCount int,
 // and valuesB.
 func ComparePerformance(valuesA []float64, valuesB []float64, attemptCount int,
 	normalizedMagnitude float64) (*CompareResults, error) {
-	LowThreshold := thresholds.LowThreshold
-	HighThreshold, err := thresholds.HighThresholdPerformance(normalizedMagnitude, attemptCount)
-	if err != nil {
-		return nil, skerr.Wrapf(err, "Could not get high threshold for bisection")
+	return DefaultThresholds().ComparePerformance(valuesA, valuesB, attemptCount, normalizedMagnitude)
+}
+
+// CompareFunctional is like the CompareFunctional function, but uses the low
+// threshold configured by t.
+func (t Thresholds) CompareFunctional(valuesA []float64, valuesB []float64, attemptCount int,
+	normalizedMagnitude float64) (*CompareResults, error) {
+	if err := t.Validate(); err != nil {
+		return nil, skerr.Wrapf(err, "Invalid thresholds")
 	}
+	lookup := thresholds.LookupHighThresholdFunctional(normalizedMagnitude, float64(attemptCount))
+	return compare(valuesA, valuesB, newThresholdDecision(t.EffectiveLowThreshold(), normalizedMagnitude, attemptCount, lookup), t.ExactPValues)
+}
 
-	return compare(valuesA, valuesB, LowThreshold, HighThreshold)
+// ComparePerformance is like the ComparePerformance function, but uses the low
+// threshold configured by t.
+func (t Thresholds) ComparePerformance(valuesA []float64, valuesB []float64, attemptCount int,
+	normalizedMagnitude float64) (*CompareResults, error) {
+	if err := t.Validate(); err != nil {
+		return nil, skerr.Wrapf(err, "Invalid thresholds")
+	}
+	lookup := thresholds.LookupHighThresholdPerformance(normalizedMagnitude, float64(attemptCount))
+	return compare(valuesA, valuesB, newThresholdDecision(t.EffectiveLowThreshold(), normalizedMagnitude, attemptCount, lookup), t.ExactPValues)
 }
 
 // compare decides whether two samples are the same, different, or unknown
-// using the KS and MWU tests and compare their p-values against the
-// LowThreshold and HighThreshold.
-func compare(valuesA []float64, valuesB []float64, LowThreshold float64, HighThreshold float64) (*CompareResults, error) {
+// using the KS and MWU tests and compare their p-values against the low and
+// high thresholds of the given decision, which is completed with the p-value.
+// If exact is true, exact p-values are computed for small samples.
+func compare(valuesA []float64, valuesB []float64, decision ThresholdDecision, exact bool) (*CompareResults, error) {
 	if len(valuesA) == 0 || len(valuesB) == 0 {
 		// A sample has no values in it. Return verdict to measure more data.
-		return &CompareResults{Verdict: Unknown}, nil
+		return &CompareResults{Verdict: Unknown, Decision: decision}, nil
 	}
+	LowThreshold, HighThreshold := decision.LowThreshold, decision.HighThreshold
 
 	// MWU is bad at detecting changes in variance, and K-S is bad with discrete
 	// distributions. So use both. We want low p-values for the below examples.
 	//        a                     b               MWU(a, b)  KS(a, b)
 	// [0]*20            [0]*15+[1]*5                0.0097     0.4973
 	// range(10, 30)     range(10)+range(30, 40)     0.4946     0.0082
-	PValueKS, err := KolmogorovSmirnov(valuesA, valuesB)
+	ks, mwu := KolmogorovSmirnov, MannWhitneyU
+	if exact {
+		ks, mwu = KolmogorovSmirnovExact, MannWhitneyUExact
+	}
+	PValueKS, err := ks(valuesA, valuesB)
 	if err != nil {
 		return nil, skerr.Wrapf(err, "Failed KS test")
 	}
-	PValueMWU := MannWhitneyU(valuesA, valuesB)
+	PValueMWU := mwu(valuesA, valuesB)
 	if err != nil {
 		return nil, skerr.Wrapf(err, "Failed MWU test")
 	}
@@ -147,7 +411,9 @@ func compare(valuesA []float64, valuesB []float64, LowThreshold float64, HighThr
 		PValueMWU:     PValueMWU,
 		LowThreshold:  LowThreshold,
 		HighThreshold: HighThreshold,
+		Decision:      decision,
 	}
+	result.Decision.PValue = PValue
 
 	if PValue <= LowThreshold {
 		// The p-value is less than the significance level. Reject the null
@@ -140,3 +140,140 @@ func TestCompare(t *testing.T) {
 		})
 	}
 }
+
+func TestComparePerformance_Decision_ExplainsVerdict(t *testing.T) {
+	x := []float64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+	y := []float64{3, 4, 5, 6, 7, 8, 9, 10, 11, 12}
+	result, err := ComparePerformance(x, y, 10, 1.05)
+	assert.NoError(t, err)
+	assert.Equal(t, Unknown, result.Verdict)
+
+	d := result.Decision
+	assert.Equal(t, result.PValue, d.PValue)
+	assert.Equal(t, result.LowThreshold, d.LowThreshold)
+	assert.Equal(t, result.HighThreshold, d.HighThreshold)
+	assert.Equal(t, 1.05, d.NormalizedMagnitude)
+	assert.Equal(t, 7, d.MagnitudeIndex)
+	assert.InDelta(t, 1.05, d.TableMagnitude, 1e-9)
+	assert.Equal(t, 10, d.SampleSize)
+	assert.Equal(t, 10.0, d.TableSampleSize)
+	assert.False(t, d.MagnitudeClamped)
+	assert.False(t, d.SampleSizeClamped)
+	assert.Greater(t, d.LowThresholdDistance(), 0.0)
+	assert.LessOrEqual(t, d.HighThresholdDistance(), 0.0)
+	assert.Contains(t, d.String(), "magnitude 1.05 [index 7] and sample size 10")
+}
+
+func TestComparePerformance_LargeMagnitudeAndSampleSize_DecisionClamped(t *testing.T) {
+	result, err := ComparePerformance([]float64{}, []float64{1}, 500, 20)
+	assert.NoError(t, err)
+	assert.Equal(t, Unknown, result.Verdict)
+	assert.True(t, result.Decision.MagnitudeClamped)
+	assert.True(t, result.Decision.SampleSizeClamped)
+	assert.Equal(t, 500, result.Decision.SampleSize)
+	assert.Equal(t, 10.0, result.Decision.TableSampleSize)
+	assert.Contains(t, result.Decision.String(), "(magnitude clamped) (sample size clamped)")
+}
+
+func TestThresholds_Validate(t *testing.T) {
+	assert.NoError(t, Thresholds{}.Validate())
+	assert.NoError(t, DefaultThresholds().Validate())
+	assert.NoError(t, Thresholds{LowThreshold: 0.05, Comparisons: 10}.Validate())
+	assert.Error(t, Thresholds{LowThreshold: -0.01}.Validate())
+	assert.Error(t, Thresholds{LowThreshold: 1}.Validate())
+	assert.Error(t, Thresholds{Comparisons: -1}.Validate())
+}
+
+func TestThresholds_EffectiveLowThreshold(t *testing.T) {
+	assert.Equal(t, 0.01, Thresholds{}.EffectiveLowThreshold())
+	assert.Equal(t, 0.05, Thresholds{LowThreshold: 0.05}.EffectiveLowThreshold())
+	assert.Equal(t, 0.05, Thresholds{LowThreshold: 0.05, Comparisons: 1}.EffectiveLowThreshold())
+	assert.InDelta(t, 0.005, Thresholds{LowThreshold: 0.05, Comparisons: 10}.EffectiveLowThreshold(), 1e-12)
+}
+
+func TestThresholds_ComparePerformance_UsesConfiguredLowThreshold(t *testing.T) {
+	x := []float64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+	y := []float64{3, 4, 5, 6, 7, 8, 9, 10, 11, 12}
+
+	// The p-value is between the default low threshold and 0.1.
+	result, err := ComparePerformance(x, y, 10, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, Unknown, result.Verdict)
+	assert.Greater(t, result.PValue, 0.01)
+	assert.Less(t, result.PValue, 0.1)
+
+	result, err = Thresholds{LowThreshold: 0.1}.ComparePerformance(x, y, 10, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, Different, result.Verdict)
+	assert.Equal(t, 0.1, result.LowThreshold)
+	assert.Equal(t, 0.1, result.Decision.LowThreshold)
+
+	// With a Bonferroni correction, the difference is no longer significant.
+	result, err = Thresholds{LowThreshold: 0.1, Comparisons: 5}.ComparePerformance(x, y, 10, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, Unknown, result.Verdict)
+
+	_, err = Thresholds{LowThreshold: 2}.CompareFunctional(x, y, 10, 1)
+	assert.Error(t, err)
+}
+
+func TestThresholds_ComparePerformance_ExactPValues(t *testing.T) {
+	x := []float64{0, 1, 2, 3, 4}
+	y := []float64{5, 6, 7, 8, 9}
+
+	result, err := Thresholds{ExactPValues: true}.ComparePerformance(x, y, 5, 1)
+	assert.NoError(t, err)
+	assert.InDelta(t, 2.0/252, result.PValueMWU, 1e-12)
+	assert.InDelta(t, 2.0/252, result.PValueKS, 1e-12)
+	assert.Equal(t, Different, result.Verdict)
+
+	result, err = ComparePerformance(x, y, 5, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, MannWhitneyU(x, y), result.PValueMWU)
+}
+
+func TestCompare_AutoMode_DetectsFunctionalData(t *testing.T) {
+	x := []float64{0, 0, 0, 0, 0, 0, 0, 0, 0, 0}
+	y := []float64{1, 1, 1, 1, 1, 1, 1, 1, 1, 1}
+	result, err := Compare(x, y, CompareOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, Different, result.Verdict)
+	expected, err := CompareFunctional(x, y, 10, defaultFunctionalMagnitude)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, result)
+
+	result, err = Compare(x, x, CompareOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, Same, result.Verdict)
+}
+
+func TestCompare_AutoMode_DetectsPerformanceData(t *testing.T) {
+	x := []float64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+	y := []float64{3, 4, 5, 6, 7, 8, 9, 10, 11, 12}
+	// The interquartile range of all of the values is 5, so a magnitude of 5 is
+	// normalized to 1.
+	result, err := Compare(x, y, CompareOptions{Magnitude: 5})
+	assert.NoError(t, err)
+	assert.Equal(t, NeedMoreData, result.Verdict)
+	expected, err := ComparePerformance(x, y, 10, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, result)
+}
+
+func TestCompare_ExplicitMode_OverridesDetection(t *testing.T) {
+	x := []float64{0, 0, 0, 0, 0, 0, 0, 0, 0, 1}
+	y := []float64{0, 0, 0, 0, 0, 0, 0, 0, 0, 0}
+	result, err := Compare(x, y, CompareOptions{Mode: PerformanceMode})
+	assert.NoError(t, err)
+	// The interquartile range is zero, so the largest normalized magnitude is used.
+	assert.True(t, result.Decision.MagnitudeClamped)
+	assert.InDelta(t, 4.0, result.Decision.TableMagnitude, 1e-9)
+
+	_, err = Compare(x, y, CompareOptions{Mode: Mode(42)})
+	assert.Error(t, err)
+}
+
+func TestCompare_InvalidThresholds_ReturnsError(t *testing.T) {
+	_, err := Compare([]float64{0}, []float64{1}, CompareOptions{Thresholds: Thresholds{LowThreshold: 2}})
+	assert.Error(t, err)
+}
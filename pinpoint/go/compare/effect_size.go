@@ -0,0 +1,152 @@
+package compare
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+
+	"go.skia.org/infra/go/skerr"
+)
+
+// defaultConfidenceLevel is the confidence level of the interval estimated by
+// EstimateEffectSize if EffectSizeOptions.ConfidenceLevel is zero.
+const defaultConfidenceLevel = 0.95
+
+// defaultResamples is the number of bootstrap resamples drawn by
+// EstimateEffectSize if EffectSizeOptions.Resamples is zero.
+const defaultResamples = 1000
+
+// EffectSizeOptions configures EstimateEffectSize.
+type EffectSizeOptions struct {
+	// ConfidenceLevel is the probability that the confidence interval contains
+	// the true median difference, between 0 and 1 exclusive. Defaults to 0.95.
+	ConfidenceLevel float64
+	// Resamples is the number of bootstrap resamples. Defaults to 1000.
+	Resamples int
+	// Seed seeds the resampling, so that the same samples and options always
+	// produce the same confidence interval.
+	Seed int64
+}
+
+// EffectSize estimates how much the values changed from one sample to another.
+type EffectSize struct {
+	// MedianDifference is the median of the second sample minus the median of
+	// the first sample.
+	MedianDifference float64
+	// RelativeDifference is MedianDifference divided by the median of the first
+	// sample, e.g. 0.1 for a 10% increase. It is NaN if the median of the first
+	// sample is zero.
+	RelativeDifference float64
+	// Lower and Upper are the bounds of the bootstrap confidence interval of
+	// MedianDifference.
+	Lower float64
+	Upper float64
+	// ConfidenceLevel is the confidence level of the interval.
+	ConfidenceLevel float64
+	// Resamples is the number of bootstrap resamples used to compute the
+	// interval.
+	Resamples int
+}
+
+// ContainsZero returns true if the confidence interval contains zero, i.e. the
+// samples may not differ at the given confidence level.
+func (e EffectSize) ContainsZero() bool {
+	return e.Lower <= 0 && e.Upper >= 0
+}
+
+// String returns a human readable summary of the effect size, suitable for
+// bug comments.
+func (e EffectSize) String() string {
+	relative := ""
+	if !math.IsNaN(e.RelativeDifference) {
+		relative = fmt.Sprintf(" (%+.2f%%)", e.RelativeDifference*100)
+	}
+	return fmt.Sprintf("median difference %+.4g%s, %g%% confidence interval [%.4g, %.4g]",
+		e.MedianDifference, relative, e.ConfidenceLevel*100, e.Lower, e.Upper)
+}
+
+// Validate returns an error if the options are invalid.
+func (o EffectSizeOptions) Validate() error {
+	if o.ConfidenceLevel < 0 || o.ConfidenceLevel >= 1 {
+		return skerr.Fmt("confidence level must be in the range [0, 1), got %v", o.ConfidenceLevel)
+	}
+	if o.Resamples < 0 {
+		return skerr.Fmt("number of resamples must not be negative, got %d", o.Resamples)
+	}
+	return nil
+}
+
+// EstimateEffectSize estimates the difference between the medians of valuesA
+// and valuesB, along with a percentile bootstrap confidence interval of the
+// difference.
+func EstimateEffectSize(valuesA []float64, valuesB []float64, opts EffectSizeOptions) (*EffectSize, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, skerr.Wrapf(err, "Invalid effect size options")
+	}
+	if len(valuesA) == 0 || len(valuesB) == 0 {
+		return nil, skerr.Fmt("cannot estimate the effect size of empty samples, got %d and %d values", len(valuesA), len(valuesB))
+	}
+	level := opts.ConfidenceLevel
+	if level == 0 {
+		level = defaultConfidenceLevel
+	}
+	resamples := opts.Resamples
+	if resamples == 0 {
+		resamples = defaultResamples
+	}
+
+	medianA, medianB := median(valuesA), median(valuesB)
+	result := &EffectSize{
+		MedianDifference:   medianB - medianA,
+		RelativeDifference: math.NaN(),
+		ConfidenceLevel:    level,
+		Resamples:          resamples,
+	}
+	if medianA != 0 {
+		result.RelativeDifference = result.MedianDifference / math.Abs(medianA)
+	}
+
+	r := rand.New(rand.NewSource(opts.Seed))
+	resampleA := make([]float64, len(valuesA))
+	resampleB := make([]float64, len(valuesB))
+	diffs := make([]float64, resamples)
+	for i := range diffs {
+		resample(r, valuesA, resampleA)
+		resample(r, valuesB, resampleB)
+		diffs[i] = median(resampleB) - median(resampleA)
+	}
+	sort.Float64s(diffs)
+	alpha := (1 - level) / 2
+	result.Lower = quantile(diffs, alpha)
+	result.Upper = quantile(diffs, 1-alpha)
+	return result, nil
+}
+
+// resample fills dst with values drawn from src with replacement.
+func resample(r *rand.Rand, src, dst []float64) {
+	for i := range dst {
+		dst[i] = src[r.Intn(len(src))]
+	}
+}
+
+// median returns the median of the values, which must not be empty. The
+// values are not modified.
+func median(values []float64) float64 {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	n := len(sorted)
+	if n%2 == 1 {
+		return sorted[n/2]
+	}
+	return (sorted[n/2-1] + sorted[n/2]) / 2
+}
+
+// quantile returns the q-th quantile of the sorted values, which must not be
+// empty, interpolating linearly between the closest ranks.
+func quantile(sorted []float64, q float64) float64 {
+	pos := q * float64(len(sorted)-1)
+	lower := int(math.Floor(pos))
+	upper := int(math.Ceil(pos))
+	return sorted[lower] + (pos-float64(lower))*(sorted[upper]-sorted[lower])
+}
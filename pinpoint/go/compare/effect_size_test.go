@@ -0,0 +1,89 @@
+package compare
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	effectSizeA = []float64{10.1, 9.8, 10.3, 10.0, 9.9, 10.2, 10.4, 9.7, 10.0, 10.1}
+	effectSizeB = []float64{11.2, 10.9, 11.4, 11.0, 11.1, 11.3, 10.8, 11.5, 11.0, 11.2}
+)
+
+func TestEstimateEffectSize_ShiftedSamples_IntervalContainsShift(t *testing.T) {
+	e, err := EstimateEffectSize(effectSizeA, effectSizeB, EffectSizeOptions{Seed: 1})
+	require.NoError(t, err)
+
+	assert.InDelta(t, 1.1, e.MedianDifference, 1e-9)
+	assert.InDelta(t, 1.1/10.05, e.RelativeDifference, 1e-9)
+	assert.Equal(t, defaultConfidenceLevel, e.ConfidenceLevel)
+	assert.Equal(t, defaultResamples, e.Resamples)
+	assert.LessOrEqual(t, e.Lower, e.MedianDifference)
+	assert.GreaterOrEqual(t, e.Upper, e.MedianDifference)
+	assert.False(t, e.ContainsZero())
+}
+
+func TestEstimateEffectSize_SameSeed_Deterministic(t *testing.T) {
+	opts := EffectSizeOptions{Seed: 42, Resamples: 200}
+	e1, err := EstimateEffectSize(effectSizeA, effectSizeB, opts)
+	require.NoError(t, err)
+	e2, err := EstimateEffectSize(effectSizeA, effectSizeB, opts)
+	require.NoError(t, err)
+	assert.Equal(t, e1, e2)
+}
+
+func TestEstimateEffectSize_HigherConfidence_WiderInterval(t *testing.T) {
+	narrow, err := EstimateEffectSize(effectSizeA, effectSizeB, EffectSizeOptions{ConfidenceLevel: 0.5, Seed: 1})
+	require.NoError(t, err)
+	wide, err := EstimateEffectSize(effectSizeA, effectSizeB, EffectSizeOptions{ConfidenceLevel: 0.99, Seed: 1})
+	require.NoError(t, err)
+	assert.LessOrEqual(t, wide.Lower, narrow.Lower)
+	assert.GreaterOrEqual(t, wide.Upper, narrow.Upper)
+}
+
+func TestEstimateEffectSize_SameSamples_IntervalContainsZero(t *testing.T) {
+	e, err := EstimateEffectSize(effectSizeA, effectSizeA, EffectSizeOptions{Seed: 1})
+	require.NoError(t, err)
+	assert.Zero(t, e.MedianDifference)
+	assert.True(t, e.ContainsZero())
+}
+
+func TestEstimateEffectSize_ZeroMedian_RelativeDifferenceNaN(t *testing.T) {
+	e, err := EstimateEffectSize([]float64{0, 0, 0}, []float64{1, 1, 1}, EffectSizeOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, 1.0, e.MedianDifference)
+	assert.True(t, math.IsNaN(e.RelativeDifference))
+	assert.Equal(t, "median difference +1, 95% confidence interval [1, 1]", e.String())
+}
+
+func TestEstimateEffectSize_InvalidInputs_ReturnsError(t *testing.T) {
+	_, err := EstimateEffectSize([]float64{}, effectSizeB, EffectSizeOptions{})
+	assert.Error(t, err)
+	_, err = EstimateEffectSize(effectSizeA, effectSizeB, EffectSizeOptions{ConfidenceLevel: 1})
+	assert.Error(t, err)
+	_, err = EstimateEffectSize(effectSizeA, effectSizeB, EffectSizeOptions{Resamples: -1})
+	assert.Error(t, err)
+}
+
+func TestMedian(t *testing.T) {
+	assert.Equal(t, 2.0, median([]float64{3, 1, 2}))
+	assert.Equal(t, 2.5, median([]float64{4, 1, 3, 2}))
+}
+
+func TestCompare_EffectSizeRequested_ReportsEffectSize(t *testing.T) {
+	result, err := Compare(effectSizeA, effectSizeB, CompareOptions{EffectSize: &EffectSizeOptions{Seed: 1}})
+	require.NoError(t, err)
+	require.NotNil(t, result.EffectSize)
+	assert.InDelta(t, 1.1, result.EffectSize.MedianDifference, 1e-9)
+
+	result, err = Compare(effectSizeA, effectSizeB, CompareOptions{})
+	require.NoError(t, err)
+	assert.Nil(t, result.EffectSize)
+
+	result, err = Compare([]float64{}, effectSizeB, CompareOptions{EffectSize: &EffectSizeOptions{}})
+	require.NoError(t, err)
+	assert.Nil(t, result.EffectSize)
+}
@@ -87,3 +87,66 @@ func survival(y float64) float64 {
 
 	return p + p
 }
+
+// KolmogorovSmirnovExact computes the 2-sample Kolmogorov-Smirnov test on
+// samples x and y like KolmogorovSmirnov, but computes the exact p-value for
+// small samples without ties. It falls back to the asymptotic distribution used
+// by KolmogorovSmirnov otherwise. This is the equivalent of SciPy's
+// method = 'exact'.
+func KolmogorovSmirnovExact(x []float64, y []float64) (float64, error) {
+	n1 := len(x)
+	n2 := len(y)
+	if n1 == 0 || n2 == 0 || n1+n2 > maxExactSampleSize || hasTies(x, y) {
+		return KolmogorovSmirnov(x, y)
+	}
+
+	sortedX := append([]float64{}, x...)
+	sortedY := append([]float64{}, y...)
+	sort.Float64s(sortedX)
+	sort.Float64s(sortedY)
+
+	// The statistic D scaled by n1*n2, which is always an integer.
+	d := 0
+	for _, value := range append(append([]float64{}, sortedX...), sortedY...) {
+		cdf1 := sort.SearchFloat64s(sortedX, value)
+		cdf2 := sort.SearchFloat64s(sortedY, value)
+		d = max(d, abs(cdf1*n2-cdf2*n1))
+	}
+
+	// Count the lattice paths from (0, 0) to (n1, n2), i.e. the ways to
+	// interleave the two samples, whose statistic is strictly less than d. The
+	// p-value is the fraction of paths whose statistic is at least d.
+	inside := make([][]float64, n1+1)
+	for i := range inside {
+		inside[i] = make([]float64, n2+1)
+	}
+	for i := 0; i <= n1; i++ {
+		for j := 0; j <= n2; j++ {
+			if abs(i*n2-j*n1) >= d {
+				continue
+			}
+			if i == 0 && j == 0 {
+				inside[i][j] = 1
+				continue
+			}
+			if i > 0 {
+				inside[i][j] += inside[i-1][j]
+			}
+			if j > 0 {
+				inside[i][j] += inside[i][j-1]
+			}
+		}
+	}
+	total := 1.0
+	for i := 1; i <= n1; i++ {
+		total = total * float64(n2+i) / float64(i)
+	}
+	return math.Max(0, 1-inside[n1][n2]/math.Round(total)), nil
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"math"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func TestKolmogorovSmirnov(t *testing.T) {
@@ -73,3 +75,30 @@ func TestKolmogorovSmirnov(t *testing.T) {
 		t.Errorf("Expected error but got nil")
 	}
 }
+
+func TestKolmogorovSmirnovExact_SmallSamplesWithoutTies_ExactPValue(t *testing.T) {
+	x := []float64{3, 1, 2}
+	p, err := KolmogorovSmirnovExact(x, []float64{4, 5, 6})
+	assert.NoError(t, err)
+	assert.InDelta(t, 0.1, p, 1e-12)
+	// The inputs are not modified.
+	assert.Equal(t, []float64{3, 1, 2}, x)
+
+	// Identical distributions interleaved perfectly have D = 1/3.
+	p, err = KolmogorovSmirnovExact([]float64{1, 3, 5}, []float64{2, 4, 6})
+	assert.NoError(t, err)
+	assert.Equal(t, 1.0, p)
+}
+
+func TestKolmogorovSmirnovExact_TiesOrEmpty_FallsBackToAsymptotic(t *testing.T) {
+	x := []float64{0, 0, 0, 1, 1}
+	y := []float64{1, 1, 1, 1, 1}
+	expected, err := KolmogorovSmirnov(append([]float64{}, x...), append([]float64{}, y...))
+	assert.NoError(t, err)
+	p, err := KolmogorovSmirnovExact(x, y)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, p)
+
+	_, err = KolmogorovSmirnovExact([]float64{}, y)
+	assert.Error(t, err)
+}
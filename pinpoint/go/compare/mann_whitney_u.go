@@ -130,3 +130,81 @@ func tieCorrectionFactor(rankvals []float64) float64 {
 func normSf(x float64) float64 {
 	return (1 - math.Erf(x/math.Sqrt(2))) / 2
 }
+
+// maxExactSampleSize is the largest combined size of two samples for which exact
+// p-values are computed. The number of ways to interleave the two samples, i.e.
+// n1+n2 choose n1, must be exactly representable as a float64.
+const maxExactSampleSize = 50
+
+// MannWhitneyUExact computes the Mann-Whitney rank test on samples x and y like
+// MannWhitneyU, but computes the exact p-value from the distribution of U for
+// small samples without ties. It falls back to the normal approximation used by
+// MannWhitneyU otherwise. This is the equivalent of SciPy's method = 'exact'.
+func MannWhitneyUExact(x []float64, y []float64) float64 {
+	n1 := len(x)
+	n2 := len(y)
+	if n1 == 0 || n2 == 0 || n1+n2 > maxExactSampleSize || hasTies(x, y) {
+		return MannWhitneyU(x, y)
+	}
+
+	all := make([]float64, 0, n1+n2)
+	all = append(all, x...)
+	all = append(all, y...)
+	ranked := rankData(all)
+	s := 0.0
+	for _, val := range ranked[:n1] {
+		s += val
+	}
+	u1 := int(math.Round(float64(n1*n2) + float64(n1*(n1+1))/2.0 - s))
+	u2 := n1*n2 - u1
+
+	// counts[u] is the number of ways to interleave the two samples such that
+	// the U statistic equals u. The two-sided p-value is the probability of a U
+	// statistic at least as extreme as the one observed.
+	counts := mannWhitneyUCounts(n1, n2)
+	total := 0.0
+	tail := 0.0
+	for u, c := range counts {
+		total += c
+		if u <= min(u1, u2) {
+			tail += c
+		}
+	}
+	return math.Min(1.0, 2*tail/total)
+}
+
+// mannWhitneyUCounts returns the number of ways to interleave samples of sizes
+// n1 and n2 that produce each value of the U statistic, from 0 to n1*n2. These
+// are the coefficients of the Gaussian binomial coefficient (n1+n2 choose n1)_q,
+// which is computed as the product of (1 - q^(n2+i)) / (1 - q^i) for i in 1..n1.
+// Every intermediate polynomial is itself a Gaussian binomial coefficient, so
+// all of the values stay exact.
+func mannWhitneyUCounts(n1, n2 int) []float64 {
+	counts := make([]float64, n1*n2+1)
+	counts[0] = 1
+	for i := 1; i <= n1; i++ {
+		// Multiply by (1 - q^(n2+i)).
+		for u := len(counts) - 1; u >= n2+i; u-- {
+			counts[u] -= counts[u-n2-i]
+		}
+		// Divide by (1 - q^i).
+		for u := i; u < len(counts); u++ {
+			counts[u] += counts[u-i]
+		}
+	}
+	return counts
+}
+
+// hasTies returns true if any value appears more than once across x and y.
+func hasTies(x []float64, y []float64) bool {
+	all := make([]float64, 0, len(x)+len(y))
+	all = append(all, x...)
+	all = append(all, y...)
+	sort.Float64s(all)
+	for i := 1; i < len(all); i++ {
+		if all[i] == all[i-1] {
+			return true
+		}
+	}
+	return false
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"math"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func TestMannWhitneyU(t *testing.T) {
@@ -68,3 +70,35 @@ func TestMannWhitneyU(t *testing.T) {
 		})
 	}
 }
+
+func TestMannWhitneyUExact_SmallSamplesWithoutTies_ExactPValue(t *testing.T) {
+	// Only 2 of the 20 ways to interleave the samples are as extreme.
+	assert.InDelta(t, 0.1, MannWhitneyUExact([]float64{1, 2, 3}, []float64{4, 5, 6}), 1e-12)
+	assert.InDelta(t, 2.0/252, MannWhitneyUExact([]float64{0, 1, 2, 3, 4}, []float64{5, 6, 7, 8, 9}), 1e-12)
+	assert.Equal(t, 1.0, MannWhitneyUExact([]float64{0}, []float64{1}))
+	// U = 1 for x, i.e. 2 of the 10 ways have U <= 1 for either sample.
+	assert.InDelta(t, 0.4, MannWhitneyUExact([]float64{1, 3}, []float64{2, 4, 5}), 1e-12)
+}
+
+func TestMannWhitneyUExact_TiesOrLargeSamples_FallsBackToAsymptotic(t *testing.T) {
+	x := []float64{0, 0, 0, 0, 0}
+	y := []float64{1, 1, 1, 1, 1}
+	assert.Equal(t, MannWhitneyU(x, y), MannWhitneyUExact(x, y))
+
+	x, y = make([]float64, 30), make([]float64, 30)
+	for i := range x {
+		x[i] = float64(i)
+		y[i] = float64(i) + 10.5
+	}
+	assert.Equal(t, MannWhitneyU(x, y), MannWhitneyUExact(x, y))
+}
+
+func TestMannWhitneyUCounts_SumsToBinomialCoefficient(t *testing.T) {
+	assert.Equal(t, []float64{1, 1, 2, 2, 2, 1, 1}, mannWhitneyUCounts(2, 3))
+	total := 0.0
+	for _, c := range mannWhitneyUCounts(25, 25) {
+		assert.GreaterOrEqual(t, c, 0.0)
+		total += c
+	}
+	assert.Equal(t, 126410606437752.0, total)
+}
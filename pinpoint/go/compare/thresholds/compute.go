@@ -0,0 +1,156 @@
+package thresholds
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+
+	"go.skia.org/infra/go/skerr"
+)
+
+// normalIQR is the interquartile range of the standard normal distribution.
+const normalIQR = 2 * 0.6744897501960817
+
+// Params defines how a table of high thresholds is generated by ComputeHighThresholds.
+//
+// The table has one row per normalized magnitude, and each row has one threshold per sample
+// size, starting at 1.
+type Params struct {
+	// MagnitudeStepsPerUnit is the granularity of the normalized magnitudes, e.g. 10 means that
+	// there is one row for each multiple of 0.1.
+	MagnitudeStepsPerUnit int
+
+	// FirstMagnitudeStep and LastMagnitudeStep are the range of normalized magnitudes covered by
+	// the table, in multiples of 1/MagnitudeStepsPerUnit. Both ends are inclusive.
+	FirstMagnitudeStep int
+	LastMagnitudeStep  int
+
+	// MinSampleSize is the smallest sample size at which a row may end. Each row ends at the
+	// first sample size whose threshold is below LowThreshold, or at MaxSampleSize.
+	MinSampleSize int
+	MaxSampleSize int
+
+	// Trials is the number of randomized pairs of samples used to estimate each threshold.
+	Trials int
+
+	// Percentile of the simulated p-values used as the threshold, between 0 and 100.
+	Percentile float64
+
+	// Seed is used to seed the random number generator, so that tables are reproducible.
+	Seed int64
+
+	// Samples returns a pair of random samples of the given size whose difference has the given
+	// normalized magnitude.
+	Samples func(r *rand.Rand, magnitude float64, sampleSize int) ([]float64, []float64)
+}
+
+// PerformanceParams are the parameters used to generate highThresholdsPerformance.
+//
+// The samples are drawn from normal distributions with an IQR of 1, so that the normalized
+// magnitude is the difference between their means.
+var PerformanceParams = Params{
+	MagnitudeStepsPerUnit: 10,
+	FirstMagnitudeStep:    3,
+	LastMagnitudeStep:     40,
+	MinSampleSize:         10,
+	MaxSampleSize:         500,
+	Trials:                10000,
+	Percentile:            99,
+	Seed:                  1,
+	Samples:               performanceSamples,
+}
+
+// FunctionalParams are the parameters used to generate highThresholdsFunctional.
+//
+// The samples contain 0s (success) and 1s (failure), and the normalized magnitude is the
+// difference in failure rate.
+var FunctionalParams = Params{
+	MagnitudeStepsPerUnit: 10,
+	FirstMagnitudeStep:    1,
+	LastMagnitudeStep:     10,
+	MinSampleSize:         1,
+	MaxSampleSize:         500,
+	Trials:                10000,
+	Percentile:            99,
+	Seed:                  1,
+	Samples:               functionalSamples,
+}
+
+// ComputeHighThresholds generates a table of high thresholds.
+//
+// For each normalized magnitude and sample size, it simulates many pairs of samples that differ by
+// that magnitude, and computes their p-values with the given function (e.g. compare.MannWhitneyU).
+// The high threshold is the given percentile of those p-values: if two samples had a difference
+// of at least that magnitude, their p-value would almost certainly be lower than it.
+func ComputeHighThresholds(params Params, pValue func(a, b []float64) float64) ([][]float64, error) {
+	if params.MagnitudeStepsPerUnit <= 0 {
+		return nil, skerr.Fmt("MagnitudeStepsPerUnit must be positive, got %d", params.MagnitudeStepsPerUnit)
+	}
+	if params.FirstMagnitudeStep <= 0 || params.LastMagnitudeStep < params.FirstMagnitudeStep {
+		return nil, skerr.Fmt("invalid magnitude range [%d, %d]", params.FirstMagnitudeStep, params.LastMagnitudeStep)
+	}
+	if params.MinSampleSize <= 0 || params.MaxSampleSize < params.MinSampleSize {
+		return nil, skerr.Fmt("invalid sample size range [%d, %d]", params.MinSampleSize, params.MaxSampleSize)
+	}
+	if params.Trials <= 0 {
+		return nil, skerr.Fmt("Trials must be positive, got %d", params.Trials)
+	}
+	if params.Percentile < 0 || params.Percentile > 100 {
+		return nil, skerr.Fmt("Percentile must be between 0 and 100, got %v", params.Percentile)
+	}
+	if params.Samples == nil || pValue == nil {
+		return nil, skerr.Fmt("Samples and pValue must not be nil")
+	}
+
+	r := rand.New(rand.NewSource(params.Seed))
+	var table [][]float64
+	for step := params.FirstMagnitudeStep; step <= params.LastMagnitudeStep; step++ {
+		magnitude := float64(step) / float64(params.MagnitudeStepsPerUnit)
+		var row []float64
+		for sampleSize := 1; sampleSize <= params.MaxSampleSize; sampleSize++ {
+			pValues := make([]float64, params.Trials)
+			for i := range pValues {
+				a, b := params.Samples(r, magnitude, sampleSize)
+				pValues[i] = pValue(a, b)
+			}
+			threshold := math.Round(percentile(pValues, params.Percentile)*10000) / 10000
+			row = append(row, threshold)
+			if sampleSize >= params.MinSampleSize && threshold < LowThreshold {
+				break
+			}
+		}
+		table = append(table, row)
+	}
+	return table, nil
+}
+
+// percentile returns the p-th percentile of the given values, interpolating linearly between the
+// closest ranks. The values are sorted in place.
+func percentile(values []float64, p float64) float64 {
+	sort.Float64s(values)
+	rank := p / 100 * float64(len(values)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+	return values[lower] + (values[upper]-values[lower])*(rank-float64(lower))
+}
+
+func performanceSamples(r *rand.Rand, magnitude float64, sampleSize int) ([]float64, []float64) {
+	a := make([]float64, sampleSize)
+	b := make([]float64, sampleSize)
+	for i := 0; i < sampleSize; i++ {
+		a[i] = r.NormFloat64() / normalIQR
+		b[i] = r.NormFloat64()/normalIQR + magnitude
+	}
+	return a, b
+}
+
+func functionalSamples(r *rand.Rand, magnitude float64, sampleSize int) ([]float64, []float64) {
+	a := make([]float64, sampleSize)
+	b := make([]float64, sampleSize)
+	for i := 0; i < sampleSize; i++ {
+		if r.Float64() < magnitude {
+			b[i] = 1
+		}
+	}
+	return a, b
+}
@@ -0,0 +1,105 @@
+package thresholds
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakePValue returns a p-value that decreases with the sample size and the difference between the
+// means of the two samples, so that tests do not depend on a real statistical test.
+func fakePValue(a, b []float64) float64 {
+	var sumA, sumB float64
+	for i := range a {
+		sumA += a[i]
+		sumB += b[i]
+	}
+	diff := (sumB - sumA) / float64(len(a))
+	return 1 / (1 + diff*diff*float64(len(a)*len(a)))
+}
+
+func testParams() Params {
+	return Params{
+		MagnitudeStepsPerUnit: 10,
+		FirstMagnitudeStep:    5,
+		LastMagnitudeStep:     10,
+		MinSampleSize:         3,
+		MaxSampleSize:         50,
+		Trials:                100,
+		Percentile:            99,
+		Seed:                  42,
+		Samples:               performanceSamples,
+	}
+}
+
+func TestComputeHighThresholds_ValidParams_RowsEndBelowLowThreshold(t *testing.T) {
+	params := testParams()
+	table, err := ComputeHighThresholds(params, fakePValue)
+	require.NoError(t, err)
+	require.Len(t, table, 6)
+
+	for _, row := range table {
+		require.GreaterOrEqual(t, len(row), params.MinSampleSize)
+		if len(row) < params.MaxSampleSize {
+			assert.Less(t, row[len(row)-1], LowThreshold)
+			for _, threshold := range row[params.MinSampleSize-1 : len(row)-1] {
+				assert.GreaterOrEqual(t, threshold, LowThreshold)
+			}
+		}
+	}
+	// Larger magnitudes need fewer samples.
+	assert.Greater(t, len(table[0]), len(table[len(table)-1]))
+}
+
+func TestComputeHighThresholds_SameSeed_SameTable(t *testing.T) {
+	first, err := ComputeHighThresholds(testParams(), fakePValue)
+	require.NoError(t, err)
+	second, err := ComputeHighThresholds(testParams(), fakePValue)
+	require.NoError(t, err)
+	assert.Equal(t, first, second)
+
+	params := testParams()
+	params.Seed = 43
+	third, err := ComputeHighThresholds(params, fakePValue)
+	require.NoError(t, err)
+	assert.NotEqual(t, first, third)
+}
+
+func TestComputeHighThresholds_InvalidParams_ReturnsError(t *testing.T) {
+	for name, modify := range map[string]func(p *Params){
+		"no magnitude steps":    func(p *Params) { p.MagnitudeStepsPerUnit = 0 },
+		"empty magnitude range": func(p *Params) { p.LastMagnitudeStep = p.FirstMagnitudeStep - 1 },
+		"empty sample sizes":    func(p *Params) { p.MaxSampleSize = p.MinSampleSize - 1 },
+		"no trials":             func(p *Params) { p.Trials = 0 },
+		"invalid percentile":    func(p *Params) { p.Percentile = 101 },
+		"no sampler":            func(p *Params) { p.Samples = nil },
+	} {
+		t.Run(name, func(t *testing.T) {
+			params := testParams()
+			modify(&params)
+			_, err := ComputeHighThresholds(params, fakePValue)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestPercentile_InterpolatesBetweenRanks(t *testing.T) {
+	assert.Equal(t, 1.0, percentile([]float64{3, 1, 2}, 0))
+	assert.Equal(t, 2.0, percentile([]float64{3, 1, 2}, 50))
+	assert.Equal(t, 3.0, percentile([]float64{3, 1, 2}, 100))
+	assert.InDelta(t, 2.5, percentile([]float64{1, 2, 3, 4}, 50), 1e-9)
+}
+
+func TestFunctionalSamples_FailureRateMatchesMagnitude(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	a, b := functionalSamples(r, 0.5, 10000)
+	var failuresA, failuresB float64
+	for i := range a {
+		failuresA += a[i]
+		failuresB += b[i]
+	}
+	assert.Zero(t, failuresA)
+	assert.InDelta(t, 0.5, failuresB/10000, 0.02)
+}
@@ -0,0 +1,97 @@
+// This executable regenerates the tables of high thresholds used by the thresholds package via
+// thresholds.ComputeHighThresholds. It is invoked via "go generate" from the thresholds package.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"os"
+	"strings"
+
+	"go.skia.org/infra/go/sklog"
+	"go.skia.org/infra/pinpoint/go/compare"
+	"go.skia.org/infra/pinpoint/go/compare/thresholds"
+)
+
+// valuesPerLine is the number of thresholds written on each line of the generated tables.
+const valuesPerLine = 10
+
+func main() {
+	output := flag.String("output", "tables.go", "Path to the generated Go file.")
+	trials := flag.Int("trials", 0, "If positive, overrides the number of trials used to estimate each threshold.")
+	seed := flag.Int64("seed", 0, "If non-zero, overrides the seed of the random number generator.")
+	flag.Parse()
+
+	functionalParams, performanceParams := thresholds.FunctionalParams, thresholds.PerformanceParams
+	for _, params := range []*thresholds.Params{&functionalParams, &performanceParams} {
+		if *trials > 0 {
+			params.Trials = *trials
+		}
+		if *seed != 0 {
+			params.Seed = *seed
+		}
+	}
+
+	functional, err := thresholds.ComputeHighThresholds(functionalParams, compare.MannWhitneyU)
+	if err != nil {
+		sklog.Fatalf("Could not compute functional thresholds: %s", err)
+	}
+	performance, err := thresholds.ComputeHighThresholds(performanceParams, compare.MannWhitneyU)
+	if err != nil {
+		sklog.Fatalf("Could not compute performance thresholds: %s", err)
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by \"go run ./generate %s\"; DO NOT EDIT.\n\n", strings.Join(os.Args[1:], " "))
+	b.WriteString("package thresholds\n\n")
+	b.WriteString("const (\n")
+	fmt.Fprintf(&b, "functionalMagnitudeStepsPerUnit = %d\n", functionalParams.MagnitudeStepsPerUnit)
+	fmt.Fprintf(&b, "functionalFirstMagnitudeStep = %d\n", functionalParams.FirstMagnitudeStep)
+	fmt.Fprintf(&b, "performanceMagnitudeStepsPerUnit = %d\n", performanceParams.MagnitudeStepsPerUnit)
+	fmt.Fprintf(&b, "performanceFirstMagnitudeStep = %d\n", performanceParams.FirstMagnitudeStep)
+	b.WriteString(")\n\n")
+	writeTable(&b, "highThresholdsFunctional", "functional", functionalParams, functional)
+	writeTable(&b, "highThresholdsPerformance", "performance", performanceParams, performance)
+
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		sklog.Fatalf("Could not format generated code: %s", err)
+	}
+	if err := os.WriteFile(*output, src, 0644); err != nil {
+		sklog.Fatalf("Could not write %s: %s", *output, err)
+	}
+}
+
+// writeTable writes the given table of thresholds as a Go variable declaration.
+func writeTable(b *bytes.Buffer, name, analysis string, params thresholds.Params, table [][]float64) {
+	fmt.Fprintf(b, "// %s is the set of high thresholds used in %s analysis by normalized magnitude.\n", name, analysis)
+	fmt.Fprintf(b, "// Computed with %d trials per threshold at the %vth percentile, using seed %d.\n", params.Trials, params.Percentile, params.Seed)
+	b.WriteString("// The sample sizes start at 1.\n")
+	fmt.Fprintf(b, "var %s = thresholds{\n", name)
+	for i, row := range table {
+		magnitude := float64(params.FirstMagnitudeStep+i) / float64(params.MagnitudeStepsPerUnit)
+		fmt.Fprintf(b, "// normalized magnitude: %v\n{\n", magnitude)
+		for j, threshold := range row {
+			b.WriteString(formatThreshold(threshold))
+			b.WriteString(",")
+			if (j+1)%valuesPerLine == 0 || j == len(row)-1 {
+				b.WriteString("\n")
+			} else {
+				b.WriteString(" ")
+			}
+		}
+		b.WriteString("},\n")
+	}
+	b.WriteString("}\n\n")
+}
+
+// formatThreshold formats a threshold in the same style as the original tables, e.g. "1.000" and
+// ".0809".
+func formatThreshold(threshold float64) string {
+	if threshold >= 1 {
+		return "1.000"
+	}
+	return strings.TrimPrefix(fmt.Sprintf("%.4f", threshold), "0")
+}
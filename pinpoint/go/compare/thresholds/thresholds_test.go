@@ -3,6 +3,8 @@ package thresholds
 import (
 	"fmt"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func TestLowThreshold(t *testing.T) {
@@ -82,3 +84,68 @@ func TestHighThresholds(t *testing.T) {
 		})
 	}
 }
+
+func TestLookupHighThresholdPerformance_RowMagnitudeAndSampleSize_ReturnsTableEntry(t *testing.T) {
+	lookup := LookupHighThresholdPerformance(1.0, 5)
+	assert.Equal(t, highThresholdsPerformance[7][4], lookup.HighThreshold)
+	assert.Equal(t, 7, lookup.MagnitudeIndex)
+	assert.InDelta(t, 1.0, lookup.Magnitude, 1e-9)
+	assert.Equal(t, 5.0, lookup.SampleSize)
+	assert.False(t, lookup.MagnitudeClamped)
+	assert.False(t, lookup.SampleSizeClamped)
+}
+
+func TestLookupHighThresholdPerformance_BetweenRows_InterpolatesLinearly(t *testing.T) {
+	lookup := LookupHighThresholdPerformance(1.05, 5)
+	expected := (highThresholdsPerformance[7][4] + highThresholdsPerformance[8][4]) / 2
+	assert.InDelta(t, expected, lookup.HighThreshold, 1e-9)
+	assert.Equal(t, 7, lookup.MagnitudeIndex)
+	assert.InDelta(t, 1.05, lookup.Magnitude, 1e-9)
+	assert.False(t, lookup.MagnitudeClamped)
+
+	// Thresholds vary smoothly with the magnitude.
+	below := LookupHighThresholdPerformance(1.0999, 5).HighThreshold
+	above := LookupHighThresholdPerformance(1.1, 5).HighThreshold
+	assert.InDelta(t, above, below, 1e-4)
+}
+
+func TestLookupHighThresholdPerformance_FractionalSampleSize_InterpolatesLinearly(t *testing.T) {
+	lookup := LookupHighThresholdPerformance(1.0, 4.25)
+	expected := 0.75*highThresholdsPerformance[7][3] + 0.25*highThresholdsPerformance[7][4]
+	assert.InDelta(t, expected, lookup.HighThreshold, 1e-9)
+	assert.Equal(t, 4.25, lookup.SampleSize)
+	assert.False(t, lookup.SampleSizeClamped)
+}
+
+func TestLookupHighThresholdPerformance_OutsideTable_Clamped(t *testing.T) {
+	lookup := LookupHighThresholdPerformance(10, 50)
+	last := highThresholdsPerformance[len(highThresholdsPerformance)-1]
+	assert.Equal(t, last[len(last)-1], lookup.HighThreshold)
+	assert.Equal(t, len(highThresholdsPerformance)-1, lookup.MagnitudeIndex)
+	assert.InDelta(t, 4.0, lookup.Magnitude, 1e-9)
+	assert.Equal(t, 10.0, lookup.SampleSize)
+	assert.True(t, lookup.MagnitudeClamped)
+	assert.True(t, lookup.SampleSizeClamped)
+
+	lookup = LookupHighThresholdPerformance(0.1, 1)
+	assert.Equal(t, highThresholdsPerformance[0][0], lookup.HighThreshold)
+	assert.Equal(t, 0, lookup.MagnitudeIndex)
+	assert.InDelta(t, 0.3, lookup.Magnitude, 1e-9)
+	assert.True(t, lookup.MagnitudeClamped)
+	assert.False(t, lookup.SampleSizeClamped)
+
+	// Rows have different lengths, so the sample size may only be clamped in one of them.
+	lookup = LookupHighThresholdPerformance(1.15, 30)
+	assert.Equal(t, 25.0, lookup.SampleSize)
+	assert.True(t, lookup.SampleSizeClamped)
+}
+
+func TestLookupHighThresholdFunctional_RowMagnitudeAndSampleSize_ReturnsTableEntry(t *testing.T) {
+	lookup := LookupHighThresholdFunctional(0.5, 20)
+	assert.Equal(t, 0.0195, lookup.HighThreshold)
+	assert.Equal(t, 4, lookup.MagnitudeIndex)
+	assert.InDelta(t, 0.5, lookup.Magnitude, 1e-9)
+	assert.Equal(t, 20.0, lookup.SampleSize)
+	assert.False(t, lookup.MagnitudeClamped)
+	assert.False(t, lookup.SampleSizeClamped)
+}
@@ -0,0 +1,238 @@
+package pinpoint
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/go/util"
+	"go.skia.org/infra/pinpoint/go/bot_configs"
+	"go.skia.org/infra/pinpoint/go/compare"
+	"go.skia.org/infra/pinpoint/go/midpoint"
+	"go.skia.org/infra/pinpoint/go/read_values"
+
+	bpb "go.chromium.org/luci/buildbucket/proto"
+	swarmingV1 "go.chromium.org/luci/common/api/swarming/swarming/v1"
+)
+
+// aggregationMethods lists the aggregation methods by their value, so that
+// they can be restored from checkpoints.
+var aggregationMethods = []read_values.AggDataMethodEnum{
+	read_values.Count,
+	read_values.Max,
+	read_values.Mean,
+	read_values.Min,
+	read_values.Std,
+	read_values.Sum,
+}
+
+// Checkpoint is the state of a bisection after an iteration, from which the
+// bisection can be resumed.
+type Checkpoint struct {
+	// JobID is the unique job ID.
+	JobID string
+	// Request is the request that started the job.
+	Request CheckpointRequest
+	// Commits is the state of every commit in the job, in the order they landed.
+	Commits []CommitCheckpoint
+	// Culprits found so far.
+	Culprits []string
+	// Updated is when the checkpoint was taken.
+	Updated time.Time
+}
+
+// CheckpointRequest is a PinpointRunRequest in a serializable form.
+type CheckpointRequest struct {
+	Device      string
+	Benchmark   string
+	Story       string
+	Chart       string
+	Magnitude   float64
+	StartCommit string
+	EndCommit   string
+	Thresholds  compare.Thresholds
+	// AggregationMethod is the value of the aggregation method, or nil if
+	// values aren't aggregated.
+	AggregationMethod *int
+}
+
+// CommitCheckpoint is the state of a commit in a bisection.
+type CommitCheckpoint struct {
+	Commit *midpoint.Commit
+	// BuildID is the buildbucket ID of the build, or 0 if it hasn't started.
+	BuildID     int64
+	BuildStatus bpb.Status
+	BuildCAS    *swarmingV1.SwarmingRpcsCASReference
+	// HasTests is true if benchmark runs have been requested for the commit.
+	HasTests       bool
+	Tasks          []string
+	States         []string
+	TestCASOutputs []*swarmingV1.SwarmingRpcsCASReference
+	TestsRunning   bool
+	// Values are the benchmark measurements compared between commits.
+	Values []float64
+}
+
+// CheckpointStore persists checkpoints of bisections, so that they can be
+// resumed after a restart.
+type CheckpointStore interface {
+	// Save stores the checkpoint, replacing any previous checkpoint of the
+	// same job.
+	Save(ctx context.Context, cp *Checkpoint) error
+
+	// Load returns the latest checkpoint of the job, or nil if there is none.
+	Load(ctx context.Context, jobID string) (*Checkpoint, error)
+}
+
+// fileCheckpointStore implements CheckpointStore by writing each checkpoint
+// as a JSON file in a directory.
+type fileCheckpointStore struct {
+	dir string
+}
+
+// NewFileCheckpointStore returns a CheckpointStore that writes checkpoints to
+// the given directory.
+func NewFileCheckpointStore(dir string) *fileCheckpointStore {
+	return &fileCheckpointStore{
+		dir: dir,
+	}
+}
+
+func (s *fileCheckpointStore) path(jobID string) string {
+	return filepath.Join(s.dir, jobID+".json")
+}
+
+// Save implements CheckpointStore.
+func (s *fileCheckpointStore) Save(ctx context.Context, cp *Checkpoint) error {
+	if cp.JobID == "" {
+		return skerr.Fmt("cannot save a checkpoint without a job ID")
+	}
+	err := util.WithWriteFile(s.path(cp.JobID), func(w io.Writer) error {
+		return json.NewEncoder(w).Encode(cp)
+	})
+	return skerr.Wrapf(err, "could not save checkpoint of job %s", cp.JobID)
+}
+
+// Load implements CheckpointStore.
+func (s *fileCheckpointStore) Load(ctx context.Context, jobID string) (*Checkpoint, error) {
+	b, err := os.ReadFile(s.path(jobID))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, skerr.Wrapf(err, "could not read checkpoint of job %s", jobID)
+	}
+	cp := &Checkpoint{}
+	if err := json.Unmarshal(b, cp); err != nil {
+		return nil, skerr.Wrapf(err, "could not decode checkpoint of job %s", jobID)
+	}
+	return cp, nil
+}
+
+// newCheckpointRequest converts the request into its serializable form.
+func newCheckpointRequest(req PinpointRunRequest) CheckpointRequest {
+	cr := CheckpointRequest{
+		Device:      req.Device,
+		Benchmark:   req.Benchmark,
+		Story:       req.Story,
+		Chart:       req.Chart,
+		Magnitude:   req.Magnitude,
+		StartCommit: req.StartCommit,
+		EndCommit:   req.EndCommit,
+		Thresholds:  req.Thresholds,
+	}
+	if req.AggregationMethod != nil {
+		agg := int(req.AggregationMethod.AggDataMethod())
+		cr.AggregationMethod = &agg
+	}
+	return cr
+}
+
+// runRequest converts the checkpointed request back into a PinpointRunRequest.
+func (cr CheckpointRequest) runRequest() (PinpointRunRequest, error) {
+	req := PinpointRunRequest{
+		Device:      cr.Device,
+		Benchmark:   cr.Benchmark,
+		Story:       cr.Story,
+		Chart:       cr.Chart,
+		Magnitude:   cr.Magnitude,
+		StartCommit: cr.StartCommit,
+		EndCommit:   cr.EndCommit,
+		Thresholds:  cr.Thresholds,
+	}
+	if cr.AggregationMethod != nil {
+		agg := *cr.AggregationMethod
+		if agg < 0 || agg >= len(aggregationMethods) {
+			return req, skerr.Fmt("unknown aggregation method %d", agg)
+		}
+		req.AggregationMethod = aggregationMethods[agg]
+	}
+	return req, nil
+}
+
+// newCheckpoint captures the state of the job.
+func newCheckpoint(jobID string, req PinpointRunRequest, cdl commitDataList, culprits []string) *Checkpoint {
+	cp := &Checkpoint{
+		JobID:    jobID,
+		Request:  newCheckpointRequest(req),
+		Culprits: culprits,
+		Updated:  time.Now(),
+	}
+	for _, c := range cdl.commits {
+		cc := CommitCheckpoint{
+			Commit: c.commit,
+			Values: c.values,
+		}
+		if c.build != nil {
+			cc.BuildID = c.build.buildID
+			cc.BuildStatus = c.build.buildStatus
+			cc.BuildCAS = c.build.buildCAS
+		}
+		if c.tests != nil {
+			cc.HasTests = true
+			cc.Tasks = c.tests.tasks
+			cc.States = c.tests.states
+			cc.TestCASOutputs = c.tests.casOutputs
+			cc.TestsRunning = c.tests.isRunning
+		}
+		cp.Commits = append(cp.Commits, cc)
+	}
+	return cp
+}
+
+// commitDataList reconstructs the state of the job from the checkpoint.
+func (cp *Checkpoint) commitDataList(cfg bot_configs.BotConfig, target string, req PinpointRunRequest) commitDataList {
+	var cdl commitDataList
+	for _, cc := range cp.Commits {
+		c := &commitData{
+			commit: cc.Commit,
+			values: cc.Values,
+		}
+		// Builds that weren't started are started again.
+		if cc.BuildID != 0 {
+			c.build = &buildMetadata{
+				buildID:     cc.BuildID,
+				buildStatus: cc.BuildStatus,
+				buildCAS:    cc.BuildCAS,
+			}
+		}
+		if cc.HasTests {
+			c.tests = &testMetadata{
+				tasks:      cc.Tasks,
+				states:     cc.States,
+				casOutputs: cc.TestCASOutputs,
+				isRunning:  cc.TestsRunning,
+			}
+			if c.build != nil {
+				c.tests.req = c.createRunBenchmarkRequest(cp.JobID, cfg, target, req)
+			}
+		}
+		cdl.commits = append(cdl.commits, c)
+	}
+	return cdl
+}
@@ -0,0 +1,167 @@
+package pinpoint
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	bpb "go.chromium.org/luci/buildbucket/proto"
+	swarmingV1 "go.chromium.org/luci/common/api/swarming/swarming/v1"
+
+	"go.skia.org/infra/pinpoint/go/bot_configs"
+	"go.skia.org/infra/pinpoint/go/compare"
+	"go.skia.org/infra/pinpoint/go/midpoint"
+	"go.skia.org/infra/pinpoint/go/read_values"
+)
+
+func checkpointTestRequest() PinpointRunRequest {
+	return PinpointRunRequest{
+		Device:            "linux-perf",
+		Benchmark:         "speedometer2",
+		Story:             "Speedometer2",
+		Chart:             "RunsPerMinute",
+		Magnitude:         1.5,
+		StartCommit:       "start",
+		EndCommit:         "end",
+		AggregationMethod: read_values.Mean,
+		Thresholds: compare.Thresholds{
+			LowThreshold: 0.05,
+			Comparisons:  2,
+		},
+	}
+}
+
+func checkpointTestCommits() commitDataList {
+	cas := &swarmingV1.SwarmingRpcsCASReference{
+		CasInstance: "instance",
+		Digest: &swarmingV1.SwarmingRpcsDigest{
+			Hash:      "hash",
+			SizeBytes: 123,
+		},
+	}
+	return commitDataList{
+		commits: []*commitData{
+			{
+				commit: &midpoint.Commit{GitHash: "start", RepositoryUrl: chromiumSrcGit},
+				build: &buildMetadata{
+					buildID:     1,
+					buildStatus: bpb.Status_SUCCESS,
+					buildCAS:    cas,
+				},
+				tests: &testMetadata{
+					tasks:      []string{"task1", "task2"},
+					states:     []string{"COMPLETED", "COMPLETED"},
+					casOutputs: []*swarmingV1.SwarmingRpcsCASReference{cas, cas},
+				},
+				values: []float64{1, 2},
+			},
+			{
+				commit: &midpoint.Commit{GitHash: "mid", RepositoryUrl: chromiumSrcGit},
+				build: &buildMetadata{
+					buildID:     2,
+					buildStatus: bpb.Status_STARTED,
+				},
+			},
+			{
+				commit: &midpoint.Commit{GitHash: "end", RepositoryUrl: chromiumSrcGit},
+			},
+		},
+	}
+}
+
+func TestCheckpoint_RoundTrip_RestoresState(t *testing.T) {
+	req := checkpointTestRequest()
+	cdl := checkpointTestCommits()
+	cp := newCheckpoint("job", req, cdl, []string{"culprit"})
+
+	b, err := json.Marshal(cp)
+	require.NoError(t, err)
+	restored := &Checkpoint{}
+	require.NoError(t, json.Unmarshal(b, restored))
+
+	actualReq, err := restored.Request.runRequest()
+	require.NoError(t, err)
+	assert.Equal(t, req, actualReq)
+	assert.Equal(t, []string{"culprit"}, restored.Culprits)
+
+	cfg, err := bot_configs.GetBotConfig(req.Device, false)
+	require.NoError(t, err)
+	actual := restored.commitDataList(cfg, "performance_test_suite", actualReq)
+	require.Len(t, actual.commits, 3)
+	for i, c := range actual.commits {
+		expected := cdl.commits[i]
+		assert.Equal(t, expected.commit, c.commit)
+		assert.Equal(t, expected.build, c.build)
+		assert.Equal(t, expected.values, c.values)
+	}
+
+	tests := actual.commits[0].tests
+	require.NotNil(t, tests)
+	assert.Equal(t, cdl.commits[0].tests.tasks, tests.tasks)
+	assert.Equal(t, cdl.commits[0].tests.states, tests.states)
+	assert.Equal(t, cdl.commits[0].tests.casOutputs, tests.casOutputs)
+	// Benchmark requests are recreated so that more runs can be scheduled.
+	assert.NotNil(t, tests.req)
+	assert.Nil(t, actual.commits[1].tests)
+}
+
+func TestCheckpointRequest_NoAggregation_RoundTrips(t *testing.T) {
+	req := checkpointTestRequest()
+	req.AggregationMethod = nil
+
+	actual, err := newCheckpointRequest(req).runRequest()
+	require.NoError(t, err)
+	assert.Equal(t, req, actual)
+}
+
+func TestCheckpointRequest_UnknownAggregation_ReturnsError(t *testing.T) {
+	agg := len(aggregationMethods)
+	_, err := CheckpointRequest{AggregationMethod: &agg}.runRequest()
+	assert.Error(t, err)
+}
+
+func TestAggregationMethods_IndexedByValue(t *testing.T) {
+	for i, agg := range aggregationMethods {
+		assert.Equal(t, i, int(agg.AggDataMethod()))
+	}
+}
+
+func TestFileCheckpointStore_SaveAndLoad(t *testing.T) {
+	ctx := context.Background()
+	s := NewFileCheckpointStore(t.TempDir())
+
+	actual, err := s.Load(ctx, "job")
+	require.NoError(t, err)
+	assert.Nil(t, actual)
+
+	cp := newCheckpoint("job", checkpointTestRequest(), checkpointTestCommits(), []string{})
+	require.NoError(t, s.Save(ctx, cp))
+	cp.Culprits = []string{"culprit"}
+	require.NoError(t, s.Save(ctx, cp))
+
+	actual, err = s.Load(ctx, "job")
+	require.NoError(t, err)
+	require.NotNil(t, actual)
+	assert.Equal(t, "job", actual.JobID)
+	assert.Equal(t, []string{"culprit"}, actual.Culprits)
+	assert.Len(t, actual.Commits, 3)
+}
+
+func TestFileCheckpointStore_NoJobID_ReturnsError(t *testing.T) {
+	s := NewFileCheckpointStore(t.TempDir())
+	assert.Error(t, s.Save(context.Background(), &Checkpoint{}))
+}
+
+func TestResume_NoCheckpointStore_ReturnsError(t *testing.T) {
+	pp := &pinpointHandlerImpl{}
+	_, err := pp.Resume(context.Background(), "job")
+	assert.Error(t, err)
+}
+
+func TestResume_NoCheckpoint_ReturnsError(t *testing.T) {
+	pp := (&pinpointHandlerImpl{}).WithCheckpointStore(NewFileCheckpointStore(t.TempDir()))
+	_, err := pp.Resume(context.Background(), "job")
+	assert.Error(t, err)
+}
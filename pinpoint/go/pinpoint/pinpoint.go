@@ -44,6 +44,10 @@ type PinpointHandler interface {
 	// the workflow and not wait on tasks to finish.
 	// TODO(sunxiaodi@): implement Run
 	Run(ctx context.Context, req PinpointRunRequest, jobID string) (*PinpointRunResponse, error)
+
+	// Resume continues a job from its latest checkpoint, e.g. after a restart.
+	// It requires a CheckpointStore, see WithCheckpointStore.
+	Resume(ctx context.Context, jobID string) (*PinpointRunResponse, error)
 }
 
 // PinpointRunRequest is the request arguments to run a Pinpoint job.
@@ -68,6 +72,9 @@ type PinpointRunRequest struct {
 	StartCommit string
 	// EndCommit is the experimental or end commit hash to run
 	EndCommit string
+	// Thresholds configures the significance level used to compare commits.
+	// Only used in bisections. The zero value uses the default significance level.
+	Thresholds compare.Thresholds
 }
 
 type PinpointRunResponse struct {
@@ -83,6 +90,10 @@ type PinpointRunResponse struct {
 // pinpointJobImpl implements the PinpointJob interface.
 type pinpointHandlerImpl struct {
 	client *http.Client
+
+	// checkpoints persists the state of jobs after every iteration. If nil,
+	// jobs cannot be resumed.
+	checkpoints CheckpointStore
 }
 
 // buildMetadata tracks relevant build Chrome metadata
@@ -131,6 +142,13 @@ func New(ctx context.Context) (*pinpointHandlerImpl, error) {
 	}, nil
 }
 
+// WithCheckpointStore makes the handler checkpoint jobs to the given store
+// after every iteration, so that they can be resumed.
+func (pp *pinpointHandlerImpl) WithCheckpointStore(s CheckpointStore) *pinpointHandlerImpl {
+	pp.checkpoints = s
+	return pp
+}
+
 // Run implements the pinpointJobImpl interface
 func (pp *pinpointHandlerImpl) Run(ctx context.Context, req PinpointRunRequest, jobID string) (
 	*PinpointRunResponse, error) {
@@ -141,9 +159,83 @@ func (pp *pinpointHandlerImpl) Run(ctx context.Context, req PinpointRunRequest,
 	if err != nil {
 		return nil, skerr.Wrapf(err, "Could not validate request inputs")
 	}
+
+	cdl := commitDataList{
+		commits: []*commitData{
+			{
+				commit: &midpoint.Commit{
+					GitHash:       req.StartCommit,
+					RepositoryUrl: chromiumSrcGit,
+				},
+			},
+			{
+				commit: &midpoint.Commit{
+					GitHash:       req.EndCommit,
+					RepositoryUrl: chromiumSrcGit,
+				},
+			},
+		},
+	}
+	return pp.run(ctx, jobID, req, cdl, []string{})
+}
+
+// Resume implements the pinpointJobImpl interface
+func (pp *pinpointHandlerImpl) Resume(ctx context.Context, jobID string) (*PinpointRunResponse, error) {
+	if pp.checkpoints == nil {
+		return nil, skerr.Fmt("Cannot resume job %s without a checkpoint store", jobID)
+	}
+	cp, err := pp.checkpoints.Load(ctx, jobID)
+	if err != nil {
+		return nil, skerr.Wrapf(err, "Could not load checkpoint of job %s", jobID)
+	}
+	if cp == nil {
+		return nil, skerr.Fmt("No checkpoint found for job %s", jobID)
+	}
+	req, err := cp.Request.runRequest()
+	if err != nil {
+		return nil, skerr.Wrapf(err, "Could not restore request of job %s", jobID)
+	}
+	if err := pp.validateRunRequest(req); err != nil {
+		return nil, skerr.Wrapf(err, "Could not validate request inputs")
+	}
+	cfg, target, err := jobConfig(req)
+	if err != nil {
+		return nil, err
+	}
+	sklog.Infof("Resuming job %s from checkpoint taken at %s", jobID, cp.Updated)
+	return pp.run(ctx, jobID, req, cp.commitDataList(cfg, target, req), cp.Culprits)
+}
+
+// jobConfig returns the bot configuration and isolate target of the request.
+func jobConfig(req PinpointRunRequest) (bot_configs.BotConfig, string, error) {
 	cfg, err := bot_configs.GetBotConfig(req.Device, false)
 	if err != nil {
-		return nil, skerr.Wrapf(err, "Device %s not allowed in bot configurations", req.Device)
+		return cfg, "", skerr.Wrapf(err, "Device %s not allowed in bot configurations", req.Device)
+	}
+	target, err := bot_configs.GetIsolateTarget(req.Device, req.Benchmark)
+	if err != nil {
+		return cfg, "", skerr.Wrapf(err, "could not get isolate target with device %s and benchmark %s", req.Device, req.Benchmark)
+	}
+	return cfg, target, nil
+}
+
+// saveCheckpoint checkpoints the job if there is a checkpoint store.
+func (pp *pinpointHandlerImpl) saveCheckpoint(ctx context.Context, jobID string, req PinpointRunRequest, cdl commitDataList, culprits []string) {
+	if pp.checkpoints == nil {
+		return
+	}
+	// A missed checkpoint only means that more work is redone on resume.
+	if err := pp.checkpoints.Save(ctx, newCheckpoint(jobID, req, cdl, culprits)); err != nil {
+		sklog.Errorf("Could not checkpoint job %s: %s", jobID, err)
+	}
+}
+
+// run executes the job from the given state until it completes.
+func (pp *pinpointHandlerImpl) run(ctx context.Context, jobID string, req PinpointRunRequest, cdl commitDataList, culprits []string) (
+	*PinpointRunResponse, error) {
+	cfg, target, err := jobConfig(req)
+	if err != nil {
+		return nil, err
 	}
 
 	bc, err := build_chrome.New(ctx)
@@ -165,29 +257,8 @@ func (pp *pinpointHandlerImpl) Run(ctx context.Context, req PinpointRunRequest,
 
 	resp := &PinpointRunResponse{
 		JobID:    jobID,
-		Culprits: []string{},
-	}
-
-	target, err := bot_configs.GetIsolateTarget(req.Device, req.Benchmark)
-	if err != nil {
-		return nil, skerr.Wrapf(err, "could not get isolate target with device %s and benchmark %s", req.Device, req.Benchmark)
-	}
-
-	cdl := commitDataList{
-		commits: []*commitData{
-			{
-				commit: &midpoint.Commit{
-					GitHash:       req.StartCommit,
-					RepositoryUrl: chromiumSrcGit,
-				},
-			},
-			{
-				commit: &midpoint.Commit{
-					GitHash:       req.EndCommit,
-					RepositoryUrl: chromiumSrcGit,
-				},
-			},
-		},
+		Commits:  cdl.commits,
+		Culprits: culprits,
 	}
 
 	// execute Pinpoint job
@@ -196,7 +267,11 @@ func (pp *pinpointHandlerImpl) Run(ctx context.Context, req PinpointRunRequest,
 		// start builds that have not been scheduled
 		for _, c := range cdl.commits {
 			if c.build == nil {
-				buildID, err := bc.SearchOrBuild(ctx, jobID, c.commit.GitHash, req.Device, nil, nil)
+				buildID, err := bc.SearchOrBuild(ctx, build_chrome.BuildParams{
+					PinpointJobID: jobID,
+					Commit:        c.commit.GitHash,
+					Device:        req.Device,
+				})
 				if err != nil {
 					return resp, skerr.Wrapf(err, "could not kick off build for commit %s", c.commit.GitHash)
 				}
@@ -259,7 +334,7 @@ func (pp *pinpointHandlerImpl) Run(ctx context.Context, req PinpointRunRequest,
 			// i would need to shift one. Doing it this way avoids any index shifting
 			left, right := i, i+1
 			sklog.Debugf("compare right [%d] vs [%d]", left, right)
-			res, err := cdl.compareNeighbor(left, right, req.Magnitude)
+			res, err := cdl.compareNeighbor(left, right, req.Magnitude, req.Thresholds)
 			if err != nil {
 				return resp, skerr.Wrapf(err, "could not compare [%d] against right neighbor", left)
 			}
@@ -276,7 +351,7 @@ func (pp *pinpointHandlerImpl) Run(ctx context.Context, req PinpointRunRequest,
 			// compare left
 			left, right = i-1, i
 			sklog.Debugf("compare left [%d] vs [%d]", left, right)
-			res, err = cdl.compareNeighbor(left, right, req.Magnitude)
+			res, err = cdl.compareNeighbor(left, right, req.Magnitude, req.Thresholds)
 			if err != nil {
 				return resp, skerr.Wrapf(err, "could not compare [%d] against left neighbor", right)
 			}
@@ -294,6 +369,7 @@ func (pp *pinpointHandlerImpl) Run(ctx context.Context, req PinpointRunRequest,
 		}
 		resp.Commits = cdl.commits
 		sklog.Debugf("current culprit list %v", resp.Culprits)
+		pp.saveCheckpoint(ctx, jobID, req, cdl, resp.Culprits)
 		time.Sleep(10 * time.Second)
 	}
 	return resp, nil
@@ -320,6 +396,9 @@ func (pp *pinpointHandlerImpl) validateRunRequest(req PinpointRunRequest) error
 	if req.Chart == "" {
 		return skerr.Fmt(missingRequiredParamTemplate, "chart")
 	}
+	if err := req.Thresholds.Validate(); err != nil {
+		return skerr.Wrapf(err, "Invalid thresholds")
+	}
 	return nil
 }
 
@@ -351,7 +430,7 @@ func (cdl commitDataList) statusCheck() {
 
 // pollBuild checks the build status of every commit in the commitQ
 // returns upon finding the first build that was running and finishes
-func (cdl commitDataList) pollBuild(ctx context.Context, bc build_chrome.BuildChromeClient) (
+func (cdl commitDataList) pollBuild(ctx context.Context, bc build_chrome.BuildClient) (
 	*commitData, error) {
 	for _, c := range cdl.commits {
 		if c.build == nil || c.build.buildID == 0 {
@@ -483,7 +562,7 @@ func (c *commitData) getValues(ctx context.Context, rc *rbeclient.Client, req Pi
 // compareNeighbor takes two commits (left and right) and compares
 // their values against each other to see if they are statistically
 // significantly different.
-func (cdl commitDataList) compareNeighbor(left, right int, rawDiff float64) (*compare.CompareResults, error) {
+func (cdl commitDataList) compareNeighbor(left, right int, rawDiff float64, t compare.Thresholds) (*compare.CompareResults, error) {
 	if left >= right {
 		return nil, skerr.Fmt("left index %d is >= right index %d", left, right)
 	}
@@ -509,7 +588,12 @@ func (cdl commitDataList) compareNeighbor(left, right int, rawDiff float64) (*co
 	normDiff := math.Abs(rawDiff / iqr)
 	attemptCount := len(all_values) / 2
 
-	return compare.ComparePerformance(cdl.commits[left].values, cdl.commits[right].values, attemptCount, normDiff)
+	res, err := t.ComparePerformance(cdl.commits[left].values, cdl.commits[right].values, attemptCount, normDiff)
+	if err != nil {
+		return nil, err
+	}
+	sklog.Debugf("compared commits [%d] and [%d]: %s", left, right, res.Decision)
+	return res, nil
 }
 
 // notComparable checks if a commit is still waiting on something to finish
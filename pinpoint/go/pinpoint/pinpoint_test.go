@@ -183,7 +183,7 @@ func TestShouldContinue(t *testing.T) {
 func TestPollBuild(t *testing.T) {
 	Convey(`OK`, t, func() {
 		ctx := context.Background()
-		mbc := mocks.NewBuildChromeClient(t)
+		mbc := mocks.NewBuildClient(t)
 		Convey(`When one build finishes`, func() {
 			cdl := commitDataList{
 				commits: []*commitData{
@@ -276,7 +276,7 @@ func TestPollBuild(t *testing.T) {
 	Convey(`Error`, t, func() {
 		Convey(`When no valid builds to poll`, func() {
 			ctx := context.Background()
-			mbc := mocks.NewBuildChromeClient(t)
+			mbc := mocks.NewBuildClient(t)
 			cdl := commitDataList{
 				commits: []*commitData{
 					{
@@ -294,7 +294,7 @@ func TestPollBuild(t *testing.T) {
 		})
 		Convey(`When client fails GetStatus`, func() {
 			ctx := context.Background()
-			mbc := mocks.NewBuildChromeClient(t)
+			mbc := mocks.NewBuildClient(t)
 			cdl := commitDataList{
 				commits: []*commitData{
 					{
@@ -629,7 +629,7 @@ func TestCompareNeighbor(t *testing.T) {
 					},
 				},
 			}
-			res, err := cdl.compareNeighbor(0, 1, 0.0)
+			res, err := cdl.compareNeighbor(0, 1, 0.0, compare.Thresholds{})
 			So(err, ShouldBeNil)
 			So(res, ShouldBeNil)
 		})
@@ -648,15 +648,15 @@ func TestCompareNeighbor(t *testing.T) {
 			},
 		}
 		Convey(`Return nil when left or right is out of bounds`, func() {
-			res, err := cdl.compareNeighbor(0, 2, 0.0)
+			res, err := cdl.compareNeighbor(0, 2, 0.0, compare.Thresholds{})
 			So(err, ShouldBeNil)
 			So(res, ShouldBeNil)
-			res, err = cdl.compareNeighbor(-1, 1, 0.0)
+			res, err = cdl.compareNeighbor(-1, 1, 0.0, compare.Thresholds{})
 			So(err, ShouldBeNil)
 			So(res, ShouldBeNil)
 		})
 		Convey(`Return result when comparison criteria is met`, func() {
-			res, err := cdl.compareNeighbor(0, 1, 0.0)
+			res, err := cdl.compareNeighbor(0, 1, 0.0, compare.Thresholds{})
 			So(err, ShouldBeNil)
 			So(res.Verdict, ShouldEqual, compare.Different)
 		})
@@ -677,7 +677,7 @@ func TestCompareNeighbor(t *testing.T) {
 				},
 			},
 		}
-		res, err := cdl.compareNeighbor(1, 0, 0.0)
+		res, err := cdl.compareNeighbor(1, 0, 0.0, compare.Thresholds{})
 		So(err, ShouldNotBeNil)
 		So(res, ShouldBeNil)
 
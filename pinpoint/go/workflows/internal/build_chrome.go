@@ -9,6 +9,7 @@ import (
 	swarmingV1 "go.chromium.org/luci/common/api/swarming/swarming/v1"
 	"go.skia.org/infra/go/skerr"
 	"go.skia.org/infra/pinpoint/go/build_chrome"
+	"go.skia.org/infra/pinpoint/go/build_chrome/cache"
 	"go.skia.org/infra/pinpoint/go/workflows"
 	"go.temporal.io/sdk/activity"
 	"go.temporal.io/sdk/temporal"
@@ -17,8 +18,18 @@ import (
 
 // BuildChromeActivity wraps BuildChrome in Activities.
 type BuildChromeActivity struct {
+	// Cache stores the results of previous builds. If nil, builds are
+	// neither looked up nor recorded.
+	Cache cache.Cache
+
+	// Watcher delivers Buildbucket notifications of build status changes.
+	// If nil, the status of builds is polled.
+	Watcher *build_chrome.BuildWatcher
 }
 
+// heartbeatInterval is how often activities waiting on builds heartbeat.
+const heartbeatInterval = time.Minute
+
 // BuildChrome is a Workflow definition that builds Chrome.
 func BuildChrome(ctx workflow.Context, params workflows.BuildChromeParams) (*swarmingV1.SwarmingRpcsCASReference, error) {
 	ao := workflow.ActivityOptions{
@@ -38,7 +49,16 @@ func BuildChrome(ctx workflow.Context, params workflows.BuildChromeParams) (*swa
 	logger := workflow.GetLogger(ctx)
 
 	bca := &BuildChromeActivity{}
-	var buildID int
+	var cas *swarmingV1.SwarmingRpcsCASReference
+	// The cache is only an optimization, so failing to use it doesn't fail the build.
+	if err := workflow.ExecuteActivity(ctx, bca.GetCachedBuildActivity, params).Get(ctx, &cas); err != nil {
+		logger.Warn("Failed to wait for GetCachedBuildActivity:", err)
+	}
+	if cas != nil {
+		return cas, nil
+	}
+
+	var buildID int64
 	if err := workflow.ExecuteActivity(ctx, bca.SearchOrBuildActivity, params).Get(ctx, &buildID); err != nil {
 		logger.Error("Failed to wait for SearchOrBuildActivity:", err)
 		return nil, err
@@ -50,15 +70,56 @@ func BuildChrome(ctx workflow.Context, params workflows.BuildChromeParams) (*swa
 		return nil, err
 	}
 
-	var cas *swarmingV1.SwarmingRpcsCASReference
 	if err := workflow.ExecuteActivity(ctx, bca.RetrieveCASActivity, buildID, params.Target).Get(ctx, &cas); err != nil {
 		logger.Error("Failed to wait for RetrieveCASActivity:", err)
 		return nil, err
 	}
+
+	if err := workflow.ExecuteActivity(ctx, bca.CacheBuildActivity, params, buildID, cas).Get(ctx, nil); err != nil {
+		logger.Warn("Failed to wait for CacheBuildActivity:", err)
+	}
 	return cas, nil
 }
 
-// SearchOrBuildActivity wraps BuildChromeClient.SearchOrBuild
+// cacheKey returns the key of the build with the given parameters in cache.Cache.
+func cacheKey(params workflows.BuildChromeParams) string {
+	return cache.Key(params.Commit, params.Device, params.Builder, params.Deps, params.Patch)
+}
+
+// GetCachedBuildActivity looks up the CAS outputs of an identical build in the cache.
+// It returns nil if there is no cache or no such build.
+func (bca *BuildChromeActivity) GetCachedBuildActivity(ctx context.Context, params workflows.BuildChromeParams) (*swarmingV1.SwarmingRpcsCASReference, error) {
+	if bca.Cache == nil {
+		return nil, nil
+	}
+	logger := activity.GetLogger(ctx)
+
+	buildID, cas, err := bca.Cache.Get(ctx, cacheKey(params), params.Target)
+	if err != nil {
+		logger.Error("Failed to look up build in cache:", err)
+		return nil, err
+	}
+	if cas != nil {
+		logger.Info("Found cached build:", buildID)
+	}
+	return cas, nil
+}
+
+// CacheBuildActivity records the CAS outputs of a successful build in the cache.
+func (bca *BuildChromeActivity) CacheBuildActivity(ctx context.Context, params workflows.BuildChromeParams, buildID int64, cas *swarmingV1.SwarmingRpcsCASReference) error {
+	if bca.Cache == nil {
+		return nil
+	}
+	logger := activity.GetLogger(ctx)
+
+	if err := bca.Cache.Put(ctx, cacheKey(params), params.Target, buildID, cas); err != nil {
+		logger.Error("Failed to cache build:", err)
+		return err
+	}
+	return nil
+}
+
+// SearchOrBuildActivity wraps BuildClient.SearchOrBuild
 func (bca *BuildChromeActivity) SearchOrBuildActivity(ctx context.Context, params workflows.BuildChromeParams) (int64, error) {
 	logger := activity.GetLogger(ctx)
 
@@ -69,7 +130,14 @@ func (bca *BuildChromeActivity) SearchOrBuildActivity(ctx context.Context, param
 	}
 
 	activity.RecordHeartbeat(ctx, "kicking off the build.")
-	buildID, err := bc.SearchOrBuild(ctx, params.PinpointJobID, params.Commit, params.Device, params.Deps, params.Patch)
+	buildID, err := bc.SearchOrBuild(ctx, build_chrome.BuildParams{
+		PinpointJobID: params.PinpointJobID,
+		Commit:        params.Commit,
+		Device:        params.Device,
+		Builder:       params.Builder,
+		Deps:          params.Deps,
+		Patches:       params.Patch,
+	})
 	if err != nil {
 		logger.Error("Failed to build chrome:", err)
 		return 0, err
@@ -77,7 +145,7 @@ func (bca *BuildChromeActivity) SearchOrBuildActivity(ctx context.Context, param
 	return buildID, nil
 }
 
-// WaitBuildCompletionActivity wraps BuildChromeClient.GetStatus and waits until it is completed or errors.
+// WaitBuildCompletionActivity wraps BuildClient.WatchBuild and waits until it is completed or errors.
 func (bca *BuildChromeActivity) WaitBuildCompletionActivity(ctx context.Context, buildID int64) (bool, error) {
 	logger := activity.GetLogger(ctx)
 
@@ -86,30 +154,39 @@ func (bca *BuildChromeActivity) WaitBuildCompletionActivity(ctx context.Context,
 		logger.Error("Failed to new build_chrome:", err)
 		return false, err
 	}
-	failureRetries := 10
+	statuses, err := bc.WithWatcher(bca.Watcher).WatchBuild(ctx, buildID)
+	if err != nil {
+		logger.Error("Failed to watch build:", err)
+		return false, err
+	}
+
+	// Notifications may be minutes apart, so heartbeat independently of them.
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+	last := buildbucketpb.Status_STATUS_UNSPECIFIED
 	for {
 		select {
 		case <-ctx.Done():
 			return false, ctx.Err()
-		default:
-			status, err := bc.GetStatus(ctx, buildID)
-			if err != nil {
-				logger.Error("Failed to get build status:", err, "remaining retries:", failureRetries)
-				failureRetries -= 1
-				if failureRetries <= 0 {
-					return false, skerr.Wrapf(err, "Failed to wait for build to complete")
+		case status, ok := <-statuses:
+			if !ok {
+				if ctx.Err() != nil {
+					return false, ctx.Err()
 				}
+				return false, skerr.Fmt("build %v ended with status %s", buildID, last)
 			}
 			if status == buildbucketpb.Status_SUCCESS {
 				return true, nil
 			}
+			last = status
+			activity.RecordHeartbeat(ctx, fmt.Sprintf("build %v is %s", buildID, status))
+		case <-heartbeat.C:
+			activity.RecordHeartbeat(ctx, fmt.Sprintf("waiting on build to complete: %v", buildID))
 		}
-		time.Sleep(5 * time.Second)
-		activity.RecordHeartbeat(ctx, fmt.Sprintf("waiting on build to complete: %v", buildID))
 	}
 }
 
-// RetrieveCASActivity wraps BuildChromeClient.RetrieveCAS and gets build artifacts in CAS.
+// RetrieveCASActivity wraps BuildClient.RetrieveCAS and gets build artifacts in CAS.
 func (bca *BuildChromeActivity) RetrieveCASActivity(ctx context.Context, buildID int64, target string) (*swarmingV1.SwarmingRpcsCASReference, error) {
 	logger := activity.GetLogger(ctx)
 
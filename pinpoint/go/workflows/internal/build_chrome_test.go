@@ -1,6 +1,7 @@
 package internal
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/mock"
@@ -21,9 +22,57 @@ func Test_BuildChrome_ShouldReturnCAS(t *testing.T) {
 		CasInstance: "fake-instance",
 	}
 
+	env.OnActivity(bca.GetCachedBuildActivity, mock.Anything, mock.Anything).Return(nil, nil)
 	env.OnActivity(bca.SearchOrBuildActivity, mock.Anything, mock.Anything).Return(buildID, nil)
 	env.OnActivity(bca.WaitBuildCompletionActivity, mock.Anything, mock.Anything).Return(true, nil)
 	env.OnActivity(bca.RetrieveCASActivity, mock.Anything, mock.Anything, mock.Anything).Return(cas, nil)
+	env.OnActivity(bca.CacheBuildActivity, mock.Anything, mock.Anything, buildID, cas).Return(nil)
+
+	env.ExecuteWorkflow(BuildChrome, workflows.BuildChromeParams{})
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.NoError(t, env.GetWorkflowError())
+	var result *swarmingV1.SwarmingRpcsCASReference
+	require.NoError(t, env.GetWorkflowResult(&result))
+	require.Equal(t, cas, result)
+}
+
+func Test_BuildChrome_CachedBuild_ShouldReturnCachedCAS(t *testing.T) {
+	testSuite := &testsuite.WorkflowTestSuite{}
+	env := testSuite.NewTestWorkflowEnvironment()
+
+	var bca *BuildChromeActivity
+	cas := &swarmingV1.SwarmingRpcsCASReference{
+		CasInstance: "fake-instance",
+	}
+
+	env.OnActivity(bca.GetCachedBuildActivity, mock.Anything, mock.Anything).Return(cas, nil)
+
+	env.ExecuteWorkflow(BuildChrome, workflows.BuildChromeParams{})
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.NoError(t, env.GetWorkflowError())
+	var result *swarmingV1.SwarmingRpcsCASReference
+	require.NoError(t, env.GetWorkflowResult(&result))
+	require.Equal(t, cas, result)
+	env.AssertNotCalled(t, "SearchOrBuildActivity", mock.Anything, mock.Anything)
+}
+
+func Test_BuildChrome_CacheErrors_ShouldStillReturnCAS(t *testing.T) {
+	testSuite := &testsuite.WorkflowTestSuite{}
+	env := testSuite.NewTestWorkflowEnvironment()
+
+	var bca *BuildChromeActivity
+	buildID := int64(1234)
+	cas := &swarmingV1.SwarmingRpcsCASReference{
+		CasInstance: "fake-instance",
+	}
+
+	env.OnActivity(bca.GetCachedBuildActivity, mock.Anything, mock.Anything).Return(nil, errors.New("cache unavailable"))
+	env.OnActivity(bca.SearchOrBuildActivity, mock.Anything, mock.Anything).Return(buildID, nil)
+	env.OnActivity(bca.WaitBuildCompletionActivity, mock.Anything, mock.Anything).Return(true, nil)
+	env.OnActivity(bca.RetrieveCASActivity, mock.Anything, mock.Anything, mock.Anything).Return(cas, nil)
+	env.OnActivity(bca.CacheBuildActivity, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(errors.New("cache unavailable"))
 
 	env.ExecuteWorkflow(BuildChrome, workflows.BuildChromeParams{})
 
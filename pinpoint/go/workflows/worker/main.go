@@ -1,9 +1,16 @@
 package main
 
 import (
+	"context"
 	"flag"
 
+	"cloud.google.com/go/datastore"
+	"golang.org/x/oauth2/google"
+
+	"go.skia.org/infra/go/pubsub/sub"
 	"go.skia.org/infra/go/sklog"
+	"go.skia.org/infra/pinpoint/go/build_chrome"
+	"go.skia.org/infra/pinpoint/go/build_chrome/cache"
 	"go.skia.org/infra/pinpoint/go/workflows"
 	"go.skia.org/infra/pinpoint/go/workflows/internal"
 	"go.temporal.io/sdk/client"
@@ -12,8 +19,13 @@ import (
 )
 
 var (
-	hostPort  = flag.String("hostPort", "localhost:7233", "Host the worker connects to.")
-	taskQueue = flag.String("taskQueue", "localhost.dev", "Task queue name registered to worker services.")
+	hostPort      = flag.String("hostPort", "localhost:7233", "Host the worker connects to.")
+	taskQueue     = flag.String("taskQueue", "localhost.dev", "Task queue name registered to worker services.")
+	fsNamespace   = flag.String("fs_namespace", "", "The Firestore namespace of the build cache, e.g. 'pinpoint-staging'. If empty, builds are not cached.")
+	fsProjectID   = flag.String("fs_project_id", "skia-firestore", "The project with the firestore instance. Datastore and Firestore can't be in the same project.")
+	local         = flag.Bool("local", false, "True if running locally and not in production.")
+	buildsTopic   = flag.String("builds_topic", "", "The pub/sub topic of Buildbucket build notifications. If empty, the status of builds is polled.")
+	buildsProject = flag.String("builds_project", "", "The project with the pub/sub topic of Buildbucket build notifications.")
 )
 
 func main() {
@@ -33,6 +45,27 @@ func main() {
 	w.RegisterWorkflowWithOptions(internal.BuildChrome, workflow.RegisterOptions{Name: workflows.BuildChrome})
 
 	bca := &internal.BuildChromeActivity{}
+	if *fsNamespace != "" {
+		ctx := context.Background()
+		ts, err := google.DefaultTokenSource(ctx, datastore.ScopeDatastore)
+		if err != nil {
+			sklog.Fatalf("Problem setting up default token source: %s", err)
+		}
+		bca.Cache, err = cache.New(ctx, ts, *fsNamespace, *fsProjectID)
+		if err != nil {
+			sklog.Fatalf("Unable to create build cache: %s", err)
+		}
+	}
+	if *buildsTopic != "" {
+		ctx := context.Background()
+		// Every worker needs all notifications, since any of them may be waiting on a build.
+		s, err := sub.NewWithSubNameProvider(ctx, *local, *buildsProject, *buildsTopic, sub.NewBroadcastNameProvider(*local, *buildsTopic), 1)
+		if err != nil {
+			sklog.Fatalf("Unable to subscribe to build notifications: %s", err)
+		}
+		bca.Watcher = build_chrome.NewBuildWatcher(s)
+		bca.Watcher.Start(ctx)
+	}
 	w.RegisterActivity(bca)
 
 	err = w.Run(worker.InterruptCh())
@@ -25,6 +25,9 @@ type BuildChromeParams struct {
 	Commit string
 	// Device is the name of the device, e.g. "linux-perf".
 	Device string
+	// Builder is the name of the Buildbucket builder. If set, it overrides
+	// the builder configured for Device, e.g. to build V8 or ANGLE.
+	Builder string
 	// Target is name of the build isolate target
 	// e.g. "performance_test_suite".
 	Target string